@@ -9,6 +9,7 @@ import (
 	"github.com/ava-labs/avalanchego/api"
 	"github.com/ava-labs/avalanchego/database/rpcdb"
 	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow/consensus/snowball"
 	"github.com/ava-labs/avalanchego/utils/formatting"
 	"github.com/ava-labs/avalanchego/utils/logging"
 	"github.com/ava-labs/avalanchego/utils/rpc"
@@ -27,6 +28,10 @@ type Client interface {
 	GetChainAliases(ctx context.Context, chainID string, options ...rpc.Option) ([]string, error)
 	Stacktrace(context.Context, ...rpc.Option) error
 	LoadVMs(context.Context, ...rpc.Option) (map[ids.ID][]string, map[ids.ID]string, error)
+	ReloadVM(ctx context.Context, vmID string, path string, options ...rpc.Option) (string, error)
+	GetConsensusParameters(ctx context.Context, chain string, options ...rpc.Option) (*ConsensusParametersReply, error)
+	SetConsensusParameters(ctx context.Context, chain string, params snowball.Parameters, options ...rpc.Option) (*ConsensusParametersReply, error)
+	RollbackConsensusParameters(ctx context.Context, chain string, options ...rpc.Option) (*ConsensusParametersReply, error)
 	SetLoggerLevel(ctx context.Context, loggerName, logLevel, displayLevel string, options ...rpc.Option) (map[string]LogAndDisplayLevels, error)
 	GetLoggerLevel(ctx context.Context, loggerName string, options ...rpc.Option) (map[string]LogAndDisplayLevels, error)
 	GetConfig(ctx context.Context, options ...rpc.Option) (interface{}, error)
@@ -93,6 +98,40 @@ func (c *client) LoadVMs(ctx context.Context, options ...rpc.Option) (map[ids.ID
 	return res.NewVMs, res.FailedVMs, err
 }
 
+func (c *client) ReloadVM(ctx context.Context, vmID string, path string, options ...rpc.Option) (string, error) {
+	res := &ReloadVMReply{}
+	err := c.requester.SendRequest(ctx, "admin.reloadVM", &ReloadVMArgs{
+		VMID: vmID,
+		Path: path,
+	}, res, options...)
+	return res.Version, err
+}
+
+func (c *client) GetConsensusParameters(ctx context.Context, chain string, options ...rpc.Option) (*ConsensusParametersReply, error) {
+	res := &ConsensusParametersReply{}
+	err := c.requester.SendRequest(ctx, "admin.getConsensusParameters", &ConsensusParametersArgs{
+		Chain: chain,
+	}, res, options...)
+	return res, err
+}
+
+func (c *client) SetConsensusParameters(ctx context.Context, chain string, params snowball.Parameters, options ...rpc.Option) (*ConsensusParametersReply, error) {
+	res := &ConsensusParametersReply{}
+	err := c.requester.SendRequest(ctx, "admin.setConsensusParameters", &SetConsensusParametersArgs{
+		Chain:      chain,
+		Parameters: params,
+	}, res, options...)
+	return res, err
+}
+
+func (c *client) RollbackConsensusParameters(ctx context.Context, chain string, options ...rpc.Option) (*ConsensusParametersReply, error) {
+	res := &ConsensusParametersReply{}
+	err := c.requester.SendRequest(ctx, "admin.rollbackConsensusParameters", &ConsensusParametersArgs{
+		Chain: chain,
+	}, res, options...)
+	return res, err
+}
+
 func (c *client) SetLoggerLevel(
 	ctx context.Context,
 	loggerName,
@@ -12,6 +12,7 @@ import (
 
 	"github.com/ava-labs/avalanchego/api"
 	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow/consensus/snowball"
 	"github.com/ava-labs/avalanchego/utils/logging"
 	"github.com/ava-labs/avalanchego/utils/rpc"
 )
@@ -62,6 +63,12 @@ func (mc *mockClient) SendRequest(_ context.Context, _ string, _ interface{}, re
 	case *LoadVMsReply:
 		response := mc.response.(*LoadVMsReply)
 		*p = *response
+	case *ReloadVMReply:
+		response := mc.response.(*ReloadVMReply)
+		*p = *response
+	case *ConsensusParametersReply:
+		response := mc.response.(*ConsensusParametersReply)
+		*p = *response
 	case *LoggerLevelReply:
 		response := mc.response.(*LoggerLevelReply)
 		*p = *response
@@ -195,6 +202,50 @@ func TestReloadInstalledVMs(t *testing.T) {
 	})
 }
 
+func TestReloadVM(t *testing.T) {
+	t.Run("successful", func(t *testing.T) {
+		require := require.New(t)
+
+		mockClient := client{requester: NewMockClient(&ReloadVMReply{
+			Version: "v1.2.3",
+		}, nil)}
+
+		version, err := mockClient.ReloadVM(context.Background(), "vm", "plugin")
+		require.NoError(err)
+		require.Equal("v1.2.3", version)
+	})
+
+	t.Run("failure", func(t *testing.T) {
+		mockClient := client{requester: NewMockClient(&ReloadVMReply{}, errTest)}
+		_, err := mockClient.ReloadVM(context.Background(), "vm", "plugin")
+		require.ErrorIs(t, err, errTest)
+	})
+}
+
+func TestSetConsensusParameters(t *testing.T) {
+	t.Run("successful", func(t *testing.T) {
+		require := require.New(t)
+
+		previous := snowball.DefaultParameters
+		expectedReply := &ConsensusParametersReply{
+			Active:   snowball.DefaultParameters,
+			Previous: &previous,
+		}
+		expectedReply.Active.OptimalProcessing = 20
+		mockClient := client{requester: NewMockClient(expectedReply, nil)}
+
+		reply, err := mockClient.SetConsensusParameters(context.Background(), "C", expectedReply.Active)
+		require.NoError(err)
+		require.Equal(expectedReply, reply)
+	})
+
+	t.Run("failure", func(t *testing.T) {
+		mockClient := client{requester: NewMockClient(&ConsensusParametersReply{}, errTest)}
+		_, err := mockClient.SetConsensusParameters(context.Background(), "C", snowball.DefaultParameters)
+		require.ErrorIs(t, err, errTest)
+	})
+}
+
 func TestSetLoggerLevel(t *testing.T) {
 	type test struct {
 		name            string
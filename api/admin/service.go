@@ -18,6 +18,7 @@ import (
 	"github.com/ava-labs/avalanchego/database"
 	"github.com/ava-labs/avalanchego/database/rpcdb"
 	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow/consensus/snowball"
 	"github.com/ava-labs/avalanchego/utils"
 	"github.com/ava-labs/avalanchego/utils/constants"
 	"github.com/ava-labs/avalanchego/utils/formatting"
@@ -29,6 +30,7 @@ import (
 	"github.com/ava-labs/avalanchego/vms/registry"
 
 	rpcdbpb "github.com/ava-labs/avalanchego/proto/pb/rpcdb"
+	smeng "github.com/ava-labs/avalanchego/snow/engine/snowman"
 )
 
 const (
@@ -355,6 +357,167 @@ func (a *Admin) LoadVMs(r *http.Request, _ *struct{}, reply *LoadVMsReply) error
 	return err
 }
 
+// ReloadVMArgs are the arguments for calling ReloadVM
+type ReloadVMArgs struct {
+	// ID or alias of the VM to reload
+	VMID string `json:"vmID"`
+	// Path of the plugin binary to reload the VM with. It must be in the
+	// plugin directory.
+	Path string `json:"path"`
+}
+
+// ReloadVMReply contains the response metadata for ReloadVM
+type ReloadVMReply struct {
+	// Version reported by the reloaded VM
+	Version string `json:"version"`
+}
+
+// ReloadVM upgrades an installed VM to the plugin binary at the provided path
+// and reloads the chains that run the VM without restarting the node. Each
+// chain resumes from its last accepted block. If the new plugin can't be
+// started or a chain can't be resumed, the chains keep running the previous
+// plugin. Once the chains are reloaded, the binary is installed as the VM's
+// plugin so that the upgrade persists across restarts.
+func (a *Admin) ReloadVM(r *http.Request, args *ReloadVMArgs, reply *ReloadVMReply) error {
+	a.Log.Debug("API called",
+		zap.String("service", "admin"),
+		zap.String("method", "reloadVM"),
+		logging.UserString("vmID", args.VMID),
+		logging.UserString("path", args.Path),
+	)
+
+	a.lock.Lock()
+	defer a.lock.Unlock()
+
+	vmID, err := a.VMManager.Lookup(args.VMID)
+	if err != nil {
+		return err
+	}
+
+	if err := a.VMRegistry.Upgrade(r.Context(), vmID, args.Path); err != nil {
+		return err
+	}
+
+	alias, err := a.VMManager.PrimaryAlias(vmID)
+	if err != nil {
+		return err
+	}
+	versions, err := a.VMManager.Versions()
+	if err != nil {
+		return err
+	}
+	reply.Version = versions[alias]
+	return nil
+}
+
+// ConsensusParametersArgs are the arguments for calling
+// GetConsensusParameters and RollbackConsensusParameters
+type ConsensusParametersArgs struct {
+	// ID or alias of the chain
+	Chain string `json:"chain"`
+}
+
+// SetConsensusParametersArgs are the arguments for calling
+// SetConsensusParameters
+type SetConsensusParametersArgs struct {
+	// ID or alias of the chain
+	Chain string `json:"chain"`
+	// Parameters to run consensus with
+	Parameters snowball.Parameters `json:"parameters"`
+}
+
+// ConsensusParametersReply contains the response metadata for the consensus
+// parameters methods
+type ConsensusParametersReply struct {
+	// Parameters that consensus currently runs with
+	Active snowball.Parameters `json:"active"`
+	// Parameters that will be applied once the next poll finishes
+	Pending *snowball.Parameters `json:"pending,omitempty"`
+	// Number of blocks that are processing. While it is zero, no polls are
+	// issued and the pending parameters are applied immediately.
+	NumProcessing json.Uint64 `json:"numProcessing"`
+	// Parameters that were active before the last change
+	Previous *snowball.Parameters `json:"previous,omitempty"`
+}
+
+// GetConsensusParameters returns the consensus parameters of a snowman chain
+func (a *Admin) GetConsensusParameters(_ *http.Request, args *ConsensusParametersArgs, reply *ConsensusParametersReply) error {
+	a.Log.Debug("API called",
+		zap.String("service", "admin"),
+		zap.String("method", "getConsensusParameters"),
+		logging.UserString("chain", args.Chain),
+	)
+
+	a.lock.RLock()
+	defer a.lock.RUnlock()
+
+	chainID, err := a.ChainManager.Lookup(args.Chain)
+	if err != nil {
+		return err
+	}
+
+	status, err := a.ChainManager.ConsensusParameters(chainID)
+	reply.set(status)
+	return err
+}
+
+// SetConsensusParameters replaces the consensus parameters of a running
+// snowman chain. The parameters are applied once the next poll finishes, or
+// immediately if no blocks are processing; until then they are reported as
+// pending. Blocks that were already issued are decided with the parameters
+// they were issued with. The parameters are not persisted, so the configured
+// parameters are used again once the node restarts.
+func (a *Admin) SetConsensusParameters(_ *http.Request, args *SetConsensusParametersArgs, reply *ConsensusParametersReply) error {
+	a.Log.Debug("API called",
+		zap.String("service", "admin"),
+		zap.String("method", "setConsensusParameters"),
+		logging.UserString("chain", args.Chain),
+		zap.Reflect("parameters", args.Parameters),
+	)
+
+	a.lock.Lock()
+	defer a.lock.Unlock()
+
+	chainID, err := a.ChainManager.Lookup(args.Chain)
+	if err != nil {
+		return err
+	}
+
+	status, err := a.ChainManager.SetConsensusParameters(chainID, args.Parameters)
+	reply.set(status)
+	return err
+}
+
+// RollbackConsensusParameters cancels the pending change of the consensus
+// parameters of a snowman chain. If no change is pending, the parameters that
+// were active before the last change are restored.
+func (a *Admin) RollbackConsensusParameters(_ *http.Request, args *ConsensusParametersArgs, reply *ConsensusParametersReply) error {
+	a.Log.Debug("API called",
+		zap.String("service", "admin"),
+		zap.String("method", "rollbackConsensusParameters"),
+		logging.UserString("chain", args.Chain),
+	)
+
+	a.lock.Lock()
+	defer a.lock.Unlock()
+
+	chainID, err := a.ChainManager.Lookup(args.Chain)
+	if err != nil {
+		return err
+	}
+
+	status, err := a.ChainManager.RollbackConsensusParameters(chainID)
+	reply.set(status)
+	return err
+}
+
+func (r *ConsensusParametersReply) set(status smeng.ParametersStatus) {
+	r.Active = status.Active
+	r.Pending = status.Pending
+	r.NumProcessing = json.Uint64(status.NumProcessing)
+	r.Previous = status.Previous
+}
+
 func (a *Admin) getLoggerNames(loggerName string) []string {
 	if len(loggerName) == 0 {
 		// Empty name means all loggers
@@ -115,6 +115,46 @@ func TestLoadVMsGetAliasesFails(t *testing.T) {
 	require.ErrorIs(err, errTest)
 }
 
+// Tests behavior for ReloadVM if everything succeeds.
+func TestReloadVMSuccess(t *testing.T) {
+	require := require.New(t)
+
+	resources := initLoadVMsTest(t)
+
+	vmID := ids.GenerateTestID()
+	resources.mockVMManager.EXPECT().Lookup("vm").Times(1).Return(vmID, nil)
+	resources.mockVMRegistry.EXPECT().Upgrade(gomock.Any(), vmID, "plugin").Times(1).Return(nil)
+	resources.mockVMManager.EXPECT().PrimaryAlias(vmID).Times(1).Return("vm", nil)
+	resources.mockVMManager.EXPECT().Versions().Times(1).Return(map[string]string{
+		"vm": "v1.2.3",
+	}, nil)
+
+	reply := ReloadVMReply{}
+	require.NoError(resources.admin.ReloadVM(&http.Request{}, &ReloadVMArgs{
+		VMID: "vm",
+		Path: "plugin",
+	}, &reply))
+	require.Equal("v1.2.3", reply.Version)
+}
+
+// Tests behavior for ReloadVM if we fail to upgrade the vm.
+func TestReloadVMUpgradeFails(t *testing.T) {
+	require := require.New(t)
+
+	resources := initLoadVMsTest(t)
+
+	vmID := ids.GenerateTestID()
+	resources.mockVMManager.EXPECT().Lookup("vm").Times(1).Return(vmID, nil)
+	resources.mockVMRegistry.EXPECT().Upgrade(gomock.Any(), vmID, "plugin").Times(1).Return(errTest)
+
+	reply := ReloadVMReply{}
+	err := resources.admin.ReloadVM(&http.Request{}, &ReloadVMArgs{
+		VMID: "vm",
+		Path: "plugin",
+	}, &reply)
+	require.ErrorIs(err, errTest)
+}
+
 func TestServiceDBGet(t *testing.T) {
 	a := &Admin{Config: Config{
 		Log: logging.NoLog{},
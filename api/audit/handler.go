@@ -0,0 +1,196 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package audit
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/ava-labs/avalanchego/api/auth"
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/ava-labs/avalanchego/utils/units"
+)
+
+const (
+	redacted = "[redacted]"
+
+	// maxCapturedResponseSize is the number of bytes of a response that are
+	// kept to look for a JSON-RPC error. Error responses are small, so larger
+	// responses are assumed to have succeeded.
+	maxCapturedResponseSize = 4 * 1024
+
+	// maxRequestSize is the largest request body that is read. Keystore
+	// users are imported in a single request, so this is generous.
+	maxRequestSize = 16 * units.MiB
+)
+
+var (
+	_ http.Handler        = (*handler)(nil)
+	_ http.ResponseWriter = (*responseRecorder)(nil)
+
+	// secretParams are the (lowercased) names of the params whose values are
+	// never written to the audit log.
+	secretParams = map[string]struct{}{
+		"password":    {},
+		"newpassword": {},
+		"privatekey":  {},
+		"privatekeys": {},
+		"user":        {},
+		"token":       {},
+	}
+)
+
+type handler struct {
+	h       http.Handler
+	log     Log
+	logger  logging.Logger
+	service string
+}
+
+// NewHandler records every JSON-RPC call served by [h] to [log] as a call to
+// [service]. Failures to write to [log] are reported to [logger].
+func NewHandler(h http.Handler, log Log, logger logging.Logger, service string) http.Handler {
+	return &handler{
+		h:       h,
+		log:     log,
+		logger:  logger,
+		service: service,
+	}
+}
+
+func (h *handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	start := time.Now()
+
+	var request struct {
+		Method string          `json:"method"`
+		Params json.RawMessage `json:"params"`
+	}
+	if r.Body != nil {
+		body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxRequestSize))
+		var maxBytesErr *http.MaxBytesError
+		switch {
+		case errors.As(err, &maxBytesErr):
+			http.Error(w, err.Error(), http.StatusRequestEntityTooLarge)
+			return
+		case err != nil:
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		r.Body = io.NopCloser(bytes.NewReader(body))
+		// An invalid request is still recorded, without its method.
+		_ = json.Unmarshal(body, &request)
+	}
+
+	recorder := &responseRecorder{
+		ResponseWriter: w,
+		status:         http.StatusOK,
+	}
+	h.h.ServeHTTP(recorder, r)
+
+	entry := Entry{
+		Time:     start,
+		RemoteIP: remoteIP(r),
+		Identity: auth.Identity(r.Context()),
+		Service:  h.service,
+		Method:   request.Method,
+		Params:   redact(request.Params),
+		Status:   recorder.status,
+		Error:    recorder.rpcError(),
+		Latency:  time.Since(start),
+	}
+	if err := h.log.Write(entry); err != nil {
+		h.logger.Error("failed to write audit entry",
+			zap.String("service", entry.Service),
+			zap.String("method", entry.Method),
+			zap.Error(err),
+		)
+	}
+}
+
+func remoteIP(r *http.Request) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
+
+// redact returns [params] with the values of all the secret params replaced.
+func redact(params json.RawMessage) json.RawMessage {
+	if len(params) == 0 {
+		return nil
+	}
+	var value interface{}
+	if err := json.Unmarshal(params, &value); err != nil {
+		// Params that can't be parsed can't be redacted, so they aren't
+		// recorded.
+		return json.RawMessage(`"` + redacted + `"`)
+	}
+	redactedParams, err := json.Marshal(redactValue(value))
+	if err != nil {
+		return nil
+	}
+	return redactedParams
+}
+
+func redactValue(value interface{}) interface{} {
+	switch value := value.(type) {
+	case map[string]interface{}:
+		for k, v := range value {
+			if _, ok := secretParams[strings.ToLower(k)]; ok {
+				value[k] = redacted
+				continue
+			}
+			value[k] = redactValue(v)
+		}
+	case []interface{}:
+		for i, v := range value {
+			value[i] = redactValue(v)
+		}
+	}
+	return value
+}
+
+// responseRecorder records the status of a response and the start of its body.
+type responseRecorder struct {
+	http.ResponseWriter
+	status int
+	body   bytes.Buffer
+}
+
+func (r *responseRecorder) WriteHeader(status int) {
+	r.status = status
+	r.ResponseWriter.WriteHeader(status)
+}
+
+func (r *responseRecorder) Write(b []byte) (int, error) {
+	if remaining := maxCapturedResponseSize + 1 - r.body.Len(); remaining > 0 {
+		r.body.Write(b[:min(len(b), remaining)])
+	}
+	return r.ResponseWriter.Write(b)
+}
+
+// rpcError returns the message of the JSON-RPC error in the response, if any.
+func (r *responseRecorder) rpcError() string {
+	if r.body.Len() > maxCapturedResponseSize {
+		return ""
+	}
+	var response struct {
+		Error *struct {
+			Message string `json:"message"`
+		} `json:"error"`
+	}
+	if err := json.Unmarshal(r.body.Bytes(), &response); err != nil || response.Error == nil {
+		return ""
+	}
+	return response.Error.Message
+}
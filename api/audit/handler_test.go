@@ -0,0 +1,136 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package audit
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/api/auth"
+	"github.com/ava-labs/avalanchego/utils/logging"
+)
+
+type testLog struct {
+	entries []Entry
+}
+
+func (l *testLog) Write(entry Entry) error {
+	l.entries = append(l.entries, entry)
+	return nil
+}
+
+func (*testLog) Close() error {
+	return nil
+}
+
+func TestHandlerRecordsCalls(t *testing.T) {
+	tests := []struct {
+		name           string
+		body           string
+		response       string
+		identity       string
+		expectedMethod string
+		expectedParams string
+		expectedError  string
+	}{
+		{
+			name:           "redacts password",
+			body:           `{"jsonrpc":"2.0","id":1,"method":"keystore.exportUser","params":{"username":"bob","password":"hunter2"}}`,
+			response:       `{"jsonrpc":"2.0","result":{"user":"0x1234"},"id":1}`,
+			identity:       "alice",
+			expectedMethod: "keystore.exportUser",
+			expectedParams: `{"password":"[redacted]","username":"bob"}`,
+		},
+		{
+			name:           "redacts nested secrets",
+			body:           `{"jsonrpc":"2.0","id":1,"method":"keystore.importUser","params":[{"username":"bob","password":"hunter2","user":"0x1234"}]}`,
+			response:       `{"jsonrpc":"2.0","result":{},"id":1}`,
+			expectedMethod: "keystore.importUser",
+			expectedParams: `[{"password":"[redacted]","user":"[redacted]","username":"bob"}]`,
+		},
+		{
+			name:           "records error",
+			body:           `{"jsonrpc":"2.0","id":1,"method":"admin.aliasChain","params":{"chain":"X","alias":"foo"}}`,
+			response:       `{"jsonrpc":"2.0","error":{"code":-32000,"message":"alias length is too long","data":null},"id":1}`,
+			expectedMethod: "admin.aliasChain",
+			expectedParams: `{"alias":"foo","chain":"X"}`,
+			expectedError:  "alias length is too long",
+		},
+		{
+			name:           "invalid request",
+			body:           `not json`,
+			response:       `{"jsonrpc":"2.0","error":{"code":-32700,"message":"parse error","data":null},"id":null}`,
+			expectedMethod: "",
+			expectedError:  "parse error",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require := require.New(t)
+
+			var body string
+			log := &testLog{}
+			h := NewHandler(
+				http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					bytes, err := io.ReadAll(r.Body)
+					require.NoError(err)
+					body = string(bytes)
+					_, _ = w.Write([]byte(test.response))
+				}),
+				log,
+				logging.NoLog{},
+				"test",
+			)
+
+			r := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(test.body))
+			r.RemoteAddr = "1.2.3.4:5678"
+			if test.identity != auth.Anonymous {
+				r = r.WithContext(auth.WithIdentity(r.Context(), test.identity))
+			}
+			w := httptest.NewRecorder()
+			h.ServeHTTP(w, r)
+
+			// The wrapped handler must receive the original request.
+			require.Equal(test.body, body)
+			require.Equal(test.response, w.Body.String())
+
+			require.Len(log.entries, 1)
+			entry := log.entries[0]
+			require.Equal("1.2.3.4", entry.RemoteIP)
+			require.Equal(test.identity, entry.Identity)
+			require.Equal("test", entry.Service)
+			require.Equal(test.expectedMethod, entry.Method)
+			require.Equal(test.expectedParams, string(entry.Params))
+			require.Equal(http.StatusOK, entry.Status)
+			require.Equal(test.expectedError, entry.Error)
+		})
+	}
+}
+
+func TestHandlerRejectsLargeRequests(t *testing.T) {
+	require := require.New(t)
+
+	log := &testLog{}
+	h := NewHandler(
+		http.HandlerFunc(func(http.ResponseWriter, *http.Request) {
+			require.FailNow("handler called")
+		}),
+		log,
+		logging.NoLog{},
+		"test",
+	)
+
+	body := strings.Repeat(" ", maxRequestSize+1)
+	r := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(body))
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, r)
+
+	require.Equal(http.StatusRequestEntityTooLarge, w.Code)
+	require.Empty(log.entries)
+}
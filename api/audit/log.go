@@ -0,0 +1,425 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package audit
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"gopkg.in/natefinch/lumberjack.v2"
+
+	"github.com/ava-labs/avalanchego/utils/perms"
+)
+
+// minKeySize is the minimum number of bytes of the key that entries are
+// authenticated with.
+const minKeySize = 32
+
+var (
+	errMissingDestination = errors.New("audit log requires a file or a syslog address")
+	errMissingHeadFile    = errors.New("audit log requires a head file")
+	errMissingKeyFile     = errors.New("audit log requires a key file")
+	errShortKey           = fmt.Errorf("audit log key must be at least %d bytes", minKeySize)
+	errTruncatedTrail     = errors.New("audit trail doesn't end at its head")
+	errHashMismatch       = errors.New("audit entry hash mismatch")
+	errBrokenChain        = errors.New("audit entry doesn't extend the previous entry")
+	errUnexpectedSeq      = errors.New("unexpected audit entry sequence number")
+
+	// genesisHash is the previous hash of the first entry of a new trail.
+	genesisHash = hex.EncodeToString(make([]byte, sha256.Size))
+)
+
+type Config struct {
+	// Used to flag if the audit log should be written
+	Enabled bool `json:"enabled"`
+
+	// Path of the file the entries are written to. The file is rotated once
+	// it reaches [MaxSize] megabytes. Ignored if [SyslogAddress] is set.
+	File     string `json:"file"`
+	MaxSize  int    `json:"maxSize"`
+	MaxFiles int    `json:"maxFiles"`
+	MaxAge   int    `json:"maxAge"`
+	Compress bool   `json:"compress"`
+
+	// If set, the entries are sent to the syslog server at [SyslogAddress]
+	// over [SyslogNetwork] rather than written to a file. An empty network
+	// connects to the local syslog server.
+	SyslogNetwork string `json:"syslogNetwork"`
+	SyslogAddress string `json:"syslogAddress"`
+
+	// Path of the file the head of the trail is persisted to, so that the
+	// trail continues across restarts and a truncated trail is detected.
+	HeadFile string `json:"headFile"`
+
+	// Path of the key that entries are authenticated with. Entries can't be
+	// forged or modified without the key.
+	KeyFile string `json:"keyFile"`
+}
+
+// head is the sequence number and hash of the last entry of a trail.
+type head struct {
+	Seq  uint64 `json:"seq"`
+	Hash string `json:"hash"`
+}
+
+// Entry records a single call to an audited API.
+//
+// Every entry is hashed with an HMAC and includes the hash of the previous
+// entry, forming a hash chain, so that modifying, removing, or reordering
+// entries is detectable by [Verify].
+type Entry struct {
+	Seq      uint64          `json:"seq"`
+	Time     time.Time       `json:"time"`
+	RemoteIP string          `json:"remoteIP"`
+	Identity string          `json:"identity,omitempty"`
+	Service  string          `json:"service"`
+	Method   string          `json:"method"`
+	Params   json.RawMessage `json:"params,omitempty"`
+	Status   int             `json:"status"`
+	Error    string          `json:"error,omitempty"`
+	Latency  time.Duration   `json:"latency"`
+	PrevHash string          `json:"prevHash"`
+	Hash     string          `json:"hash,omitempty"`
+}
+
+// hash returns the HMAC of [e] under [key], excluding its [Hash] field.
+func (e Entry) hash(key []byte) (string, error) {
+	e.Hash = ""
+	bytes, err := json.Marshal(e)
+	if err != nil {
+		return "", err
+	}
+	mac := hmac.New(sha256.New, key)
+	_, _ = mac.Write(bytes)
+	return hex.EncodeToString(mac.Sum(nil)), nil
+}
+
+// Log appends entries to the audit trail.
+type Log interface {
+	// Write completes [entry] with its sequence number and hashes, and
+	// appends it to the trail.
+	Write(entry Entry) error
+
+	io.Closer
+}
+
+type log struct {
+	lock     sync.Mutex
+	writer   io.WriteCloser
+	key      []byte
+	headFile string
+	nextSeq  uint64
+	prevHash string
+}
+
+// New returns the audit log described by [config].
+//
+// The trail continues from the head persisted to [config.HeadFile] so that it
+// remains verifiable across restarts. When writing to a file, the last entry in
+// the file must be the head, so that truncating the trail is detected.
+func New(config Config) (Log, error) {
+	if !config.Enabled {
+		return Noop, nil
+	}
+	if config.HeadFile == "" {
+		return nil, errMissingHeadFile
+	}
+
+	key, err := readKey(config.KeyFile)
+	if err != nil {
+		return nil, err
+	}
+	last, err := readHead(config.HeadFile)
+	if err != nil {
+		return nil, err
+	}
+
+	var writer io.WriteCloser
+	switch {
+	case config.SyslogAddress != "" || config.SyslogNetwork != "":
+		writer, err = newSyslogWriter(config.SyslogNetwork, config.SyslogAddress)
+		if err != nil {
+			return nil, fmt.Errorf("couldn't connect to syslog: %w", err)
+		}
+	case config.File != "":
+		last, err = checkTail(config.File, key, last)
+		if err != nil {
+			return nil, err
+		}
+		if err := os.MkdirAll(filepath.Dir(config.File), perms.ReadWriteExecute); err != nil {
+			return nil, err
+		}
+		writer = &lumberjack.Logger{
+			Filename:   config.File,
+			MaxSize:    config.MaxSize,
+			MaxBackups: config.MaxFiles,
+			MaxAge:     config.MaxAge,
+			Compress:   config.Compress,
+		}
+	default:
+		return nil, errMissingDestination
+	}
+	if err := os.MkdirAll(filepath.Dir(config.HeadFile), perms.ReadWriteExecute); err != nil {
+		_ = writer.Close()
+		return nil, err
+	}
+
+	l := &log{
+		writer:   writer,
+		key:      key,
+		headFile: config.HeadFile,
+		prevHash: genesisHash,
+	}
+	if last != nil {
+		l.nextSeq = last.Seq + 1
+		l.prevHash = last.Hash
+	}
+	return l, nil
+}
+
+func (l *log) Write(entry Entry) error {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	entry.Seq = l.nextSeq
+	entry.Time = entry.Time.UTC()
+	entry.PrevHash = l.prevHash
+	hash, err := entry.hash(l.key)
+	if err != nil {
+		return err
+	}
+	entry.Hash = hash
+
+	line, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+	if _, err := l.writer.Write(append(line, '\n')); err != nil {
+		return err
+	}
+
+	l.nextSeq++
+	l.prevHash = hash
+	return writeHead(l.headFile, head{
+		Seq:  entry.Seq,
+		Hash: hash,
+	})
+}
+
+func (l *log) Close() error {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	return l.writer.Close()
+}
+
+// readKey returns the key stored in [path].
+func readKey(path string) ([]byte, error) {
+	if path == "" {
+		return nil, errMissingKeyFile
+	}
+	key, err := os.ReadFile(filepath.Clean(path))
+	if err != nil {
+		return nil, fmt.Errorf("couldn't read audit log key file: %w", err)
+	}
+	if len(key) < minKeySize {
+		return nil, errShortKey
+	}
+	return key, nil
+}
+
+// readHead returns the head persisted to [path], or nil if there is none.
+func readHead(path string) (*head, error) {
+	headBytes, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	h := &head{}
+	if err := json.Unmarshal(headBytes, h); err != nil {
+		return nil, fmt.Errorf("couldn't parse the audit head %q: %w", path, err)
+	}
+	return h, nil
+}
+
+// writeHead replaces the head persisted to [path] with [h]. The head is written
+// to a temporary file that is renamed into place, so that a crash can't leave a
+// corrupt head behind.
+func writeHead(path string, h head) error {
+	headBytes, err := json.Marshal(h)
+	if err != nil {
+		return err
+	}
+
+	file, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*")
+	if err != nil {
+		return err
+	}
+	tmpPath := file.Name()
+	if _, err := file.Write(headBytes); err != nil {
+		_ = file.Close()
+		_ = os.Remove(tmpPath)
+		return err
+	}
+	if err := file.Sync(); err != nil {
+		_ = file.Close()
+		_ = os.Remove(tmpPath)
+		return err
+	}
+	if err := file.Close(); err != nil {
+		_ = os.Remove(tmpPath)
+		return err
+	}
+	if err := os.Rename(tmpPath, path); err != nil {
+		_ = os.Remove(tmpPath)
+		return err
+	}
+	return nil
+}
+
+// checkTail returns the head to continue the trail in [path] from. The last
+// entry in [path] must be the persisted head [h], or the entry that follows it
+// if the node stopped before the head was persisted. Otherwise, entries were
+// removed from the end of the trail.
+func checkTail(path string, key []byte, h *head) (*head, error) {
+	last, err := readLastEntry(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		nextSeq  uint64
+		prevHash = genesisHash
+	)
+	if h != nil {
+		nextSeq = h.Seq + 1
+		prevHash = h.Hash
+	}
+	switch {
+	case last == nil && h == nil:
+		return nil, nil
+	case last == nil:
+		return nil, fmt.Errorf("%w: %q has no entries but the head is seq %d", errTruncatedTrail, path, h.Seq)
+	}
+
+	hash, err := last.hash(key)
+	if err != nil {
+		return nil, err
+	}
+	if hash != last.Hash {
+		return nil, fmt.Errorf("%w: seq %d", errHashMismatch, last.Seq)
+	}
+
+	isHead := h != nil && last.Seq == h.Seq && last.Hash == h.Hash
+	followsHead := last.Seq == nextSeq && last.PrevHash == prevHash
+	switch {
+	case isHead || followsHead:
+		return &head{
+			Seq:  last.Seq,
+			Hash: last.Hash,
+		}, nil
+	case h == nil:
+		return nil, fmt.Errorf("%w: %q ends at seq %d but there is no head", errTruncatedTrail, path, last.Seq)
+	default:
+		return nil, fmt.Errorf("%w: %q ends at seq %d but the head is seq %d", errTruncatedTrail, path, last.Seq, h.Seq)
+	}
+}
+
+// readLastEntry returns the last entry in [path], or nil if there are no
+// entries.
+func readLastEntry(path string) (*Entry, error) {
+	file, err := os.Open(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	var (
+		reader = bufio.NewReader(file)
+		last   []byte
+	)
+	for {
+		line, err := reader.ReadBytes('\n')
+		if line = bytes.TrimSpace(line); len(line) > 0 {
+			last = line
+		}
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	if last == nil {
+		return nil, nil
+	}
+
+	entry := &Entry{}
+	if err := json.Unmarshal(last, entry); err != nil {
+		return nil, fmt.Errorf("couldn't parse the last audit entry of %q: %w", path, err)
+	}
+	return entry, nil
+}
+
+// Verify checks that the entries read from [r] form an unbroken hash chain
+// that was authenticated with [key].
+//
+// If [prevHash] is non-empty, the first entry must extend it. This allows a
+// trail that was rotated across multiple files to be verified by passing the
+// returned hash of one file to the verification of the next file. Verify
+// returns the hash of the last entry.
+func Verify(r io.Reader, key []byte, prevHash string) (string, error) {
+	var (
+		decoder = json.NewDecoder(r)
+		nextSeq uint64
+		first   = true
+	)
+	for {
+		var entry Entry
+		err := decoder.Decode(&entry)
+		if err == io.EOF {
+			return prevHash, nil
+		}
+		if err != nil {
+			return "", err
+		}
+
+		switch {
+		case first && prevHash == "":
+		case entry.PrevHash != prevHash:
+			return "", fmt.Errorf("%w: seq %d", errBrokenChain, entry.Seq)
+		case !first && entry.Seq != nextSeq:
+			return "", fmt.Errorf("%w: expected %d but got %d", errUnexpectedSeq, nextSeq, entry.Seq)
+		}
+
+		hash, err := entry.hash(key)
+		if err != nil {
+			return "", err
+		}
+		if hash != entry.Hash {
+			return "", fmt.Errorf("%w: seq %d", errHashMismatch, entry.Seq)
+		}
+
+		first = false
+		nextSeq = entry.Seq + 1
+		prevHash = hash
+	}
+}
@@ -0,0 +1,288 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package audit
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/utils/perms"
+)
+
+var testKey = bytes.Repeat([]byte{1}, minKeySize)
+
+// newTestConfig returns the config of an audit log written to [dir].
+func newTestConfig(require *require.Assertions, dir string) Config {
+	keyFile := filepath.Join(dir, "audit.key")
+	require.NoError(os.WriteFile(keyFile, testKey, perms.ReadWrite))
+	return Config{
+		Enabled:  true,
+		File:     filepath.Join(dir, "audit.log"),
+		MaxSize:  1,
+		HeadFile: filepath.Join(dir, "audit-head.json"),
+		KeyFile:  keyFile,
+	}
+}
+
+func newTestLog(require *require.Assertions, config Config) Log {
+	l, err := New(config)
+	require.NoError(err)
+	return l
+}
+
+func writeTestEntries(require *require.Assertions, l Log, methods ...string) {
+	for _, method := range methods {
+		require.NoError(l.Write(Entry{
+			Time:     time.Now(),
+			RemoteIP: "127.0.0.1",
+			Service:  "admin",
+			Method:   method,
+			Params:   json.RawMessage(`[{"alias":"x"}]`),
+			Status:   200,
+			Latency:  time.Millisecond,
+		}))
+	}
+}
+
+func TestLogResumesChain(t *testing.T) {
+	require := require.New(t)
+
+	config := newTestConfig(require, t.TempDir())
+	l := newTestLog(require, config)
+	writeTestEntries(require, l, "admin.alias", "admin.aliasChain")
+	require.NoError(l.Close())
+
+	l = newTestLog(require, config)
+	writeTestEntries(require, l, "admin.loadVMs")
+	require.NoError(l.Close())
+
+	file, err := os.Open(config.File)
+	require.NoError(err)
+	defer file.Close()
+
+	lastHash, err := Verify(file, testKey, genesisHash)
+	require.NoError(err)
+
+	last, err := readLastEntry(config.File)
+	require.NoError(err)
+	require.Equal(uint64(2), last.Seq)
+	require.Equal("admin.loadVMs", last.Method)
+	require.Equal(last.Hash, lastHash)
+
+	h, err := readHead(config.HeadFile)
+	require.NoError(err)
+	require.Equal(&head{Seq: 2, Hash: lastHash}, h)
+
+	// The head is renamed into place, so no temporary files are left behind.
+	files, err := os.ReadDir(filepath.Dir(config.HeadFile))
+	require.NoError(err)
+	require.Len(files, 3)
+}
+
+func TestLogResumesFromUnpersistedHead(t *testing.T) {
+	require := require.New(t)
+
+	config := newTestConfig(require, t.TempDir())
+	l := newTestLog(require, config)
+	writeTestEntries(require, l, "admin.alias")
+	headBytes, err := os.ReadFile(config.HeadFile)
+	require.NoError(err)
+	writeTestEntries(require, l, "admin.aliasChain")
+	require.NoError(l.Close())
+
+	// The node stopped after writing the second entry, but before persisting
+	// it as the head.
+	require.NoError(os.WriteFile(config.HeadFile, headBytes, perms.ReadWrite))
+
+	l = newTestLog(require, config)
+	writeTestEntries(require, l, "admin.loadVMs")
+	require.NoError(l.Close())
+
+	file, err := os.Open(config.File)
+	require.NoError(err)
+	defer file.Close()
+
+	_, err = Verify(file, testKey, genesisHash)
+	require.NoError(err)
+
+	last, err := readLastEntry(config.File)
+	require.NoError(err)
+	require.Equal(uint64(2), last.Seq)
+}
+
+func TestNewDetectsTruncation(t *testing.T) {
+	tests := []struct {
+		name      string
+		keepLines int
+	}{
+		{
+			name:      "removed last entry",
+			keepLines: 2,
+		},
+		{
+			name:      "removed all entries",
+			keepLines: 0,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require := require.New(t)
+
+			config := newTestConfig(require, t.TempDir())
+			l := newTestLog(require, config)
+			writeTestEntries(require, l, "admin.alias", "admin.aliasChain", "admin.loadVMs")
+			require.NoError(l.Close())
+
+			contents, err := os.ReadFile(config.File)
+			require.NoError(err)
+			lines := bytes.SplitAfter(contents, []byte("\n"))
+			truncated := bytes.Join(lines[:test.keepLines], nil)
+			require.NoError(os.WriteFile(config.File, truncated, perms.ReadWrite))
+
+			_, err = New(config)
+			require.ErrorIs(err, errTruncatedTrail)
+		})
+	}
+}
+
+func TestNewRequiresHeadAfterEntries(t *testing.T) {
+	require := require.New(t)
+
+	config := newTestConfig(require, t.TempDir())
+	l := newTestLog(require, config)
+	writeTestEntries(require, l, "admin.alias", "admin.aliasChain")
+	require.NoError(l.Close())
+
+	require.NoError(os.Remove(config.HeadFile))
+
+	_, err := New(config)
+	require.ErrorIs(err, errTruncatedTrail)
+}
+
+func TestVerifyDetectsTampering(t *testing.T) {
+	config := newTestConfig(require.New(t), t.TempDir())
+	l := newTestLog(require.New(t), config)
+	writeTestEntries(require.New(t), l, "admin.alias", "admin.aliasChain", "admin.loadVMs")
+	require.NoError(t, l.Close())
+
+	contents, err := os.ReadFile(config.File)
+	require.NoError(t, err)
+	lines := bytes.Split(bytes.TrimSpace(contents), []byte("\n"))
+	require.Len(t, lines, 3)
+
+	// An entry modified by someone without the key can't be rehashed.
+	var forged Entry
+	require.NoError(t, json.Unmarshal(lines[2], &forged))
+	forged.Method = "admin.getConfig"
+	forged.Hash, err = forged.hash(bytes.Repeat([]byte{2}, minKeySize))
+	require.NoError(t, err)
+	forgedLine, err := json.Marshal(forged)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name        string
+		lines       [][]byte
+		prevHash    string
+		expectedErr error
+	}{
+		{
+			name:        "modified entry",
+			lines:       [][]byte{lines[0], bytes.Replace(lines[1], []byte("aliasChain"), []byte("getConfig"), 1), lines[2]},
+			prevHash:    genesisHash,
+			expectedErr: errHashMismatch,
+		},
+		{
+			name:        "forged entry",
+			lines:       [][]byte{lines[0], lines[1], forgedLine},
+			prevHash:    genesisHash,
+			expectedErr: errHashMismatch,
+		},
+		{
+			name:        "removed entry",
+			lines:       [][]byte{lines[0], lines[2]},
+			prevHash:    genesisHash,
+			expectedErr: errBrokenChain,
+		},
+		{
+			name:        "reordered entries",
+			lines:       [][]byte{lines[0], lines[2], lines[1]},
+			prevHash:    genesisHash,
+			expectedErr: errBrokenChain,
+		},
+		{
+			name:        "removed first entry",
+			lines:       [][]byte{lines[1], lines[2]},
+			prevHash:    genesisHash,
+			expectedErr: errBrokenChain,
+		},
+		{
+			name:     "rotated file",
+			lines:    [][]byte{lines[1], lines[2]},
+			prevHash: "",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			contents := bytes.Join(test.lines, []byte("\n"))
+			_, err := Verify(bytes.NewReader(contents), testKey, test.prevHash)
+			require.ErrorIs(t, err, test.expectedErr)
+		})
+	}
+}
+
+func TestNewRequiresConfig(t *testing.T) {
+	dir := t.TempDir()
+	config := newTestConfig(require.New(t), dir)
+	shortKeyFile := filepath.Join(dir, "short.key")
+	require.NoError(t, os.WriteFile(shortKeyFile, testKey[1:], perms.ReadWrite))
+
+	tests := []struct {
+		name        string
+		modify      func(*Config)
+		expectedErr error
+	}{
+		{
+			name: "missing destination",
+			modify: func(c *Config) {
+				c.File = ""
+			},
+			expectedErr: errMissingDestination,
+		},
+		{
+			name: "missing head file",
+			modify: func(c *Config) {
+				c.HeadFile = ""
+			},
+			expectedErr: errMissingHeadFile,
+		},
+		{
+			name: "missing key file",
+			modify: func(c *Config) {
+				c.KeyFile = ""
+			},
+			expectedErr: errMissingKeyFile,
+		},
+		{
+			name: "short key",
+			modify: func(c *Config) {
+				c.KeyFile = shortKeyFile
+			},
+			expectedErr: errShortKey,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			config := config
+			test.modify(&config)
+			_, err := New(config)
+			require.ErrorIs(t, err, test.expectedErr)
+		})
+	}
+}
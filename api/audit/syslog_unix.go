@@ -0,0 +1,18 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+//go:build !windows
+// +build !windows
+
+package audit
+
+import (
+	"io"
+	"log/syslog"
+)
+
+const syslogTag = "avalanchego-audit"
+
+func newSyslogWriter(network, address string) (io.WriteCloser, error) {
+	return syslog.Dial(network, address, syslog.LOG_NOTICE|syslog.LOG_AUTHPRIV, syslogTag)
+}
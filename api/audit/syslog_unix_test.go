@@ -0,0 +1,59 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+//go:build !windows
+// +build !windows
+
+package audit
+
+import (
+	"bytes"
+	"io/fs"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSyslogLogResumesChain(t *testing.T) {
+	require := require.New(t)
+
+	dir := t.TempDir()
+	address := filepath.Join(dir, "syslog.sock")
+	conn, err := net.ListenPacket("unixgram", address)
+	require.NoError(err)
+	defer conn.Close()
+
+	config := newTestConfig(require, dir)
+	config.SyslogNetwork = "unixgram"
+	config.SyslogAddress = address
+	for _, method := range []string{"admin.alias", "admin.loadVMs"} {
+		l, err := New(config)
+		require.NoError(err)
+		writeTestEntries(require, l, method)
+		require.NoError(l.Close())
+	}
+
+	// The entries sent after the restart extend the entries sent before it.
+	var entries bytes.Buffer
+	buf := make([]byte, 4096)
+	for i := 0; i < 2; i++ {
+		n, _, err := conn.ReadFrom(buf)
+		require.NoError(err)
+		message := buf[:n]
+		entries.Write(message[bytes.IndexByte(message, '{'):])
+	}
+	lastHash, err := Verify(&entries, testKey, genesisHash)
+	require.NoError(err)
+
+	// The entries are only sent to syslog.
+	_, err = os.Stat(config.File)
+	require.ErrorIs(err, fs.ErrNotExist)
+
+	last, err := readHead(config.HeadFile)
+	require.NoError(err)
+	require.Equal(uint64(1), last.Seq)
+	require.Equal(lastHash, last.Hash)
+}
@@ -0,0 +1,18 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+//go:build windows
+// +build windows
+
+package audit
+
+import (
+	"errors"
+	"io"
+)
+
+var errSyslogNotSupported = errors.New("syslog is not supported on windows")
+
+func newSyslogWriter(string, string) (io.WriteCloser, error) {
+	return nil, errSyslogNotSupported
+}
@@ -0,0 +1,168 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package auth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+
+	"github.com/ava-labs/avalanchego/api"
+)
+
+// Anonymous is the identity of callers that don't provide credentials.
+const Anonymous = ""
+
+// identityKey is the context key of the identity of the caller of a request.
+type identityKey struct{}
+
+var (
+	// ErrUnauthenticated is returned if the caller of a request couldn't be
+	// authenticated, or if an anonymous caller isn't granted access.
+	ErrUnauthenticated = errors.New("unauthenticated")
+	// ErrForbidden is returned if an authenticated caller isn't granted
+	// access.
+	ErrForbidden = errors.New("forbidden")
+)
+
+type Config struct {
+	// Path of the policy file. If empty, authentication is disabled and all
+	// callers may call all APIs.
+	PolicyFile string `json:"policyFile"`
+	// Path of the key that JWTs are verified with. If empty, JWTs aren't
+	// accepted.
+	JWTKeyFile string `json:"jwtKeyFile"`
+}
+
+// Auth authenticates the callers of API requests and authorizes the requests
+// against a policy.
+type Auth struct {
+	policy         *Policy
+	authenticators []Authenticator
+}
+
+// New returns the Auth described by [config], or nil if authentication is
+// disabled.
+//
+// Client certificates are only used as credentials if the HTTP server verifies
+// them against its client CAs.
+func New(config Config) (*Auth, error) {
+	if config.PolicyFile == "" {
+		return nil, nil
+	}
+
+	policyBytes, err := os.ReadFile(filepath.Clean(config.PolicyFile))
+	if err != nil {
+		return nil, fmt.Errorf("couldn't read policy file: %w", err)
+	}
+	policy, err := ParsePolicy(policyBytes)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't parse policy file: %w", err)
+	}
+	tokens, err := policy.tokenIdentities()
+	if err != nil {
+		return nil, err
+	}
+
+	authenticators := []Authenticator{
+		certAuthenticator{},
+		&tokenAuthenticator{
+			identities: tokens,
+		},
+	}
+	if config.JWTKeyFile != "" {
+		keyBytes, err := os.ReadFile(filepath.Clean(config.JWTKeyFile))
+		if err != nil {
+			return nil, fmt.Errorf("couldn't read JWT key file: %w", err)
+		}
+		jwtAuthenticator, err := newJWTAuthenticator(keyBytes)
+		if err != nil {
+			return nil, err
+		}
+		authenticators = append(authenticators, jwtAuthenticator)
+	}
+	return NewWithAuthenticators(policy, authenticators...), nil
+}
+
+// NewWithAuthenticators returns an Auth that identifies callers with the first
+// of [authenticators] that handles their credentials.
+func NewWithAuthenticators(policy *Policy, authenticators ...Authenticator) *Auth {
+	return &Auth{
+		policy:         policy,
+		authenticators: authenticators,
+	}
+}
+
+// Authenticate returns the identity of the caller of [r]. If [r] doesn't
+// include any credentials, Anonymous is returned.
+func (a *Auth) Authenticate(r *http.Request) (string, error) {
+	for _, authenticator := range a.authenticators {
+		identity, err := authenticator.Authenticate(r)
+		if err == nil {
+			return identity, nil
+		}
+		if !errors.Is(err, ErrNoCredentials) {
+			return "", fmt.Errorf("%w: %w", ErrUnauthenticated, err)
+		}
+	}
+	// Credentials that no authenticator recognizes are rejected, rather than
+	// treated as anonymous, so that misconfigured callers notice.
+	if r.Header.Get("Authorization") != "" {
+		return "", fmt.Errorf("%w: unrecognized credentials", ErrUnauthenticated)
+	}
+	return Anonymous, nil
+}
+
+// Authorize returns nil if [identity] is granted access to [r]. [routes] are
+// the names of the route that [r] is dispatched to. [w] is used to reject
+// requests that are too large to determine the methods of.
+func (a *Auth) Authorize(identity string, routes []string, w http.ResponseWriter, r *http.Request) error {
+	var (
+		methods       []string
+		methodsErr    error
+		parsedMethods bool
+	)
+	for _, rule := range a.policy.rules(identity) {
+		if !rule.matchesRoute(routes) {
+			continue
+		}
+		if len(rule.Methods) == 0 {
+			return nil
+		}
+
+		if !parsedMethods {
+			methods, methodsErr = api.RequestMethods(w, r)
+			parsedMethods = true
+		}
+		if errors.Is(methodsErr, api.ErrRequestTooLarge) {
+			return methodsErr
+		}
+		// Requests whose methods can't be determined can only be granted by
+		// rules that don't restrict the methods.
+		if methodsErr == nil && rule.matchesMethods(methods) {
+			return nil
+		}
+	}
+
+	if identity == Anonymous {
+		return ErrUnauthenticated
+	}
+	return fmt.Errorf("%w: %q", ErrForbidden, identity)
+}
+
+// WithIdentity returns a copy of [ctx] that carries the authenticated
+// [identity] of the caller of a request.
+func WithIdentity(ctx context.Context, identity string) context.Context {
+	return context.WithValue(ctx, identityKey{}, identity)
+}
+
+// Identity returns the identity carried by [ctx], or [Anonymous] if [ctx]
+// doesn't carry one.
+func Identity(ctx context.Context) string {
+	identity, _ := ctx.Value(identityKey{}).(string)
+	return identity
+}
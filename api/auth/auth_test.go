@@ -0,0 +1,375 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package auth
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/hex"
+	"encoding/pem"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/utils/hashing"
+)
+
+const (
+	testToken   = "explorer-token"
+	testHMACKey = "0123456789abcdef0123456789abcdef"
+)
+
+var testPolicy = `{
+	"tokens": {
+		"explorer": ["` + hex.EncodeToString(hashing.ComputeHash256([]byte(testToken))) + `"]
+	},
+	"identities": {
+		"explorer": [
+			{"routes": ["/ext/info"], "methods": ["info.get*"]},
+			{"routes": ["/ext/bc/*"], "methods": ["avm.get*", "platform.get*"]}
+		],
+		"operator": [
+			{"routes": ["*"]}
+		]
+	},
+	"anonymous": [
+		{"routes": ["/ext/health", "/ext/health/*"]}
+	]
+}`
+
+func newTestAuth(t *testing.T, jwtKey []byte) *Auth {
+	require := require.New(t)
+
+	dir := t.TempDir()
+	config := Config{
+		PolicyFile: filepath.Join(dir, "policy.json"),
+	}
+	require.NoError(os.WriteFile(config.PolicyFile, []byte(testPolicy), 0o600))
+	if jwtKey != nil {
+		config.JWTKeyFile = filepath.Join(dir, "jwt.key")
+		require.NoError(os.WriteFile(config.JWTKeyFile, jwtKey, 0o600))
+	}
+
+	a, err := New(config)
+	require.NoError(err)
+	return a
+}
+
+func newRequest(body string, authorization string) *http.Request {
+	r := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(body))
+	if authorization != "" {
+		r.Header.Set("Authorization", authorization)
+	}
+	return r
+}
+
+func signJWT(t *testing.T, method jwt.SigningMethod, key interface{}, claims jwt.RegisteredClaims) string {
+	token, err := jwt.NewWithClaims(method, claims).SignedString(key)
+	require.NoError(t, err)
+	return "Bearer " + token
+}
+
+func TestNewDisabled(t *testing.T) {
+	a, err := New(Config{})
+	require.NoError(t, err)
+	require.Nil(t, a)
+}
+
+func TestAuthenticate(t *testing.T) {
+	ecdsaKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	publicKeyBytes, err := x509.MarshalPKIXPublicKey(&ecdsaKey.PublicKey)
+	require.NoError(t, err)
+	ecdsaPEM := pem.EncodeToMemory(&pem.Block{
+		Type:  "PUBLIC KEY",
+		Bytes: publicKeyBytes,
+	})
+
+	var (
+		expiry       = jwt.NewNumericDate(time.Now().Add(time.Hour))
+		validClaims  = jwt.RegisteredClaims{Subject: "operator", ExpiresAt: expiry}
+		hmacAuth     = newTestAuth(t, []byte(testHMACKey))
+		ecdsaAuth    = newTestAuth(t, ecdsaPEM)
+		noJWTKeyAuth = newTestAuth(t, nil)
+	)
+
+	tests := []struct {
+		name             string
+		auth             *Auth
+		authorization    string
+		tls              *tls.ConnectionState
+		expectedIdentity string
+		expectedErr      error
+	}{
+		{
+			name:             "anonymous",
+			auth:             hmacAuth,
+			expectedIdentity: Anonymous,
+		},
+		{
+			name:             "static token",
+			auth:             hmacAuth,
+			authorization:    "Bearer " + testToken,
+			expectedIdentity: "explorer",
+		},
+		{
+			name:          "unknown static token",
+			auth:          hmacAuth,
+			authorization: "Bearer unknown",
+			expectedErr:   ErrUnauthenticated,
+		},
+		{
+			name:          "unsupported scheme",
+			auth:          hmacAuth,
+			authorization: "Basic dXNlcjpwYXNz",
+			expectedErr:   ErrUnauthenticated,
+		},
+		{
+			name:             "hmac jwt",
+			auth:             hmacAuth,
+			authorization:    signJWT(t, jwt.SigningMethodHS256, []byte(testHMACKey), validClaims),
+			expectedIdentity: "operator",
+		},
+		{
+			name:          "hmac jwt with wrong key",
+			auth:          hmacAuth,
+			authorization: signJWT(t, jwt.SigningMethodHS256, []byte(strings.Repeat("x", 32)), validClaims),
+			expectedErr:   ErrUnauthenticated,
+		},
+		{
+			name: "expired jwt",
+			auth: hmacAuth,
+			authorization: signJWT(t, jwt.SigningMethodHS256, []byte(testHMACKey), jwt.RegisteredClaims{
+				Subject:   "operator",
+				ExpiresAt: jwt.NewNumericDate(time.Now().Add(-time.Hour)),
+			}),
+			expectedErr: ErrUnauthenticated,
+		},
+		{
+			name: "jwt without expiry",
+			auth: hmacAuth,
+			authorization: signJWT(t, jwt.SigningMethodHS256, []byte(testHMACKey), jwt.RegisteredClaims{
+				Subject: "operator",
+			}),
+			expectedErr: errMissingExpiry,
+		},
+		{
+			name: "jwt without subject",
+			auth: hmacAuth,
+			authorization: signJWT(t, jwt.SigningMethodHS256, []byte(testHMACKey), jwt.RegisteredClaims{
+				ExpiresAt: expiry,
+			}),
+			expectedErr: errMissingSubject,
+		},
+		{
+			name:             "ecdsa jwt",
+			auth:             ecdsaAuth,
+			authorization:    signJWT(t, jwt.SigningMethodES256, ecdsaKey, validClaims),
+			expectedIdentity: "operator",
+		},
+		{
+			name:          "hmac jwt signed with public key",
+			auth:          ecdsaAuth,
+			authorization: signJWT(t, jwt.SigningMethodHS256, ecdsaPEM, validClaims),
+			expectedErr:   ErrUnauthenticated,
+		},
+		{
+			name:          "jwt without key",
+			auth:          noJWTKeyAuth,
+			authorization: signJWT(t, jwt.SigningMethodHS256, []byte(testHMACKey), validClaims),
+			expectedErr:   ErrUnauthenticated,
+		},
+		{
+			name: "verified client certificate",
+			auth: noJWTKeyAuth,
+			tls: &tls.ConnectionState{
+				VerifiedChains: [][]*x509.Certificate{{
+					{Subject: pkix.Name{CommonName: "operator"}},
+				}},
+			},
+			expectedIdentity: "operator",
+		},
+		{
+			name: "unverified client certificate",
+			auth: noJWTKeyAuth,
+			tls: &tls.ConnectionState{
+				PeerCertificates: []*x509.Certificate{
+					{Subject: pkix.Name{CommonName: "operator"}},
+				},
+			},
+			expectedIdentity: Anonymous,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require := require.New(t)
+
+			r := newRequest("", test.authorization)
+			r.TLS = test.tls
+			identity, err := test.auth.Authenticate(r)
+			require.ErrorIs(err, test.expectedErr)
+			require.Equal(test.expectedIdentity, identity)
+		})
+	}
+}
+
+func TestAuthorize(t *testing.T) {
+	a := newTestAuth(t, nil)
+
+	tests := []struct {
+		name        string
+		identity    string
+		routes      []string
+		body        string
+		expectedErr error
+	}{
+		{
+			name:     "allowed method",
+			identity: "explorer",
+			routes:   []string{"/ext/info"},
+			body:     `{"jsonrpc":"2.0","id":1,"method":"info.getNodeID"}`,
+		},
+		{
+			name:        "disallowed method",
+			identity:    "explorer",
+			routes:      []string{"/ext/info"},
+			body:        `{"jsonrpc":"2.0","id":1,"method":"info.peers"}`,
+			expectedErr: ErrForbidden,
+		},
+		{
+			name:     "allowed alias",
+			identity: "explorer",
+			routes:   []string{"/ext/bc/2oYMBNV4eNHyqk2fjjV5nVQLDbtmNJzq5s3qs3Lo6ftnC6FByM", "/ext/bc/X"},
+			body:     `{"jsonrpc":"2.0","id":1,"method":"avm.getBalance"}`,
+		},
+		{
+			name:     "allowed batch",
+			identity: "explorer",
+			routes:   []string{"/ext/bc/C"},
+			body:     `[{"jsonrpc":"2.0","id":1,"method":"avm.getTx"},{"jsonrpc":"2.0","id":2,"method":"platform.getHeight"}]`,
+		},
+		{
+			name:        "partially disallowed batch",
+			identity:    "explorer",
+			routes:      []string{"/ext/bc/P"},
+			body:        `[{"jsonrpc":"2.0","id":1,"method":"platform.getHeight"},{"jsonrpc":"2.0","id":2,"method":"platform.issueTx"}]`,
+			expectedErr: ErrForbidden,
+		},
+		{
+			name:        "method granted for another route",
+			identity:    "explorer",
+			routes:      []string{"/ext/info"},
+			body:        `{"jsonrpc":"2.0","id":1,"method":"avm.getTx"}`,
+			expectedErr: ErrForbidden,
+		},
+		{
+			name:        "unparsable request",
+			identity:    "explorer",
+			routes:      []string{"/ext/info"},
+			body:        `not json`,
+			expectedErr: ErrForbidden,
+		},
+		{
+			name:        "disallowed route",
+			identity:    "explorer",
+			routes:      []string{"/ext/admin"},
+			body:        `{"jsonrpc":"2.0","id":1,"method":"admin.getConfig"}`,
+			expectedErr: ErrForbidden,
+		},
+		{
+			name:     "all routes",
+			identity: "operator",
+			routes:   []string{"/ext/admin"},
+			body:     `{"jsonrpc":"2.0","id":1,"method":"admin.getConfig"}`,
+		},
+		{
+			name:        "unknown identity",
+			identity:    "unknown",
+			routes:      []string{"/ext/health"},
+			expectedErr: ErrForbidden,
+		},
+		{
+			name:     "anonymous allowed route",
+			identity: Anonymous,
+			routes:   []string{"/ext/health/liveness"},
+		},
+		{
+			name:        "anonymous disallowed route",
+			identity:    Anonymous,
+			routes:      []string{"/ext/info"},
+			body:        `{"jsonrpc":"2.0","id":1,"method":"info.getNodeID"}`,
+			expectedErr: ErrUnauthenticated,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require := require.New(t)
+
+			r := newRequest(test.body, "")
+			err := a.Authorize(test.identity, test.routes, httptest.NewRecorder(), r)
+			require.ErrorIs(err, test.expectedErr)
+
+			// The handler must still be able to read the request.
+			body, err := io.ReadAll(r.Body)
+			require.NoError(err)
+			require.Equal(test.body, string(body))
+		})
+	}
+}
+
+func TestParsePolicy(t *testing.T) {
+	tests := []struct {
+		name        string
+		policy      string
+		expectedErr error
+	}{
+		{
+			name:   "valid",
+			policy: testPolicy,
+		},
+		{
+			name:        "invalid pattern",
+			policy:      `{"identities": {"explorer": [{"routes": ["/ext/["]}]}}`,
+			expectedErr: errInvalidPattern,
+		},
+		{
+			name:        "empty identity",
+			policy:      `{"identities": {"": [{"routes": ["*"]}]}}`,
+			expectedErr: errEmptyIdentity,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := ParsePolicy([]byte(test.policy))
+			require.ErrorIs(t, err, test.expectedErr)
+		})
+	}
+}
+
+func TestPolicyTokenIdentities(t *testing.T) {
+	require := require.New(t)
+
+	hash := hex.EncodeToString(hashing.ComputeHash256([]byte(testToken)))
+	policy, err := ParsePolicy([]byte(`{"tokens": {"a": ["` + hash + `"], "b": ["` + hash + `"]}}`))
+	require.NoError(err)
+	_, err = policy.tokenIdentities()
+	require.ErrorIs(err, errDuplicateToken)
+
+	policy, err = ParsePolicy([]byte(`{"tokens": {"a": ["abcd"]}}`))
+	require.NoError(err)
+	_, err = policy.tokenIdentities()
+	require.ErrorIs(err, errInvalidTokenHash)
+}
@@ -0,0 +1,168 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package auth
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/golang-jwt/jwt/v4"
+
+	"github.com/ava-labs/avalanchego/utils/hashing"
+)
+
+const (
+	bearerPrefix = "Bearer "
+
+	// minHMACKeyLen is the minimum length of a shared key used to verify
+	// JWTs.
+	minHMACKeyLen = 32
+)
+
+var (
+	_ Authenticator = (*certAuthenticator)(nil)
+	_ Authenticator = (*tokenAuthenticator)(nil)
+	_ Authenticator = (*jwtAuthenticator)(nil)
+
+	// ErrNoCredentials is returned by an Authenticator if the request doesn't
+	// include credentials handled by the Authenticator.
+	ErrNoCredentials = errors.New("no credentials")
+
+	errUnsupportedKey = errors.New("unsupported JWT key")
+	errWeakKey        = errors.New("JWT key is too short")
+	errMissingSubject = errors.New("JWT doesn't specify a subject")
+	errMissingExpiry  = errors.New("JWT doesn't specify an expiry")
+)
+
+// Authenticator identifies the caller of a request.
+type Authenticator interface {
+	// Authenticate returns the identity of the caller of [r]. If [r] doesn't
+	// include credentials handled by this Authenticator, ErrNoCredentials is
+	// returned.
+	Authenticate(r *http.Request) (string, error)
+}
+
+// certAuthenticator identifies callers by the common name of their verified
+// TLS client certificate.
+type certAuthenticator struct{}
+
+func (certAuthenticator) Authenticate(r *http.Request) (string, error) {
+	// Only the certificates that were verified against the client CAs can be
+	// trusted.
+	if r.TLS == nil || len(r.TLS.VerifiedChains) == 0 || len(r.TLS.VerifiedChains[0]) == 0 {
+		return "", ErrNoCredentials
+	}
+	identity := r.TLS.VerifiedChains[0][0].Subject.CommonName
+	if identity == "" {
+		return "", ErrNoCredentials
+	}
+	return identity, nil
+}
+
+// tokenAuthenticator identifies callers by a static bearer token.
+type tokenAuthenticator struct {
+	// hash of the token -> identity
+	identities map[hashing.Hash256]string
+}
+
+func (a *tokenAuthenticator) Authenticate(r *http.Request) (string, error) {
+	token, ok := bearerToken(r)
+	if !ok {
+		return "", ErrNoCredentials
+	}
+	identity, ok := a.identities[hashing.ComputeHash256Array([]byte(token))]
+	if !ok {
+		return "", ErrNoCredentials
+	}
+	return identity, nil
+}
+
+// jwtAuthenticator identifies callers by the subject of a bearer JWT signed by
+// a local key.
+type jwtAuthenticator struct {
+	parser *jwt.Parser
+	key    interface{}
+	now    func() time.Time
+}
+
+// newJWTAuthenticator returns an authenticator that verifies JWTs with
+// [keyBytes]. If [keyBytes] is a PEM encoded public key, JWTs must be signed by
+// the corresponding private key. Otherwise, [keyBytes] is used as the shared
+// key of HMAC signed JWTs.
+func newJWTAuthenticator(keyBytes []byte) (*jwtAuthenticator, error) {
+	var (
+		key     interface{}
+		methods []string
+	)
+	if block, _ := pem.Decode(keyBytes); block != nil {
+		publicKey, err := x509.ParsePKIXPublicKey(block.Bytes)
+		if err != nil {
+			return nil, fmt.Errorf("couldn't parse JWT key: %w", err)
+		}
+		switch publicKey.(type) {
+		case *rsa.PublicKey:
+			methods = []string{"RS256", "RS384", "RS512", "PS256", "PS384", "PS512"}
+		case *ecdsa.PublicKey:
+			methods = []string{"ES256", "ES384", "ES512"}
+		case ed25519.PublicKey:
+			methods = []string{"EdDSA"}
+		default:
+			return nil, fmt.Errorf("%w: %T", errUnsupportedKey, publicKey)
+		}
+		key = publicKey
+	} else {
+		sharedKey := bytes.TrimSpace(keyBytes)
+		if len(sharedKey) < minHMACKeyLen {
+			return nil, fmt.Errorf("%w: expected at least %d bytes but got %d", errWeakKey, minHMACKeyLen, len(sharedKey))
+		}
+		methods = []string{"HS256", "HS384", "HS512"}
+		key = sharedKey
+	}
+	return &jwtAuthenticator{
+		parser: jwt.NewParser(jwt.WithValidMethods(methods)),
+		key:    key,
+		now:    time.Now,
+	}, nil
+}
+
+func (a *jwtAuthenticator) Authenticate(r *http.Request) (string, error) {
+	token, ok := bearerToken(r)
+	// A JWT consists of 3 segments separated by dots.
+	if !ok || strings.Count(token, ".") != 2 {
+		return "", ErrNoCredentials
+	}
+
+	claims := &jwt.RegisteredClaims{}
+	_, err := a.parser.ParseWithClaims(token, claims, func(*jwt.Token) (interface{}, error) {
+		return a.key, nil
+	})
+	if err != nil {
+		return "", err
+	}
+	// Tokens that never expire can't be revoked, so they aren't accepted.
+	if !claims.VerifyExpiresAt(a.now(), true) {
+		return "", errMissingExpiry
+	}
+	if claims.Subject == "" {
+		return "", errMissingSubject
+	}
+	return claims.Subject, nil
+}
+
+func bearerToken(r *http.Request) (string, bool) {
+	header := r.Header.Get("Authorization")
+	if len(header) < len(bearerPrefix) || !strings.EqualFold(header[:len(bearerPrefix)], bearerPrefix) {
+		return "", false
+	}
+	return strings.TrimSpace(header[len(bearerPrefix):]), true
+}
@@ -0,0 +1,169 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package auth
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"path"
+
+	"github.com/ava-labs/avalanchego/utils/hashing"
+)
+
+// wildcard is a pattern that matches every route or method.
+const wildcard = "*"
+
+var (
+	errInvalidPattern   = errors.New("invalid pattern")
+	errInvalidTokenHash = errors.New("invalid token hash")
+	errDuplicateToken   = errors.New("token is assigned to multiple identities")
+	errEmptyIdentity    = errors.New("empty identity")
+)
+
+// Policy maps identities to the APIs they may call.
+//
+// Example:
+//
+//	{
+//		"tokens": {
+//			"explorer": ["<hex encoded sha256 hash of the token>"]
+//		},
+//		"identities": {
+//			"explorer": [
+//				{"routes": ["/ext/info"], "methods": ["info.get*", "info.isBootstrapped"]},
+//				{"routes": ["/ext/bc/X", "/ext/bc/P"], "methods": ["avm.get*", "platform.get*"]}
+//			],
+//			"operator": [
+//				{"routes": ["*"]}
+//			]
+//		},
+//		"anonymous": [
+//			{"routes": ["/ext/health", "/ext/health/*"]}
+//		]
+//	}
+type Policy struct {
+	// Identity -> hashes of the bearer tokens that authenticate as the
+	// identity. Only the hashes are stored so that the policy file doesn't
+	// leak the tokens.
+	Tokens map[string][]string `json:"tokens"`
+	// Identity -> rules granting access to the identity
+	Identities map[string][]Rule `json:"identities"`
+	// Rules granting access to requests without credentials
+	Anonymous []Rule `json:"anonymous"`
+}
+
+// Rule grants access to the routes matching [Routes].
+//
+// Routes and methods are matched with [path.Match], so "/ext/bc/*" matches
+// "/ext/bc/X" but not "/ext/bc/X/wallet". The pattern "*" matches everything.
+// Routes are matched against all of their aliases, so a rule for "/ext/bc/X"
+// also grants access to "/ext/bc/<X-chain ID>".
+type Rule struct {
+	Routes []string `json:"routes"`
+	// If non-empty, only requests that call JSON-RPC methods matching
+	// [Methods] are granted.
+	Methods []string `json:"methods"`
+}
+
+// ParsePolicy parses and verifies the policy in [policyBytes].
+func ParsePolicy(policyBytes []byte) (*Policy, error) {
+	p := &Policy{}
+	if err := json.Unmarshal(policyBytes, p); err != nil {
+		return nil, err
+	}
+
+	for identity, rules := range p.Identities {
+		if identity == Anonymous {
+			return nil, errEmptyIdentity
+		}
+		if err := verifyRules(rules); err != nil {
+			return nil, fmt.Errorf("invalid rules for %q: %w", identity, err)
+		}
+	}
+	if err := verifyRules(p.Anonymous); err != nil {
+		return nil, fmt.Errorf("invalid anonymous rules: %w", err)
+	}
+	return p, nil
+}
+
+func verifyRules(rules []Rule) error {
+	for _, rule := range rules {
+		for _, patterns := range [][]string{rule.Routes, rule.Methods} {
+			for _, pattern := range patterns {
+				if _, err := path.Match(pattern, ""); err != nil {
+					return fmt.Errorf("%w %q: %w", errInvalidPattern, pattern, err)
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// tokenIdentities returns a map from the hash of each token to the identity it
+// authenticates as.
+func (p *Policy) tokenIdentities() (map[hashing.Hash256]string, error) {
+	identities := make(map[hashing.Hash256]string)
+	for identity, hashes := range p.Tokens {
+		if identity == Anonymous {
+			return nil, errEmptyIdentity
+		}
+		for _, hashStr := range hashes {
+			hashBytes, err := hex.DecodeString(hashStr)
+			if err != nil {
+				return nil, fmt.Errorf("%w for %q: %w", errInvalidTokenHash, identity, err)
+			}
+			hash, err := hashing.ToHash256(hashBytes)
+			if err != nil {
+				return nil, fmt.Errorf("%w for %q: %w", errInvalidTokenHash, identity, err)
+			}
+			if _, ok := identities[hash]; ok {
+				return nil, fmt.Errorf("%w: %q", errDuplicateToken, identity)
+			}
+			identities[hash] = identity
+		}
+	}
+	return identities, nil
+}
+
+// rules returns the rules granting access to [identity].
+func (p *Policy) rules(identity string) []Rule {
+	if identity == Anonymous {
+		return p.Anonymous
+	}
+	return p.Identities[identity]
+}
+
+// matchesRoute returns true if one of [routes] matches the rule.
+func (r *Rule) matchesRoute(routes []string) bool {
+	for _, route := range routes {
+		if matchesAny(r.Routes, route) {
+			return true
+		}
+	}
+	return false
+}
+
+// matchesMethods returns true if all of [methods] match the rule.
+func (r *Rule) matchesMethods(methods []string) bool {
+	for _, method := range methods {
+		if !matchesAny(r.Methods, method) {
+			return false
+		}
+	}
+	return true
+}
+
+func matchesAny(patterns []string, name string) bool {
+	for _, pattern := range patterns {
+		if pattern == wildcard {
+			return true
+		}
+		if matched, _ := path.Match(pattern, name); matched {
+			return true
+		}
+	}
+	return false
+}
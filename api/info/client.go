@@ -25,6 +25,7 @@ type Client interface {
 	GetBlockchainID(context.Context, string, ...rpc.Option) (ids.ID, error)
 	Peers(context.Context, ...rpc.Option) ([]Peer, error)
 	IsBootstrapped(context.Context, string, ...rpc.Option) (bool, error)
+	GetBootstrapProgress(context.Context, string, ...rpc.Option) (*GetBootstrapProgressReply, error)
 	GetTxFee(context.Context, ...rpc.Option) (*GetTxFeeResponse, error)
 	Uptime(context.Context, ids.ID, ...rpc.Option) (*UptimeResponse, error)
 	GetVMs(context.Context, ...rpc.Option) (map[ids.ID][]string, error)
@@ -94,6 +95,14 @@ func (c *client) IsBootstrapped(ctx context.Context, chainID string, options ...
 	return res.IsBootstrapped, err
 }
 
+func (c *client) GetBootstrapProgress(ctx context.Context, chainID string, options ...rpc.Option) (*GetBootstrapProgressReply, error) {
+	res := &GetBootstrapProgressReply{}
+	err := c.requester.SendRequest(ctx, "info.getBootstrapProgress", &GetBootstrapProgressArgs{
+		Chain: chainID,
+	}, res, options...)
+	return res, err
+}
+
 func (c *client) GetTxFee(ctx context.Context, options ...rpc.Option) (*GetTxFeeResponse, error) {
 	res := &GetTxFeeResponse{}
 	err := c.requester.SendRequest(ctx, "info.getTxFee", struct{}{}, res, options...)
@@ -7,6 +7,7 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
+	"time"
 
 	"github.com/gorilla/rpc/v2"
 	"go.uber.org/zap"
@@ -289,6 +290,62 @@ func (i *Info) IsBootstrapped(_ *http.Request, args *IsBootstrappedArgs, reply *
 	return nil
 }
 
+// GetBootstrapProgressArgs are the arguments for calling GetBootstrapProgress
+type GetBootstrapProgressArgs struct {
+	// Alias of the chain
+	// Can also be the string representation of the chain's ID
+	Chain string `json:"chain"`
+}
+
+// GetBootstrapProgressReply are the results from calling GetBootstrapProgress
+type GetBootstrapProgressReply struct {
+	// True iff the chain is done bootstrapping
+	IsBootstrapped bool `json:"isBootstrapped"`
+	// Height of the last accepted block when the bootstrapping round started
+	StartingHeight json.Uint64 `json:"startingHeight"`
+	// Height of the accepted frontier being bootstrapped to
+	TipHeight json.Uint64 `json:"tipHeight"`
+	// Number of blocks fetched but not yet executed
+	NumFetched json.Uint64 `json:"numFetched"`
+	// Number of blocks executed during the bootstrapping round
+	NumExecuted json.Uint64 `json:"numExecuted"`
+	// Estimated time until all blocks are fetched
+	FetchETA time.Duration `json:"fetchETA"`
+	// Estimated time until all fetched blocks are executed
+	ExecuteETA time.Duration `json:"executeETA"`
+}
+
+// GetBootstrapProgress returns the progress of the most recent bootstrapping
+// round of [args.Chain]
+func (i *Info) GetBootstrapProgress(_ *http.Request, args *GetBootstrapProgressArgs, reply *GetBootstrapProgressReply) error {
+	i.log.Debug("API called",
+		zap.String("service", "info"),
+		zap.String("method", "getBootstrapProgress"),
+		logging.UserString("chain", args.Chain),
+	)
+
+	if args.Chain == "" {
+		return errNoChainProvided
+	}
+	chainID, err := i.chainManager.Lookup(args.Chain)
+	if err != nil {
+		return fmt.Errorf("there is no chain with alias/ID '%s'", args.Chain)
+	}
+	progress, err := i.chainManager.BootstrapProgress(chainID)
+	if err != nil {
+		return err
+	}
+
+	reply.IsBootstrapped = i.chainManager.IsBootstrapped(chainID)
+	reply.StartingHeight = json.Uint64(progress.StartingHeight)
+	reply.TipHeight = json.Uint64(progress.TipHeight)
+	reply.NumFetched = json.Uint64(progress.NumFetched)
+	reply.NumExecuted = json.Uint64(progress.NumExecuted)
+	reply.FetchETA = progress.FetchETA
+	reply.ExecuteETA = progress.ExecuteETA
+	return nil
+}
+
 // UptimeResponse are the results from calling Uptime
 type UptimeResponse struct {
 	// RewardingStakePercentage shows what percent of network stake thinks we're
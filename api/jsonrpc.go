@@ -0,0 +1,75 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/ava-labs/avalanchego/utils/units"
+)
+
+// MaxRequestSize is the largest request body that is read to determine the
+// JSON-RPC methods called by a request.
+const MaxRequestSize = 16 * units.MiB
+
+var (
+	ErrRequestTooLarge = errors.New("request too large")
+
+	errNoJSONRPCMethod = errors.New("request doesn't specify a JSON-RPC method")
+)
+
+// RequestMethods returns the JSON-RPC methods called by [r]. A batch request
+// calls multiple methods. If the body of [r] is larger than [MaxRequestSize],
+// [ErrRequestTooLarge] is returned and the request should be rejected with
+// [http.StatusRequestEntityTooLarge].
+//
+// The body of [r] is replaced so that it can still be read by the handler of
+// the request.
+func RequestMethods(w http.ResponseWriter, r *http.Request) ([]string, error) {
+	if r.Body == nil {
+		return nil, errNoJSONRPCMethod
+	}
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, MaxRequestSize))
+	var maxBytesErr *http.MaxBytesError
+	if errors.As(err, &maxBytesErr) {
+		return nil, fmt.Errorf("%w: %w", ErrRequestTooLarge, err)
+	}
+	if err != nil {
+		return nil, err
+	}
+	r.Body = io.NopCloser(bytes.NewReader(body))
+
+	type request struct {
+		Method string `json:"method"`
+	}
+	var requests []request
+	if body = bytes.TrimSpace(body); len(body) > 0 && body[0] == '[' {
+		if err := json.Unmarshal(body, &requests); err != nil {
+			return nil, err
+		}
+	} else {
+		var req request
+		if err := json.Unmarshal(body, &req); err != nil {
+			return nil, err
+		}
+		requests = []request{req}
+	}
+	if len(requests) == 0 {
+		return nil, errNoJSONRPCMethod
+	}
+
+	methods := make([]string, len(requests))
+	for i, req := range requests {
+		if req.Method == "" {
+			return nil, errNoJSONRPCMethod
+		}
+		methods[i] = req.Method
+	}
+	return methods, nil
+}
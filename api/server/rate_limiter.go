@@ -0,0 +1,421 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package server
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"path"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"golang.org/x/time/rate"
+
+	"github.com/ava-labs/avalanchego/cache"
+	"github.com/ava-labs/avalanchego/utils"
+)
+
+const (
+	defaultMethodCost = 1
+
+	// Clients with IPv6 addresses are identified by their /64 prefix, as a
+	// single host is commonly assigned an entire /64.
+	ipv6PrefixLen = 64
+
+	routeLabel  = "route"
+	methodLabel = "method"
+
+	// patternWildcards are the characters that make a route pattern match
+	// more than a single route.
+	patternWildcards = `*?[\`
+)
+
+var (
+	rateLimiterLabels = []string{routeLabel, methodLabel}
+
+	errInvalidRate       = errors.New("rate must be positive")
+	errInvalidBurst      = errors.New("burst must be positive")
+	errInvalidCost       = errors.New("cost must be non-negative")
+	errCostExceedsBurst  = errors.New("cost exceeds burst")
+	errInvalidMaxClients = errors.New("max clients must be positive")
+	errInvalidRoute      = errors.New("invalid route pattern")
+)
+
+type RateLimitConfig struct {
+	// Rate, in tokens per second, at which the budget of each client
+	// replenishes. If 0, requests aren't rate limited.
+	Rate float64 `json:"rate"`
+	// Max number of tokens that can accumulate in the budget of each client
+	Burst int `json:"burst"`
+	// Max number of clients whose budgets are tracked. The budgets of the
+	// least recently seen clients are forgotten first.
+	MaxClients int `json:"maxClients"`
+
+	RateLimitRules `json:"rules"`
+}
+
+type RateLimitRules struct {
+	// Route pattern -> separate budget for the routes matching the pattern.
+	// Patterns are matched with [path.Match] against all the aliases of a
+	// route. If several patterns match, the most specific one is used:
+	// patterns without wildcards before patterns with wildcards, and then
+	// longer patterns before shorter ones.
+	Routes map[string]RateLimit `json:"routes"`
+	// JSON-RPC method -> cost of calling the method
+	Methods map[string]MethodRateLimit `json:"methods"`
+}
+
+type RateLimit struct {
+	Rate  float64 `json:"rate"`
+	Burst int     `json:"burst"`
+}
+
+type MethodRateLimit struct {
+	// Number of tokens consumed by a call to the method. If 0, a call
+	// consumes 1 token.
+	Cost int `json:"cost"`
+	// If [Rate] is non-zero, calls to the method consume tokens from a
+	// separate budget, rather than the budget of the route.
+	RateLimit
+}
+
+func (c *RateLimitConfig) Verify() error {
+	switch {
+	case c.Rate < 0:
+		return errInvalidRate
+	case c.Rate == 0:
+		return nil
+	case c.MaxClients <= 0:
+		return errInvalidMaxClients
+	}
+	if err := c.defaultLimit().verify(); err != nil {
+		return err
+	}
+	for pattern, limit := range c.Routes {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return fmt.Errorf("%w %q: %w", errInvalidRoute, pattern, err)
+		}
+		if err := limit.verify(); err != nil {
+			return fmt.Errorf("invalid limit of %q: %w", pattern, err)
+		}
+	}
+	for method, limit := range c.Methods {
+		if err := c.verifyMethod(limit); err != nil {
+			return fmt.Errorf("invalid limit of %q: %w", method, err)
+		}
+	}
+	return nil
+}
+
+func (c *RateLimitConfig) verifyMethod(limit MethodRateLimit) error {
+	if limit.Cost < 0 {
+		return errInvalidCost
+	}
+	if limit.Rate != 0 {
+		if err := limit.RateLimit.verify(); err != nil {
+			return err
+		}
+		if limit.cost() > limit.Burst {
+			return errCostExceedsBurst
+		}
+		return nil
+	}
+
+	// A call to the method may be charged to any route, so it must fit
+	// within the budget of every route.
+	if limit.cost() > c.Burst {
+		return errCostExceedsBurst
+	}
+	for _, routeLimit := range c.Routes {
+		if limit.cost() > routeLimit.Burst {
+			return errCostExceedsBurst
+		}
+	}
+	return nil
+}
+
+func (c *RateLimitConfig) defaultLimit() RateLimit {
+	return RateLimit{
+		Rate:  c.Rate,
+		Burst: c.Burst,
+	}
+}
+
+func (l RateLimit) verify() error {
+	switch {
+	case l.Rate <= 0:
+		return errInvalidRate
+	case l.Burst <= 0:
+		return errInvalidBurst
+	default:
+		return nil
+	}
+}
+
+func (l MethodRateLimit) cost() int {
+	if l.Cost == 0 {
+		return defaultMethodCost
+	}
+	return l.Cost
+}
+
+// rateLimiter limits the rate at which each client can call the API. Each
+// client has a token bucket per budget, and each call consumes tokens from the
+// budget of its method or route.
+type rateLimiter struct {
+	config RateLimitConfig
+	// The patterns of [config.Routes], from the most to the least specific
+	routePatterns []string
+
+	allowedCost *prometheus.CounterVec
+	limitedCost *prometheus.CounterVec
+	limitedReqs *prometheus.CounterVec
+
+	lock sync.Mutex
+	// client -> budget -> token bucket
+	clients cache.LRU[string, map[string]*rate.Limiter]
+}
+
+// newRateLimiter returns a rateLimiter that enforces [config], or nil if
+// requests aren't rate limited.
+func newRateLimiter(
+	namespace string,
+	registerer prometheus.Registerer,
+	config RateLimitConfig,
+) (*rateLimiter, error) {
+	if config.Rate == 0 {
+		return nil, nil
+	}
+
+	l := &rateLimiter{
+		config:        config,
+		routePatterns: sortedRoutePatterns(config.Routes),
+		allowedCost: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Namespace: namespace,
+				Name:      "rate_limit_allowed_cost",
+				Help:      "The total cost of the API calls that were within their client's rate limit",
+			},
+			rateLimiterLabels,
+		),
+		limitedCost: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Namespace: namespace,
+				Name:      "rate_limit_rejected_cost",
+				Help:      "The total cost of the API calls that exceeded their client's rate limit",
+			},
+			rateLimiterLabels,
+		),
+		limitedReqs: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Namespace: namespace,
+				Name:      "rate_limit_rejected_requests",
+				Help:      "The number of API requests that exceeded their client's rate limit",
+			},
+			[]string{routeLabel},
+		),
+		clients: cache.LRU[string, map[string]*rate.Limiter]{
+			Size: config.MaxClients,
+		},
+	}
+	err := utils.Err(
+		registerer.Register(l.allowedCost),
+		registerer.Register(l.limitedCost),
+		registerer.Register(l.limitedReqs),
+	)
+	return l, err
+}
+
+// Allow returns true if [client] may call [methods] on the route named
+// [routes]. If true is returned, the cost of the call is consumed from the
+// client's budgets. Otherwise, the duration after which the call would be
+// allowed is returned. If the call can never be allowed, the returned duration
+// is 0.
+//
+// [methods] is empty if the request isn't a JSON-RPC request.
+func (l *rateLimiter) Allow(client string, routes []string, methods []string, now time.Time) (bool, time.Duration) {
+	routeBudget, routeLimit := l.routeLimit(routes)
+	if len(methods) == 0 {
+		// Requests that don't call a JSON-RPC method, such as websocket
+		// upgrades, are charged a single token.
+		methods = []string{""}
+	}
+
+	// Calls in the same batch are charged together.
+	var (
+		costs  = make(map[string]int)
+		limits = make(map[string]RateLimit)
+		labels = make([]prometheus.Labels, len(methods))
+		cost   = make([]int, len(methods))
+	)
+	for i, method := range methods {
+		budget, limit := routeBudget, routeLimit
+		methodLimit, ok := l.config.Methods[method]
+		if ok && methodLimit.Rate != 0 {
+			budget, limit = "method:"+method, methodLimit.RateLimit
+		}
+		if !ok {
+			// Method names are provided by the client, so only the
+			// configured methods are used as labels.
+			method = ""
+		}
+
+		cost[i] = methodLimit.cost()
+		costs[budget] += cost[i]
+		limits[budget] = limit
+		labels[i] = prometheus.Labels{
+			routeLabel:  routes[0],
+			methodLabel: method,
+		}
+	}
+
+	buckets := l.getBuckets(client, limits)
+	var (
+		reservations = make([]*rate.Reservation, 0, len(costs))
+		allowed      = true
+		retryAfter   time.Duration
+	)
+	for budget, budgetCost := range costs {
+		reservation := buckets[budget].ReserveN(now, budgetCost)
+		if !reservation.OK() {
+			allowed = false
+			retryAfter = 0
+			break
+		}
+		reservations = append(reservations, reservation)
+		if delay := reservation.DelayFrom(now); delay > 0 {
+			allowed = false
+			retryAfter = max(retryAfter, delay)
+		}
+	}
+
+	counter := l.allowedCost
+	if !allowed {
+		// Return the tokens of the rejected calls.
+		for _, reservation := range reservations {
+			reservation.CancelAt(now)
+		}
+		counter = l.limitedCost
+		l.limitedReqs.WithLabelValues(routes[0]).Inc()
+	}
+	for i := range methods {
+		counter.With(labels[i]).Add(float64(cost[i]))
+	}
+	return allowed, retryAfter
+}
+
+// Exhausted returns true if [client] can't afford any call to the route named
+// [routes], along with the duration after which it could. Unlike [Allow], it
+// doesn't need the methods of the request, so it can reject requests before
+// their bodies are parsed.
+func (l *rateLimiter) Exhausted(client string, routes []string, now time.Time) (bool, time.Duration) {
+	routeBudget, routeLimit := l.routeLimit(routes)
+	limits := map[string]RateLimit{
+		routeBudget: routeLimit,
+	}
+	for method, methodLimit := range l.config.Methods {
+		if methodLimit.Rate != 0 {
+			limits["method:"+method] = methodLimit.RateLimit
+		}
+	}
+
+	var retryAfter time.Duration
+	for budget, bucket := range l.getBuckets(client, limits) {
+		tokens := bucket.TokensAt(now)
+		if tokens >= defaultMethodCost {
+			return false, 0
+		}
+
+		seconds := (defaultMethodCost - tokens) / limits[budget].Rate
+		delay := time.Duration(seconds * float64(time.Second))
+		if retryAfter == 0 || delay < retryAfter {
+			retryAfter = delay
+		}
+	}
+	return true, retryAfter
+}
+
+// routeLimit returns the budget, and its limit, that calls to the route named
+// [routes] are charged to.
+func (l *rateLimiter) routeLimit(routes []string) (string, RateLimit) {
+	for _, pattern := range l.routePatterns {
+		for _, route := range routes {
+			if matched, _ := path.Match(pattern, route); matched {
+				return "route:" + pattern, l.config.Routes[pattern]
+			}
+		}
+	}
+	return "", l.config.defaultLimit()
+}
+
+// sortedRoutePatterns returns the patterns of [routes], sorted from the most
+// to the least specific.
+func sortedRoutePatterns(routes map[string]RateLimit) []string {
+	patterns := make([]string, 0, len(routes))
+	for pattern := range routes {
+		patterns = append(patterns, pattern)
+	}
+	slices.SortFunc(patterns, func(a, b string) int {
+		aWildcard := strings.ContainsAny(a, patternWildcards)
+		bWildcard := strings.ContainsAny(b, patternWildcards)
+		switch {
+		case aWildcard != bWildcard:
+			if aWildcard {
+				return 1
+			}
+			return -1
+		case len(a) != len(b):
+			return len(b) - len(a)
+		default:
+			return strings.Compare(a, b)
+		}
+	})
+	return patterns
+}
+
+// getBuckets returns the token buckets of [client] for each of [limits],
+// creating them if needed.
+func (l *rateLimiter) getBuckets(client string, limits map[string]RateLimit) map[string]*rate.Limiter {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	clientBuckets, ok := l.clients.Get(client)
+	if !ok {
+		clientBuckets = make(map[string]*rate.Limiter)
+		l.clients.Put(client, clientBuckets)
+	}
+
+	// The returned buckets are copied so that they can be used after the lock
+	// is released.
+	buckets := make(map[string]*rate.Limiter, len(limits))
+	for budget, limit := range limits {
+		bucket, ok := clientBuckets[budget]
+		if !ok {
+			bucket = rate.NewLimiter(rate.Limit(limit.Rate), limit.Burst)
+			clientBuckets[budget] = bucket
+		}
+		buckets[budget] = bucket
+	}
+	return buckets
+}
+
+// clientIP returns the address that identifies the client at [remoteAddr].
+func clientIP(remoteAddr string) string {
+	host, _, err := net.SplitHostPort(remoteAddr)
+	if err != nil {
+		host = remoteAddr
+	}
+	ip := net.ParseIP(host)
+	if ip == nil {
+		return host
+	}
+	if ip.To4() != nil {
+		return ip.String()
+	}
+	return ip.Mask(net.CIDRMask(ipv6PrefixLen, 8*net.IPv6len)).String()
+}
@@ -0,0 +1,451 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package server
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/require"
+)
+
+var testRateLimitConfig = RateLimitConfig{
+	Rate:       1,
+	Burst:      10,
+	MaxClients: 2,
+	RateLimitRules: RateLimitRules{
+		Routes: map[string]RateLimit{
+			"/ext/bc/X": {
+				Rate:  1,
+				Burst: 20,
+			},
+		},
+		Methods: map[string]MethodRateLimit{
+			"avm.getUTXOs": {
+				Cost: 5,
+			},
+			"platform.getCurrentValidators": {
+				Cost: 2,
+				RateLimit: RateLimit{
+					Rate:  1,
+					Burst: 4,
+				},
+			},
+		},
+	},
+}
+
+func newTestRateLimiter(t *testing.T) *rateLimiter {
+	require := require.New(t)
+
+	require.NoError(testRateLimitConfig.Verify())
+	l, err := newRateLimiter("", prometheus.NewRegistry(), testRateLimitConfig)
+	require.NoError(err)
+	require.NotNil(l)
+	return l
+}
+
+func TestRateLimitConfigVerify(t *testing.T) {
+	tests := []struct {
+		name        string
+		config      RateLimitConfig
+		expectedErr error
+	}{
+		{
+			name:   "disabled",
+			config: RateLimitConfig{},
+		},
+		{
+			name:   "valid",
+			config: testRateLimitConfig,
+		},
+		{
+			name: "negative rate",
+			config: RateLimitConfig{
+				Rate: -1,
+			},
+			expectedErr: errInvalidRate,
+		},
+		{
+			name: "zero burst",
+			config: RateLimitConfig{
+				Rate:       1,
+				MaxClients: 1,
+			},
+			expectedErr: errInvalidBurst,
+		},
+		{
+			name: "zero max clients",
+			config: RateLimitConfig{
+				Rate:  1,
+				Burst: 1,
+			},
+			expectedErr: errInvalidMaxClients,
+		},
+		{
+			name: "invalid route pattern",
+			config: RateLimitConfig{
+				Rate:       1,
+				Burst:      1,
+				MaxClients: 1,
+				RateLimitRules: RateLimitRules{
+					Routes: map[string]RateLimit{
+						"/ext/[": {Rate: 1, Burst: 1},
+					},
+				},
+			},
+			expectedErr: errInvalidRoute,
+		},
+		{
+			name: "negative cost",
+			config: RateLimitConfig{
+				Rate:       1,
+				Burst:      1,
+				MaxClients: 1,
+				RateLimitRules: RateLimitRules{
+					Methods: map[string]MethodRateLimit{
+						"avm.getUTXOs": {Cost: -1},
+					},
+				},
+			},
+			expectedErr: errInvalidCost,
+		},
+		{
+			name: "cost exceeds route burst",
+			config: RateLimitConfig{
+				Rate:       1,
+				Burst:      10,
+				MaxClients: 1,
+				RateLimitRules: RateLimitRules{
+					Routes: map[string]RateLimit{
+						"/ext/bc/X": {Rate: 1, Burst: 4},
+					},
+					Methods: map[string]MethodRateLimit{
+						"avm.getUTXOs": {Cost: 5},
+					},
+				},
+			},
+			expectedErr: errCostExceedsBurst,
+		},
+		{
+			name: "cost exceeds method burst",
+			config: RateLimitConfig{
+				Rate:       1,
+				Burst:      10,
+				MaxClients: 1,
+				RateLimitRules: RateLimitRules{
+					Methods: map[string]MethodRateLimit{
+						"avm.getUTXOs": {
+							Cost:      5,
+							RateLimit: RateLimit{Rate: 1, Burst: 4},
+						},
+					},
+				},
+			},
+			expectedErr: errCostExceedsBurst,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require.ErrorIs(t, test.config.Verify(), test.expectedErr)
+		})
+	}
+}
+
+func TestRateLimiterCosts(t *testing.T) {
+	require := require.New(t)
+
+	var (
+		l       = newTestRateLimiter(t)
+		now     = time.Now()
+		infoAPI = []string{"/ext/info"}
+		xChain  = []string{"/ext/bc/chainID", "/ext/bc/X"}
+	)
+
+	// The default budget allows 2 calls that cost 5.
+	for i := 0; i < 2; i++ {
+		allowed, _ := l.Allow("a", infoAPI, []string{"avm.getUTXOs"}, now)
+		require.True(allowed)
+	}
+	allowed, retryAfter := l.Allow("a", infoAPI, []string{"avm.getUTXOs"}, now)
+	require.False(allowed)
+	require.Equal(5*time.Second, retryAfter)
+
+	// Clients and routes have separate budgets.
+	allowed, _ = l.Allow("b", infoAPI, []string{"avm.getUTXOs"}, now)
+	require.True(allowed)
+	allowed, _ = l.Allow("a", xChain, []string{"avm.getUTXOs"}, now)
+	require.True(allowed)
+
+	// Methods with their own limit have separate budgets.
+	for i := 0; i < 2; i++ {
+		allowed, _ = l.Allow("a", infoAPI, []string{"platform.getCurrentValidators"}, now)
+		require.True(allowed)
+	}
+	allowed, retryAfter = l.Allow("a", infoAPI, []string{"platform.getCurrentValidators"}, now)
+	require.False(allowed)
+	require.Equal(2*time.Second, retryAfter)
+
+	// The budget replenishes over time.
+	now = now.Add(5 * time.Second)
+	allowed, _ = l.Allow("a", infoAPI, []string{"avm.getUTXOs"}, now)
+	require.True(allowed)
+
+	require.Equal(
+		float64(20),
+		testutil.ToFloat64(l.allowedCost.WithLabelValues("/ext/info", "avm.getUTXOs")),
+	)
+	require.Equal(
+		float64(5),
+		testutil.ToFloat64(l.limitedCost.WithLabelValues("/ext/info", "avm.getUTXOs")),
+	)
+	require.Equal(
+		float64(2),
+		testutil.ToFloat64(l.limitedReqs.WithLabelValues("/ext/info")),
+	)
+}
+
+func TestRateLimiterBatch(t *testing.T) {
+	require := require.New(t)
+
+	var (
+		l       = newTestRateLimiter(t)
+		now     = time.Now()
+		infoAPI = []string{"/ext/info"}
+	)
+
+	// The batch fits in the default budget, but not in the budget of
+	// platform.getCurrentValidators.
+	batch := []string{
+		"avm.getUTXOs",
+		"platform.getCurrentValidators",
+		"platform.getCurrentValidators",
+		"platform.getCurrentValidators",
+	}
+	allowed, _ := l.Allow("a", infoAPI, batch, now)
+	require.False(allowed)
+
+	// The rejected batch doesn't consume tokens.
+	allowed, _ = l.Allow("a", infoAPI, []string{"avm.getUTXOs", "avm.getUTXOs"}, now)
+	require.True(allowed)
+
+	// A batch that exceeds the burst can never be allowed.
+	allowed, retryAfter := l.Allow("b", infoAPI, []string{"avm.getUTXOs", "avm.getUTXOs", "info.peers"}, now)
+	require.False(allowed)
+	require.Zero(retryAfter)
+}
+
+func TestRateLimiterRouteSpecificity(t *testing.T) {
+	require := require.New(t)
+
+	routes := map[string]RateLimit{
+		"/ext/bc/?":  {Rate: 1, Burst: 1},
+		"/ext/*":     {Rate: 1, Burst: 1},
+		"/ext/bc/X":  {Rate: 1, Burst: 1},
+		"/ext/bc/*":  {Rate: 1, Burst: 1},
+		"/ext/bc/XY": {Rate: 1, Burst: 1},
+	}
+	require.Equal(
+		[]string{"/ext/bc/XY", "/ext/bc/X", "/ext/bc/*", "/ext/bc/?", "/ext/*"},
+		sortedRoutePatterns(routes),
+	)
+
+	l := &rateLimiter{
+		config: RateLimitConfig{
+			RateLimitRules: RateLimitRules{
+				Routes: routes,
+			},
+		},
+		routePatterns: sortedRoutePatterns(routes),
+	}
+	budget, _ := l.routeLimit([]string{"/ext/bc/chainID", "/ext/bc/X"})
+	require.Equal("route:/ext/bc/X", budget)
+	budget, _ = l.routeLimit([]string{"/ext/bc/chainID"})
+	require.Equal("route:/ext/bc/*", budget)
+	budget, _ = l.routeLimit([]string{"/ext/info"})
+	require.Equal("route:/ext/*", budget)
+}
+
+func TestRateLimiterExhausted(t *testing.T) {
+	require := require.New(t)
+
+	var (
+		l       = newTestRateLimiter(t)
+		now     = time.Now()
+		infoAPI = []string{"/ext/info"}
+	)
+
+	allowed, _ := l.Allow("a", infoAPI, []string{"avm.getUTXOs", "avm.getUTXOs"}, now)
+	require.True(allowed)
+
+	// The client can still call the methods with their own budget.
+	exhausted, _ := l.Exhausted("a", infoAPI, now)
+	require.False(exhausted)
+
+	allowed, _ = l.Allow("a", infoAPI, []string{"platform.getCurrentValidators", "platform.getCurrentValidators"}, now)
+	require.True(allowed)
+	exhausted, retryAfter := l.Exhausted("a", infoAPI, now)
+	require.True(exhausted)
+	require.Equal(time.Second, retryAfter)
+
+	exhausted, _ = l.Exhausted("a", infoAPI, now.Add(time.Second))
+	require.False(exhausted)
+	exhausted, _ = l.Exhausted("b", infoAPI, now)
+	require.False(exhausted)
+}
+
+func TestRateLimiterEvictsClients(t *testing.T) {
+	require := require.New(t)
+
+	var (
+		l       = newTestRateLimiter(t)
+		now     = time.Now()
+		infoAPI = []string{"/ext/info"}
+	)
+
+	allowed, _ := l.Allow("a", infoAPI, []string{"avm.getUTXOs", "avm.getUTXOs"}, now)
+	require.True(allowed)
+	allowed, _ = l.Allow("a", infoAPI, nil, now)
+	require.False(allowed)
+
+	for _, client := range []string{"b", "c"} {
+		allowed, _ = l.Allow(client, infoAPI, nil, now)
+		require.True(allowed)
+	}
+	require.Equal(2, l.clients.Len())
+
+	// The budget of the least recently seen client was forgotten.
+	allowed, _ = l.Allow("a", infoAPI, nil, now)
+	require.True(allowed)
+}
+
+func TestClientIP(t *testing.T) {
+	tests := []struct {
+		remoteAddr string
+		expectedIP string
+	}{
+		{
+			remoteAddr: "1.2.3.4:5678",
+			expectedIP: "1.2.3.4",
+		},
+		{
+			remoteAddr: "[2001:db8:1:2:3:4:5:6]:5678",
+			expectedIP: "2001:db8:1:2::",
+		},
+		{
+			remoteAddr: "[::ffff:1.2.3.4]:5678",
+			expectedIP: "1.2.3.4",
+		},
+		{
+			remoteAddr: "invalid",
+			expectedIP: "invalid",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.remoteAddr, func(t *testing.T) {
+			require.Equal(t, test.expectedIP, clientIP(test.remoteAddr))
+		})
+	}
+}
+
+func TestRouterRateLimiting(t *testing.T) {
+	r := newRouter(nil, newTestRateLimiter(t))
+	require.NoError(t, r.AddRouter("/ext/info", "", &testHandler{}))
+
+	tests := []struct {
+		name               string
+		remoteAddr         string
+		body               string
+		expectedStatus     int
+		expectedRetryAfter string
+		expectedBody       string
+	}{
+		{
+			name:           "allowed",
+			remoteAddr:     "1.2.3.4:1",
+			body:           `{"method":"avm.getUTXOs"}`,
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "allowed from another port",
+			remoteAddr:     "1.2.3.4:2",
+			body:           `{"method":"avm.getUTXOs"}`,
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:               "rate limited json-rpc",
+			remoteAddr:         "1.2.3.4:3",
+			body:               `{"method":"avm.getUTXOs"}`,
+			expectedStatus:     http.StatusTooManyRequests,
+			expectedRetryAfter: "5",
+			expectedBody:       string(rateLimitedResponse),
+		},
+		{
+			name:               "rate limited",
+			remoteAddr:         "1.2.3.4:4",
+			expectedStatus:     http.StatusTooManyRequests,
+			expectedRetryAfter: "1",
+			expectedBody:       errRateLimited.Error() + "\n",
+		},
+		{
+			name:           "other client",
+			remoteAddr:     "5.6.7.8:1",
+			expectedStatus: http.StatusOK,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require := require.New(t)
+
+			req := httptest.NewRequest(http.MethodPost, "/ext/info", strings.NewReader(test.body))
+			req.RemoteAddr = test.remoteAddr
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, req)
+
+			require.Equal(test.expectedStatus, w.Code)
+			require.Equal(test.expectedRetryAfter, w.Header().Get("Retry-After"))
+			if test.expectedBody != "" {
+				require.Equal(test.expectedBody, w.Body.String())
+			}
+		})
+	}
+}
+
+// unreadableBody fails the test if the body of the request is read.
+type unreadableBody struct {
+	t *testing.T
+}
+
+func (b unreadableBody) Read([]byte) (int, error) {
+	require.FailNow(b.t, "body was read")
+	return 0, nil
+}
+
+func TestRouterRejectsExhaustedClientsBeforeParsing(t *testing.T) {
+	require := require.New(t)
+
+	l := newTestRateLimiter(t)
+	r := newRouter(nil, l)
+	require.NoError(r.AddRouter("/ext/info", "", &testHandler{}))
+
+	var (
+		now     = time.Now()
+		infoAPI = []string{"/ext/info"}
+	)
+	allowed, _ := l.Allow("ip:1.2.3.4", infoAPI, []string{"avm.getUTXOs", "avm.getUTXOs", "platform.getCurrentValidators", "platform.getCurrentValidators"}, now)
+	require.True(allowed)
+
+	req := httptest.NewRequest(http.MethodPost, "/ext/info", unreadableBody{t: t})
+	req.RemoteAddr = "1.2.3.4:1"
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	require.Equal(http.StatusTooManyRequests, w.Code)
+	require.Equal("1", w.Header().Get("Retry-After"))
+}
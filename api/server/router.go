@@ -6,36 +6,70 @@ package server
 import (
 	"errors"
 	"fmt"
+	"math"
 	"net/http"
+	"strconv"
 	"sync"
+	"time"
 
 	"github.com/gorilla/mux"
 
+	"github.com/ava-labs/avalanchego/api"
+	"github.com/ava-labs/avalanchego/api/auth"
 	"github.com/ava-labs/avalanchego/utils/set"
 )
 
+const rateLimitedCode = -32005
+
 var (
+	// rateLimitedResponse is the JSON-RPC response to requests that exceed
+	// their client's rate limit. The ID of the request isn't echoed, as the
+	// request isn't parsed beyond its methods.
+	rateLimitedResponse = []byte(fmt.Sprintf(
+		`{"jsonrpc":"2.0","error":{"code":%d,"message":%q},"id":null}`,
+		rateLimitedCode,
+		errRateLimited,
+	))
+
+	errRateLimited     = errors.New("rate limit exceeded")
 	errUnknownBaseURL  = errors.New("unknown base url")
 	errUnknownEndpoint = errors.New("unknown endpoint")
 	errAlreadyReserved = errors.New("route is either already aliased or already maps to a handle")
 )
 
+type routeKey struct {
+	base     string
+	endpoint string
+}
+
 type router struct {
 	lock   sync.RWMutex
 	router *mux.Router
 
+	// If non-nil, requests must be authorized before they are dispatched.
+	auth *auth.Auth
+	// If non-nil, requests must be within their client's rate limit before
+	// they are dispatched.
+	rateLimiter *rateLimiter
+
 	routeLock      sync.Mutex
 	reservedRoutes set.Set[string]                    // Reserves routes so that there can't be alias that conflict
 	aliases        map[string][]string                // Maps a route to a set of reserved routes
+	aliasOf        map[string]string                  // Maps a reserved route to the route it aliases
 	routes         map[string]map[string]http.Handler // Maps routes to a handler
+	routeKeys      map[string]routeKey                // Maps the name of a route to its route and endpoint
 }
 
-func newRouter() *router {
+func newRouter(auth *auth.Auth, rateLimiter *rateLimiter) *router {
 	return &router{
 		router:         mux.NewRouter(),
+		auth:           auth,
+		rateLimiter:    rateLimiter,
 		reservedRoutes: set.Set[string]{},
 		aliases:        make(map[string][]string),
+		aliasOf:        make(map[string]string),
 		routes:         make(map[string]map[string]http.Handler),
+		routeKeys:      make(map[string]routeKey),
 	}
 }
 
@@ -43,9 +77,158 @@ func (r *router) ServeHTTP(writer http.ResponseWriter, request *http.Request) {
 	r.lock.RLock()
 	defer r.lock.RUnlock()
 
+	if r.auth != nil || r.rateLimiter != nil {
+		var ok bool
+		request, ok = r.admit(writer, request)
+		if !ok {
+			return
+		}
+	}
 	r.router.ServeHTTP(writer, request)
 }
 
+// admit returns true if [request] may be dispatched, along with the request to
+// dispatch, which carries the identity of its caller. Otherwise, the rejection
+// is written to [writer].
+//
+// Assumes [r.lock] is held.
+func (r *router) admit(writer http.ResponseWriter, request *http.Request) (*http.Request, bool) {
+	identity := auth.Anonymous
+	if r.auth != nil {
+		var err error
+		identity, err = r.auth.Authenticate(request)
+		if err != nil {
+			writer.Header().Set("WWW-Authenticate", "Bearer")
+			http.Error(writer, err.Error(), http.StatusUnauthorized)
+			return nil, false
+		}
+		request = request.WithContext(auth.WithIdentity(request.Context(), identity))
+	}
+
+	var match mux.RouteMatch
+	if !r.router.Match(request, &match) || match.Route == nil {
+		// The router rejects requests that don't match a route.
+		return request, true
+	}
+	routes := r.routeNames(match.Route.GetName())
+
+	if r.rateLimiter != nil && !r.allow(writer, request, identity, routes) {
+		return nil, false
+	}
+	if r.auth == nil {
+		return request, true
+	}
+
+	err := r.auth.Authorize(identity, routes, writer, request)
+	switch {
+	case errors.Is(err, api.ErrRequestTooLarge):
+		http.Error(writer, err.Error(), http.StatusRequestEntityTooLarge)
+		return nil, false
+	case errors.Is(err, auth.ErrUnauthenticated):
+		writer.Header().Set("WWW-Authenticate", "Bearer")
+		http.Error(writer, err.Error(), http.StatusUnauthorized)
+		return nil, false
+	case err != nil:
+		http.Error(writer, err.Error(), http.StatusForbidden)
+		return nil, false
+	default:
+		return request, true
+	}
+}
+
+// allow returns true if [request] is within the rate limit of its client.
+// Otherwise, the rejection is written to [writer].
+//
+// Authenticated clients are identified by their identity, and anonymous
+// clients by their IP.
+func (r *router) allow(writer http.ResponseWriter, request *http.Request, identity string, routes []string) bool {
+	client := "ip:" + clientIP(request.RemoteAddr)
+	if identity != auth.Anonymous {
+		client = "identity:" + identity
+	}
+
+	// Clients that can't afford any call are rejected before the body of
+	// their request is parsed.
+	now := time.Now()
+	if exhausted, retryAfter := r.rateLimiter.Exhausted(client, routes, now); exhausted {
+		writeRetryAfter(writer, retryAfter)
+		http.Error(writer, errRateLimited.Error(), http.StatusTooManyRequests)
+		return false
+	}
+
+	var methods []string
+	if len(r.rateLimiter.config.Methods) > 0 {
+		// Requests whose methods can't be determined are charged as if they
+		// don't call any method.
+		var err error
+		methods, err = api.RequestMethods(writer, request)
+		if errors.Is(err, api.ErrRequestTooLarge) {
+			http.Error(writer, err.Error(), http.StatusRequestEntityTooLarge)
+			return false
+		}
+	}
+
+	allowed, retryAfter := r.rateLimiter.Allow(client, routes, methods, now)
+	if allowed {
+		return true
+	}
+
+	writeRetryAfter(writer, retryAfter)
+	if len(methods) == 0 {
+		http.Error(writer, errRateLimited.Error(), http.StatusTooManyRequests)
+		return false
+	}
+
+	// JSON-RPC clients are also sent a JSON-RPC error so that they can
+	// handle the rejection like any other error.
+	writer.Header().Set("Content-Type", "application/json")
+	writer.WriteHeader(http.StatusTooManyRequests)
+	_, _ = writer.Write(rateLimitedResponse)
+	return false
+}
+
+// writeRetryAfter tells the client to retry after [retryAfter], if the request
+// can be retried.
+func writeRetryAfter(writer http.ResponseWriter, retryAfter time.Duration) {
+	if retryAfter > 0 {
+		seconds := int64(math.Ceil(retryAfter.Seconds()))
+		writer.Header().Set("Retry-After", strconv.FormatInt(seconds, 10))
+	}
+}
+
+// routeNames returns the names of the route named [name], including all of its
+// aliases.
+//
+// Assumes [r.lock] is held.
+func (r *router) routeNames(name string) []string {
+	key, ok := r.routeKeys[name]
+	if !ok {
+		return []string{name}
+	}
+
+	base := key.base
+	for {
+		aliased, ok := r.aliasOf[base]
+		if !ok {
+			break
+		}
+		base = aliased
+	}
+
+	var (
+		names = []string{base + key.endpoint}
+		bases = []string{base}
+	)
+	for len(bases) > 0 {
+		base, bases = bases[0], bases[1:]
+		for _, alias := range r.aliases[base] {
+			names = append(names, alias+key.endpoint)
+			bases = append(bases, alias)
+		}
+	}
+	return names
+}
+
 func (r *router) GetHandler(base, endpoint string) (http.Handler, error) {
 	r.routeLock.Lock()
 	defer r.routeLock.Unlock()
@@ -90,6 +273,10 @@ func (r *router) forceAddRouter(base, endpoint string, handler http.Handler) err
 
 	endpoints[endpoint] = handler
 	r.routes[base] = endpoints
+	r.routeKeys[url] = routeKey{
+		base:     base,
+		endpoint: endpoint,
+	}
 
 	// Name routes based on their URL for easy retrieval in the future
 	route := r.router.Handle(url, handler)
@@ -123,6 +310,7 @@ func (r *router) AddAlias(base string, aliases ...string) error {
 
 	for _, alias := range aliases {
 		r.reservedRoutes.Add(alias)
+		r.aliasOf[alias] = base
 	}
 
 	r.aliases[base] = append(r.aliases[base], aliases...)
@@ -5,21 +5,30 @@ package server
 
 import (
 	"net/http"
+	"net/http/httptest"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/api"
+	"github.com/ava-labs/avalanchego/api/auth"
 )
 
-type testHandler struct{ called bool }
+type testHandler struct {
+	called   bool
+	identity string
+}
 
-func (t *testHandler) ServeHTTP(_ http.ResponseWriter, _ *http.Request) {
+func (t *testHandler) ServeHTTP(_ http.ResponseWriter, r *http.Request) {
 	t.called = true
+	t.identity = auth.Identity(r.Context())
 }
 
 func TestAliasing(t *testing.T) {
 	require := require.New(t)
 
-	r := newRouter()
+	r := newRouter(nil, nil)
 
 	require.NoError(r.AddAlias("1", "2", "3"))
 	require.NoError(r.AddAlias("1", "4"))
@@ -50,7 +59,7 @@ func TestAliasing(t *testing.T) {
 
 func TestBlock(t *testing.T) {
 	require := require.New(t)
-	r := newRouter()
+	r := newRouter(nil, nil)
 
 	require.NoError(r.AddAlias("1", "1"))
 
@@ -58,3 +67,111 @@ func TestBlock(t *testing.T) {
 	err := r.AddRouter("1", "", handler1)
 	require.ErrorIs(err, errAlreadyReserved)
 }
+
+type headerAuthenticator struct{}
+
+func (headerAuthenticator) Authenticate(r *http.Request) (string, error) {
+	identity := r.Header.Get("Identity")
+	if identity == "" {
+		return "", auth.ErrNoCredentials
+	}
+	return identity, nil
+}
+
+func TestAuthorization(t *testing.T) {
+	policy, err := auth.ParsePolicy([]byte(`{
+		"identities": {
+			"explorer": [
+				{"routes": ["/ext/bc/X"], "methods": ["avm.get*"]}
+			]
+		},
+		"anonymous": [
+			{"routes": ["/ext/health"]}
+		]
+	}`))
+	require.NoError(t, err)
+
+	r := newRouter(auth.NewWithAuthenticators(policy, headerAuthenticator{}), nil)
+	handler := &testHandler{}
+	require.NoError(t, r.AddRouter("/ext/bc/chainID", "", handler))
+	require.NoError(t, r.AddRouter("/ext/bc/chainID", "/wallet", &testHandler{}))
+	require.NoError(t, r.AddAlias("/ext/bc/chainID", "/ext/bc/X"))
+	require.NoError(t, r.AddRouter("/ext/health", "", &testHandler{}))
+
+	tests := []struct {
+		name           string
+		path           string
+		identity       string
+		body           string
+		expectedStatus int
+	}{
+		{
+			name:           "anonymous allowed",
+			path:           "/ext/health",
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "anonymous denied",
+			path:           "/ext/bc/X",
+			body:           `{"method":"avm.getTx"}`,
+			expectedStatus: http.StatusUnauthorized,
+		},
+		{
+			name:           "allowed by alias",
+			path:           "/ext/bc/X",
+			identity:       "explorer",
+			body:           `{"method":"avm.getTx"}`,
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "allowed by aliased route",
+			path:           "/ext/bc/chainID",
+			identity:       "explorer",
+			body:           `{"method":"avm.getTx"}`,
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "denied method",
+			path:           "/ext/bc/X",
+			identity:       "explorer",
+			body:           `{"method":"avm.issueTx"}`,
+			expectedStatus: http.StatusForbidden,
+		},
+		{
+			name:           "denied endpoint",
+			path:           "/ext/bc/X/wallet",
+			identity:       "explorer",
+			body:           `{"method":"avm.getTx"}`,
+			expectedStatus: http.StatusForbidden,
+		},
+		{
+			name:           "request too large",
+			path:           "/ext/bc/X",
+			identity:       "explorer",
+			body:           `{"method":"avm.getTx","params":"` + strings.Repeat("a", api.MaxRequestSize) + `"}`,
+			expectedStatus: http.StatusRequestEntityTooLarge,
+		},
+		{
+			name:           "unknown route",
+			path:           "/ext/admin",
+			identity:       "explorer",
+			expectedStatus: http.StatusNotFound,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			*handler = testHandler{}
+			req := httptest.NewRequest(http.MethodPost, test.path, strings.NewReader(test.body))
+			if test.identity != "" {
+				req.Header.Set("Identity", test.identity)
+			}
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, req)
+			require.Equal(t, test.expectedStatus, w.Code)
+			if handler.called {
+				// The handler must be told who the caller is.
+				require.Equal(t, test.identity, handler.identity)
+			}
+		})
+	}
+}
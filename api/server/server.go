@@ -19,6 +19,7 @@ import (
 	"golang.org/x/net/http2"
 
 	"github.com/ava-labs/avalanchego/api"
+	"github.com/ava-labs/avalanchego/api/auth"
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/snow"
 	"github.com/ava-labs/avalanchego/snow/engine/common"
@@ -74,6 +75,8 @@ type HTTPConfig struct {
 	ReadHeaderTimeout time.Duration `json:"readHeaderTimeout"`
 	WriteTimeout      time.Duration `json:"writeHeaderTimeout"`
 	IdleTimeout       time.Duration `json:"idleTimeout"`
+
+	RateLimitConfig RateLimitConfig `json:"rateLimitConfig"`
 }
 
 type server struct {
@@ -112,13 +115,19 @@ func New(
 	registerer prometheus.Registerer,
 	httpConfig HTTPConfig,
 	allowedHosts []string,
+	auth *auth.Auth,
 ) (Server, error) {
 	m, err := newMetrics(namespace, registerer)
 	if err != nil {
 		return nil, err
 	}
 
-	router := newRouter()
+	rateLimiter, err := newRateLimiter(namespace, registerer, httpConfig.RateLimitConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	router := newRouter(auth, rateLimiter)
 	allowedHostsHandler := filterInvalidHosts(router, allowedHosts)
 	corsHandler := cors.New(cors.Options{
 		AllowedOrigins:   allowedOrigins,
@@ -29,6 +29,7 @@ import (
 	"github.com/ava-labs/avalanchego/network"
 	"github.com/ava-labs/avalanchego/proto/pb/p2p"
 	"github.com/ava-labs/avalanchego/snow"
+	"github.com/ava-labs/avalanchego/snow/consensus/snowball"
 	"github.com/ava-labs/avalanchego/snow/engine/avalanche/state"
 	"github.com/ava-labs/avalanchego/snow/engine/avalanche/vertex"
 	"github.com/ava-labs/avalanchego/snow/engine/common"
@@ -59,6 +60,7 @@ import (
 	"github.com/ava-labs/avalanchego/vms/platformvm/warp"
 	"github.com/ava-labs/avalanchego/vms/propertyfx"
 	"github.com/ava-labs/avalanchego/vms/proposervm"
+	"github.com/ava-labs/avalanchego/vms/proposervm/proposer"
 	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
 	"github.com/ava-labs/avalanchego/vms/tracedvm"
 
@@ -86,14 +88,19 @@ var (
 	VertexBootstrappingDBPrefix = []byte("vertex_bs")
 	TxBootstrappingDBPrefix     = []byte("tx_bs")
 	BlockBootstrappingDBPrefix  = []byte("block_bs")
+	BlockFetchingDBPrefix       = []byte("block_fetch")
 
 	// Bootstrapping prefixes for ChainVMs
 	ChainBootstrappingDBPrefix = []byte("bs")
+	ChainFetchingDBPrefix      = []byte("fetch")
 
 	errUnknownVMType           = errors.New("the vm should have type avalanche.DAGVM or snowman.ChainVM")
 	errCreatePlatformVM        = errors.New("attempted to create a chain running the PlatformVM")
 	errNotBootstrapped         = errors.New("subnets not bootstrapped")
 	errPartialSyncAsAValidator = errors.New("partial sync should not be configured for a validator")
+	errNotReloadable           = errors.New("vm can't be reloaded")
+	errNoSnowmanEngine         = errors.New("chain has no snowman engine")
+	errUnknownChain            = errors.New("unknown chain")
 
 	fxs = map[ids.ID]fx.Factory{
 		secp256k1fx.ID: &secp256k1fx.Factory{},
@@ -132,6 +139,30 @@ type Manager interface {
 	// Returns true iff the chain with the given ID exists and is finished bootstrapping
 	IsBootstrapped(ids.ID) bool
 
+	// Returns the progress of the most recent bootstrapping round of the chain
+	// [chainID].
+	BootstrapProgress(chainID ids.ID) (snow.BootstrapProgress, error)
+
+	// Replaces the instances of the VM [vmID] that run chains with instances
+	// created by [factory]. Each chain's handler is drained while its VM is
+	// replaced, and the chain resumes from its last accepted block. If a chain
+	// fails to resume, the chains are reloaded with [fallback].
+	ReloadVM(ctx context.Context, vmID ids.ID, factory vms.Factory, fallback vms.Factory) error
+
+	// Returns the snowball parameters of the snowman engine of the chain
+	// [chainID].
+	ConsensusParameters(chainID ids.ID) (smeng.ParametersStatus, error)
+
+	// Schedules [params] to replace the snowball parameters of the snowman
+	// engine of the chain [chainID]. The parameters are applied once the next
+	// poll finishes. They are not persisted across restarts.
+	SetConsensusParameters(chainID ids.ID, params snowball.Parameters) (smeng.ParametersStatus, error)
+
+	// Cancels the pending change of the snowball parameters of the snowman
+	// engine of the chain [chainID]. If no change is pending, the parameters
+	// that were active before the last change are restored.
+	RollbackConsensusParameters(chainID ids.ID) (smeng.ParametersStatus, error)
+
 	// Starts the chain creator with the initial platform chain parameters, must
 	// be called once.
 	StartChainCreator(platformChain ChainParameters) error
@@ -160,6 +191,23 @@ type chain struct {
 	Context *snow.ConsensusContext
 	VM      common.VM
 	Handler handler.Handler
+	// Instance is the VM as created by the VM's factory, before it was
+	// wrapped.
+	Instance interface{}
+	// Engine is the snowman engine of the chain
+	Engine *smeng.Transitive
+}
+
+// reloadableVM is a VM instance that can be replaced while its chain runs.
+type reloadableVM interface {
+	Reload(ctx context.Context, factory vms.Factory) error
+}
+
+// chainVM is the instance of a VM, as created by the VM's factory, that runs
+// a chain.
+type chainVM struct {
+	vmID     ids.ID
+	instance interface{}
 }
 
 // ChainConfig is configuration settings for the current execution.
@@ -174,7 +222,7 @@ type ManagerConfig struct {
 	SybilProtectionEnabled bool
 	StakingTLSSigner       crypto.Signer
 	StakingTLSCert         *staking.Certificate
-	StakingBLSKey          *bls.SecretKey
+	StakingBLSKey          bls.Signer
 	TracingEnabled         bool
 	// Must not be used unless [TracingEnabled] is true as this may be nil.
 	Tracer                    trace.Tracer
@@ -255,6 +303,12 @@ type manager struct {
 	// Key: Chain's ID
 	// Value: The chain
 	chains map[ids.ID]handler.Handler
+	// Key: Chain's ID
+	// Value: The VM instance running the chain
+	chainVMs map[ids.ID]chainVM
+	// Key: Chain's ID
+	// Value: The snowman engine running the chain
+	engines map[ids.ID]*smeng.Transitive
 
 	// snowman++ related interface to allow validators retrieval
 	validatorState validators.State
@@ -266,6 +320,8 @@ func New(config *ManagerConfig) Manager {
 		Aliaser:                ids.NewAliaser(),
 		ManagerConfig:          *config,
 		chains:                 make(map[ids.ID]handler.Handler),
+		chainVMs:               make(map[ids.ID]chainVM),
+		engines:                make(map[ids.ID]*smeng.Transitive),
 		chainsQueue:            buffer.NewUnboundedBlockingDeque[ChainParameters](initialQueueSize),
 		unblockChainCreatorCh:  make(chan struct{}),
 		chainCreatorShutdownCh: make(chan struct{}),
@@ -362,6 +418,11 @@ func (m *manager) createChain(chainParams ChainParameters) {
 
 	m.chainsLock.Lock()
 	m.chains[chainParams.ID] = chain.Handler
+	m.chainVMs[chainParams.ID] = chainVM{
+		vmID:     chainParams.VMID,
+		instance: chain.Instance,
+	}
+	m.engines[chainParams.ID] = chain.Engine
 	m.chainsLock.Unlock()
 
 	// Associate the newly created chain with its default alias
@@ -445,7 +506,7 @@ func (m *manager) buildChain(chainParams ChainParameters, sb subnets.Subnet) (*c
 			SubnetID:  chainParams.SubnetID,
 			ChainID:   chainParams.ID,
 			NodeID:    m.NodeID,
-			PublicKey: bls.PublicFromSecretKey(m.StakingBLSKey),
+			PublicKey: m.StakingBLSKey.PublicKey(),
 
 			XChainID:    m.XChainID,
 			CChainID:    m.CChainID,
@@ -536,6 +597,7 @@ func (m *manager) buildChain(chainParams ChainParameters, sb subnets.Subnet) (*c
 		return nil, err
 	}
 
+	chain.Instance = vm
 	return chain, nil
 }
 
@@ -570,6 +632,7 @@ func (m *manager) createAvalancheChain(
 	vertexBootstrappingDB := prefixdb.New(VertexBootstrappingDBPrefix, prefixDB)
 	txBootstrappingDB := prefixdb.New(TxBootstrappingDBPrefix, prefixDB)
 	blockBootstrappingDB := prefixdb.New(BlockBootstrappingDBPrefix, prefixDB)
+	blockFetchingDB := prefixdb.New(BlockFetchingDBPrefix, prefixDB)
 
 	vtxBlocker, err := queue.NewWithMissing(vertexBootstrappingDB, "vtx", ctx.AvalancheRegisterer)
 	if err != nil {
@@ -688,16 +751,23 @@ func (m *manager) createAvalancheChain(
 	var (
 		minBlockDelay       = proposervm.DefaultMinBlockDelay
 		numHistoricalBlocks = proposervm.DefaultNumHistoricalBlocks
+		proposerSelection   proposer.PolicyConfig
 	)
 	if subnetCfg, ok := m.SubnetConfigs[ctx.SubnetID]; ok {
 		minBlockDelay = subnetCfg.ProposerMinBlockDelay
 		numHistoricalBlocks = subnetCfg.ProposerNumHistoricalBlocks
+		proposerSelection = subnetCfg.ProposerSelection
+	}
+	proposerPolicy, err := proposerSelection.NewPolicy()
+	if err != nil {
+		return nil, fmt.Errorf("error while creating proposer policy: %w", err)
 	}
 	m.Log.Info("creating proposervm wrapper",
 		zap.Time("activationTime", m.ApricotPhase4Time),
 		zap.Uint64("minPChainHeight", m.ApricotPhase4MinPChainHeight),
 		zap.Duration("minBlockDelay", minBlockDelay),
 		zap.Uint64("numHistoricalBlocks", numHistoricalBlocks),
+		zap.Reflect("proposerSelection", proposerSelection),
 	)
 
 	chainAlias := m.PrimaryAliasOrDefault(ctx.ChainID)
@@ -722,6 +792,7 @@ func (m *manager) createAvalancheChain(
 			NumHistoricalBlocks: numHistoricalBlocks,
 			StakingLeafSigner:   m.StakingTLSSigner,
 			StakingCertLeaf:     m.StakingTLSCert,
+			ProposerPolicy:      proposerPolicy,
 		},
 	)
 
@@ -816,12 +887,13 @@ func (m *manager) createAvalancheChain(
 		Params:              consensusParams,
 		Consensus:           snowmanConsensus,
 	}
-	var snowmanEngine common.Engine
-	snowmanEngine, err = smeng.New(snowmanEngineConfig)
+	transitive, err := smeng.New(snowmanEngineConfig)
 	if err != nil {
 		return nil, fmt.Errorf("error initializing snowman engine: %w", err)
 	}
 
+	var snowmanEngine common.Engine = transitive
+
 	if m.TracingEnabled {
 		snowmanEngine = common.TraceEngine(snowmanEngine, m.Tracer)
 	}
@@ -838,6 +910,7 @@ func (m *manager) createAvalancheChain(
 		Timer:                          h,
 		AncestorsMaxContainersReceived: m.BootstrapAncestorsMaxContainersReceived,
 		Blocked:                        blockBlocker,
+		DB:                             blockFetchingDB,
 		VM:                             vmWrappingProposerVM,
 	}
 	var snowmanBootstrapper common.BootstrapableEngine
@@ -923,6 +996,7 @@ func (m *manager) createAvalancheChain(
 		Context: ctx,
 		VM:      dagVM,
 		Handler: h,
+		Engine:  transitive,
 	}, nil
 }
 
@@ -951,6 +1025,7 @@ func (m *manager) createSnowmanChain(
 	prefixDB := prefixdb.New(ctx.ChainID[:], meterDB)
 	vmDB := prefixdb.New(VMDBPrefix, prefixDB)
 	bootstrappingDB := prefixdb.New(ChainBootstrappingDBPrefix, prefixDB)
+	fetchingDB := prefixdb.New(ChainFetchingDBPrefix, prefixDB)
 
 	blocked, err := queue.NewWithMissing(bootstrappingDB, "block", ctx.Registerer)
 	if err != nil {
@@ -1032,16 +1107,23 @@ func (m *manager) createSnowmanChain(
 	var (
 		minBlockDelay       = proposervm.DefaultMinBlockDelay
 		numHistoricalBlocks = proposervm.DefaultNumHistoricalBlocks
+		proposerSelection   proposer.PolicyConfig
 	)
 	if subnetCfg, ok := m.SubnetConfigs[ctx.SubnetID]; ok {
 		minBlockDelay = subnetCfg.ProposerMinBlockDelay
 		numHistoricalBlocks = subnetCfg.ProposerNumHistoricalBlocks
+		proposerSelection = subnetCfg.ProposerSelection
+	}
+	proposerPolicy, err := proposerSelection.NewPolicy()
+	if err != nil {
+		return nil, fmt.Errorf("error while creating proposer policy: %w", err)
 	}
 	m.Log.Info("creating proposervm wrapper",
 		zap.Time("activationTime", m.ApricotPhase4Time),
 		zap.Uint64("minPChainHeight", m.ApricotPhase4MinPChainHeight),
 		zap.Duration("minBlockDelay", minBlockDelay),
 		zap.Uint64("numHistoricalBlocks", numHistoricalBlocks),
+		zap.Reflect("proposerSelection", proposerSelection),
 	)
 
 	chainAlias := m.PrimaryAliasOrDefault(ctx.ChainID)
@@ -1059,6 +1141,7 @@ func (m *manager) createSnowmanChain(
 			NumHistoricalBlocks: numHistoricalBlocks,
 			StakingLeafSigner:   m.StakingTLSSigner,
 			StakingCertLeaf:     m.StakingTLSCert,
+			ProposerPolicy:      proposerPolicy,
 		},
 	)
 
@@ -1154,12 +1237,13 @@ func (m *manager) createSnowmanChain(
 		Consensus:           consensus,
 		PartialSync:         m.PartialSyncPrimaryNetwork && ctx.ChainID == constants.PlatformChainID,
 	}
-	var engine common.Engine
-	engine, err = smeng.New(engineConfig)
+	transitive, err := smeng.New(engineConfig)
 	if err != nil {
 		return nil, fmt.Errorf("error initializing snowman engine: %w", err)
 	}
 
+	var engine common.Engine = transitive
+
 	if m.TracingEnabled {
 		engine = common.TraceEngine(engine, m.Tracer)
 	}
@@ -1176,6 +1260,7 @@ func (m *manager) createSnowmanChain(
 		Timer:                          h,
 		AncestorsMaxContainersReceived: m.BootstrapAncestorsMaxContainersReceived,
 		Blocked:                        blocked,
+		DB:                             fetchingDB,
 		VM:                             vm,
 		Bootstrapped:                   bootstrapFunc,
 	}
@@ -1235,6 +1320,7 @@ func (m *manager) createSnowmanChain(
 		Context: ctx,
 		VM:      vm,
 		Handler: h,
+		Engine:  transitive,
 	}, nil
 }
 
@@ -1249,6 +1335,147 @@ func (m *manager) IsBootstrapped(id ids.ID) bool {
 	return chain.Context().State.Get().State == snow.NormalOp
 }
 
+func (m *manager) BootstrapProgress(chainID ids.ID) (snow.BootstrapProgress, error) {
+	m.chainsLock.Lock()
+	chain, exists := m.chains[chainID]
+	m.chainsLock.Unlock()
+	if !exists {
+		return snow.BootstrapProgress{}, fmt.Errorf("%w: %s", errUnknownChain, chainID)
+	}
+
+	return chain.Context().BootstrapProgress.Get(), nil
+}
+
+func (m *manager) ReloadVM(ctx context.Context, vmID ids.ID, factory vms.Factory, fallback vms.Factory) error {
+	handlers, instances, err := m.getReloadableChains(vmID)
+	if err != nil {
+		return err
+	}
+
+	for i, h := range handlers {
+		chainCtx := h.Context()
+		m.Log.Info("reloading vm",
+			zap.Stringer("chainID", chainCtx.ChainID),
+			zap.Stringer("vmID", vmID),
+		)
+
+		err := reloadChainVM(ctx, chainCtx, instances[i], factory)
+		if err == nil {
+			continue
+		}
+
+		m.Log.Error("failed to reload vm",
+			zap.Stringer("chainID", chainCtx.ChainID),
+			zap.Stringer("vmID", vmID),
+			zap.Error(err),
+		)
+
+		// Every chain of the VM is restored so that the chains don't run
+		// different versions of the VM.
+		for j := 0; j <= i; j++ {
+			chainCtx := handlers[j].Context()
+			if err := reloadChainVM(ctx, chainCtx, instances[j], fallback); err != nil {
+				handlers[j].StopWithError(ctx, fmt.Errorf("failed to restore vm %s: %w", vmID, err))
+			}
+		}
+		return fmt.Errorf("failed to reload vm %s of chain %s: %w", vmID, chainCtx.ChainID, err)
+	}
+	return nil
+}
+
+// getReloadableChains returns the handlers of the running chains of the VM
+// [vmID] along with their VM instances.
+func (m *manager) getReloadableChains(vmID ids.ID) ([]handler.Handler, []reloadableVM, error) {
+	m.chainsLock.Lock()
+	defer m.chainsLock.Unlock()
+
+	var (
+		handlers  []handler.Handler
+		instances []reloadableVM
+	)
+	for chainID, chainVM := range m.chainVMs {
+		if chainVM.vmID != vmID {
+			continue
+		}
+
+		// Chains that were stopped have already shutdown their VM.
+		h := m.chains[chainID]
+		stoppedCtx, cancel := context.WithCancel(context.Background())
+		cancel()
+		if _, err := h.AwaitStopped(stoppedCtx); err == nil {
+			continue
+		}
+
+		vm, ok := chainVM.instance.(reloadableVM)
+		if !ok {
+			return nil, nil, fmt.Errorf("%w: %T", errNotReloadable, chainVM.instance)
+		}
+		handlers = append(handlers, h)
+		instances = append(instances, vm)
+	}
+	return handlers, instances, nil
+}
+
+// reloadChainVM replaces the instance of a chain's VM. Holding the chain's
+// lock drains its handler, as messages are only handled while the lock is
+// held. The calls into the VM that don't take the chain's lock, such as HTTP
+// requests, gossip and health checks, are drained by [vm.Reload].
+func reloadChainVM(ctx context.Context, chainCtx *snow.ConsensusContext, vm reloadableVM, factory vms.Factory) error {
+	chainCtx.Lock.Lock()
+	defer chainCtx.Lock.Unlock()
+
+	return vm.Reload(ctx, factory)
+}
+
+func (m *manager) ConsensusParameters(chainID ids.ID) (smeng.ParametersStatus, error) {
+	engine, err := m.getEngine(chainID)
+	if err != nil {
+		return smeng.ParametersStatus{}, err
+	}
+
+	engine.Ctx.Lock.Lock()
+	defer engine.Ctx.Lock.Unlock()
+
+	return engine.Parameters(), nil
+}
+
+func (m *manager) SetConsensusParameters(chainID ids.ID, params snowball.Parameters) (smeng.ParametersStatus, error) {
+	engine, err := m.getEngine(chainID)
+	if err != nil {
+		return smeng.ParametersStatus{}, err
+	}
+
+	engine.Ctx.Lock.Lock()
+	defer engine.Ctx.Lock.Unlock()
+
+	err = engine.SetParameters(params)
+	return engine.Parameters(), err
+}
+
+func (m *manager) RollbackConsensusParameters(chainID ids.ID) (smeng.ParametersStatus, error) {
+	engine, err := m.getEngine(chainID)
+	if err != nil {
+		return smeng.ParametersStatus{}, err
+	}
+
+	engine.Ctx.Lock.Lock()
+	defer engine.Ctx.Lock.Unlock()
+
+	err = engine.RollbackParameters()
+	return engine.Parameters(), err
+}
+
+func (m *manager) getEngine(chainID ids.ID) (*smeng.Transitive, error) {
+	m.chainsLock.Lock()
+	defer m.chainsLock.Unlock()
+
+	engine, ok := m.engines[chainID]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", errNoSnowmanEngine, chainID)
+	}
+	return engine, nil
+}
+
 func (m *manager) registerBootstrappedHealthChecks() error {
 	bootstrappedCheck := health.CheckerFunc(func(context.Context) (interface{}, error) {
 		if subnetIDs := m.Subnets.Bootstrapping(); len(subnetIDs) != 0 {
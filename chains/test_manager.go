@@ -3,7 +3,16 @@
 
 package chains
 
-import "github.com/ava-labs/avalanchego/ids"
+import (
+	"context"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow"
+	"github.com/ava-labs/avalanchego/snow/consensus/snowball"
+	"github.com/ava-labs/avalanchego/vms"
+
+	smeng "github.com/ava-labs/avalanchego/snow/engine/snowman"
+)
 
 // TestManager implements Manager but does nothing. Always returns nil error.
 // To be used only in tests
@@ -49,6 +58,26 @@ func (testManager) IsBootstrapped(ids.ID) bool {
 	return false
 }
 
+func (testManager) BootstrapProgress(ids.ID) (snow.BootstrapProgress, error) {
+	return snow.BootstrapProgress{}, nil
+}
+
+func (testManager) ReloadVM(context.Context, ids.ID, vms.Factory, vms.Factory) error {
+	return nil
+}
+
+func (testManager) ConsensusParameters(ids.ID) (smeng.ParametersStatus, error) {
+	return smeng.ParametersStatus{}, nil
+}
+
+func (testManager) SetConsensusParameters(ids.ID, snowball.Parameters) (smeng.ParametersStatus, error) {
+	return smeng.ParametersStatus{}, nil
+}
+
+func (testManager) RollbackConsensusParameters(ids.ID) (smeng.ParametersStatus, error) {
+	return smeng.ParametersStatus{}, nil
+}
+
 func (testManager) Lookup(s string) (ids.ID, error) {
 	return ids.FromString(s)
 }
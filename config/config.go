@@ -4,7 +4,9 @@
 package config
 
 import (
+	"context"
 	"crypto/tls"
+	"crypto/x509"
 	"encoding/base64"
 	"encoding/json"
 	"errors"
@@ -13,17 +15,24 @@ import (
 	"math"
 	"os"
 	"path/filepath"
+	"slices"
+	"strconv"
 	"strings"
 	"time"
 
 	"github.com/spf13/viper"
+	"google.golang.org/grpc/credentials"
 
+	"github.com/ava-labs/avalanchego/api/audit"
+	"github.com/ava-labs/avalanchego/api/auth"
 	"github.com/ava-labs/avalanchego/api/server"
 	"github.com/ava-labs/avalanchego/chains"
 	"github.com/ava-labs/avalanchego/genesis"
 	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/message"
 	"github.com/ava-labs/avalanchego/network"
 	"github.com/ava-labs/avalanchego/network/dialer"
+	"github.com/ava-labs/avalanchego/network/peer"
 	"github.com/ava-labs/avalanchego/network/throttling"
 	"github.com/ava-labs/avalanchego/node"
 	"github.com/ava-labs/avalanchego/snow/consensus/snowball"
@@ -31,6 +40,7 @@ import (
 	"github.com/ava-labs/avalanchego/snow/networking/router"
 	"github.com/ava-labs/avalanchego/snow/networking/tracker"
 	"github.com/ava-labs/avalanchego/staking"
+	"github.com/ava-labs/avalanchego/staking/gsigner"
 	"github.com/ava-labs/avalanchego/subnets"
 	"github.com/ava-labs/avalanchego/trace"
 	"github.com/ava-labs/avalanchego/utils/compression"
@@ -46,12 +56,18 @@ import (
 	"github.com/ava-labs/avalanchego/version"
 	"github.com/ava-labs/avalanchego/vms/platformvm/reward"
 	"github.com/ava-labs/avalanchego/vms/proposervm"
+	"github.com/ava-labs/avalanchego/vms/rpcchainvm"
+	"github.com/ava-labs/avalanchego/vms/rpcchainvm/grpcutils"
+
+	signerpb "github.com/ava-labs/avalanchego/proto/pb/signer"
 )
 
 const (
 	chainConfigFileName  = "config"
 	chainUpgradeFileName = "upgrade"
 	subnetConfigFileExt  = ".json"
+	auditLogFileName     = "audit.log"
+	auditLogHeadFileName = "audit-head.json"
 
 	keystoreDeprecationMsg = "keystore API is deprecated"
 )
@@ -83,11 +99,19 @@ var (
 	errStakingKeyContentUnset                 = fmt.Errorf("%s key not set but %s set", StakingTLSKeyContentKey, StakingCertContentKey)
 	errStakingCertContentUnset                = fmt.Errorf("%s key set but %s not set", StakingTLSKeyContentKey, StakingCertContentKey)
 	errMissingStakingSigningKeyFile           = errors.New("missing staking signing key file")
+	errRemoteSignerLocalKeys                  = errors.New("local staking keys can't be used with a remote signer")
+	errMissingRemoteSignerTLSFiles            = fmt.Errorf("%s, %s, and %s must be set when using a remote signer", StakingRemoteSignerTLSCertPathKey, StakingRemoteSignerTLSKeyPathKey, StakingRemoteSignerTLSCAPathKey)
+	errInvalidRemoteSignerCA                  = errors.New("couldn't parse remote signer certificate authority")
 	errTracingEndpointEmpty                   = fmt.Errorf("%s cannot be empty", TracingEndpointKey)
+	errHTTPSClientCAWithoutTLS                = fmt.Errorf("%s requires %s", HTTPSClientCAFileKey, HTTPSEnabledKey)
+	errAPICredentialsWithoutPolicy            = fmt.Errorf("%s and %s require %s", APIAuthJWTKeyFileKey, HTTPSClientCAFileKey, APIAuthPolicyFileKey)
 	errPluginDirNotADirectory                 = errors.New("plugin dir is not a directory")
 	errCannotReadDirectory                    = errors.New("cannot read directory")
 	errUnmarshalling                          = errors.New("unmarshalling failed")
 	errFileDoesNotExist                       = errors.New("file does not exist")
+	errUnsupportedCompressionType             = errors.New("compression type can't be advertised as supported")
+	errDuplicateCompressionType               = errors.New("duplicate compression type")
+	errNoSupportedCompressionTypes            = errors.New("no supported compression types")
 )
 
 func getConsensusConfig(v *viper.Viper) snowball.Parameters {
@@ -175,12 +199,37 @@ func getHTTPConfig(v *viper.Viper) (node.HTTPConfig, error) {
 		}
 	}
 
+	var httpsClientCAs []byte
+	if v.IsSet(HTTPSClientCAFileKey) {
+		if !v.GetBool(HTTPSEnabledKey) {
+			return node.HTTPConfig{}, errHTTPSClientCAWithoutTLS
+		}
+		httpsClientCAs, err = os.ReadFile(filepath.Clean(GetExpandedArg(v, HTTPSClientCAFileKey)))
+		if err != nil {
+			return node.HTTPConfig{}, err
+		}
+	}
+
+	authConfig := auth.Config{
+		PolicyFile: GetExpandedArg(v, APIAuthPolicyFileKey),
+		JWTKeyFile: GetExpandedArg(v, APIAuthJWTKeyFileKey),
+	}
+	if authConfig.PolicyFile == "" && (authConfig.JWTKeyFile != "" || httpsClientCAs != nil) {
+		return node.HTTPConfig{}, errAPICredentialsWithoutPolicy
+	}
+
+	rateLimitConfig, err := getRateLimitConfig(v)
+	if err != nil {
+		return node.HTTPConfig{}, err
+	}
+
 	return node.HTTPConfig{
 		HTTPConfig: server.HTTPConfig{
 			ReadTimeout:       v.GetDuration(HTTPReadTimeoutKey),
 			ReadHeaderTimeout: v.GetDuration(HTTPReadHeaderTimeoutKey),
 			WriteTimeout:      v.GetDuration(HTTPWriteTimeoutKey),
 			IdleTimeout:       v.GetDuration(HTTPIdleTimeoutKey),
+			RateLimitConfig:   rateLimitConfig,
 		},
 		APIConfig: node.APIConfig{
 			APIIndexerConfig: node.APIIndexerConfig{
@@ -198,6 +247,8 @@ func getHTTPConfig(v *viper.Viper) (node.HTTPConfig, error) {
 		HTTPSEnabled:       v.GetBool(HTTPSEnabledKey),
 		HTTPSKey:           httpsKey,
 		HTTPSCert:          httpsCert,
+		HTTPSClientCAs:     httpsClientCAs,
+		AuthConfig:         authConfig,
 		HTTPAllowedOrigins: v.GetStringSlice(HTTPAllowedOrigins),
 		HTTPAllowedHosts:   v.GetStringSlice(HTTPAllowedHostsKey),
 		ShutdownTimeout:    v.GetDuration(HTTPShutdownTimeoutKey),
@@ -205,6 +256,27 @@ func getHTTPConfig(v *viper.Viper) (node.HTTPConfig, error) {
 	}, nil
 }
 
+func getRateLimitConfig(v *viper.Viper) (server.RateLimitConfig, error) {
+	config := server.RateLimitConfig{
+		Rate:       v.GetFloat64(APIRateLimitRateKey),
+		Burst:      int(v.GetUint(APIRateLimitBurstKey)),
+		MaxClients: int(v.GetUint(APIRateLimitMaxClientsKey)),
+	}
+	if v.IsSet(APIRateLimitRulesFileKey) {
+		rulesBytes, err := os.ReadFile(filepath.Clean(GetExpandedArg(v, APIRateLimitRulesFileKey)))
+		if err != nil {
+			return server.RateLimitConfig{}, err
+		}
+		if err := json.Unmarshal(rulesBytes, &config.RateLimitRules); err != nil {
+			return server.RateLimitConfig{}, fmt.Errorf("%w: %w", errUnmarshalling, err)
+		}
+	}
+	if err := config.Verify(); err != nil {
+		return server.RateLimitConfig{}, fmt.Errorf("invalid API rate limit config: %w", err)
+	}
+	return config, nil
+}
+
 func getRouterHealthConfig(v *viper.Viper, halflife time.Duration) (router.HealthConfig, error) {
 	config := router.HealthConfig{
 		MaxDropRate:            v.GetFloat64(RouterHealthMaxDropRateKey),
@@ -248,6 +320,81 @@ func getAdaptiveTimeoutConfig(v *viper.Viper) (timer.AdaptiveTimeoutConfig, erro
 	return config, nil
 }
 
+func getSubnetBandwidthQuotaConfig(v *viper.Viper) (throttling.BandwidthQuotaConfig, error) {
+	config := throttling.BandwidthQuotaConfig{
+		RefillRate:         v.GetUint64(SubnetBandwidthQuotaRefillRateKey),
+		MaxBurstSize:       v.GetUint64(SubnetBandwidthQuotaMaxBurstSizeKey),
+		SubnetShares:       make(map[ids.ID]float64),
+		DefaultSubnetShare: v.GetFloat64(SubnetBandwidthQuotaDefaultShareKey),
+		OpShares:           make(map[message.Op]float64),
+	}
+	if config.DefaultSubnetShare < 0 {
+		return throttling.BandwidthQuotaConfig{}, fmt.Errorf("%s must be >= 0", SubnetBandwidthQuotaDefaultShareKey)
+	}
+
+	for subnetIDStr, shareStr := range v.GetStringMapString(SubnetBandwidthQuotaSharesKey) {
+		subnetID, err := ids.FromString(subnetIDStr)
+		if err != nil {
+			return throttling.BandwidthQuotaConfig{}, fmt.Errorf("invalid subnetID in %s: %w", SubnetBandwidthQuotaSharesKey, err)
+		}
+		share, err := strconv.ParseFloat(shareStr, 64)
+		if err != nil {
+			return throttling.BandwidthQuotaConfig{}, fmt.Errorf("invalid share in %s: %w", SubnetBandwidthQuotaSharesKey, err)
+		}
+		if share < 0 {
+			return throttling.BandwidthQuotaConfig{}, fmt.Errorf("share of %s in %s must be >= 0", subnetID, SubnetBandwidthQuotaSharesKey)
+		}
+		config.SubnetShares[subnetID] = share
+	}
+
+	for opStr, shareStr := range v.GetStringMapString(SubnetBandwidthQuotaOpSharesKey) {
+		op, err := message.ExternalOpFromString(opStr)
+		if err != nil {
+			return throttling.BandwidthQuotaConfig{}, fmt.Errorf("invalid op in %s: %w", SubnetBandwidthQuotaOpSharesKey, err)
+		}
+		share, err := strconv.ParseFloat(shareStr, 64)
+		if err != nil {
+			return throttling.BandwidthQuotaConfig{}, fmt.Errorf("invalid share in %s: %w", SubnetBandwidthQuotaOpSharesKey, err)
+		}
+		if share < 0 || share > 1 {
+			return throttling.BandwidthQuotaConfig{}, fmt.Errorf("share of %s in %s must be in [0,1]", op, SubnetBandwidthQuotaOpSharesKey)
+		}
+		config.OpShares[op] = share
+	}
+
+	for _, opStr := range v.GetStringSlice(SubnetBandwidthQuotaPriorityOpsKey) {
+		op, err := message.ExternalOpFromString(opStr)
+		if err != nil {
+			return throttling.BandwidthQuotaConfig{}, fmt.Errorf("invalid op in %s: %w", SubnetBandwidthQuotaPriorityOpsKey, err)
+		}
+		config.PriorityOps.Add(op)
+	}
+	return config, nil
+}
+
+func getSupportedCompressionTypes(v *viper.Viper) ([]compression.Type, error) {
+	compressionTypeStrs := v.GetStringSlice(NetworkSupportedCompressionTypesKey)
+	if len(compressionTypeStrs) == 0 {
+		return nil, fmt.Errorf("%w: %s is empty", errNoSupportedCompressionTypes, NetworkSupportedCompressionTypesKey)
+	}
+
+	compressionTypes := make([]compression.Type, 0, len(compressionTypeStrs))
+	for _, compressionTypeStr := range compressionTypeStrs {
+		compressionType, err := compression.TypeFromString(compressionTypeStr)
+		if err != nil {
+			return nil, err
+		}
+		if !slices.Contains(message.CompressionTypes, compressionType) {
+			return nil, fmt.Errorf("%w: %s", errUnsupportedCompressionType, compressionType)
+		}
+		if slices.Contains(compressionTypes, compressionType) {
+			return nil, fmt.Errorf("%w: %s", errDuplicateCompressionType, compressionType)
+		}
+		compressionTypes = append(compressionTypes, compressionType)
+	}
+	return compressionTypes, nil
+}
+
 func getNetworkConfig(
 	v *viper.Viper,
 	networkID uint32,
@@ -266,6 +413,11 @@ func getNetworkConfig(
 		return network.Config{}, err
 	}
 
+	supportedCompressionTypes, err := getSupportedCompressionTypes(v)
+	if err != nil {
+		return network.Config{}, err
+	}
+
 	allowPrivateIPs := !constants.ProductionNetworkIDs.Contains(networkID)
 	if v.IsSet(NetworkAllowPrivateIPsKey) {
 		allowPrivateIPs = v.GetBool(NetworkAllowPrivateIPsKey)
@@ -297,6 +449,11 @@ func getNetworkConfig(
 	// peers that we support these upgrades.
 	supportedACPs.Union(constants.ScheduledACPs)
 
+	subnetBandwidthQuotaConfig, err := getSubnetBandwidthQuotaConfig(v)
+	if err != nil {
+		return network.Config{}, err
+	}
+
 	config := network.Config{
 		ThrottlerConfig: network.ThrottlerConfig{
 			MaxInboundConnsPerSec: maxInboundConnsPerSec,
@@ -329,6 +486,8 @@ func getNetworkConfig(
 				VdrAllocSize:        v.GetUint64(OutboundThrottlerVdrAllocSizeKey),
 				NodeMaxAtLargeBytes: v.GetUint64(OutboundThrottlerNodeMaxAtLargeBytesKey),
 			},
+
+			SubnetBandwidthQuotaConfig: subnetBandwidthQuotaConfig,
 		},
 
 		HealthConfig: network.HealthConfig{
@@ -369,6 +528,7 @@ func getNetworkConfig(
 
 		MaxClockDifference:           v.GetDuration(NetworkMaxClockDifferenceKey),
 		CompressionType:              compressionType,
+		SupportedCompressionTypes:    supportedCompressionTypes,
 		PingFrequency:                v.GetDuration(NetworkPingFrequencyKey),
 		AllowPrivateIPs:              allowPrivateIPs,
 		UptimeMetricFreq:             v.GetDuration(UptimeMetricFreqKey),
@@ -380,6 +540,13 @@ func getNetworkConfig(
 		RequireValidatorToConnect: v.GetBool(NetworkRequireValidatorToConnectKey),
 		PeerReadBufferSize:        int(v.GetUint(NetworkPeerReadBufferSizeKey)),
 		PeerWriteBufferSize:       int(v.GetUint(NetworkPeerWriteBufferSizeKey)),
+
+		ReputationConfig: peer.ReputationConfig{
+			Halflife:     v.GetDuration(NetworkReputationHalflifeKey),
+			MaxScore:     v.GetFloat64(NetworkReputationMaxScoreKey),
+			BanThreshold: v.GetFloat64(NetworkReputationBanThresholdKey),
+			BanDuration:  v.GetDuration(NetworkReputationBanDurationKey),
+		},
 	}
 
 	switch {
@@ -417,6 +584,14 @@ func getNetworkConfig(
 		return network.Config{}, fmt.Errorf("%s must be >= 0", NetworkReadHandshakeTimeoutKey)
 	case config.MaxClockDifference < 0:
 		return network.Config{}, fmt.Errorf("%s must be >= 0", NetworkMaxClockDifferenceKey)
+	case config.ReputationConfig.Halflife < 0:
+		return network.Config{}, fmt.Errorf("%s must be >= 0", NetworkReputationHalflifeKey)
+	case config.ReputationConfig.MaxScore < 0:
+		return network.Config{}, fmt.Errorf("%s must be >= 0", NetworkReputationMaxScoreKey)
+	case config.ReputationConfig.BanThreshold > 0:
+		return network.Config{}, fmt.Errorf("%s must be <= 0", NetworkReputationBanThresholdKey)
+	case config.ReputationConfig.BanDuration < 0:
+		return network.Config{}, fmt.Errorf("%s must be >= 0", NetworkReputationBanDurationKey)
 	}
 	return config, nil
 }
@@ -553,6 +728,7 @@ func getIPConfig(v *viper.Viper) (node.IPConfig, error) {
 		PublicIPResolutionFreq:    v.GetDuration(PublicIPResolutionFreqKey),
 		ListenHost:                v.GetString(StakingHostKey),
 		ListenPort:                uint16(v.GetUint(StakingPortKey)),
+		PublicPort:                uint16(v.GetUint(PublicPortKey)),
 	}
 	if ipConfig.PublicIPResolutionFreq <= 0 {
 		return node.IPConfig{}, fmt.Errorf("%q must be > 0", PublicIPResolutionFreqKey)
@@ -705,6 +881,72 @@ func getStakingSigner(v *viper.Viper) (*bls.SecretKey, error) {
 	return key, nil
 }
 
+// getRemoteSigner connects to the remote signer and fetches its staking keys.
+// The connection is authenticated with mutual TLS.
+func getRemoteSigner(v *viper.Viper) (*gsigner.Client, error) {
+	if v.GetBool(StakingEphemeralCertEnabledKey) || v.GetBool(StakingEphemeralSignerEnabledKey) {
+		return nil, errRemoteSignerLocalKeys
+	}
+	for _, key := range []string{
+		StakingTLSKeyPathKey,
+		StakingTLSKeyContentKey,
+		StakingCertPathKey,
+		StakingCertContentKey,
+		StakingSignerKeyPathKey,
+		StakingSignerKeyContentKey,
+	} {
+		if v.IsSet(key) {
+			return nil, fmt.Errorf("%w: %s is set", errRemoteSignerLocalKeys, key)
+		}
+	}
+
+	var (
+		certPath = GetExpandedArg(v, StakingRemoteSignerTLSCertPathKey)
+		keyPath  = GetExpandedArg(v, StakingRemoteSignerTLSKeyPathKey)
+		caPath   = GetExpandedArg(v, StakingRemoteSignerTLSCAPathKey)
+	)
+	if certPath == "" || keyPath == "" || caPath == "" {
+		return nil, errMissingRemoteSignerTLSFiles
+	}
+
+	cert, err := tls.LoadX509KeyPair(certPath, keyPath)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't read remote signer TLS certificate: %w", err)
+	}
+	caBytes, err := os.ReadFile(caPath)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't read remote signer certificate authority: %w", err)
+	}
+	caPool := x509.NewCertPool()
+	if !caPool.AppendCertsFromPEM(caBytes) {
+		return nil, errInvalidRemoteSignerCA
+	}
+
+	endpoint := v.GetString(StakingRemoteSignerEndpointKey)
+	conn, err := grpcutils.Dial(
+		endpoint,
+		grpcutils.WithTransportCredentials(credentials.NewTLS(&tls.Config{
+			Certificates: []tls.Certificate{cert},
+			RootCAs:      caPool,
+			MinVersion:   tls.VersionTLS13,
+		})),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't dial remote signer at %s: %w", endpoint, err)
+	}
+
+	client, err := gsigner.NewClient(
+		context.Background(),
+		signerpb.NewSignerClient(conn),
+		v.GetDuration(StakingRemoteSignerTimeoutKey),
+	)
+	if err != nil {
+		_ = conn.Close()
+		return nil, fmt.Errorf("couldn't connect to remote signer at %s: %w", endpoint, err)
+	}
+	return client, nil
+}
+
 func getStakingConfig(v *viper.Viper, networkID uint32) (node.StakingConfig, error) {
 	config := node.StakingConfig{
 		SybilProtectionEnabled:        v.GetBool(SybilProtectionEnabledKey),
@@ -722,14 +964,24 @@ func getStakingConfig(v *viper.Viper, networkID uint32) (node.StakingConfig, err
 		return node.StakingConfig{}, errSybilProtectionDisabledOnPublicNetwork
 	}
 
-	var err error
-	config.StakingTLSCert, err = getStakingTLSCert(v)
-	if err != nil {
-		return node.StakingConfig{}, err
-	}
-	config.StakingSigningKey, err = getStakingSigner(v)
-	if err != nil {
-		return node.StakingConfig{}, err
+	if v.GetString(StakingRemoteSignerEndpointKey) != "" {
+		signer, err := getRemoteSigner(v)
+		if err != nil {
+			return node.StakingConfig{}, err
+		}
+		config.StakingTLSCert = signer.TLSCertificate()
+		config.StakingSigner = signer
+	} else {
+		var err error
+		config.StakingTLSCert, err = getStakingTLSCert(v)
+		if err != nil {
+			return node.StakingConfig{}, err
+		}
+		signingKey, err := getStakingSigner(v)
+		if err != nil {
+			return node.StakingConfig{}, err
+		}
+		config.StakingSigner = bls.NewLocalSigner(signingKey)
 	}
 	if networkID != constants.MainnetID && networkID != constants.FujiID {
 		config.UptimeRequirement = v.GetFloat64(UptimeRequirementKey)
@@ -779,6 +1031,7 @@ func getTxFeeConfig(v *viper.Viper, networkID uint32) genesis.TxFeeConfig {
 			AddPrimaryNetworkDelegatorFee: v.GetUint64(AddPrimaryNetworkDelegatorFeeKey),
 			AddSubnetValidatorFee:         v.GetUint64(AddSubnetValidatorFeeKey),
 			AddSubnetDelegatorFee:         v.GetUint64(AddSubnetDelegatorFeeKey),
+			DynamicFeeConfig:              genesis.GetTxFeeConfig(networkID).DynamicFeeConfig,
 		}
 	}
 	return genesis.GetTxFeeConfig(networkID)
@@ -852,33 +1105,39 @@ func getDatabaseConfig(v *viper.Viper, networkID uint32) (node.DatabaseConfig, e
 	}, nil
 }
 
-func getAliases(v *viper.Viper, name string, contentKey string, fileKey string) (map[ids.ID][]string, error) {
-	var fileBytes []byte
+// getFileContent returns the base64 decoded value of [contentKey] if it is set
+// and the content of the file at [fileKey] otherwise. If the file doesn't exist
+// and [fileKey] wasn't set, false is returned.
+func getFileContent(v *viper.Viper, name string, contentKey string, fileKey string) ([]byte, bool, error) {
 	if v.IsSet(contentKey) {
-		var err error
-		aliasFlagContent := v.GetString(contentKey)
-		fileBytes, err = base64.StdEncoding.DecodeString(aliasFlagContent)
-		if err != nil {
-			return nil, fmt.Errorf("unable to decode base64 content for %s: %w", name, err)
-		}
-	} else {
-		aliasFilePath := filepath.Clean(GetExpandedArg(v, fileKey))
-		exists, err := storage.FileExists(aliasFilePath)
+		fileBytes, err := base64.StdEncoding.DecodeString(v.GetString(contentKey))
 		if err != nil {
-			return nil, err
+			return nil, false, fmt.Errorf("unable to decode base64 content for %s: %w", name, err)
 		}
+		return fileBytes, true, nil
+	}
 
-		if !exists {
-			if v.IsSet(fileKey) {
-				return nil, fmt.Errorf("%w: %s", errFileDoesNotExist, aliasFilePath)
-			}
-			return nil, nil
-		}
+	filePath := filepath.Clean(GetExpandedArg(v, fileKey))
+	exists, err := storage.FileExists(filePath)
+	if err != nil {
+		return nil, false, err
+	}
 
-		fileBytes, err = os.ReadFile(aliasFilePath)
-		if err != nil {
-			return nil, err
+	if !exists {
+		if v.IsSet(fileKey) {
+			return nil, false, fmt.Errorf("%w: %s", errFileDoesNotExist, filePath)
 		}
+		return nil, false, nil
+	}
+
+	fileBytes, err := os.ReadFile(filePath)
+	return fileBytes, err == nil, err
+}
+
+func getAliases(v *viper.Viper, name string, contentKey string, fileKey string) (map[ids.ID][]string, error) {
+	fileBytes, ok, err := getFileContent(v, name, contentKey, fileKey)
+	if !ok || err != nil {
+		return nil, err
 	}
 
 	aliasMap := make(map[ids.ID][]string)
@@ -896,6 +1155,25 @@ func getChainAliases(v *viper.Viper) (map[ids.ID][]string, error) {
 	return getAliases(v, "chain aliases", ChainAliasesContentKey, ChainAliasesFileKey)
 }
 
+func getRemoteVMs(v *viper.Viper) (map[string]rpcchainvm.RemoteConfig, error) {
+	const name = "remote vms"
+	fileBytes, ok, err := getFileContent(v, name, RemoteVMsContentKey, RemoteVMsFileKey)
+	if !ok || err != nil {
+		return nil, err
+	}
+
+	remoteVMs := make(map[string]rpcchainvm.RemoteConfig)
+	if err := json.Unmarshal(fileBytes, &remoteVMs); err != nil {
+		return nil, fmt.Errorf("%w on %s: %w", errUnmarshalling, name, err)
+	}
+	for name, config := range remoteVMs {
+		if err := config.Verify(); err != nil {
+			return nil, fmt.Errorf("invalid remote vm %q: %w", name, err)
+		}
+	}
+	return remoteVMs, nil
+}
+
 // getPathFromDirKey reads flag value from viper instance and then checks the folder existence
 func getPathFromDirKey(v *viper.Viper, configKey string) (string, error) {
 	configDir := GetExpandedArg(v, configKey)
@@ -1178,6 +1456,31 @@ func getTraceConfig(v *viper.Viper) (trace.Config, error) {
 	}, nil
 }
 
+func getAuditLogConfig(v *viper.Viper) audit.Config {
+	if !v.GetBool(AuditLogEnabledKey) {
+		return audit.Config{
+			Enabled: false,
+		}
+	}
+
+	file := GetExpandedArg(v, AuditLogFileKey)
+	if file == "" {
+		file = filepath.Join(GetExpandedArg(v, LogsDirKey), auditLogFileName)
+	}
+	return audit.Config{
+		Enabled:       true,
+		File:          file,
+		MaxSize:       int(v.GetUint(AuditLogMaxSizeKey)),
+		MaxFiles:      int(v.GetUint(AuditLogMaxFilesKey)),
+		MaxAge:        int(v.GetUint(AuditLogMaxAgeKey)),
+		Compress:      v.GetBool(AuditLogCompressEnabledKey),
+		SyslogNetwork: v.GetString(AuditLogSyslogNetworkKey),
+		SyslogAddress: v.GetString(AuditLogSyslogAddressKey),
+		HeadFile:      filepath.Join(filepath.Dir(file), auditLogHeadFileName),
+		KeyFile:       GetExpandedArg(v, AuditLogKeyFileKey),
+	}
+}
+
 // Returns the path to the directory that contains VM binaries.
 func getPluginDir(v *viper.Viper) (string, error) {
 	pluginDir := GetExpandedString(v, v.GetString(PluginDirKey))
@@ -1372,6 +1675,11 @@ func GetNodeConfig(v *viper.Viper) (node.Config, error) {
 	if err != nil {
 		return node.Config{}, err
 	}
+	// Remote VMs
+	nodeConfig.RemoteVMs, err = getRemoteVMs(v)
+	if err != nil {
+		return node.Config{}, err
+	}
 	// Chain aliases
 	nodeConfig.ChainAliases, err = getChainAliases(v)
 	if err != nil {
@@ -1403,6 +1711,8 @@ func GetNodeConfig(v *viper.Viper) (node.Config, error) {
 		return node.Config{}, err
 	}
 
+	nodeConfig.AuditLogConfig = getAuditLogConfig(v)
+
 	nodeConfig.ChainDataDir = GetExpandedArg(v, ChainDataDirKey)
 
 	nodeConfig.ProcessContextFilePath = GetExpandedArg(v, ProcessContextFileKey)
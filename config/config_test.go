@@ -18,8 +18,13 @@ import (
 
 	"github.com/ava-labs/avalanchego/chains"
 	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/message"
 	"github.com/ava-labs/avalanchego/snow/consensus/snowball"
 	"github.com/ava-labs/avalanchego/subnets"
+	"github.com/ava-labs/avalanchego/utils/compression"
+	"github.com/ava-labs/avalanchego/utils/set"
+	"github.com/ava-labs/avalanchego/vms/rpcchainvm"
+	"github.com/ava-labs/avalanchego/vms/rpcchainvm/runtime"
 )
 
 func TestGetChainConfigsFromFiles(t *testing.T) {
@@ -362,6 +367,87 @@ func TestGetVMAliasesDirNotExists(t *testing.T) {
 	require.NoError(err)
 }
 
+func TestGetRemoteVMsFromFlag(t *testing.T) {
+	tests := map[string]struct {
+		givenJSON   string
+		expected    map[string]rpcchainvm.RemoteConfig
+		expectedErr error
+	}{
+		"invalid json": {
+			givenJSON:   `{"xsvm": []}`,
+			expected:    nil,
+			expectedErr: errUnmarshalling,
+		},
+		"missing tls": {
+			givenJSON:   `{"xsvm": {"address": "127.0.0.1:9700"}}`,
+			expected:    nil,
+			expectedErr: runtime.ErrInvalidConfig,
+		},
+		"valid": {
+			givenJSON: `{"xsvm": {
+				"address": "xsvm:9700",
+				"certFile": "vm.crt",
+				"keyFile": "vm.key",
+				"caFile": "ca.crt",
+				"advertiseHost": "avalanchego"
+			}}`,
+			expected: map[string]rpcchainvm.RemoteConfig{
+				"xsvm": {
+					Address:       "xsvm:9700",
+					CertFile:      "vm.crt",
+					KeyFile:       "vm.key",
+					CAFile:        "ca.crt",
+					AdvertiseHost: "avalanchego",
+				},
+			},
+			expectedErr: nil,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			require := require.New(t)
+			encodedFileContent := base64.StdEncoding.EncodeToString([]byte(test.givenJSON))
+
+			// build viper config
+			v := setupViperFlags()
+			v.Set(RemoteVMsContentKey, encodedFileContent)
+
+			remoteVMs, err := getRemoteVMs(v)
+			require.ErrorIs(err, test.expectedErr)
+			require.Equal(test.expected, remoteVMs)
+		})
+	}
+}
+
+func TestGetRemoteVMsDefaultDir(t *testing.T) {
+	require := require.New(t)
+	root := t.TempDir()
+	// changes internal package variable, since using defaultDir (under user home) is risky.
+	defaultRemoteVMsFilePath = filepath.Join(root, "remote.json")
+	configFilePath := setupConfigJSON(t, root, "{}")
+
+	v := setupViper(configFilePath)
+	require.Equal(defaultRemoteVMsFilePath, v.GetString(RemoteVMsFileKey))
+
+	// the default file is optional
+	remoteVMs, err := getRemoteVMs(v)
+	require.NoError(err)
+	require.Nil(remoteVMs)
+
+	setupFile(t, root, "remote.json", `{"xsvm": {"address": "xsvm:9700", "certFile": "vm.crt", "keyFile": "vm.key", "caFile": "ca.crt"}}`)
+	remoteVMs, err = getRemoteVMs(v)
+	require.NoError(err)
+	require.Equal(map[string]rpcchainvm.RemoteConfig{
+		"xsvm": {
+			Address:  "xsvm:9700",
+			CertFile: "vm.crt",
+			KeyFile:  "vm.key",
+			CAFile:   "ca.crt",
+		},
+	}, remoteVMs)
+}
+
 func TestGetSubnetConfigsFromFile(t *testing.T) {
 	subnetID, err := ids.FromString("2Ctt6eGAeo4MLqTmGa7AdRecuVMPGWEX9wSsCLBYrLhX4a394i")
 	require.NoError(t, err)
@@ -539,6 +625,120 @@ func TestGetSubnetConfigsFromFlags(t *testing.T) {
 	}
 }
 
+func TestGetRemoteSignerInvalidConfig(t *testing.T) {
+	tests := map[string]struct {
+		flags       map[string]interface{}
+		expectedErr error
+	}{
+		"ephemeral signer": {
+			flags: map[string]interface{}{
+				StakingEphemeralSignerEnabledKey: true,
+			},
+			expectedErr: errRemoteSignerLocalKeys,
+		},
+		"local signer key": {
+			flags: map[string]interface{}{
+				StakingSignerKeyContentKey: "key",
+			},
+			expectedErr: errRemoteSignerLocalKeys,
+		},
+		"local tls key": {
+			flags: map[string]interface{}{
+				StakingTLSKeyPathKey: "staker.key",
+			},
+			expectedErr: errRemoteSignerLocalKeys,
+		},
+		"missing tls files": {
+			flags: map[string]interface{}{
+				StakingRemoteSignerTLSCertPathKey: "client.crt",
+				StakingRemoteSignerTLSKeyPathKey:  "client.key",
+			},
+			expectedErr: errMissingRemoteSignerTLSFiles,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			v := setupViperFlags()
+			v.Set(StakingRemoteSignerEndpointKey, "127.0.0.1:9652")
+			for key, value := range test.flags {
+				v.Set(key, value)
+			}
+
+			_, err := getRemoteSigner(v)
+			require.ErrorIs(t, err, test.expectedErr)
+		})
+	}
+}
+
+func TestGetSubnetBandwidthQuotaConfig(t *testing.T) {
+	require := require.New(t)
+
+	v := setupViperFlags()
+	config, err := getSubnetBandwidthQuotaConfig(v)
+	require.NoError(err)
+	require.Equal(map[message.Op]float64{message.AppGossipOp: 0.5}, config.OpShares)
+	require.Equal(set.Of(message.ConsensusResponseOps...), config.PriorityOps)
+
+	subnetID := ids.GenerateTestID()
+	v.Set(SubnetBandwidthQuotaSharesKey, map[string]string{subnetID.String(): "0.25"})
+	v.Set(SubnetBandwidthQuotaOpSharesKey, map[string]string{message.AppRequestOp.String(): "0.1"})
+	v.Set(SubnetBandwidthQuotaPriorityOpsKey, []string{message.ChitsOp.String()})
+	config, err = getSubnetBandwidthQuotaConfig(v)
+	require.NoError(err)
+	require.Equal(map[ids.ID]float64{subnetID: 0.25}, config.SubnetShares)
+	require.Equal(map[message.Op]float64{message.AppRequestOp: 0.1}, config.OpShares)
+	require.Equal(set.Of(message.ChitsOp), config.PriorityOps)
+}
+
+func TestGetSupportedCompressionTypes(t *testing.T) {
+	tests := []struct {
+		name                     string
+		compressionTypes         []string
+		expectedCompressionTypes []compression.Type
+		expectedErr              error
+	}{
+		{
+			name:                     "default",
+			expectedCompressionTypes: message.CompressionTypes,
+		},
+		{
+			name:                     "preference order",
+			compressionTypes:         []string{"lz4", "zstd"},
+			expectedCompressionTypes: []compression.Type{compression.TypeLZ4, compression.TypeZstd},
+		},
+		{
+			name:             "empty",
+			compressionTypes: []string{},
+			expectedErr:      errNoSupportedCompressionTypes,
+		},
+		{
+			name:             "none",
+			compressionTypes: []string{"none"},
+			expectedErr:      errUnsupportedCompressionType,
+		},
+		{
+			name:             "duplicate",
+			compressionTypes: []string{"snappy", "snappy"},
+			expectedErr:      errDuplicateCompressionType,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require := require.New(t)
+
+			v := setupViperFlags()
+			if test.compressionTypes != nil {
+				v.Set(NetworkSupportedCompressionTypesKey, test.compressionTypes)
+			}
+			compressionTypes, err := getSupportedCompressionTypes(v)
+			require.ErrorIs(err, test.expectedErr)
+			if test.expectedErr == nil {
+				require.Equal(test.expectedCompressionTypes, compressionTypes)
+			}
+		})
+	}
+}
+
 // setups config json file and writes content
 func setupConfigJSON(t *testing.T, rootPath string, value string) string {
 	configFilePath := filepath.Join(rootPath, "config.json")
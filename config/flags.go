@@ -17,6 +17,7 @@ import (
 	"github.com/ava-labs/avalanchego/database/memdb"
 	"github.com/ava-labs/avalanchego/database/pebble"
 	"github.com/ava-labs/avalanchego/genesis"
+	"github.com/ava-labs/avalanchego/message"
 	"github.com/ava-labs/avalanchego/snow/consensus/snowball"
 	"github.com/ava-labs/avalanchego/trace"
 	"github.com/ava-labs/avalanchego/utils/compression"
@@ -50,6 +51,7 @@ var (
 	defaultChainConfigDir       = filepath.Join(defaultConfigDir, "chains")
 	defaultVMConfigDir          = filepath.Join(defaultConfigDir, "vms")
 	defaultVMAliasFilePath      = filepath.Join(defaultVMConfigDir, "aliases.json")
+	defaultRemoteVMsFilePath    = filepath.Join(defaultVMConfigDir, "remote.json")
 	defaultChainAliasFilePath   = filepath.Join(defaultChainConfigDir, "aliases.json")
 	defaultSubnetConfigDir      = filepath.Join(defaultConfigDir, "subnets")
 	defaultPluginDir            = filepath.Join(defaultUnexpandedDataDir, "plugins")
@@ -134,6 +136,7 @@ func addNodeFlags(fs *pflag.FlagSet) {
 	// Public IP Resolution
 	fs.String(PublicIPKey, "", "Public IP of this node for P2P communication")
 	fs.Duration(PublicIPResolutionFreqKey, 5*time.Minute, "Frequency at which this node resolves/updates its public IP and renew NAT mappings, if applicable")
+	fs.Uint(PublicPortKey, 0, "Public port of this node for P2P communication. If 0, the staking port is used")
 	fs.String(PublicIPResolutionServiceKey, "", fmt.Sprintf("Only acceptable values are %q, %q or %q. When provided, the node will use that service to periodically resolve/update its public IP", dynamicip.OpenDNSName, dynamicip.IFConfigCoName, dynamicip.IFConfigMeName))
 
 	// Inbound Connection Throttling
@@ -153,7 +156,8 @@ func addNodeFlags(fs *pflag.FlagSet) {
 	fs.Duration(NetworkPingTimeoutKey, constants.DefaultPingPongTimeout, "Timeout value for Ping-Pong with a peer")
 	fs.Duration(NetworkPingFrequencyKey, constants.DefaultPingFrequency, "Frequency of pinging other peers")
 
-	fs.String(NetworkCompressionTypeKey, constants.DefaultNetworkCompressionType.String(), fmt.Sprintf("Compression type for outbound messages. Must be one of [%s, %s]", compression.TypeZstd, compression.TypeNone))
+	fs.String(NetworkCompressionTypeKey, constants.DefaultNetworkCompressionType.String(), fmt.Sprintf("Compression type for outbound messages. If not none, messages sent to a peer are compressed with the compression type that the peer prefers most out of the supported compression types of this node instead. Must be one of [%s, %s, %s, %s]", compression.TypeZstd, compression.TypeLZ4, compression.TypeSnappy, compression.TypeNone))
+	fs.StringSlice(NetworkSupportedCompressionTypesKey, defaultSupportedCompressionTypes(), fmt.Sprintf("Compression types, in order of preference, that peers are asked to compress the messages they send to this node with. Messages are only compressed with these compression types, and messages compressed with any known compression type are accepted regardless. Must not be empty and must be a subset of [%s, %s, %s]", compression.TypeZstd, compression.TypeLZ4, compression.TypeSnappy))
 
 	fs.Duration(NetworkMaxClockDifferenceKey, constants.DefaultNetworkMaxClockDifference, "Max allowed clock difference value between this node and peers")
 	// Note: The default value is set to false here because the default
@@ -172,6 +176,12 @@ func addNodeFlags(fs *pflag.FlagSet) {
 	// a timeout of 0 should generally not be provided.
 	fs.Duration(NetworkTCPProxyReadTimeoutKey, constants.DefaultNetworkTCPProxyReadTimeout, "Maximum duration to wait for a TCP proxy header")
 
+	// Peer reputation
+	fs.Duration(NetworkReputationHalflifeKey, constants.DefaultNetworkReputationHalflife, "Halflife of the decay of a peer's reputation score towards 0. If 0, scores don't decay")
+	fs.Float64(NetworkReputationMaxScoreKey, constants.DefaultNetworkReputationMaxScore, "Maximum reputation score a peer can accumulate with good behavior. If 0, scores are unbounded")
+	fs.Float64(NetworkReputationBanThresholdKey, constants.DefaultNetworkReputationBanThreshold, "Reputation score at or below which a peer is disconnected and banned. Validators and beacons are never banned. If 0, peers are never banned")
+	fs.Duration(NetworkReputationBanDurationKey, constants.DefaultNetworkReputationBanDuration, "Duration for which connections with a banned peer are refused")
+
 	fs.String(NetworkTLSKeyLogFileKey, "", "TLS key log file path. Should only be specified for debugging")
 
 	// Benchlist
@@ -199,6 +209,14 @@ func addNodeFlags(fs *pflag.FlagSet) {
 	fs.Uint64(OutboundThrottlerVdrAllocSizeKey, constants.DefaultOutboundThrottlerVdrAllocSize, "Size, in bytes, of validator byte allocation in outbound message throttler")
 	fs.Uint64(OutboundThrottlerNodeMaxAtLargeBytesKey, constants.DefaultOutboundThrottlerNodeMaxAtLargeBytes, "Max number of bytes a node can take from the outbound message throttler's at-large allocation. Must be at least the max message size")
 
+	// Subnet Bandwidth Quotas
+	fs.Uint64(SubnetBandwidthQuotaRefillRateKey, constants.DefaultSubnetBandwidthQuotaRefillRate, "Max average bandwidth usage, in bytes per second, of the inbound and outbound messages of a subnet with a share of 1. If 0, the bandwidth of subnets isn't limited")
+	fs.Uint64(SubnetBandwidthQuotaMaxBurstSizeKey, constants.DefaultSubnetBandwidthQuotaMaxBurstSize, "Max bandwidth, in bytes, a subnet with a share of 1 can use at once. Scaled by each subnet's share, it must be at least the max message size")
+	fs.StringToString(SubnetBandwidthQuotaSharesKey, map[string]string{}, "Share of the bandwidth quota given to each subnet, formatted as subnetID=share. The primary network is only limited if it is given a share")
+	fs.Float64(SubnetBandwidthQuotaDefaultShareKey, constants.DefaultSubnetBandwidthQuotaDefaultShare, fmt.Sprintf("Share of the bandwidth quota given to subnets, other than the primary network, that aren't specified in %s", SubnetBandwidthQuotaSharesKey))
+	fs.StringToString(SubnetBandwidthQuotaOpSharesKey, map[string]string{message.AppGossipOp.String(): "0.5"}, "Share of a subnet's bandwidth quota that messages of an op can use, formatted as op=share")
+	fs.StringSlice(SubnetBandwidthQuotaPriorityOpsKey, defaultSubnetBandwidthQuotaPriorityOps(), "Ops whose messages are never dropped for exceeding a subnet's bandwidth quota, but still count against it")
+
 	// HTTP APIs
 	fs.String(HTTPHostKey, "127.0.0.1", "Address of the HTTP server. If the address is empty or a literal unspecified IP address, the server will bind on all available unicast and anycast IP addresses of the local system")
 	fs.Uint(HTTPPortKey, DefaultHTTPPort, "Port of the HTTP server. If the port is 0 a port number is automatically chosen")
@@ -207,6 +225,7 @@ func addNodeFlags(fs *pflag.FlagSet) {
 	fs.String(HTTPSKeyContentKey, "", "Specifies base64 encoded TLS private key for the HTTPs server")
 	fs.String(HTTPSCertFileKey, "", fmt.Sprintf("TLS certificate file for the HTTPs server. Ignored if %s is specified", HTTPSCertContentKey))
 	fs.String(HTTPSCertContentKey, "", "Specifies base64 encoded TLS certificate for the HTTPs server")
+	fs.String(HTTPSClientCAFileKey, "", fmt.Sprintf("PEM encoded CA certificates that TLS client certificates are verified against. Verified client certificates authenticate API callers by their common name. Requires %s", HTTPSEnabledKey))
 	fs.String(HTTPAllowedOrigins, "*", "Origins to allow on the HTTP port. Defaults to * which allows all origins. Example: https://*.avax.network https://*.avax-test.network")
 	fs.StringSlice(HTTPAllowedHostsKey, []string{"localhost"}, "List of acceptable host names in API requests. Provide the wildcard ('*') to accept requests from all hosts. API requests where the Host field is empty or an IP address will always be accepted. An API call whose HTTP Host field isn't acceptable will receive a 403 error code")
 	fs.Duration(HTTPShutdownWaitKey, 0, "Duration to wait after receiving SIGTERM or SIGINT before initiating shutdown. The /health endpoint will return unhealthy during this duration")
@@ -223,6 +242,27 @@ func addNodeFlags(fs *pflag.FlagSet) {
 	fs.Bool(MetricsAPIEnabledKey, true, "If true, this node exposes the Metrics API")
 	fs.Bool(HealthAPIEnabledKey, true, "If true, this node exposes the Health API")
 
+	// API Rate Limiting
+	fs.Float64(APIRateLimitRateKey, 0, "Rate, in tokens per second, at which the API budget of each client replenishes. Each API call consumes a token, or the cost of its method. Clients are identified by their API credentials or IP. If 0, API calls aren't rate limited")
+	fs.Uint(APIRateLimitBurstKey, 100, "Max number of tokens that can accumulate in the API budget of each client")
+	fs.Uint(APIRateLimitMaxClientsKey, 65536, "Max number of clients whose API budgets are tracked")
+	fs.String(APIRateLimitRulesFileKey, "", "JSON file specifying separate API budgets for routes, and the costs of JSON-RPC methods")
+
+	// API Authentication
+	fs.String(APIAuthPolicyFileKey, "", "Policy file mapping API callers to the routes and methods they may call. If empty, API calls aren't authenticated")
+	fs.String(APIAuthJWTKeyFileKey, "", fmt.Sprintf("Key that bearer JWTs are verified with. Either a PEM encoded public key or an HMAC key of at least 32 bytes. Requires %s", APIAuthPolicyFileKey))
+
+	// Audit Log
+	fs.Bool(AuditLogEnabledKey, false, "If true, calls to the Admin and Keystore APIs are recorded to a tamper-evident audit log")
+	fs.String(AuditLogFileKey, "", fmt.Sprintf("File the audit log is written to. Defaults to audit.log in the directory specified by %s. The head of the audit trail is persisted to %s in the same directory so that the trail continues across restarts and truncating it is detected. Only the head is written if %s is specified", LogsDirKey, auditLogHeadFileName, AuditLogSyslogAddressKey))
+	fs.Uint(AuditLogMaxSizeKey, 8, "The maximum file size in megabytes of the audit log file before it gets rotated")
+	fs.Uint(AuditLogMaxFilesKey, 0, "The maximum number of old audit log files to retain. 0 means retain all old audit log files")
+	fs.Uint(AuditLogMaxAgeKey, 0, "The maximum number of days to retain old audit log files based on the timestamp encoded in their filename. 0 means retain all old audit log files")
+	fs.Bool(AuditLogCompressEnabledKey, false, "Enables the compression of rotated audit log files through gzip")
+	fs.String(AuditLogSyslogNetworkKey, "", fmt.Sprintf("Network used to connect to %s. Options are [udp, tcp, unix]. If empty, the local syslog server is used", AuditLogSyslogAddressKey))
+	fs.String(AuditLogSyslogAddressKey, "", "If set, the audit log is sent to the syslog server at this address rather than written to a file")
+	fs.String(AuditLogKeyFileKey, "", fmt.Sprintf("File containing the key, of at least 32 bytes, that audit entries are authenticated with through HMAC-SHA256. Required if %s is true", AuditLogEnabledKey))
+
 	// Health Checks
 	fs.Duration(HealthCheckFreqKey, 30*time.Second, "Time between health checks")
 	fs.Duration(HealthCheckAveragerHalflifeKey, constants.DefaultHealthCheckAveragerHalflife, "Halflife of averager when calculating a running average in a health check")
@@ -248,6 +288,11 @@ func addNodeFlags(fs *pflag.FlagSet) {
 	fs.Bool(StakingEphemeralSignerEnabledKey, false, "If true, the node uses an ephemeral staking signer key")
 	fs.String(StakingSignerKeyPathKey, defaultStakingSignerKeyPath, fmt.Sprintf("Path to the signer private key for staking. Ignored if %s is specified", StakingSignerKeyContentKey))
 	fs.String(StakingSignerKeyContentKey, "", "Specifies base64 encoded signer private key for staking")
+	fs.String(StakingRemoteSignerEndpointKey, "", "Address of a remote signer that holds the staking TLS and signer keys. If specified, the staking keys are never read from or written to disk and the node fails to start if the remote signer is unavailable")
+	fs.String(StakingRemoteSignerTLSCertPathKey, "", "Path to the TLS certificate the node uses to authenticate to the remote signer")
+	fs.String(StakingRemoteSignerTLSKeyPathKey, "", "Path to the TLS private key the node uses to authenticate to the remote signer")
+	fs.String(StakingRemoteSignerTLSCAPathKey, "", "Path to the certificate authority used to authenticate the remote signer")
+	fs.Duration(StakingRemoteSignerTimeoutKey, 5*time.Second, "Timeout of requests to the remote signer")
 	fs.Bool(SybilProtectionEnabledKey, true, "Enables sybil protection. If enabled, Network TLS is required")
 	fs.Uint64(SybilProtectionDisabledWeightKey, 100, "Weight to provide to each peer when sybil protection is disabled")
 	fs.Bool(PartialSyncPrimaryNetworkKey, false, "Only sync the P-chain on the Primary Network. If the node is a Primary Network validator, it will report unhealthy")
@@ -330,6 +375,8 @@ func addNodeFlags(fs *pflag.FlagSet) {
 	// Aliasing
 	fs.String(VMAliasesFileKey, defaultVMAliasFilePath, fmt.Sprintf("Specifies a JSON file that maps vmIDs with custom aliases. Ignored if %s is specified", VMAliasesContentKey))
 	fs.String(VMAliasesContentKey, "", "Specifies base64 encoded maps vmIDs with custom aliases")
+	fs.String(RemoteVMsFileKey, defaultRemoteVMsFilePath, fmt.Sprintf("Specifies a JSON file that maps vmIDs or VM aliases to VM servers that are reached over mutual TLS instead of being started from the plugin directory. Ignored if %s is specified", RemoteVMsContentKey))
+	fs.String(RemoteVMsContentKey, "", "Specifies base64 encoded map from vmIDs or VM aliases to remote VM servers")
 	fs.String(ChainAliasesFileKey, defaultChainAliasFilePath, fmt.Sprintf("Specifies a JSON file that maps blockchainIDs with custom aliases. Ignored if %s is specified", ChainConfigContentKey))
 	fs.String(ChainAliasesContentKey, "", "Specifies base64 encoded map from blockchainID to custom aliases")
 
@@ -374,6 +421,25 @@ func BuildFlagSet() *pflag.FlagSet {
 	return fs
 }
 
+// defaultSubnetBandwidthQuotaPriorityOps returns the consensus response ops.
+// Responses are only sent in reply to this node's requests, so their bandwidth
+// is bounded by this node and dropping them would only cause timeouts.
+func defaultSubnetBandwidthQuotaPriorityOps() []string {
+	ops := make([]string, len(message.ConsensusResponseOps))
+	for i, op := range message.ConsensusResponseOps {
+		ops[i] = op.String()
+	}
+	return ops
+}
+
+func defaultSupportedCompressionTypes() []string {
+	compressionTypes := make([]string, len(message.CompressionTypes))
+	for i, compressionType := range message.CompressionTypes {
+		compressionTypes[i] = compressionType.String()
+	}
+	return compressionTypes
+}
+
 // GetExpandedArg gets the string in viper corresponding to [key] and expands
 // any variables using the OS env. If the [AvalancheGoDataDirVar] var is used,
 // we expand the value of the variable with the string in viper corresponding to
@@ -43,6 +43,7 @@ const (
 	PublicIPKey                                        = "public-ip"
 	PublicIPResolutionFreqKey                          = "public-ip-resolution-frequency"
 	PublicIPResolutionServiceKey                       = "public-ip-resolution-service"
+	PublicPortKey                                      = "public-port"
 	HTTPHostKey                                        = "http-host"
 	HTTPPortKey                                        = "http-port"
 	HTTPSEnabledKey                                    = "http-tls-enabled"
@@ -50,6 +51,7 @@ const (
 	HTTPSKeyContentKey                                 = "http-tls-key-file-content"
 	HTTPSCertFileKey                                   = "http-tls-cert-file"
 	HTTPSCertContentKey                                = "http-tls-cert-file-content"
+	HTTPSClientCAFileKey                               = "http-tls-client-ca-file"
 	HTTPAllowedOrigins                                 = "http-allowed-origins"
 	HTTPAllowedHostsKey                                = "http-allowed-hosts"
 	HTTPShutdownTimeoutKey                             = "http-shutdown-timeout"
@@ -72,6 +74,11 @@ const (
 	StakingEphemeralSignerEnabledKey                   = "staking-ephemeral-signer-enabled"
 	StakingSignerKeyPathKey                            = "staking-signer-key-file"
 	StakingSignerKeyContentKey                         = "staking-signer-key-file-content"
+	StakingRemoteSignerEndpointKey                     = "staking-remote-signer-endpoint"
+	StakingRemoteSignerTLSCertPathKey                  = "staking-remote-signer-tls-cert-file"
+	StakingRemoteSignerTLSKeyPathKey                   = "staking-remote-signer-tls-key-file"
+	StakingRemoteSignerTLSCAPathKey                    = "staking-remote-signer-tls-ca-file"
+	StakingRemoteSignerTimeoutKey                      = "staking-remote-signer-timeout"
 	SybilProtectionEnabledKey                          = "sybil-protection-enabled"
 	SybilProtectionDisabledWeightKey                   = "sybil-protection-disabled-weight"
 	NetworkInitialTimeoutKey                           = "network-initial-timeout"
@@ -95,6 +102,7 @@ const (
 	NetworkPingFrequencyKey                            = "network-ping-frequency"
 	NetworkMaxReconnectDelayKey                        = "network-max-reconnect-delay"
 	NetworkCompressionTypeKey                          = "network-compression-type"
+	NetworkSupportedCompressionTypesKey                = "network-supported-compression-types"
 	NetworkMaxClockDifferenceKey                       = "network-max-clock-difference"
 	NetworkAllowPrivateIPsKey                          = "network-allow-private-ips"
 	NetworkRequireValidatorToConnectKey                = "network-require-validator-to-connect"
@@ -102,6 +110,10 @@ const (
 	NetworkPeerWriteBufferSizeKey                      = "network-peer-write-buffer-size"
 	NetworkTCPProxyEnabledKey                          = "network-tcp-proxy-enabled"
 	NetworkTCPProxyReadTimeoutKey                      = "network-tcp-proxy-read-timeout"
+	NetworkReputationHalflifeKey                       = "network-reputation-halflife"
+	NetworkReputationMaxScoreKey                       = "network-reputation-max-score"
+	NetworkReputationBanThresholdKey                   = "network-reputation-ban-threshold"
+	NetworkReputationBanDurationKey                    = "network-reputation-ban-duration"
 	NetworkTLSKeyLogFileKey                            = "network-tls-key-log-file-unsafe"
 	NetworkInboundConnUpgradeThrottlerCooldownKey      = "network-inbound-connection-throttling-cooldown"
 	NetworkInboundThrottlerMaxConnsPerSecKey           = "network-inbound-connection-throttling-max-conns-per-sec"
@@ -137,6 +149,21 @@ const (
 	KeystoreAPIEnabledKey                              = "api-keystore-enabled"
 	MetricsAPIEnabledKey                               = "api-metrics-enabled"
 	HealthAPIEnabledKey                                = "api-health-enabled"
+	APIRateLimitRateKey                                = "api-rate-limit-rate"
+	APIRateLimitBurstKey                               = "api-rate-limit-burst"
+	APIRateLimitMaxClientsKey                          = "api-rate-limit-max-clients"
+	APIRateLimitRulesFileKey                           = "api-rate-limit-rules-file"
+	APIAuthPolicyFileKey                               = "api-auth-policy-file"
+	APIAuthJWTKeyFileKey                               = "api-auth-jwt-key-file"
+	AuditLogEnabledKey                                 = "api-audit-log-enabled"
+	AuditLogFileKey                                    = "api-audit-log-file"
+	AuditLogMaxSizeKey                                 = "api-audit-log-max-size"
+	AuditLogMaxFilesKey                                = "api-audit-log-max-files"
+	AuditLogMaxAgeKey                                  = "api-audit-log-max-age"
+	AuditLogCompressEnabledKey                         = "api-audit-log-compress-enabled"
+	AuditLogSyslogNetworkKey                           = "api-audit-log-syslog-network"
+	AuditLogSyslogAddressKey                           = "api-audit-log-syslog-address"
+	AuditLogKeyFileKey                                 = "api-audit-log-key-file"
 	MeterVMsEnabledKey                                 = "meter-vms-enabled"
 	ConsensusAppConcurrencyKey                         = "consensus-app-concurrency"
 	ConsensusShutdownTimeoutKey                        = "consensus-shutdown-timeout"
@@ -186,9 +213,17 @@ const (
 	OutboundThrottlerAtLargeAllocSizeKey               = "throttler-outbound-at-large-alloc-size"
 	OutboundThrottlerVdrAllocSizeKey                   = "throttler-outbound-validator-alloc-size"
 	OutboundThrottlerNodeMaxAtLargeBytesKey            = "throttler-outbound-node-max-at-large-bytes"
+	SubnetBandwidthQuotaRefillRateKey                  = "throttler-subnet-bandwidth-quota-refill-rate"
+	SubnetBandwidthQuotaMaxBurstSizeKey                = "throttler-subnet-bandwidth-quota-max-burst-size"
+	SubnetBandwidthQuotaSharesKey                      = "throttler-subnet-bandwidth-quota-shares"
+	SubnetBandwidthQuotaDefaultShareKey                = "throttler-subnet-bandwidth-quota-default-share"
+	SubnetBandwidthQuotaOpSharesKey                    = "throttler-subnet-bandwidth-quota-op-shares"
+	SubnetBandwidthQuotaPriorityOpsKey                 = "throttler-subnet-bandwidth-quota-priority-ops"
 	UptimeMetricFreqKey                                = "uptime-metric-freq"
 	VMAliasesFileKey                                   = "vm-aliases-file"
 	VMAliasesContentKey                                = "vm-aliases-file-content"
+	RemoteVMsFileKey                                   = "remote-vms-file"
+	RemoteVMsContentKey                                = "remote-vms-file-content"
 	ChainAliasesFileKey                                = "chain-aliases-file"
 	ChainAliasesContentKey                             = "chain-aliases-file-content"
 	TracingEnabledKey                                  = "tracing-enabled"
@@ -0,0 +1,152 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"syscall"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/spf13/cobra"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/database/leveldb"
+	"github.com/ava-labs/avalanchego/database/migrate"
+	"github.com/ava-labs/avalanchego/database/pebble"
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/ava-labs/avalanchego/version"
+)
+
+var (
+	errDBDirRequired = errors.New("--db-dir is required")
+	errSameDBType    = errors.New("--from and --to must be different")
+)
+
+// dbPath returns the directory that a node stores a database of [dbType] in,
+// given the network specific database directory [dir].
+func dbPath(dir string, dbType string) (string, error) {
+	switch dbType {
+	case leveldb.Name:
+		return filepath.Join(dir, version.CurrentDatabase.String()), nil
+	case pebble.Name:
+		return filepath.Join(dir, pebble.Name), nil
+	default:
+		return "", fmt.Errorf("db type was %q but should have been one of {%s, %s}",
+			dbType,
+			leveldb.Name,
+			pebble.Name,
+		)
+	}
+}
+
+func openDB(dbType string, path string, configFile string, log logging.Logger) (database.Database, error) {
+	var (
+		configBytes []byte
+		err         error
+	)
+	if len(configFile) > 0 {
+		configBytes, err = os.ReadFile(configFile)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	switch dbType {
+	case leveldb.Name:
+		return leveldb.New(path, configBytes, log, "", prometheus.NewRegistry())
+	case pebble.Name:
+		return pebble.New(path, configBytes, log, "", prometheus.NewRegistry())
+	default:
+		return nil, fmt.Errorf("db type was %q but should have been one of {%s, %s}",
+			dbType,
+			leveldb.Name,
+			pebble.Name,
+		)
+	}
+}
+
+func main() {
+	var (
+		dbDir          string
+		from           string
+		to             string
+		fromConfigFile string
+		toConfigFile   string
+		config         = migrate.DefaultConfig
+	)
+	rootCmd := &cobra.Command{
+		Use:   "dbmigrate",
+		Short: "Convert a stopped node's database between leveldb and pebble",
+		RunE: func(*cobra.Command, []string) (err error) {
+			if len(dbDir) == 0 {
+				return errDBDirRequired
+			}
+			if from == to {
+				return errSameDBType
+			}
+			srcPath, err := dbPath(dbDir, from)
+			if err != nil {
+				return err
+			}
+			dstPath, err := dbPath(dbDir, to)
+			if err != nil {
+				return err
+			}
+			if _, err := os.Stat(srcPath); err != nil {
+				return fmt.Errorf("failed to find source database: %w", err)
+			}
+
+			log := logging.NewLogger(
+				"dbmigrate",
+				logging.NewWrappedCore(logging.Info, os.Stdout, logging.Plain.ConsoleEncoder()),
+			)
+
+			src, err := openDB(from, srcPath, fromConfigFile, log)
+			if err != nil {
+				return fmt.Errorf("failed to open source database: %w", err)
+			}
+			defer func() {
+				err = errors.Join(err, src.Close())
+			}()
+
+			dst, err := openDB(to, dstPath, toConfigFile, log)
+			if err != nil {
+				return fmt.Errorf("failed to open destination database: %w", err)
+			}
+			defer func() {
+				err = errors.Join(err, dst.Close())
+			}()
+
+			ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+			defer cancel()
+
+			stats, err := migrate.Migrate(ctx, log, config, src, dst)
+			if err != nil {
+				return err
+			}
+
+			fmt.Fprintf(os.Stdout, "Migrated %d keys (%d bytes) from %s to %s\n", stats.Keys, stats.Bytes, srcPath, dstPath)
+			fmt.Fprintf(os.Stdout, "Start the node with --db-type=%s to use the migrated database\n", to)
+			return nil
+		},
+	}
+	rootCmd.PersistentFlags().StringVar(&dbDir, "db-dir", "", "The network specific database directory of the node (e.g. ~/.avalanchego/db/mainnet)")
+	rootCmd.PersistentFlags().StringVar(&from, "from", leveldb.Name, fmt.Sprintf("The database type to migrate from. Must be one of {%s, %s}", leveldb.Name, pebble.Name))
+	rootCmd.PersistentFlags().StringVar(&to, "to", pebble.Name, fmt.Sprintf("The database type to migrate to. Must be one of {%s, %s}", leveldb.Name, pebble.Name))
+	rootCmd.PersistentFlags().StringVar(&fromConfigFile, "from-config-file", "", "[optional] path to the config file of the source database")
+	rootCmd.PersistentFlags().StringVar(&toConfigFile, "to-config-file", "", "[optional] path to the config file of the destination database")
+	rootCmd.PersistentFlags().IntVar(&config.BatchSize, "batch-size", config.BatchSize, "The number of bytes to write to the destination database per batch")
+	rootCmd.PersistentFlags().DurationVar(&config.ProgressInterval, "progress-interval", config.ProgressInterval, "How frequently to log progress")
+
+	if err := rootCmd.Execute(); err != nil {
+		fmt.Fprintf(os.Stderr, "dbmigrate failed: %v\n", err)
+		os.Exit(1)
+	}
+	os.Exit(0)
+}
@@ -0,0 +1,243 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+// Package migrate copies the contents of one database into another, such as
+// when moving a node's data directory between database backends.
+package migrate
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash"
+	"math"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/ava-labs/avalanchego/utils/timer"
+	"github.com/ava-labs/avalanchego/utils/units"
+)
+
+var (
+	ErrDestinationNotEmpty = errors.New("destination database is not empty")
+	ErrChecksumMismatch    = errors.New("checksum mismatch")
+
+	DefaultConfig = Config{
+		BatchSize:        4 * units.MiB,
+		ProgressInterval: 10 * time.Second,
+	}
+)
+
+type Config struct {
+	// BatchSize is the number of bytes written to the destination database
+	// per batch.
+	BatchSize int `json:"batchSize"`
+	// ProgressInterval is how frequently progress is logged.
+	ProgressInterval time.Duration `json:"progressInterval"`
+}
+
+// Stats describes the key-value pairs that were processed.
+type Stats struct {
+	Keys  uint64 `json:"keys"`
+	Bytes uint64 `json:"bytes"`
+}
+
+func (s *Stats) add(key, value []byte) {
+	s.Keys++
+	s.Bytes += uint64(len(key) + len(value))
+}
+
+// Migrate copies every key-value pair in [src] into the empty database [dst].
+// After the copy completes, both databases are re-read and their checksums
+// are compared to verify that [dst] is an exact copy of [src].
+//
+// [src] must not be modified while the migration is in progress.
+func Migrate(
+	ctx context.Context,
+	log logging.Logger,
+	config Config,
+	src database.Iteratee,
+	dst database.Database,
+) (Stats, error) {
+	isEmpty, err := database.IsEmpty(dst)
+	if err != nil {
+		return Stats{}, err
+	}
+	if !isEmpty {
+		return Stats{}, ErrDestinationNotEmpty
+	}
+
+	log.Info("copying database")
+	stats, err := Copy(ctx, log, config, src, dst)
+	if err != nil {
+		return stats, fmt.Errorf("failed to copy database: %w", err)
+	}
+	log.Info("copied database",
+		zap.Uint64("numKeys", stats.Keys),
+		zap.Uint64("numBytes", stats.Bytes),
+	)
+
+	log.Info("verifying source database checksum")
+	srcChecksum, srcStats, err := Checksum(ctx, log, config, src)
+	if err != nil {
+		return stats, fmt.Errorf("failed to calculate source checksum: %w", err)
+	}
+	log.Info("verifying destination database checksum")
+	dstChecksum, dstStats, err := Checksum(ctx, log, config, dst)
+	if err != nil {
+		return stats, fmt.Errorf("failed to calculate destination checksum: %w", err)
+	}
+	if srcChecksum != dstChecksum || srcStats != dstStats {
+		return stats, fmt.Errorf("%w: source (%s, %d keys) != destination (%s, %d keys)",
+			ErrChecksumMismatch,
+			srcChecksum,
+			srcStats.Keys,
+			dstChecksum,
+			dstStats.Keys,
+		)
+	}
+
+	log.Info("verified database checksum",
+		zap.Stringer("checksum", dstChecksum),
+	)
+	return stats, nil
+}
+
+// Copy writes every key-value pair in [src] into [dst].
+func Copy(
+	ctx context.Context,
+	log logging.Logger,
+	config Config,
+	src database.Iteratee,
+	dst database.Batcher,
+) (Stats, error) {
+	var (
+		it       = src.NewIterator()
+		batch    = dst.NewBatch()
+		progress = newProgressLogger(log, config.ProgressInterval, "copying database")
+		stats    Stats
+	)
+	defer it.Release()
+
+	for it.Next() {
+		if err := ctx.Err(); err != nil {
+			return stats, err
+		}
+
+		key := it.Key()
+		value := it.Value()
+		if err := batch.Put(key, value); err != nil {
+			return stats, err
+		}
+		stats.add(key, value)
+
+		if batch.Size() >= config.BatchSize {
+			if err := batch.Write(); err != nil {
+				return stats, err
+			}
+			batch.Reset()
+		}
+		progress.update(key, stats)
+	}
+	if err := it.Error(); err != nil {
+		return stats, err
+	}
+	return stats, batch.Write()
+}
+
+// Checksum returns a hash committing to every key-value pair in [db] in
+// iteration order.
+func Checksum(
+	ctx context.Context,
+	log logging.Logger,
+	config Config,
+	db database.Iteratee,
+) (ids.ID, Stats, error) {
+	var (
+		it       = db.NewIterator()
+		hasher   = sha256.New()
+		progress = newProgressLogger(log, config.ProgressInterval, "calculating checksum")
+		stats    Stats
+	)
+	defer it.Release()
+
+	for it.Next() {
+		if err := ctx.Err(); err != nil {
+			return ids.Empty, stats, err
+		}
+
+		key := it.Key()
+		value := it.Value()
+		writeLengthPrefixed(hasher, key)
+		writeLengthPrefixed(hasher, value)
+		stats.add(key, value)
+
+		progress.update(key, stats)
+	}
+	if err := it.Error(); err != nil {
+		return ids.Empty, stats, err
+	}
+
+	var checksum ids.ID
+	copy(checksum[:], hasher.Sum(nil))
+	return checksum, stats, nil
+}
+
+// writeLengthPrefixed writes [b] to [h] such that the boundaries between the
+// written values are unambiguous.
+func writeLengthPrefixed(h hash.Hash, b []byte) {
+	var length [binary.MaxVarintLen64]byte
+	n := binary.PutUvarint(length[:], uint64(len(b)))
+	_, _ = h.Write(length[:n])
+	_, _ = h.Write(b)
+}
+
+// progressLogger periodically logs the progress of iterating over a database.
+//
+// The keys of the node's database are prefixed by hashes, so the progress is
+// estimated by assuming keys are uniformly distributed.
+type progressLogger struct {
+	log       logging.Logger
+	interval  time.Duration
+	msg       string
+	startTime time.Time
+	lastLog   time.Time
+}
+
+func newProgressLogger(log logging.Logger, interval time.Duration, msg string) *progressLogger {
+	now := time.Now()
+	return &progressLogger{
+		log:       log,
+		interval:  interval,
+		msg:       msg,
+		startTime: now,
+		lastLog:   now,
+	}
+}
+
+func (p *progressLogger) update(key []byte, stats Stats) {
+	if time.Since(p.lastLog) < p.interval {
+		return
+	}
+	p.lastLog = time.Now()
+
+	progress := timer.ProgressFromHash(key)
+	fields := []zap.Field{
+		zap.Uint64("numKeys", stats.Keys),
+		zap.Uint64("numBytes", stats.Bytes),
+	}
+	// The estimate is meaningless until some progress has been made.
+	if progress > 0 {
+		fields = append(fields,
+			zap.String("progress", fmt.Sprintf("%.2f%%", 100*float64(progress)/math.MaxUint64)),
+			zap.Duration("eta", timer.EstimateETA(p.startTime, progress, math.MaxUint64)),
+		)
+	}
+	p.log.Info(p.msg, fields...)
+}
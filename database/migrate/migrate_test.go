@@ -0,0 +1,125 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package migrate
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/database/memdb"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils"
+	"github.com/ava-labs/avalanchego/utils/logging"
+)
+
+func newPopulatedDB(require *require.Assertions, numKeys int) database.Database {
+	db := memdb.New()
+	for i := 0; i < numKeys; i++ {
+		require.NoError(db.Put(utils.RandomBytes(32), utils.RandomBytes(i)))
+	}
+	return db
+}
+
+func TestMigrate(t *testing.T) {
+	require := require.New(t)
+
+	src := newPopulatedDB(require, 100)
+	dst := memdb.New()
+
+	config := DefaultConfig
+	config.BatchSize = 256
+	stats, err := Migrate(context.Background(), logging.NoLog{}, config, src, dst)
+	require.NoError(err)
+	require.Equal(uint64(100), stats.Keys)
+
+	srcChecksum, srcStats, err := Checksum(context.Background(), logging.NoLog{}, config, src)
+	require.NoError(err)
+	dstChecksum, dstStats, err := Checksum(context.Background(), logging.NoLog{}, config, dst)
+	require.NoError(err)
+	require.Equal(srcChecksum, dstChecksum)
+	require.Equal(stats, srcStats)
+	require.Equal(stats, dstStats)
+}
+
+func TestMigrateDestinationNotEmpty(t *testing.T) {
+	require := require.New(t)
+
+	src := newPopulatedDB(require, 10)
+	dst := newPopulatedDB(require, 1)
+
+	_, err := Migrate(context.Background(), logging.NoLog{}, DefaultConfig, src, dst)
+	require.ErrorIs(err, ErrDestinationNotEmpty)
+}
+
+func TestMigrateCanceled(t *testing.T) {
+	require := require.New(t)
+
+	src := newPopulatedDB(require, 10)
+	dst := memdb.New()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err := Migrate(ctx, logging.NoLog{}, DefaultConfig, src, dst)
+	require.ErrorIs(err, context.Canceled)
+}
+
+func TestChecksum(t *testing.T) {
+	tests := []struct {
+		name          string
+		a             map[string]string
+		b             map[string]string
+		expectedEqual bool
+	}{
+		{
+			name:          "empty",
+			a:             map[string]string{},
+			b:             map[string]string{},
+			expectedEqual: true,
+		},
+		{
+			name:          "same",
+			a:             map[string]string{"a": "1", "b": "2"},
+			b:             map[string]string{"a": "1", "b": "2"},
+			expectedEqual: true,
+		},
+		{
+			name:          "different value",
+			a:             map[string]string{"a": "1", "b": "2"},
+			b:             map[string]string{"a": "1", "b": "3"},
+			expectedEqual: false,
+		},
+		{
+			name:          "missing key",
+			a:             map[string]string{"a": "1", "b": "2"},
+			b:             map[string]string{"a": "1"},
+			expectedEqual: false,
+		},
+		{
+			name:          "moved boundary",
+			a:             map[string]string{"ab": "c"},
+			b:             map[string]string{"a": "bc"},
+			expectedEqual: false,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require := require.New(t)
+
+			checksum := func(kvs map[string]string) ids.ID {
+				db := memdb.New()
+				for k, v := range kvs {
+					require.NoError(db.Put([]byte(k), []byte(v)))
+				}
+				checksum, _, err := Checksum(context.Background(), logging.NoLog{}, DefaultConfig, db)
+				require.NoError(err)
+				return checksum
+			}
+			require.Equal(test.expectedEqual, checksum(test.a) == checksum(test.b))
+		})
+	}
+}
@@ -8,10 +8,12 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"slices"
 	"sync"
 
 	"github.com/cockroachdb/pebble"
+	"github.com/cockroachdb/pebble/bloom"
 	"github.com/prometheus/client_golang/prometheus"
 	"go.uber.org/zap"
 
@@ -27,12 +29,16 @@ const (
 	// pebbleByteOverHead is the number of bytes of constant overhead that
 	// should be added to a batch size per operation.
 	pebbleByteOverHead = 8
+
+	// numLevels is the number of levels in the pebble LSM.
+	numLevels = 7
 )
 
 var (
 	_ database.Database = (*Database)(nil)
 
 	errInvalidOperation = errors.New("invalid operation")
+	errInvalidConfig    = errors.New("invalid config")
 
 	defaultCacheSize = 512 * units.MiB
 	DefaultConfig    = Config{
@@ -43,6 +49,9 @@ var (
 		MemTableSize:                defaultCacheSize / 4,
 		MaxOpenFiles:                4096,
 		MaxConcurrentCompactions:    1,
+		// Bloom filters are opt-in so that the tables written by existing
+		// nodes keep their layout.
+		BloomFilterBitsPerKey: 0,
 	}
 
 	DefaultConfigBytes []byte
@@ -64,13 +73,29 @@ type Database struct {
 }
 
 type Config struct {
-	CacheSize                   int `json:"cacheSize"`
+	CacheSize                   int `json:"cacheSize"` // size of the block cache
 	BytesPerSync                int `json:"bytesPerSync"`
 	WALBytesPerSync             int `json:"walBytesPerSync"` // 0 means no background syncing
 	MemTableStopWritesThreshold int `json:"memTableStopWritesThreshold"`
 	MemTableSize                int `json:"memTableSize"`
 	MaxOpenFiles                int `json:"maxOpenFiles"`
 	MaxConcurrentCompactions    int `json:"maxConcurrentCompactions"`
+	BloomFilterBitsPerKey       int `json:"bloomFilterBitsPerKey"` // 0 disables bloom filters
+}
+
+func (c *Config) Verify() error {
+	switch {
+	case c.CacheSize < 0:
+		return fmt.Errorf("%w: cacheSize (%d) < 0", errInvalidConfig, c.CacheSize)
+	case c.MemTableSize <= 0:
+		return fmt.Errorf("%w: memTableSize (%d) <= 0", errInvalidConfig, c.MemTableSize)
+	case c.MaxConcurrentCompactions <= 0:
+		return fmt.Errorf("%w: maxConcurrentCompactions (%d) <= 0", errInvalidConfig, c.MaxConcurrentCompactions)
+	case c.BloomFilterBitsPerKey < 0:
+		return fmt.Errorf("%w: bloomFilterBitsPerKey (%d) < 0", errInvalidConfig, c.BloomFilterBitsPerKey)
+	default:
+		return nil
+	}
 }
 
 // TODO: Add metrics
@@ -81,9 +106,16 @@ func New(file string, configBytes []byte, log logging.Logger, _ string, _ promet
 			return nil, err
 		}
 	}
+	if err := cfg.Verify(); err != nil {
+		return nil, err
+	}
+
+	// The cache is reference counted by the database after it is opened.
+	cache := pebble.NewCache(int64(cfg.CacheSize))
+	defer cache.Unref()
 
 	opts := &pebble.Options{
-		Cache:                       pebble.NewCache(int64(cfg.CacheSize)),
+		Cache:                       cache,
 		BytesPerSync:                cfg.BytesPerSync,
 		Comparer:                    pebble.DefaultComparer,
 		WALBytesPerSync:             cfg.WALBytesPerSync,
@@ -93,6 +125,14 @@ func New(file string, configBytes []byte, log logging.Logger, _ string, _ promet
 		MaxConcurrentCompactions:    func() int { return cfg.MaxConcurrentCompactions },
 	}
 	opts.Experimental.ReadSamplingMultiplier = -1 // Disable seek compaction
+	if cfg.BloomFilterBitsPerKey > 0 {
+		filterPolicy := bloom.FilterPolicy(cfg.BloomFilterBitsPerKey)
+		opts.Levels = make([]pebble.LevelOptions, numLevels)
+		for i := range opts.Levels {
+			opts.Levels[i].FilterPolicy = filterPolicy
+			opts.Levels[i].FilterType = pebble.TableFilter
+		}
+	}
 
 	log.Info(
 		"opening pebble",
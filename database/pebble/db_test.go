@@ -4,6 +4,7 @@
 package pebble
 
 import (
+	"encoding/json"
 	"fmt"
 	"testing"
 
@@ -154,3 +155,76 @@ func TestKeyRange(t *testing.T) {
 		})
 	}
 }
+
+func TestConfigVerify(t *testing.T) {
+	tests := []struct {
+		name        string
+		modify      func(*Config)
+		expectedErr error
+	}{
+		{
+			name:        "default",
+			modify:      func(*Config) {},
+			expectedErr: nil,
+		},
+		{
+			name: "bloom filters enabled",
+			modify: func(c *Config) {
+				c.BloomFilterBitsPerKey = 10
+			},
+			expectedErr: nil,
+		},
+		{
+			name: "negative cache size",
+			modify: func(c *Config) {
+				c.CacheSize = -1
+			},
+			expectedErr: errInvalidConfig,
+		},
+		{
+			name: "zero memtable size",
+			modify: func(c *Config) {
+				c.MemTableSize = 0
+			},
+			expectedErr: errInvalidConfig,
+		},
+		{
+			name: "zero compaction concurrency",
+			modify: func(c *Config) {
+				c.MaxConcurrentCompactions = 0
+			},
+			expectedErr: errInvalidConfig,
+		},
+		{
+			name: "negative bloom filter bits per key",
+			modify: func(c *Config) {
+				c.BloomFilterBitsPerKey = -1
+			},
+			expectedErr: errInvalidConfig,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require := require.New(t)
+
+			config := DefaultConfig
+			test.modify(&config)
+			require.ErrorIs(config.Verify(), test.expectedErr)
+
+			configBytes, err := json.Marshal(config)
+			require.NoError(err)
+
+			db, err := New(t.TempDir(), configBytes, logging.NoLog{}, "pebble", prometheus.NewRegistry())
+			require.ErrorIs(err, test.expectedErr)
+			if err != nil {
+				return
+			}
+
+			require.NoError(db.Put([]byte("key"), []byte("value")))
+			value, err := db.Get([]byte("key"))
+			require.NoError(err)
+			require.Equal([]byte("value"), value)
+			require.NoError(db.Close())
+		})
+	}
+}
@@ -28,6 +28,7 @@ var (
 			AddPrimaryNetworkDelegatorFee: 0,
 			AddSubnetValidatorFee:         units.MilliAvax,
 			AddSubnetDelegatorFee:         units.MilliAvax,
+			DynamicFeeConfig:              defaultDynamicFeeConfig,
 		},
 		StakingConfig: StakingConfig{
 			UptimeRequirement: .8, // 80%
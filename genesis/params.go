@@ -7,9 +7,46 @@ import (
 	"time"
 
 	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/ava-labs/avalanchego/utils/units"
+	"github.com/ava-labs/avalanchego/vms/components/fees"
 	"github.com/ava-labs/avalanchego/vms/platformvm/reward"
 )
 
+// defaultDynamicFeeConfig prices a simple transfer, with two inputs, two
+// outputs and two signatures, at roughly the static [TxFee] of mainnet.
+var defaultDynamicFeeConfig = fees.DynamicFeesConfig{
+	InitialFeeRate: fees.Dimensions{
+		fees.Bandwidth: 500 * units.NanoAvax,
+		fees.UTXORead:  100 * units.MicroAvax,
+		fees.UTXOWrite: 200 * units.MicroAvax,
+		fees.Compute:   100 * units.NanoAvax,
+	},
+	MinFeeRate: fees.Dimensions{
+		fees.Bandwidth: 50 * units.NanoAvax,
+		fees.UTXORead:  10 * units.MicroAvax,
+		fees.UTXOWrite: 20 * units.MicroAvax,
+		fees.Compute:   10 * units.NanoAvax,
+	},
+	UpdateDenominator: fees.Dimensions{
+		fees.Bandwidth: 8,
+		fees.UTXORead:  8,
+		fees.UTXOWrite: 8,
+		fees.Compute:   8,
+	},
+	BlockMaxComplexity: fees.Dimensions{
+		fees.Bandwidth: 2 * units.MiB,
+		fees.UTXORead:  10_000,
+		fees.UTXOWrite: 10_000,
+		fees.Compute:   10_000_000,
+	},
+	BlockTargetComplexityRate: fees.Dimensions{
+		fees.Bandwidth: 128 * units.KiB,
+		fees.UTXORead:  500,
+		fees.UTXOWrite: 500,
+		fees.Compute:   500_000,
+	},
+}
+
 type StakingConfig struct {
 	// Staking uptime requirements
 	UptimeRequirement float64 `json:"uptimeRequirement"`
@@ -52,6 +89,8 @@ type TxFeeConfig struct {
 	AddSubnetValidatorFee uint64 `json:"addSubnetValidatorFee"`
 	// Transaction fee for adding a subnet delegator
 	AddSubnetDelegatorFee uint64 `json:"addSubnetDelegatorFee"`
+	// Dynamic fee parameters used once the E upgrade is activated
+	DynamicFeeConfig fees.DynamicFeesConfig `json:"dynamicFeeConfig"`
 }
 
 type Params struct {
@@ -46,6 +46,10 @@ type index struct {
 	lock  sync.RWMutex
 	// The index of the next accepted transaction
 	nextAcceptedIndex uint64
+	// Closed and replaced each time a container is accepted. Closed without
+	// replacement when the index is closed.
+	accepted chan struct{}
+	closed   bool
 	// When [baseDB] is committed, writes to [baseDB]
 	vDB    *versiondb.Database
 	baseDB database.Database
@@ -76,6 +80,7 @@ func newIndex(
 		indexToContainer: indexToContainer,
 		containerToIndex: containerToIndex,
 		log:              log,
+		accepted:         make(chan struct{}),
 	}
 
 	// Get next accepted index from db
@@ -97,8 +102,18 @@ func newIndex(
 	return i, nil
 }
 
-// Close this index
+// Close this index. Closing an index more than once returns
+// database.ErrClosed.
 func (i *index) Close() error {
+	i.lock.Lock()
+	if i.closed {
+		i.lock.Unlock()
+		return database.ErrClosed
+	}
+	i.closed = true
+	close(i.accepted)
+	i.lock.Unlock()
+
 	return utils.Err(
 		i.indexToContainer.Close(),
 		i.containerToIndex.Close(),
@@ -159,7 +174,14 @@ func (i *index) Accept(ctx *snow.ConsensusContext, containerID ids.ID, container
 	}
 
 	// Atomically commit [i.vDB], [i.indexToContainer], [i.containerToIndex] to [i.baseDB]
-	return i.vDB.Commit()
+	if err := i.vDB.Commit(); err != nil {
+		return err
+	}
+
+	// Wake up anyone waiting for this container to be accepted
+	close(i.accepted)
+	i.accepted = make(chan struct{})
+	return nil
 }
 
 // Returns the ID of the [index]th accepted container and the container itself.
@@ -271,6 +293,19 @@ func (i *index) GetLastAccepted() (Container, error) {
 	return i.getContainerByIndex(lastAcceptedIndex)
 }
 
+// NextAcceptedIndex returns the index that will be assigned to the next
+// accepted container along with a channel that is closed once that container
+// has been accepted, or once this index has been closed.
+func (i *index) NextAcceptedIndex() (uint64, <-chan struct{}, error) {
+	i.lock.RLock()
+	defer i.lock.RUnlock()
+
+	if i.closed {
+		return 0, nil, database.ErrClosed
+	}
+	return i.nextAcceptedIndex, i.accepted, nil
+}
+
 // Assumes i.lock is held
 // Returns:
 //
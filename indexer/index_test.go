@@ -8,6 +8,7 @@ import (
 
 	"github.com/stretchr/testify/require"
 
+	"github.com/ava-labs/avalanchego/database"
 	"github.com/ava-labs/avalanchego/database/memdb"
 	"github.com/ava-labs/avalanchego/database/versiondb"
 	"github.com/ava-labs/avalanchego/ids"
@@ -162,3 +163,14 @@ func TestDontIndexSameContainerTwice(t *testing.T) {
 	require.NoError(err)
 	require.Equal([]byte{1, 2, 3}, gotContainer.Bytes)
 }
+
+func TestIndexCloseTwice(t *testing.T) {
+	require := require.New(t)
+
+	idx, err := newIndex(memdb.New(), logging.NoLog{}, mockable.Clock{})
+	require.NoError(err)
+
+	require.NoError(idx.Close())
+	err = idx.Close()
+	require.ErrorIs(err, database.ErrClosed)
+}
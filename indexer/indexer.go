@@ -341,6 +341,16 @@ func (i *indexer) registerChainHelper(
 		_ = index.Close()
 		return nil, err
 	}
+
+	// Create a websocket endpoint to stream newly accepted containers
+	streamer := &streamer{
+		log:   i.log,
+		index: index,
+	}
+	if err := i.pathAdder.AddRoute(streamer, "index/"+name, "/"+endpoint+"/events"); err != nil {
+		_ = index.Close()
+		return nil, err
+	}
 	return index, nil
 }
 
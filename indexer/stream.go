@@ -0,0 +1,215 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package indexer
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"go.uber.org/zap"
+
+	"github.com/ava-labs/avalanchego/utils/formatting"
+	"github.com/ava-labs/avalanchego/utils/json"
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/ava-labs/avalanchego/utils/units"
+)
+
+const (
+	// Size of the ws read buffer
+	streamReadBufferSize = units.KiB
+
+	// Size of the ws write buffer
+	streamWriteBufferSize = units.KiB
+
+	// Time allowed to write a message to the peer. If a subscriber is unable
+	// to keep up with this deadline, the subscription is closed.
+	streamWriteWait = 10 * time.Second
+
+	// Time allowed to read the next pong message from the peer.
+	streamPongWait = 60 * time.Second
+
+	// Send pings to peer with this period. Must be less than streamPongWait.
+	streamPingPeriod = (streamPongWait * 9) / 10
+
+	// Maximum message size allowed from peer.
+	streamMaxMessageSize = units.KiB
+)
+
+var (
+	errStartIndexTooHigh = errors.New("start index is greater than the next accepted index")
+
+	streamUpgrader = websocket.Upgrader{
+		ReadBufferSize:  streamReadBufferSize,
+		WriteBufferSize: streamWriteBufferSize,
+		CheckOrigin: func(*http.Request) bool {
+			return true
+		},
+	}
+)
+
+// SubscribeArgs is the first, and only, message a subscriber sends after
+// opening a stream.
+type SubscribeArgs struct {
+	// StartIndex is the index of the first container to send. Subscribers
+	// resume a dropped stream by specifying the index after the last container
+	// they received.
+	StartIndex json.Uint64         `json:"startIndex"`
+	Encoding   formatting.Encoding `json:"encoding"`
+}
+
+// StreamMessage is sent to a subscriber for each accepted container, in order
+// of acceptance. If the stream fails, a final message is sent with [Error]
+// populated before the stream is closed.
+type StreamMessage struct {
+	Container *FormattedContainer `json:"container,omitempty"`
+	Error     string              `json:"error,omitempty"`
+}
+
+// streamer pushes the contents of an index to websocket subscribers.
+//
+// Each subscription reads containers from the index at the rate the
+// subscriber is able to receive them, so at most one container is buffered
+// per subscription regardless of how far behind the subscriber is.
+type streamer struct {
+	log   logging.Logger
+	index *index
+}
+
+func (s *streamer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	conn, err := streamUpgrader.Upgrade(w, r, nil)
+	if err != nil {
+		s.log.Debug("failed to upgrade",
+			zap.Error(err),
+		)
+		return
+	}
+	defer conn.Close()
+
+	conn.SetReadLimit(streamMaxMessageSize)
+	if err := conn.SetReadDeadline(time.Now().Add(streamPongWait)); err != nil {
+		return
+	}
+	var args SubscribeArgs
+	if err := conn.ReadJSON(&args); err != nil {
+		s.closeWithError(conn, fmt.Errorf("couldn't read subscription: %w", err))
+		return
+	}
+
+	// The read loop is required to process pongs and to notice when the
+	// subscriber disconnects.
+	disconnected := make(chan struct{})
+	go func() {
+		defer close(disconnected)
+
+		conn.SetPongHandler(func(string) error {
+			return conn.SetReadDeadline(time.Now().Add(streamPongWait))
+		})
+		if err := conn.SetReadDeadline(time.Now().Add(streamPongWait)); err != nil {
+			return
+		}
+		for {
+			if _, _, err := conn.NextReader(); err != nil {
+				return
+			}
+		}
+	}()
+
+	if err := s.stream(conn, &args, disconnected); err != nil {
+		s.log.Debug("closing index stream",
+			zap.Uint64("startIndex", uint64(args.StartIndex)),
+			zap.Error(err),
+		)
+		s.closeWithError(conn, err)
+	}
+}
+
+// stream sends every accepted container, starting at [args.StartIndex], to
+// [conn] until the subscriber disconnects or an error occurs.
+func (s *streamer) stream(conn *websocket.Conn, args *SubscribeArgs, disconnected <-chan struct{}) error {
+	ticker := time.NewTicker(streamPingPeriod)
+	defer ticker.Stop()
+
+	nextIndex := uint64(args.StartIndex)
+	nextAcceptedIndex, _, err := s.index.NextAcceptedIndex()
+	if err != nil {
+		return err
+	}
+	if nextIndex > nextAcceptedIndex {
+		return fmt.Errorf("%w: %d > %d", errStartIndexTooHigh, nextIndex, nextAcceptedIndex)
+	}
+
+	for {
+		nextAcceptedIndex, accepted, err := s.index.NextAcceptedIndex()
+		if err != nil {
+			return err
+		}
+
+		if nextIndex < nextAcceptedIndex {
+			if err := s.send(conn, nextIndex, args.Encoding); err != nil {
+				return err
+			}
+			nextIndex++
+
+			// Keep the connection alive while the subscriber catches up.
+			select {
+			case <-disconnected:
+				return nil
+			case <-ticker.C:
+				if err := s.ping(conn); err != nil {
+					return err
+				}
+			default:
+			}
+			continue
+		}
+
+		select {
+		case <-disconnected:
+			return nil
+		case <-accepted:
+		case <-ticker.C:
+			if err := s.ping(conn); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+func (s *streamer) send(conn *websocket.Conn, index uint64, enc formatting.Encoding) error {
+	container, err := s.index.GetContainerByIndex(index)
+	if err != nil {
+		return fmt.Errorf("couldn't get container at index %d: %w", index, err)
+	}
+	fc, err := newFormattedContainer(container, index, enc)
+	if err != nil {
+		return fmt.Errorf("couldn't format container at index %d: %w", index, err)
+	}
+	if err := conn.SetWriteDeadline(time.Now().Add(streamWriteWait)); err != nil {
+		return err
+	}
+	return conn.WriteJSON(&StreamMessage{
+		Container: &fc,
+	})
+}
+
+func (*streamer) ping(conn *websocket.Conn) error {
+	if err := conn.SetWriteDeadline(time.Now().Add(streamWriteWait)); err != nil {
+		return err
+	}
+	return conn.WriteMessage(websocket.PingMessage, nil)
+}
+
+// closeWithError attempts to notify the subscriber of [err] before the
+// connection is closed.
+func (*streamer) closeWithError(conn *websocket.Conn, err error) {
+	if err := conn.SetWriteDeadline(time.Now().Add(streamWriteWait)); err != nil {
+		return
+	}
+	_ = conn.WriteJSON(&StreamMessage{
+		Error: err.Error(),
+	})
+}
@@ -0,0 +1,95 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package indexer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/gorilla/websocket"
+
+	"github.com/ava-labs/avalanchego/utils/formatting"
+	"github.com/ava-labs/avalanchego/utils/json"
+)
+
+var (
+	errStreamFailed       = errors.New("stream failed")
+	errUnexpectedIndex    = errors.New("unexpected index")
+	errMissingStreamValue = errors.New("stream message is missing a container")
+)
+
+// Subscription is a stream of the containers accepted by an index.
+//
+// If the subscription fails, a new subscription can resume from where this
+// one left off by subscribing from [NextIndex].
+type Subscription struct {
+	conn      *websocket.Conn
+	nextIndex uint64
+}
+
+// Subscribe opens a stream of the containers accepted by an index, starting
+// with the container at [startIndex].
+// [uri] is the websocket endpoint of the index.
+// For example:
+//   - ws://1.2.3.4:9650/ext/index/C/block/events
+//   - ws://1.2.3.4:9650/ext/index/X/tx/events
+func Subscribe(ctx context.Context, uri string, startIndex uint64) (*Subscription, error) {
+	conn, _, err := websocket.DefaultDialer.DialContext(ctx, uri, nil)
+	if err != nil {
+		return nil, err
+	}
+	err = conn.WriteJSON(&SubscribeArgs{
+		StartIndex: json.Uint64(startIndex),
+		Encoding:   formatting.Hex,
+	})
+	if err != nil {
+		_ = conn.Close()
+		return nil, err
+	}
+	return &Subscription{
+		conn:      conn,
+		nextIndex: startIndex,
+	}, nil
+}
+
+// Next blocks until the next container is accepted and returns it along with
+// its index.
+func (s *Subscription) Next() (Container, uint64, error) {
+	var msg StreamMessage
+	if err := s.conn.ReadJSON(&msg); err != nil {
+		return Container{}, 0, err
+	}
+	if len(msg.Error) != 0 {
+		return Container{}, 0, fmt.Errorf("%w: %s", errStreamFailed, msg.Error)
+	}
+	fc := msg.Container
+	if fc == nil {
+		return Container{}, 0, errMissingStreamValue
+	}
+	if index := uint64(fc.Index); index != s.nextIndex {
+		return Container{}, 0, fmt.Errorf("%w: expected %d but got %d", errUnexpectedIndex, s.nextIndex, index)
+	}
+
+	containerBytes, err := formatting.Decode(fc.Encoding, fc.Bytes)
+	if err != nil {
+		return Container{}, 0, fmt.Errorf("couldn't decode container %s: %w", fc.ID, err)
+	}
+	s.nextIndex++
+	return Container{
+		ID:        fc.ID,
+		Timestamp: fc.Timestamp.Unix(),
+		Bytes:     containerBytes,
+	}, uint64(fc.Index), nil
+}
+
+// NextIndex returns the index of the next container that will be returned by
+// [Next].
+func (s *Subscription) NextIndex() uint64 {
+	return s.nextIndex
+}
+
+func (s *Subscription) Close() error {
+	return s.conn.Close()
+}
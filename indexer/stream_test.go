@@ -0,0 +1,143 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package indexer
+
+import (
+	"context"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/database/memdb"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow"
+	"github.com/ava-labs/avalanchego/snow/snowtest"
+	"github.com/ava-labs/avalanchego/utils"
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/ava-labs/avalanchego/utils/timer/mockable"
+)
+
+type testContainer struct {
+	id    ids.ID
+	bytes []byte
+}
+
+func newTestStream(t *testing.T) (*index, *snow.ConsensusContext, string) {
+	require := require.New(t)
+
+	idx, err := newIndex(memdb.New(), logging.NoLog{}, mockable.Clock{})
+	require.NoError(err)
+
+	server := httptest.NewServer(&streamer{
+		log:   logging.NoLog{},
+		index: idx,
+	})
+	t.Cleanup(server.Close)
+
+	snowCtx := snowtest.Context(t, snowtest.CChainID)
+	ctx := snowtest.ConsensusContext(snowCtx)
+	return idx, ctx, "ws" + strings.TrimPrefix(server.URL, "http")
+}
+
+func acceptTestContainers(require *require.Assertions, idx *index, ctx *snow.ConsensusContext, num int) []testContainer {
+	containers := make([]testContainer, num)
+	for i := range containers {
+		containers[i] = testContainer{
+			id:    ids.GenerateTestID(),
+			bytes: utils.RandomBytes(32),
+		}
+		require.NoError(idx.Accept(ctx, containers[i].id, containers[i].bytes))
+	}
+	return containers
+}
+
+func requireNext(require *require.Assertions, sub *Subscription, expectedIndex uint64, expected testContainer) {
+	container, index, err := sub.Next()
+	require.NoError(err)
+	require.Equal(expectedIndex, index)
+	require.Equal(expected.id, container.ID)
+	require.Equal(expected.bytes, container.Bytes)
+}
+
+func TestStream(t *testing.T) {
+	require := require.New(t)
+
+	idx, ctx, uri := newTestStream(t)
+	accepted := acceptTestContainers(require, idx, ctx, 5)
+
+	sub, err := Subscribe(context.Background(), uri, 2)
+	require.NoError(err)
+	defer sub.Close()
+
+	// Containers accepted before subscribing are sent first
+	for i := 2; i < len(accepted); i++ {
+		requireNext(require, sub, uint64(i), accepted[i])
+	}
+
+	// Followed by containers as they are accepted
+	accepted = append(accepted, acceptTestContainers(require, idx, ctx, 3)...)
+	for i := 5; i < len(accepted); i++ {
+		requireNext(require, sub, uint64(i), accepted[i])
+	}
+	require.Equal(uint64(len(accepted)), sub.NextIndex())
+}
+
+func TestStreamResume(t *testing.T) {
+	require := require.New(t)
+
+	idx, ctx, uri := newTestStream(t)
+	accepted := acceptTestContainers(require, idx, ctx, 3)
+
+	sub, err := Subscribe(context.Background(), uri, 0)
+	require.NoError(err)
+	requireNext(require, sub, 0, accepted[0])
+	require.NoError(sub.Close())
+
+	// Containers accepted while disconnected are not missed
+	accepted = append(accepted, acceptTestContainers(require, idx, ctx, 2)...)
+
+	sub, err = Subscribe(context.Background(), uri, sub.NextIndex())
+	require.NoError(err)
+	defer sub.Close()
+
+	for i := 1; i < len(accepted); i++ {
+		requireNext(require, sub, uint64(i), accepted[i])
+	}
+}
+
+func TestStreamStartIndexTooHigh(t *testing.T) {
+	require := require.New(t)
+
+	idx, ctx, uri := newTestStream(t)
+	_ = acceptTestContainers(require, idx, ctx, 1)
+
+	sub, err := Subscribe(context.Background(), uri, 2)
+	require.NoError(err)
+	defer sub.Close()
+
+	_, _, err = sub.Next()
+	require.ErrorIs(err, errStreamFailed)
+	require.Contains(err.Error(), errStartIndexTooHigh.Error())
+}
+
+func TestStreamIndexClosed(t *testing.T) {
+	require := require.New(t)
+
+	idx, ctx, uri := newTestStream(t)
+	accepted := acceptTestContainers(require, idx, ctx, 1)
+
+	sub, err := Subscribe(context.Background(), uri, 0)
+	require.NoError(err)
+	defer sub.Close()
+
+	requireNext(require, sub, 0, accepted[0])
+	require.NoError(idx.Close())
+
+	_, _, err = sub.Next()
+	require.ErrorIs(err, errStreamFailed)
+	require.Contains(err.Error(), database.ErrClosed.Error())
+}
@@ -18,11 +18,17 @@ var _ Creator = (*creator)(nil)
 type Creator interface {
 	OutboundMsgBuilder
 	InboundMsgBuilder
+
+	// Recompress returns [msg] compressed with [compressionType]. If [msg] is
+	// already compressed with [compressionType], [msg] is returned.
+	Recompress(msg OutboundMessage, compressionType compression.Type) (OutboundMessage, error)
 }
 
 type creator struct {
 	OutboundMsgBuilder
 	InboundMsgBuilder
+
+	builder *msgBuilder
 }
 
 func NewCreator(
@@ -46,5 +52,10 @@ func NewCreator(
 	return &creator{
 		OutboundMsgBuilder: newOutboundBuilder(compressionType, builder),
 		InboundMsgBuilder:  newInboundBuilder(builder),
+		builder:            builder,
 	}, nil
 }
+
+func (c *creator) Recompress(msg OutboundMessage, compressionType compression.Type) (OutboundMessage, error) {
+	return c.builder.recompress(msg, compressionType)
+}
@@ -6,6 +6,7 @@ package message
 import (
 	"errors"
 	"fmt"
+	"sync"
 	"time"
 
 	"github.com/prometheus/client_golang/prometheus"
@@ -27,6 +28,14 @@ var (
 	_ OutboundMessage = (*outboundMessage)(nil)
 
 	errUnknownCompressionType = errors.New("message is compressed with an unknown compression type")
+
+	// CompressionTypes are the compression types, other than
+	// [compression.TypeNone], that messages can be compressed with.
+	CompressionTypes = []compression.Type{
+		compression.TypeZstd,
+		compression.TypeLZ4,
+		compression.TypeSnappy,
+	}
 )
 
 // InboundMessage represents a set of fields for an inbound message
@@ -102,6 +111,9 @@ type OutboundMessage interface {
 	// BytesSavedCompression returns the number of bytes that this message saved
 	// due to being compressed
 	BytesSavedCompression() int
+	// CompressionType returns the compression type that the bytes are
+	// compressed with
+	CompressionType() compression.Type
 }
 
 type outboundMessage struct {
@@ -109,6 +121,13 @@ type outboundMessage struct {
 	op                    Op
 	bytes                 []byte
 	bytesSavedCompression int
+	compressionType       compression.Type
+
+	// recompressedLock protects recompressed, since a message may be sent to
+	// many peers concurrently.
+	recompressedLock sync.Mutex
+	// Compression type --> this message compressed with it
+	recompressed map[compression.Type]OutboundMessage
 }
 
 func (m *outboundMessage) BypassThrottling() bool {
@@ -127,13 +146,24 @@ func (m *outboundMessage) BytesSavedCompression() int {
 	return m.bytesSavedCompression
 }
 
-// TODO: add other compression algorithms with extended interface
+func (m *outboundMessage) CompressionType() compression.Type {
+	return m.compressionType
+}
+
+type messageCompressor struct {
+	compressor compression.Compressor
+
+	compressTimeMetrics   map[Op]metric.Averager
+	decompressTimeMetrics map[Op]metric.Averager
+	compressRatioMetric   metric.Averager
+	decompressRatioMetric metric.Averager
+}
+
 type msgBuilder struct {
 	log logging.Logger
 
-	zstdCompressor            compression.Compressor
-	zstdCompressTimeMetrics   map[Op]metric.Averager
-	zstdDecompressTimeMetrics map[Op]metric.Averager
+	// Compression type --> compressor and its metrics
+	compressors map[compression.Type]*messageCompressor
 
 	maxMessageTimeout time.Duration
 }
@@ -144,37 +174,55 @@ func newMsgBuilder(
 	metrics prometheus.Registerer,
 	maxMessageTimeout time.Duration,
 ) (*msgBuilder, error) {
-	zstdCompressor, err := compression.NewZstdCompressor(constants.DefaultMaxMessageSize)
-	if err != nil {
-		return nil, err
-	}
-
 	mb := &msgBuilder{
-		log: log,
-
-		zstdCompressor:            zstdCompressor,
-		zstdCompressTimeMetrics:   make(map[Op]metric.Averager, len(ExternalOps)),
-		zstdDecompressTimeMetrics: make(map[Op]metric.Averager, len(ExternalOps)),
-
+		log:               log,
+		compressors:       make(map[compression.Type]*messageCompressor, len(CompressionTypes)),
 		maxMessageTimeout: maxMessageTimeout,
 	}
 
 	errs := wrappers.Errs{}
-	for _, op := range ExternalOps {
-		mb.zstdCompressTimeMetrics[op] = metric.NewAveragerWithErrs(
-			namespace,
-			fmt.Sprintf("zstd_%s_compress_time", op),
-			fmt.Sprintf("time (in ns) to compress %s messages with zstd", op),
-			metrics,
-			&errs,
-		)
-		mb.zstdDecompressTimeMetrics[op] = metric.NewAveragerWithErrs(
-			namespace,
-			fmt.Sprintf("zstd_%s_decompress_time", op),
-			fmt.Sprintf("time (in ns) to decompress %s messages with zstd", op),
-			metrics,
-			&errs,
-		)
+	for _, compressionType := range CompressionTypes {
+		c, err := compression.NewCompressor(compressionType, constants.DefaultMaxMessageSize)
+		if err != nil {
+			return nil, err
+		}
+
+		compressor := &messageCompressor{
+			compressor:            c,
+			compressTimeMetrics:   make(map[Op]metric.Averager, len(ExternalOps)),
+			decompressTimeMetrics: make(map[Op]metric.Averager, len(ExternalOps)),
+			compressRatioMetric: metric.NewAveragerWithErrs(
+				namespace,
+				fmt.Sprintf("%s_compress_ratio", compressionType),
+				fmt.Sprintf("ratio of the compressed size to the uncompressed size of messages compressed with %s", compressionType),
+				metrics,
+				&errs,
+			),
+			decompressRatioMetric: metric.NewAveragerWithErrs(
+				namespace,
+				fmt.Sprintf("%s_decompress_ratio", compressionType),
+				fmt.Sprintf("ratio of the compressed size to the uncompressed size of messages decompressed with %s", compressionType),
+				metrics,
+				&errs,
+			),
+		}
+		for _, op := range ExternalOps {
+			compressor.compressTimeMetrics[op] = metric.NewAveragerWithErrs(
+				namespace,
+				fmt.Sprintf("%s_%s_compress_time", compressionType, op),
+				fmt.Sprintf("time (in ns) to compress %s messages with %s", op, compressionType),
+				metrics,
+				&errs,
+			)
+			compressor.decompressTimeMetrics[op] = metric.NewAveragerWithErrs(
+				namespace,
+				fmt.Sprintf("%s_%s_decompress_time", compressionType, op),
+				fmt.Sprintf("time (in ns) to decompress %s messages with %s", op, compressionType),
+				metrics,
+				&errs,
+			)
+		}
+		mb.compressors[compressionType] = compressor
 	}
 	return mb, errs.Err
 }
@@ -193,31 +241,41 @@ func (mb *msgBuilder) marshal(
 		return nil, 0, 0, err
 	}
 
+	if compressionType == compression.TypeNone {
+		return uncompressedMsgBytes, 0, op, nil
+	}
+
+	compressor, ok := mb.compressors[compressionType]
+	if !ok {
+		return nil, 0, 0, errUnknownCompressionType
+	}
+
 	// If compression is enabled, we marshal twice:
 	// 1. the original message
 	// 2. the message with compressed bytes
 	//
 	// This recursive packing allows us to avoid an extra compression on/off
 	// field in the message.
-	var (
-		startTime               = time.Now()
-		compressedMsg           p2p.Message
-		opToCompressTimeMetrics map[Op]metric.Averager
-	)
+	startTime := time.Now()
+	compressedBytes, err := compressor.compressor.Compress(uncompressedMsgBytes)
+	if err != nil {
+		return nil, 0, 0, err
+	}
+
+	compressedMsg := p2p.Message{}
 	switch compressionType {
-	case compression.TypeNone:
-		return uncompressedMsgBytes, 0, op, nil
 	case compression.TypeZstd:
-		compressedBytes, err := mb.zstdCompressor.Compress(uncompressedMsgBytes)
-		if err != nil {
-			return nil, 0, 0, err
+		compressedMsg.Message = &p2p.Message_CompressedZstd{
+			CompressedZstd: compressedBytes,
 		}
-		compressedMsg = p2p.Message{
-			Message: &p2p.Message_CompressedZstd{
-				CompressedZstd: compressedBytes,
-			},
+	case compression.TypeLZ4:
+		compressedMsg.Message = &p2p.Message_CompressedLz4{
+			CompressedLz4: compressedBytes,
+		}
+	case compression.TypeSnappy:
+		compressedMsg.Message = &p2p.Message_CompressedSnappy{
+			CompressedSnappy: compressedBytes,
 		}
-		opToCompressTimeMetrics = mb.zstdCompressTimeMetrics
 	default:
 		return nil, 0, 0, errUnknownCompressionType
 	}
@@ -228,7 +286,7 @@ func (mb *msgBuilder) marshal(
 	}
 	compressTook := time.Since(startTime)
 
-	if compressTimeMetric, ok := opToCompressTimeMetrics[op]; ok {
+	if compressTimeMetric, ok := compressor.compressTimeMetrics[op]; ok {
 		compressTimeMetric.Observe(float64(compressTook))
 	} else {
 		// Should never happen
@@ -237,6 +295,9 @@ func (mb *msgBuilder) marshal(
 			zap.Stringer("compressionType", compressionType),
 		)
 	}
+	if len(uncompressedMsgBytes) > 0 {
+		compressor.compressRatioMetric.Observe(float64(len(compressedMsgBytes)) / float64(len(uncompressedMsgBytes)))
+	}
 
 	bytesSaved := len(uncompressedMsgBytes) - len(compressedMsgBytes)
 	return compressedMsgBytes, bytesSaved, op, nil
@@ -250,25 +311,34 @@ func (mb *msgBuilder) unmarshal(b []byte) (*p2p.Message, int, Op, error) {
 
 	// Figure out what compression type, if any, was used to compress the message.
 	var (
-		opToDecompressTimeMetrics map[Op]metric.Averager
-		compressor                compression.Compressor
-		compressedBytes           []byte
-		zstdCompressed            = m.GetCompressedZstd()
+		compressionType compression.Type
+		compressedBytes []byte
 	)
-	switch {
-	case len(zstdCompressed) > 0:
-		opToDecompressTimeMetrics = mb.zstdDecompressTimeMetrics
-		compressor = mb.zstdCompressor
-		compressedBytes = zstdCompressed
-	default:
+	switch msg := m.GetMessage().(type) {
+	case *p2p.Message_CompressedZstd:
+		compressionType = compression.TypeZstd
+		compressedBytes = msg.CompressedZstd
+	case *p2p.Message_CompressedLz4:
+		compressionType = compression.TypeLZ4
+		compressedBytes = msg.CompressedLz4
+	case *p2p.Message_CompressedSnappy:
+		compressionType = compression.TypeSnappy
+		compressedBytes = msg.CompressedSnappy
+	}
+	if len(compressedBytes) == 0 {
 		// The message wasn't compressed
 		op, err := ToOp(m)
 		return m, 0, op, err
 	}
 
+	compressor, ok := mb.compressors[compressionType]
+	if !ok {
+		return nil, 0, 0, errUnknownCompressionType
+	}
+
 	startTime := time.Now()
 
-	decompressed, err := compressor.Decompress(compressedBytes)
+	decompressed, err := compressor.compressor.Decompress(compressedBytes)
 	if err != nil {
 		return nil, 0, 0, err
 	}
@@ -284,14 +354,18 @@ func (mb *msgBuilder) unmarshal(b []byte) (*p2p.Message, int, Op, error) {
 	if err != nil {
 		return nil, 0, 0, err
 	}
-	if decompressTimeMetric, ok := opToDecompressTimeMetrics[op]; ok {
+	if decompressTimeMetric, ok := compressor.decompressTimeMetrics[op]; ok {
 		decompressTimeMetric.Observe(float64(decompressTook))
 	} else {
 		// Should never happen
 		mb.log.Warn("no decompression metric found for op",
 			zap.Stringer("op", op),
+			zap.Stringer("compressionType", compressionType),
 		)
 	}
+	if len(decompressed) > 0 {
+		compressor.decompressRatioMetric.Observe(float64(len(b)) / float64(len(decompressed)))
+	}
 
 	return m, bytesSavedCompression, op, nil
 }
@@ -307,9 +381,53 @@ func (mb *msgBuilder) createOutbound(m *p2p.Message, compressionType compression
 		op:                    op,
 		bytes:                 b,
 		bytesSavedCompression: saved,
+		compressionType:       compressionType,
 	}, nil
 }
 
+// recompress returns [msg] compressed with [compressionType]. The result is
+// cached on [msg], so that a message sent to many peers is only compressed
+// once per compression type.
+func (mb *msgBuilder) recompress(msg OutboundMessage, compressionType compression.Type) (OutboundMessage, error) {
+	if msg.CompressionType() == compressionType {
+		return msg, nil
+	}
+
+	outboundMsg, ok := msg.(*outboundMessage)
+	if !ok {
+		return mb.compress(msg, compressionType)
+	}
+
+	outboundMsg.recompressedLock.Lock()
+	defer outboundMsg.recompressedLock.Unlock()
+
+	if recompressedMsg, ok := outboundMsg.recompressed[compressionType]; ok {
+		return recompressedMsg, nil
+	}
+
+	recompressedMsg, err := mb.compress(msg, compressionType)
+	if err != nil {
+		return nil, err
+	}
+	if outboundMsg.recompressed == nil {
+		outboundMsg.recompressed = make(map[compression.Type]OutboundMessage)
+	}
+	outboundMsg.recompressed[compressionType] = recompressedMsg
+	return recompressedMsg, nil
+}
+
+func (mb *msgBuilder) compress(msg OutboundMessage, compressionType compression.Type) (OutboundMessage, error) {
+	m, _, _, err := mb.unmarshal(msg.Bytes())
+	if err != nil {
+		return nil, err
+	}
+	outboundMsg, err := mb.createOutbound(m, compressionType, msg.BypassThrottling())
+	if err != nil {
+		return nil, err
+	}
+	return outboundMsg, nil
+}
+
 func (mb *msgBuilder) parseInbound(
 	bytes []byte,
 	nodeID ids.NodeID,
@@ -5,6 +5,7 @@ package message
 
 import (
 	"bytes"
+	"fmt"
 	"net"
 	"testing"
 	"time"
@@ -682,6 +683,60 @@ func TestMessage(t *testing.T) {
 	}
 }
 
+func TestRecompress(t *testing.T) {
+	t.Parallel()
+
+	mb, err := newMsgBuilder(
+		logging.NoLog{},
+		"test",
+		prometheus.NewRegistry(),
+		5*time.Second,
+	)
+	require.NoError(t, err)
+
+	testID := ids.GenerateTestID()
+	msg := &p2p.Message{
+		Message: &p2p.Message_Put{
+			Put: &p2p.Put{
+				ChainId:   testID[:],
+				RequestId: 1,
+				Container: bytes.Repeat([]byte{0}, 100),
+			},
+		},
+	}
+	compressionTypes := append([]compression.Type{compression.TypeNone}, CompressionTypes...)
+	for _, fromType := range compressionTypes {
+		for _, toType := range compressionTypes {
+			t.Run(fmt.Sprintf("%s_to_%s", fromType, toType), func(t *testing.T) {
+				require := require.New(t)
+
+				encodedMsg, err := mb.createOutbound(msg, fromType, true)
+				require.NoError(err)
+				require.Equal(fromType, encodedMsg.CompressionType())
+
+				recompressedMsg, err := mb.recompress(encodedMsg, toType)
+				require.NoError(err)
+				require.Equal(toType, recompressedMsg.CompressionType())
+
+				// The message is only compressed once per compression type.
+				cachedMsg, err := mb.recompress(encodedMsg, toType)
+				require.NoError(err)
+				require.Same(recompressedMsg, cachedMsg)
+				require.Equal(PutOp, recompressedMsg.Op())
+				require.True(recompressedMsg.BypassThrottling())
+				if toType != compression.TypeNone {
+					require.Positive(recompressedMsg.BytesSavedCompression())
+				}
+
+				parsedMsg, err := mb.parseInbound(recompressedMsg.Bytes(), ids.EmptyNodeID, func() {})
+				require.NoError(err)
+				require.Equal(PutOp, parsedMsg.Op())
+				require.True(proto.Equal(msg.GetPut(), parsedMsg.Message().(*p2p.Put)))
+			})
+		}
+	}
+}
+
 // Tests the Stringer interface on inbound messages
 func TestInboundMessageToString(t *testing.T) {
 	t.Parallel()
@@ -356,6 +356,17 @@ func Unwrap(m *p2p.Message) (fmt.Stringer, error) {
 	}
 }
 
+// ExternalOpFromString returns the op of the messages exchanged with peers
+// whose name is [s].
+func ExternalOpFromString(s string) (Op, error) {
+	for _, op := range ExternalOps {
+		if op.String() == s {
+			return op, nil
+		}
+	}
+	return 0, fmt.Errorf("%w: %q", errUnknownMessageType, s)
+}
+
 func ToOp(m *p2p.Message) (Op, error) {
 	switch msg := m.GetMessage().(type) {
 	case *p2p.Message_Ping:
@@ -34,6 +34,7 @@ type OutboundMsgBuilder interface {
 		objectedACPs []uint32,
 		knownPeersFilter []byte,
 		knownPeersSalt []byte,
+		supportedCompressionTypes []compression.Type,
 	) (OutboundMessage, error)
 
 	GetPeerList(
@@ -114,6 +115,14 @@ type OutboundMsgBuilder interface {
 		engineType p2p.EngineType,
 	) (OutboundMessage, error)
 
+	GetAncestorsAtHeight(
+		chainID ids.ID,
+		requestID uint32,
+		deadline time.Duration,
+		height uint64,
+		engineType p2p.EngineType,
+	) (OutboundMessage, error)
+
 	Ancestors(
 		chainID ids.ID,
 		requestID uint32,
@@ -250,9 +259,14 @@ func (b *outMsgBuilder) Handshake(
 	objectedACPs []uint32,
 	knownPeersFilter []byte,
 	knownPeersSalt []byte,
+	supportedCompressionTypes []compression.Type,
 ) (OutboundMessage, error) {
 	subnetIDBytes := make([][]byte, len(trackedSubnets))
 	encodeIDs(trackedSubnets, subnetIDBytes)
+	compressionTypes := make([]uint32, len(supportedCompressionTypes))
+	for i, compressionType := range supportedCompressionTypes {
+		compressionTypes[i] = uint32(compressionType)
+	}
 	return b.builder.createOutbound(
 		&p2p.Message{
 			Message: &p2p.Message_Handshake{
@@ -276,7 +290,8 @@ func (b *outMsgBuilder) Handshake(
 						Filter: knownPeersFilter,
 						Salt:   knownPeersSalt,
 					},
-					IpBlsSig: ipBLSSig,
+					IpBlsSig:                  ipBLSSig,
+					SupportedCompressionTypes: compressionTypes,
 				},
 			},
 		},
@@ -524,6 +539,30 @@ func (b *outMsgBuilder) GetAncestors(
 	)
 }
 
+func (b *outMsgBuilder) GetAncestorsAtHeight(
+	chainID ids.ID,
+	requestID uint32,
+	deadline time.Duration,
+	height uint64,
+	engineType p2p.EngineType,
+) (OutboundMessage, error) {
+	return b.builder.createOutbound(
+		&p2p.Message{
+			Message: &p2p.Message_GetAncestors{
+				GetAncestors: &p2p.GetAncestors{
+					ChainId:    chainID[:],
+					RequestId:  requestID,
+					Deadline:   uint64(deadline),
+					EngineType: engineType,
+					Height:     height,
+				},
+			},
+		},
+		compression.TypeNone,
+		false,
+	)
+}
+
 func (b *outMsgBuilder) Ancestors(
 	chainID ids.ID,
 	requestID uint32,
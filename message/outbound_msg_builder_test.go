@@ -29,6 +29,8 @@ func Test_newOutboundBuilder(t *testing.T) {
 	for _, compressionType := range []compression.Type{
 		compression.TypeNone,
 		compression.TypeZstd,
+		compression.TypeLZ4,
+		compression.TypeSnappy,
 	} {
 		t.Run(compressionType.String(), func(t *testing.T) {
 			builder := newOutboundBuilder(compressionType, mb)
@@ -10,6 +10,7 @@ import (
 
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/network/dialer"
+	"github.com/ava-labs/avalanchego/network/peer"
 	"github.com/ava-labs/avalanchego/network/throttling"
 	"github.com/ava-labs/avalanchego/snow/networking/tracker"
 	"github.com/ava-labs/avalanchego/snow/uptime"
@@ -93,6 +94,10 @@ type ThrottlerConfig struct {
 	InboundMsgThrottlerConfig         throttling.InboundMsgThrottlerConfig         `json:"inboundMsgThrottlerConfig"`
 	OutboundMsgThrottlerConfig        throttling.MsgByteThrottlerConfig            `json:"outboundMsgThrottlerConfig"`
 	MaxInboundConnsPerSec             float64                                      `json:"maxInboundConnsPerSec"`
+
+	// Limits the bandwidth of the messages of each subnet. Applied to inbound
+	// and outbound messages independently.
+	SubnetBandwidthQuotaConfig throttling.BandwidthQuotaConfig `json:"subnetBandwidthQuotaConfig"`
 }
 
 type Config struct {
@@ -121,14 +126,19 @@ type Config struct {
 	SupportedACPs set.Set[uint32] `json:"supportedACPs"`
 	ObjectedACPs  set.Set[uint32] `json:"objectedACPs"`
 
-	// The compression type to use when compressing outbound messages.
-	// Assumes all peers support this compression type.
+	// The compression type to use when compressing outbound messages. If a
+	// peer doesn't support this compression type, messages sent to the peer
+	// are compressed with the peer's preferred compression type instead.
 	CompressionType compression.Type `json:"compressionType"`
 
+	// The compression types, in order of preference, that peers are asked to
+	// compress the messages they send us with.
+	SupportedCompressionTypes []compression.Type `json:"supportedCompressionTypes"`
+
 	// TLSKey is this node's TLS key that is used to sign IPs.
 	TLSKey crypto.Signer `json:"-"`
 	// BLSKey is this node's BLS key that is used to sign IPs.
-	BLSKey *bls.SecretKey `json:"-"`
+	BLSKey bls.Signer `json:"-"`
 
 	// TrackedSubnets of the node.
 	TrackedSubnets set.Set[ids.ID]    `json:"-"`
@@ -176,4 +186,8 @@ type Config struct {
 	// Specifies how much disk usage each peer can cause before
 	// we rate-limit them.
 	DiskTargeter tracker.Targeter `json:"-"`
+
+	// Configures the scoring of peer behavior and the banning of peers that
+	// misbehave.
+	ReputationConfig peer.ReputationConfig `json:"reputationConfig"`
 }
@@ -33,6 +33,7 @@ import (
 	"github.com/ava-labs/avalanchego/utils/constants"
 	"github.com/ava-labs/avalanchego/utils/ips"
 	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/ava-labs/avalanchego/utils/metric"
 	"github.com/ava-labs/avalanchego/utils/set"
 	"github.com/ava-labs/avalanchego/utils/wrappers"
 	"github.com/ava-labs/avalanchego/version"
@@ -87,6 +88,10 @@ type Network interface {
 	// NodeUptime returns given node's [subnetID] UptimeResults in the view of
 	// this node's peer validators.
 	NodeUptime(subnetID ids.ID) (UptimeResult, error)
+
+	// Reputation returns the scores of the behavior of peers. Misbehavior
+	// reported to it can cause this network to disconnect from and ban a peer.
+	Reputation() peer.Reputation
 }
 
 type UptimeResult struct {
@@ -119,6 +124,8 @@ type network struct {
 	metrics    *metrics
 
 	outboundMsgThrottler throttling.OutboundMsgThrottler
+	// Limits the bandwidth of outbound messages of each subnet
+	outboundQuota throttling.BandwidthQuota
 
 	// Limits the number of connection attempts based on IP.
 	inboundConnUpgradeThrottler throttling.InboundConnUpgradeThrottler
@@ -225,6 +232,16 @@ func NewNetwork(
 		return nil, fmt.Errorf("initializing outbound message throttler failed with: %w", err)
 	}
 
+	outboundQuota, err := throttling.NewBandwidthQuota(
+		metric.AppendNamespace(config.Namespace, "outbound_subnet_quota"),
+		metricsRegisterer,
+		constants.PrimaryNetworkID,
+		config.ThrottlerConfig.SubnetBandwidthQuotaConfig,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("initializing outbound bandwidth quota failed with: %w", err)
+	}
+
 	peerMetrics, err := peer.NewMetrics(log, config.Namespace, metricsRegisterer)
 	if err != nil {
 		return nil, fmt.Errorf("initializing peer metrics failed with: %w", err)
@@ -253,23 +270,24 @@ func NewNetwork(
 		Metrics:         peerMetrics,
 		MessageCreator:  msgCreator,
 
-		Log:                  log,
-		InboundMsgThrottler:  inboundMsgThrottler,
-		Network:              nil, // This is set below.
-		Router:               router,
-		VersionCompatibility: version.GetCompatibility(config.NetworkID),
-		MySubnets:            config.TrackedSubnets,
-		Beacons:              config.Beacons,
-		Validators:           config.Validators,
-		NetworkID:            config.NetworkID,
-		PingFrequency:        config.PingFrequency,
-		PongTimeout:          config.PingPongTimeout,
-		MaxClockDifference:   config.MaxClockDifference,
-		SupportedACPs:        config.SupportedACPs.List(),
-		ObjectedACPs:         config.ObjectedACPs.List(),
-		ResourceTracker:      config.ResourceTracker,
-		UptimeCalculator:     config.UptimeCalculator,
-		IPSigner:             peer.NewIPSigner(config.MyIPPort, config.TLSKey, config.BLSKey),
+		Log:                       log,
+		InboundMsgThrottler:       inboundMsgThrottler,
+		Network:                   nil, // This is set below.
+		Router:                    router,
+		VersionCompatibility:      version.GetCompatibility(config.NetworkID),
+		MySubnets:                 config.TrackedSubnets,
+		Beacons:                   config.Beacons,
+		Validators:                config.Validators,
+		NetworkID:                 config.NetworkID,
+		PingFrequency:             config.PingFrequency,
+		PongTimeout:               config.PingPongTimeout,
+		MaxClockDifference:        config.MaxClockDifference,
+		SupportedACPs:             config.SupportedACPs.List(),
+		ObjectedACPs:              config.ObjectedACPs.List(),
+		SupportedCompressionTypes: config.SupportedCompressionTypes,
+		ResourceTracker:           config.ResourceTracker,
+		UptimeCalculator:          config.UptimeCalculator,
+		IPSigner:                  peer.NewIPSigner(config.MyIPPort, config.TLSKey, config.BLSKey),
 	}
 
 	onCloseCtx, cancel := context.WithCancel(context.Background())
@@ -278,6 +296,7 @@ func NewNetwork(
 		peerConfig:           peerConfig,
 		metrics:              metrics,
 		outboundMsgThrottler: outboundMsgThrottler,
+		outboundQuota:        outboundQuota,
 
 		inboundConnUpgradeThrottler: throttling.NewInboundConnUpgradeThrottler(log, config.ThrottlerConfig.InboundConnUpgradeThrottlerConfig),
 		listener:                    listener,
@@ -301,6 +320,19 @@ func NewNetwork(
 		router:          router,
 	}
 	n.peerConfig.Network = n
+
+	reputation, err := peer.NewReputation(
+		log,
+		config.Namespace,
+		metricsRegisterer,
+		config.ReputationConfig,
+		n.isBanExempt,
+		n.disconnectBanned,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("initializing peer reputation failed with: %w", err)
+	}
+	n.peerConfig.Reputation = reputation
 	return n, nil
 }
 
@@ -327,6 +359,11 @@ func (n *network) Send(
 	// Note: It is guaranteed that namedPeers and sampledPeers are disjoint.
 	for _, peers := range [][]peer.Peer{namedPeers, sampledPeers} {
 		for _, peer := range peers {
+			// Messages that exceed the quota of the subnet are deliberately
+			// dropped, so they aren't counted as failed sends.
+			if !n.outboundQuota.Allow(subnetID, msg.Op(), uint64(len(msg.Bytes()))) {
+				continue
+			}
 			if peer.Send(n.onCloseCtx, msg) {
 				sentTo.Add(peer.ID())
 
@@ -465,6 +502,9 @@ func (n *network) Connected(nodeID ids.NodeID) {
 // of peers, then it should only connect if this node is a validator, or the
 // peer is a validator/beacon.
 func (n *network) AllowConnection(nodeID ids.NodeID) bool {
+	if n.peerConfig.Reputation.IsBanned(nodeID) {
+		return false
+	}
 	if !n.config.RequireValidatorToConnect {
 		return true
 	}
@@ -472,6 +512,10 @@ func (n *network) AllowConnection(nodeID ids.NodeID) bool {
 	return iAmAValidator || n.ipTracker.WantsConnection(nodeID)
 }
 
+func (n *network) Reputation() peer.Reputation {
+	return n.peerConfig.Reputation
+}
+
 func (n *network) Track(claimedIPPorts []*ips.ClaimedIPPort) error {
 	for _, ip := range claimedIPPorts {
 		if err := n.track(ip); err != nil {
@@ -665,6 +709,32 @@ func (n *network) track(ip *ips.ClaimedIPPort) error {
 	return nil
 }
 
+// isBanExempt returns true if [nodeID] is a beacon or a primary network
+// validator. They are never banned for their reputation, as timeouts also
+// lower the scores of honest peers and this node relies on them to stay
+// connected to the network.
+func (n *network) isBanExempt(nodeID ids.NodeID) bool {
+	if _, ok := n.config.Beacons.GetValidator(constants.PrimaryNetworkID, nodeID); ok {
+		return true
+	}
+	_, ok := n.config.Validators.GetValidator(constants.PrimaryNetworkID, nodeID)
+	return ok
+}
+
+// disconnectBanned closes the connection with [nodeID], which was banned for
+// its reputation.
+func (n *network) disconnectBanned(nodeID ids.NodeID) {
+	n.peersLock.RLock()
+	defer n.peersLock.RUnlock()
+
+	if peer, ok := n.connectingPeers.GetByID(nodeID); ok {
+		peer.StartClose()
+	}
+	if peer, ok := n.connectedPeers.GetByID(nodeID); ok {
+		peer.StartClose()
+	}
+}
+
 // getPeers returns a slice of connected peers from a set of [nodeIDs].
 //
 //   - [nodeIDs] the IDs of the peers that should be returned if they are
@@ -111,7 +111,8 @@ var (
 		PingFrequency:      constants.DefaultPingFrequency,
 		AllowPrivateIPs:    true,
 
-		CompressionType: constants.DefaultNetworkCompressionType,
+		CompressionType:           constants.DefaultNetworkCompressionType,
+		SupportedCompressionTypes: message.CompressionTypes,
 
 		UptimeCalculator:  uptime.NewManager(uptime.NewTestState(), &mockable.Clock{}),
 		UptimeMetricFreq:  30 * time.Second,
@@ -176,7 +177,7 @@ func newTestNetwork(t *testing.T, count int) (*testDialer, []*testListener, []id
 		config.MyNodeID = nodeID
 		config.MyIPPort = ip
 		config.TLSKey = tlsCert.PrivateKey.(crypto.Signer)
-		config.BLSKey = blsKey
+		config.BLSKey = bls.NewLocalSigner(blsKey)
 
 		listeners[i] = listener
 		nodeIDs[i] = nodeID
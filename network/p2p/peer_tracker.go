@@ -35,6 +35,12 @@ const (
 	randomPeerProbability = 0.2
 )
 
+// PeerReputation scores the past behavior of peers. Peers with negative scores
+// have misbehaved.
+type PeerReputation interface {
+	Score(nodeID ids.NodeID) float64
+}
+
 // Tracks the bandwidth of responses coming from peers,
 // preferring to contact peers with known good bandwidth, connecting
 // to new peers with an exponentially decaying probability.
@@ -63,6 +69,7 @@ type PeerTracker struct {
 	log          logging.Logger
 	ignoredNodes set.Set[ids.NodeID]
 	minVersion   *version.Application
+	reputation   PeerReputation
 	metrics      peerTrackerMetrics
 }
 
@@ -78,6 +85,7 @@ func NewPeerTracker(
 	registerer prometheus.Registerer,
 	ignoredNodes set.Set[ids.NodeID],
 	minVersion *version.Application,
+	reputation PeerReputation,
 ) (*PeerTracker, error) {
 	t := &PeerTracker{
 		peerBandwidth: make(map[ids.NodeID]safemath.Averager),
@@ -88,6 +96,7 @@ func NewPeerTracker(
 		log:              log,
 		ignoredNodes:     ignoredNodes,
 		minVersion:       minVersion,
+		reputation:       reputation,
 		metrics: peerTrackerMetrics{
 			numTrackedPeers: prometheus.NewGauge(
 				prometheus.GaugeOpts{
@@ -160,13 +169,16 @@ func (p *PeerTracker) shouldSelectUntrackedPeer() bool {
 // With probability [1-randomPeerProbability] returns the peer in
 // [p.bandwidthHeap] with the highest bandwidth.
 //
+// If a reputation was provided, peers with negative scores are only returned
+// if no other peer could be selected.
+//
 // Returns false if there are no connected peers.
 func (p *PeerTracker) SelectPeer() (ids.NodeID, bool) {
 	p.lock.RLock()
 	defer p.lock.RUnlock()
 
 	if p.shouldSelectUntrackedPeer() {
-		if nodeID, ok := p.untrackedPeers.Peek(); ok {
+		if nodeID, ok := p.peekReputable(p.untrackedPeers); ok {
 			p.log.Debug("selecting peer",
 				zap.String("reason", "untracked"),
 				zap.Stringer("nodeID", nodeID),
@@ -179,7 +191,7 @@ func (p *PeerTracker) SelectPeer() (ids.NodeID, bool) {
 
 	useBandwidthHeap := rand.Float64() > randomPeerProbability // #nosec G404
 	if useBandwidthHeap {
-		if nodeID, bandwidth, ok := p.bandwidthHeap.Peek(); ok {
+		if nodeID, bandwidth, ok := p.bandwidthHeap.Peek(); ok && p.isReputable(nodeID) {
 			p.log.Debug("selecting peer",
 				zap.String("reason", "bandwidth"),
 				zap.Stringer("nodeID", nodeID),
@@ -188,7 +200,7 @@ func (p *PeerTracker) SelectPeer() (ids.NodeID, bool) {
 			return nodeID, true
 		}
 	} else {
-		if nodeID, ok := p.responsivePeers.Peek(); ok {
+		if nodeID, ok := p.peekReputable(p.responsivePeers); ok {
 			p.log.Debug("selecting peer",
 				zap.String("reason", "responsive"),
 				zap.Stringer("nodeID", nodeID),
@@ -197,7 +209,7 @@ func (p *PeerTracker) SelectPeer() (ids.NodeID, bool) {
 		}
 	}
 
-	if nodeID, ok := p.trackedPeers.Peek(); ok {
+	if nodeID, ok := p.peekReputable(p.trackedPeers); ok {
 		p.log.Debug("selecting peer",
 			zap.String("reason", "tracked"),
 			zap.Stringer("nodeID", nodeID),
@@ -206,10 +218,42 @@ func (p *PeerTracker) SelectPeer() (ids.NodeID, bool) {
 		return nodeID, true
 	}
 
+	// Only peers that misbehaved remain, which is preferable to not sending
+	// the request at all.
+	for _, peers := range []set.Set[ids.NodeID]{p.trackedPeers, p.untrackedPeers} {
+		if nodeID, ok := peers.Peek(); ok {
+			p.log.Debug("selecting peer",
+				zap.String("reason", "disreputable"),
+				zap.Stringer("nodeID", nodeID),
+			)
+			return nodeID, true
+		}
+	}
+
 	// We're not connected to any peers.
 	return ids.EmptyNodeID, false
 }
 
+// Assumes the read lock is held.
+func (p *PeerTracker) isReputable(nodeID ids.NodeID) bool {
+	return p.reputation == nil || p.reputation.Score(nodeID) >= 0
+}
+
+// peekReputable returns a peer in [peers] that hasn't misbehaved.
+//
+// Assumes the read lock is held.
+func (p *PeerTracker) peekReputable(peers set.Set[ids.NodeID]) (ids.NodeID, bool) {
+	if p.reputation == nil {
+		return peers.Peek()
+	}
+	for nodeID := range peers {
+		if p.isReputable(nodeID) {
+			return nodeID, true
+		}
+	}
+	return ids.EmptyNodeID, false
+}
+
 // Record that we sent a request to [nodeID].
 //
 // Removes the peer's bandwidth averager from the bandwidth heap.
@@ -22,6 +22,7 @@ func TestPeerTracker(t *testing.T) {
 		prometheus.NewRegistry(),
 		nil,
 		nil,
+		nil,
 	)
 	require.NoError(err)
 
@@ -102,3 +103,53 @@ func TestPeerTracker(t *testing.T) {
 	require.True(ok)
 	require.Falsef(responsive, "expected connecting to a non-responsive peer, but got a peer that was responsive: peer %s", peer)
 }
+
+type testPeerReputation map[ids.NodeID]float64
+
+func (r testPeerReputation) Score(nodeID ids.NodeID) float64 {
+	return r[nodeID]
+}
+
+func TestPeerTrackerReputation(t *testing.T) {
+	require := require.New(t)
+
+	var (
+		goodPeer = ids.GenerateTestNodeID()
+		badPeer  = ids.GenerateTestNodeID()
+	)
+	reputation := testPeerReputation{
+		badPeer: -1,
+	}
+	p, err := NewPeerTracker(
+		logging.NoLog{},
+		"",
+		prometheus.NewRegistry(),
+		nil,
+		nil,
+		reputation,
+	)
+	require.NoError(err)
+
+	peerVersion := &version.Application{
+		Major: 1,
+		Minor: 2,
+		Patch: 3,
+	}
+	p.Connected(goodPeer, peerVersion)
+	p.Connected(badPeer, peerVersion)
+
+	// Peers that misbehaved aren't selected while other peers are available
+	for i := 0; i < 10; i++ {
+		peer, ok := p.SelectPeer()
+		require.True(ok)
+		require.Equal(goodPeer, peer)
+		p.RegisterRequest(peer)
+		p.RegisterResponse(peer, 10)
+	}
+
+	// Requests fall back on peers that misbehaved when no other choice is left
+	p.Disconnected(goodPeer)
+	peer, ok := p.SelectPeer()
+	require.True(ok)
+	require.Equal(badPeer, peer)
+}
@@ -107,7 +107,7 @@ func TestSignedIpVerify(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			signedIP, err := tt.ip.Sign(tt.tlsSigner, tt.blsSigner)
+			signedIP, err := tt.ip.Sign(tt.tlsSigner, bls.NewLocalSigner(tt.blsSigner))
 			require.NoError(t, err)
 
 			err = signedIP.Verify(tt.expectedCert, tt.maxTimestamp)
@@ -10,6 +10,7 @@ import (
 	"io"
 	"math"
 	"net"
+	"slices"
 	"sync"
 	"sync/atomic"
 	"time"
@@ -22,6 +23,7 @@ import (
 	"github.com/ava-labs/avalanchego/staking"
 	"github.com/ava-labs/avalanchego/utils"
 	"github.com/ava-labs/avalanchego/utils/bloom"
+	"github.com/ava-labs/avalanchego/utils/compression"
 	"github.com/ava-labs/avalanchego/utils/constants"
 	"github.com/ava-labs/avalanchego/utils/crypto/bls"
 	"github.com/ava-labs/avalanchego/utils/ips"
@@ -38,6 +40,10 @@ const maxBloomSaltLen = 32
 var (
 	errClosed = errors.New("closed")
 
+	// legacyCompressionTypes are the compression types supported by peers
+	// that don't advertise their supported compression types.
+	legacyCompressionTypes = []compression.Type{compression.TypeZstd}
+
 	_ Peer = (*peer)(nil)
 )
 
@@ -137,6 +143,13 @@ type peer struct {
 	// options of ACPs provided in the Handshake message.
 	supportedACPs set.Set[uint32]
 	objectedACPs  set.Set[uint32]
+	// supportedCompressionTypes are the compression types, in order of
+	// preference, that the peer provided in the Handshake message.
+	supportedCompressionTypes utils.Atomic[[]compression.Type]
+	// compressionType is the compression type that messages sent to the peer
+	// are compressed with. It is the compression type that the peer prefers
+	// most out of the compression types that we also support.
+	compressionType utils.Atomic[compression.Type]
 
 	// txIDOfVerifiedBLSKey is the txID that added the BLS key that was most
 	// recently verified to have signed the IP.
@@ -212,6 +225,7 @@ func Start(
 		observedUptimes:    make(map[ids.ID]uint32),
 		getPeerListChan:    make(chan struct{}, 1),
 	}
+	p.setSupportedCompressionTypes(legacyCompressionTypes)
 
 	go p.readMessages()
 	go p.writeMessages()
@@ -290,6 +304,8 @@ func (p *peer) Info() Info {
 		TrackedSubnets:        p.trackedSubnets,
 		SupportedACPs:         p.supportedACPs,
 		ObjectedACPs:          p.objectedACPs,
+		CompressionTypes:      p.supportedCompressionTypes.Get(),
+		Score:                 p.Reputation.Score(p.id),
 	}
 }
 
@@ -314,7 +330,44 @@ func (p *peer) ObservedUptime(subnetID ids.ID) (uint32, bool) {
 }
 
 func (p *peer) Send(ctx context.Context, msg message.OutboundMessage) bool {
-	return p.messageQueue.Push(ctx, msg)
+	compressedMsg, err := p.compress(msg)
+	if err != nil {
+		p.Log.Error("failed to compress message",
+			zap.Stringer("nodeID", p.id),
+			zap.Stringer("messageOp", msg.Op()),
+			zap.Stringer("compressionType", msg.CompressionType()),
+			zap.Error(err),
+		)
+		return false
+	}
+	return p.messageQueue.Push(ctx, compressedMsg)
+}
+
+// compress returns [msg] compressed with the compression type negotiated with
+// the peer. If [msg] isn't compressed, compression is disabled and [msg] is
+// returned.
+func (p *peer) compress(msg message.OutboundMessage) (message.OutboundMessage, error) {
+	if msg.CompressionType() == compression.TypeNone {
+		return msg, nil
+	}
+	return p.MessageCreator.Recompress(msg, p.compressionType.Get())
+}
+
+// setSupportedCompressionTypes records the compression types that the peer
+// supports and negotiates the compression type of the messages sent to the
+// peer. The peer's order of preference is honored. If we don't support any of
+// the peer's compression types, messages are sent uncompressed.
+func (p *peer) setSupportedCompressionTypes(supportedCompressionTypes []compression.Type) {
+	compressionType := compression.TypeNone
+	for _, supportedCompressionType := range supportedCompressionTypes {
+		if slices.Contains(p.SupportedCompressionTypes, supportedCompressionType) {
+			compressionType = supportedCompressionType
+			break
+		}
+	}
+
+	p.supportedCompressionTypes.Set(supportedCompressionTypes)
+	p.compressionType.Set(compressionType)
 }
 
 func (p *peer) StartSendGetPeerList() {
@@ -479,6 +532,7 @@ func (p *peer) readMessages() {
 			)
 
 			p.Metrics.FailedToParse.Inc()
+			p.Reputation.Report(p.id, InvalidMessage)
 
 			// Couldn't parse the message. Read the next one.
 			onFinishedHandling()
@@ -541,6 +595,7 @@ func (p *peer) writeMessages() {
 		p.ObjectedACPs,
 		knownPeersFilter,
 		knownPeersSalt,
+		p.SupportedCompressionTypes,
 	)
 	if err != nil {
 		p.Log.Error("failed to create message",
@@ -846,6 +901,7 @@ func (p *peer) observeUptimes(primaryUptime uint32, subnetUptimes []*p2p.SubnetU
 			zap.Stringer("subnetID", constants.PrimaryNetworkID),
 			zap.Uint32("uptime", primaryUptime),
 		)
+		p.Reputation.Report(p.id, InvalidMessage)
 		p.StartClose()
 		return
 	}
@@ -858,6 +914,7 @@ func (p *peer) observeUptimes(primaryUptime uint32, subnetUptimes []*p2p.SubnetU
 				zap.Stringer("nodeID", p.id),
 				zap.Error(err),
 			)
+			p.Reputation.Report(p.id, InvalidMessage)
 			p.StartClose()
 			return
 		}
@@ -878,6 +935,7 @@ func (p *peer) observeUptimes(primaryUptime uint32, subnetUptimes []*p2p.SubnetU
 				zap.Stringer("subnetID", subnetID),
 				zap.Uint32("uptime", uptime),
 			)
+			p.Reputation.Report(p.id, InvalidMessage)
 			p.StartClose()
 			return
 		}
@@ -901,6 +959,7 @@ func (p *peer) handleHandshake(msg *p2p.Handshake) {
 		p.Log.Verbo("dropping duplicated handshake message",
 			zap.Stringer("nodeID", p.id),
 		)
+		p.Reputation.Report(p.id, DuplicateGossip)
 		return
 	}
 
@@ -987,6 +1046,20 @@ func (p *peer) handleHandshake(msg *p2p.Handshake) {
 		}
 	}
 
+	if len(msg.SupportedCompressionTypes) > 0 {
+		// Unknown compression types are ignored, so that new compression
+		// types can be introduced without breaking older peers.
+		supportedCompressionTypes := make([]compression.Type, 0, len(msg.SupportedCompressionTypes))
+		for _, compressionTypeInt := range msg.SupportedCompressionTypes {
+			for _, compressionType := range message.CompressionTypes {
+				if uint32(compressionType) == compressionTypeInt && !slices.Contains(supportedCompressionTypes, compressionType) {
+					supportedCompressionTypes = append(supportedCompressionTypes, compressionType)
+				}
+			}
+		}
+		p.setSupportedCompressionTypes(supportedCompressionTypes)
+	}
+
 	if p.supportedACPs.Overlaps(p.objectedACPs) {
 		p.Log.Debug("message with invalid field",
 			zap.Stringer("nodeID", p.id),
@@ -995,6 +1068,7 @@ func (p *peer) handleHandshake(msg *p2p.Handshake) {
 			zap.Reflect("supportedACPs", p.supportedACPs),
 			zap.Reflect("objectedACPs", p.objectedACPs),
 		)
+		p.Reputation.Report(p.id, InvalidMessage)
 		p.StartClose()
 		return
 	}
@@ -1013,6 +1087,7 @@ func (p *peer) handleHandshake(msg *p2p.Handshake) {
 				zap.String("field", "KnownPeers.Filter"),
 				zap.Error(err),
 			)
+			p.Reputation.Report(p.id, InvalidMessage)
 			p.StartClose()
 			return
 		}
@@ -1025,6 +1100,7 @@ func (p *peer) handleHandshake(msg *p2p.Handshake) {
 				zap.String("field", "KnownPeers.Salt"),
 				zap.Int("saltLen", saltLen),
 			)
+			p.Reputation.Report(p.id, InvalidMessage)
 			p.StartClose()
 			return
 		}
@@ -1038,6 +1114,7 @@ func (p *peer) handleHandshake(msg *p2p.Handshake) {
 			zap.String("field", "IP"),
 			zap.Int("ipLen", ipLen),
 		)
+		p.Reputation.Report(p.id, InvalidMessage)
 		p.StartClose()
 		return
 	}
@@ -1048,6 +1125,7 @@ func (p *peer) handleHandshake(msg *p2p.Handshake) {
 			zap.String("field", "Port"),
 			zap.Uint32("port", msg.IpPort),
 		)
+		p.Reputation.Report(p.id, InvalidMessage)
 		p.StartClose()
 		return
 	}
@@ -1082,6 +1160,10 @@ func (p *peer) handleHandshake(msg *p2p.Handshake) {
 			)
 		}
 
+		// Peers whose clocks are out of sync aren't misbehaving
+		if errors.Is(err, errInvalidTLSSignature) {
+			p.Reputation.Report(p.id, FailedVerification)
+		}
 		p.StartClose()
 		return
 	}
@@ -1095,6 +1177,7 @@ func (p *peer) handleHandshake(msg *p2p.Handshake) {
 				zap.String("signatureType", "bls"),
 				zap.Error(err),
 			)
+			p.Reputation.Report(p.id, FailedVerification)
 			p.StartClose()
 			return
 		}
@@ -1154,6 +1237,7 @@ func (p *peer) handleGetPeerList(msg *p2p.GetPeerList) {
 			zap.String("field", "KnownPeers.Filter"),
 			zap.Error(err),
 		)
+		p.Reputation.Report(p.id, InvalidMessage)
 		p.StartClose()
 		return
 	}
@@ -1166,6 +1250,7 @@ func (p *peer) handleGetPeerList(msg *p2p.GetPeerList) {
 			zap.String("field", "KnownPeers.Salt"),
 			zap.Int("saltLen", saltLen),
 		)
+		p.Reputation.Report(p.id, InvalidMessage)
 		p.StartClose()
 		return
 	}
@@ -1217,6 +1302,7 @@ func (p *peer) handlePeerList(msg *p2p.PeerList) {
 				zap.String("field", "Cert"),
 				zap.Error(err),
 			)
+			p.Reputation.Report(p.id, InvalidMessage)
 			p.StartClose()
 			return
 		}
@@ -1229,6 +1315,7 @@ func (p *peer) handlePeerList(msg *p2p.PeerList) {
 				zap.String("field", "IP"),
 				zap.Int("ipLen", ipLen),
 			)
+			p.Reputation.Report(p.id, InvalidMessage)
 			p.StartClose()
 			return
 		}
@@ -1261,6 +1348,7 @@ func (p *peer) handlePeerList(msg *p2p.PeerList) {
 			zap.String("field", "claimedIP"),
 			zap.Error(err),
 		)
+		p.Reputation.Report(p.id, FailedVerification)
 		p.StartClose()
 	}
 }
@@ -6,6 +6,7 @@ package peer
 import (
 	"context"
 	"crypto"
+	"math"
 	"net"
 	"testing"
 	"time"
@@ -22,6 +23,7 @@ import (
 	"github.com/ava-labs/avalanchego/snow/uptime"
 	"github.com/ava-labs/avalanchego/snow/validators"
 	"github.com/ava-labs/avalanchego/staking"
+	"github.com/ava-labs/avalanchego/utils/compression"
 	"github.com/ava-labs/avalanchego/utils/constants"
 	"github.com/ava-labs/avalanchego/utils/crypto/bls"
 	"github.com/ava-labs/avalanchego/utils/ips"
@@ -97,20 +99,22 @@ func makeRawTestPeers(t *testing.T, trackedSubnets set.Set[ids.ID]) (*rawTestPee
 	require.NoError(err)
 
 	sharedConfig := Config{
-		Metrics:              metrics,
-		MessageCreator:       mc,
-		Log:                  logging.NoLog{},
-		InboundMsgThrottler:  throttling.NewNoInboundThrottler(),
-		VersionCompatibility: version.GetCompatibility(constants.LocalID),
-		MySubnets:            trackedSubnets,
-		UptimeCalculator:     uptime.NoOpCalculator,
-		Beacons:              validators.NewManager(),
-		Validators:           validators.NewManager(),
-		NetworkID:            constants.LocalID,
-		PingFrequency:        constants.DefaultPingFrequency,
-		PongTimeout:          constants.DefaultPingPongTimeout,
-		MaxClockDifference:   time.Minute,
-		ResourceTracker:      resourceTracker,
+		Metrics:                   metrics,
+		MessageCreator:            mc,
+		Log:                       logging.NoLog{},
+		InboundMsgThrottler:       throttling.NewNoInboundThrottler(),
+		VersionCompatibility:      version.GetCompatibility(constants.LocalID),
+		MySubnets:                 trackedSubnets,
+		UptimeCalculator:          uptime.NoOpCalculator,
+		Beacons:                   validators.NewManager(),
+		Validators:                validators.NewManager(),
+		NetworkID:                 constants.LocalID,
+		PingFrequency:             constants.DefaultPingFrequency,
+		PongTimeout:               constants.DefaultPingPongTimeout,
+		MaxClockDifference:        time.Minute,
+		SupportedCompressionTypes: message.CompressionTypes,
+		ResourceTracker:           resourceTracker,
+		Reputation:                NoReputation,
 	}
 	peerConfig0 := sharedConfig
 	peerConfig1 := sharedConfig
@@ -120,7 +124,7 @@ func makeRawTestPeers(t *testing.T, trackedSubnets set.Set[ids.ID]) (*rawTestPee
 	bls0, err := bls.NewSecretKey()
 	require.NoError(err)
 
-	peerConfig0.IPSigner = NewIPSigner(ip0, tls0, bls0)
+	peerConfig0.IPSigner = NewIPSigner(ip0, tls0, bls.NewLocalSigner(bls0))
 
 	peerConfig0.Network = TestNetwork
 	inboundMsgChan0 := make(chan message.InboundMessage)
@@ -133,7 +137,7 @@ func makeRawTestPeers(t *testing.T, trackedSubnets set.Set[ids.ID]) (*rawTestPee
 	bls1, err := bls.NewSecretKey()
 	require.NoError(err)
 
-	peerConfig1.IPSigner = NewIPSigner(ip1, tls1, bls1)
+	peerConfig1.IPSigner = NewIPSigner(ip1, tls1, bls.NewLocalSigner(bls1))
 
 	peerConfig1.Network = TestNetwork
 	inboundMsgChan1 := make(chan message.InboundMessage)
@@ -271,6 +275,118 @@ func TestSend(t *testing.T) {
 	require.NoError(peer1.AwaitClosed(context.Background()))
 }
 
+func TestSendNegotiatesCompression(t *testing.T) {
+	tests := []struct {
+		name                      string
+		localCompressionTypes     []compression.Type
+		supportedCompressionTypes []compression.Type
+		expectedCompressionTypes  []compression.Type
+		expectedCompressionType   compression.Type
+	}{
+		{
+			name:                     "legacy peer",
+			expectedCompressionTypes: []compression.Type{compression.TypeZstd},
+			expectedCompressionType:  compression.TypeZstd,
+		},
+		{
+			name:                      "supports message compression type",
+			supportedCompressionTypes: []compression.Type{compression.TypeZstd, compression.TypeSnappy},
+			expectedCompressionTypes:  []compression.Type{compression.TypeZstd, compression.TypeSnappy},
+			expectedCompressionType:   compression.TypeZstd,
+		},
+		{
+			name:                      "honors peer preference",
+			supportedCompressionTypes: []compression.Type{compression.TypeSnappy, compression.TypeZstd},
+			expectedCompressionTypes:  []compression.Type{compression.TypeSnappy, compression.TypeZstd},
+			expectedCompressionType:   compression.TypeSnappy,
+		},
+		{
+			name:                      "best common compression type",
+			localCompressionTypes:     []compression.Type{compression.TypeZstd},
+			supportedCompressionTypes: []compression.Type{compression.TypeLZ4, compression.TypeZstd},
+			expectedCompressionTypes:  []compression.Type{compression.TypeLZ4, compression.TypeZstd},
+			expectedCompressionType:   compression.TypeZstd,
+		},
+		{
+			name:                      "no common compression type",
+			localCompressionTypes:     []compression.Type{compression.TypeZstd},
+			supportedCompressionTypes: []compression.Type{compression.TypeLZ4},
+			expectedCompressionTypes:  []compression.Type{compression.TypeLZ4},
+			expectedCompressionType:   compression.TypeNone,
+		},
+		{
+			name:                      "prefers another compression type",
+			supportedCompressionTypes: []compression.Type{compression.TypeLZ4, compression.TypeSnappy},
+			expectedCompressionTypes:  []compression.Type{compression.TypeLZ4, compression.TypeSnappy},
+			expectedCompressionType:   compression.TypeLZ4,
+		},
+		{
+			name:                      "unknown compression types",
+			supportedCompressionTypes: []compression.Type{compression.Type(math.MaxUint8)},
+			expectedCompressionTypes:  []compression.Type{},
+			expectedCompressionType:   compression.TypeNone,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require := require.New(t)
+
+			rawPeer0, rawPeer1 := makeRawTestPeers(t, set.Set[ids.ID]{})
+			if test.localCompressionTypes != nil {
+				rawPeer0.config.SupportedCompressionTypes = test.localCompressionTypes
+			}
+			rawPeer1.config.SupportedCompressionTypes = test.supportedCompressionTypes
+
+			peer0 := Start(
+				rawPeer0.config,
+				rawPeer0.conn,
+				rawPeer1.cert,
+				rawPeer1.nodeID,
+				NewThrottledMessageQueue(
+					rawPeer0.config.Metrics,
+					rawPeer1.nodeID,
+					logging.NoLog{},
+					throttling.NewNoOutboundThrottler(),
+				),
+			)
+			peer1 := Start(
+				rawPeer1.config,
+				rawPeer1.conn,
+				rawPeer0.cert,
+				rawPeer0.nodeID,
+				NewThrottledMessageQueue(
+					rawPeer1.config.Metrics,
+					rawPeer0.nodeID,
+					logging.NoLog{},
+					throttling.NewNoOutboundThrottler(),
+				),
+			)
+			require.NoError(peer0.AwaitReady(context.Background()))
+			require.NoError(peer1.AwaitReady(context.Background()))
+
+			require.Equal(test.expectedCompressionTypes, peer0.Info().CompressionTypes)
+
+			mc := newMessageCreator(t)
+			outboundPutMsg, err := mc.Put(ids.Empty, 1, make([]byte, 100))
+			require.NoError(err)
+			require.Equal(compression.TypeZstd, outboundPutMsg.CompressionType())
+
+			compressedMsg, err := peer0.(*peer).compress(outboundPutMsg)
+			require.NoError(err)
+			require.Equal(test.expectedCompressionType, compressedMsg.CompressionType())
+
+			require.True(peer0.Send(context.Background(), outboundPutMsg))
+
+			inboundPutMsg := <-rawPeer1.inboundMsgChan
+			require.Equal(message.PutOp, inboundPutMsg.Op())
+
+			peer1.StartClose()
+			require.NoError(peer0.AwaitClosed(context.Background()))
+			require.NoError(peer1.AwaitClosed(context.Background()))
+		})
+	}
+}
+
 func TestPingUptimes(t *testing.T) {
 	trackedSubnetID := ids.GenerateTestID()
 	untrackedSubnetID := ids.GenerateTestID()
@@ -394,7 +510,7 @@ func TestInvalidBLSKeyDisconnects(t *testing.T) {
 	require.NoError(rawPeer0.config.Validators.AddStaker(
 		constants.PrimaryNetworkID,
 		rawPeer1.nodeID,
-		bls.PublicFromSecretKey(rawPeer1.config.IPSigner.blsSigner),
+		rawPeer1.config.IPSigner.blsSigner.PublicKey(),
 		ids.GenerateTestID(),
 		1,
 	))
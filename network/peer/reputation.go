@@ -0,0 +1,309 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package peer
+
+import (
+	"math"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"go.uber.org/zap"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow/networking/benchlist"
+	"github.com/ava-labs/avalanchego/utils"
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/ava-labs/avalanchego/utils/timer/mockable"
+)
+
+// Scores closer to 0 than this are forgotten to bound the memory used to track
+// peers that behaved well or badly a long time ago.
+const minTrackedScore = 0.01
+
+var (
+	NoReputation Reputation = noReputation{}
+
+	_ Reputation        = (*reputation)(nil)
+	_ benchlist.Manager = (*reputationBenchlist)(nil)
+)
+
+// ReputationEvent is a behavior of a peer that changes its reputation.
+type ReputationEvent uint8
+
+const (
+	// InvalidMessage is reported when a peer sends a message that can't be
+	// parsed or that contains invalid fields.
+	InvalidMessage ReputationEvent = iota
+	// FailedVerification is reported when a signature or other content
+	// provided by a peer fails verification.
+	FailedVerification
+	// Timeout is reported when a peer fails to respond to a request in time.
+	Timeout
+	// DuplicateGossip is reported when a peer sends information that it was
+	// told this node already knows.
+	DuplicateGossip
+	// GoodResponse is reported when a peer responds to a request in time.
+	GoodResponse
+)
+
+// Score changes applied when an event is reported. Misbehavior is penalized
+// more than good behavior is rewarded so that a peer can't offset spam with
+// responses.
+var reputationEventScores = [...]float64{
+	InvalidMessage:     -20,
+	FailedVerification: -20,
+	Timeout:            -2,
+	DuplicateGossip:    -1,
+	GoodResponse:       1,
+}
+
+func (e ReputationEvent) String() string {
+	switch e {
+	case InvalidMessage:
+		return "invalid_message"
+	case FailedVerification:
+		return "failed_verification"
+	case Timeout:
+		return "timeout"
+	case DuplicateGossip:
+		return "duplicate_gossip"
+	case GoodResponse:
+		return "good_response"
+	default:
+		return "unknown"
+	}
+}
+
+type ReputationConfig struct {
+	// Halflife of the decay of a peer's score towards 0. If 0, scores don't
+	// decay.
+	Halflife time.Duration `json:"halflife"`
+
+	// MaxScore is the maximum score a peer can accumulate, which limits how
+	// much misbehavior past good behavior can offset.
+	MaxScore float64 `json:"maxScore"`
+
+	// BanThreshold is the score at or below which a peer is disconnected and
+	// banned. Must be negative to enable bans. Exempt peers are never banned.
+	BanThreshold float64 `json:"banThreshold"`
+
+	// BanDuration is how long connections with a banned peer are refused.
+	BanDuration time.Duration `json:"banDuration"`
+}
+
+// Reputation scores the behavior of peers. Every peer starts with a score of
+// 0, misbehavior lowers the score and good behavior raises it.
+type Reputation interface {
+	// Report that [nodeID] exhibited [event].
+	Report(nodeID ids.NodeID, event ReputationEvent)
+
+	// Score returns the current score of [nodeID].
+	Score(nodeID ids.NodeID) float64
+
+	// IsBanned returns true if connections with [nodeID] should be refused.
+	IsBanned(nodeID ids.NodeID) bool
+}
+
+type peerScore struct {
+	value       float64
+	lastUpdated time.Time
+}
+
+type reputation struct {
+	log    logging.Logger
+	config ReputationConfig
+	clock  mockable.Clock
+	// isExempt returns true if a peer must never be banned.
+	isExempt func(ids.NodeID) bool
+	// onBan is called, without the lock held, when a peer is banned.
+	onBan func(ids.NodeID)
+
+	numEvents *prometheus.CounterVec
+	numBans   prometheus.Counter
+
+	lock        sync.Mutex
+	scores      map[ids.NodeID]*peerScore
+	bannedUntil map[ids.NodeID]time.Time
+}
+
+// NewReputation returns a Reputation that calls [onBan] whenever the score of
+// a peer that isn't exempt, according to [isExempt], falls to the ban threshold
+// of [config].
+func NewReputation(
+	log logging.Logger,
+	namespace string,
+	registerer prometheus.Registerer,
+	config ReputationConfig,
+	isExempt func(ids.NodeID) bool,
+	onBan func(ids.NodeID),
+) (Reputation, error) {
+	r := &reputation{
+		log:      log,
+		config:   config,
+		isExempt: isExempt,
+		onBan:    onBan,
+		numEvents: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Namespace: namespace,
+				Name:      "reputation_events",
+				Help:      "number of reputation events reported about peers",
+			},
+			[]string{"event"},
+		),
+		numBans: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "reputation_bans",
+			Help:      "number of times a peer was banned for its reputation",
+		}),
+		scores:      make(map[ids.NodeID]*peerScore),
+		bannedUntil: make(map[ids.NodeID]time.Time),
+	}
+	err := utils.Err(
+		registerer.Register(r.numEvents),
+		registerer.Register(r.numBans),
+	)
+	return r, err
+}
+
+func (r *reputation) Report(nodeID ids.NodeID, event ReputationEvent) {
+	if int(event) >= len(reputationEventScores) {
+		return
+	}
+	r.numEvents.WithLabelValues(event.String()).Inc()
+
+	// The exemption is checked before grabbing the lock, as it may grab
+	// other locks.
+	canBan := r.config.BanThreshold < 0 && !r.isExempt(nodeID)
+
+	r.lock.Lock()
+	now := r.clock.Time()
+	score := r.decay(nodeID, now)
+	score += reputationEventScores[event]
+	if r.config.MaxScore > 0 {
+		score = math.Min(score, r.config.MaxScore)
+	}
+
+	banned := canBan && score <= r.config.BanThreshold
+	if banned {
+		// The peer starts over once the ban expires
+		delete(r.scores, nodeID)
+		r.pruneBans(now)
+		r.bannedUntil[nodeID] = now.Add(r.config.BanDuration)
+	} else {
+		r.set(nodeID, score, now)
+	}
+	r.lock.Unlock()
+
+	if !banned {
+		return
+	}
+	r.numBans.Inc()
+	r.log.Info("banning peer",
+		zap.Stringer("nodeID", nodeID),
+		zap.Stringer("event", event),
+		zap.Duration("duration", r.config.BanDuration),
+	)
+	r.onBan(nodeID)
+}
+
+func (r *reputation) Score(nodeID ids.NodeID) float64 {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	now := r.clock.Time()
+	score := r.decay(nodeID, now)
+	r.set(nodeID, score, now)
+	return score
+}
+
+func (r *reputation) IsBanned(nodeID ids.NodeID) bool {
+	r.lock.Lock()
+	bannedUntil, ok := r.bannedUntil[nodeID]
+	banned := ok && r.clock.Time().Before(bannedUntil)
+	r.lock.Unlock()
+
+	// A peer that became exempt after it was banned is no longer banned.
+	return banned && !r.isExempt(nodeID)
+}
+
+// pruneBans forgets the bans that expired by [now]. Banned peers may never
+// reconnect, so expired bans are pruned whenever a peer is banned to bound the
+// number of tracked bans.
+//
+// Assumes the lock is held.
+func (r *reputation) pruneBans(now time.Time) {
+	for nodeID, bannedUntil := range r.bannedUntil {
+		if !now.Before(bannedUntil) {
+			delete(r.bannedUntil, nodeID)
+		}
+	}
+}
+
+// decay returns the score of [nodeID] at [now].
+//
+// Assumes the lock is held.
+func (r *reputation) decay(nodeID ids.NodeID, now time.Time) float64 {
+	score, ok := r.scores[nodeID]
+	if !ok {
+		return 0
+	}
+	if r.config.Halflife <= 0 {
+		return score.value
+	}
+	elapsed := now.Sub(score.lastUpdated)
+	value := score.value * math.Pow(0.5, float64(elapsed)/float64(r.config.Halflife))
+	if math.Abs(value) < minTrackedScore {
+		return 0
+	}
+	return value
+}
+
+// Assumes the lock is held.
+func (r *reputation) set(nodeID ids.NodeID, value float64, now time.Time) {
+	if math.Abs(value) < minTrackedScore {
+		delete(r.scores, nodeID)
+		return
+	}
+	r.scores[nodeID] = &peerScore{
+		value:       value,
+		lastUpdated: now,
+	}
+}
+
+type noReputation struct{}
+
+func (noReputation) Report(ids.NodeID, ReputationEvent) {}
+
+func (noReputation) Score(ids.NodeID) float64 {
+	return 0
+}
+
+func (noReputation) IsBanned(ids.NodeID) bool {
+	return false
+}
+
+// NewReputationBenchlist returns a benchlist manager that additionally reports
+// the responses and timeouts registered with [manager] to [reputation].
+func NewReputationBenchlist(manager benchlist.Manager, reputation Reputation) benchlist.Manager {
+	return &reputationBenchlist{
+		Manager:    manager,
+		reputation: reputation,
+	}
+}
+
+type reputationBenchlist struct {
+	benchlist.Manager
+	reputation Reputation
+}
+
+func (b *reputationBenchlist) RegisterResponse(chainID ids.ID, nodeID ids.NodeID) {
+	b.reputation.Report(nodeID, GoodResponse)
+	b.Manager.RegisterResponse(chainID, nodeID)
+}
+
+func (b *reputationBenchlist) RegisterFailure(chainID ids.ID, nodeID ids.NodeID) {
+	b.reputation.Report(nodeID, Timeout)
+	b.Manager.RegisterFailure(chainID, nodeID)
+}
@@ -0,0 +1,154 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package peer
+
+import (
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow/networking/benchlist"
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/ava-labs/avalanchego/utils/set"
+)
+
+func newTestReputation(t *testing.T, config ReputationConfig, exempt ...ids.NodeID) (*reputation, *[]ids.NodeID) {
+	var (
+		exemptSet = set.Of(exempt...)
+		banned    []ids.NodeID
+	)
+	r, err := NewReputation(
+		logging.NoLog{},
+		"",
+		prometheus.NewRegistry(),
+		config,
+		exemptSet.Contains,
+		func(nodeID ids.NodeID) {
+			banned = append(banned, nodeID)
+		},
+	)
+	require.NoError(t, err)
+
+	rep := r.(*reputation)
+	rep.clock.Set(time.Unix(0, 0))
+	return rep, &banned
+}
+
+func TestReputationScore(t *testing.T) {
+	require := require.New(t)
+
+	r, _ := newTestReputation(t, ReputationConfig{
+		Halflife: time.Minute,
+		MaxScore: 3,
+	})
+	nodeID := ids.GenerateTestNodeID()
+	require.Zero(r.Score(nodeID))
+
+	r.Report(nodeID, GoodResponse)
+	require.Equal(1.0, r.Score(nodeID))
+
+	// Good behavior is capped
+	for i := 0; i < 5; i++ {
+		r.Report(nodeID, GoodResponse)
+	}
+	require.Equal(3.0, r.Score(nodeID))
+
+	r.Report(nodeID, InvalidMessage)
+	require.Equal(-17.0, r.Score(nodeID))
+
+	// The score decays towards 0
+	r.clock.Set(r.clock.Time().Add(time.Minute))
+	require.InDelta(-8.5, r.Score(nodeID), 0.001)
+
+	// Scores close to 0 are forgotten
+	r.clock.Set(r.clock.Time().Add(time.Hour))
+	require.Zero(r.Score(nodeID))
+	require.Empty(r.scores)
+}
+
+func TestReputationBan(t *testing.T) {
+	require := require.New(t)
+
+	r, banned := newTestReputation(t, ReputationConfig{
+		Halflife:     time.Minute,
+		BanThreshold: -30,
+		BanDuration:  time.Hour,
+	})
+	nodeID := ids.GenerateTestNodeID()
+
+	r.Report(nodeID, FailedVerification)
+	require.False(r.IsBanned(nodeID))
+	require.Empty(*banned)
+
+	r.Report(nodeID, FailedVerification)
+	require.True(r.IsBanned(nodeID))
+	require.Equal([]ids.NodeID{nodeID}, *banned)
+
+	// The peer starts over once the ban expires
+	require.Zero(r.Score(nodeID))
+	r.clock.Set(r.clock.Time().Add(time.Hour))
+	require.False(r.IsBanned(nodeID))
+
+	// Expired bans are forgotten once another peer is banned
+	otherNodeID := ids.GenerateTestNodeID()
+	r.Report(otherNodeID, FailedVerification)
+	r.Report(otherNodeID, FailedVerification)
+	require.True(r.IsBanned(otherNodeID))
+	require.Equal([]ids.NodeID{nodeID, otherNodeID}, *banned)
+	require.Len(r.bannedUntil, 1)
+	require.Contains(r.bannedUntil, otherNodeID)
+}
+
+func TestReputationExemptPeersAreNotBanned(t *testing.T) {
+	require := require.New(t)
+
+	exemptNodeID := ids.GenerateTestNodeID()
+	r, banned := newTestReputation(t, ReputationConfig{
+		BanThreshold: -30,
+		BanDuration:  time.Hour,
+	}, exemptNodeID)
+
+	for i := 0; i < 10; i++ {
+		r.Report(exemptNodeID, FailedVerification)
+	}
+	require.Equal(-200.0, r.Score(exemptNodeID))
+	require.False(r.IsBanned(exemptNodeID))
+	require.Empty(*banned)
+}
+
+func TestReputationNoBanThreshold(t *testing.T) {
+	require := require.New(t)
+
+	r, banned := newTestReputation(t, ReputationConfig{
+		BanDuration: time.Hour,
+	})
+	nodeID := ids.GenerateTestNodeID()
+
+	for i := 0; i < 10; i++ {
+		r.Report(nodeID, InvalidMessage)
+	}
+	require.Equal(-200.0, r.Score(nodeID))
+	require.False(r.IsBanned(nodeID))
+	require.Empty(*banned)
+}
+
+func TestReputationBenchlist(t *testing.T) {
+	require := require.New(t)
+
+	r, _ := newTestReputation(t, ReputationConfig{})
+	b := NewReputationBenchlist(benchlist.NewNoBenchlist(), r)
+
+	var (
+		chainID = ids.GenerateTestID()
+		nodeID  = ids.GenerateTestNodeID()
+	)
+	b.RegisterResponse(chainID, nodeID)
+	require.Equal(1.0, r.Score(nodeID))
+
+	b.RegisterFailure(chainID, nodeID)
+	require.Equal(-1.0, r.Score(nodeID))
+}
@@ -112,23 +112,25 @@ func StartTestPeer(
 
 	peer := Start(
 		&Config{
-			Metrics:              metrics,
-			MessageCreator:       mc,
-			Log:                  logging.NoLog{},
-			InboundMsgThrottler:  throttling.NewNoInboundThrottler(),
-			Network:              TestNetwork,
-			Router:               router,
-			VersionCompatibility: version.GetCompatibility(networkID),
-			MySubnets:            set.Set[ids.ID]{},
-			Beacons:              validators.NewManager(),
-			Validators:           validators.NewManager(),
-			NetworkID:            networkID,
-			PingFrequency:        constants.DefaultPingFrequency,
-			PongTimeout:          constants.DefaultPingPongTimeout,
-			MaxClockDifference:   time.Minute,
-			ResourceTracker:      resourceTracker,
-			UptimeCalculator:     uptime.NoOpCalculator,
-			IPSigner:             NewIPSigner(signerIP, tlsKey, blsKey),
+			Metrics:                   metrics,
+			MessageCreator:            mc,
+			Log:                       logging.NoLog{},
+			InboundMsgThrottler:       throttling.NewNoInboundThrottler(),
+			Network:                   TestNetwork,
+			Router:                    router,
+			VersionCompatibility:      version.GetCompatibility(networkID),
+			MySubnets:                 set.Set[ids.ID]{},
+			Beacons:                   validators.NewManager(),
+			Validators:                validators.NewManager(),
+			NetworkID:                 networkID,
+			PingFrequency:             constants.DefaultPingFrequency,
+			PongTimeout:               constants.DefaultPingPongTimeout,
+			MaxClockDifference:        time.Minute,
+			SupportedCompressionTypes: message.CompressionTypes,
+			ResourceTracker:           resourceTracker,
+			UptimeCalculator:          uptime.NoOpCalculator,
+			IPSigner:                  NewIPSigner(signerIP, tlsKey, bls.NewLocalSigner(blsKey)),
+			Reputation:                NoReputation,
 		},
 		conn,
 		cert,
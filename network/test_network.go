@@ -164,6 +164,7 @@ func NewTestNetwork(
 
 		MaxClockDifference:           constants.DefaultNetworkMaxClockDifference,
 		CompressionType:              constants.DefaultNetworkCompressionType,
+		SupportedCompressionTypes:    message.CompressionTypes,
 		PingFrequency:                constants.DefaultPingFrequency,
 		AllowPrivateIPs:              !constants.ProductionNetworkIDs.Contains(networkID),
 		UptimeMetricFreq:             constants.DefaultUptimeMetricFreq,
@@ -184,10 +185,11 @@ func NewTestNetwork(
 	tlsConfig := peer.TLSConfig(*tlsCert, nil)
 	networkConfig.TLSConfig = tlsConfig
 	networkConfig.TLSKey = tlsCert.PrivateKey.(crypto.Signer)
-	networkConfig.BLSKey, err = bls.NewSecretKey()
+	blsKey, err := bls.NewSecretKey()
 	if err != nil {
 		return nil, err
 	}
+	networkConfig.BLSKey = bls.NewLocalSigner(blsKey)
 
 	networkConfig.Validators = currentValidators
 	networkConfig.Beacons = validators.NewManager()
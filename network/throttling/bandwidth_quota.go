@@ -0,0 +1,222 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package throttling
+
+import (
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"golang.org/x/time/rate"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/message"
+	"github.com/ava-labs/avalanchego/utils"
+	"github.com/ava-labs/avalanchego/utils/set"
+)
+
+const (
+	subnetLabel = "subnet"
+	opLabel     = "op"
+)
+
+var (
+	_ BandwidthQuota = (*bandwidthQuota)(nil)
+	_ BandwidthQuota = noBandwidthQuota{}
+
+	bandwidthQuotaLabels = []string{subnetLabel, opLabel}
+)
+
+// BandwidthQuota limits the bandwidth that messages of each subnet, and of
+// each op within a subnet, can consume. Unlike the other throttlers, a
+// BandwidthQuota never blocks. Messages that exceed their quota are expected to
+// be dropped.
+type BandwidthQuota interface {
+	// Allow returns true if a message of [op] and [msgSize] bytes on
+	// [subnetID] fits within its quotas. If true is returned, the message is
+	// counted against the quotas.
+	// It's safe for multiple goroutines to concurrently call Allow.
+	Allow(subnetID ids.ID, op message.Op, msgSize uint64) bool
+}
+
+type BandwidthQuotaConfig struct {
+	// Rate, in bytes per second, at which the quota of a subnet with a share
+	// of 1 replenishes. If 0, bandwidth isn't limited.
+	RefillRate uint64 `json:"refillRate"`
+	// Max amount of bandwidth, in bytes, that can accumulate for a subnet
+	// with a share of 1
+	MaxBurstSize uint64 `json:"maxBurstSize"`
+	// Share of [RefillRate] and [MaxBurstSize] given to a subnet. The primary
+	// network is only limited if it is given a share.
+	SubnetShares map[ids.ID]float64 `json:"subnetShares"`
+	// Share given to subnets, other than the primary network, that aren't
+	// in [SubnetShares]
+	DefaultSubnetShare float64 `json:"defaultSubnetShare"`
+	// Share of a subnet's quota that each op can consume. Ops without a share
+	// can consume the subnet's entire quota.
+	OpShares map[message.Op]float64 `json:"opShares"`
+	// Ops that are never dropped. Their bandwidth is still counted against
+	// the quotas, which delays the replenishment of the quotas for other ops.
+	PriorityOps set.Set[message.Op] `json:"priorityOps"`
+}
+
+// NewBandwidthQuota returns a BandwidthQuota that enforces [config]. The
+// bandwidth of each subnet and op is reported under [namespace].
+func NewBandwidthQuota(
+	namespace string,
+	registerer prometheus.Registerer,
+	primaryNetworkID ids.ID,
+	config BandwidthQuotaConfig,
+) (BandwidthQuota, error) {
+	if config.RefillRate == 0 {
+		return noBandwidthQuota{}, nil
+	}
+
+	q := &bandwidthQuota{
+		config:           config,
+		primaryNetworkID: primaryNetworkID,
+		allowedBytes: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Namespace: namespace,
+				Name:      "allowed_bytes",
+				Help:      "bytes of messages that fit within their subnet's quota",
+			},
+			bandwidthQuotaLabels,
+		),
+		droppedBytes: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Namespace: namespace,
+				Name:      "dropped_bytes",
+				Help:      "bytes of messages that exceeded their subnet's quota",
+			},
+			bandwidthQuotaLabels,
+		),
+		droppedMsgs: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Namespace: namespace,
+				Name:      "dropped_msgs",
+				Help:      "number of messages that exceeded their subnet's quota",
+			},
+			bandwidthQuotaLabels,
+		),
+		subnetLimiters: make(map[ids.ID]*rate.Limiter),
+		opLimiters:     make(map[ids.ID]map[message.Op]*rate.Limiter),
+	}
+	err := utils.Err(
+		registerer.Register(q.allowedBytes),
+		registerer.Register(q.droppedBytes),
+		registerer.Register(q.droppedMsgs),
+	)
+	return q, err
+}
+
+type bandwidthQuota struct {
+	config           BandwidthQuotaConfig
+	primaryNetworkID ids.ID
+
+	allowedBytes *prometheus.CounterVec
+	droppedBytes *prometheus.CounterVec
+	droppedMsgs  *prometheus.CounterVec
+
+	lock sync.Mutex
+	// Subnet ID --> token bucket based rate limiter where each token is a
+	// byte of bandwidth
+	subnetLimiters map[ids.ID]*rate.Limiter
+	// Subnet ID --> Op --> token bucket of the op within the subnet
+	opLimiters map[ids.ID]map[message.Op]*rate.Limiter
+}
+
+func (q *bandwidthQuota) Allow(subnetID ids.ID, op message.Op, msgSize uint64) bool {
+	labels := prometheus.Labels{
+		subnetLabel: subnetID.String(),
+		opLabel:     op.String(),
+	}
+
+	q.lock.Lock()
+	subnetLimiter, opLimiter := q.getLimiters(subnetID, op)
+	q.lock.Unlock()
+
+	if subnetLimiter == nil {
+		q.allowedBytes.With(labels).Add(float64(msgSize))
+		return true
+	}
+
+	now := time.Now()
+	if q.config.PriorityOps.Contains(op) {
+		// Priority messages are always allowed, but they consume bandwidth
+		// that would otherwise be available to other ops. Messages larger
+		// than the burst size can't be reserved.
+		subnetLimiter.ReserveN(now, int(msgSize))
+		if opLimiter != nil {
+			opLimiter.ReserveN(now, int(msgSize))
+		}
+		q.allowedBytes.With(labels).Add(float64(msgSize))
+		return true
+	}
+
+	// The op's quota is checked first so that a message dropped by its op's
+	// quota doesn't consume the subnet's quota.
+	if (opLimiter != nil && !opLimiter.AllowN(now, int(msgSize))) ||
+		!subnetLimiter.AllowN(now, int(msgSize)) {
+		q.droppedBytes.With(labels).Add(float64(msgSize))
+		q.droppedMsgs.With(labels).Inc()
+		return false
+	}
+	q.allowedBytes.With(labels).Add(float64(msgSize))
+	return true
+}
+
+// getLimiters returns the limiters of [subnetID] and [op] within [subnetID],
+// creating them if needed.
+//
+// Assumes the lock is held.
+func (q *bandwidthQuota) getLimiters(subnetID ids.ID, op message.Op) (*rate.Limiter, *rate.Limiter) {
+	subnetShare, ok := q.config.SubnetShares[subnetID]
+	if !ok {
+		if subnetID == q.primaryNetworkID {
+			return nil, nil
+		}
+		subnetShare = q.config.DefaultSubnetShare
+	}
+
+	subnetLimiter, ok := q.subnetLimiters[subnetID]
+	if !ok {
+		subnetLimiter = q.newLimiter(subnetShare)
+		q.subnetLimiters[subnetID] = subnetLimiter
+	}
+
+	opShare, ok := q.config.OpShares[op]
+	if !ok {
+		return subnetLimiter, nil
+	}
+	opLimiters, ok := q.opLimiters[subnetID]
+	if !ok {
+		opLimiters = make(map[message.Op]*rate.Limiter)
+		q.opLimiters[subnetID] = opLimiters
+	}
+	opLimiter, ok := opLimiters[op]
+	if !ok {
+		opLimiter = q.newLimiter(subnetShare * opShare)
+		opLimiters[op] = opLimiter
+	}
+	return subnetLimiter, opLimiter
+}
+
+func (q *bandwidthQuota) newLimiter(share float64) *rate.Limiter {
+	return rate.NewLimiter(
+		rate.Limit(share*float64(q.config.RefillRate)),
+		int(share*float64(q.config.MaxBurstSize)),
+	)
+}
+
+type noBandwidthQuota struct{}
+
+func (noBandwidthQuota) Allow(ids.ID, message.Op, uint64) bool {
+	return true
+}
+
+// NewNoBandwidthQuota returns a BandwidthQuota that allows all messages.
+func NewNoBandwidthQuota() BandwidthQuota {
+	return noBandwidthQuota{}
+}
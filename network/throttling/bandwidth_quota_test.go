@@ -0,0 +1,71 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package throttling
+
+import (
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/message"
+	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/ava-labs/avalanchego/utils/set"
+)
+
+func TestBandwidthQuotaDisabled(t *testing.T) {
+	require := require.New(t)
+
+	q, err := NewBandwidthQuota("", prometheus.NewRegistry(), constants.PrimaryNetworkID, BandwidthQuotaConfig{})
+	require.NoError(err)
+	require.IsType(noBandwidthQuota{}, q)
+	require.True(q.Allow(ids.GenerateTestID(), message.AppGossipOp, 1<<30))
+}
+
+func TestBandwidthQuota(t *testing.T) {
+	require := require.New(t)
+
+	var (
+		limitedSubnetID = ids.GenerateTestID()
+		defaultSubnetID = ids.GenerateTestID()
+	)
+	q, err := NewBandwidthQuota(
+		"",
+		prometheus.NewRegistry(),
+		constants.PrimaryNetworkID,
+		BandwidthQuotaConfig{
+			// Small enough that the quotas don't replenish during the test
+			RefillRate:   1,
+			MaxBurstSize: 1000,
+			SubnetShares: map[ids.ID]float64{
+				limitedSubnetID: 0.5,
+			},
+			DefaultSubnetShare: 1,
+			OpShares: map[message.Op]float64{
+				message.AppGossipOp: 0.2,
+			},
+			PriorityOps: set.Of(message.AppResponseOp),
+		},
+	)
+	require.NoError(err)
+
+	// The primary network isn't limited unless it is given a share
+	require.True(q.Allow(constants.PrimaryNetworkID, message.AppGossipOp, 1<<30))
+
+	// The subnet's quota is scaled by its share
+	require.True(q.Allow(limitedSubnetID, message.AppRequestOp, 400))
+	require.False(q.Allow(limitedSubnetID, message.AppRequestOp, 200))
+
+	// Subnets share no quota
+	require.True(q.Allow(defaultSubnetID, message.AppRequestOp, 600))
+
+	// The op's quota is scaled by the subnet's share
+	require.True(q.Allow(defaultSubnetID, message.AppGossipOp, 200))
+	require.False(q.Allow(defaultSubnetID, message.AppGossipOp, 1))
+
+	// Priority ops are allowed beyond the quota, but consume it
+	require.True(q.Allow(defaultSubnetID, message.AppResponseOp, 200))
+	require.False(q.Allow(defaultSubnetID, message.AppRequestOp, 1))
+}
@@ -7,6 +7,8 @@ import (
 	"crypto/tls"
 	"time"
 
+	"github.com/ava-labs/avalanchego/api/audit"
+	"github.com/ava-labs/avalanchego/api/auth"
 	"github.com/ava-labs/avalanchego/api/server"
 	"github.com/ava-labs/avalanchego/chains"
 	"github.com/ava-labs/avalanchego/genesis"
@@ -23,6 +25,7 @@ import (
 	"github.com/ava-labs/avalanchego/utils/profiler"
 	"github.com/ava-labs/avalanchego/utils/set"
 	"github.com/ava-labs/avalanchego/utils/timer"
+	"github.com/ava-labs/avalanchego/vms/rpcchainvm"
 )
 
 type APIIndexerConfig struct {
@@ -39,6 +42,11 @@ type HTTPConfig struct {
 	HTTPSEnabled bool   `json:"httpsEnabled"`
 	HTTPSKey     []byte `json:"-"`
 	HTTPSCert    []byte `json:"-"`
+	// If non-empty, TLS client certificates signed by these PEM encoded CAs
+	// are verified and used to authenticate API callers.
+	HTTPSClientCAs []byte `json:"-"`
+
+	AuthConfig auth.Config `json:"authConfig"`
 
 	HTTPAllowedOrigins []string `json:"httpAllowedOrigins"`
 	HTTPAllowedHosts   []string `json:"httpAllowedHosts"`
@@ -69,6 +77,9 @@ type IPConfig struct {
 	// - If populated, listen only on the specified address.
 	ListenHost string `json:"listenHost"`
 	ListenPort uint16 `json:"listenPort"`
+	// The port advertised to peers. If 0, the port that is listened on is
+	// advertised. Allows peers to connect through a port forwarder or proxy.
+	PublicPort uint16 `json:"publicPort"`
 }
 
 type StakingConfig struct {
@@ -76,7 +87,7 @@ type StakingConfig struct {
 	SybilProtectionEnabled        bool            `json:"sybilProtectionEnabled"`
 	PartialSyncPrimaryNetwork     bool            `json:"partialSyncPrimaryNetwork"`
 	StakingTLSCert                tls.Certificate `json:"-"`
-	StakingSigningKey             *bls.SecretKey  `json:"-"`
+	StakingSigner                 bls.Signer      `json:"-"`
 	SybilProtectionDisabledWeight uint64          `json:"sybilProtectionDisabledWeight"`
 	StakingKeyPath                string          `json:"stakingKeyPath"`
 	StakingCertPath               string          `json:"stakingCertPath"`
@@ -176,6 +187,8 @@ type Config struct {
 
 	VMAliases map[ids.ID][]string `json:"vmAliases"`
 
+	RemoteVMs map[string]rpcchainvm.RemoteConfig `json:"remoteVMs"`
+
 	// Halflife to use for the processing requests tracker.
 	// Larger halflife --> usage metrics change more slowly.
 	SystemTrackerProcessingHalflife time.Duration `json:"systemTrackerProcessingHalflife"`
@@ -202,6 +215,8 @@ type Config struct {
 
 	TraceConfig trace.Config `json:"traceConfig"`
 
+	AuditLogConfig audit.Config `json:"auditLogConfig"`
+
 	// See comment on [UseCurrentHeight] in platformvm.Config
 	UseCurrentHeight bool `json:"useCurrentHeight"`
 
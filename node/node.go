@@ -7,12 +7,14 @@ import (
 	"context"
 	"crypto"
 	"crypto/tls"
+	"crypto/x509"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
 	"io/fs"
 	"net"
+	"net/http"
 	"os"
 	"path/filepath"
 	"strconv"
@@ -25,6 +27,8 @@ import (
 	"go.uber.org/zap"
 
 	"github.com/ava-labs/avalanchego/api/admin"
+	"github.com/ava-labs/avalanchego/api/audit"
+	"github.com/ava-labs/avalanchego/api/auth"
 	"github.com/ava-labs/avalanchego/api/health"
 	"github.com/ava-labs/avalanchego/api/info"
 	"github.com/ava-labs/avalanchego/api/keystore"
@@ -59,13 +63,13 @@ import (
 	"github.com/ava-labs/avalanchego/trace"
 	"github.com/ava-labs/avalanchego/utils"
 	"github.com/ava-labs/avalanchego/utils/constants"
-	"github.com/ava-labs/avalanchego/utils/crypto/bls"
 	"github.com/ava-labs/avalanchego/utils/dynamicip"
 	"github.com/ava-labs/avalanchego/utils/filesystem"
 	"github.com/ava-labs/avalanchego/utils/hashing"
 	"github.com/ava-labs/avalanchego/utils/ips"
 	"github.com/ava-labs/avalanchego/utils/logging"
 	"github.com/ava-labs/avalanchego/utils/math/meter"
+	"github.com/ava-labs/avalanchego/utils/metric"
 	"github.com/ava-labs/avalanchego/utils/perms"
 	"github.com/ava-labs/avalanchego/utils/profiler"
 	"github.com/ava-labs/avalanchego/utils/resource"
@@ -97,8 +101,9 @@ var (
 	indexerDBPrefix  = []byte{0x00}
 	keystoreDBPrefix = []byte("keystore")
 
-	errInvalidTLSKey = errors.New("invalid TLS key")
-	errShuttingDown  = errors.New("server shutting down")
+	errInvalidTLSKey         = errors.New("invalid TLS key")
+	errInvalidHTTPSClientCAs = errors.New("invalid HTTPS client CAs")
+	errShuttingDown          = errors.New("server shutting down")
 )
 
 // New returns an instance of Node
@@ -124,7 +129,10 @@ func New(
 
 	n.DoneShuttingDown.Add(1)
 
-	pop := signer.NewProofOfPossession(n.Config.StakingSigningKey)
+	pop, err := signer.NewProofOfPossessionFromSigner(n.Config.StakingSigner)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't generate proof of possession: %w", err)
+	}
 	logger.Info("initializing node",
 		zap.Stringer("version", version.CurrentApp),
 		zap.Stringer("nodeID", n.ID),
@@ -159,6 +167,12 @@ func New(
 		return nil, fmt.Errorf("couldn't initialize tracer: %w", err)
 	}
 
+	// Set up the audit log of privileged API calls
+	n.auditLog, err = audit.New(n.Config.AuditLogConfig)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't initialize audit log: %w", err)
+	}
+
 	n.initMetrics()
 	n.initNAT()
 	if err := n.initAPIServer(); err != nil { // Start the API Server
@@ -337,6 +351,9 @@ type Node struct {
 
 	tracer trace.Tracer
 
+	// Records calls to the admin and keystore APIs
+	auditLog audit.Log
+
 	// ensures that we only close the node once.
 	shutdownOnce sync.Once
 
@@ -418,6 +435,10 @@ func (n *Node) initNetworking() error {
 	if err != nil {
 		return err
 	}
+	listenPort := ipPort.Port
+	if n.Config.PublicPort != 0 {
+		ipPort.Port = n.Config.PublicPort
+	}
 
 	var dynamicIP ips.DynamicIPPort
 	switch {
@@ -462,7 +483,7 @@ func (n *Node) initNetworking() error {
 
 	// Regularly update our public IP and port mappings.
 	n.portMapper.Map(
-		ipPort.Port,
+		listenPort,
 		ipPort.Port,
 		stakingPortName,
 		dynamicIP,
@@ -512,6 +533,16 @@ func (n *Node) initNetworking() error {
 
 	// Create chain router
 	n.chainRouter = &router.ChainRouter{}
+	inboundQuota, err := throttling.NewBandwidthQuota(
+		metric.AppendNamespace(n.networkNamespace, "inbound_subnet_quota"),
+		n.MetricsRegisterer,
+		constants.PrimaryNetworkID,
+		n.Config.NetworkConfig.ThrottlerConfig.SubnetBandwidthQuotaConfig,
+	)
+	if err != nil {
+		return fmt.Errorf("initializing inbound bandwidth quota failed with: %w", err)
+	}
+	n.chainRouter = router.WithBandwidthQuota(n.chainRouter, inboundQuota)
 	if n.Config.TraceConfig.Enabled {
 		n.chainRouter = router.Trace(n.chainRouter, n.tracer)
 	}
@@ -534,7 +565,7 @@ func (n *Node) initNetworking() error {
 		err := n.vdrs.AddStaker(
 			constants.PrimaryNetworkID,
 			n.ID,
-			bls.PublicFromSecretKey(n.Config.StakingSigningKey),
+			n.Config.StakingSigner.PublicKey(),
 			dummyTxID,
 			n.Config.SybilProtectionDisabledWeight,
 		)
@@ -591,7 +622,7 @@ func (n *Node) initNetworking() error {
 	n.Config.NetworkConfig.Beacons = n.bootstrappers
 	n.Config.NetworkConfig.TLSConfig = tlsConfig
 	n.Config.NetworkConfig.TLSKey = tlsKey
-	n.Config.NetworkConfig.BLSKey = n.Config.StakingSigningKey
+	n.Config.NetworkConfig.BLSKey = n.Config.StakingSigner
 	n.Config.NetworkConfig.TrackedSubnets = n.Config.TrackedSubnets
 	n.Config.NetworkConfig.UptimeCalculator = n.uptimeCalculator
 	n.Config.NetworkConfig.UptimeRequirement = n.Config.UptimeRequirement
@@ -960,12 +991,30 @@ func (n *Node) initAPIServer() error {
 			MinVersion:   tls.VersionTLS12,
 			Certificates: []tls.Certificate{cert},
 		}
+		if len(n.Config.HTTPSClientCAs) > 0 {
+			clientCAs := x509.NewCertPool()
+			if !clientCAs.AppendCertsFromPEM(n.Config.HTTPSClientCAs) {
+				return errInvalidHTTPSClientCAs
+			}
+			// Client certificates are optional so that callers can also
+			// authenticate with bearer tokens.
+			config.ClientCAs = clientCAs
+			config.ClientAuth = tls.VerifyClientCertIfGiven
+		}
 		listener = tls.NewListener(listener, config)
 
 		protocol = "https"
 	}
 	n.apiURI = fmt.Sprintf("%s://%s", protocol, listener.Addr())
 
+	authorizer, err := auth.New(n.Config.AuthConfig)
+	if err != nil {
+		return fmt.Errorf("couldn't initialize API authentication: %w", err)
+	}
+	if authorizer == nil && hostIsPublic {
+		n.Log.Warn("API calls aren't authenticated. Anyone who can reach the HTTP port can call all enabled APIs")
+	}
+
 	n.APIServer, err = server.New(
 		n.Log,
 		n.LogFactory,
@@ -979,6 +1028,7 @@ func (n *Node) initAPIServer() error {
 		n.MetricsRegisterer,
 		n.Config.HTTPConfig.HTTPConfig,
 		n.Config.HTTPAllowedHosts,
+		authorizer,
 	)
 	return err
 }
@@ -1022,7 +1072,8 @@ func (n *Node) initChainManager(avaxAssetID ids.ID) error {
 
 	n.timeoutManager, err = timeout.NewManager(
 		&n.Config.AdaptiveTimeoutConfig,
-		n.benchlistManager,
+		// Responses and timeouts also affect the reputation of peers
+		peer.NewReputationBenchlist(n.benchlistManager, n.Net.Reputation()),
 		"requests",
 		n.MetricsRegisterer,
 	)
@@ -1058,7 +1109,7 @@ func (n *Node) initChainManager(avaxAssetID ids.ID) error {
 			SybilProtectionEnabled:                  n.Config.SybilProtectionEnabled,
 			StakingTLSSigner:                        n.StakingTLSSigner,
 			StakingTLSCert:                          n.StakingTLSCert,
-			StakingBLSKey:                           n.Config.StakingSigningKey,
+			StakingBLSKey:                           n.Config.StakingSigner,
 			Log:                                     n.Log,
 			LogFactory:                              n.LogFactory,
 			VMManager:                               n.VMManager,
@@ -1141,6 +1192,7 @@ func (n *Node) initVMs() error {
 				AddPrimaryNetworkDelegatorFee: n.Config.AddPrimaryNetworkDelegatorFee,
 				AddSubnetValidatorFee:         n.Config.AddSubnetValidatorFee,
 				AddSubnetDelegatorFee:         n.Config.AddSubnetDelegatorFee,
+				DynamicFeeConfig:              n.Config.DynamicFeeConfig,
 				UptimePercentage:              n.Config.UptimeRequirement,
 				MinValidatorStake:             n.Config.MinValidatorStake,
 				MaxValidatorStake:             n.Config.MaxValidatorStake,
@@ -1162,6 +1214,7 @@ func (n *Node) initVMs() error {
 			Config: avmconfig.Config{
 				TxFee:            n.Config.TxFee,
 				CreateAssetTxFee: n.Config.CreateAssetTxFee,
+				DynamicFeeConfig: n.Config.DynamicFeeConfig,
 				EUpgradeTime:     eUpgradeTime,
 			},
 		}),
@@ -1180,10 +1233,12 @@ func (n *Node) initVMs() error {
 			FileReader:      filesystem.NewReader(),
 			Manager:         n.VMManager,
 			PluginDirectory: n.Config.PluginDir,
+			RemoteVMs:       n.Config.RemoteVMs,
 			CPUTracker:      n.resourceManager,
 			RuntimeTracker:  n.runtimeManager,
 		}),
-		VMManager: n.VMManager,
+		VMManager:    n.VMManager,
+		ChainManager: n.chainManager,
 	})
 
 	// register any vms that need to be installed as plugins from disk
@@ -1218,7 +1273,7 @@ func (n *Node) initKeystoreAPI() error {
 		return nil
 	}
 	n.Log.Warn("initializing deprecated keystore API")
-	return n.APIServer.AddRoute(handler, "keystore", "")
+	return n.APIServer.AddRoute(n.audited(handler, "keystore"), "keystore", "")
 }
 
 // initMetricsAPI initializes the Metrics API
@@ -1282,12 +1337,21 @@ func (n *Node) initAdminAPI() error {
 		return err
 	}
 	return n.APIServer.AddRoute(
-		service,
+		n.audited(service, "admin"),
 		"admin",
 		"",
 	)
 }
 
+// audited returns [handler] wrapped to record its calls to the audit log, if
+// the audit log is enabled.
+func (n *Node) audited(handler http.Handler, service string) http.Handler {
+	if !n.Config.AuditLogConfig.Enabled {
+		return handler
+	}
+	return audit.NewHandler(handler, n.auditLog, n.Log, service)
+}
+
 // initProfiler initializes the continuous profiling
 func (n *Node) initProfiler() {
 	if !n.Config.ProfilerConfig.Enabled {
@@ -1320,11 +1384,16 @@ func (n *Node) initInfoAPI() error {
 
 	n.Log.Info("initializing info API")
 
+	pop, err := signer.NewProofOfPossessionFromSigner(n.Config.StakingSigner)
+	if err != nil {
+		return fmt.Errorf("couldn't generate proof of possession: %w", err)
+	}
+
 	service, err := info.NewService(
 		info.Parameters{
 			Version:                       version.CurrentApp,
 			NodeID:                        n.ID,
-			NodePOP:                       signer.NewProofOfPossession(n.Config.StakingSigningKey),
+			NodePOP:                       pop,
 			NetworkID:                     n.Config.NetworkID,
 			TxFee:                         n.Config.TxFee,
 			CreateAssetTxFee:              n.Config.CreateAssetTxFee,
@@ -1629,6 +1698,12 @@ func (n *Node) shutdown() {
 		)
 	}
 
+	if err := n.auditLog.Close(); err != nil {
+		n.Log.Warn("error during audit log shutdown",
+			zap.Error(err),
+		)
+	}
+
 	n.DoneShuttingDown.Done()
 	n.Log.Info("finished node shutdown")
 }
@@ -110,3 +110,134 @@ var Runtime_ServiceDesc = grpc.ServiceDesc{
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "vm/runtime/runtime.proto",
 }
+
+const (
+	Remote_Handshake_FullMethodName = "/vm.runtime.Remote/Handshake"
+	Remote_Shutdown_FullMethodName  = "/vm.runtime.Remote/Shutdown"
+)
+
+// RemoteClient is the client API for Remote service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type RemoteClient interface {
+	// Handshake reports the protocol version and instance of the VM server.
+	Handshake(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*HandshakeResponse, error)
+	// Shutdown stops the VM server once in-flight requests have completed.
+	Shutdown(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*emptypb.Empty, error)
+}
+
+type remoteClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewRemoteClient(cc grpc.ClientConnInterface) RemoteClient {
+	return &remoteClient{cc}
+}
+
+func (c *remoteClient) Handshake(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*HandshakeResponse, error) {
+	out := new(HandshakeResponse)
+	err := c.cc.Invoke(ctx, Remote_Handshake_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *remoteClient) Shutdown(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, Remote_Shutdown_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// RemoteServer is the server API for Remote service.
+// All implementations must embed UnimplementedRemoteServer
+// for forward compatibility
+type RemoteServer interface {
+	// Handshake reports the protocol version and instance of the VM server.
+	Handshake(context.Context, *emptypb.Empty) (*HandshakeResponse, error)
+	// Shutdown stops the VM server once in-flight requests have completed.
+	Shutdown(context.Context, *emptypb.Empty) (*emptypb.Empty, error)
+	mustEmbedUnimplementedRemoteServer()
+}
+
+// UnimplementedRemoteServer must be embedded to have forward compatible implementations.
+type UnimplementedRemoteServer struct {
+}
+
+func (UnimplementedRemoteServer) Handshake(context.Context, *emptypb.Empty) (*HandshakeResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Handshake not implemented")
+}
+func (UnimplementedRemoteServer) Shutdown(context.Context, *emptypb.Empty) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Shutdown not implemented")
+}
+func (UnimplementedRemoteServer) mustEmbedUnimplementedRemoteServer() {}
+
+// UnsafeRemoteServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to RemoteServer will
+// result in compilation errors.
+type UnsafeRemoteServer interface {
+	mustEmbedUnimplementedRemoteServer()
+}
+
+func RegisterRemoteServer(s grpc.ServiceRegistrar, srv RemoteServer) {
+	s.RegisterService(&Remote_ServiceDesc, srv)
+}
+
+func _Remote_Handshake_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RemoteServer).Handshake(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Remote_Handshake_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RemoteServer).Handshake(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Remote_Shutdown_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RemoteServer).Shutdown(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Remote_Shutdown_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RemoteServer).Shutdown(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// Remote_ServiceDesc is the grpc.ServiceDesc for Remote service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var Remote_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "vm.runtime.Remote",
+	HandlerType: (*RemoteServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Handshake",
+			Handler:    _Remote_Handshake_Handler,
+		},
+		{
+			MethodName: "Shutdown",
+			Handler:    _Remote_Shutdown_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "vm/runtime/runtime.proto",
+}
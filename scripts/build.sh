@@ -10,13 +10,16 @@ print_usage() {
   Options:
 
     -r  Build with race detector
+    -c  Build with the clockskew tag, which allows tests to skew the node's clock
 "
 }
 
 race=''
-while getopts 'r' flag; do
+clockskew=''
+while getopts 'rc' flag; do
   case "${flag}" in
     r) race='-r' ;;
+    c) clockskew='-c' ;;
     *) print_usage
       exit 1 ;;
   esac
@@ -31,7 +34,7 @@ source "$AVALANCHE_PATH"/scripts/constants.sh
 echo "Downloading dependencies..."
 go mod download
 
-build_args="$race"
+build_args="$race $clockskew"
 
 # Build avalanchego
 "$AVALANCHE_PATH"/scripts/build_avalanche.sh $build_args
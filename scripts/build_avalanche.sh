@@ -10,13 +10,16 @@ print_usage() {
   Options:
 
     -r  Build with race detector
+    -c  Build with the clockskew tag, which allows tests to skew the node's clock
 "
 }
 
 race=''
-while getopts 'r' flag; do
+tags=''
+while getopts 'rc' flag; do
   case "${flag}" in
     r) race='-race' ;;
+    c) tags='-tags=clockskew' ;;
     *) print_usage
       exit 1 ;;
   esac
@@ -57,6 +60,6 @@ AVALANCHE_PATH=$( cd "$( dirname "${BASH_SOURCE[0]}" )"; cd .. && pwd )
 # Load the constants
 source "$AVALANCHE_PATH"/scripts/constants.sh
 
-build_args="$race"
+build_args="$race $tags"
 echo "Building AvalancheGo..."
 go build $build_args -ldflags "-X github.com/ava-labs/avalanchego/version.GitCommit=$git_commit $static_ld_flags" -o "$avalanchego_path" "$AVALANCHE_PATH/main/"*.go
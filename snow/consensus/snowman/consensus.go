@@ -28,6 +28,11 @@ type Consensus interface {
 		lastAcceptedTime time.Time,
 	) error
 
+	// SetParameters replaces the snowball parameters that were provided to
+	// Initialize. Snowball instances that were already created keep the
+	// parameters they were created with.
+	SetParameters(params snowball.Parameters) error
+
 	// Returns the number of blocks processing
 	NumProcessing() int
 
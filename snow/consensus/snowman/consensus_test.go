@@ -53,6 +53,7 @@ var (
 		RecordPollTransitivelyResetConfidenceTest,
 		RecordPollInvalidVoteTest,
 		RecordPollTransitiveVotingTest,
+		RecordPollTransitiveVotingOrderTest,
 		RecordPollDivergedVotingTest,
 		RecordPollDivergedVotingWithNoConflictingBitTest,
 		RecordPollChangePreferredChainTest,
@@ -68,6 +69,7 @@ var (
 		ErrorOnAddDecidedBlockTest,
 		ErrorOnAddDuplicateBlockIDTest,
 		RecordPollWithDefaultParameters,
+		SetParametersTest,
 	}
 
 	errTest = errors.New("non-nil error")
@@ -937,6 +939,79 @@ func RecordPollTransitiveVotingTest(t *testing.T, factory Factory) {
 	require.Equal(choices.Rejected, block4.Status())
 }
 
+// Votes must be applied transitively regardless of the order they are iterated
+// over.
+func RecordPollTransitiveVotingOrderTest(t *testing.T, factory Factory) {
+	require := require.New(t)
+
+	params := snowball.Parameters{
+		K:                     2,
+		AlphaPreference:       2,
+		AlphaConfidence:       2,
+		BetaVirtuous:          1,
+		BetaRogue:             1,
+		ConcurrentRepolls:     1,
+		OptimalProcessing:     1,
+		MaxOutstandingItems:   1,
+		MaxItemProcessingTime: 1,
+	}
+
+	// Iteration over the votes is randomized, so the poll is repeated to
+	// ensure every order is exercised.
+	for i := 0; i < 64; i++ {
+		sm := factory.New()
+
+		snowCtx := snowtest.Context(t, snowtest.CChainID)
+		ctx := snowtest.ConsensusContext(snowCtx)
+		require.NoError(sm.Initialize(ctx, params, GenesisID, GenesisHeight, GenesisTimestamp))
+
+		block0 := &TestBlock{
+			TestDecidable: choices.TestDecidable{
+				IDV:     ids.Empty.Prefix(1),
+				StatusV: choices.Processing,
+			},
+			ParentV: Genesis.IDV,
+			HeightV: Genesis.HeightV + 1,
+		}
+		block1 := &TestBlock{
+			TestDecidable: choices.TestDecidable{
+				IDV:     ids.Empty.Prefix(2),
+				StatusV: choices.Processing,
+			},
+			ParentV: block0.IDV,
+			HeightV: block0.HeightV + 1,
+		}
+		block2 := &TestBlock{
+			TestDecidable: choices.TestDecidable{
+				IDV:     ids.Empty.Prefix(3),
+				StatusV: choices.Processing,
+			},
+			ParentV: block1.IDV,
+			HeightV: block1.HeightV + 1,
+		}
+
+		require.NoError(sm.Add(context.Background(), block0))
+		require.NoError(sm.Add(context.Background(), block1))
+		require.NoError(sm.Add(context.Background(), block2))
+
+		// Current graph structure:
+		//   G
+		//   |
+		//   0
+		//   |
+		//   1
+		//   |
+		//   2
+		//
+		// Both votes transitively support block 0 and block 1.
+		votes := bag.Of(block1.ID(), block2.ID())
+		require.NoError(sm.RecordPoll(context.Background(), votes))
+		require.Equal(choices.Accepted, block0.Status())
+		require.Equal(choices.Accepted, block1.Status())
+		require.Equal(1, sm.NumProcessing())
+	}
+}
+
 func RecordPollDivergedVotingTest(t *testing.T, factory Factory) {
 	sm := factory.New()
 	require := require.New(t)
@@ -1780,3 +1855,66 @@ func RecordPollWithDefaultParameters(t *testing.T, factory Factory) {
 	}
 	require.Zero(sm.NumProcessing())
 }
+
+func SetParametersTest(t *testing.T, factory Factory) {
+	require := require.New(t)
+
+	sm := factory.New()
+
+	snowCtx := snowtest.Context(t, snowtest.CChainID)
+	ctx := snowtest.ConsensusContext(snowCtx)
+	params := snowball.Parameters{
+		K:                     1,
+		AlphaPreference:       1,
+		AlphaConfidence:       1,
+		BetaVirtuous:          2,
+		BetaRogue:             2,
+		ConcurrentRepolls:     1,
+		OptimalProcessing:     1,
+		MaxOutstandingItems:   1,
+		MaxItemProcessingTime: 1,
+	}
+	require.NoError(sm.Initialize(ctx, params, GenesisID, GenesisHeight, GenesisTimestamp))
+
+	block0 := &TestBlock{
+		TestDecidable: choices.TestDecidable{
+			IDV:     ids.Empty.Prefix(1),
+			StatusV: choices.Processing,
+		},
+		ParentV: Genesis.IDV,
+		HeightV: Genesis.HeightV + 1,
+	}
+	block1 := &TestBlock{
+		TestDecidable: choices.TestDecidable{
+			IDV:     ids.Empty.Prefix(2),
+			StatusV: choices.Processing,
+		},
+		ParentV: block0.IDV,
+		HeightV: block0.HeightV + 1,
+	}
+
+	require.NoError(sm.Add(context.Background(), block0))
+
+	invalidParams := params
+	invalidParams.BetaVirtuous = 0
+	err := sm.SetParameters(invalidParams)
+	require.ErrorIs(err, snowball.ErrParametersInvalid)
+
+	newParams := params
+	newParams.BetaVirtuous = 1
+	newParams.BetaRogue = 1
+	require.NoError(sm.SetParameters(newParams))
+
+	// The block that was already processing is decided with the parameters it
+	// was issued with.
+	require.NoError(sm.RecordPoll(context.Background(), bag.Of(block0.ID())))
+	require.Equal(choices.Processing, block0.Status())
+	require.NoError(sm.RecordPoll(context.Background(), bag.Of(block0.ID())))
+	require.Equal(choices.Accepted, block0.Status())
+
+	// A single successful poll is now enough to accept a block.
+	require.NoError(sm.Add(context.Background(), block1))
+	require.NoError(sm.RecordPoll(context.Background(), bag.Of(block1.ID())))
+	require.Equal(choices.Accepted, block1.Status())
+	require.Zero(sm.NumProcessing())
+}
@@ -134,6 +134,21 @@ func (ts *Topological) Initialize(
 	return nil
 }
 
+func (ts *Topological) SetParameters(params snowball.Parameters) error {
+	if err := params.Verify(); err != nil {
+		return err
+	}
+	ts.params = params
+	// Blocks without children create their snowball instance with these
+	// parameters once their first child is added.
+	for _, node := range ts.blocks {
+		if node.sb == nil {
+			node.params = params
+		}
+	}
+	return nil
+}
+
 func (ts *Topological) NumProcessing() int {
 	return len(ts.blocks) - 1
 }
@@ -409,20 +424,20 @@ func (ts *Topological) calculateInDegree(votes bag.Bag[ids.ID]) {
 			parentID = n.blk.Parent()
 
 			// Increase the inDegree by one
-			kahn := ts.kahnNodes[parentID]
+			kahn, previouslySeen := ts.kahnNodes[parentID]
 			kahn.inDegree++
 			ts.kahnNodes[parentID] = kahn
 
-			// If we have already seen this block, then we shouldn't increase
-			// the inDegree of the ancestors through this block again.
-			if kahn.inDegree != 1 {
+			// This block has a child with votes, so it isn't a leaf.
+			ts.leaves.Remove(parentID)
+
+			// If we have already seen this block, either because it was
+			// previously a leaf or because it was previously reached
+			// transitively, then the inDegrees of its ancestors have already
+			// been increased through this block.
+			if previouslySeen {
 				break
 			}
-
-			// If I am transitively seeing this block for the first time, either
-			// the block was previously unknown or it was previously a leaf.
-			// Regardless, it shouldn't be tracked as a leaf.
-			ts.leaves.Remove(parentID)
 		}
 	}
 }
@@ -3,8 +3,71 @@
 
 package snowman
 
-import "testing"
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow/choices"
+	"github.com/ava-labs/avalanchego/snow/consensus/snowball"
+	"github.com/ava-labs/avalanchego/snow/snowtest"
+	"github.com/ava-labs/avalanchego/utils/bag"
+	"github.com/ava-labs/avalanchego/utils/set"
+)
 
 func TestTopological(t *testing.T) {
 	runConsensusTests(t, TopologicalFactory{})
 }
+
+// When a voted block's parent is also voted for, the ancestors of the parent
+// must only be counted once, regardless of which vote is iterated over first.
+func TestTopologicalCalculateInDegree(t *testing.T) {
+	require := require.New(t)
+
+	snowCtx := snowtest.Context(t, snowtest.CChainID)
+	ctx := snowtest.ConsensusContext(snowCtx)
+	ts := &Topological{}
+	require.NoError(ts.Initialize(ctx, snowball.DefaultParameters, GenesisID, GenesisHeight, GenesisTimestamp))
+
+	block0 := &TestBlock{
+		TestDecidable: choices.TestDecidable{
+			IDV:     ids.Empty.Prefix(1),
+			StatusV: choices.Processing,
+		},
+		ParentV: Genesis.IDV,
+		HeightV: Genesis.HeightV + 1,
+	}
+	block1 := &TestBlock{
+		TestDecidable: choices.TestDecidable{
+			IDV:     ids.Empty.Prefix(2),
+			StatusV: choices.Processing,
+		},
+		ParentV: block0.IDV,
+		HeightV: block0.HeightV + 1,
+	}
+	block2 := &TestBlock{
+		TestDecidable: choices.TestDecidable{
+			IDV:     ids.Empty.Prefix(3),
+			StatusV: choices.Processing,
+		},
+		ParentV: block1.IDV,
+		HeightV: block1.HeightV + 1,
+	}
+	require.NoError(ts.Add(context.Background(), block0))
+	require.NoError(ts.Add(context.Background(), block1))
+	require.NoError(ts.Add(context.Background(), block2))
+
+	// Iteration over the votes is randomized, so the calculation is repeated
+	// to ensure every order is exercised.
+	votes := bag.Of(block1.ID(), block2.ID())
+	for i := 0; i < 64; i++ {
+		ts.calculateInDegree(votes)
+
+		require.Equal(1, ts.kahnNodes[GenesisID].inDegree)
+		require.Equal(1, ts.kahnNodes[block0.ID()].inDegree)
+		require.Zero(ts.kahnNodes[block1.ID()].inDegree)
+		require.Equal(set.Of(block1.ID()), ts.leaves)
+	}
+}
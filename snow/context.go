@@ -95,4 +95,7 @@ type ConsensusContext struct {
 
 	// True iff this chain is currently state-syncing
 	StateSyncing utils.Atomic[bool]
+
+	// Progress of the most recent bootstrapping round of this chain.
+	BootstrapProgress utils.Atomic[BootstrapProgress]
 }
@@ -102,6 +102,16 @@ func (gh *getter) GetAccepted(_ context.Context, nodeID ids.NodeID, requestID ui
 	return nil
 }
 
+func (gh *getter) GetAncestorsAtHeight(_ context.Context, nodeID ids.NodeID, requestID uint32, _ uint64) error {
+	gh.log.Debug("dropping request",
+		zap.String("reason", "unhandled by this gear"),
+		zap.Stringer("messageOp", message.GetAncestorsOp),
+		zap.Stringer("nodeID", nodeID),
+		zap.Uint32("requestID", requestID),
+	)
+	return nil
+}
+
 func (gh *getter) GetAncestors(ctx context.Context, nodeID ids.NodeID, requestID uint32, vtxID ids.ID) error {
 	startTime := time.Now()
 	gh.log.Verbo("called GetAncestors",
@@ -259,6 +259,18 @@ func (mr *MockSenderMockRecorder) SendGetAncestors(ctx, nodeID, requestID, conta
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendGetAncestors", reflect.TypeOf((*MockSender)(nil).SendGetAncestors), ctx, nodeID, requestID, containerID)
 }
 
+// SendGetAncestorsAtHeight mocks base method.
+func (m *MockSender) SendGetAncestorsAtHeight(ctx context.Context, nodeID ids.NodeID, requestID uint32, height uint64) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SendGetAncestorsAtHeight", ctx, nodeID, requestID, height)
+}
+
+// SendGetAncestorsAtHeight indicates an expected call of SendGetAncestorsAtHeight.
+func (mr *MockSenderMockRecorder) SendGetAncestorsAtHeight(ctx, nodeID, requestID, height any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendGetAncestorsAtHeight", reflect.TypeOf((*MockSender)(nil).SendGetAncestorsAtHeight), ctx, nodeID, requestID, height)
+}
+
 // SendGetStateSummaryFrontier mocks base method.
 func (m *MockSender) SendGetStateSummaryFrontier(ctx context.Context, nodeIDs set.Set[ids.NodeID], requestID uint32) {
 	m.ctrl.T.Helper()
@@ -120,6 +120,10 @@ type FetchSender interface {
 	// and its ancestors.
 	SendGetAncestors(ctx context.Context, nodeID ids.NodeID, requestID uint32, containerID ids.ID)
 
+	// SendGetAncestorsAtHeight requests that node [nodeID] send its accepted
+	// container at [height] and its ancestors.
+	SendGetAncestorsAtHeight(ctx context.Context, nodeID ids.NodeID, requestID uint32, height uint64)
+
 	// Tell the specified node about [container].
 	SendPut(ctx context.Context, nodeID ids.NodeID, requestID uint32, container []byte)
 
@@ -35,6 +35,7 @@ var (
 	errAccepted                      = errors.New("unexpectedly called Accepted")
 	errGet                           = errors.New("unexpectedly called Get")
 	errGetAncestors                  = errors.New("unexpectedly called GetAncestors")
+	errGetAncestorsAtHeight          = errors.New("unexpectedly called GetAncestorsAtHeight")
 	errGetFailed                     = errors.New("unexpectedly called GetFailed")
 	errGetAncestorsFailed            = errors.New("unexpectedly called GetAncestorsFailed")
 	errPut                           = errors.New("unexpectedly called Put")
@@ -82,6 +83,7 @@ type EngineTest struct {
 
 	CantGet,
 	CantGetAncestors,
+	CantGetAncestorsAtHeight,
 	CantGetFailed,
 	CantGetAncestorsFailed,
 	CantPut,
@@ -115,6 +117,7 @@ type EngineTest struct {
 	TimeoutF, GossipF, ShutdownF func(context.Context) error
 	NotifyF                      func(context.Context, Message) error
 	GetF, GetAncestorsF          func(ctx context.Context, nodeID ids.NodeID, requestID uint32, containerID ids.ID) error
+	GetAncestorsAtHeightF        func(ctx context.Context, nodeID ids.NodeID, requestID uint32, height uint64) error
 	PullQueryF                   func(ctx context.Context, nodeID ids.NodeID, requestID uint32, containerID ids.ID, requestedHeight uint64) error
 	PutF                         func(ctx context.Context, nodeID ids.NodeID, requestID uint32, container []byte) error
 	PushQueryF                   func(ctx context.Context, nodeID ids.NodeID, requestID uint32, container []byte, requestedHeight uint64) error
@@ -164,6 +167,7 @@ func (e *EngineTest) Default(cant bool) {
 	e.CantAccepted = cant
 	e.CantGet = cant
 	e.CantGetAncestors = cant
+	e.CantGetAncestorsAtHeight = cant
 	e.CantGetAncestorsFailed = cant
 	e.CantGetFailed = cant
 	e.CantPut = cant
@@ -458,6 +462,19 @@ func (e *EngineTest) GetAncestors(ctx context.Context, nodeID ids.NodeID, reques
 	return errGetAncestors
 }
 
+func (e *EngineTest) GetAncestorsAtHeight(ctx context.Context, nodeID ids.NodeID, requestID uint32, height uint64) error {
+	if e.GetAncestorsAtHeightF != nil {
+		return e.GetAncestorsAtHeightF(ctx, nodeID, requestID, height)
+	}
+	if !e.CantGetAncestorsAtHeight {
+		return nil
+	}
+	if e.T != nil {
+		require.FailNow(e.T, errGetAncestorsAtHeight.Error())
+	}
+	return errGetAncestorsAtHeight
+}
+
 func (e *EngineTest) GetFailed(ctx context.Context, nodeID ids.NodeID, requestID uint32) error {
 	if e.GetFailedF != nil {
 		return e.GetFailedF(ctx, nodeID, requestID)
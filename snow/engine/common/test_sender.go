@@ -31,7 +31,7 @@ type SenderTest struct {
 	CantSendGetAcceptedStateSummary, CantSendAcceptedStateSummary,
 	CantSendGetAcceptedFrontier, CantSendAcceptedFrontier,
 	CantSendGetAccepted, CantSendAccepted,
-	CantSendGet, CantSendGetAncestors, CantSendPut, CantSendAncestors,
+	CantSendGet, CantSendGetAncestors, CantSendGetAncestorsAtHeight, CantSendPut, CantSendAncestors,
 	CantSendPullQuery, CantSendPushQuery, CantSendChits,
 	CantSendAppRequest, CantSendAppResponse, CantSendAppError,
 	CantSendAppGossip,
@@ -47,6 +47,7 @@ type SenderTest struct {
 	SendAcceptedF                func(context.Context, ids.NodeID, uint32, []ids.ID)
 	SendGetF                     func(context.Context, ids.NodeID, uint32, ids.ID)
 	SendGetAncestorsF            func(context.Context, ids.NodeID, uint32, ids.ID)
+	SendGetAncestorsAtHeightF    func(context.Context, ids.NodeID, uint32, uint64)
 	SendPutF                     func(context.Context, ids.NodeID, uint32, []byte)
 	SendAncestorsF               func(context.Context, ids.NodeID, uint32, [][]byte)
 	SendPushQueryF               func(context.Context, set.Set[ids.NodeID], uint32, []byte, uint64)
@@ -195,6 +196,17 @@ func (s *SenderTest) SendGetAncestors(ctx context.Context, validatorID ids.NodeI
 	}
 }
 
+// SendGetAncestorsAtHeight calls SendGetAncestorsAtHeightF if it was
+// initialized. If it wasn't initialized and this function shouldn't be called
+// and testing was initialized, then testing will fail.
+func (s *SenderTest) SendGetAncestorsAtHeight(ctx context.Context, validatorID ids.NodeID, requestID uint32, height uint64) {
+	if s.SendGetAncestorsAtHeightF != nil {
+		s.SendGetAncestorsAtHeightF(ctx, validatorID, requestID, height)
+	} else if s.CantSendGetAncestorsAtHeight && s.T != nil {
+		require.FailNow(s.T, "Unexpectedly called SendGetAncestorsAtHeight")
+	}
+}
+
 // SendPut calls SendPutF if it was initialized. If it wasn't initialized and
 // this function shouldn't be called and testing was initialized, then testing
 // will fail.
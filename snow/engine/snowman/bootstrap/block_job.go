@@ -25,6 +25,7 @@ var errMissingDependenciesOnAccept = errors.New("attempting to accept a block wi
 type parser struct {
 	log         logging.Logger
 	numAccepted prometheus.Counter
+	onAccepted  func()
 	vm          block.ChainVM
 }
 
@@ -36,6 +37,7 @@ func (p *parser) Parse(ctx context.Context, blkBytes []byte) (queue.Job, error)
 	return &blockJob{
 		log:         p.log,
 		numAccepted: p.numAccepted,
+		onAccepted:  p.onAccepted,
 		blk:         blk,
 		vm:          p.vm,
 	}, nil
@@ -44,6 +46,7 @@ func (p *parser) Parse(ctx context.Context, blkBytes []byte) (queue.Job, error)
 type blockJob struct {
 	log         logging.Logger
 	numAccepted prometheus.Counter
+	onAccepted  func()
 	blk         snowman.Block
 	vm          block.Getter
 }
@@ -104,6 +107,9 @@ func (b *blockJob) Execute(ctx context.Context) error {
 			)
 			return fmt.Errorf("failed to accept block in bootstrapping: %w", err)
 		}
+		if b.onAccepted != nil {
+			b.onAccepted()
+		}
 	}
 	return nil
 }
@@ -13,6 +13,7 @@ import (
 
 	"go.uber.org/zap"
 
+	"github.com/ava-labs/avalanchego/database"
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/proto/pb/p2p"
 	"github.com/ava-labs/avalanchego/snow"
@@ -38,12 +39,26 @@ const (
 	// maxOutstandingBroadcastRequests is the maximum number of requests to have
 	// outstanding when broadcasting.
 	maxOutstandingBroadcastRequests = 50
+
+	// fetchRangeSize is the number of heights in each range that is fetched in
+	// parallel. Ancestries that are missing fewer blocks are only fetched by
+	// traversing them.
+	fetchRangeSize = 16384
 )
 
 var (
 	_ common.BootstrapableEngine = (*Bootstrapper)(nil)
 
 	errUnexpectedTimeout = errors.New("unexpected timeout fired")
+
+	// heightRequestsVersion is the first version that serves GetAncestors
+	// requests by height.
+	heightRequestsVersion = &version.Application{
+		Name:  version.Client,
+		Major: 1,
+		Minor: 11,
+		Patch: 3,
+	}
 )
 
 // bootstrapper repeatedly performs the bootstrapping protocol.
@@ -52,7 +67,9 @@ var (
 //  2. Sample a small number of nodes to get the last accepted block ID
 //  3. Verify against the full network that the last accepted block ID received
 //     in step 2 is an accepted block.
-//  4. Sync the full ancestry of the last accepted block.
+//  4. Sync the full ancestry of the last accepted block. Disjoint height ranges
+//     of the ancestry are fetched from several peers in parallel, ahead of the
+//     traversal that verifies them.
 //  5. Execute all the fetched blocks that haven't already been executed.
 //  6. Restart the bootstrapping protocol until the number of blocks being
 //     accepted during a bootstrapping round stops decreasing.
@@ -95,6 +112,29 @@ type Bootstrapper struct {
 	// tracks which validators were asked for which containers in which requests
 	outstandingRequests *bimap.BiMap[common.Request, ids.ID]
 
+	// Height of the last accepted block as of the last time blocks were
+	// executed
+	acceptedHeight uint64
+	// Number of heights in each range
+	fetchRangeSize uint64
+	// Ranges of heights being fetched, sorted from the highest to the lowest
+	ranges []*fetchRange
+	// Blocks fetched by [ranges]
+	fetched *fetchedBlocks
+	// Tracks the range and height of each outstanding range request
+	rangeRequests map[common.Request]rangeRequest
+	// Height -> ID of the block the traversal is waiting on a range to fetch
+	awaiting map[uint64]ids.ID
+	// Throughput and outstanding requests of the peers blocks are fetched from
+	peers *fetchPeers
+	// Connected peers that support fetching blocks by height
+	heightPeers set.Set[ids.NodeID]
+
+	// Number of blocks executed and to be executed in the current round, and
+	// the time that executing them started
+	numExecuted, numToExecute uint64
+	executeStartTime          time.Time
+
 	// number of state transitions executed
 	executedStateTransitions int
 
@@ -119,8 +159,17 @@ type Bootstrapper struct {
 	onFinished func(ctx context.Context, lastReqID uint32) error
 }
 
+type rangeRequest struct {
+	r      *fetchRange
+	height uint64
+}
+
 func New(config Config, onFinished func(ctx context.Context, lastReqID uint32) error) (*Bootstrapper, error) {
 	metrics, err := newMetrics("bs", config.Ctx.Registerer)
+	if err != nil {
+		return nil, err
+	}
+	fetched, err := newFetchedBlocks(config.DB)
 	return &Bootstrapper{
 		Config:                      config,
 		metrics:                     metrics,
@@ -136,6 +185,12 @@ func New(config Config, onFinished func(ctx context.Context, lastReqID uint32) e
 
 		outstandingRequests: bimap.New[common.Request, ids.ID](),
 
+		fetchRangeSize: fetchRangeSize,
+		fetched:        fetched,
+		rangeRequests:  make(map[common.Request]rangeRequest),
+		awaiting:       make(map[uint64]ids.ID),
+		peers:          newFetchPeers(),
+
 		executedStateTransitions: math.MaxInt,
 		onFinished:               onFinished,
 	}, err
@@ -152,6 +207,9 @@ func (b *Bootstrapper) Clear(context.Context) error {
 	if err := b.Config.Blocked.Clear(); err != nil {
 		return err
 	}
+	if err := b.fetched.Clear(); err != nil {
+		return err
+	}
 	return b.Config.Blocked.Commit()
 }
 
@@ -170,6 +228,7 @@ func (b *Bootstrapper) Start(ctx context.Context, startReqID uint32) error {
 	b.parser = &parser{
 		log:         b.Ctx.Log,
 		numAccepted: b.numAccepted,
+		onAccepted:  b.onBlockExecuted,
 		vm:          b.VM,
 	}
 	if err := b.Blocked.SetParser(ctx, b.parser); err != nil {
@@ -186,8 +245,13 @@ func (b *Bootstrapper) Start(ctx context.Context, startReqID uint32) error {
 		return fmt.Errorf("couldn't get last accepted block: %w", err)
 	}
 	b.startingHeight = lastAccepted.Height()
+	b.acceptedHeight = b.startingHeight
 	b.requestID = startReqID
 
+	if err := b.loadRanges(); err != nil {
+		return err
+	}
+
 	return b.tryStartBootstrapping(ctx)
 }
 
@@ -203,6 +267,9 @@ func (b *Bootstrapper) Connected(ctx context.Context, nodeID ids.NodeID, nodeVer
 	if _, ok := b.Beacons.GetValidator(b.Ctx.SubnetID, nodeID); ok {
 		b.fetchFrom.Add(nodeID)
 	}
+	if !nodeVersion.Before(heightRequestsVersion) {
+		b.heightPeers.Add(nodeID)
+	}
 
 	return b.tryStartBootstrapping(ctx)
 }
@@ -217,6 +284,7 @@ func (b *Bootstrapper) Disconnected(ctx context.Context, nodeID ids.NodeID) erro
 	}
 
 	b.markUnavailable(nodeID)
+	b.heightPeers.Remove(nodeID)
 	return nil
 }
 
@@ -400,6 +468,13 @@ func (b *Bootstrapper) startSyncing(ctx context.Context, acceptedContainerIDs []
 		// `database.ErrNotFound`, then the error should be propagated.
 		blk, err := b.VM.GetBlock(ctx, blkID)
 		if err != nil {
+			// The block may have been fetched by a range before a restart.
+			blk, err = b.getFetched(ctx, blkID)
+			if err != nil {
+				return err
+			}
+		}
+		if blk == nil {
 			if err := b.fetch(ctx, blkID); err != nil {
 				return err
 			}
@@ -408,7 +483,7 @@ func (b *Bootstrapper) startSyncing(ctx context.Context, acceptedContainerIDs []
 		toProcess = append(toProcess, blk)
 	}
 
-	b.initiallyFetched = b.Blocked.PendingJobs()
+	b.initiallyFetched = b.Blocked.PendingJobs() + b.fetched.numBlocks
 	b.startTime = time.Now()
 
 	// Process received blocks
@@ -418,6 +493,7 @@ func (b *Bootstrapper) startSyncing(ctx context.Context, acceptedContainerIDs []
 		}
 	}
 
+	b.updateProgress()
 	return b.tryStartExecuting(ctx)
 }
 
@@ -433,7 +509,22 @@ func (b *Bootstrapper) fetch(ctx context.Context, blkID ids.ID) error {
 		return b.tryStartExecuting(ctx)
 	}
 
-	validatorID, ok := b.fetchFrom.Peek()
+	// If a range covers this block, the range fetches it
+	height, err := b.fetched.GetMissingHeight(blkID)
+	switch {
+	case err == nil:
+		if r := b.rangeAt(height); r != nil {
+			return b.redirectRange(ctx, r, height, blkID)
+		}
+	case !errors.Is(err, database.ErrNotFound):
+		return err
+	}
+
+	// Prefer the peers that are not already serving a request
+	validatorID, ok := b.peers.Sample(b.idlePeers())
+	if !ok {
+		validatorID, ok = b.peers.Sample(b.fetchFrom)
+	}
 	if !ok {
 		return fmt.Errorf("dropping request for %s as there are no validators", blkID)
 	}
@@ -443,13 +534,12 @@ func (b *Bootstrapper) fetch(ctx context.Context, blkID ids.ID) error {
 
 	b.requestID++
 
-	b.outstandingRequests.Put(
-		common.Request{
-			NodeID:    validatorID,
-			RequestID: b.requestID,
-		},
-		blkID,
-	)
+	request := common.Request{
+		NodeID:    validatorID,
+		RequestID: b.requestID,
+	}
+	b.outstandingRequests.Put(request, blkID)
+	b.peers.Sent(request, time.Now())
 	b.Config.Sender.SendGetAncestors(ctx, validatorID, b.requestID, blkID) // request block and ancestors
 	return nil
 }
@@ -457,11 +547,17 @@ func (b *Bootstrapper) fetch(ctx context.Context, blkID ids.ID) error {
 // Ancestors handles the receipt of multiple containers. Should be received in
 // response to a GetAncestors message to [nodeID] with request ID [requestID]
 func (b *Bootstrapper) Ancestors(ctx context.Context, nodeID ids.NodeID, requestID uint32, blks [][]byte) error {
-	// Make sure this is in response to a request we made
-	wantedBlkID, ok := b.outstandingRequests.DeleteKey(common.Request{
+	request := common.Request{
 		NodeID:    nodeID,
 		RequestID: requestID,
-	})
+	}
+	if rangeRequest, ok := b.rangeRequests[request]; ok {
+		delete(b.rangeRequests, request)
+		return b.rangeAncestors(ctx, request, rangeRequest, blks)
+	}
+
+	// Make sure this is in response to a request we made
+	wantedBlkID, ok := b.outstandingRequests.DeleteKey(request)
 	if !ok { // this message isn't in response to a request we made
 		b.Ctx.Log.Debug("received unexpected Ancestors",
 			zap.Stringer("nodeID", nodeID),
@@ -478,6 +574,7 @@ func (b *Bootstrapper) Ancestors(ctx context.Context, nodeID ids.NodeID, request
 		)
 
 		b.markUnavailable(nodeID)
+		b.peers.Received(request, 0, time.Now())
 
 		// Send another request for this
 		return b.fetch(ctx, wantedBlkID)
@@ -496,6 +593,7 @@ func (b *Bootstrapper) Ancestors(ctx context.Context, nodeID ids.NodeID, request
 	}
 
 	blocks, err := block.BatchedParseBlock(ctx, b.VM, blks)
+	b.peers.Received(request, len(blocks), time.Now())
 	if err != nil { // the provided blocks couldn't be parsed
 		b.Ctx.Log.Debug("failed to parse blocks in Ancestors",
 			zap.Stringer("nodeID", nodeID),
@@ -526,14 +624,27 @@ func (b *Bootstrapper) Ancestors(ctx context.Context, nodeID ids.NodeID, request
 	for _, block := range blocks[1:] {
 		blockSet[block.ID()] = block
 	}
-	return b.process(ctx, requestedBlock, blockSet)
+	if err := b.process(ctx, requestedBlock, blockSet); err != nil {
+		return err
+	}
+	b.updateProgress()
+	return nil
 }
 
 func (b *Bootstrapper) GetAncestorsFailed(ctx context.Context, nodeID ids.NodeID, requestID uint32) error {
-	blkID, ok := b.outstandingRequests.DeleteKey(common.Request{
+	request := common.Request{
 		NodeID:    nodeID,
 		RequestID: requestID,
-	})
+	}
+	if rangeRequest, ok := b.rangeRequests[request]; ok {
+		delete(b.rangeRequests, request)
+		rangeRequest.r.requested = false
+		b.peers.Received(request, 0, time.Now())
+		b.fetchRanges(ctx)
+		return nil
+	}
+
+	blkID, ok := b.outstandingRequests.DeleteKey(request)
 	if !ok {
 		b.Ctx.Log.Debug("unexpectedly called GetAncestorsFailed",
 			zap.Stringer("nodeID", nodeID),
@@ -544,6 +655,7 @@ func (b *Bootstrapper) GetAncestorsFailed(ctx context.Context, nodeID ids.NodeID
 
 	// This node timed out their request, so we can add them back to [fetchFrom]
 	b.fetchFrom.Add(nodeID)
+	b.peers.Received(request, 0, time.Now())
 
 	// Send another request for this
 	return b.fetch(ctx, blkID)
@@ -562,6 +674,381 @@ func (b *Bootstrapper) markUnavailable(nodeID ids.NodeID) {
 	}
 }
 
+// idlePeers returns the peers in [fetchFrom] without an outstanding request.
+func (b *Bootstrapper) idlePeers() set.Set[ids.NodeID] {
+	idle := set.NewSet[ids.NodeID](b.fetchFrom.Len())
+	for nodeID := range b.fetchFrom {
+		if !b.peers.Busy(nodeID) {
+			idle.Add(nodeID)
+		}
+	}
+	return idle
+}
+
+// loadRanges restores the ranges that were being fetched before a restart,
+// dropping the heights that have since been accepted.
+func (b *Bootstrapper) loadRanges() error {
+	ranges, err := b.fetched.GetRanges()
+	if err != nil {
+		return err
+	}
+	if err := b.fetched.DeleteBlocks(0, b.startingHeight); err != nil {
+		return err
+	}
+
+	b.ranges = b.ranges[:0]
+	for _, r := range ranges {
+		if r.start > b.startingHeight {
+			b.ranges = append(b.ranges, r)
+			continue
+		}
+		if err := b.fetched.DeleteRange(r); err != nil {
+			return err
+		}
+		if r.top <= b.startingHeight {
+			continue
+		}
+		r.start = b.startingHeight + 1
+		if err := b.fetched.PutRange(r); err != nil {
+			return err
+		}
+		b.ranges = append(b.ranges, r)
+	}
+	return nil
+}
+
+// addRanges splits the heights up to [height] that aren't covered by a range
+// into ranges, if there are enough of them to be worth fetching in parallel.
+func (b *Bootstrapper) addRanges(height uint64) error {
+	covered := b.acceptedHeight
+	if len(b.ranges) > 0 {
+		covered = max(covered, b.ranges[0].top)
+	}
+	if height <= covered || height-covered <= b.fetchRangeSize {
+		return nil
+	}
+
+	var ranges []*fetchRange
+	for top := height; top > covered; {
+		start := covered + 1
+		if top-covered > b.fetchRangeSize {
+			start = top - b.fetchRangeSize + 1
+		}
+		r := &fetchRange{
+			start: start,
+			top:   top,
+			next:  top,
+		}
+		if err := b.fetched.PutRange(r); err != nil {
+			return err
+		}
+		ranges = append(ranges, r)
+		top = start - 1
+	}
+
+	b.Ctx.Log.Debug("fetching ranges in parallel",
+		zap.Int("numRanges", len(ranges)),
+		zap.Uint64("startHeight", covered+1),
+		zap.Uint64("endHeight", height),
+	)
+	b.ranges = append(ranges, b.ranges...)
+	return nil
+}
+
+// rangeAt returns the range that contains [height], if any.
+func (b *Bootstrapper) rangeAt(height uint64) *fetchRange {
+	for _, r := range b.ranges {
+		if r.start <= height && height <= r.top {
+			return r
+		}
+	}
+	return nil
+}
+
+// redirectRange makes [r] fetch [blkID] at [height] next, as the traversal
+// is waiting on it.
+func (b *Bootstrapper) redirectRange(ctx context.Context, r *fetchRange, height uint64, blkID ids.ID) error {
+	b.awaiting[height] = blkID
+	if r.next != height || r.nextID != blkID {
+		// The blocks below [height] that [r] already fetched can't be used
+		// without the block at [height], so they are fetched again.
+		if r.next < height {
+			if err := b.fetched.DeleteBlocks(max(r.start, r.next+1), height); err != nil {
+				return err
+			}
+		}
+		r.next = height
+		r.nextID = blkID
+		if err := b.fetched.PutRange(r); err != nil {
+			return err
+		}
+	}
+	b.fetchRanges(ctx)
+	return nil
+}
+
+// fetchRanges sends a request for each range that isn't done and doesn't have
+// an outstanding request, while there are idle peers to send them to.
+func (b *Bootstrapper) fetchRanges(ctx context.Context) {
+	idle := b.idlePeers()
+	for _, r := range b.ranges {
+		if r.done() || r.requested {
+			continue
+		}
+
+		// Until the ID of the next block of a range is known, the range can
+		// only be fetched by height from the peers that support it. Otherwise,
+		// the range is fetched by ID once the traversal reaches it.
+		candidates := idle
+		if r.nextID == ids.Empty {
+			candidates = set.NewSet[ids.NodeID](idle.Len())
+			for nodeID := range idle {
+				if b.heightPeers.Contains(nodeID) {
+					candidates.Add(nodeID)
+				}
+			}
+		}
+
+		nodeID, ok := b.peers.Sample(candidates)
+		if !ok {
+			if idle.Len() == 0 {
+				return
+			}
+			continue
+		}
+		idle.Remove(nodeID)
+
+		b.requestID++
+		request := common.Request{
+			NodeID:    nodeID,
+			RequestID: b.requestID,
+		}
+		b.rangeRequests[request] = rangeRequest{
+			r:      r,
+			height: r.next,
+		}
+		r.requested = true
+		b.peers.Sent(request, time.Now())
+
+		if r.nextID == ids.Empty {
+			b.Sender.SendGetAncestorsAtHeight(ctx, nodeID, b.requestID, r.next)
+		} else {
+			b.Sender.SendGetAncestors(ctx, nodeID, b.requestID, r.nextID)
+		}
+	}
+}
+
+// rangeAncestors handles the response to a request for the blocks of a range.
+// The blocks are stored until the traversal reaches them, unless the
+// traversal is already waiting on them.
+func (b *Bootstrapper) rangeAncestors(ctx context.Context, request common.Request, rangeRequest rangeRequest, blks [][]byte) error {
+	var (
+		nodeID    = request.NodeID
+		requestID = request.RequestID
+		r         = rangeRequest.r
+	)
+	r.requested = false
+
+	if len(blks) == 0 {
+		b.Ctx.Log.Debug("received Ancestors with no block",
+			zap.Stringer("nodeID", nodeID),
+			zap.Uint32("requestID", requestID),
+		)
+		b.markUnavailable(nodeID)
+		b.peers.Received(request, 0, time.Now())
+		b.fetchRanges(ctx)
+		return nil
+	}
+
+	if len(blks) > b.Config.AncestorsMaxContainersReceived {
+		blks = blks[:b.Config.AncestorsMaxContainersReceived]
+	}
+
+	blocks, err := block.BatchedParseBlock(ctx, b.VM, blks)
+	if err != nil {
+		b.Ctx.Log.Debug("failed to parse blocks in Ancestors",
+			zap.Stringer("nodeID", nodeID),
+			zap.Uint32("requestID", requestID),
+			zap.Error(err),
+		)
+		b.peers.Received(request, 0, time.Now())
+		b.fetchRanges(ctx)
+		return nil
+	}
+
+	// The range may have been redirected since the request was sent
+	if rangeRequest.height != r.next {
+		b.peers.Received(request, len(blocks), time.Now())
+		b.fetchRanges(ctx)
+		return nil
+	}
+
+	rangeBlks := rangeBlocks(r, blocks)
+	b.peers.Received(request, len(rangeBlks), time.Now())
+	if len(rangeBlks) == 0 {
+		b.Ctx.Log.Debug("received Ancestors that don't continue the range",
+			zap.Stringer("nodeID", nodeID),
+			zap.Uint32("requestID", requestID),
+			zap.Uint64("height", r.next),
+		)
+		b.fetchRanges(ctx)
+		return nil
+	}
+
+	lowest := rangeBlks[len(rangeBlks)-1]
+	r.next = lowest.Height() - 1
+	r.nextID = lowest.Parent()
+	if err := b.fetched.PutRange(r); err != nil {
+		return err
+	}
+
+	first := rangeBlks[0]
+	if blkID, ok := b.awaiting[rangeRequest.height]; ok && blkID == first.ID() {
+		// The traversal verifies the blocks as it goes, so it can also use
+		// the blocks below the range.
+		delete(b.awaiting, rangeRequest.height)
+
+		blockSet := make(map[ids.ID]snowman.Block, len(blocks)-1)
+		for _, blk := range blocks[1:] {
+			blockSet[blk.ID()] = blk
+		}
+		if err := b.process(ctx, first, blockSet); err != nil {
+			return err
+		}
+	} else {
+		for _, blk := range rangeBlks {
+			if err := b.fetched.PutBlock(blk.Height(), blk.Bytes()); err != nil {
+				return err
+			}
+		}
+	}
+
+	b.updateProgress()
+	b.fetchRanges(ctx)
+	return nil
+}
+
+// rangeBlocks returns the longest prefix of [blocks] that continues [r], that
+// is, consecutive ancestors starting at the next height of [r] that don't
+// extend below [r].
+func rangeBlocks(r *fetchRange, blocks []snowman.Block) []snowman.Block {
+	first := blocks[0]
+	if first.Height() != r.next || (r.nextID != ids.Empty && first.ID() != r.nextID) {
+		return nil
+	}
+	for i := 1; i < len(blocks); i++ {
+		var (
+			blk    = blocks[i]
+			child  = blocks[i-1]
+			height = blk.Height()
+		)
+		if height < r.start || height+1 != child.Height() || blk.ID() != child.Parent() {
+			return blocks[:i]
+		}
+	}
+	return blocks
+}
+
+// getFetched returns the missing block [blkID] if a range has fetched it.
+// Returns nil if the block hasn't been fetched.
+func (b *Bootstrapper) getFetched(ctx context.Context, blkID ids.ID) (snowman.Block, error) {
+	height, err := b.fetched.GetMissingHeight(blkID)
+	if errors.Is(err, database.ErrNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return b.getFetchedAtHeight(ctx, blkID, height)
+}
+
+// getFetchedAtHeight returns the block [blkID] at [height] if a range has
+// fetched it. Returns nil if the block hasn't been fetched, or if the range
+// fetched a different block at [height].
+func (b *Bootstrapper) getFetchedAtHeight(ctx context.Context, blkID ids.ID, height uint64) (snowman.Block, error) {
+	blkBytes, err := b.fetched.GetBlock(height)
+	if errors.Is(err, database.ErrNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	blk, err := b.VM.ParseBlock(ctx, blkBytes)
+	if err != nil || blk.ID() != blkID {
+		// The range was served a block that isn't in the ancestry, so it
+		// will be redirected when the block is fetched.
+		b.Ctx.Log.Debug("dropping fetched block",
+			zap.String("reason", "not in the ancestry"),
+			zap.Stringer("blkID", blkID),
+			zap.Uint64("height", height),
+			zap.Error(err),
+		)
+		return nil, nil
+	}
+	b.fetched.Consume(height)
+	return blk, nil
+}
+
+// clearRanges drops the ranges and the blocks they fetched.
+func (b *Bootstrapper) clearRanges() error {
+	b.ranges = nil
+	clear(b.rangeRequests)
+	clear(b.awaiting)
+	b.peers.Reset()
+	return b.fetched.Clear()
+}
+
+// commit persists the traversed blocks, after which the fetched blocks that
+// were traversed are no longer needed.
+func (b *Bootstrapper) commit() error {
+	if err := b.Blocked.Commit(); err != nil {
+		return err
+	}
+	return b.fetched.DeleteConsumed()
+}
+
+func (b *Bootstrapper) onBlockExecuted() {
+	b.numExecuted++
+	b.updateProgress()
+}
+
+// updateProgress publishes the progress of the current bootstrapping round to
+// the consensus context.
+func (b *Bootstrapper) updateProgress() snow.BootstrapProgress {
+	var (
+		numFetched = b.Blocked.PendingJobs() + b.fetched.numBlocks
+		numToFetch uint64
+	)
+	if b.tipHeight > b.acceptedHeight {
+		numToFetch = b.tipHeight - b.acceptedHeight
+	}
+	progress := snow.BootstrapProgress{
+		StartingHeight: b.acceptedHeight,
+		TipHeight:      max(b.tipHeight, b.acceptedHeight),
+		NumFetched:     numFetched,
+		NumExecuted:    b.numExecuted,
+		FetchETA: estimateETA(
+			b.startTime,
+			numFetched-min(numFetched, b.initiallyFetched), // Number of blocks we have fetched during this run
+			numToFetch-min(numToFetch, b.initiallyFetched), // Number of blocks we expect to fetch during this run
+		),
+		ExecuteETA: estimateETA(b.executeStartTime, b.numExecuted, b.numToExecute),
+	}
+	b.fetchETA.Set(float64(progress.FetchETA))
+	b.Ctx.BootstrapProgress.Set(progress)
+	return progress
+}
+
+// estimateETA returns 0 if there is no progress to estimate from, or if the
+// job is done.
+func estimateETA(startTime time.Time, progress, end uint64) time.Duration {
+	if progress == 0 || progress >= end {
+		return 0
+	}
+	return timer.EstimateETA(startTime, progress, end)
+}
+
 // process a series of consecutive blocks starting at [blk].
 //
 //   - blk is a block that is assumed to have been marked as acceptable by the
@@ -580,7 +1067,7 @@ func (b *Bootstrapper) process(ctx context.Context, blk snowman.Block, processin
 			// guaranteed to continue processing from this state when the
 			// bootstrapper is restarted.
 			b.Blocked.AddMissingID(blkID)
-			return b.Blocked.Commit()
+			return b.commit()
 		}
 
 		b.Blocked.RemoveMissingID(blkID)
@@ -595,7 +1082,7 @@ func (b *Bootstrapper) process(ctx context.Context, blk snowman.Block, processin
 		blkHeight := blk.Height()
 		if status == choices.Accepted || blkHeight <= b.startingHeight {
 			// We can stop traversing, as we have reached the accepted frontier
-			if err := b.Blocked.Commit(); err != nil {
+			if err := b.commit(); err != nil {
 				return err
 			}
 			return b.tryStartExecuting(ctx)
@@ -610,6 +1097,7 @@ func (b *Bootstrapper) process(ctx context.Context, blk snowman.Block, processin
 		pushed, err := b.Blocked.Push(ctx, &blockJob{
 			log:         b.Ctx.Log,
 			numAccepted: b.numAccepted,
+			onAccepted:  b.onBlockExecuted,
 			blk:         blk,
 			vm:          b.VM,
 		})
@@ -620,7 +1108,7 @@ func (b *Bootstrapper) process(ctx context.Context, blk snowman.Block, processin
 		if !pushed {
 			// We can stop traversing, as we have reached a block that we
 			// previously pushed onto the jobs queue
-			if err := b.Blocked.Commit(); err != nil {
+			if err := b.commit(); err != nil {
 				return err
 			}
 			return b.tryStartExecuting(ctx)
@@ -630,27 +1118,20 @@ func (b *Bootstrapper) process(ctx context.Context, blk snowman.Block, processin
 		b.numFetched.Inc()
 
 		// Periodically log progress
-		blocksFetchedSoFar := b.Blocked.Jobs.PendingJobs()
-		if blocksFetchedSoFar%statusUpdateFrequency == 0 {
-			totalBlocksToFetch := b.tipHeight - b.startingHeight
-			eta := timer.EstimateETA(
-				b.startTime,
-				blocksFetchedSoFar-b.initiallyFetched, // Number of blocks we have fetched during this run
-				totalBlocksToFetch-b.initiallyFetched, // Number of blocks we expect to fetch during this run
-			)
-			b.fetchETA.Set(float64(eta))
-
+		if b.Blocked.Jobs.PendingJobs()%statusUpdateFrequency == 0 {
+			progress := b.updateProgress()
+			totalBlocksToFetch := progress.TipHeight - progress.StartingHeight
 			if !b.restarted {
 				b.Ctx.Log.Info("fetching blocks",
-					zap.Uint64("numFetchedBlocks", blocksFetchedSoFar),
+					zap.Uint64("numFetchedBlocks", progress.NumFetched),
 					zap.Uint64("numTotalBlocks", totalBlocksToFetch),
-					zap.Duration("eta", eta),
+					zap.Duration("eta", progress.FetchETA),
 				)
 			} else {
 				b.Ctx.Log.Debug("fetching blocks",
-					zap.Uint64("numFetchedBlocks", blocksFetchedSoFar),
+					zap.Uint64("numFetchedBlocks", progress.NumFetched),
 					zap.Uint64("numTotalBlocks", totalBlocksToFetch),
-					zap.Duration("eta", eta),
+					zap.Duration("eta", progress.FetchETA),
 				)
 			}
 		}
@@ -674,14 +1155,31 @@ func (b *Bootstrapper) process(ctx context.Context, blk snowman.Block, processin
 		}
 		// TODO: report errors that aren't `database.ErrNotFound`
 
+		// Otherwise, the parent may have already been fetched by a range
+		parentHeight := blkHeight - 1
+		parent, err = b.getFetchedAtHeight(ctx, parentID, parentHeight)
+		if err != nil {
+			return err
+		}
+		if parent != nil {
+			blk = parent
+			continue
+		}
+
 		// If the block wasn't able to be acquired immediately, attempt to fetch
 		// it
 		b.Blocked.AddMissingID(parentID)
+		if err := b.fetched.PutMissingHeight(parentID, parentHeight); err != nil {
+			return err
+		}
+		if err := b.addRanges(parentHeight); err != nil {
+			return err
+		}
 		if err := b.fetch(ctx, parentID); err != nil {
 			return err
 		}
 
-		if err := b.Blocked.Commit(); err != nil {
+		if err := b.commit(); err != nil {
 			return err
 		}
 		return b.tryStartExecuting(ctx)
@@ -700,6 +1198,11 @@ func (b *Bootstrapper) tryStartExecuting(ctx context.Context) error {
 		return nil
 	}
 
+	// The full ancestry has been fetched, so the ranges are no longer needed.
+	if err := b.clearRanges(); err != nil {
+		return err
+	}
+
 	if !b.restarted {
 		b.Ctx.Log.Info("executing blocks",
 			zap.Uint64("numPendingJobs", b.Blocked.PendingJobs()),
@@ -710,6 +1213,9 @@ func (b *Bootstrapper) tryStartExecuting(ctx context.Context) error {
 		)
 	}
 
+	b.numExecuted = 0
+	b.numToExecute = b.Blocked.PendingJobs()
+	b.executeStartTime = time.Now()
 	executedBlocks, err := b.Blocked.ExecuteAll(
 		ctx,
 		b.Config.Ctx,
@@ -721,6 +1227,9 @@ func (b *Bootstrapper) tryStartExecuting(ctx context.Context) error {
 		return err
 	}
 
+	b.acceptedHeight = max(b.acceptedHeight, b.tipHeight)
+	b.updateProgress()
+
 	previouslyExecuted := b.executedStateTransitions
 	b.executedStateTransitions = executedBlocks
 
@@ -775,6 +1284,7 @@ func (b *Bootstrapper) restartBootstrapping(ctx context.Context) error {
 	b.Ctx.Log.Debug("Checking for new frontiers")
 	b.restarted = true
 	b.outstandingRequests = bimap.New[common.Request, ids.ID]()
+	b.peers.Reset()
 	return b.startBootstrapping(ctx)
 }
 
@@ -797,6 +1307,7 @@ func (b *Bootstrapper) HealthCheck(ctx context.Context) (interface{}, error) {
 	vmIntf, vmErr := b.VM.HealthCheck(ctx)
 	intf := map[string]interface{}{
 		"consensus": struct{}{},
+		"progress":  b.Ctx.BootstrapProgress.Get(),
 		"vm":        vmIntf,
 	}
 	return intf, vmErr
@@ -91,6 +91,7 @@ func newConfig(t *testing.T) (Config, ids.NodeID, *common.SenderTest, *block.Tes
 		Timer:                          &common.TimerTest{},
 		AncestorsMaxContainersReceived: 2000,
 		Blocked:                        blocker,
+		DB:                             memdb.New(),
 		VM:                             vm,
 	}, peer, sender, vm
 }
@@ -131,6 +132,7 @@ func TestBootstrapperStartsOnlyIfEnoughStakeIsConnected(t *testing.T) {
 		Timer:                          &common.TimerTest{},
 		AncestorsMaxContainersReceived: 2000,
 		Blocked:                        blocker,
+		DB:                             memdb.New(),
 		VM:                             vm,
 	}
 
@@ -1410,6 +1412,7 @@ func TestBootstrapNoParseOnNew(t *testing.T) {
 		Timer:                          &common.TimerTest{},
 		AncestorsMaxContainersReceived: 2000,
 		Blocked:                        blocker,
+		DB:                             memdb.New(),
 		VM:                             vm,
 	}
 
@@ -1546,3 +1549,293 @@ func TestBootstrapperReceiveStaleAncestorsMessage(t *testing.T) {
 	require.NoError(bs.Ancestors(context.Background(), peerID, reqIDBlk1, [][]byte{blkBytes1}))
 	require.Equal(snow.Bootstrapping, config.Ctx.State.Get().State)
 }
+
+// generateBlockchain returns a chain of [length] blocks, of which only the
+// first is accepted.
+func generateBlockchain(length uint64) []*snowman.TestBlock {
+	blks := make([]*snowman.TestBlock, length)
+	for i := range blks {
+		blks[i] = &snowman.TestBlock{
+			TestDecidable: choices.TestDecidable{
+				IDV:     ids.GenerateTestID(),
+				StatusV: choices.Processing,
+			},
+			HeightV: uint64(i),
+			BytesV:  utils.RandomBytes(32),
+		}
+		if i > 0 {
+			blks[i].ParentV = blks[i-1].IDV
+		}
+	}
+	blks[0].StatusV = choices.Accepted
+	return blks
+}
+
+// ancestorsOf returns the bytes of the blocks in [blks] from [top] down to
+// [bottom].
+func ancestorsOf(blks []*snowman.TestBlock, top, bottom uint64) [][]byte {
+	var blkBytes [][]byte
+	for height := top; height >= bottom; height-- {
+		blkBytes = append(blkBytes, blks[height].Bytes())
+	}
+	return blkBytes
+}
+
+// setupRangeVM makes [vm] serve [blks]. Only accepted blocks and the tip are
+// stored by the VM, so the remaining blocks must be fetched.
+func setupRangeVM(t *testing.T, vm *block.TestVM, blks []*snowman.TestBlock) {
+	tip := blks[len(blks)-1]
+	vm.CantSetState = false
+	vm.LastAcceptedF = func(context.Context) (ids.ID, error) {
+		return blks[0].ID(), nil
+	}
+	vm.GetBlockF = func(_ context.Context, blkID ids.ID) (snowman.Block, error) {
+		for _, blk := range blks {
+			if blk.ID() == blkID && (blk == tip || blk.Status() == choices.Accepted) {
+				return blk, nil
+			}
+		}
+		return nil, database.ErrNotFound
+	}
+	vm.ParseBlockF = func(_ context.Context, blkBytes []byte) (snowman.Block, error) {
+		for _, blk := range blks {
+			if bytes.Equal(blkBytes, blk.Bytes()) {
+				return blk, nil
+			}
+		}
+		require.FailNow(t, errUnknownBlock.Error())
+		return nil, errUnknownBlock
+	}
+}
+
+type ancestorsRequest struct {
+	nodeID    ids.NodeID
+	requestID uint32
+}
+
+// recordAncestorsRequests records the requests sent by [sender] by the ID or
+// the height of the requested block.
+func recordAncestorsRequests(sender *common.SenderTest) (map[ids.ID]ancestorsRequest, map[uint64]ancestorsRequest) {
+	byID := make(map[ids.ID]ancestorsRequest)
+	byHeight := make(map[uint64]ancestorsRequest)
+	sender.SendGetAncestorsF = func(_ context.Context, nodeID ids.NodeID, requestID uint32, blkID ids.ID) {
+		byID[blkID] = ancestorsRequest{
+			nodeID:    nodeID,
+			requestID: requestID,
+		}
+	}
+	sender.SendGetAncestorsAtHeightF = func(_ context.Context, nodeID ids.NodeID, requestID uint32, height uint64) {
+		byHeight[height] = ancestorsRequest{
+			nodeID:    nodeID,
+			requestID: requestID,
+		}
+	}
+	return byID, byHeight
+}
+
+func TestBootstrapperFetchesRangesInParallel(t *testing.T) {
+	require := require.New(t)
+
+	config, peerID, sender, vm := newConfig(t)
+	peerIDs := set.Of(peerID)
+	for i := 0; i < 2; i++ {
+		nodeID := ids.GenerateTestNodeID()
+		require.NoError(config.Beacons.AddStaker(config.Ctx.SubnetID, nodeID, nil, ids.Empty, 1))
+		require.NoError(config.StartupTracker.Connected(context.Background(), nodeID, version.CurrentApp))
+		peerIDs.Add(nodeID)
+	}
+
+	blks := generateBlockchain(11)
+	setupRangeVM(t, vm, blks)
+
+	bs, err := New(config, func(context.Context, uint32) error { return nil })
+	require.NoError(err)
+	bs.fetchRangeSize = 3
+	bs.heightPeers = peerIDs
+	require.NoError(bs.Start(context.Background(), 0))
+
+	byID, byHeight := recordAncestorsRequests(sender)
+	require.NoError(bs.startSyncing(context.Background(), []ids.ID{blks[10].ID()}))
+
+	// The range the traversal is waiting on is fetched by ID, the others are
+	// fetched by height, each from a different peer.
+	require.Len(byID, 1)
+	require.Len(byHeight, 2)
+	tipRequest, ok := byID[blks[9].ID()]
+	require.True(ok)
+	middleRequest, ok := byHeight[6]
+	require.True(ok)
+	lowRequest, ok := byHeight[3]
+	require.True(ok)
+	require.Equal(peerIDs, set.Of(tipRequest.nodeID, middleRequest.nodeID, lowRequest.nodeID))
+
+	require.NoError(bs.Ancestors(context.Background(), lowRequest.nodeID, lowRequest.requestID, ancestorsOf(blks, 3, 1)))
+	require.NoError(bs.Ancestors(context.Background(), middleRequest.nodeID, middleRequest.requestID, ancestorsOf(blks, 6, 4)))
+	require.Equal(uint64(6), bs.fetched.numBlocks)
+
+	progress := config.Ctx.BootstrapProgress.Get()
+	require.Equal(uint64(10), progress.TipHeight)
+	require.Equal(uint64(7), progress.NumFetched)
+
+	require.NoError(bs.Ancestors(context.Background(), tipRequest.nodeID, tipRequest.requestID, ancestorsOf(blks, 9, 7)))
+	for _, blk := range blks {
+		require.Equal(choices.Accepted, blk.Status())
+	}
+	require.Zero(bs.fetched.numBlocks)
+	require.Empty(bs.ranges)
+
+	progress = config.Ctx.BootstrapProgress.Get()
+	require.Equal(uint64(10), progress.NumExecuted)
+}
+
+func TestBootstrapperResumesRanges(t *testing.T) {
+	require := require.New(t)
+
+	config, _, sender, vm := newConfig(t)
+	blks := generateBlockchain(11)
+	setupRangeVM(t, vm, blks)
+
+	bs, err := New(config, func(context.Context, uint32) error { return nil })
+	require.NoError(err)
+	bs.fetchRangeSize = 3
+	require.NoError(bs.Start(context.Background(), 0))
+
+	// With a single peer, only the range the traversal is waiting on is
+	// fetched.
+	byID, byHeight := recordAncestorsRequests(sender)
+	require.NoError(bs.startSyncing(context.Background(), []ids.ID{blks[10].ID()}))
+	require.Len(byID, 1)
+	require.Empty(byHeight)
+
+	tipRequest := byID[blks[9].ID()]
+	require.NoError(bs.Ancestors(context.Background(), tipRequest.nodeID, tipRequest.requestID, ancestorsOf(blks, 9, 7)))
+	middleRequest, ok := byID[blks[6].ID()]
+	require.True(ok)
+	require.NoError(bs.Ancestors(context.Background(), middleRequest.nodeID, middleRequest.requestID, ancestorsOf(blks, 6, 5)))
+
+	// Restart before the remaining blocks are fetched.
+	config.Ctx.Registerer = prometheus.NewRegistry()
+	bs, err = New(config, func(context.Context, uint32) error { return nil })
+	require.NoError(err)
+	bs.fetchRangeSize = 3
+	require.NoError(bs.Start(context.Background(), 0))
+	require.Len(bs.ranges, 3)
+
+	byID, byHeight = recordAncestorsRequests(sender)
+	require.NoError(bs.startSyncing(context.Background(), []ids.ID{blks[10].ID()}))
+
+	// Fetching continues from the last fetched block.
+	require.Len(byID, 1)
+	require.Empty(byHeight)
+	request, ok := byID[blks[4].ID()]
+	require.True(ok)
+	require.NoError(bs.Ancestors(context.Background(), request.nodeID, request.requestID, ancestorsOf(blks, 4, 1)))
+
+	for _, blk := range blks {
+		require.Equal(choices.Accepted, blk.Status())
+	}
+}
+
+func TestBootstrapperFetchesRangesByIDFromOldPeers(t *testing.T) {
+	require := require.New(t)
+
+	config, _, sender, vm := newConfig(t)
+	nodeID := ids.GenerateTestNodeID()
+	require.NoError(config.Beacons.AddStaker(config.Ctx.SubnetID, nodeID, nil, ids.Empty, 1))
+
+	blks := generateBlockchain(8)
+	setupRangeVM(t, vm, blks)
+	vm.ConnectedF = func(context.Context, ids.NodeID, *version.Application) error {
+		return nil
+	}
+
+	bs, err := New(config, func(context.Context, uint32) error { return nil })
+	require.NoError(err)
+	bs.fetchRangeSize = 3
+	require.NoError(bs.Start(context.Background(), 0))
+
+	// [nodeID] is idle, but it doesn't serve blocks by height.
+	oldVersion := &version.Application{
+		Name:  version.Client,
+		Major: heightRequestsVersion.Major,
+		Minor: heightRequestsVersion.Minor,
+		Patch: heightRequestsVersion.Patch - 1,
+	}
+	require.NoError(bs.Connected(context.Background(), nodeID, oldVersion))
+
+	byID, byHeight := recordAncestorsRequests(sender)
+	require.NoError(bs.startSyncing(context.Background(), []ids.ID{blks[7].ID()}))
+	require.Len(byID, 1)
+	require.Empty(byHeight)
+
+	// The lower range is fetched by ID once the traversal reaches it.
+	tipRequest := byID[blks[6].ID()]
+	require.NoError(bs.Ancestors(context.Background(), tipRequest.nodeID, tipRequest.requestID, ancestorsOf(blks, 6, 4)))
+	request, ok := byID[blks[3].ID()]
+	require.True(ok)
+	require.NoError(bs.Ancestors(context.Background(), request.nodeID, request.requestID, ancestorsOf(blks, 3, 1)))
+	require.Empty(byHeight)
+
+	for _, blk := range blks {
+		require.Equal(choices.Accepted, blk.Status())
+	}
+}
+
+func TestBootstrapperRefetchesRangeNotInAncestry(t *testing.T) {
+	require := require.New(t)
+
+	config, peerID, sender, vm := newConfig(t)
+	nodeID := ids.GenerateTestNodeID()
+	require.NoError(config.Beacons.AddStaker(config.Ctx.SubnetID, nodeID, nil, ids.Empty, 1))
+	require.NoError(config.StartupTracker.Connected(context.Background(), nodeID, version.CurrentApp))
+
+	blks := generateBlockchain(8)
+	// [conflicting] is a valid chain that isn't in the ancestry of the tip.
+	conflicting := generateBlockchain(4)
+	conflicting[0] = blks[0]
+	for _, blk := range conflicting[1:] {
+		blk.ParentV = blks[0].ID()
+		if blk.HeightV > 1 {
+			blk.ParentV = conflicting[blk.HeightV-1].ID()
+		}
+	}
+	setupRangeVM(t, vm, append(blks, conflicting[1:]...))
+	vm.GetBlockF = func(_ context.Context, blkID ids.ID) (snowman.Block, error) {
+		for _, blk := range blks {
+			if blk.ID() == blkID && (blk == blks[7] || blk.Status() == choices.Accepted) {
+				return blk, nil
+			}
+		}
+		return nil, database.ErrNotFound
+	}
+
+	bs, err := New(config, func(context.Context, uint32) error { return nil })
+	require.NoError(err)
+	bs.fetchRangeSize = 3
+	bs.heightPeers = set.Of(peerID, nodeID)
+	require.NoError(bs.Start(context.Background(), 0))
+
+	byID, byHeight := recordAncestorsRequests(sender)
+	require.NoError(bs.startSyncing(context.Background(), []ids.ID{blks[7].ID()}))
+	lowRequest, ok := byHeight[3]
+	require.True(ok)
+	require.NoError(bs.Ancestors(context.Background(), lowRequest.nodeID, lowRequest.requestID, ancestorsOf(conflicting, 3, 1)))
+	require.Equal(uint64(3), bs.fetched.numBlocks)
+
+	// Once the traversal reaches the conflicting blocks, they are dropped and
+	// the range is fetched again from the block in the ancestry.
+	tipRequest := byID[blks[6].ID()]
+	require.NoError(bs.Ancestors(context.Background(), tipRequest.nodeID, tipRequest.requestID, ancestorsOf(blks, 6, 4)))
+	require.Zero(bs.fetched.numBlocks)
+	request, ok := byID[blks[3].ID()]
+	require.True(ok)
+	require.Contains([]ids.NodeID{peerID, nodeID}, request.nodeID)
+	require.NoError(bs.Ancestors(context.Background(), request.nodeID, request.requestID, ancestorsOf(blks, 3, 1)))
+
+	for _, blk := range blks {
+		require.Equal(choices.Accepted, blk.Status())
+	}
+	for _, blk := range conflicting[1:] {
+		require.Equal(choices.Processing, blk.Status())
+	}
+}
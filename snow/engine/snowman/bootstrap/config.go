@@ -4,6 +4,7 @@
 package bootstrap
 
 import (
+	"github.com/ava-labs/avalanchego/database"
 	"github.com/ava-labs/avalanchego/snow"
 	"github.com/ava-labs/avalanchego/snow/engine/common"
 	"github.com/ava-labs/avalanchego/snow/engine/common/queue"
@@ -35,6 +36,10 @@ type Config struct {
 	// to the queue.
 	Blocked *queue.JobsWithMissing
 
+	// DB persists the blocks that are fetched ahead of the ancestry being
+	// traversed, so that they aren't fetched again after a restart.
+	DB database.Database
+
 	VM block.ChainVM
 
 	Bootstrapped func()
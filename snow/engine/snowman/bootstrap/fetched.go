@@ -0,0 +1,204 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package bootstrap
+
+import (
+	"errors"
+	"slices"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/database/prefixdb"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/wrappers"
+)
+
+const fetchRangeLen = 2*wrappers.LongLen + ids.IDLen
+
+var (
+	fetchedBlockPrefix = []byte("block")
+	fetchRangePrefix   = []byte("range")
+	missingPrefix      = []byte("missing")
+
+	errInvalidFetchRange = errors.New("invalid fetch range")
+)
+
+// fetchRange is a range of heights whose blocks are fetched from the top down,
+// ahead of the verified ancestry reaching them.
+type fetchRange struct {
+	// Lowest height in the range.
+	start uint64
+	// Highest height in the range.
+	top uint64
+	// Next height to fetch. The range is done once [next] is below [start].
+	next uint64
+	// ID of the block at [next], if known. Otherwise, the block is requested
+	// by its height.
+	nextID ids.ID
+
+	// True iff there is an outstanding request for this range.
+	requested bool
+}
+
+func (r *fetchRange) done() bool {
+	return r.next < r.start
+}
+
+// fetchedBlocks persists the blocks fetched by the ranges, and the progress of
+// the ranges, so that fetching resumes where it left off after a restart.
+//
+// The fetched blocks haven't been verified to be in the ancestry of the
+// accepted frontier. They are only used once the ancestry being traversed
+// reaches them.
+type fetchedBlocks struct {
+	// height -> block bytes
+	blocks database.Database
+	// start height -> range
+	ranges database.Database
+	// blkID -> height of the missing block
+	missing database.Database
+
+	// Number of blocks in [blocks].
+	numBlocks uint64
+	// Heights of the blocks that were used by the ancestry being traversed,
+	// and can be deleted once the traversal is committed.
+	consumed []uint64
+}
+
+func newFetchedBlocks(db database.Database) (*fetchedBlocks, error) {
+	f := &fetchedBlocks{
+		blocks:  prefixdb.New(fetchedBlockPrefix, db),
+		ranges:  prefixdb.New(fetchRangePrefix, db),
+		missing: prefixdb.New(missingPrefix, db),
+	}
+	numBlocks, err := database.Count(f.blocks)
+	f.numBlocks = uint64(numBlocks)
+	return f, err
+}
+
+func (f *fetchedBlocks) GetBlock(height uint64) ([]byte, error) {
+	return f.blocks.Get(database.PackUInt64(height))
+}
+
+func (f *fetchedBlocks) PutBlock(height uint64, blkBytes []byte) error {
+	key := database.PackUInt64(height)
+	has, err := f.blocks.Has(key)
+	if err != nil {
+		return err
+	}
+	if !has {
+		f.numBlocks++
+	}
+	return f.blocks.Put(key, blkBytes)
+}
+
+// DeleteBlocks deletes the blocks in [start, end].
+func (f *fetchedBlocks) DeleteBlocks(start, end uint64) error {
+	it := f.blocks.NewIteratorWithStart(database.PackUInt64(start))
+	defer it.Release()
+
+	var keys [][]byte
+	for it.Next() {
+		height, err := database.ParseUInt64(it.Key())
+		if err != nil {
+			return err
+		}
+		if height > end {
+			break
+		}
+		keys = append(keys, slices.Clone(it.Key()))
+	}
+	if err := it.Error(); err != nil {
+		return err
+	}
+
+	for _, key := range keys {
+		if err := f.blocks.Delete(key); err != nil {
+			return err
+		}
+		f.numBlocks--
+	}
+	return nil
+}
+
+// Consume marks the block at [height] to be deleted on the next call to
+// DeleteConsumed.
+func (f *fetchedBlocks) Consume(height uint64) {
+	f.consumed = append(f.consumed, height)
+}
+
+func (f *fetchedBlocks) DeleteConsumed() error {
+	for _, height := range f.consumed {
+		if err := f.DeleteBlocks(height, height); err != nil {
+			return err
+		}
+	}
+	f.consumed = f.consumed[:0]
+	return nil
+}
+
+// GetRanges returns the persisted ranges, sorted from the highest to the
+// lowest.
+func (f *fetchedBlocks) GetRanges() ([]*fetchRange, error) {
+	it := f.ranges.NewIterator()
+	defer it.Release()
+
+	var ranges []*fetchRange
+	for it.Next() {
+		start, err := database.ParseUInt64(it.Key())
+		if err != nil {
+			return nil, err
+		}
+		value := it.Value()
+		if len(value) != fetchRangeLen {
+			return nil, errInvalidFetchRange
+		}
+		p := wrappers.Packer{Bytes: value}
+		r := &fetchRange{
+			start: start,
+			top:   p.UnpackLong(),
+			next:  p.UnpackLong(),
+		}
+		copy(r.nextID[:], p.UnpackFixedBytes(ids.IDLen))
+		if p.Err != nil {
+			return nil, p.Err
+		}
+		ranges = append(ranges, r)
+	}
+	slices.Reverse(ranges)
+	return ranges, it.Error()
+}
+
+func (f *fetchedBlocks) PutRange(r *fetchRange) error {
+	p := wrappers.Packer{Bytes: make([]byte, fetchRangeLen)}
+	p.PackLong(r.top)
+	p.PackLong(r.next)
+	p.PackFixedBytes(r.nextID[:])
+	return f.ranges.Put(database.PackUInt64(r.start), p.Bytes)
+}
+
+func (f *fetchedBlocks) DeleteRange(r *fetchRange) error {
+	return f.ranges.Delete(database.PackUInt64(r.start))
+}
+
+// GetMissingHeight returns the height of the missing block [blkID], if it is
+// known.
+func (f *fetchedBlocks) GetMissingHeight(blkID ids.ID) (uint64, error) {
+	return database.GetUInt64(f.missing, blkID[:])
+}
+
+func (f *fetchedBlocks) PutMissingHeight(blkID ids.ID, height uint64) error {
+	return database.PutUInt64(f.missing, blkID[:], height)
+}
+
+// Clear deletes all the fetched blocks and ranges.
+func (f *fetchedBlocks) Clear() error {
+	for _, db := range []database.Database{f.blocks, f.ranges, f.missing} {
+		if err := database.AtomicClear(db, db); err != nil {
+			return err
+		}
+	}
+	f.numBlocks = 0
+	f.consumed = f.consumed[:0]
+	return nil
+}
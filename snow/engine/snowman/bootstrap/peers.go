@@ -0,0 +1,141 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package bootstrap
+
+import (
+	"time"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow/engine/common"
+	"github.com/ava-labs/avalanchego/utils/sampler"
+	"github.com/ava-labs/avalanchego/utils/set"
+
+	safemath "github.com/ava-labs/avalanchego/utils/math"
+)
+
+const (
+	// throughputHalflife is the halflife of the moving average of the rate at
+	// which a peer serves blocks.
+	throughputHalflife = time.Minute
+
+	// defaultThroughput is the assumed rate, in blocks per second, at which
+	// peers serve blocks before any of them have been measured.
+	defaultThroughput = 1000
+)
+
+// fetchPeers tracks the outstanding block requests to each peer and the rate
+// at which each peer has served blocks, so that more requests are sent to the
+// peers that serve blocks the fastest.
+type fetchPeers struct {
+	// peer -> moving average of the blocks per second served by the peer
+	throughput map[ids.NodeID]safemath.Averager
+	// request -> time the request was sent
+	outstanding map[common.Request]time.Time
+	// peer -> number of outstanding requests to the peer
+	numOutstanding map[ids.NodeID]int
+}
+
+func newFetchPeers() *fetchPeers {
+	return &fetchPeers{
+		throughput:     make(map[ids.NodeID]safemath.Averager),
+		outstanding:    make(map[common.Request]time.Time),
+		numOutstanding: make(map[ids.NodeID]int),
+	}
+}
+
+// Sent marks that [request] was sent at [now].
+func (p *fetchPeers) Sent(request common.Request, now time.Time) {
+	if _, ok := p.outstanding[request]; ok {
+		return
+	}
+	p.outstanding[request] = now
+	p.numOutstanding[request.NodeID]++
+}
+
+// Busy returns true if there is an outstanding request to [nodeID].
+func (p *fetchPeers) Busy(nodeID ids.NodeID) bool {
+	return p.numOutstanding[nodeID] > 0
+}
+
+// Received marks that [request] was answered with [numBlocks] usable blocks at
+// [now]. A failed request should be reported with no blocks.
+func (p *fetchPeers) Received(request common.Request, numBlocks int, now time.Time) {
+	sentAt, ok := p.outstanding[request]
+	if !ok {
+		return
+	}
+	delete(p.outstanding, request)
+
+	nodeID := request.NodeID
+	p.numOutstanding[nodeID]--
+	if p.numOutstanding[nodeID] == 0 {
+		delete(p.numOutstanding, nodeID)
+	}
+
+	var throughput float64
+	if elapsed := now.Sub(sentAt).Seconds(); elapsed > 0 {
+		throughput = float64(numBlocks) / elapsed
+	}
+	averager, ok := p.throughput[nodeID]
+	if !ok {
+		p.throughput[nodeID] = safemath.NewAverager(throughput, throughputHalflife, now)
+		return
+	}
+	averager.Observe(throughput, now)
+}
+
+// Reset forgets all the outstanding requests.
+func (p *fetchPeers) Reset() {
+	clear(p.outstanding)
+	clear(p.numOutstanding)
+}
+
+// Sample returns one of [nodeIDs] with probability proportional to its
+// throughput. Peers that haven't been measured yet are assumed to serve
+// blocks at the average rate of the measured peers.
+func (p *fetchPeers) Sample(nodeIDs set.Set[ids.NodeID]) (ids.NodeID, bool) {
+	if nodeIDs.Len() == 0 {
+		return ids.EmptyNodeID, false
+	}
+
+	var (
+		candidates   = nodeIDs.List()
+		throughputs  = make([]float64, len(candidates))
+		sum          float64
+		numMeasured  int
+		weights      = make([]uint64, len(candidates))
+		unmeasuredAt = float64(defaultThroughput)
+	)
+	for i, nodeID := range candidates {
+		averager, ok := p.throughput[nodeID]
+		if !ok {
+			throughputs[i] = -1
+			continue
+		}
+		throughputs[i] = averager.Read()
+		sum += throughputs[i]
+		numMeasured++
+	}
+	if numMeasured > 0 {
+		unmeasuredAt = sum / float64(numMeasured)
+	}
+	for i, throughput := range throughputs {
+		if throughput < 0 {
+			throughput = unmeasuredAt
+		}
+		// Every peer keeps a non-zero weight so that peers that previously
+		// failed are eventually retried.
+		weights[i] = uint64(throughput) + 1
+	}
+
+	s := sampler.NewWeightedWithoutReplacement()
+	if err := s.Initialize(weights); err != nil {
+		return candidates[0], true
+	}
+	indices, err := s.Sample(1)
+	if err != nil {
+		return candidates[0], true
+	}
+	return candidates[indices[0]], true
+}
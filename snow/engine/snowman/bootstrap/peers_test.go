@@ -0,0 +1,48 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package bootstrap
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow/engine/common"
+)
+
+func TestFetchPeersTracksRequests(t *testing.T) {
+	require := require.New(t)
+
+	var (
+		p      = newFetchPeers()
+		nodeID = ids.GenerateTestNodeID()
+		first  = common.Request{
+			NodeID:    nodeID,
+			RequestID: 1,
+		}
+		second = common.Request{
+			NodeID:    nodeID,
+			RequestID: 2,
+		}
+		now = time.Now()
+	)
+	p.Sent(first, now)
+	p.Sent(second, now.Add(time.Second))
+	require.True(p.Busy(nodeID))
+
+	// Each response is measured from the time its own request was sent.
+	p.Received(first, 10, now.Add(2*time.Second))
+	require.True(p.Busy(nodeID))
+	require.Equal(float64(5), p.throughput[nodeID].Read())
+
+	// Responses to unknown requests are ignored.
+	p.Received(first, 10, now.Add(2*time.Second))
+	require.True(p.Busy(nodeID))
+
+	p.Received(second, 0, now.Add(2*time.Second))
+	require.False(p.Busy(nodeID))
+	require.Empty(p.outstanding)
+}
@@ -186,6 +186,21 @@ func (gh *getter) GetAncestors(ctx context.Context, nodeID ids.NodeID, requestID
 	return nil
 }
 
+func (gh *getter) GetAncestorsAtHeight(ctx context.Context, nodeID ids.NodeID, requestID uint32, height uint64) error {
+	blkID, err := gh.vm.GetBlockIDAtHeight(ctx, height)
+	if err != nil {
+		gh.log.Verbo("dropping GetAncestors message",
+			zap.String("reason", "couldn't get block at height"),
+			zap.Stringer("nodeID", nodeID),
+			zap.Uint32("requestID", requestID),
+			zap.Uint64("height", height),
+			zap.Error(err),
+		)
+		return nil
+	}
+	return gh.GetAncestors(ctx, nodeID, requestID, blkID)
+}
+
 func (gh *getter) Get(ctx context.Context, nodeID ids.NodeID, requestID uint32, blkID ids.ID) error {
 	blk, err := gh.vm.GetBlock(ctx, blkID)
 	if err != nil {
@@ -116,3 +116,58 @@ func TestFilterAccepted(t *testing.T) {
 	require.Contains(accepted, blkID1)
 	require.NotContains(accepted, blkID2)
 }
+
+func TestGetAncestorsAtHeight(t *testing.T) {
+	require := require.New(t)
+	bs, vm, sender := newTest(t)
+
+	blk0 := &snowman.TestBlock{
+		TestDecidable: choices.TestDecidable{
+			IDV:     ids.GenerateTestID(),
+			StatusV: choices.Accepted,
+		},
+		HeightV: 0,
+		BytesV:  []byte{0},
+	}
+	blk1 := &snowman.TestBlock{
+		TestDecidable: choices.TestDecidable{
+			IDV:     ids.GenerateTestID(),
+			StatusV: choices.Accepted,
+		},
+		ParentV: blk0.ID(),
+		HeightV: 1,
+		BytesV:  []byte{1},
+	}
+
+	vm.GetBlockIDAtHeightF = func(_ context.Context, height uint64) (ids.ID, error) {
+		switch height {
+		case 0:
+			return blk0.ID(), nil
+		case 1:
+			return blk1.ID(), nil
+		}
+		return ids.Empty, errUnknownBlock
+	}
+	vm.GetBlockF = func(_ context.Context, blkID ids.ID) (snowman.Block, error) {
+		switch blkID {
+		case blk0.ID():
+			return blk0, nil
+		case blk1.ID():
+			return blk1, nil
+		}
+		return nil, errUnknownBlock
+	}
+
+	var ancestors [][]byte
+	sender.SendAncestorsF = func(_ context.Context, _ ids.NodeID, _ uint32, containers [][]byte) {
+		ancestors = containers
+	}
+
+	require.NoError(bs.GetAncestorsAtHeight(context.Background(), ids.EmptyNodeID, 0, 1))
+	require.Equal([][]byte{blk1.Bytes(), blk0.Bytes()}, ancestors)
+
+	// Requests for heights that aren't accepted are dropped.
+	ancestors = nil
+	require.NoError(bs.GetAncestorsAtHeight(context.Background(), ids.EmptyNodeID, 0, 2))
+	require.Nil(ancestors)
+}
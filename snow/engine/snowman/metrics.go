@@ -6,6 +6,7 @@ package snowman
 import (
 	"github.com/prometheus/client_golang/prometheus"
 
+	"github.com/ava-labs/avalanchego/snow/consensus/snowball"
 	"github.com/ava-labs/avalanchego/utils/metric"
 	"github.com/ava-labs/avalanchego/utils/wrappers"
 )
@@ -36,6 +37,8 @@ type metrics struct {
 	selectedVoteIndex                     metric.Averager
 	issuerStake                           metric.Averager
 	issued                                *prometheus.CounterVec
+	parameters                            *prometheus.GaugeVec
+	numParametersChanges                  prometheus.Counter
 }
 
 func (m *metrics) Initialize(namespace string, reg prometheus.Registerer) error {
@@ -137,6 +140,17 @@ func (m *metrics) Initialize(namespace string, reg prometheus.Registerer) error
 		Help:      "number of blocks that have been issued into consensus by discovery mechanism",
 	}, []string{"source"})
 
+	m.parameters = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "snowball_parameters",
+		Help:      "snowball parameters that consensus is running with",
+	}, []string{"parameter"})
+	m.numParametersChanges = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "snowball_parameters_changes",
+		Help:      "Number of times the snowball parameters have been changed",
+	})
+
 	// Register the labels
 	m.issued.WithLabelValues(pullGossipSource)
 	m.issued.WithLabelValues(pushGossipSource)
@@ -159,6 +173,23 @@ func (m *metrics) Initialize(namespace string, reg prometheus.Registerer) error
 		reg.Register(m.numProcessingAncestorFetchesSucceeded),
 		reg.Register(m.numProcessingAncestorFetchesUnneeded),
 		reg.Register(m.issued),
+		reg.Register(m.parameters),
+		reg.Register(m.numParametersChanges),
 	)
 	return errs.Err
 }
+
+// setParameters reports [params] as the parameters that consensus is running
+// with, so that the poll and acceptance latencies can be compared across
+// parameters.
+func (m *metrics) setParameters(params snowball.Parameters) {
+	m.parameters.WithLabelValues("k").Set(float64(params.K))
+	m.parameters.WithLabelValues("alpha_preference").Set(float64(params.AlphaPreference))
+	m.parameters.WithLabelValues("alpha_confidence").Set(float64(params.AlphaConfidence))
+	m.parameters.WithLabelValues("beta_virtuous").Set(float64(params.BetaVirtuous))
+	m.parameters.WithLabelValues("beta_rogue").Set(float64(params.BetaRogue))
+	m.parameters.WithLabelValues("concurrent_repolls").Set(float64(params.ConcurrentRepolls))
+	m.parameters.WithLabelValues("optimal_processing").Set(float64(params.OptimalProcessing))
+	m.parameters.WithLabelValues("max_outstanding_items").Set(float64(params.MaxOutstandingItems))
+	m.parameters.WithLabelValues("max_item_processing_time").Set(float64(params.MaxItemProcessingTime))
+}
@@ -0,0 +1,132 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package snowman
+
+import (
+	"errors"
+
+	"go.uber.org/zap"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow/consensus/snowball"
+	"github.com/ava-labs/avalanchego/snow/consensus/snowman/poll"
+	"github.com/ava-labs/avalanchego/utils/bag"
+)
+
+var (
+	_ poll.Factory = pollFactory{}
+
+	errNoPreviousParameters = errors.New("no previous parameters")
+)
+
+// ParametersStatus reports the snowball parameters of the engine.
+type ParametersStatus struct {
+	// Active are the parameters that consensus currently runs with.
+	Active snowball.Parameters
+	// Pending are the parameters that will be applied once the next poll
+	// finishes. If nil, no change is pending.
+	Pending *snowball.Parameters
+	// NumProcessing is the number of blocks that are processing. While it is
+	// zero, no polls are issued and Pending is applied immediately.
+	NumProcessing int
+	// Previous are the parameters that were active before the last change.
+	// If nil, the parameters were never changed.
+	Previous *snowball.Parameters
+}
+
+// Parameters returns the snowball parameters of the engine.
+//
+// Invariant: The context lock must be held.
+func (t *Transitive) Parameters() ParametersStatus {
+	status := ParametersStatus{
+		Active:   t.Params,
+		Pending:  t.pendingParams,
+		Previous: t.previousParams,
+	}
+	if t.started {
+		status.NumProcessing = t.Consensus.NumProcessing()
+	}
+	return status
+}
+
+// SetParameters verifies [params] and schedules them to replace the active
+// parameters. The parameters are applied between polls, once the next poll
+// finishes, or immediately if no blocks are processing. Blocks that were
+// already issued are decided with the parameters they were issued with. A
+// change that is still pending is replaced.
+//
+// The parameters are not persisted, so the configured parameters are used
+// again once the node restarts.
+//
+// Invariant: The context lock must be held.
+func (t *Transitive) SetParameters(params snowball.Parameters) error {
+	if err := params.Verify(); err != nil {
+		return err
+	}
+
+	t.Ctx.Log.Info("scheduling consensus parameters change",
+		zap.Reflect("params", params),
+	)
+	t.pendingParams = &params
+	if t.started && t.Consensus.NumProcessing() != 0 {
+		return nil
+	}
+	return t.applyPendingParameters()
+}
+
+// RollbackParameters cancels the pending change of parameters. If no change is
+// pending, the parameters that were active before the last change are
+// scheduled to replace the active parameters.
+//
+// Invariant: The context lock must be held.
+func (t *Transitive) RollbackParameters() error {
+	if t.pendingParams != nil {
+		t.Ctx.Log.Info("cancelling consensus parameters change",
+			zap.Reflect("params", *t.pendingParams),
+		)
+		t.pendingParams = nil
+		return nil
+	}
+	if t.previousParams == nil {
+		return errNoPreviousParameters
+	}
+	return t.SetParameters(*t.previousParams)
+}
+
+// applyPendingParameters replaces the active parameters with the pending
+// parameters if consensus has started.
+func (t *Transitive) applyPendingParameters() error {
+	if t.pendingParams == nil || !t.started {
+		return nil
+	}
+
+	params := *t.pendingParams
+	if err := t.Consensus.SetParameters(params); err != nil {
+		return err
+	}
+
+	t.Ctx.Log.Info("applied consensus parameters",
+		zap.Reflect("previousParams", t.Params),
+		zap.Reflect("params", params),
+	)
+	previousParams := t.Params
+	t.previousParams = &previousParams
+	t.pendingParams = nil
+	t.Params = params
+	t.metrics.setParameters(params)
+	t.metrics.numParametersChanges.Inc()
+	return nil
+}
+
+// pollFactory creates polls with the active parameters of the engine.
+type pollFactory struct {
+	params *snowball.Parameters
+}
+
+func (f pollFactory) New(vdrs bag.Bag[ids.NodeID]) poll.Poll {
+	return poll.NewEarlyTermNoTraversalFactory(
+		f.params.AlphaPreference,
+		f.params.AlphaConfidence,
+	).New(vdrs)
+}
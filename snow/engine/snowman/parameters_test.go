@@ -0,0 +1,140 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package snowman
+
+import (
+	"context"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow/choices"
+	"github.com/ava-labs/avalanchego/snow/consensus/snowball"
+	"github.com/ava-labs/avalanchego/snow/consensus/snowman"
+	"github.com/ava-labs/avalanchego/snow/engine/common"
+	"github.com/ava-labs/avalanchego/utils/set"
+)
+
+func TestEngineSetParameters(t *testing.T) {
+	require := require.New(t)
+
+	vdr, _, sender, vm, te, gBlk := setupDefaultConfig(t)
+	initialParams := te.Params
+
+	invalidParams := initialParams
+	invalidParams.K = 0
+	err := te.SetParameters(invalidParams)
+	require.ErrorIs(err, snowball.ErrParametersInvalid)
+	require.Equal(ParametersStatus{Active: initialParams}, te.Parameters())
+
+	// Nothing is processing, so the parameters are applied immediately.
+	params0 := initialParams
+	params0.OptimalProcessing = 10
+	params0.BetaVirtuous = 2
+	params0.BetaRogue = 2
+	require.NoError(te.SetParameters(params0))
+	require.Equal(ParametersStatus{
+		Active:   params0,
+		Previous: &initialParams,
+	}, te.Parameters())
+	require.Equal(float64(10), testutil.ToFloat64(te.metrics.parameters.WithLabelValues("optimal_processing")))
+	require.Equal(float64(1), testutil.ToFloat64(te.metrics.numParametersChanges))
+
+	blk := &snowman.TestBlock{
+		TestDecidable: choices.TestDecidable{
+			IDV:     ids.GenerateTestID(),
+			StatusV: choices.Processing,
+		},
+		ParentV: gBlk.ID(),
+		HeightV: 1,
+		BytesV:  []byte{1},
+	}
+	vm.GetBlockF = func(_ context.Context, blkID ids.ID) (snowman.Block, error) {
+		switch blkID {
+		case gBlk.ID():
+			return gBlk, nil
+		case blk.ID():
+			return blk, nil
+		default:
+			return nil, errUnknownBlock
+		}
+	}
+	vm.BuildBlockF = func(context.Context) (snowman.Block, error) {
+		return blk, nil
+	}
+
+	var requestID uint32
+	sender.SendPushQueryF = func(_ context.Context, _ set.Set[ids.NodeID], reqID uint32, _ []byte, _ uint64) {
+		requestID = reqID
+	}
+	sender.SendPullQueryF = func(_ context.Context, _ set.Set[ids.NodeID], reqID uint32, _ ids.ID, _ uint64) {
+		requestID = reqID
+	}
+	require.NoError(te.Notify(context.Background(), common.PendingTxs))
+	require.Equal(1, te.Consensus.NumProcessing())
+
+	// A block is processing, so the parameters are applied once the next
+	// poll finishes.
+	params1 := params0
+	params1.OptimalProcessing = 20
+	require.NoError(te.SetParameters(params1))
+	require.Equal(ParametersStatus{
+		Active:        params0,
+		Pending:       &params1,
+		NumProcessing: 1,
+		Previous:      &initialParams,
+	}, te.Parameters())
+
+	require.NoError(te.Chits(context.Background(), vdr, requestID, blk.ID(), blk.ID(), blk.ID()))
+	require.Equal(choices.Processing, blk.Status())
+	require.Equal(ParametersStatus{
+		Active:        params1,
+		NumProcessing: 1,
+		Previous:      &params0,
+	}, te.Parameters())
+
+	// The block is decided with the parameters it was issued with.
+	require.NoError(te.Chits(context.Background(), vdr, requestID, blk.ID(), blk.ID(), blk.ID()))
+	require.Equal(choices.Accepted, blk.Status())
+	require.Equal(ParametersStatus{
+		Active:   params1,
+		Previous: &params0,
+	}, te.Parameters())
+	require.Equal(float64(20), testutil.ToFloat64(te.metrics.parameters.WithLabelValues("optimal_processing")))
+	require.Equal(float64(2), testutil.ToFloat64(te.metrics.numParametersChanges))
+
+	// Rolling back restores the previous parameters.
+	require.NoError(te.RollbackParameters())
+	require.Equal(ParametersStatus{
+		Active:   params0,
+		Previous: &params1,
+	}, te.Parameters())
+}
+
+func TestEngineRollbackParameters(t *testing.T) {
+	require := require.New(t)
+
+	engCfg := DefaultConfig(t)
+	te, err := New(engCfg)
+	require.NoError(err)
+	initialParams := te.Params
+
+	err = te.RollbackParameters()
+	require.ErrorIs(err, errNoPreviousParameters)
+
+	// Consensus hasn't started, so the change is pending.
+	params := initialParams
+	params.ConcurrentRepolls = 2
+	require.NoError(te.SetParameters(params))
+	require.Equal(ParametersStatus{
+		Active:  initialParams,
+		Pending: &params,
+	}, te.Parameters())
+
+	// Rolling back cancels the pending change.
+	require.NoError(te.RollbackParameters())
+	require.Equal(ParametersStatus{Active: initialParams}, te.Parameters())
+}
@@ -16,6 +16,7 @@ import (
 	"github.com/ava-labs/avalanchego/proto/pb/p2p"
 	"github.com/ava-labs/avalanchego/snow"
 	"github.com/ava-labs/avalanchego/snow/choices"
+	"github.com/ava-labs/avalanchego/snow/consensus/snowball"
 	"github.com/ava-labs/avalanchego/snow/consensus/snowman"
 	"github.com/ava-labs/avalanchego/snow/consensus/snowman/poll"
 	"github.com/ava-labs/avalanchego/snow/engine/common"
@@ -89,6 +90,15 @@ type Transitive struct {
 	// processing blocks has gone below the optimal number.
 	pendingBuildBlocks int
 
+	// started is set once consensus has been initialized
+	started bool
+	// pendingParams are the parameters that will replace the active
+	// parameters once the next poll finishes. If nil, no change is pending.
+	pendingParams *snowball.Parameters
+	// previousParams are the parameters that were active before the last
+	// change of parameters. If nil, the parameters were never changed.
+	previousParams *snowball.Parameters
+
 	// errs tracks if an error has occurred in a callback
 	errs wrappers.Errs
 }
@@ -111,20 +121,6 @@ func New(config Config) (*Transitive, error) {
 	acceptedFrontiers := tracker.NewAccepted()
 	config.Validators.RegisterCallbackListener(config.Ctx.SubnetID, acceptedFrontiers)
 
-	factory := poll.NewEarlyTermNoTraversalFactory(
-		config.Params.AlphaPreference,
-		config.Params.AlphaConfidence,
-	)
-	polls, err := poll.NewSet(
-		factory,
-		config.Ctx.Log,
-		"",
-		config.Ctx.Registerer,
-	)
-	if err != nil {
-		return nil, err
-	}
-
 	t := &Transitive{
 		Config:                      config,
 		StateSummaryFrontierHandler: common.NewNoOpStateSummaryFrontierHandler(config.Ctx.Log),
@@ -138,12 +134,27 @@ func New(config Config) (*Transitive, error) {
 		nonVerifieds:                ancestor.NewTree(),
 		nonVerifiedCache:            nonVerifiedCache,
 		acceptedFrontiers:           acceptedFrontiers,
-		polls:                       polls,
 		blkReqs:                     bimap.New[common.Request, ids.ID](),
 		blkReqSourceMetric:          make(map[common.Request]prometheus.Counter),
 	}
 
-	return t, t.metrics.Initialize("", config.Ctx.Registerer)
+	// Polls are created with the parameters that are active when the poll is
+	// issued, so that the parameters can be changed while the engine runs.
+	t.polls, err = poll.NewSet(
+		pollFactory{params: &t.Params},
+		config.Ctx.Log,
+		"",
+		config.Ctx.Registerer,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := t.metrics.Initialize("", config.Ctx.Registerer); err != nil {
+		return nil, err
+	}
+	t.metrics.setParameters(t.Params)
+	return t, nil
 }
 
 func (t *Transitive) Gossip(ctx context.Context) error {
@@ -474,6 +485,10 @@ func (t *Transitive) Start(ctx context.Context, startReqID uint32) error {
 	if err := t.Consensus.Initialize(t.Ctx, t.Params, lastAcceptedID, lastAccepted.Height(), lastAccepted.Timestamp()); err != nil {
 		return err
 	}
+	t.started = true
+	if err := t.applyPendingParameters(); err != nil {
+		return err
+	}
 
 	// to maintain the invariant that oracle blocks are issued in the correct
 	// preferences, we need to handle the case that we are bootstrapping into an oracle block
@@ -88,6 +88,13 @@ func (v *voter) Update(ctx context.Context) {
 		return
 	}
 
+	// The poll is finished, so a pending change of parameters can be applied
+	// before the next poll is issued.
+	if err := v.t.applyPendingParameters(); err != nil {
+		v.t.errs.Add(err)
+		return
+	}
+
 	if v.t.Consensus.NumProcessing() == 0 {
 		v.t.Ctx.Log.Debug("Snowman engine can quiesce")
 		return
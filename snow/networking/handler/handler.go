@@ -635,6 +635,10 @@ func (h *handler) handleSyncMsg(ctx context.Context, msg Message) error {
 		return engine.GetAcceptedFailed(ctx, nodeID, msg.RequestID)
 
 	case *p2p.GetAncestors:
+		if len(msg.ContainerId) == 0 {
+			return engine.GetAncestorsAtHeight(ctx, nodeID, msg.RequestId, msg.Height)
+		}
+
 		containerID, err := ids.ToID(msg.ContainerId)
 		if err != nil {
 			h.ctx.Log.Debug("dropping message with invalid field",
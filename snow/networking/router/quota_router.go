@@ -0,0 +1,176 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package router
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"go.uber.org/zap"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/message"
+	"github.com/ava-labs/avalanchego/network/throttling"
+	"github.com/ava-labs/avalanchego/proto/pb/p2p"
+	"github.com/ava-labs/avalanchego/snow/networking/handler"
+	"github.com/ava-labs/avalanchego/snow/networking/timeout"
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/ava-labs/avalanchego/utils/set"
+	"github.com/ava-labs/avalanchego/version"
+)
+
+var _ Router = (*quotaRouter)(nil)
+
+// quotaRouter drops inbound messages that exceed the bandwidth quota of the
+// subnet of the chain they are sent to.
+type quotaRouter struct {
+	router Router
+	quota  throttling.BandwidthQuota
+	log    logging.Logger
+
+	lock sync.RWMutex
+	// Chain ID --> ID of the subnet that validates the chain
+	chainSubnets map[ids.ID]ids.ID
+}
+
+func WithBandwidthQuota(router Router, quota throttling.BandwidthQuota) Router {
+	return &quotaRouter{
+		router:       router,
+		quota:        quota,
+		log:          logging.NoLog{},
+		chainSubnets: make(map[ids.ID]ids.ID),
+	}
+}
+
+func (r *quotaRouter) Initialize(
+	nodeID ids.NodeID,
+	log logging.Logger,
+	timeoutManager timeout.Manager,
+	closeTimeout time.Duration,
+	criticalChains set.Set[ids.ID],
+	sybilProtectionEnabled bool,
+	trackedSubnets set.Set[ids.ID],
+	onFatal func(exitCode int),
+	healthConfig HealthConfig,
+	metricsNamespace string,
+	metricsRegisterer prometheus.Registerer,
+) error {
+	r.log = log
+	return r.router.Initialize(
+		nodeID,
+		log,
+		timeoutManager,
+		closeTimeout,
+		criticalChains,
+		sybilProtectionEnabled,
+		trackedSubnets,
+		onFatal,
+		healthConfig,
+		metricsNamespace,
+		metricsRegisterer,
+	)
+}
+
+func (r *quotaRouter) RegisterRequest(
+	ctx context.Context,
+	nodeID ids.NodeID,
+	requestingChainID ids.ID,
+	respondingChainID ids.ID,
+	requestID uint32,
+	op message.Op,
+	failedMsg message.InboundMessage,
+	engineType p2p.EngineType,
+) {
+	r.router.RegisterRequest(
+		ctx,
+		nodeID,
+		requestingChainID,
+		respondingChainID,
+		requestID,
+		op,
+		failedMsg,
+		engineType,
+	)
+}
+
+func (r *quotaRouter) HandleInbound(ctx context.Context, msg message.InboundMessage) {
+	chainID, err := message.GetChainID(msg.Message())
+	if err != nil {
+		r.router.HandleInbound(ctx, msg)
+		return
+	}
+
+	r.lock.RLock()
+	subnetID, ok := r.chainSubnets[chainID]
+	r.lock.RUnlock()
+	if !ok {
+		r.router.HandleInbound(ctx, msg)
+		return
+	}
+
+	op := msg.Op()
+	msgSize := inboundMsgSize(msg)
+	if !r.quota.Allow(subnetID, op, msgSize) {
+		r.log.Debug("dropping message",
+			zap.String("reason", "exceeded bandwidth quota"),
+			zap.Stringer("messageOp", op),
+			zap.Stringer("nodeID", msg.NodeID()),
+			zap.Stringer("chainID", chainID),
+			zap.Stringer("subnetID", subnetID),
+			zap.Uint64("messageSize", msgSize),
+		)
+		msg.OnFinishedHandling()
+		return
+	}
+	r.router.HandleInbound(ctx, msg)
+}
+
+func (r *quotaRouter) Shutdown(ctx context.Context) {
+	r.router.Shutdown(ctx)
+}
+
+func (r *quotaRouter) AddChain(ctx context.Context, chain handler.Handler) {
+	chainCtx := chain.Context()
+	r.lock.Lock()
+	r.chainSubnets[chainCtx.ChainID] = chainCtx.SubnetID
+	r.lock.Unlock()
+
+	r.router.AddChain(ctx, chain)
+}
+
+func (r *quotaRouter) Connected(nodeID ids.NodeID, nodeVersion *version.Application, subnetID ids.ID) {
+	r.router.Connected(nodeID, nodeVersion, subnetID)
+}
+
+func (r *quotaRouter) Disconnected(nodeID ids.NodeID) {
+	r.router.Disconnected(nodeID)
+}
+
+func (r *quotaRouter) Benched(chainID ids.ID, nodeID ids.NodeID) {
+	r.router.Benched(chainID, nodeID)
+}
+
+func (r *quotaRouter) Unbenched(chainID ids.ID, nodeID ids.NodeID) {
+	r.router.Unbenched(chainID, nodeID)
+}
+
+func (r *quotaRouter) HealthCheck(ctx context.Context) (interface{}, error) {
+	return r.router.HealthCheck(ctx)
+}
+
+// inboundMsgSize returns the number of bytes [msg] consumed on the wire.
+func inboundMsgSize(msg message.InboundMessage) uint64 {
+	m, ok := msg.Message().(proto.Message)
+	if !ok {
+		return 0
+	}
+	size := proto.Size(m) - msg.BytesSavedCompression()
+	if size < 0 {
+		return 0
+	}
+	return uint64(size)
+}
@@ -6,6 +6,7 @@ package sender
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/prometheus/client_golang/prometheus"
 	"go.uber.org/zap"
@@ -644,6 +645,50 @@ func (s *sender) SendAccepted(ctx context.Context, nodeID ids.NodeID, requestID
 }
 
 func (s *sender) SendGetAncestors(ctx context.Context, nodeID ids.NodeID, requestID uint32, containerID ids.ID) {
+	s.sendGetAncestors(
+		ctx,
+		nodeID,
+		requestID,
+		zap.Stringer("containerID", containerID),
+		func(deadline time.Duration) (message.OutboundMessage, error) {
+			return s.msgCreator.GetAncestors(
+				s.ctx.ChainID,
+				requestID,
+				deadline,
+				containerID,
+				s.engineType,
+			)
+		},
+	)
+}
+
+func (s *sender) SendGetAncestorsAtHeight(ctx context.Context, nodeID ids.NodeID, requestID uint32, height uint64) {
+	s.sendGetAncestors(
+		ctx,
+		nodeID,
+		requestID,
+		zap.Uint64("height", height),
+		func(deadline time.Duration) (message.OutboundMessage, error) {
+			return s.msgCreator.GetAncestorsAtHeight(
+				s.ctx.ChainID,
+				requestID,
+				deadline,
+				height,
+				s.engineType,
+			)
+		},
+	)
+}
+
+// sendGetAncestors sends the GetAncestors message built by [buildMsg].
+// [requested] identifies the requested container in logs.
+func (s *sender) sendGetAncestors(
+	ctx context.Context,
+	nodeID ids.NodeID,
+	requestID uint32,
+	requested zap.Field,
+	buildMsg func(deadline time.Duration) (message.OutboundMessage, error),
+) {
 	ctx = context.WithoutCancel(ctx)
 
 	// Tell the router to expect a response message or a message notifying
@@ -684,19 +729,13 @@ func (s *sender) SendGetAncestors(ctx context.Context, nodeID ids.NodeID, reques
 	// registered. That's OK.
 	deadline := s.timeouts.TimeoutDuration()
 	// Create the outbound message.
-	outMsg, err := s.msgCreator.GetAncestors(
-		s.ctx.ChainID,
-		requestID,
-		deadline,
-		containerID,
-		s.engineType,
-	)
+	outMsg, err := buildMsg(deadline)
 	if err != nil {
 		s.ctx.Log.Error("failed to build message",
 			zap.Stringer("messageOp", message.GetAncestorsOp),
 			zap.Stringer("chainID", s.ctx.ChainID),
 			zap.Uint32("requestID", requestID),
-			zap.Stringer("containerID", containerID),
+			requested,
 			zap.Error(err),
 		)
 
@@ -720,7 +759,7 @@ func (s *sender) SendGetAncestors(ctx context.Context, nodeID ids.NodeID, reques
 			zap.Stringer("nodeID", nodeID),
 			zap.Stringer("chainID", s.ctx.ChainID),
 			zap.Uint32("requestID", requestID),
-			zap.Stringer("containerID", containerID),
+			requested,
 		)
 
 		s.timeouts.RegisterRequestToUnreachableValidator()
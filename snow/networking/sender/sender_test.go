@@ -1130,6 +1130,48 @@ func TestSender_Single_Request(t *testing.T) {
 			},
 			expectedEngineType: engineType,
 		},
+		{
+			name: "GetAncestorsAtHeight",
+			failedMsgF: func(nodeID ids.NodeID) message.InboundMessage {
+				return message.InternalGetAncestorsFailed(
+					nodeID,
+					ctx.ChainID,
+					requestID,
+					engineType,
+				)
+			},
+			assertMsgToMyself: func(require *require.Assertions, msg message.InboundMessage) {
+				require.IsType(&message.GetAncestorsFailed{}, msg.Message())
+				innerMsg := msg.Message().(*message.GetAncestorsFailed)
+				require.Equal(ctx.ChainID, innerMsg.ChainID)
+				require.Equal(requestID, innerMsg.RequestID)
+				require.Equal(engineType, innerMsg.EngineType)
+			},
+			expectedResponseOp: message.AncestorsOp,
+			setMsgCreatorExpect: func(msgCreator *message.MockOutboundMsgBuilder) {
+				msgCreator.EXPECT().GetAncestorsAtHeight(
+					ctx.ChainID,
+					requestID,
+					deadline,
+					uint64(10),
+					engineType,
+				).Return(nil, nil)
+			},
+			setExternalSenderExpect: func(externalSender *MockExternalSender, sentTo set.Set[ids.NodeID]) {
+				externalSender.EXPECT().Send(
+					gomock.Any(), // Outbound message
+					common.SendConfig{
+						NodeIDs: set.Of(destinationNodeID),
+					},
+					ctx.SubnetID,
+					gomock.Any(),
+				).Return(sentTo)
+			},
+			sendF: func(_ *require.Assertions, sender common.Sender, nodeID ids.NodeID) {
+				sender.SendGetAncestorsAtHeight(context.Background(), nodeID, requestID, 10)
+			},
+			expectedEngineType: engineType,
+		},
 		{
 			name: "Get",
 			failedMsgF: func(nodeID ids.NodeID) message.InboundMessage {
@@ -0,0 +1,638 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package simulator
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"math/rand"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"go.uber.org/zap"
+
+	"github.com/ava-labs/avalanchego/api/metrics"
+	"github.com/ava-labs/avalanchego/database/memdb"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/message"
+	"github.com/ava-labs/avalanchego/proto/pb/p2p"
+	"github.com/ava-labs/avalanchego/snow"
+	"github.com/ava-labs/avalanchego/snow/engine/common"
+	"github.com/ava-labs/avalanchego/snow/engine/common/queue"
+	"github.com/ava-labs/avalanchego/snow/engine/common/tracker"
+	"github.com/ava-labs/avalanchego/snow/engine/snowman/bootstrap"
+	"github.com/ava-labs/avalanchego/snow/engine/snowman/getter"
+	"github.com/ava-labs/avalanchego/snow/networking/handler"
+	"github.com/ava-labs/avalanchego/snow/networking/router"
+	"github.com/ava-labs/avalanchego/snow/networking/sender"
+	"github.com/ava-labs/avalanchego/snow/validators"
+	"github.com/ava-labs/avalanchego/subnets"
+	"github.com/ava-labs/avalanchego/utils"
+	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/ava-labs/avalanchego/utils/sampler"
+	"github.com/ava-labs/avalanchego/utils/set"
+	"github.com/ava-labs/avalanchego/version"
+
+	smcon "github.com/ava-labs/avalanchego/snow/consensus/snowman"
+	smeng "github.com/ava-labs/avalanchego/snow/engine/snowman"
+	nettracker "github.com/ava-labs/avalanchego/snow/networking/tracker"
+)
+
+const (
+	shutdownTimeout                 = time.Second
+	consensusAppConcurrency         = 2
+	bootstrapMaxTimeGetAncestors    = time.Second
+	bootstrapAncestorsMaxContainers = 2000
+)
+
+var (
+	chainID = ids.ID{'s', 'i', 'm', 'u', 'l', 'a', 't', 'o', 'r'}
+
+	errMissingEngine = errors.New("missing engine")
+
+	_ snow.Acceptor              = noOpAcceptor{}
+	_ validators.Manager         = (*validatorManager)(nil)
+	_ tracker.Peers              = (*peers)(nil)
+	_ tracker.Startup            = (*startup)(nil)
+	_ common.Sender              = (*countingSender)(nil)
+	_ router.Router              = (*selfRouter)(nil)
+	_ sender.ExternalSender      = (*externalSender)(nil)
+	_ handler.Handler            = (*trackedHandler)(nil)
+	_ message.InboundMessage     = (*trackedMessage)(nil)
+	_ nettracker.ResourceTracker = resourceTracker{}
+	_ nettracker.Tracker         = resourceTracker{}
+	_ nettracker.DiskTracker     = resourceTracker{}
+)
+
+// node is a full snowman node whose network, timeouts and VM are provided by
+// the simulator.
+type node struct {
+	sim *Simulator
+	id  ids.NodeID
+
+	// group is the partition this node is in. Nodes can only communicate with
+	// nodes in the same group.
+	group int
+	// connected is the set of peers this node's router was told are
+	// connected.
+	connected set.Set[ids.NodeID]
+
+	skewLock sync.RWMutex
+	skew     time.Duration
+
+	stopped  utils.Atomic[bool]
+	stopping bool
+
+	ctx      *snow.ConsensusContext
+	vm       *vm
+	router   *router.ChainRouter
+	handler  handler.Handler
+	timeouts *timeoutManager
+}
+
+func newNode(
+	sim *Simulator,
+	nodeID ids.NodeID,
+	nodeIDs []ids.NodeID,
+	skew time.Duration,
+	seed int64,
+) (*node, error) {
+	n := &node{
+		sim:  sim,
+		id:   nodeID,
+		skew: skew,
+	}
+	n.vm = newVM(sim.log, sim.genesis, n.time, sim.config.MaxBlockDrift, n.onAccept)
+	n.timeouts = &timeoutManager{
+		node:     n,
+		requests: make(map[ids.RequestID]struct{}),
+	}
+
+	n.ctx = &snow.ConsensusContext{
+		Context: &snow.Context{
+			NetworkID: constants.UnitTestID,
+			SubnetID:  constants.PrimaryNetworkID,
+			ChainID:   chainID,
+			NodeID:    nodeID,
+			Log:       sim.log,
+			BCLookup:  ids.NewAliaser(),
+			Metrics:   metrics.NewOptionalGatherer(),
+		},
+		Registerer:          prometheus.NewRegistry(),
+		AvalancheRegisterer: prometheus.NewRegistry(),
+		BlockAcceptor:       noOpAcceptor{},
+		TxAcceptor:          noOpAcceptor{},
+		VertexAcceptor:      noOpAcceptor{},
+	}
+	n.ctx.State.Set(snow.EngineState{
+		Type:  p2p.EngineType_ENGINE_TYPE_SNOWMAN,
+		State: snow.Initializing,
+	})
+
+	// Every random decision made by this node's engines is drawn from [rng].
+	// The engines only sample while holding the context lock, so [rng] is
+	// never accessed concurrently.
+	rng := rand.New(rand.NewSource(seed)) // #nosec G404
+	vdrs := &validatorManager{
+		Manager: validators.NewManager(),
+		rng:     rng,
+	}
+	for _, vdrID := range nodeIDs {
+		if err := vdrs.AddStaker(constants.PrimaryNetworkID, vdrID, nil, ids.Empty, 1); err != nil {
+			return nil, err
+		}
+	}
+	totalWeight, err := vdrs.TotalWeight(constants.PrimaryNetworkID)
+	if err != nil {
+		return nil, err
+	}
+
+	n.router = &router.ChainRouter{}
+	if err := n.router.Initialize(
+		nodeID,
+		sim.log,
+		n.timeouts,
+		shutdownTimeout,
+		set.Set[ids.ID]{},
+		true,
+		set.Set[ids.ID]{},
+		nil,
+		router.HealthConfig{},
+		"",
+		prometheus.NewRegistry(),
+	); err != nil {
+		return nil, err
+	}
+
+	subnet := subnets.New(nodeID, subnets.Config{})
+	subnet.AddChain(chainID)
+
+	// Passes messages from the consensus engine to the network
+	messageSender, err := sender.New(
+		n.ctx,
+		sim.msgCreator,
+		&externalSender{node: n},
+		&selfRouter{
+			Router: n.router,
+			node:   n,
+		},
+		n.timeouts,
+		p2p.EngineType_ENGINE_TYPE_SNOWMAN,
+		subnet,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't initialize sender: %w", err)
+	}
+	engineSender := &countingSender{
+		Sender:  messageSender,
+		nodeID:  nodeID,
+		pending: &sim.pending,
+	}
+
+	connectedValidators := &peers{
+		Peers: tracker.NewPeers(),
+		rng:   rng,
+	}
+	vdrs.RegisterCallbackListener(constants.PrimaryNetworkID, connectedValidators)
+
+	// Asynchronously passes messages from the network to the consensus engine
+	h, err := handler.New(
+		n.ctx,
+		vdrs,
+		nil,
+		// Gossip is driven by the simulator rather than by the handler.
+		math.MaxInt64,
+		consensusAppConcurrency,
+		resourceTracker{},
+		validators.UnhandledSubnetConnector,
+		subnet,
+		connectedValidators,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't initialize message handler: %w", err)
+	}
+
+	startupTracker := &startup{
+		Startup: tracker.NewStartup(tracker.NewPeers(), (3*totalWeight+3)/4),
+		rng:     rng,
+	}
+	vdrs.RegisterCallbackListener(constants.PrimaryNetworkID, startupTracker)
+
+	snowGetHandler, err := getter.New(
+		n.vm,
+		engineSender,
+		sim.log,
+		bootstrapMaxTimeGetAncestors,
+		bootstrapAncestorsMaxContainers,
+		n.ctx.Registerer,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't initialize snow base message handler: %w", err)
+	}
+
+	engine, err := smeng.New(smeng.Config{
+		Ctx:                 n.ctx,
+		AllGetsServer:       snowGetHandler,
+		VM:                  n.vm,
+		Sender:              engineSender,
+		Validators:          vdrs,
+		ConnectedValidators: connectedValidators,
+		Params:              sim.config.Params,
+		Consensus:           &smcon.Topological{},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error initializing snowman engine: %w", err)
+	}
+
+	blocked, err := queue.NewWithMissing(memdb.New(), "block", n.ctx.Registerer)
+	if err != nil {
+		return nil, err
+	}
+	bootstrapper, err := bootstrap.New(
+		bootstrap.Config{
+			AllGetsServer:                  snowGetHandler,
+			Ctx:                            n.ctx,
+			Beacons:                        vdrs,
+			SampleK:                        len(nodeIDs),
+			StartupTracker:                 startupTracker,
+			Sender:                         engineSender,
+			BootstrapTracker:               subnet,
+			Timer:                          &timer{node: n},
+			AncestorsMaxContainersReceived: bootstrapAncestorsMaxContainers,
+			Blocked:                        blocked,
+			DB:                             memdb.New(),
+			VM:                             n.vm,
+		},
+		engine.Start,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("error initializing snowman bootstrapper: %w", err)
+	}
+
+	h.SetEngineManager(&handler.EngineManager{
+		Snowman: &handler.Engine{
+			Bootstrapper: bootstrapper,
+			Consensus:    engine,
+		},
+	})
+	n.handler = &trackedHandler{
+		Handler: h,
+		node:    n,
+	}
+	return n, nil
+}
+
+func (n *node) start() {
+	ctx := context.Background()
+	n.router.AddChain(ctx, n.handler)
+	n.handler.Start(ctx, false)
+}
+
+func (n *node) shutdown() {
+	n.stopping = true
+	n.router.Shutdown(context.Background())
+}
+
+// time returns the local time of this node.
+func (n *node) time() time.Time {
+	n.skewLock.RLock()
+	defer n.skewLock.RUnlock()
+
+	return n.sim.now.Add(n.skew)
+}
+
+func (n *node) setSkew(skew time.Duration) {
+	n.skewLock.Lock()
+	defer n.skewLock.Unlock()
+
+	n.skew = skew
+}
+
+func (n *node) isConnected(peer *node) bool {
+	return n.group == peer.group
+}
+
+func (n *node) connect(nodeID ids.NodeID) {
+	n.connected.Add(nodeID)
+	n.router.Connected(nodeID, version.CurrentApp, constants.PrimaryNetworkID)
+}
+
+func (n *node) disconnect(nodeID ids.NodeID) {
+	n.connected.Remove(nodeID)
+	n.router.Disconnected(nodeID)
+}
+
+// shouldNotify returns true if the engine should be told that there are
+// pending txs. Notifications are only sent once the node has finished
+// bootstrapping.
+func (n *node) shouldNotify() bool {
+	if n.ctx.State.Get().State != snow.NormalOp {
+		return false
+	}
+	return n.vm.takeNotify()
+}
+
+// withEngine calls [f] with the currently running engine while holding the
+// context lock, the same way the handler does.
+func (n *node) withEngine(f func(common.Engine) error) error {
+	n.ctx.Lock.Lock()
+	defer n.ctx.Lock.Unlock()
+
+	state := n.ctx.State.Get()
+	engine, ok := n.handler.GetEngineManager().Get(state.Type).Get(state.State)
+	if !ok {
+		return fmt.Errorf(
+			"%w %s running %s on %s",
+			errMissingEngine,
+			state.State,
+			state.Type,
+			n.id,
+		)
+	}
+	return f(engine)
+}
+
+func (n *node) onAccept(blk *Block) {
+	n.sim.log.Debug("simulated node accepted block",
+		zap.Stringer("nodeID", n.id),
+		zap.Stringer("blkID", blk.ID()),
+		zap.Uint64("height", blk.Height()),
+	)
+}
+
+type noOpAcceptor struct{}
+
+func (noOpAcceptor) Accept(*snow.ConsensusContext, ids.ID, []byte) error {
+	return nil
+}
+
+// externalSender hands messages sent by a node to the simulated network.
+type externalSender struct {
+	node *node
+}
+
+func (s *externalSender) Send(
+	msg message.OutboundMessage,
+	config common.SendConfig,
+	_ ids.ID,
+	_ subnets.Allower,
+) set.Set[ids.NodeID] {
+	// The consensus engines only send messages to explicit nodes. Messages
+	// are always reported as sent, even if they will be lost, so that
+	// failures are reported by timeouts.
+	return s.node.sim.send(s.node.id, config.NodeIDs, msg)
+}
+
+// trackedHandler counts every message pushed to the handler until it is
+// finished being handled. This allows the simulator to know when a node has
+// finished handling all of its messages.
+type trackedHandler struct {
+	handler.Handler
+	node *node
+}
+
+func (h *trackedHandler) Push(ctx context.Context, msg handler.Message) {
+	pending := &h.node.sim.pending
+	pending.add(1)
+	msg.InboundMessage = &trackedMessage{
+		InboundMessage: msg.InboundMessage,
+		pending:        pending,
+	}
+	h.Handler.Push(ctx, msg)
+}
+
+func (h *trackedHandler) SetOnStopped(onStopped func()) {
+	h.Handler.SetOnStopped(func() {
+		if !h.node.stopping {
+			h.node.stopped.Set(true)
+		}
+		if onStopped != nil {
+			onStopped()
+		}
+	})
+}
+
+type trackedMessage struct {
+	message.InboundMessage
+	pending *pendingWork
+}
+
+func (m *trackedMessage) OnFinishedHandling() {
+	m.InboundMessage.OnFinishedHandling()
+	m.pending.add(-1)
+}
+
+// The sender delivers messages that a node sends to itself in a new
+// goroutine. countingSender counts these messages from the moment they are
+// sent until they are handed to selfRouter.
+type countingSender struct {
+	common.Sender
+	nodeID  ids.NodeID
+	pending *pendingWork
+}
+
+func (s *countingSender) countIfContains(nodeIDs set.Set[ids.NodeID]) {
+	if nodeIDs.Contains(s.nodeID) {
+		s.pending.add(1)
+	}
+}
+
+func (s *countingSender) countIfEqual(nodeID ids.NodeID) {
+	if nodeID == s.nodeID {
+		s.pending.add(1)
+	}
+}
+
+func (s *countingSender) SendGetStateSummaryFrontier(ctx context.Context, nodeIDs set.Set[ids.NodeID], requestID uint32) {
+	s.countIfContains(nodeIDs)
+	s.Sender.SendGetStateSummaryFrontier(ctx, nodeIDs, requestID)
+}
+
+func (s *countingSender) SendStateSummaryFrontier(ctx context.Context, nodeID ids.NodeID, requestID uint32, summary []byte) {
+	s.countIfEqual(nodeID)
+	s.Sender.SendStateSummaryFrontier(ctx, nodeID, requestID, summary)
+}
+
+func (s *countingSender) SendGetAcceptedStateSummary(ctx context.Context, nodeIDs set.Set[ids.NodeID], requestID uint32, heights []uint64) {
+	s.countIfContains(nodeIDs)
+	s.Sender.SendGetAcceptedStateSummary(ctx, nodeIDs, requestID, heights)
+}
+
+func (s *countingSender) SendAcceptedStateSummary(ctx context.Context, nodeID ids.NodeID, requestID uint32, summaryIDs []ids.ID) {
+	s.countIfEqual(nodeID)
+	s.Sender.SendAcceptedStateSummary(ctx, nodeID, requestID, summaryIDs)
+}
+
+func (s *countingSender) SendGetAcceptedFrontier(ctx context.Context, nodeIDs set.Set[ids.NodeID], requestID uint32) {
+	s.countIfContains(nodeIDs)
+	s.Sender.SendGetAcceptedFrontier(ctx, nodeIDs, requestID)
+}
+
+func (s *countingSender) SendAcceptedFrontier(ctx context.Context, nodeID ids.NodeID, requestID uint32, containerID ids.ID) {
+	s.countIfEqual(nodeID)
+	s.Sender.SendAcceptedFrontier(ctx, nodeID, requestID, containerID)
+}
+
+func (s *countingSender) SendGetAccepted(ctx context.Context, nodeIDs set.Set[ids.NodeID], requestID uint32, containerIDs []ids.ID) {
+	s.countIfContains(nodeIDs)
+	s.Sender.SendGetAccepted(ctx, nodeIDs, requestID, containerIDs)
+}
+
+func (s *countingSender) SendAccepted(ctx context.Context, nodeID ids.NodeID, requestID uint32, containerIDs []ids.ID) {
+	s.countIfEqual(nodeID)
+	s.Sender.SendAccepted(ctx, nodeID, requestID, containerIDs)
+}
+
+func (s *countingSender) SendGetAncestors(ctx context.Context, nodeID ids.NodeID, requestID uint32, containerID ids.ID) {
+	s.countIfEqual(nodeID)
+	s.Sender.SendGetAncestors(ctx, nodeID, requestID, containerID)
+}
+
+func (s *countingSender) SendGetAncestorsAtHeight(ctx context.Context, nodeID ids.NodeID, requestID uint32, height uint64) {
+	s.countIfEqual(nodeID)
+	s.Sender.SendGetAncestorsAtHeight(ctx, nodeID, requestID, height)
+}
+
+func (s *countingSender) SendGet(ctx context.Context, nodeID ids.NodeID, requestID uint32, containerID ids.ID) {
+	s.countIfEqual(nodeID)
+	s.Sender.SendGet(ctx, nodeID, requestID, containerID)
+}
+
+func (s *countingSender) SendPushQuery(ctx context.Context, nodeIDs set.Set[ids.NodeID], requestID uint32, container []byte, requestedHeight uint64) {
+	s.countIfContains(nodeIDs)
+	s.Sender.SendPushQuery(ctx, nodeIDs, requestID, container, requestedHeight)
+}
+
+func (s *countingSender) SendPullQuery(ctx context.Context, nodeIDs set.Set[ids.NodeID], requestID uint32, containerID ids.ID, requestedHeight uint64) {
+	s.countIfContains(nodeIDs)
+	s.Sender.SendPullQuery(ctx, nodeIDs, requestID, containerID, requestedHeight)
+}
+
+func (s *countingSender) SendChits(ctx context.Context, nodeID ids.NodeID, requestID uint32, preferredID ids.ID, preferredIDAtHeight ids.ID, acceptedID ids.ID) {
+	s.countIfEqual(nodeID)
+	s.Sender.SendChits(ctx, nodeID, requestID, preferredID, preferredIDAtHeight, acceptedID)
+}
+
+// selfRouter hands the messages that a node sends to itself to the simulator
+// rather than to the node's router. Because the sender delivers these messages
+// concurrently, the simulator must order them before they are delivered.
+type selfRouter struct {
+	router.Router
+	node *node
+}
+
+func (r *selfRouter) HandleInbound(_ context.Context, msg message.InboundMessage) {
+	r.node.sim.sendToSelf(r.node, msg)
+}
+
+// validatorManager samples validators using a seeded source of randomness.
+type validatorManager struct {
+	validators.Manager
+	rng *rand.Rand
+}
+
+func (m *validatorManager) Sample(subnetID ids.ID, size int) ([]ids.NodeID, error) {
+	if size == 0 {
+		return nil, nil
+	}
+
+	vdrs := m.GetMap(subnetID)
+	nodeIDs := make([]ids.NodeID, 0, len(vdrs))
+	for nodeID := range vdrs {
+		nodeIDs = append(nodeIDs, nodeID)
+	}
+	utils.Sort(nodeIDs)
+
+	weights := make([]uint64, len(nodeIDs))
+	for i, nodeID := range nodeIDs {
+		weights[i] = vdrs[nodeID].Weight
+	}
+
+	s := sampler.NewDeterministicWeightedWithoutReplacement(m.rng)
+	if err := s.Initialize(weights); err != nil {
+		return nil, err
+	}
+	indices, err := s.Sample(size)
+	if err != nil {
+		return nil, err
+	}
+
+	sampled := make([]ids.NodeID, len(indices))
+	for i, index := range indices {
+		sampled[i] = nodeIDs[index]
+	}
+	return sampled, nil
+}
+
+// peers samples connected validators using a seeded source of randomness.
+type peers struct {
+	tracker.Peers
+	rng *rand.Rand
+}
+
+func (p *peers) SampleValidator() (ids.NodeID, bool) {
+	if p.ConnectedWeight() == 0 {
+		return ids.EmptyNodeID, false
+	}
+	return sample(p.PreferredPeers(), p.rng)
+}
+
+// startup only ever prefers a single, randomly sampled, peer. This ensures the
+// bootstrapper fetches blocks from peers in a deterministic order.
+type startup struct {
+	tracker.Startup
+	rng *rand.Rand
+}
+
+func (s *startup) PreferredPeers() set.Set[ids.NodeID] {
+	nodeID, ok := sample(s.Startup.PreferredPeers(), s.rng)
+	if !ok {
+		return nil
+	}
+	return set.Of(nodeID)
+}
+
+func sample(nodeIDs set.Set[ids.NodeID], rng *rand.Rand) (ids.NodeID, bool) {
+	if nodeIDs.Len() == 0 {
+		return ids.EmptyNodeID, false
+	}
+	sorted := nodeIDs.List()
+	utils.Sort(sorted)
+	return sorted[rng.Intn(len(sorted))], true
+}
+
+// resourceTracker reports that no resources are ever used so that the handler
+// never reorders messages based on how long they took to process.
+type resourceTracker struct{}
+
+func (resourceTracker) CPUTracker() nettracker.Tracker {
+	return resourceTracker{}
+}
+
+func (resourceTracker) DiskTracker() nettracker.DiskTracker {
+	return resourceTracker{}
+}
+
+func (resourceTracker) StartProcessing(ids.NodeID, time.Time) {}
+
+func (resourceTracker) StopProcessing(ids.NodeID, time.Time) {}
+
+func (resourceTracker) Usage(ids.NodeID, time.Time) float64 {
+	return 0
+}
+
+func (resourceTracker) TotalUsage() float64 {
+	return 0
+}
+
+func (resourceTracker) TimeUntilUsage(ids.NodeID, time.Time, float64) time.Duration {
+	return 0
+}
+
+func (resourceTracker) AvailableDiskBytes() uint64 {
+	return math.MaxUint64
+}
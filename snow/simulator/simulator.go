@@ -0,0 +1,754 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+// Package simulator runs many full snowman nodes in a single process over a
+// virtual network.
+//
+// Every node runs the production engine, bootstrapper, handler, router and
+// sender. Only the edges of the node are replaced: the network, the timeout
+// manager and the VM. Time is virtual and only advances when the next event is
+// processed, so a simulation of minutes of network time completes in
+// milliseconds.
+//
+// Events are processed one at a time. After each event, the simulator waits
+// for every node to finish handling all of the messages caused by the event
+// before any of the newly sent messages are scheduled. All random decisions,
+// including the latency and loss of every message, the clock skew of every
+// node and the validators sampled by every engine, are drawn from a source
+// seeded by [Config.Seed]. Running a simulation twice with the same seed and
+// the same inputs produces the same trace.
+package simulator
+
+import (
+	"bytes"
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"math/rand"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/message"
+	"github.com/ava-labs/avalanchego/snow/consensus/snowball"
+	"github.com/ava-labs/avalanchego/snow/engine/common"
+	"github.com/ava-labs/avalanchego/utils"
+	"github.com/ava-labs/avalanchego/utils/compression"
+	"github.com/ava-labs/avalanchego/utils/heap"
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/ava-labs/avalanchego/utils/set"
+)
+
+const maxMessageTimeout = 10 * time.Second
+
+var (
+	// StartTime is the simulated time that every simulation starts at. It is
+	// also the timestamp of the genesis block.
+	StartTime = time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+	// DefaultConfig is a 5 node network with moderate latency and no faults.
+	DefaultConfig = Config{
+		NumNodes: 5,
+		Params: snowball.Parameters{
+			K:                     5,
+			AlphaPreference:       3,
+			AlphaConfidence:       4,
+			BetaVirtuous:          4,
+			BetaRogue:             8,
+			ConcurrentRepolls:     4,
+			OptimalProcessing:     10,
+			MaxOutstandingItems:   256,
+			MaxItemProcessingTime: 30 * time.Second,
+		},
+		MinLatency:      10 * time.Millisecond,
+		MaxLatency:      100 * time.Millisecond,
+		MaxBlockDrift:   10 * time.Second,
+		RequestTimeout:  2 * time.Second,
+		GossipFrequency: time.Second,
+	}
+
+	errInvalidNumNodes       = errors.New("invalid number of nodes")
+	errInvalidLatency        = errors.New("invalid latency")
+	errInvalidDropRate       = errors.New("invalid drop rate")
+	errInvalidRequestTimeout = errors.New("invalid request timeout")
+	errUnknownNode           = errors.New("unknown node")
+	errDuplicateNode         = errors.New("node specified in multiple partitions")
+	errNodeStopped           = errors.New("node stopped unexpectedly")
+	errConflictingBlocks     = errors.New("conflicting blocks accepted")
+	errShutdown              = errors.New("simulator shutdown")
+)
+
+type Config struct {
+	// Seed determines every random decision made during the simulation.
+	Seed int64
+	// NumNodes is the number of equally weighted validators in the network.
+	NumNodes int
+	// Params are the consensus parameters used by every node.
+	Params snowball.Parameters
+
+	// MinLatency and MaxLatency bound the delay of every message sent between
+	// two different nodes. Each delay is drawn uniformly from the range.
+	MinLatency time.Duration
+	MaxLatency time.Duration
+	// DropRate is the probability that a message sent between two different
+	// nodes is lost.
+	DropRate float64
+	// MaxClockSkew bounds the initial offset of each node's clock from the
+	// simulated time. Each offset is drawn uniformly from
+	// [-MaxClockSkew, MaxClockSkew].
+	MaxClockSkew time.Duration
+	// MaxBlockDrift is how far ahead of its local clock a node will allow the
+	// timestamp of a block to be.
+	MaxBlockDrift time.Duration
+
+	// RequestTimeout is how long a node waits for a response to a request.
+	RequestTimeout time.Duration
+	// GossipFrequency is how often each node gossips its preference. If 0,
+	// nodes will not gossip.
+	GossipFrequency time.Duration
+
+	// Log is used by every node. Defaults to [logging.NoLog].
+	Log logging.Logger
+}
+
+func (c *Config) verify() error {
+	switch {
+	case c.NumNodes <= 0:
+		return fmt.Errorf("%w: %d", errInvalidNumNodes, c.NumNodes)
+	case c.MinLatency < 0 || c.MaxLatency < c.MinLatency:
+		return fmt.Errorf("%w: [%s, %s]", errInvalidLatency, c.MinLatency, c.MaxLatency)
+	case c.DropRate < 0 || c.DropRate > 1:
+		return fmt.Errorf("%w: %f", errInvalidDropRate, c.DropRate)
+	case c.RequestTimeout <= 0:
+		return fmt.Errorf("%w: %s", errInvalidRequestTimeout, c.RequestTimeout)
+	default:
+		return c.Params.Verify()
+	}
+}
+
+// Event describes a message that was delivered, or lost, by the simulated
+// network.
+type Event struct {
+	Time    time.Time
+	From    ids.NodeID
+	To      ids.NodeID
+	Op      message.Op
+	Dropped bool
+}
+
+func (e Event) String() string {
+	status := "delivered"
+	if e.Dropped {
+		status = "dropped"
+	}
+	return fmt.Sprintf("%s %s %s -> %s %s",
+		e.Time.Sub(StartTime),
+		e.Op,
+		e.From,
+		e.To,
+		status,
+	)
+}
+
+type event struct {
+	time time.Time
+	seq  uint64
+	f    func() error
+}
+
+func (e *event) Less(other *event) bool {
+	if !e.time.Equal(other.time) {
+		return e.time.Before(other.time)
+	}
+	return e.seq < other.seq
+}
+
+type outboundMessage struct {
+	from  ids.NodeID
+	to    ids.NodeID
+	op    message.Op
+	bytes []byte
+}
+
+func (m *outboundMessage) Compare(other *outboundMessage) int {
+	if c := m.from.Compare(other.from); c != 0 {
+		return c
+	}
+	if c := m.to.Compare(other.to); c != 0 {
+		return c
+	}
+	return bytes.Compare(m.bytes, other.bytes)
+}
+
+// selfMessage is a message that a node sent to itself.
+type selfMessage struct {
+	node *node
+	msg  message.InboundMessage
+	// key is a canonical encoding of [msg] used to order the messages.
+	key []byte
+}
+
+func newSelfMessage(n *node, msg message.InboundMessage) *selfMessage {
+	var key []byte
+	if protoMsg, ok := msg.Message().(proto.Message); ok {
+		// Errors are ignored because the key is only used for ordering.
+		key, _ = proto.MarshalOptions{Deterministic: true}.Marshal(protoMsg)
+	}
+	return &selfMessage{
+		node: n,
+		msg:  msg,
+		key:  key,
+	}
+}
+
+func (m *selfMessage) Compare(other *selfMessage) int {
+	if c := m.node.id.Compare(other.node.id); c != 0 {
+		return c
+	}
+	if c := cmp.Compare(m.msg.Op(), other.msg.Op()); c != 0 {
+		return c
+	}
+	return bytes.Compare(m.key, other.key)
+}
+
+type Simulator struct {
+	config     Config
+	log        logging.Logger
+	rng        *rand.Rand
+	msgCreator message.Creator
+	genesis    *Block
+
+	nodes     []*node
+	nodesByID map[ids.NodeID]*node
+
+	// The following fields are only accessed by the goroutine driving the
+	// simulation.
+	now      time.Time
+	events   heap.Queue[*event]
+	nextSeq  uint64
+	trace    []Event
+	err      error
+	shutdown bool
+
+	// pending counts the messages that have been handed to nodes but haven't
+	// finished being handled.
+	pending pendingWork
+
+	// lock protects the work scheduled by nodes while they are handling
+	// messages. This work is only added to [events] once the network is
+	// quiescent, in a canonical order, so that the order in which nodes
+	// happened to perform the work doesn't impact the simulation.
+	lock        sync.Mutex
+	outbox      []*outboundMessage
+	selfOutbox  []*selfMessage
+	newTimeouts []*timeoutEntry
+	newTimers   []*timerEntry
+}
+
+// New creates and bootstraps a network of nodes described by [config].
+func New(config Config) (*Simulator, error) {
+	if err := config.verify(); err != nil {
+		return nil, err
+	}
+	log := config.Log
+	if log == nil {
+		log = logging.NoLog{}
+	}
+
+	msgCreator, err := message.NewCreator(
+		log,
+		prometheus.NewRegistry(),
+		"",
+		compression.TypeNone,
+		maxMessageTimeout,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	genesis, err := newBlock(ids.Empty, 0, StartTime, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	s := &Simulator{
+		config:     config,
+		log:        log,
+		rng:        rand.New(rand.NewSource(config.Seed)), // #nosec G404
+		msgCreator: msgCreator,
+		genesis:    genesis,
+		nodesByID:  make(map[ids.NodeID]*node, config.NumNodes),
+		now:        StartTime,
+		events:     heap.NewQueue((*event).Less),
+	}
+	s.pending.cond = sync.NewCond(&s.pending.lock)
+
+	nodeIDs := make([]ids.NodeID, 0, config.NumNodes)
+	for len(nodeIDs) < config.NumNodes {
+		var nodeID ids.NodeID
+		_, _ = s.rng.Read(nodeID[:])
+		if _, ok := s.nodesByID[nodeID]; ok {
+			continue
+		}
+		s.nodesByID[nodeID] = nil
+		nodeIDs = append(nodeIDs, nodeID)
+	}
+	utils.Sort(nodeIDs)
+
+	for _, nodeID := range nodeIDs {
+		var skew time.Duration
+		if config.MaxClockSkew > 0 {
+			skew = time.Duration(s.rng.Int63n(2*int64(config.MaxClockSkew)+1)) - config.MaxClockSkew
+		}
+		n, err := newNode(s, nodeID, nodeIDs, skew, s.rng.Int63())
+		if err != nil {
+			s.Shutdown()
+			return nil, fmt.Errorf("failed to create node %s: %w", nodeID, err)
+		}
+		s.nodes = append(s.nodes, n)
+		s.nodesByID[nodeID] = n
+	}
+
+	for _, n := range s.nodes {
+		n.start()
+	}
+	// Nodes are connected to themselves before any of their peers, which
+	// mirrors the real network.
+	for _, n := range s.nodes {
+		n.connect(n.id)
+		for _, peer := range s.nodes {
+			if peer != n {
+				n.connect(peer.id)
+			}
+		}
+	}
+	if err := s.settle(); err != nil {
+		s.Shutdown()
+		return nil, err
+	}
+
+	if config.GossipFrequency > 0 {
+		for _, n := range s.nodes {
+			offset := time.Duration(s.rng.Int63n(int64(config.GossipFrequency)))
+			s.scheduleGossip(n, offset)
+		}
+	}
+	return s, nil
+}
+
+// NodeIDs returns the IDs of the nodes in the network, in sorted order.
+func (s *Simulator) NodeIDs() []ids.NodeID {
+	nodeIDs := make([]ids.NodeID, len(s.nodes))
+	for i, n := range s.nodes {
+		nodeIDs[i] = n.id
+	}
+	return nodeIDs
+}
+
+// Now returns the current simulated time.
+func (s *Simulator) Now() time.Time {
+	return s.now
+}
+
+// Trace returns every message sent between nodes so far, in the order they
+// were delivered or dropped.
+func (s *Simulator) Trace() []Event {
+	return append([]Event(nil), s.trace...)
+}
+
+// Issue adds [tx] to the mempool of [nodeID]. The node will attempt to build
+// a block containing it the next time the simulation runs.
+func (s *Simulator) Issue(nodeID ids.NodeID, tx []byte) error {
+	n, err := s.getNode(nodeID)
+	if err != nil {
+		return err
+	}
+	n.vm.issue(tx)
+	return s.settle()
+}
+
+// Partition splits the network into [groups]. Nodes in different groups are
+// disconnected from each other and all messages between them are lost. Nodes
+// that aren't included in any group form an additional group.
+func (s *Simulator) Partition(groups ...[]ids.NodeID) error {
+	newGroups := make(map[ids.NodeID]int, len(s.nodes))
+	for i, group := range groups {
+		for _, nodeID := range group {
+			if _, ok := s.nodesByID[nodeID]; !ok {
+				return fmt.Errorf("%w: %s", errUnknownNode, nodeID)
+			}
+			if _, ok := newGroups[nodeID]; ok {
+				return fmt.Errorf("%w: %s", errDuplicateNode, nodeID)
+			}
+			newGroups[nodeID] = i + 1
+		}
+	}
+	for _, n := range s.nodes {
+		n.group = newGroups[n.id]
+	}
+	s.updateConnections()
+	return s.settle()
+}
+
+// Heal removes all partitions.
+func (s *Simulator) Heal() error {
+	return s.Partition()
+}
+
+// SetClockSkew sets the offset of [nodeID]'s clock from the simulated time.
+func (s *Simulator) SetClockSkew(nodeID ids.NodeID, skew time.Duration) error {
+	n, err := s.getNode(nodeID)
+	if err != nil {
+		return err
+	}
+	n.setSkew(skew)
+	return nil
+}
+
+// SetDropRate sets the probability that a message sent between two different
+// nodes is lost.
+func (s *Simulator) SetDropRate(dropRate float64) error {
+	if dropRate < 0 || dropRate > 1 {
+		return fmt.Errorf("%w: %f", errInvalidDropRate, dropRate)
+	}
+	s.config.DropRate = dropRate
+	return nil
+}
+
+// RunFor processes events until [duration] of simulated time has passed.
+func (s *Simulator) RunFor(duration time.Duration) error {
+	_, err := s.RunUntil(func() bool { return false }, duration)
+	return err
+}
+
+// RunUntil processes events until [done] returns true or until [timeout] of
+// simulated time has passed. Returns true if [done] returned true.
+func (s *Simulator) RunUntil(done func() bool, timeout time.Duration) (bool, error) {
+	if s.err != nil {
+		return false, s.err
+	}
+
+	end := s.now.Add(timeout)
+	for !done() {
+		next, ok := s.events.Peek()
+		if !ok || next.time.After(end) {
+			s.now = end
+			return done(), nil
+		}
+		_, _ = s.events.Pop()
+
+		s.now = next.time
+		if err := next.f(); err != nil {
+			s.err = err
+			return false, err
+		}
+		if err := s.settle(); err != nil {
+			s.err = err
+			return false, err
+		}
+	}
+	return true, nil
+}
+
+// Accepted returns the IDs of the blocks accepted by [nodeID], indexed by
+// height.
+func (s *Simulator) Accepted(nodeID ids.NodeID) ([]ids.ID, error) {
+	n, err := s.getNode(nodeID)
+	if err != nil {
+		return nil, err
+	}
+	return n.vm.acceptedIDs(), nil
+}
+
+// AcceptedTx returns true if [nodeID] has accepted a block containing [tx].
+func (s *Simulator) AcceptedTx(nodeID ids.NodeID, tx []byte) (bool, error) {
+	n, err := s.getNode(nodeID)
+	if err != nil {
+		return false, err
+	}
+	return n.vm.isAccepted(tx), nil
+}
+
+// VerifySafety returns an error if any two nodes accepted different blocks at
+// the same height.
+func (s *Simulator) VerifySafety() error {
+	var longest []ids.ID
+	for _, n := range s.nodes {
+		accepted := n.vm.acceptedIDs()
+		for height, blkID := range accepted {
+			if height >= len(longest) {
+				longest = append(longest, blkID)
+				continue
+			}
+			if expected := longest[height]; blkID != expected {
+				return fmt.Errorf("%w: %s accepted %s at height %d but %s was accepted by another node",
+					errConflictingBlocks,
+					n.id,
+					blkID,
+					height,
+					expected,
+				)
+			}
+		}
+	}
+	return nil
+}
+
+// Shutdown stops every node. The simulator can't be used after it is shutdown.
+func (s *Simulator) Shutdown() {
+	if s.shutdown {
+		return
+	}
+	s.shutdown = true
+	if s.err == nil {
+		s.err = errShutdown
+	}
+	for _, n := range s.nodes {
+		n.shutdown()
+	}
+}
+
+func (s *Simulator) getNode(nodeID ids.NodeID) (*node, error) {
+	n, ok := s.nodesByID[nodeID]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", errUnknownNode, nodeID)
+	}
+	return n, nil
+}
+
+func (s *Simulator) schedule(delay time.Duration, f func() error) {
+	s.events.Push(&event{
+		time: s.now.Add(delay),
+		seq:  s.nextSeq,
+		f:    f,
+	})
+	s.nextSeq++
+}
+
+func (s *Simulator) scheduleGossip(n *node, delay time.Duration) {
+	s.schedule(delay, func() error {
+		s.scheduleGossip(n, s.config.GossipFrequency)
+		return n.withEngine(func(engine common.Engine) error {
+			return engine.Gossip(context.Background())
+		})
+	})
+}
+
+// settle waits for every node to finish handling its messages and then
+// schedules all of the work that was produced.
+func (s *Simulator) settle() error {
+	s.pending.wait()
+
+	s.lock.Lock()
+	outbox := s.outbox
+	selfOutbox := s.selfOutbox
+	newTimeouts := s.newTimeouts
+	newTimers := s.newTimers
+	s.outbox = nil
+	s.selfOutbox = nil
+	s.newTimeouts = nil
+	s.newTimers = nil
+	s.lock.Unlock()
+
+	for _, n := range s.nodes {
+		if n.stopped.Get() {
+			return fmt.Errorf("%w: %s", errNodeStopped, n.id)
+		}
+	}
+
+	utils.Sort(outbox)
+	for _, msg := range outbox {
+		s.scheduleMessage(msg)
+	}
+
+	utils.Sort(selfOutbox)
+	for _, msg := range selfOutbox {
+		msg := msg
+		s.schedule(0, func() error {
+			s.trace = append(s.trace, Event{
+				Time: s.now,
+				From: msg.node.id,
+				To:   msg.node.id,
+				Op:   msg.msg.Op(),
+			})
+			msg.node.router.HandleInbound(context.Background(), msg.msg)
+			return nil
+		})
+	}
+
+	utils.Sort(newTimeouts)
+	for _, entry := range newTimeouts {
+		s.scheduleTimeout(entry)
+	}
+
+	utils.Sort(newTimers)
+	for _, entry := range newTimers {
+		n := entry.node
+		s.schedule(entry.delay, func() error {
+			return n.withEngine(func(engine common.Engine) error {
+				return engine.Timeout(context.Background())
+			})
+		})
+	}
+
+	for _, n := range s.nodes {
+		if !n.shouldNotify() {
+			continue
+		}
+		n := n
+		s.schedule(0, func() error {
+			return n.withEngine(func(engine common.Engine) error {
+				return engine.Notify(context.Background(), common.PendingTxs)
+			})
+		})
+	}
+	return nil
+}
+
+func (s *Simulator) scheduleMessage(msg *outboundMessage) {
+	from := s.nodesByID[msg.from]
+	to := s.nodesByID[msg.to]
+
+	// Messages to ourself never go over the network.
+	if from == to {
+		s.schedule(0, func() error {
+			return s.deliver(from, to, msg)
+		})
+		return
+	}
+
+	if !from.isConnected(to) || s.rng.Float64() < s.config.DropRate {
+		s.drop(msg)
+		return
+	}
+
+	latency := s.config.MinLatency
+	if jitter := s.config.MaxLatency - s.config.MinLatency; jitter > 0 {
+		latency += time.Duration(s.rng.Int63n(int64(jitter) + 1))
+	}
+	s.schedule(latency, func() error {
+		// The nodes may have been partitioned while the message was in flight.
+		if !from.isConnected(to) {
+			s.drop(msg)
+			return nil
+		}
+		return s.deliver(from, to, msg)
+	})
+}
+
+func (s *Simulator) deliver(from, to *node, msg *outboundMessage) error {
+	s.trace = append(s.trace, Event{
+		Time: s.now,
+		From: from.id,
+		To:   to.id,
+		Op:   msg.op,
+	})
+
+	inMsg, err := s.msgCreator.Parse(msg.bytes, from.id, nil)
+	if err != nil {
+		return fmt.Errorf("failed to parse %s from %s: %w", msg.op, from.id, err)
+	}
+	to.router.HandleInbound(context.Background(), inMsg)
+	return nil
+}
+
+func (s *Simulator) drop(msg *outboundMessage) {
+	s.trace = append(s.trace, Event{
+		Time:    s.now,
+		From:    msg.from,
+		To:      msg.to,
+		Op:      msg.op,
+		Dropped: true,
+	})
+}
+
+func (s *Simulator) scheduleTimeout(entry *timeoutEntry) {
+	s.schedule(s.config.RequestTimeout, func() error {
+		if !entry.node.timeouts.remove(entry.requestID) {
+			return nil
+		}
+		entry.handler()
+		return nil
+	})
+}
+
+// updateConnections connects and disconnects nodes to match their partitions.
+func (s *Simulator) updateConnections() {
+	for _, n := range s.nodes {
+		for _, peer := range s.nodes {
+			if n == peer {
+				continue
+			}
+			shouldBeConnected := n.group == peer.group
+			isConnected := n.connected.Contains(peer.id)
+			switch {
+			case shouldBeConnected && !isConnected:
+				n.connect(peer.id)
+			case !shouldBeConnected && isConnected:
+				n.disconnect(peer.id)
+			}
+		}
+	}
+}
+
+// send is called by nodes to send [msg] to [nodeIDs].
+func (s *Simulator) send(from ids.NodeID, nodeIDs set.Set[ids.NodeID], msg message.OutboundMessage) set.Set[ids.NodeID] {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	sentTo := set.NewSet[ids.NodeID](nodeIDs.Len())
+	for nodeID := range nodeIDs {
+		if _, ok := s.nodesByID[nodeID]; !ok {
+			continue
+		}
+		s.outbox = append(s.outbox, &outboundMessage{
+			from:  from,
+			to:    nodeID,
+			op:    msg.Op(),
+			bytes: msg.Bytes(),
+		})
+		sentTo.Add(nodeID)
+	}
+	return sentTo
+}
+
+// sendToSelf is called by nodes to send [msg] to themselves. The caller must
+// have counted [msg] as pending.
+func (s *Simulator) sendToSelf(n *node, msg message.InboundMessage) {
+	s.lock.Lock()
+	s.selfOutbox = append(s.selfOutbox, newSelfMessage(n, msg))
+	s.lock.Unlock()
+
+	s.pending.add(-1)
+}
+
+// pendingWork counts the messages that have been handed to nodes but haven't
+// finished being handled.
+type pendingWork struct {
+	lock  sync.Mutex
+	cond  *sync.Cond
+	count int
+}
+
+func (p *pendingWork) add(delta int) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	p.count += delta
+	if p.count <= 0 {
+		p.cond.Broadcast()
+	}
+}
+
+func (p *pendingWork) wait() {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	for p.count > 0 {
+		p.cond.Wait()
+	}
+}
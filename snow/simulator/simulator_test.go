@@ -0,0 +1,276 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package simulator
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/ids"
+)
+
+func newTestSimulator(t *testing.T, config Config) *Simulator {
+	require := require.New(t)
+
+	s, err := New(config)
+	require.NoError(err)
+	t.Cleanup(s.Shutdown)
+	return s
+}
+
+// allAccepted returns a function that reports whether every node accepted
+// [tx].
+func allAccepted(t *testing.T, s *Simulator, tx []byte) func() bool {
+	return func() bool {
+		for _, nodeID := range s.NodeIDs() {
+			accepted, err := s.AcceptedTx(nodeID, tx)
+			require.NoError(t, err)
+			if !accepted {
+				return false
+			}
+		}
+		return true
+	}
+}
+
+func TestConfigVerify(t *testing.T) {
+	tests := []struct {
+		name        string
+		config      func(*Config)
+		expectedErr error
+	}{
+		{
+			name:        "valid",
+			config:      func(*Config) {},
+			expectedErr: nil,
+		},
+		{
+			name: "no nodes",
+			config: func(c *Config) {
+				c.NumNodes = 0
+			},
+			expectedErr: errInvalidNumNodes,
+		},
+		{
+			name: "negative latency",
+			config: func(c *Config) {
+				c.MinLatency = -time.Second
+			},
+			expectedErr: errInvalidLatency,
+		},
+		{
+			name: "inverted latency",
+			config: func(c *Config) {
+				c.MinLatency = time.Second
+				c.MaxLatency = time.Millisecond
+			},
+			expectedErr: errInvalidLatency,
+		},
+		{
+			name: "drop rate too high",
+			config: func(c *Config) {
+				c.DropRate = 1.5
+			},
+			expectedErr: errInvalidDropRate,
+		},
+		{
+			name: "no request timeout",
+			config: func(c *Config) {
+				c.RequestTimeout = 0
+			},
+			expectedErr: errInvalidRequestTimeout,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			config := DefaultConfig
+			test.config(&config)
+			err := config.verify()
+			require.ErrorIs(t, err, test.expectedErr)
+		})
+	}
+}
+
+func TestAcceptTx(t *testing.T) {
+	require := require.New(t)
+
+	s := newTestSimulator(t, DefaultConfig)
+	nodeIDs := s.NodeIDs()
+	require.Len(nodeIDs, DefaultConfig.NumNodes)
+
+	tx := []byte("tx")
+	require.NoError(s.Issue(nodeIDs[0], tx))
+
+	done, err := s.RunUntil(allAccepted(t, s, tx), time.Minute)
+	require.NoError(err)
+	require.True(done)
+	require.NoError(s.VerifySafety())
+
+	for _, nodeID := range nodeIDs {
+		accepted, err := s.Accepted(nodeID)
+		require.NoError(err)
+		require.Len(accepted, 2)
+	}
+}
+
+func TestDeterministic(t *testing.T) {
+	require := require.New(t)
+
+	config := DefaultConfig
+	config.Seed = 1337
+	config.DropRate = .1
+	config.MaxClockSkew = time.Second
+
+	run := func() ([]Event, [][]ids.ID) {
+		s := newTestSimulator(t, config)
+		nodeIDs := s.NodeIDs()
+		for i := 0; i < 10; i++ {
+			nodeID := nodeIDs[i%len(nodeIDs)]
+			require.NoError(s.Issue(nodeID, []byte{byte(i)}))
+			require.NoError(s.RunFor(500 * time.Millisecond))
+		}
+		require.NoError(s.RunFor(time.Minute))
+		require.NoError(s.VerifySafety())
+
+		accepted := make([][]ids.ID, len(nodeIDs))
+		for i, nodeID := range nodeIDs {
+			var err error
+			accepted[i], err = s.Accepted(nodeID)
+			require.NoError(err)
+		}
+		return s.Trace(), accepted
+	}
+
+	expectedTrace, expectedAccepted := run()
+	require.NotEmpty(expectedTrace)
+	for i := 0; i < 3; i++ {
+		trace, accepted := run()
+		require.Equal(expectedTrace, trace)
+		require.Equal(expectedAccepted, accepted)
+	}
+}
+
+func TestDifferentSeeds(t *testing.T) {
+	require := require.New(t)
+
+	run := func(seed int64) []Event {
+		config := DefaultConfig
+		config.Seed = seed
+		s := newTestSimulator(t, config)
+		require.NoError(s.Issue(s.NodeIDs()[0], []byte("tx")))
+		require.NoError(s.RunFor(10 * time.Second))
+		return s.Trace()
+	}
+	require.NotEqual(run(1), run(2))
+}
+
+func TestPartition(t *testing.T) {
+	require := require.New(t)
+
+	s := newTestSimulator(t, DefaultConfig)
+	nodeIDs := s.NodeIDs()
+
+	// Neither side of the partition has enough stake to make progress.
+	require.NoError(s.Partition(nodeIDs[:2], nodeIDs[2:]))
+
+	tx := []byte("tx")
+	require.NoError(s.Issue(nodeIDs[0], tx))
+	require.NoError(s.Issue(nodeIDs[4], tx))
+
+	done, err := s.RunUntil(func() bool {
+		for _, nodeID := range nodeIDs {
+			accepted, err := s.AcceptedTx(nodeID, tx)
+			require.NoError(err)
+			if accepted {
+				return true
+			}
+		}
+		return false
+	}, time.Minute)
+	require.NoError(err)
+	require.False(done)
+	require.NoError(s.VerifySafety())
+
+	require.NoError(s.Heal())
+	done, err = s.RunUntil(allAccepted(t, s, tx), 5*time.Minute)
+	require.NoError(err)
+	require.True(done)
+	require.NoError(s.VerifySafety())
+}
+
+func TestPartitionErrors(t *testing.T) {
+	require := require.New(t)
+
+	s := newTestSimulator(t, DefaultConfig)
+	nodeIDs := s.NodeIDs()
+
+	err := s.Partition(nodeIDs[:2], nodeIDs[1:])
+	require.ErrorIs(err, errDuplicateNode)
+
+	err = s.Partition([]ids.NodeID{ids.GenerateTestNodeID()})
+	require.ErrorIs(err, errUnknownNode)
+}
+
+func TestDroppedMessages(t *testing.T) {
+	require := require.New(t)
+
+	config := DefaultConfig
+	config.DropRate = .2
+	s := newTestSimulator(t, config)
+
+	tx := []byte("tx")
+	require.NoError(s.Issue(s.NodeIDs()[0], tx))
+
+	done, err := s.RunUntil(allAccepted(t, s, tx), 5*time.Minute)
+	require.NoError(err)
+	require.True(done)
+	require.NoError(s.VerifySafety())
+
+	var numDropped int
+	for _, event := range s.Trace() {
+		if event.Dropped {
+			numDropped++
+		}
+	}
+	require.Positive(numDropped)
+}
+
+func TestClockSkew(t *testing.T) {
+	require := require.New(t)
+
+	s := newTestSimulator(t, DefaultConfig)
+	nodeIDs := s.NodeIDs()
+
+	// Blocks built by the skewed node are too far in the future for the rest
+	// of the network to verify.
+	require.NoError(s.SetClockSkew(nodeIDs[0], time.Hour))
+
+	tx := []byte("tx")
+	require.NoError(s.Issue(nodeIDs[0], tx))
+
+	done, err := s.RunUntil(allAccepted(t, s, tx), time.Minute)
+	require.NoError(err)
+	require.False(done)
+
+	// Once the clock is corrected, the tx can be included in a valid block.
+	require.NoError(s.SetClockSkew(nodeIDs[0], 0))
+	require.NoError(s.Issue(nodeIDs[1], tx))
+
+	done, err = s.RunUntil(allAccepted(t, s, tx), time.Minute)
+	require.NoError(err)
+	require.True(done)
+	require.NoError(s.VerifySafety())
+}
+
+func TestShutdown(t *testing.T) {
+	require := require.New(t)
+
+	s := newTestSimulator(t, DefaultConfig)
+	s.Shutdown()
+
+	err := s.RunFor(time.Second)
+	require.ErrorIs(err, errShutdown)
+}
@@ -0,0 +1,148 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package simulator
+
+import (
+	"cmp"
+	"sync"
+	"time"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/message"
+	"github.com/ava-labs/avalanchego/snow"
+	"github.com/ava-labs/avalanchego/snow/engine/common"
+	"github.com/ava-labs/avalanchego/snow/networking/timeout"
+)
+
+var (
+	_ timeout.Manager = (*timeoutManager)(nil)
+	_ common.Timer    = (*timer)(nil)
+)
+
+// timeoutManager reports request timeouts to the simulator so that they fire
+// at a simulated time.
+type timeoutManager struct {
+	node *node
+
+	lock     sync.Mutex
+	requests map[ids.RequestID]struct{}
+}
+
+func (*timeoutManager) Dispatch() {}
+
+func (m *timeoutManager) TimeoutDuration() time.Duration {
+	return m.node.sim.config.RequestTimeout
+}
+
+func (*timeoutManager) IsBenched(ids.NodeID, ids.ID) bool {
+	return false
+}
+
+func (*timeoutManager) RegisterChain(*snow.ConsensusContext) error {
+	return nil
+}
+
+func (m *timeoutManager) RegisterRequest(
+	_ ids.NodeID,
+	_ ids.ID,
+	_ bool,
+	requestID ids.RequestID,
+	timeoutHandler func(),
+) {
+	m.lock.Lock()
+	m.requests[requestID] = struct{}{}
+	m.lock.Unlock()
+
+	sim := m.node.sim
+	sim.lock.Lock()
+	defer sim.lock.Unlock()
+
+	sim.newTimeouts = append(sim.newTimeouts, &timeoutEntry{
+		node:      m.node,
+		requestID: requestID,
+		handler:   timeoutHandler,
+	})
+}
+
+func (*timeoutManager) RegisterRequestToUnreachableValidator() {}
+
+func (m *timeoutManager) RegisterResponse(
+	_ ids.NodeID,
+	_ ids.ID,
+	requestID ids.RequestID,
+	_ message.Op,
+	_ time.Duration,
+) {
+	m.remove(requestID)
+}
+
+func (m *timeoutManager) RemoveRequest(requestID ids.RequestID) {
+	m.remove(requestID)
+}
+
+func (*timeoutManager) Stop() {}
+
+// remove returns true if [requestID] was still outstanding.
+func (m *timeoutManager) remove(requestID ids.RequestID) bool {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	_, ok := m.requests[requestID]
+	delete(m.requests, requestID)
+	return ok
+}
+
+type timeoutEntry struct {
+	node      *node
+	requestID ids.RequestID
+	handler   func()
+}
+
+func (e *timeoutEntry) Compare(other *timeoutEntry) int {
+	if c := e.node.id.Compare(other.node.id); c != 0 {
+		return c
+	}
+	a, b := e.requestID, other.requestID
+	if c := a.NodeID.Compare(b.NodeID); c != 0 {
+		return c
+	}
+	if c := a.SourceChainID.Compare(b.SourceChainID); c != 0 {
+		return c
+	}
+	if c := a.DestinationChainID.Compare(b.DestinationChainID); c != 0 {
+		return c
+	}
+	if c := cmp.Compare(a.RequestID, b.RequestID); c != 0 {
+		return c
+	}
+	return cmp.Compare(a.Op, b.Op)
+}
+
+// timer reports the timeouts requested by the bootstrapper to the simulator.
+type timer struct {
+	node *node
+}
+
+func (t *timer) RegisterTimeout(delay time.Duration) {
+	sim := t.node.sim
+	sim.lock.Lock()
+	defer sim.lock.Unlock()
+
+	sim.newTimers = append(sim.newTimers, &timerEntry{
+		node:  t.node,
+		delay: delay,
+	})
+}
+
+type timerEntry struct {
+	node  *node
+	delay time.Duration
+}
+
+func (e *timerEntry) Compare(other *timerEntry) int {
+	if c := e.node.id.Compare(other.node.id); c != 0 {
+		return c
+	}
+	return cmp.Compare(e.delay, other.delay)
+}
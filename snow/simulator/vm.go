@@ -0,0 +1,445 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package simulator
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow"
+	"github.com/ava-labs/avalanchego/snow/choices"
+	"github.com/ava-labs/avalanchego/snow/consensus/snowman"
+	"github.com/ava-labs/avalanchego/snow/engine/common"
+	"github.com/ava-labs/avalanchego/snow/engine/snowman/block"
+	"github.com/ava-labs/avalanchego/utils/hashing"
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/ava-labs/avalanchego/utils/wrappers"
+	"github.com/ava-labs/avalanchego/version"
+)
+
+const maxBlockSize = 1024 * 1024
+
+var (
+	_ block.ChainVM = (*vm)(nil)
+	_ snowman.Block = (*Block)(nil)
+
+	errNoPendingTxs      = errors.New("no pending txs")
+	errUnknownParent     = errors.New("unknown parent")
+	errWrongHeight       = errors.New("wrong height")
+	errTimestampTooEarly = errors.New("block timestamp is before its parent's")
+	errTimestampTooLate  = errors.New("block timestamp is too far in the future")
+)
+
+// Block is a block of the simulated chain. Blocks contain opaque txs and are
+// valid as long as their timestamp is consistent with their parent and with
+// the clock of the node verifying them.
+type Block struct {
+	vm *vm
+
+	id        ids.ID
+	parentID  ids.ID
+	height    uint64
+	timestamp time.Time
+	txs       [][]byte
+	bytes     []byte
+	status    choices.Status
+}
+
+func newBlock(parentID ids.ID, height uint64, timestamp time.Time, txs [][]byte) (*Block, error) {
+	p := wrappers.Packer{
+		MaxSize: maxBlockSize,
+	}
+	p.PackFixedBytes(parentID[:])
+	p.PackLong(height)
+	p.PackLong(uint64(timestamp.UnixNano()))
+	p.PackInt(uint32(len(txs)))
+	for _, tx := range txs {
+		p.PackBytes(tx)
+	}
+	if p.Errored() {
+		return nil, p.Err
+	}
+	return &Block{
+		id:        hashing.ComputeHash256Array(p.Bytes),
+		parentID:  parentID,
+		height:    height,
+		timestamp: timestamp,
+		txs:       txs,
+		bytes:     p.Bytes,
+		status:    choices.Processing,
+	}, nil
+}
+
+func parseBlock(bytes []byte) (*Block, error) {
+	p := wrappers.Packer{
+		Bytes: bytes,
+	}
+	parentID, err := ids.ToID(p.UnpackFixedBytes(ids.IDLen))
+	if err != nil {
+		return nil, err
+	}
+	height := p.UnpackLong()
+	timestamp := time.Unix(0, int64(p.UnpackLong()))
+	numTxs := p.UnpackInt()
+	if numTxs > maxBlockSize {
+		return nil, fmt.Errorf("%w: %d txs", wrappers.ErrInsufficientLength, numTxs)
+	}
+	txs := make([][]byte, 0, numTxs)
+	for i := uint32(0); i < numTxs && !p.Errored(); i++ {
+		txs = append(txs, p.UnpackBytes())
+	}
+	if p.Errored() {
+		return nil, p.Err
+	}
+	if p.Offset != len(bytes) {
+		return nil, fmt.Errorf("%w: %d bytes", wrappers.ErrInsufficientLength, len(bytes)-p.Offset)
+	}
+	return &Block{
+		id:        hashing.ComputeHash256Array(bytes),
+		parentID:  parentID,
+		height:    height,
+		timestamp: timestamp,
+		txs:       txs,
+		bytes:     bytes,
+		status:    choices.Processing,
+	}, nil
+}
+
+func (b *Block) ID() ids.ID {
+	return b.id
+}
+
+func (b *Block) Parent() ids.ID {
+	return b.parentID
+}
+
+func (b *Block) Height() uint64 {
+	return b.height
+}
+
+func (b *Block) Timestamp() time.Time {
+	return b.timestamp
+}
+
+func (b *Block) Bytes() []byte {
+	return b.bytes
+}
+
+func (b *Block) Status() choices.Status {
+	return b.status
+}
+
+// Txs returns the txs included in this block.
+func (b *Block) Txs() [][]byte {
+	return b.txs
+}
+
+func (b *Block) Verify(context.Context) error {
+	b.vm.lock.Lock()
+	defer b.vm.lock.Unlock()
+
+	parent, ok := b.vm.blocks[b.parentID]
+	if !ok {
+		return fmt.Errorf("%w: %s", errUnknownParent, b.parentID)
+	}
+	if expectedHeight := parent.height + 1; b.height != expectedHeight {
+		return fmt.Errorf("%w: expected %d but got %d", errWrongHeight, expectedHeight, b.height)
+	}
+	if b.timestamp.Before(parent.timestamp) {
+		return fmt.Errorf("%w: %s < %s", errTimestampTooEarly, b.timestamp, parent.timestamp)
+	}
+	if maxTimestamp := b.vm.clock().Add(b.vm.maxBlockDrift); b.timestamp.After(maxTimestamp) {
+		return fmt.Errorf("%w: %s > %s", errTimestampTooLate, b.timestamp, maxTimestamp)
+	}
+	return nil
+}
+
+func (b *Block) Accept(context.Context) error {
+	b.vm.lock.Lock()
+	defer b.vm.lock.Unlock()
+
+	b.status = choices.Accepted
+	b.vm.accepted = append(b.vm.accepted, b.id)
+	b.vm.lastAccepted = b
+	for _, tx := range b.txs {
+		txID := hashing.ComputeHash256Array(tx)
+		b.vm.acceptedTxs[txID] = struct{}{}
+		delete(b.vm.mempool, txID)
+	}
+	b.vm.onAccept(b)
+	return nil
+}
+
+func (b *Block) Reject(context.Context) error {
+	b.vm.lock.Lock()
+	defer b.vm.lock.Unlock()
+
+	b.status = choices.Rejected
+	// Only txs that this node was asked to issue are returned to the mempool.
+	for _, tx := range b.txs {
+		txID := hashing.ComputeHash256Array(tx)
+		if _, ok := b.vm.issuedTxs[txID]; !ok {
+			continue
+		}
+		if _, ok := b.vm.acceptedTxs[txID]; ok {
+			continue
+		}
+		b.vm.mempool[txID] = tx
+		b.vm.notify = true
+	}
+	return nil
+}
+
+// vm is an in-memory chain whose blocks are built from txs issued by the
+// simulator.
+type vm struct {
+	common.AppHandler
+
+	// clock returns the local time of the node running this VM.
+	clock         func() time.Time
+	maxBlockDrift time.Duration
+	onAccept      func(*Block)
+
+	lock         sync.Mutex
+	state        snow.State
+	blocks       map[ids.ID]*Block
+	accepted     []ids.ID
+	lastAccepted *Block
+	preferred    ids.ID
+	issuedTxs    map[ids.ID]struct{}
+	acceptedTxs  map[ids.ID]struct{}
+	mempool      map[ids.ID][]byte
+	// mempoolOrder preserves the order that txs were issued in so that blocks
+	// are built deterministically.
+	mempoolOrder []ids.ID
+	// notify is set when txs are added to the mempool and the engine should
+	// be notified.
+	notify bool
+}
+
+func newVM(
+	log logging.Logger,
+	genesis *Block,
+	clock func() time.Time,
+	maxBlockDrift time.Duration,
+	onAccept func(*Block),
+) *vm {
+	vm := &vm{
+		AppHandler:    common.NewNoOpAppHandler(log),
+		clock:         clock,
+		maxBlockDrift: maxBlockDrift,
+		onAccept:      onAccept,
+		blocks:        make(map[ids.ID]*Block),
+		issuedTxs:     make(map[ids.ID]struct{}),
+		acceptedTxs:   make(map[ids.ID]struct{}),
+		mempool:       make(map[ids.ID][]byte),
+	}
+
+	// Each VM gets its own copy of genesis so that statuses aren't shared
+	// across nodes.
+	genesisCopy := *genesis
+	genesisCopy.vm = vm
+	genesisCopy.status = choices.Accepted
+	vm.blocks[genesisCopy.id] = &genesisCopy
+	vm.accepted = []ids.ID{genesisCopy.id}
+	vm.lastAccepted = &genesisCopy
+	vm.preferred = genesisCopy.id
+	return vm
+}
+
+// issue adds [tx] to the mempool. Returns true if the tx was added.
+func (vm *vm) issue(tx []byte) bool {
+	vm.lock.Lock()
+	defer vm.lock.Unlock()
+
+	txID := hashing.ComputeHash256Array(tx)
+	if _, ok := vm.issuedTxs[txID]; ok {
+		return false
+	}
+	vm.issuedTxs[txID] = struct{}{}
+	vm.mempool[txID] = tx
+	vm.mempoolOrder = append(vm.mempoolOrder, txID)
+	vm.notify = true
+	return true
+}
+
+// takeNotify returns true if the engine should be notified of pending txs
+// since the last call.
+func (vm *vm) takeNotify() bool {
+	vm.lock.Lock()
+	defer vm.lock.Unlock()
+
+	notify := vm.notify
+	vm.notify = false
+	return notify
+}
+
+// isAccepted returns true if [tx] was included in an accepted block.
+func (vm *vm) isAccepted(tx []byte) bool {
+	vm.lock.Lock()
+	defer vm.lock.Unlock()
+
+	_, ok := vm.acceptedTxs[hashing.ComputeHash256Array(tx)]
+	return ok
+}
+
+// acceptedIDs returns the IDs of the accepted blocks, indexed by height.
+func (vm *vm) acceptedIDs() []ids.ID {
+	vm.lock.Lock()
+	defer vm.lock.Unlock()
+
+	return append([]ids.ID(nil), vm.accepted...)
+}
+
+func (*vm) Initialize(
+	context.Context,
+	*snow.Context,
+	database.Database,
+	[]byte,
+	[]byte,
+	[]byte,
+	chan<- common.Message,
+	[]*common.Fx,
+	common.AppSender,
+) error {
+	return nil
+}
+
+func (vm *vm) SetState(_ context.Context, state snow.State) error {
+	vm.lock.Lock()
+	defer vm.lock.Unlock()
+
+	vm.state = state
+	return nil
+}
+
+func (*vm) Shutdown(context.Context) error {
+	return nil
+}
+
+func (*vm) Version(context.Context) (string, error) {
+	return version.Current.String(), nil
+}
+
+func (*vm) CreateHandlers(context.Context) (map[string]http.Handler, error) {
+	return nil, nil
+}
+
+func (*vm) HealthCheck(context.Context) (interface{}, error) {
+	return nil, nil
+}
+
+func (*vm) Connected(context.Context, ids.NodeID, *version.Application) error {
+	return nil
+}
+
+func (*vm) Disconnected(context.Context, ids.NodeID) error {
+	return nil
+}
+
+func (vm *vm) BuildBlock(context.Context) (snowman.Block, error) {
+	vm.lock.Lock()
+	defer vm.lock.Unlock()
+
+	var (
+		txs          [][]byte
+		mempoolOrder = vm.mempoolOrder[:0]
+	)
+	for _, txID := range vm.mempoolOrder {
+		if _, ok := vm.acceptedTxs[txID]; ok {
+			continue
+		}
+		mempoolOrder = append(mempoolOrder, txID)
+		if tx, ok := vm.mempool[txID]; ok {
+			txs = append(txs, tx)
+		}
+	}
+	vm.mempoolOrder = mempoolOrder
+	if len(txs) == 0 {
+		return nil, errNoPendingTxs
+	}
+
+	parent := vm.blocks[vm.preferred]
+	timestamp := vm.clock()
+	if timestamp.Before(parent.timestamp) {
+		timestamp = parent.timestamp
+	}
+	blk, err := newBlock(parent.id, parent.height+1, timestamp, txs)
+	if err != nil {
+		return nil, err
+	}
+	blk.vm = vm
+	vm.blocks[blk.id] = blk
+
+	// Txs are removed from the mempool once they are included in a block. If
+	// the block is rejected, they will be re-added.
+	for _, tx := range txs {
+		delete(vm.mempool, hashing.ComputeHash256Array(tx))
+	}
+	return blk, nil
+}
+
+func (vm *vm) ParseBlock(_ context.Context, bytes []byte) (snowman.Block, error) {
+	blk, err := parseBlock(bytes)
+	if err != nil {
+		return nil, err
+	}
+
+	vm.lock.Lock()
+	defer vm.lock.Unlock()
+
+	if existing, ok := vm.blocks[blk.id]; ok {
+		return existing, nil
+	}
+
+	blk.vm = vm
+	if blk.height <= vm.lastAccepted.height {
+		blk.status = choices.Rejected
+	}
+	vm.blocks[blk.id] = blk
+	return blk, nil
+}
+
+func (vm *vm) GetBlock(_ context.Context, blkID ids.ID) (snowman.Block, error) {
+	vm.lock.Lock()
+	defer vm.lock.Unlock()
+
+	blk, ok := vm.blocks[blkID]
+	if !ok {
+		return nil, database.ErrNotFound
+	}
+	return blk, nil
+}
+
+func (vm *vm) SetPreference(_ context.Context, blkID ids.ID) error {
+	vm.lock.Lock()
+	defer vm.lock.Unlock()
+
+	vm.preferred = blkID
+	return nil
+}
+
+func (vm *vm) LastAccepted(context.Context) (ids.ID, error) {
+	vm.lock.Lock()
+	defer vm.lock.Unlock()
+
+	return vm.lastAccepted.id, nil
+}
+
+func (vm *vm) GetBlockIDAtHeight(_ context.Context, height uint64) (ids.ID, error) {
+	vm.lock.Lock()
+	defer vm.lock.Unlock()
+
+	if height >= uint64(len(vm.accepted)) || height > math.MaxInt {
+		return ids.Empty, database.ErrNotFound
+	}
+	return vm.accepted[height], nil
+}
@@ -5,6 +5,7 @@ package snow
 
 import (
 	"errors"
+	"time"
 
 	"github.com/ava-labs/avalanchego/proto/pb/p2p"
 )
@@ -39,3 +40,20 @@ type EngineState struct {
 	Type  p2p.EngineType
 	State State
 }
+
+// BootstrapProgress describes how far a chain has gotten in its current
+// bootstrapping round.
+type BootstrapProgress struct {
+	// Height of the last accepted block when the round started.
+	StartingHeight uint64 `json:"startingHeight"`
+	// Height of the accepted frontier being bootstrapped to.
+	TipHeight uint64 `json:"tipHeight"`
+	// Number of blocks that have been fetched but not yet executed.
+	NumFetched uint64 `json:"numFetched"`
+	// Number of blocks executed during this round.
+	NumExecuted uint64 `json:"numExecuted"`
+	// Estimated time until all blocks are fetched.
+	FetchETA time.Duration `json:"fetchETA"`
+	// Estimated time until all fetched blocks are executed.
+	ExecuteETA time.Duration `json:"executeETA"`
+}
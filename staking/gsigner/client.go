@@ -0,0 +1,146 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package gsigner
+
+import (
+	"context"
+	"crypto"
+	"crypto/rsa"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"io"
+	"time"
+
+	"google.golang.org/protobuf/types/known/emptypb"
+
+	"github.com/ava-labs/avalanchego/utils/crypto/bls"
+
+	pb "github.com/ava-labs/avalanchego/proto/pb/signer"
+)
+
+var (
+	_ bls.Signer    = (*Client)(nil)
+	_ crypto.Signer = (*tlsSigner)(nil)
+)
+
+// Client signs messages with staking keys held by a remote signer.
+//
+// Every signing request must complete within the configured timeout. Because
+// the keys are never available locally, a signer that is unavailable causes
+// every signing request to fail rather than falling back to other keys.
+type Client struct {
+	client  pb.SignerClient
+	timeout time.Duration
+
+	blsPublicKey *bls.PublicKey
+	tlsCert      tls.Certificate
+}
+
+// NewClient fetches the public keys of the remote signer and returns a client
+// that signs with the corresponding secret keys. An error is returned if the
+// remote signer is unavailable.
+func NewClient(ctx context.Context, client pb.SignerClient, timeout time.Duration) (*Client, error) {
+	c := &Client{
+		client:  client,
+		timeout: timeout,
+	}
+
+	requestCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	blsResp, err := client.BLSPublicKey(requestCtx, &emptypb.Empty{})
+	if err != nil {
+		return nil, fmt.Errorf("couldn't fetch BLS public key: %w", err)
+	}
+	c.blsPublicKey, err = bls.PublicKeyFromCompressedBytes(blsResp.PublicKey)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't parse BLS public key: %w", err)
+	}
+
+	tlsResp, err := client.TLSCertificate(requestCtx, &emptypb.Empty{})
+	if err != nil {
+		return nil, fmt.Errorf("couldn't fetch TLS certificate: %w", err)
+	}
+	leaf, err := x509.ParseCertificate(tlsResp.Certificate)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't parse TLS certificate: %w", err)
+	}
+	c.tlsCert = tls.Certificate{
+		Certificate: [][]byte{tlsResp.Certificate},
+		PrivateKey: &tlsSigner{
+			client:    c,
+			publicKey: leaf.PublicKey,
+		},
+		Leaf: leaf,
+	}
+	return c, nil
+}
+
+func (c *Client) PublicKey() *bls.PublicKey {
+	return c.blsPublicKey
+}
+
+func (c *Client) Sign(msg []byte) (*bls.Signature, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), c.timeout)
+	defer cancel()
+
+	resp, err := c.client.BLSSign(ctx, &pb.BLSSignRequest{
+		Message: msg,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return bls.SignatureFromBytes(resp.Signature)
+}
+
+func (c *Client) SignProofOfPossession(msg []byte) (*bls.Signature, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), c.timeout)
+	defer cancel()
+
+	resp, err := c.client.BLSSignProofOfPossession(ctx, &pb.BLSSignRequest{
+		Message: msg,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return bls.SignatureFromBytes(resp.Signature)
+}
+
+// TLSCertificate returns the staking certificate of the remote signer. The
+// private key of the returned certificate signs with the remote signer.
+func (c *Client) TLSCertificate() tls.Certificate {
+	return c.tlsCert
+}
+
+type tlsSigner struct {
+	client    *Client
+	publicKey crypto.PublicKey
+}
+
+func (s *tlsSigner) Public() crypto.PublicKey {
+	return s.publicKey
+}
+
+// Sign signs [digest] with the remote signer. The remote signer provides its
+// own randomness, so [rand] is ignored.
+func (s *tlsSigner) Sign(_ io.Reader, digest []byte, opts crypto.SignerOpts) ([]byte, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), s.client.timeout)
+	defer cancel()
+
+	req := &pb.TLSSignRequest{
+		Digest: digest,
+		Hash:   uint32(opts.HashFunc()),
+	}
+	if pssOpts, ok := opts.(*rsa.PSSOptions); ok {
+		req.Pss = true
+		req.PssSaltLength = int32(pssOpts.SaltLength)
+	}
+
+	resp, err := s.client.client.TLSSign(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return resp.Signature, nil
+}
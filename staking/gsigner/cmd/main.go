@@ -0,0 +1,157 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package main
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/spf13/cobra"
+	"google.golang.org/grpc/credentials"
+
+	"github.com/ava-labs/avalanchego/staking"
+	"github.com/ava-labs/avalanchego/staking/gsigner"
+	"github.com/ava-labs/avalanchego/utils/crypto/bls"
+	"github.com/ava-labs/avalanchego/vms/rpcchainvm/grpcutils"
+
+	pb "github.com/ava-labs/avalanchego/proto/pb/signer"
+)
+
+var (
+	errTLSCertFileRequired          = errors.New("--tls-cert-file is required")
+	errTLSKeyFileRequired           = errors.New("--tls-key-file is required")
+	errClientCAFileRequired         = errors.New("--client-ca-file is required")
+	errStakingTLSCertFileRequired   = errors.New("--staking-tls-cert-file is required")
+	errStakingTLSKeyFileRequired    = errors.New("--staking-tls-key-file is required")
+	errStakingSignerKeyFileRequired = errors.New("--staking-signer-key-file is required")
+	errInvalidClientCA              = errors.New("couldn't parse client certificate authority")
+)
+
+type config struct {
+	listenAddress        string
+	tlsCertFile          string
+	tlsKeyFile           string
+	clientCAFile         string
+	stakingTLSCertFile   string
+	stakingTLSKeyFile    string
+	stakingSignerKeyFile string
+}
+
+func (c *config) verify() error {
+	switch {
+	case len(c.tlsCertFile) == 0:
+		return errTLSCertFileRequired
+	case len(c.tlsKeyFile) == 0:
+		return errTLSKeyFileRequired
+	case len(c.clientCAFile) == 0:
+		return errClientCAFileRequired
+	case len(c.stakingTLSCertFile) == 0:
+		return errStakingTLSCertFileRequired
+	case len(c.stakingTLSKeyFile) == 0:
+		return errStakingTLSKeyFileRequired
+	case len(c.stakingSignerKeyFile) == 0:
+		return errStakingSignerKeyFileRequired
+	default:
+		return nil
+	}
+}
+
+// transportCredentials returns credentials that only accept clients with a
+// certificate issued by the client certificate authority.
+func (c *config) transportCredentials() (credentials.TransportCredentials, error) {
+	cert, err := tls.LoadX509KeyPair(c.tlsCertFile, c.tlsKeyFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read TLS certificate: %w", err)
+	}
+	caBytes, err := os.ReadFile(c.clientCAFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read client certificate authority: %w", err)
+	}
+	caPool := x509.NewCertPool()
+	if !caPool.AppendCertsFromPEM(caBytes) {
+		return nil, errInvalidClientCA
+	}
+	return credentials.NewTLS(&tls.Config{
+		Certificates: []tls.Certificate{cert},
+		ClientAuth:   tls.RequireAndVerifyClientCert,
+		ClientCAs:    caPool,
+		MinVersion:   tls.VersionTLS13,
+	}), nil
+}
+
+func (c *config) newServer() (*gsigner.Server, error) {
+	stakingTLSCert, err := staking.LoadTLSCertFromFiles(c.stakingTLSKeyFile, c.stakingTLSCertFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read staking TLS certificate: %w", err)
+	}
+	signerKeyBytes, err := os.ReadFile(c.stakingSignerKeyFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read staking signer key: %w", err)
+	}
+	signerKey, err := bls.SecretKeyFromBytes(signerKeyBytes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse staking signer key: %w", err)
+	}
+	return gsigner.NewServer(bls.NewLocalSigner(signerKey), stakingTLSCert)
+}
+
+func main() {
+	var c config
+	rootCmd := &cobra.Command{
+		Use:   "signer",
+		Short: "Serve the staking keys of a node to a node running with --staking-remote-signer-endpoint",
+		RunE: func(*cobra.Command, []string) error {
+			if err := c.verify(); err != nil {
+				return err
+			}
+
+			signerServer, err := c.newServer()
+			if err != nil {
+				return err
+			}
+			creds, err := c.transportCredentials()
+			if err != nil {
+				return err
+			}
+
+			listener, err := net.Listen("tcp", c.listenAddress)
+			if err != nil {
+				return fmt.Errorf("failed to listen on %s: %w", c.listenAddress, err)
+			}
+
+			server := grpcutils.NewServer(grpcutils.WithCreds(creds))
+			pb.RegisterSignerServer(server, signerServer)
+
+			ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+			defer cancel()
+			go func() {
+				<-ctx.Done()
+				server.GracefulStop()
+			}()
+
+			fmt.Fprintf(os.Stdout, "Serving staking keys on %s\n", listener.Addr())
+			return server.Serve(listener)
+		},
+	}
+	rootCmd.PersistentFlags().StringVar(&c.listenAddress, "listen-address", "127.0.0.1:9652", "The address to serve the staking keys on")
+	rootCmd.PersistentFlags().StringVar(&c.tlsCertFile, "tls-cert-file", "", "Path to the TLS certificate the signer uses to authenticate to nodes")
+	rootCmd.PersistentFlags().StringVar(&c.tlsKeyFile, "tls-key-file", "", "Path to the TLS private key the signer uses to authenticate to nodes")
+	rootCmd.PersistentFlags().StringVar(&c.clientCAFile, "client-ca-file", "", "Path to the certificate authority used to authenticate nodes")
+	rootCmd.PersistentFlags().StringVar(&c.stakingTLSCertFile, "staking-tls-cert-file", "", "Path to the TLS certificate for staking")
+	rootCmd.PersistentFlags().StringVar(&c.stakingTLSKeyFile, "staking-tls-key-file", "", "Path to the TLS private key for staking")
+	rootCmd.PersistentFlags().StringVar(&c.stakingSignerKeyFile, "staking-signer-key-file", "", "Path to the signer private key for staking")
+
+	if err := rootCmd.Execute(); err != nil {
+		fmt.Fprintf(os.Stderr, "signer failed: %v\n", err)
+		os.Exit(1)
+	}
+	os.Exit(0)
+}
@@ -0,0 +1,109 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package gsigner
+
+import (
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/tls"
+	"errors"
+	"fmt"
+
+	"google.golang.org/protobuf/types/known/emptypb"
+
+	"github.com/ava-labs/avalanchego/utils/crypto/bls"
+
+	pb "github.com/ava-labs/avalanchego/proto/pb/signer"
+)
+
+var (
+	_ pb.SignerServer = (*Server)(nil)
+
+	errInvalidTLSKey  = errors.New("invalid TLS key")
+	errUnknownHash    = errors.New("unknown hash function")
+	errPSSUnsupported = errors.New("PSS signatures require an RSA key")
+)
+
+// Server exposes staking keys to remote clients.
+type Server struct {
+	pb.UnsafeSignerServer
+
+	blsSigner bls.Signer
+	tlsCert   []byte
+	tlsSigner crypto.Signer
+}
+
+// NewServer returns a server that signs with [blsSigner] and the private key of
+// [tlsCert].
+func NewServer(blsSigner bls.Signer, tlsCert *tls.Certificate) (*Server, error) {
+	tlsSigner, ok := tlsCert.PrivateKey.(crypto.Signer)
+	if !ok || len(tlsCert.Certificate) == 0 {
+		return nil, errInvalidTLSKey
+	}
+	return &Server{
+		blsSigner: blsSigner,
+		tlsCert:   tlsCert.Certificate[0],
+		tlsSigner: tlsSigner,
+	}, nil
+}
+
+func (s *Server) BLSPublicKey(context.Context, *emptypb.Empty) (*pb.BLSPublicKeyResponse, error) {
+	return &pb.BLSPublicKeyResponse{
+		PublicKey: bls.PublicKeyToCompressedBytes(s.blsSigner.PublicKey()),
+	}, nil
+}
+
+func (s *Server) BLSSign(_ context.Context, req *pb.BLSSignRequest) (*pb.BLSSignResponse, error) {
+	sig, err := s.blsSigner.Sign(req.Message)
+	if err != nil {
+		return nil, err
+	}
+	return &pb.BLSSignResponse{
+		Signature: bls.SignatureToBytes(sig),
+	}, nil
+}
+
+func (s *Server) BLSSignProofOfPossession(_ context.Context, req *pb.BLSSignRequest) (*pb.BLSSignResponse, error) {
+	sig, err := s.blsSigner.SignProofOfPossession(req.Message)
+	if err != nil {
+		return nil, err
+	}
+	return &pb.BLSSignResponse{
+		Signature: bls.SignatureToBytes(sig),
+	}, nil
+}
+
+func (s *Server) TLSCertificate(context.Context, *emptypb.Empty) (*pb.TLSCertificateResponse, error) {
+	return &pb.TLSCertificateResponse{
+		Certificate: s.tlsCert,
+	}, nil
+}
+
+func (s *Server) TLSSign(_ context.Context, req *pb.TLSSignRequest) (*pb.TLSSignResponse, error) {
+	hash := crypto.Hash(req.Hash)
+	if hash != 0 && !hash.Available() {
+		return nil, fmt.Errorf("%w: %d", errUnknownHash, req.Hash)
+	}
+
+	var opts crypto.SignerOpts = hash
+	if req.Pss {
+		if _, ok := s.tlsSigner.Public().(*rsa.PublicKey); !ok {
+			return nil, errPSSUnsupported
+		}
+		opts = &rsa.PSSOptions{
+			SaltLength: int(req.PssSaltLength),
+			Hash:       hash,
+		}
+	}
+
+	sig, err := s.tlsSigner.Sign(rand.Reader, req.Digest, opts)
+	if err != nil {
+		return nil, err
+	}
+	return &pb.TLSSignResponse{
+		Signature: sig,
+	}, nil
+}
@@ -0,0 +1,179 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package gsigner
+
+import (
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/tls"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/ava-labs/avalanchego/staking"
+	"github.com/ava-labs/avalanchego/utils/crypto/bls"
+	"github.com/ava-labs/avalanchego/utils/hashing"
+	"github.com/ava-labs/avalanchego/vms/rpcchainvm/grpcutils"
+
+	pb "github.com/ava-labs/avalanchego/proto/pb/signer"
+)
+
+const testTimeout = 10 * time.Second
+
+type testSigner struct {
+	client  *Client
+	sk      *bls.SecretKey
+	tlsCert *tls.Certificate
+	stop    func()
+}
+
+func setupSigner(t testing.TB) *testSigner {
+	require := require.New(t)
+
+	sk, err := bls.NewSecretKey()
+	require.NoError(err)
+
+	tlsCert, err := staking.NewTLSCert()
+	require.NoError(err)
+
+	signerServer, err := NewServer(bls.NewLocalSigner(sk), tlsCert)
+	require.NoError(err)
+
+	listener, err := grpcutils.NewListener()
+	require.NoError(err)
+	serverCloser := grpcutils.ServerCloser{}
+
+	server := grpcutils.NewServer()
+	pb.RegisterSignerServer(server, signerServer)
+	serverCloser.Add(server)
+
+	go grpcutils.Serve(listener, server)
+
+	conn, err := grpcutils.Dial(listener.Addr().String())
+	require.NoError(err)
+
+	client, err := NewClient(context.Background(), pb.NewSignerClient(conn), testTimeout)
+	require.NoError(err)
+
+	t.Cleanup(func() {
+		serverCloser.Stop()
+		_ = conn.Close()
+		_ = listener.Close()
+	})
+
+	return &testSigner{
+		client:  client,
+		sk:      sk,
+		tlsCert: tlsCert,
+		stop:    serverCloser.Stop,
+	}
+}
+
+func TestBLSSigner(t *testing.T) {
+	require := require.New(t)
+
+	s := setupSigner(t)
+	require.Equal(bls.PublicFromSecretKey(s.sk), s.client.PublicKey())
+
+	msg := []byte("message")
+	sig, err := s.client.Sign(msg)
+	require.NoError(err)
+	require.True(bls.Verify(s.client.PublicKey(), sig, msg))
+	require.False(bls.VerifyProofOfPossession(s.client.PublicKey(), sig, msg))
+
+	sig, err = s.client.SignProofOfPossession(msg)
+	require.NoError(err)
+	require.True(bls.VerifyProofOfPossession(s.client.PublicKey(), sig, msg))
+	require.False(bls.Verify(s.client.PublicKey(), sig, msg))
+}
+
+func TestTLSSigner(t *testing.T) {
+	s := setupSigner(t)
+
+	cert := s.client.TLSCertificate()
+	require.Equal(t, s.tlsCert.Certificate, cert.Certificate)
+	require.Equal(t, s.tlsCert.Leaf.Raw, cert.Leaf.Raw)
+
+	signer := cert.PrivateKey.(crypto.Signer)
+	publicKey := signer.Public().(*rsa.PublicKey)
+	digest := hashing.ComputeHash256([]byte("message"))
+
+	t.Run("pkcs1v15", func(t *testing.T) {
+		require := require.New(t)
+
+		sig, err := signer.Sign(rand.Reader, digest, crypto.SHA256)
+		require.NoError(err)
+		require.NoError(rsa.VerifyPKCS1v15(publicKey, crypto.SHA256, digest, sig))
+	})
+
+	t.Run("pss", func(t *testing.T) {
+		require := require.New(t)
+
+		opts := &rsa.PSSOptions{
+			SaltLength: rsa.PSSSaltLengthEqualsHash,
+			Hash:       crypto.SHA256,
+		}
+		sig, err := signer.Sign(rand.Reader, digest, opts)
+		require.NoError(err)
+		require.NoError(rsa.VerifyPSS(publicKey, crypto.SHA256, digest, sig, opts))
+	})
+}
+
+func TestTLSHandshake(t *testing.T) {
+	require := require.New(t)
+
+	s := setupSigner(t)
+
+	clientConn, serverConn := net.Pipe()
+	defer clientConn.Close()
+	defer serverConn.Close()
+
+	tlsConfig := &tls.Config{
+		Certificates:       []tls.Certificate{s.client.TLSCertificate()},
+		ClientAuth:         tls.RequireAnyClientCert,
+		InsecureSkipVerify: true, //#nosec G402
+		MinVersion:         tls.VersionTLS13,
+	}
+	client := tls.Client(clientConn, tlsConfig)
+	server := tls.Server(serverConn, tlsConfig)
+
+	errs := make(chan error, 1)
+	go func() {
+		errs <- server.Handshake()
+	}()
+	require.NoError(client.Handshake())
+	require.NoError(<-errs)
+
+	peerCerts := server.ConnectionState().PeerCertificates
+	require.Len(peerCerts, 1)
+	require.Equal(s.tlsCert.Leaf.Raw, peerCerts[0].Raw)
+}
+
+func TestSignerUnavailable(t *testing.T) {
+	require := require.New(t)
+
+	s := setupSigner(t)
+	s.client.timeout = 100 * time.Millisecond
+	s.stop()
+
+	// Depending on when the connection is noticed to be closed, requests either
+	// fail immediately or time out.
+	unavailableCodes := []codes.Code{codes.Unavailable, codes.DeadlineExceeded}
+
+	_, err := s.client.Sign([]byte("message"))
+	require.Contains(unavailableCodes, status.Code(err))
+
+	_, err = s.client.SignProofOfPossession([]byte("message"))
+	require.Contains(unavailableCodes, status.Code(err))
+
+	signer := s.client.TLSCertificate().PrivateKey.(crypto.Signer)
+	_, err = signer.Sign(rand.Reader, hashing.ComputeHash256([]byte("message")), crypto.SHA256)
+	require.Contains(unavailableCodes, status.Code(err))
+}
@@ -11,6 +11,7 @@ import (
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/snow/consensus/snowball"
 	"github.com/ava-labs/avalanchego/utils/set"
+	"github.com/ava-labs/avalanchego/vms/proposervm/proposer"
 )
 
 var errAllowedNodesWhenNotValidatorOnly = errors.New("allowedNodes can only be set when ValidatorOnly is true")
@@ -49,6 +50,13 @@ type Config struct {
 	// TODO: Move this flag once the proposervm is configurable on a per-chain
 	// basis.
 	ProposerNumHistoricalBlocks uint64 `json:"proposerNumHistoricalBlocks" yaml:"proposerNumHistoricalBlocks"`
+	// ProposerSelection is the policy that snowman++ block proposers are
+	// selected with. If the policy isn't specified, proposers are sampled
+	// weighted by their stake.
+	//
+	// Invariant: Every node of the subnet must use the same policy. Blocks
+	// built by a proposer that the policy doesn't expect fail verification.
+	ProposerSelection proposer.PolicyConfig `json:"proposerSelection" yaml:"proposerSelection"`
 }
 
 func (c *Config) Valid() error {
@@ -58,5 +66,8 @@ func (c *Config) Valid() error {
 	if !c.ValidatorOnly && c.AllowedNodes.Len() > 0 {
 		return errAllowedNodesWhenNotValidatorOnly
 	}
+	if err := c.ProposerSelection.Verify(); err != nil {
+		return fmt.Errorf("proposer selection %w", err)
+	}
 	return nil
 }
@@ -11,6 +11,7 @@ import (
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/snow/consensus/snowball"
 	"github.com/ava-labs/avalanchego/utils/set"
+	"github.com/ava-labs/avalanchego/vms/proposervm/proposer"
 )
 
 var validParameters = snowball.Parameters{
@@ -50,6 +51,16 @@ func TestValid(t *testing.T) {
 			},
 			expectedErr: errAllowedNodesWhenNotValidatorOnly,
 		},
+		{
+			name: "invalid proposer selection",
+			s: Config{
+				ConsensusParameters: validParameters,
+				ProposerSelection: proposer.PolicyConfig{
+					Policy: "unknown",
+				},
+			},
+			expectedErr: proposer.ErrUnknownPolicy,
+		},
 		{
 			name: "valid",
 			s: Config{
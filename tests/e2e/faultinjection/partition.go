@@ -0,0 +1,60 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package faultinjection
+
+import (
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/api/info"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/tests/fixture/e2e"
+	"github.com/ava-labs/avalanchego/tests/fixture/tmpnet"
+
+	ginkgo "github.com/onsi/ginkgo/v2"
+)
+
+var _ = ginkgo.Describe("Network partition handling", func() {
+	require := require.New(ginkgo.GinkgoT())
+
+	ginkgo.It("should disconnect partitioned nodes and reconnect them once the partition heals", func() {
+		network := e2e.Env.GetNetwork()
+		if len(network.DefaultRuntimeConfig.TmpnetctlPath) == 0 {
+			ginkgo.Skip("fault injection requires the network to be started with --tmpnetctl-path")
+		}
+
+		ginkgo.By("creating a new node")
+		node := e2e.AddEphemeralNode(network, tmpnet.FlagsMap{})
+		e2e.WaitForHealthy(node)
+		ginkgo.DeferCleanup(func() {
+			require.NoError(network.ClearFaults())
+		})
+
+		ginkgo.By("partitioning the new node from the rest of the network")
+		nodeIDs := make([]ids.NodeID, 0, len(network.Nodes))
+		for _, existingNode := range network.Nodes {
+			nodeIDs = append(nodeIDs, existingNode.NodeID)
+		}
+		require.NoError(network.Partition(nodeIDs, []ids.NodeID{node.NodeID}))
+
+		ginkgo.By("checking that the new node loses its peers")
+		e2e.Eventually(func() bool {
+			return countPeers(node) == 0
+		}, e2e.DefaultTimeout, e2e.DefaultPollingInterval, "failed to see the partitioned node lose its peers before timeout")
+
+		ginkgo.By("healing the partition")
+		require.NoError(network.HealPartition())
+
+		ginkgo.By("checking that the new node reconnects to its peers")
+		e2e.Eventually(func() bool {
+			return countPeers(node) >= len(network.Nodes)
+		}, e2e.DefaultTimeout, e2e.DefaultPollingInterval, "failed to see the node reconnect to its peers before timeout")
+		checkConnectedPeers(network.Nodes, node)
+	})
+})
+
+func countPeers(node *tmpnet.Node) int {
+	peers, err := info.NewClient(node.URI).Peers(e2e.DefaultContext())
+	require.NoError(ginkgo.GinkgoT(), err)
+	return len(peers)
+}
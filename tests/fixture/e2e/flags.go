@@ -14,6 +14,7 @@ import (
 
 type FlagVars struct {
 	avalancheGoExecPath  string
+	tmpnetctlPath        string
 	pluginDir            string
 	networkDir           string
 	useExistingNetwork   bool
@@ -24,6 +25,10 @@ func (v *FlagVars) AvalancheGoExecPath() string {
 	return v.avalancheGoExecPath
 }
 
+func (v *FlagVars) TmpnetctlPath() string {
+	return v.tmpnetctlPath
+}
+
 func (v *FlagVars) PluginDir() string {
 	return v.pluginDir
 }
@@ -54,6 +59,12 @@ func RegisterFlags() *FlagVars {
 		os.Getenv(tmpnet.AvalancheGoPathEnvName),
 		fmt.Sprintf("avalanchego executable path (required if not using an existing network). Also possible to configure via the %s env variable.", tmpnet.AvalancheGoPathEnvName),
 	)
+	flag.StringVar(
+		&vars.tmpnetctlPath,
+		"tmpnetctl-path",
+		os.Getenv(tmpnet.TmpnetctlPathEnvName),
+		fmt.Sprintf("[optional] tmpnetctl executable path. If provided, nodes of a new network run behind fault proxies to support the injection of network faults. Also possible to configure via the %s env variable.", tmpnet.TmpnetctlPathEnvName),
+	)
 	flag.StringVar(
 		&vars.pluginDir,
 		"plugin-dir",
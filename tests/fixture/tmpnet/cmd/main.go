@@ -5,15 +5,20 @@ package main
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"io/fs"
 	"os"
+	"os/signal"
 	"path/filepath"
+	"strings"
+	"syscall"
 	"time"
 
 	"github.com/spf13/cobra"
 
+	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/tests/fixture/tmpnet"
 	"github.com/ava-labs/avalanchego/version"
 )
@@ -23,6 +28,11 @@ const cliVersion = "0.0.1"
 var (
 	errAvalancheGoRequired = fmt.Errorf("--avalanchego-path or %s are required", tmpnet.AvalancheGoPathEnvName)
 	errNetworkDirRequired  = fmt.Errorf("--network-dir or %s are required", tmpnet.NetworkDirEnvName)
+	errNodeDirRequired     = errors.New("--node-dir is required")
+	errTwoNodeIDsRequired  = errors.New("--node-ids must contain exactly two node IDs")
+	errManifestRequired    = errors.New("--manifest is required")
+	errSubnetNameRequired  = errors.New("a subnet name is required")
+	errOutputDirRequired   = errors.New("--output-dir is required")
 )
 
 func main() {
@@ -53,6 +63,7 @@ func main() {
 		avalancheGoPath string
 		pluginDir       string
 		nodeCount       uint8
+		faultInjection  bool
 	)
 	startNetworkCmd := &cobra.Command{
 		Use:   "start-network",
@@ -67,6 +78,14 @@ func main() {
 			network := &tmpnet.Network{
 				Owner: networkOwner,
 			}
+			if faultInjection {
+				// Nodes run their fault proxies with this binary
+				tmpnetctlPath, err := os.Executable()
+				if err != nil {
+					return err
+				}
+				network.DefaultRuntimeConfig.TmpnetctlPath = tmpnetctlPath
+			}
 
 			// Extreme upper bound, should never take this long
 			networkStartTimeout := 2 * time.Minute
@@ -85,24 +104,7 @@ func main() {
 			if err != nil {
 				return err
 			}
-
-			// Symlink the new network to the 'latest' network to simplify usage
-			networkRootDir := filepath.Dir(network.Dir)
-			networkDirName := filepath.Base(network.Dir)
-			latestSymlinkPath := filepath.Join(networkRootDir, "latest")
-			if err := os.Remove(latestSymlinkPath); err != nil && !errors.Is(err, fs.ErrNotExist) {
-				return err
-			}
-			if err := os.Symlink(networkDirName, latestSymlinkPath); err != nil {
-				return err
-			}
-
-			fmt.Fprintf(os.Stdout, "\nConfigure tmpnetctl to target this network by default with one of the following statements:\n")
-			fmt.Fprintf(os.Stdout, " - source %s\n", network.EnvFilePath())
-			fmt.Fprintf(os.Stdout, " - %s\n", network.EnvFileContents())
-			fmt.Fprintf(os.Stdout, " - export %s=%s\n", tmpnet.NetworkDirEnvName, latestSymlinkPath)
-
-			return nil
+			return linkLatestNetwork(network)
 		},
 	}
 	startNetworkCmd.PersistentFlags().StringVar(&rootDir, "root-dir", os.Getenv(tmpnet.RootDirEnvName), "The path to the root directory for temporary networks")
@@ -110,8 +112,72 @@ func main() {
 	startNetworkCmd.PersistentFlags().StringVar(&pluginDir, "plugin-dir", os.ExpandEnv("$HOME/.avalanchego/plugins"), "[optional] the dir containing VM plugins")
 	startNetworkCmd.PersistentFlags().Uint8Var(&nodeCount, "node-count", tmpnet.DefaultNodeCount, "Number of nodes the network should initially consist of")
 	startNetworkCmd.PersistentFlags().StringVar(&networkOwner, "network-owner", "", "The string identifying the intended owner of the network")
+	startNetworkCmd.PersistentFlags().BoolVar(&faultInjection, "enable-fault-injection", false, "Whether to run a fault proxy in front of each node's staking port to support the injection of network faults")
 	rootCmd.AddCommand(startNetworkCmd)
 
+	var manifestPath string
+	applyManifestCmd := &cobra.Command{
+		Use:   "apply-manifest",
+		Short: "Start a new temporary network and create its subnets as declared by a YAML or JSON manifest",
+		RunE: func(*cobra.Command, []string) error {
+			if len(manifestPath) == 0 {
+				return errManifestRequired
+			}
+			manifest, err := tmpnet.ReadNetworkManifest(manifestPath)
+			if err != nil {
+				return err
+			}
+
+			// Paths in the manifest take precedence over flags
+			if len(manifest.AvalancheGoPath) > 0 {
+				avalancheGoPath = manifest.ResolvePath(manifest.AvalancheGoPath)
+			}
+			if len(avalancheGoPath) == 0 {
+				return errAvalancheGoRequired
+			}
+			if len(manifest.PluginDir) > 0 {
+				pluginDir = manifest.ResolvePath(manifest.PluginDir)
+			}
+
+			network, err := manifest.NewNetwork()
+			if err != nil {
+				return err
+			}
+			if manifest.EnableFaultInjection {
+				tmpnetctlPath, err := os.Executable()
+				if err != nil {
+					return err
+				}
+				network.DefaultRuntimeConfig.TmpnetctlPath = tmpnetctlPath
+			}
+
+			// Allow time for subnet creation, which requires restarting the nodes
+			ctx, cancel := context.WithTimeout(context.Background(), 2*time.Minute+time.Duration(len(network.Subnets))*tmpnet.DefaultNetworkTimeout)
+			defer cancel()
+			err = tmpnet.StartNewNetwork(
+				ctx,
+				os.Stdout,
+				network,
+				rootDir,
+				avalancheGoPath,
+				pluginDir,
+				manifest.GetNodeCount(),
+			)
+			if err != nil {
+				return err
+			}
+			if err := network.CreateSubnets(ctx, os.Stdout); err != nil {
+				return err
+			}
+			return linkLatestNetwork(network)
+		},
+	}
+	applyManifestCmd.PersistentFlags().StringVar(&manifestPath, "manifest", "", "The path to a YAML or JSON network manifest")
+	applyManifestCmd.PersistentFlags().StringVar(&rootDir, "root-dir", os.Getenv(tmpnet.RootDirEnvName), "The path to the root directory for temporary networks")
+	applyManifestCmd.PersistentFlags().StringVar(&avalancheGoPath, "avalanchego-path", os.Getenv(tmpnet.AvalancheGoPathEnvName), "The path to an avalanchego binary. Ignored if the manifest specifies a path")
+	applyManifestCmd.PersistentFlags().StringVar(&pluginDir, "plugin-dir", os.ExpandEnv("$HOME/.avalanchego/plugins"), "[optional] the dir containing VM plugins. Ignored if the manifest specifies a dir")
+	rootCmd.AddCommand(applyManifestCmd)
+
 	stopNetworkCmd := &cobra.Command{
 		Use:   "stop-network",
 		Short: "Stop a temporary network",
@@ -144,9 +210,413 @@ func main() {
 	}
 	rootCmd.AddCommand(restartNetworkCmd)
 
+	var (
+		nodeFlags     string
+		nodeEphemeral bool
+	)
+	addNodeCmd := &cobra.Command{
+		Use:   "add-node",
+		Short: "Add a new node to a temporary network",
+		RunE: func(*cobra.Command, []string) error {
+			network, err := readNetwork(networkDir)
+			if err != nil {
+				return err
+			}
+			flags := tmpnet.FlagsMap{}
+			if len(nodeFlags) > 0 {
+				if err := json.Unmarshal([]byte(nodeFlags), &flags); err != nil {
+					return fmt.Errorf("failed to parse --flags: %w", err)
+				}
+			}
+			ctx, cancel := context.WithTimeout(context.Background(), tmpnet.DefaultNetworkTimeout)
+			defer cancel()
+			node, err := network.AddNode(ctx, os.Stdout, flags, nodeEphemeral)
+			if err != nil {
+				return err
+			}
+			if err := tmpnet.WaitForHealthy(ctx, node); err != nil {
+				return err
+			}
+			fmt.Fprintf(os.Stdout, "Added node %s @ %s\n", node.NodeID, node.URI)
+			return nil
+		},
+	}
+	addNodeCmd.PersistentFlags().StringVar(&nodeFlags, "flags", "", "[optional] JSON object of avalanchego flags for the node (e.g. '{\"log-level\":\"debug\"}')")
+	addNodeCmd.PersistentFlags().BoolVar(&nodeEphemeral, "ephemeral", false, "Whether the node is excluded from bootstrapping and the node URIs of the network")
+	rootCmd.AddCommand(addNodeCmd)
+
+	var removeNodeID string
+	removeNodeCmd := &cobra.Command{
+		Use:   "remove-node",
+		Short: "Stop a node of a temporary network and remove its data",
+		RunE: func(*cobra.Command, []string) error {
+			network, err := readNetwork(networkDir)
+			if err != nil {
+				return err
+			}
+			nodeID, err := ids.NodeIDFromString(removeNodeID)
+			if err != nil {
+				return err
+			}
+			ctx, cancel := context.WithTimeout(context.Background(), tmpnet.DefaultNetworkTimeout)
+			defer cancel()
+			if err := network.RemoveNode(ctx, nodeID); err != nil {
+				return err
+			}
+			fmt.Fprintf(os.Stdout, "Removed node %s\n", nodeID)
+			return nil
+		},
+	}
+	removeNodeCmd.PersistentFlags().StringVar(&removeNodeID, "node-id", "", "The ID of the node to remove")
+	rootCmd.AddCommand(removeNodeCmd)
+
+	var subnetName string
+	createSubnetCmd := &cobra.Command{
+		Use:   "create-subnet",
+		Short: "Create a subnet validated by all nodes of a temporary network",
+		RunE: func(*cobra.Command, []string) error {
+			if len(subnetName) == 0 {
+				return errSubnetNameRequired
+			}
+			network, err := readNetwork(networkDir)
+			if err != nil {
+				return err
+			}
+			if network.GetSubnet(subnetName) != nil {
+				return fmt.Errorf("a subnet with name %s already exists", subnetName)
+			}
+			network.Subnets = append(network.Subnets, &tmpnet.Subnet{
+				Name: subnetName,
+			})
+			ctx, cancel := context.WithTimeout(context.Background(), tmpnet.DefaultNetworkTimeout)
+			defer cancel()
+			return network.CreateSubnets(ctx, os.Stdout)
+		},
+	}
+	createSubnetCmd.PersistentFlags().StringVar(&subnetName, "name", "", "The name of the subnet")
+	rootCmd.AddCommand(createSubnetCmd)
+
+	var chainManifest tmpnet.ChainManifest
+	createChainCmd := &cobra.Command{
+		Use:   "create-chain",
+		Short: "Create a chain on a subnet of a temporary network",
+		RunE: func(*cobra.Command, []string) error {
+			if len(subnetName) == 0 {
+				return errSubnetNameRequired
+			}
+			network, err := readNetwork(networkDir)
+			if err != nil {
+				return err
+			}
+			chain, err := chainManifest.NewChain()
+			if err != nil {
+				return err
+			}
+			ctx, cancel := context.WithTimeout(context.Background(), tmpnet.DefaultNetworkTimeout)
+			defer cancel()
+			return network.CreateChain(ctx, os.Stdout, subnetName, chain)
+		},
+	}
+	createChainCmd.PersistentFlags().StringVar(&subnetName, "subnet", "", "The name of the subnet to create the chain on")
+	createChainCmd.PersistentFlags().Var(&vmIDValue{id: &chainManifest.VMID}, "vm-id", "The ID of the chain's VM. Mutually exclusive with --vm-name")
+	createChainCmd.PersistentFlags().StringVar(&chainManifest.VMName, "vm-name", "", "The name of the chain's VM, converted to a VM ID by zero-padding its bytes. Mutually exclusive with --vm-id")
+	createChainCmd.PersistentFlags().StringVar(&chainManifest.GenesisFile, "genesis-file", "", "[optional] the path to a file containing the genesis of the chain")
+	createChainCmd.PersistentFlags().StringVar(&chainManifest.ConfigFile, "config-file", "", "[optional] the path to a file containing the configuration of the chain")
+	rootCmd.AddCommand(createChainCmd)
+
+	var outputDir string
+	collectCmd := &cobra.Command{
+		Use:   "collect",
+		Short: "Copy the logs and metrics of the nodes of a temporary network to a directory",
+		RunE: func(*cobra.Command, []string) error {
+			if len(outputDir) == 0 {
+				return errOutputDirRequired
+			}
+			network, err := readNetwork(networkDir)
+			if err != nil {
+				return err
+			}
+			ctx, cancel := context.WithTimeout(context.Background(), tmpnet.DefaultNetworkTimeout)
+			defer cancel()
+			return network.CollectArtifacts(ctx, os.Stdout, outputDir)
+		},
+	}
+	collectCmd.PersistentFlags().StringVar(&outputDir, "output-dir", "", "The path to the directory to copy logs and metrics to")
+	rootCmd.AddCommand(collectCmd)
+
+	var urisAsJSON bool
+	nodeURIsCmd := &cobra.Command{
+		Use:   "node-uris",
+		Short: "Print the API URIs of the running nodes of a temporary network",
+		RunE: func(*cobra.Command, []string) error {
+			network, err := readNetwork(networkDir)
+			if err != nil {
+				return err
+			}
+			uris := network.GetNodeURIs()
+			if urisAsJSON {
+				bytes, err := tmpnet.DefaultJSONMarshal(uris)
+				if err != nil {
+					return err
+				}
+				fmt.Fprintf(os.Stdout, "%s\n", bytes)
+				return nil
+			}
+			for _, uri := range uris {
+				fmt.Fprintf(os.Stdout, "%s %s\n", uri.NodeID, uri.URI)
+			}
+			return nil
+		},
+	}
+	nodeURIsCmd.PersistentFlags().BoolVar(&urisAsJSON, "json", false, "Whether to print the URIs as JSON")
+	rootCmd.AddCommand(nodeURIsCmd)
+
+	var nodeDir string
+	runFaultProxyCmd := &cobra.Command{
+		Use:    tmpnet.FaultProxyCommand,
+		Short:  "Run a fault proxy in front of the staking port of a node",
+		Hidden: true, // Started by tmpnet rather than by users
+		RunE: func(*cobra.Command, []string) error {
+			if len(networkDir) == 0 {
+				return errNetworkDirRequired
+			}
+			if len(nodeDir) == 0 {
+				return errNodeDirRequired
+			}
+			ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+			defer stop()
+			return tmpnet.RunFaultProxy(ctx, os.Stdout, networkDir, nodeDir)
+		},
+	}
+	runFaultProxyCmd.PersistentFlags().StringVar(&nodeDir, "node-dir", "", "The path to the data directory of the node to proxy")
+	rootCmd.AddCommand(runFaultProxyCmd)
+
+	var partitionGroups []string
+	partitionCmd := &cobra.Command{
+		Use:   "partition",
+		Short: "Partition the nodes of a temporary network into groups that can't communicate with each other",
+		RunE: func(*cobra.Command, []string) error {
+			network, err := readNetwork(networkDir)
+			if err != nil {
+				return err
+			}
+			groups := make([][]ids.NodeID, len(partitionGroups))
+			for i, group := range partitionGroups {
+				groups[i], err = parseNodeIDs(group)
+				if err != nil {
+					return err
+				}
+			}
+			if err := network.Partition(groups...); err != nil {
+				return err
+			}
+			fmt.Fprintf(os.Stdout, "Partitioned network into %d group(s)\n", len(groups))
+			return nil
+		},
+	}
+	partitionCmd.PersistentFlags().StringArrayVar(&partitionGroups, "group", nil, "Comma-separated IDs of nodes that form a partition group. May be repeated. Omitting groups heals the partition")
+	rootCmd.AddCommand(partitionCmd)
+
+	var (
+		linkNodeIDs  string
+		linkLatency  time.Duration
+		linkLossRate float64
+	)
+	degradeLinkCmd := &cobra.Command{
+		Use:   "degrade-link",
+		Short: "Add latency or message loss to the link between two nodes of a temporary network",
+		RunE: func(*cobra.Command, []string) error {
+			network, err := readNetwork(networkDir)
+			if err != nil {
+				return err
+			}
+			nodeIDs, err := parseNodeIDs(linkNodeIDs)
+			if err != nil {
+				return err
+			}
+			if len(nodeIDs) != 2 {
+				return errTwoNodeIDsRequired
+			}
+			err = network.SetLinkFault(tmpnet.LinkFault{
+				NodeIDs:  [2]ids.NodeID{nodeIDs[0], nodeIDs[1]},
+				Latency:  linkLatency,
+				LossRate: linkLossRate,
+			})
+			if err != nil {
+				return err
+			}
+			fmt.Fprintf(os.Stdout, "Set latency of %s and loss rate of %f on the link between %s and %s\n", linkLatency, linkLossRate, nodeIDs[0], nodeIDs[1])
+			return nil
+		},
+	}
+	degradeLinkCmd.PersistentFlags().StringVar(&linkNodeIDs, "node-ids", "", "Comma-separated IDs of the two nodes joined by the link")
+	degradeLinkCmd.PersistentFlags().DurationVar(&linkLatency, "latency", 0, "Delay to add to every message sent over the link")
+	degradeLinkCmd.PersistentFlags().Float64Var(&linkLossRate, "loss-rate", 0, "Fraction of the messages sent over the link to drop")
+	rootCmd.AddCommand(degradeLinkCmd)
+
+	clearFaultsCmd := &cobra.Command{
+		Use:   "clear-faults",
+		Short: "Remove all partitions and link faults from a temporary network",
+		RunE: func(*cobra.Command, []string) error {
+			network, err := readNetwork(networkDir)
+			if err != nil {
+				return err
+			}
+			if err := network.ClearFaults(); err != nil {
+				return err
+			}
+			fmt.Fprintf(os.Stdout, "Cleared faults of network configured at: %s\n", networkDir)
+			return nil
+		},
+	}
+	rootCmd.AddCommand(clearFaultsCmd)
+
+	var targetNodeID string
+	pauseNodeCmd := &cobra.Command{
+		Use:   "pause-node",
+		Short: "Suspend a node of a temporary network without stopping it",
+		RunE: func(*cobra.Command, []string) error {
+			node, err := readNetworkNode(networkDir, targetNodeID)
+			if err != nil {
+				return err
+			}
+			if err := node.Pause(); err != nil {
+				return err
+			}
+			fmt.Fprintf(os.Stdout, "Paused node %s\n", node.NodeID)
+			return nil
+		},
+	}
+	pauseNodeCmd.PersistentFlags().StringVar(&targetNodeID, "node-id", "", "The ID of the node to pause")
+	rootCmd.AddCommand(pauseNodeCmd)
+
+	resumeNodeCmd := &cobra.Command{
+		Use:   "resume-node",
+		Short: "Resume a paused node of a temporary network",
+		RunE: func(*cobra.Command, []string) error {
+			node, err := readNetworkNode(networkDir, targetNodeID)
+			if err != nil {
+				return err
+			}
+			if err := node.Resume(); err != nil {
+				return err
+			}
+			fmt.Fprintf(os.Stdout, "Resumed node %s\n", node.NodeID)
+			return nil
+		},
+	}
+	resumeNodeCmd.PersistentFlags().StringVar(&targetNodeID, "node-id", "", "The ID of the node to resume")
+	rootCmd.AddCommand(resumeNodeCmd)
+
+	var clockSkew time.Duration
+	skewClockCmd := &cobra.Command{
+		Use:   "skew-clock",
+		Short: "Restart a node of a temporary network with its clock skewed. Requires avalanchego to be built with the clockskew tag",
+		RunE: func(*cobra.Command, []string) error {
+			network, err := readNetwork(networkDir)
+			if err != nil {
+				return err
+			}
+			nodeID, err := ids.NodeIDFromString(targetNodeID)
+			if err != nil {
+				return err
+			}
+			node, err := network.GetNode(nodeID)
+			if err != nil {
+				return err
+			}
+			ctx, cancel := context.WithTimeout(context.Background(), tmpnet.DefaultNetworkTimeout)
+			defer cancel()
+			return network.SkewNodeClock(ctx, os.Stdout, node, clockSkew)
+		},
+	}
+	skewClockCmd.PersistentFlags().StringVar(&targetNodeID, "node-id", "", "The ID of the node whose clock to skew")
+	skewClockCmd.PersistentFlags().DurationVar(&clockSkew, "skew", 0, "Offset to add to the node's clock. 0 restores the node's clock")
+	rootCmd.AddCommand(skewClockCmd)
+
 	if err := rootCmd.Execute(); err != nil {
 		fmt.Fprintf(os.Stderr, "tmpnetctl failed: %v\n", err)
 		os.Exit(1)
 	}
 	os.Exit(0)
 }
+
+// Symlinks the new network to the 'latest' network to simplify usage
+func linkLatestNetwork(network *tmpnet.Network) error {
+	networkRootDir := filepath.Dir(network.Dir)
+	networkDirName := filepath.Base(network.Dir)
+	latestSymlinkPath := filepath.Join(networkRootDir, "latest")
+	if err := os.Remove(latestSymlinkPath); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+	if err := os.Symlink(networkDirName, latestSymlinkPath); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(os.Stdout, "\nConfigure tmpnetctl to target this network by default with one of the following statements:\n")
+	fmt.Fprintf(os.Stdout, " - source %s\n", network.EnvFilePath())
+	fmt.Fprintf(os.Stdout, " - %s\n", network.EnvFileContents())
+	fmt.Fprintf(os.Stdout, " - export %s=%s\n", tmpnet.NetworkDirEnvName, latestSymlinkPath)
+
+	return nil
+}
+
+func readNetwork(networkDir string) (*tmpnet.Network, error) {
+	if len(networkDir) == 0 {
+		return nil, errNetworkDirRequired
+	}
+	return tmpnet.ReadNetwork(networkDir)
+}
+
+func readNetworkNode(networkDir string, nodeID string) (*tmpnet.Node, error) {
+	network, err := readNetwork(networkDir)
+	if err != nil {
+		return nil, err
+	}
+	parsedNodeID, err := ids.NodeIDFromString(nodeID)
+	if err != nil {
+		return nil, err
+	}
+	return network.GetNode(parsedNodeID)
+}
+
+func parseNodeIDs(nodeIDs string) ([]ids.NodeID, error) {
+	parsedNodeIDs := []ids.NodeID{}
+	for _, nodeID := range strings.Split(nodeIDs, ",") {
+		nodeID = strings.TrimSpace(nodeID)
+		if len(nodeID) == 0 {
+			continue
+		}
+		parsedNodeID, err := ids.NodeIDFromString(nodeID)
+		if err != nil {
+			return nil, err
+		}
+		parsedNodeIDs = append(parsedNodeIDs, parsedNodeID)
+	}
+	return parsedNodeIDs, nil
+}
+
+// Supports parsing an ID flag with the pflag.Value interface
+type vmIDValue struct {
+	id *ids.ID
+}
+
+func (v *vmIDValue) String() string {
+	if v.id == nil || *v.id == ids.Empty {
+		return ""
+	}
+	return v.id.String()
+}
+
+func (v *vmIDValue) Set(value string) error {
+	id, err := ids.FromString(value)
+	if err != nil {
+		return err
+	}
+	*v.id = id
+	return nil
+}
+
+func (*vmIDValue) Type() string {
+	return "id"
+}
@@ -0,0 +1,636 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package tmpnet
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"math/rand"
+	"net"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/ava-labs/avalanchego/config"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/network/peer"
+	"github.com/ava-labs/avalanchego/node"
+	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/ava-labs/avalanchego/utils/perms"
+	"github.com/ava-labs/avalanchego/utils/wrappers"
+)
+
+const (
+	TmpnetctlPathEnvName = "TMPNETCTL_PATH"
+
+	faultProxyContextFilename = "fault_proxy.json"
+	faultProxyLogFilename     = "fault_proxy.log"
+
+	// The tmpnetctl subcommand that runs a fault proxy
+	FaultProxyCommand = "run-fault-proxy"
+
+	faultsPollInterval    = 100 * time.Millisecond
+	faultProxyDialTimeout = 5 * time.Second
+	// Bounds the number of messages being delayed on a link before reads
+	// from the sender are blocked.
+	maxDelayedMessages = 1024
+)
+
+var (
+	errUnknownPeer      = errors.New("peer is not a node of the network")
+	errUnexpectedNodeID = errors.New("node presented an unexpected node ID")
+	errLinkPartitioned  = errors.New("link is partitioned")
+)
+
+// FaultProxyContext describes a running fault proxy. It is written to the data
+// dir of the node the proxy runs in front of.
+type FaultProxyContext struct {
+	// PID of the fault proxy process
+	PID int `json:"pid"`
+	// The address that peers connect to the node with
+	Address string `json:"address"`
+}
+
+// FaultProxy forwards the connections of peers to the staking port of a node
+// and applies the faults injected into the network to them.
+//
+// Every connection between two nodes of the network is established to the
+// proxy of one of them. To identify the peer at the other end of a connection,
+// the proxy terminates TLS with the staking certificate of its node and
+// connects to its node with the staking certificate of the peer. Both
+// certificates are known to the network, so no root privileges are required.
+// The plaintext stream is forwarded message by message, which allows messages
+// to be delayed or dropped.
+type FaultProxy struct {
+	networkDir string
+	nodeDir    string
+	nodeID     ids.NodeID
+	listener   net.Listener
+
+	serverUpgrader peer.Upgrader
+	invalidCerts   prometheus.Counter
+
+	lock      sync.Mutex
+	faults    *NetworkFaults
+	peerCerts map[ids.NodeID]*tls.Certificate
+	conns     map[*proxyConn]struct{}
+
+	wg sync.WaitGroup
+}
+
+type proxyConn struct {
+	peerID   ids.NodeID
+	inbound  net.Conn
+	outbound net.Conn
+	once     sync.Once
+}
+
+func (c *proxyConn) close() {
+	c.once.Do(func() {
+		_ = c.inbound.Close()
+		_ = c.outbound.Close()
+	})
+}
+
+// NewFaultProxy starts listening for the connections of peers to the node
+// configured in [nodeDir]. The proxy listens on the node's public port if it
+// is set and on a dynamically allocated port otherwise.
+func NewFaultProxy(networkDir string, nodeDir string) (*FaultProxy, error) {
+	node, err := ReadNode(nodeDir)
+	if err != nil {
+		return nil, err
+	}
+	cert, err := node.getTLSCert()
+	if err != nil {
+		return nil, err
+	}
+	faults, err := readFaults(networkDir)
+	if err != nil {
+		return nil, err
+	}
+
+	host, err := node.Flags.GetStringVal(config.StakingHostKey)
+	if err != nil {
+		return nil, err
+	}
+	port, err := node.Flags.GetStringVal(config.PublicPortKey)
+	if err != nil {
+		return nil, err
+	}
+	if len(port) == 0 {
+		port = "0"
+	}
+	listener, err := net.Listen(constants.NetworkType, net.JoinHostPort(host, port))
+	if err != nil {
+		return nil, fmt.Errorf("failed to listen for peers of node %s: %w", node.NodeID, err)
+	}
+
+	invalidCerts := prometheus.NewCounter(prometheus.CounterOpts{})
+	return &FaultProxy{
+		networkDir:     networkDir,
+		nodeDir:        nodeDir,
+		nodeID:         node.NodeID,
+		listener:       listener,
+		serverUpgrader: peer.NewTLSServerUpgrader(peer.TLSConfig(*cert, nil), invalidCerts),
+		invalidCerts:   invalidCerts,
+		faults:         faults,
+		peerCerts:      map[ids.NodeID]*tls.Certificate{},
+		conns:          map[*proxyConn]struct{}{},
+	}, nil
+}
+
+// Address returns the address that peers connect to the node with.
+func (p *FaultProxy) Address() string {
+	return p.listener.Addr().String()
+}
+
+// Run forwards connections until [ctx] is cancelled.
+func (p *FaultProxy) Run(ctx context.Context, w io.Writer) error {
+	p.wg.Add(1)
+	go func() {
+		defer p.wg.Done()
+		p.pollFaults(ctx, w)
+	}()
+
+	go func() {
+		<-ctx.Done()
+		_ = p.listener.Close()
+	}()
+
+	defer func() {
+		p.lock.Lock()
+		for conn := range p.conns {
+			conn.close()
+		}
+		p.lock.Unlock()
+		p.wg.Wait()
+	}()
+
+	for {
+		conn, err := p.listener.Accept()
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+			return err
+		}
+
+		p.wg.Add(1)
+		go func() {
+			defer p.wg.Done()
+			if err := p.handle(conn); err != nil {
+				fmt.Fprintf(w, "closed connection from %s: %v\n", conn.RemoteAddr(), err)
+			}
+		}()
+	}
+}
+
+// Reloads the faults of the network and closes the connections of peers that
+// are partitioned from the node.
+func (p *FaultProxy) pollFaults(ctx context.Context, w io.Writer) {
+	ticker := time.NewTicker(faultsPollInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		faults, err := readFaults(p.networkDir)
+		if err != nil {
+			fmt.Fprintf(w, "failed to reload faults: %v\n", err)
+			continue
+		}
+
+		p.lock.Lock()
+		p.faults = faults
+		for conn := range p.conns {
+			if faults.IsPartitioned(p.nodeID, conn.peerID) {
+				conn.close()
+			}
+		}
+		p.lock.Unlock()
+	}
+}
+
+func (p *FaultProxy) getLink(peerID ids.NodeID) LinkFault {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	return p.faults.Link(p.nodeID, peerID)
+}
+
+func (p *FaultProxy) isPartitioned(peerID ids.NodeID) bool {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	return p.faults.IsPartitioned(p.nodeID, peerID)
+}
+
+// Returns the staking certificate of the peer, reading the nodes of the network
+// if the peer isn't known yet (e.g. an ephemeral node that was just added).
+func (p *FaultProxy) getPeerCert(peerID ids.NodeID) (*tls.Certificate, error) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	if cert, ok := p.peerCerts[peerID]; ok {
+		return cert, nil
+	}
+
+	nodes, err := ReadNodes(p.networkDir, true /* includeEphemeral */)
+	if err != nil {
+		return nil, err
+	}
+	for _, node := range nodes {
+		cert, err := node.getTLSCert()
+		if err != nil {
+			return nil, err
+		}
+		p.peerCerts[node.NodeID] = cert
+	}
+
+	cert, ok := p.peerCerts[peerID]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", errUnknownPeer, peerID)
+	}
+	return cert, nil
+}
+
+func (p *FaultProxy) handle(conn net.Conn) error {
+	// Avoid blocking forever on a peer that never completes the handshake
+	if err := conn.SetDeadline(time.Now().Add(faultProxyDialTimeout)); err != nil {
+		_ = conn.Close()
+		return err
+	}
+	peerID, inbound, _, err := p.serverUpgrader.Upgrade(conn)
+	if err != nil {
+		_ = conn.Close()
+		return err
+	}
+	if err := p.connect(peerID, inbound); err != nil {
+		_ = inbound.Close()
+		return fmt.Errorf("peer %s: %w", peerID, err)
+	}
+	return nil
+}
+
+func (p *FaultProxy) connect(peerID ids.NodeID, inbound net.Conn) error {
+	if err := inbound.SetDeadline(time.Time{}); err != nil {
+		return err
+	}
+	if p.isPartitioned(peerID) {
+		return errLinkPartitioned
+	}
+
+	cert, err := p.getPeerCert(peerID)
+	if err != nil {
+		return err
+	}
+
+	// The node's staking address changes when the node is restarted
+	processContext, err := readNodeProcessContext(p.nodeDir)
+	if err != nil {
+		return err
+	}
+	if len(processContext.StakingAddress) == 0 {
+		return ErrNotRunning
+	}
+	rawConn, err := net.DialTimeout(constants.NetworkType, processContext.StakingAddress, faultProxyDialTimeout)
+	if err != nil {
+		return err
+	}
+	if err := rawConn.SetDeadline(time.Now().Add(faultProxyDialTimeout)); err != nil {
+		_ = rawConn.Close()
+		return err
+	}
+	clientUpgrader := peer.NewTLSClientUpgrader(peer.TLSConfig(*cert, nil), p.invalidCerts)
+	nodeID, outbound, _, err := clientUpgrader.Upgrade(rawConn)
+	if err != nil {
+		_ = rawConn.Close()
+		return err
+	}
+	if nodeID != p.nodeID {
+		_ = outbound.Close()
+		return fmt.Errorf("%w: expected %s but got %s", errUnexpectedNodeID, p.nodeID, nodeID)
+	}
+	if err := outbound.SetDeadline(time.Time{}); err != nil {
+		_ = outbound.Close()
+		return err
+	}
+
+	conn := &proxyConn{
+		peerID:   peerID,
+		inbound:  inbound,
+		outbound: outbound,
+	}
+	p.lock.Lock()
+	p.conns[conn] = struct{}{}
+	p.lock.Unlock()
+
+	var wg sync.WaitGroup
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		p.forward(conn, inbound, outbound)
+	}()
+	go func() {
+		defer wg.Done()
+		p.forward(conn, outbound, inbound)
+	}()
+	wg.Wait()
+
+	p.lock.Lock()
+	delete(p.conns, conn)
+	p.lock.Unlock()
+	return nil
+}
+
+type delayedMessage struct {
+	msg       []byte
+	deliverAt time.Time
+}
+
+// Forwards length-prefixed messages from [src] to [dst], delaying or dropping
+// them according to the fault of the link. The connection is closed once
+// either side fails.
+func (p *FaultProxy) forward(conn *proxyConn, src net.Conn, dst net.Conn) {
+	defer conn.close()
+
+	var (
+		messages = make(chan delayedMessage, maxDelayedMessages)
+		done     = make(chan struct{})
+	)
+	go func() {
+		defer close(done)
+
+		// Messages are drained after a failed write so that the reader is
+		// never blocked.
+		failed := false
+		for message := range messages {
+			if failed {
+				continue
+			}
+			time.Sleep(time.Until(message.deliverAt))
+			if _, err := dst.Write(message.msg); err != nil {
+				conn.close()
+				failed = true
+			}
+		}
+	}()
+	defer func() {
+		close(messages)
+		<-done
+	}()
+
+	for {
+		msgLenBytes := make([]byte, wrappers.IntLen)
+		if _, err := io.ReadFull(src, msgLenBytes); err != nil {
+			return
+		}
+		msgLen := binary.BigEndian.Uint32(msgLenBytes)
+		if msgLen > constants.DefaultMaxMessageSize {
+			return
+		}
+		msg := make([]byte, wrappers.IntLen+int(msgLen))
+		copy(msg, msgLenBytes)
+		if _, err := io.ReadFull(src, msg[wrappers.IntLen:]); err != nil {
+			return
+		}
+
+		link := p.getLink(conn.peerID)
+		if link.LossRate > 0 && rand.Float64() < link.LossRate { // #nosec G404
+			continue
+		}
+		messages <- delayedMessage{
+			msg:       msg,
+			deliverAt: time.Now().Add(link.Latency),
+		}
+	}
+}
+
+// RunFaultProxy runs a fault proxy in front of the node configured in
+// [nodeDir] until [ctx] is cancelled. The address of the proxy is written to
+// the node's data dir so that the network can direct peers to it.
+func RunFaultProxy(ctx context.Context, w io.Writer, networkDir string, nodeDir string) error {
+	proxy, err := NewFaultProxy(networkDir, nodeDir)
+	if err != nil {
+		return err
+	}
+
+	contextPath := filepath.Join(nodeDir, faultProxyContextFilename)
+	bytes, err := DefaultJSONMarshal(FaultProxyContext{
+		PID:     os.Getpid(),
+		Address: proxy.Address(),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to marshal fault proxy context: %w", err)
+	}
+	if err := os.WriteFile(contextPath, bytes, perms.ReadWrite); err != nil {
+		return fmt.Errorf("failed to write fault proxy context: %w", err)
+	}
+	fmt.Fprintf(w, "Forwarding peers of node %s from %s\n", proxy.nodeID, proxy.Address())
+
+	err = proxy.Run(ctx, w)
+	// Removing the context indicates to the network that the proxy stopped
+	if removeErr := os.Remove(contextPath); removeErr != nil && !errors.Is(removeErr, fs.ErrNotExist) {
+		err = errors.Join(err, removeErr)
+	}
+	return err
+}
+
+func readNodeProcessContext(nodeDir string) (node.NodeProcessContext, error) {
+	processContext := node.NodeProcessContext{}
+	bytes, err := os.ReadFile(filepath.Join(nodeDir, config.DefaultProcessContextFilename))
+	if errors.Is(err, fs.ErrNotExist) {
+		return processContext, nil
+	}
+	if err != nil {
+		return processContext, fmt.Errorf("failed to read node process context: %w", err)
+	}
+	if err := json.Unmarshal(bytes, &processContext); err != nil {
+		return processContext, fmt.Errorf("failed to unmarshal node process context: %w", err)
+	}
+	return processContext, nil
+}
+
+// Returns true if peers connect to the node through a fault proxy.
+func (n *Node) hasFaultProxy() bool {
+	return n.RuntimeConfig != nil && len(n.RuntimeConfig.TmpnetctlPath) > 0
+}
+
+func (n *Node) getFaultProxyContextPath() string {
+	return filepath.Join(n.getDataDir(), faultProxyContextFilename)
+}
+
+// Reads the context of the node's fault proxy. A zero context is returned if
+// the proxy isn't running.
+func (n *Node) readFaultProxyContext() (FaultProxyContext, error) {
+	proxyContext := FaultProxyContext{}
+	bytes, err := os.ReadFile(n.getFaultProxyContextPath())
+	if errors.Is(err, fs.ErrNotExist) {
+		return proxyContext, nil
+	}
+	if err != nil {
+		return proxyContext, fmt.Errorf("failed to read fault proxy context: %w", err)
+	}
+	if err := json.Unmarshal(bytes, &proxyContext); err != nil {
+		return proxyContext, fmt.Errorf("failed to unmarshal fault proxy context: %w", err)
+	}
+	return proxyContext, nil
+}
+
+// Ensures the node's fault proxy is running and configures the node to
+// advertise the proxy's port to its peers. The proxy keeps its port across
+// restarts so that peers can reconnect to the node at the same address.
+func (n *Node) startFaultProxy(ctx context.Context, networkDir string) error {
+	if !n.hasFaultProxy() {
+		return nil
+	}
+
+	proxyContext, err := n.readFaultProxyContext()
+	if err != nil {
+		return err
+	}
+	if proxyContext.PID == 0 {
+		if err := n.Write(); err != nil {
+			return err
+		}
+
+		logFile, err := os.OpenFile(
+			filepath.Join(n.getDataDir(), faultProxyLogFilename),
+			os.O_CREATE|os.O_APPEND|os.O_WRONLY,
+			perms.ReadWrite,
+		)
+		if err != nil {
+			return fmt.Errorf("failed to open fault proxy log: %w", err)
+		}
+		defer logFile.Close()
+
+		cmd := exec.Command( // #nosec G204
+			n.RuntimeConfig.TmpnetctlPath,
+			FaultProxyCommand,
+			"--network-dir", networkDir,
+			"--node-dir", n.getDataDir(),
+		)
+		cmd.Stdout = logFile
+		cmd.Stderr = logFile
+		// Ensure the proxy is detached from the parent process so that it
+		// outlives the process that started the network.
+		cmd.SysProcAttr = &syscall.SysProcAttr{
+			Setsid: true,
+		}
+		if err := cmd.Start(); err != nil {
+			return fmt.Errorf("failed to start fault proxy: %w", err)
+		}
+
+		proxyContext, err = n.waitForFaultProxyContext(ctx)
+		if err != nil {
+			return err
+		}
+	}
+
+	_, port, err := net.SplitHostPort(proxyContext.Address)
+	if err != nil {
+		return err
+	}
+	n.Flags[config.PublicPortKey] = port
+	return nil
+}
+
+func (n *Node) waitForFaultProxyContext(ctx context.Context) (FaultProxyContext, error) {
+	ticker := time.NewTicker(defaultNodeTickerInterval)
+	defer ticker.Stop()
+
+	ctx, cancel := context.WithTimeout(ctx, defaultNodeInitTimeout)
+	defer cancel()
+	for {
+		proxyContext, err := n.readFaultProxyContext()
+		if err != nil {
+			return FaultProxyContext{}, err
+		}
+		if proxyContext.PID != 0 {
+			return proxyContext, nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return FaultProxyContext{}, fmt.Errorf("failed to start fault proxy for node %q before timeout: %w", n.NodeID, ctx.Err())
+		case <-ticker.C:
+		}
+	}
+}
+
+// Stops the node's fault proxy and waits for it to remove its context.
+func (n *Node) stopFaultProxy(ctx context.Context) error {
+	proxyContext, err := n.readFaultProxyContext()
+	if err != nil {
+		return err
+	}
+	if proxyContext.PID == 0 {
+		return nil
+	}
+
+	proc, err := os.FindProcess(proxyContext.PID)
+	if err != nil {
+		return fmt.Errorf("failed to find fault proxy process: %w", err)
+	}
+	if err := proc.Signal(syscall.SIGTERM); err != nil {
+		if errors.Is(err, os.ErrProcessDone) {
+			// The proxy exited without removing its context
+			return os.Remove(n.getFaultProxyContextPath())
+		}
+		return fmt.Errorf("failed to send SIGTERM to fault proxy pid %d: %w", proxyContext.PID, err)
+	}
+
+	ticker := time.NewTicker(defaultNodeTickerInterval)
+	defer ticker.Stop()
+	for {
+		proxyContext, err := n.readFaultProxyContext()
+		if err != nil {
+			return err
+		}
+		if proxyContext.PID == 0 {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("failed to see fault proxy of node %q stop before timeout: %w", n.NodeID, ctx.Err())
+		case <-ticker.C:
+		}
+	}
+}
+
+// Returns the address that peers connect to the node with. If the node runs
+// behind a fault proxy, this is the address of the proxy.
+func (n *Node) getPeerAddress() (string, error) {
+	if len(n.StakingAddress) == 0 || !n.hasFaultProxy() {
+		return n.StakingAddress, nil
+	}
+	host, _, err := net.SplitHostPort(n.StakingAddress)
+	if err != nil {
+		return "", err
+	}
+	port, err := n.Flags.GetStringVal(config.PublicPortKey)
+	if err != nil {
+		return "", err
+	}
+	if _, err := strconv.ParseUint(port, 10, 16); err != nil {
+		return "", fmt.Errorf("invalid public port for node %s: %w", n.NodeID, err)
+	}
+	return net.JoinHostPort(host, port), nil
+}
@@ -0,0 +1,188 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package tmpnet
+
+import (
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"io"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/config"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/network/peer"
+	"github.com/ava-labs/avalanchego/node"
+	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/ava-labs/avalanchego/utils/perms"
+	"github.com/ava-labs/avalanchego/utils/wrappers"
+)
+
+// Writes the configuration of a node that runs behind a fault proxy to the
+// network dir.
+func newProxiedTestNode(t *testing.T, networkDir string, name string) *Node {
+	require := require.New(t)
+
+	node := NewNode(filepath.Join(networkDir, name))
+	require.NoError(node.EnsureKeys())
+	node.Flags[config.StakingHostKey] = "127.0.0.1"
+	node.RuntimeConfig = &NodeRuntimeConfig{
+		TmpnetctlPath: "tmpnetctl",
+	}
+	require.NoError(node.Write())
+	return node
+}
+
+// Starts a staking server for the node that echoes the messages of its peers
+// and records the node IDs of the peers.
+func startEchoNode(t *testing.T, n *Node) <-chan ids.NodeID {
+	require := require.New(t)
+
+	cert, err := n.getTLSCert()
+	require.NoError(err)
+	listener, err := net.Listen(constants.NetworkType, "127.0.0.1:0")
+	require.NoError(err)
+	t.Cleanup(func() {
+		_ = listener.Close()
+	})
+
+	bytes, err := json.Marshal(node.NodeProcessContext{
+		PID:            os.Getpid(),
+		StakingAddress: listener.Addr().String(),
+	})
+	require.NoError(err)
+	require.NoError(os.WriteFile(filepath.Join(n.getDataDir(), config.DefaultProcessContextFilename), bytes, perms.ReadWrite))
+
+	upgrader := peer.NewTLSServerUpgrader(peer.TLSConfig(*cert, nil), prometheus.NewCounter(prometheus.CounterOpts{}))
+	peerIDs := make(chan ids.NodeID, 10)
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			go func() {
+				defer conn.Close()
+				peerID, tlsConn, _, err := upgrader.Upgrade(conn)
+				if err != nil {
+					return
+				}
+				peerIDs <- peerID
+				_, _ = io.Copy(tlsConn, tlsConn)
+			}()
+		}
+	}()
+	return peerIDs
+}
+
+// Connects to [address] as [n] and verifies that the server is [expectedID].
+func dialAsNode(t *testing.T, n *Node, address string, expectedID ids.NodeID) net.Conn {
+	require := require.New(t)
+
+	cert, err := n.getTLSCert()
+	require.NoError(err)
+	conn, err := net.Dial(constants.NetworkType, address)
+	require.NoError(err)
+	upgrader := peer.NewTLSClientUpgrader(peer.TLSConfig(*cert, nil), prometheus.NewCounter(prometheus.CounterOpts{}))
+	nodeID, tlsConn, _, err := upgrader.Upgrade(conn)
+	require.NoError(err)
+	require.Equal(expectedID, nodeID)
+	t.Cleanup(func() {
+		_ = tlsConn.Close()
+	})
+	return tlsConn
+}
+
+func writeMessage(t *testing.T, conn net.Conn, msg []byte) {
+	msgLenBytes := make([]byte, wrappers.IntLen)
+	binary.BigEndian.PutUint32(msgLenBytes, uint32(len(msg)))
+	_, err := conn.Write(append(msgLenBytes, msg...))
+	require.NoError(t, err)
+}
+
+func readMessage(conn net.Conn) ([]byte, error) {
+	msgLenBytes := make([]byte, wrappers.IntLen)
+	if _, err := io.ReadFull(conn, msgLenBytes); err != nil {
+		return nil, err
+	}
+	msg := make([]byte, binary.BigEndian.Uint32(msgLenBytes))
+	_, err := io.ReadFull(conn, msg)
+	return msg, err
+}
+
+func TestFaultProxy(t *testing.T) {
+	require := require.New(t)
+
+	networkDir := t.TempDir()
+	var (
+		peerNode  = newProxiedTestNode(t, networkDir, "peer")
+		proxyNode = newProxiedTestNode(t, networkDir, "node")
+		network   = &Network{
+			Dir:   networkDir,
+			Nodes: []*Node{peerNode, proxyNode},
+		}
+	)
+	peerIDs := startEchoNode(t, proxyNode)
+
+	proxy, err := NewFaultProxy(networkDir, proxyNode.getDataDir())
+	require.NoError(err)
+	ctx, cancel := context.WithCancel(context.Background())
+	proxyDone := make(chan error)
+	go func() {
+		proxyDone <- proxy.Run(ctx, io.Discard)
+	}()
+	defer func() {
+		cancel()
+		require.NoError(<-proxyDone)
+	}()
+
+	// The node sees the connection as coming from the peer
+	conn := dialAsNode(t, peerNode, proxy.Address(), proxyNode.NodeID)
+	require.Equal(peerNode.NodeID, <-peerIDs)
+
+	msg := []byte("ping")
+	writeMessage(t, conn, msg)
+	echoed, err := readMessage(conn)
+	require.NoError(err)
+	require.Equal(msg, echoed)
+
+	// Latency is added to the messages sent in both directions
+	latency := 100 * time.Millisecond
+	require.NoError(network.SetLinkFault(LinkFault{
+		NodeIDs: [2]ids.NodeID{peerNode.NodeID, proxyNode.NodeID},
+		Latency: latency,
+	}))
+	time.Sleep(2 * faultsPollInterval)
+	start := time.Now()
+	writeMessage(t, conn, msg)
+	echoed, err = readMessage(conn)
+	require.NoError(err)
+	require.Equal(msg, echoed)
+	require.GreaterOrEqual(time.Since(start), 2*latency)
+
+	// Partitioning the nodes closes the connection
+	require.NoError(network.Partition(
+		[]ids.NodeID{peerNode.NodeID},
+		[]ids.NodeID{proxyNode.NodeID},
+	))
+	require.NoError(conn.SetReadDeadline(time.Now().Add(5 * time.Second)))
+	_, err = readMessage(conn)
+	require.ErrorIs(err, io.EOF)
+
+	// Healing the partition allows the peer to reconnect
+	require.NoError(network.ClearFaults())
+	time.Sleep(2 * faultsPollInterval)
+	conn = dialAsNode(t, peerNode, proxy.Address(), proxyNode.NodeID)
+	writeMessage(t, conn, msg)
+	echoed, err = readMessage(conn)
+	require.NoError(err)
+	require.Equal(msg, echoed)
+}
@@ -0,0 +1,227 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package tmpnet
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/perms"
+	"github.com/ava-labs/avalanchego/utils/set"
+)
+
+const faultsFilename = "faults.json"
+
+var (
+	errNodeInMultipleGroups = errors.New("node is in multiple partition groups")
+	errInvalidLink          = errors.New("link must join two distinct nodes")
+	errInvalidLatency       = errors.New("latency must be non-negative")
+	errInvalidLossRate      = errors.New("loss rate must be in the range [0, 1]")
+	errFaultProxyRequired   = errors.New("network faults require nodes to run behind a fault proxy")
+)
+
+// NetworkFaults are the faults injected into the links between the nodes of a
+// network. They are stored in the network dir and applied by the fault proxy
+// running in front of the staking port of each node.
+type NetworkFaults struct {
+	// Groups of nodes that can only communicate with nodes of the same group.
+	// A node that isn't in any group can communicate with every node.
+	Partitions [][]ids.NodeID `json:"partitions,omitempty"`
+	// Faults of the links between pairs of nodes.
+	Links []LinkFault `json:"links,omitempty"`
+}
+
+// LinkFault degrades the link between two nodes in both directions.
+type LinkFault struct {
+	// The nodes at either end of the link.
+	NodeIDs [2]ids.NodeID `json:"nodeIDs"`
+	// Delay added to every message sent over the link.
+	Latency time.Duration `json:"latency,omitempty"`
+	// Fraction of the messages sent over the link that are dropped.
+	LossRate float64 `json:"lossRate,omitempty"`
+}
+
+func (l LinkFault) joins(nodeID0, nodeID1 ids.NodeID) bool {
+	return (l.NodeIDs[0] == nodeID0 && l.NodeIDs[1] == nodeID1) ||
+		(l.NodeIDs[0] == nodeID1 && l.NodeIDs[1] == nodeID0)
+}
+
+func (l LinkFault) isZero() bool {
+	return l.Latency == 0 && l.LossRate == 0
+}
+
+func (f *NetworkFaults) Verify() error {
+	grouped := set.Set[ids.NodeID]{}
+	for _, group := range f.Partitions {
+		for _, nodeID := range group {
+			if grouped.Contains(nodeID) {
+				return fmt.Errorf("%w: %s", errNodeInMultipleGroups, nodeID)
+			}
+			grouped.Add(nodeID)
+		}
+	}
+	for _, link := range f.Links {
+		if link.NodeIDs[0] == link.NodeIDs[1] {
+			return fmt.Errorf("%w: %s", errInvalidLink, link.NodeIDs[0])
+		}
+		if link.Latency < 0 {
+			return fmt.Errorf("%w: %s", errInvalidLatency, link.Latency)
+		}
+		if link.LossRate < 0 || link.LossRate > 1 {
+			return fmt.Errorf("%w: %f", errInvalidLossRate, link.LossRate)
+		}
+	}
+	return nil
+}
+
+// IsPartitioned returns true if the two nodes are in different partition
+// groups.
+func (f *NetworkFaults) IsPartitioned(nodeID0, nodeID1 ids.NodeID) bool {
+	group0, group1 := -1, -1
+	for i, group := range f.Partitions {
+		for _, nodeID := range group {
+			switch nodeID {
+			case nodeID0:
+				group0 = i
+			case nodeID1:
+				group1 = i
+			}
+		}
+	}
+	return group0 != -1 && group1 != -1 && group0 != group1
+}
+
+// Link returns the fault of the link between the two nodes. If the link isn't
+// degraded, a zero fault is returned.
+func (f *NetworkFaults) Link(nodeID0, nodeID1 ids.NodeID) LinkFault {
+	for _, link := range f.Links {
+		if link.joins(nodeID0, nodeID1) {
+			return link
+		}
+	}
+	return LinkFault{
+		NodeIDs: [2]ids.NodeID{nodeID0, nodeID1},
+	}
+}
+
+func readFaults(networkDir string) (*NetworkFaults, error) {
+	bytes, err := os.ReadFile(filepath.Join(networkDir, faultsFilename))
+	if errors.Is(err, fs.ErrNotExist) {
+		// The absence of the faults file indicates that no faults are injected
+		return &NetworkFaults{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read network faults: %w", err)
+	}
+	faults := &NetworkFaults{}
+	if err := json.Unmarshal(bytes, faults); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal network faults: %w", err)
+	}
+	return faults, nil
+}
+
+// ReadFaults returns the faults currently injected into the network.
+func (n *Network) ReadFaults() (*NetworkFaults, error) {
+	return readFaults(n.Dir)
+}
+
+// WriteFaults replaces the faults injected into the network. The fault proxies
+// of running nodes apply the new faults within a fraction of a second.
+func (n *Network) WriteFaults(faults *NetworkFaults) error {
+	if err := faults.Verify(); err != nil {
+		return err
+	}
+	for _, node := range n.Nodes {
+		if !node.hasFaultProxy() {
+			return fmt.Errorf("%w: %s", errFaultProxyRequired, node.NodeID)
+		}
+	}
+
+	bytes, err := DefaultJSONMarshal(faults)
+	if err != nil {
+		return fmt.Errorf("failed to marshal network faults: %w", err)
+	}
+	// Write to a temporary file and rename it to ensure that a fault proxy
+	// never reads a partially written file.
+	path := filepath.Join(n.Dir, faultsFilename)
+	tmpPath := path + ".tmp"
+	if err := os.WriteFile(tmpPath, bytes, perms.ReadWrite); err != nil {
+		return fmt.Errorf("failed to write network faults: %w", err)
+	}
+	if err := os.Rename(tmpPath, path); err != nil {
+		return fmt.Errorf("failed to write network faults: %w", err)
+	}
+	return nil
+}
+
+// Partition prevents communication between nodes of different groups. Any
+// existing partition is replaced and connections between nodes of different
+// groups are closed.
+func (n *Network) Partition(groups ...[]ids.NodeID) error {
+	faults, err := n.ReadFaults()
+	if err != nil {
+		return err
+	}
+	faults.Partitions = groups
+	return n.WriteFaults(faults)
+}
+
+// HealPartition allows communication between all nodes again.
+func (n *Network) HealPartition() error {
+	return n.Partition()
+}
+
+// SetLinkFault degrades the link between two nodes, replacing any previous
+// fault of the link. A fault with zero latency and loss rate restores the link.
+func (n *Network) SetLinkFault(fault LinkFault) error {
+	faults, err := n.ReadFaults()
+	if err != nil {
+		return err
+	}
+	links := make([]LinkFault, 0, len(faults.Links)+1)
+	for _, link := range faults.Links {
+		if !link.joins(fault.NodeIDs[0], fault.NodeIDs[1]) {
+			links = append(links, link)
+		}
+	}
+	if !fault.isZero() {
+		links = append(links, fault)
+	}
+	faults.Links = links
+	return n.WriteFaults(faults)
+}
+
+// ClearFaults removes all partitions and link faults from the network.
+func (n *Network) ClearFaults() error {
+	return n.WriteFaults(&NetworkFaults{})
+}
+
+// SkewNodeClock restarts the node with its clock offset by [skew]. A zero skew
+// restores the node's clock. The skew is only applied if the node's binary was
+// built with the clockskew tag. The node isn't waited on to become healthy
+// since peers may reject a node whose clock is skewed too far.
+func (n *Network) SkewNodeClock(ctx context.Context, w io.Writer, node *Node, skew time.Duration) error {
+	node.ClockSkew = skew
+
+	// Reuse the API port to ensure consistent labeling of metrics
+	if err := node.SaveAPIPort(); err != nil {
+		return err
+	}
+	if err := node.Stop(ctx); err != nil {
+		return fmt.Errorf("failed to stop node %s: %w", node.NodeID, err)
+	}
+	if err := n.StartNode(ctx, w, node); err != nil {
+		return fmt.Errorf("failed to start node %s: %w", node.NodeID, err)
+	}
+	_, err := fmt.Fprintf(w, "Restarted node %s with its clock skewed by %s\n", node.NodeID, skew)
+	return err
+}
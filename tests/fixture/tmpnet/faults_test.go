@@ -0,0 +1,140 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package tmpnet
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/ids"
+)
+
+func TestNetworkFaultsVerify(t *testing.T) {
+	var (
+		nodeID0 = ids.GenerateTestNodeID()
+		nodeID1 = ids.GenerateTestNodeID()
+	)
+	tests := []struct {
+		name        string
+		faults      NetworkFaults
+		expectedErr error
+	}{
+		{
+			name:   "no faults",
+			faults: NetworkFaults{},
+		},
+		{
+			name: "valid faults",
+			faults: NetworkFaults{
+				Partitions: [][]ids.NodeID{{nodeID0}, {nodeID1}},
+				Links: []LinkFault{{
+					NodeIDs:  [2]ids.NodeID{nodeID0, nodeID1},
+					Latency:  time.Second,
+					LossRate: 0.5,
+				}},
+			},
+		},
+		{
+			name: "node in multiple groups",
+			faults: NetworkFaults{
+				Partitions: [][]ids.NodeID{{nodeID0}, {nodeID0, nodeID1}},
+			},
+			expectedErr: errNodeInMultipleGroups,
+		},
+		{
+			name: "link to self",
+			faults: NetworkFaults{
+				Links: []LinkFault{{
+					NodeIDs: [2]ids.NodeID{nodeID0, nodeID0},
+				}},
+			},
+			expectedErr: errInvalidLink,
+		},
+		{
+			name: "negative latency",
+			faults: NetworkFaults{
+				Links: []LinkFault{{
+					NodeIDs: [2]ids.NodeID{nodeID0, nodeID1},
+					Latency: -time.Second,
+				}},
+			},
+			expectedErr: errInvalidLatency,
+		},
+		{
+			name: "loss rate too high",
+			faults: NetworkFaults{
+				Links: []LinkFault{{
+					NodeIDs:  [2]ids.NodeID{nodeID0, nodeID1},
+					LossRate: 1.5,
+				}},
+			},
+			expectedErr: errInvalidLossRate,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require.ErrorIs(t, test.faults.Verify(), test.expectedErr)
+		})
+	}
+}
+
+func TestNetworkFaultsIsPartitioned(t *testing.T) {
+	require := require.New(t)
+
+	var (
+		nodeID0 = ids.GenerateTestNodeID()
+		nodeID1 = ids.GenerateTestNodeID()
+		nodeID2 = ids.GenerateTestNodeID()
+		nodeID3 = ids.GenerateTestNodeID()
+	)
+	faults := NetworkFaults{
+		Partitions: [][]ids.NodeID{{nodeID0, nodeID1}, {nodeID2}},
+	}
+	require.False(faults.IsPartitioned(nodeID0, nodeID1))
+	require.True(faults.IsPartitioned(nodeID0, nodeID2))
+	require.True(faults.IsPartitioned(nodeID2, nodeID1))
+
+	// Nodes that aren't in a group can communicate with every node
+	require.False(faults.IsPartitioned(nodeID0, nodeID3))
+	require.False(faults.IsPartitioned(nodeID3, nodeID2))
+}
+
+func TestNetworkSetLinkFault(t *testing.T) {
+	require := require.New(t)
+
+	network := &Network{Dir: t.TempDir()}
+	var (
+		nodeID0 = ids.GenerateTestNodeID()
+		nodeID1 = ids.GenerateTestNodeID()
+		fault   = LinkFault{
+			NodeIDs: [2]ids.NodeID{nodeID0, nodeID1},
+			Latency: time.Second,
+		}
+	)
+	require.NoError(network.SetLinkFault(fault))
+
+	faults, err := network.ReadFaults()
+	require.NoError(err)
+	require.Equal(fault, faults.Link(nodeID1, nodeID0))
+
+	// Replacing the fault of a link is independent of the order of its nodes
+	fault = LinkFault{
+		NodeIDs:  [2]ids.NodeID{nodeID1, nodeID0},
+		LossRate: 0.1,
+	}
+	require.NoError(network.SetLinkFault(fault))
+	faults, err = network.ReadFaults()
+	require.NoError(err)
+	require.Equal([]LinkFault{fault}, faults.Links)
+
+	// A zero fault restores the link
+	require.NoError(network.SetLinkFault(LinkFault{
+		NodeIDs: [2]ids.NodeID{nodeID0, nodeID1},
+	}))
+	faults, err = network.ReadFaults()
+	require.NoError(err)
+	require.Empty(faults.Links)
+}
@@ -0,0 +1,325 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package tmpnet
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/crypto/secp256k1"
+	"github.com/ava-labs/avalanchego/utils/set"
+)
+
+var (
+	errNodeCountConflict    = errors.New("nodeCount and nodes are mutually exclusive")
+	errInvalidNodeCount     = errors.New("nodeCount must be non-negative")
+	errKeyCountConflict     = errors.New("preFundedKeyCount and preFundedKeys are mutually exclusive")
+	errInvalidKeyCount      = errors.New("preFundedKeyCount must be non-negative")
+	errSubnetNameRequired   = errors.New("subnet name is required")
+	errDuplicateSubnetName  = errors.New("subnet name is not unique")
+	errChainVMRequired      = errors.New("chain requires exactly one of vmID and vmName")
+	errVMNameTooLong        = fmt.Errorf("vm name must be at most %d bytes", ids.IDLen)
+	errChainGenesisConflict = errors.New("genesis and genesisFile are mutually exclusive")
+	errChainConfigConflict  = errors.New("config and configFile are mutually exclusive")
+)
+
+// NetworkManifest declares a temporary network so that it can be started
+// without writing Go. Manifests are written in YAML or JSON, and relative
+// paths in a manifest are resolved against the directory containing it.
+type NetworkManifest struct {
+	// The string identifying the intended owner of the network
+	Owner string `json:"owner,omitempty"`
+
+	// Path to the avalanchego binary the nodes should run
+	AvalancheGoPath string `json:"avalancheGoPath,omitempty"`
+	// The dir containing the VM plugins of the network's chains
+	PluginDir string `json:"pluginDir,omitempty"`
+	// Whether to run a fault proxy in front of each node's staking port
+	EnableFaultInjection bool `json:"enableFaultInjection,omitempty"`
+
+	// Number of nodes with default configuration. Mutually exclusive with
+	// Nodes. If neither is set, DefaultNodeCount nodes are created.
+	NodeCount int `json:"nodeCount,omitempty"`
+	// Nodes with individual configuration
+	Nodes []NodeManifest `json:"nodes,omitempty"`
+
+	// Flags supplied to every node unless overridden by the node's own flags
+	DefaultFlags FlagsMap `json:"defaultFlags,omitempty"`
+	// Configuration of the primary network chains keyed by chain alias
+	ChainConfigs map[string]FlagsMap `json:"chainConfigs,omitempty"`
+
+	// Keys to pre-fund in the genesis. Mutually exclusive with
+	// PreFundedKeyCount. If neither is set, DefaultPreFundedKeyCount keys
+	// are generated.
+	PreFundedKeys []*secp256k1.PrivateKey `json:"preFundedKeys,omitempty"`
+	// Number of keys to generate and pre-fund in the genesis
+	PreFundedKeyCount int `json:"preFundedKeyCount,omitempty"`
+
+	// Subnets to create once the network is running
+	Subnets []SubnetManifest `json:"subnets,omitempty"`
+
+	// The dir that relative paths are resolved against
+	dir string
+}
+
+// NodeManifest declares a node of a temporary network.
+type NodeManifest struct {
+	Flags FlagsMap `json:"flags,omitempty"`
+}
+
+// SubnetManifest declares a subnet of a temporary network. Subnets are
+// validated by all of the network's nodes.
+type SubnetManifest struct {
+	Name string `json:"name"`
+	// The key that owns the subnet. If not set, one of the network's
+	// pre-funded keys is used.
+	OwningKey *secp256k1.PrivateKey `json:"owningKey,omitempty"`
+	Chains    []ChainManifest       `json:"chains,omitempty"`
+}
+
+// ChainManifest declares a chain of a subnet.
+type ChainManifest struct {
+	// The ID of the chain's VM. Mutually exclusive with VMName.
+	VMID ids.ID `json:"vmID,omitempty"`
+	// The name of the chain's VM, converted to a VM ID by zero-padding its
+	// bytes (e.g. `subnetevm`). Mutually exclusive with VMID.
+	VMName string `json:"vmName,omitempty"`
+
+	// Genesis of the chain. Mutually exclusive with GenesisFile.
+	Genesis string `json:"genesis,omitempty"`
+	// Path to a file containing the genesis of the chain
+	GenesisFile string `json:"genesisFile,omitempty"`
+
+	// Configuration of the chain. Mutually exclusive with ConfigFile.
+	Config FlagsMap `json:"config,omitempty"`
+	// Path to a file containing the configuration of the chain
+	ConfigFile string `json:"configFile,omitempty"`
+}
+
+// ReadNetworkManifest reads a YAML or JSON manifest from the provided path.
+func ReadNetworkManifest(path string) (*NetworkManifest, error) {
+	manifestBytes, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read network manifest: %w", err)
+	}
+	absPath, err := filepath.Abs(path)
+	if err != nil {
+		return nil, err
+	}
+	return ParseNetworkManifest(manifestBytes, filepath.Dir(absPath))
+}
+
+// ParseNetworkManifest parses a YAML or JSON manifest whose relative paths
+// will be resolved against [dir].
+func ParseNetworkManifest(manifestBytes []byte, dir string) (*NetworkManifest, error) {
+	// JSON is a subset of YAML, so all manifests are parsed as YAML. The
+	// result is converted to JSON to reuse the JSON encoding of the types
+	// referenced by the manifest (e.g. IDs and keys).
+	var content interface{}
+	if err := yaml.Unmarshal(manifestBytes, &content); err != nil {
+		return nil, fmt.Errorf("failed to parse network manifest: %w", err)
+	}
+	jsonBytes, err := json.Marshal(content)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse network manifest: %w", err)
+	}
+
+	manifest := &NetworkManifest{
+		dir: dir,
+	}
+	decoder := json.NewDecoder(bytes.NewReader(jsonBytes))
+	// Report misspelled fields rather than silently ignoring them
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(manifest); err != nil {
+		return nil, fmt.Errorf("failed to parse network manifest: %w", err)
+	}
+	return manifest, manifest.Verify()
+}
+
+func (m *NetworkManifest) Verify() error {
+	if m.NodeCount < 0 {
+		return errInvalidNodeCount
+	}
+	if m.NodeCount > 0 && len(m.Nodes) > 0 {
+		return errNodeCountConflict
+	}
+	if m.PreFundedKeyCount < 0 {
+		return errInvalidKeyCount
+	}
+	if m.PreFundedKeyCount > 0 && len(m.PreFundedKeys) > 0 {
+		return errKeyCountConflict
+	}
+
+	subnetNames := set.NewSet[string](len(m.Subnets))
+	for _, subnet := range m.Subnets {
+		if len(subnet.Name) == 0 {
+			return errSubnetNameRequired
+		}
+		if subnetNames.Contains(subnet.Name) {
+			return fmt.Errorf("%w: %s", errDuplicateSubnetName, subnet.Name)
+		}
+		subnetNames.Add(subnet.Name)
+
+		for _, chain := range subnet.Chains {
+			if err := chain.Verify(); err != nil {
+				return fmt.Errorf("invalid chain of subnet %s: %w", subnet.Name, err)
+			}
+		}
+	}
+	return nil
+}
+
+// ResolvePath resolves a path of the manifest against the directory
+// containing the manifest. Environment variables in the path are expanded.
+func (m *NetworkManifest) ResolvePath(path string) string {
+	path = os.ExpandEnv(path)
+	if len(path) == 0 || filepath.IsAbs(path) {
+		return path
+	}
+	return filepath.Join(m.dir, path)
+}
+
+// GetNodeCount returns the number of nodes the network should initially
+// consist of.
+func (m *NetworkManifest) GetNodeCount() int {
+	switch {
+	case len(m.Nodes) > 0:
+		return len(m.Nodes)
+	case m.NodeCount > 0:
+		return m.NodeCount
+	default:
+		return DefaultNodeCount
+	}
+}
+
+// NewNetwork returns an unstarted network with the configuration declared by
+// the manifest.
+func (m *NetworkManifest) NewNetwork() (*Network, error) {
+	network := &Network{
+		Owner:         m.Owner,
+		DefaultFlags:  FlagsMap{},
+		ChainConfigs:  map[string]FlagsMap{},
+		PreFundedKeys: m.PreFundedKeys,
+	}
+	network.DefaultRuntimeConfig.AvalancheGoPath = m.ResolvePath(m.AvalancheGoPath)
+	network.DefaultFlags.SetDefaults(m.DefaultFlags)
+	for alias, chainConfig := range m.ChainConfigs {
+		network.ChainConfigs[alias] = FlagsMap{}
+		network.ChainConfigs[alias].SetDefaults(chainConfig)
+	}
+
+	if m.PreFundedKeyCount > 0 {
+		keys, err := NewPrivateKeys(m.PreFundedKeyCount)
+		if err != nil {
+			return nil, err
+		}
+		network.PreFundedKeys = keys
+	}
+
+	for _, nodeManifest := range m.Nodes {
+		node := NewNode("")
+		node.Flags.SetDefaults(nodeManifest.Flags)
+		network.Nodes = append(network.Nodes, node)
+	}
+
+	for _, subnetManifest := range m.Subnets {
+		subnet := &Subnet{
+			Name:      subnetManifest.Name,
+			OwningKey: subnetManifest.OwningKey,
+		}
+		for _, chainManifest := range subnetManifest.Chains {
+			chain, err := m.newChain(chainManifest)
+			if err != nil {
+				return nil, fmt.Errorf("failed to configure chain of subnet %s: %w", subnet.Name, err)
+			}
+			subnet.Chains = append(subnet.Chains, chain)
+		}
+		network.Subnets = append(network.Subnets, subnet)
+	}
+
+	return network, nil
+}
+
+func (m *NetworkManifest) newChain(chainManifest ChainManifest) (*Chain, error) {
+	if len(chainManifest.GenesisFile) > 0 {
+		chainManifest.GenesisFile = m.ResolvePath(chainManifest.GenesisFile)
+	}
+	if len(chainManifest.ConfigFile) > 0 {
+		chainManifest.ConfigFile = m.ResolvePath(chainManifest.ConfigFile)
+	}
+	return chainManifest.NewChain()
+}
+
+func (c *ChainManifest) Verify() error {
+	if (c.VMID == ids.Empty) == (len(c.VMName) == 0) {
+		return errChainVMRequired
+	}
+	if len(c.VMName) > ids.IDLen {
+		return fmt.Errorf("%w: %s", errVMNameTooLong, c.VMName)
+	}
+	if len(c.Genesis) > 0 && len(c.GenesisFile) > 0 {
+		return errChainGenesisConflict
+	}
+	if len(c.Config) > 0 && len(c.ConfigFile) > 0 {
+		return errChainConfigConflict
+	}
+	return nil
+}
+
+// NewChain returns an uncreated chain with the configuration declared by the
+// manifest. The files referenced by the manifest are read from paths relative
+// to the current working directory.
+func (c *ChainManifest) NewChain() (*Chain, error) {
+	if err := c.Verify(); err != nil {
+		return nil, err
+	}
+
+	chain := &Chain{
+		VMID:    c.VMID,
+		Genesis: []byte(c.Genesis),
+	}
+	if len(c.VMName) > 0 {
+		chain.VMID = VMIDFromName(c.VMName)
+	}
+
+	if len(c.GenesisFile) > 0 {
+		genesis, err := os.ReadFile(c.GenesisFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read chain genesis: %w", err)
+		}
+		chain.Genesis = genesis
+	}
+
+	switch {
+	case len(c.ConfigFile) > 0:
+		config, err := os.ReadFile(c.ConfigFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read chain config: %w", err)
+		}
+		chain.Config = string(config)
+	case len(c.Config) > 0:
+		config, err := json.Marshal(c.Config)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal chain config: %w", err)
+		}
+		chain.Config = string(config)
+	}
+
+	return chain, nil
+}
+
+// VMIDFromName converts a VM name of at most 32 bytes to a VM ID by
+// zero-padding its bytes.
+func VMIDFromName(name string) ids.ID {
+	vmID := ids.ID{}
+	copy(vmID[:], name)
+	return vmID
+}
@@ -0,0 +1,264 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package tmpnet
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/crypto/secp256k1"
+	"github.com/ava-labs/avalanchego/utils/perms"
+)
+
+func TestParseNetworkManifest(t *testing.T) {
+	require := require.New(t)
+
+	key, err := secp256k1.NewPrivateKey()
+	require.NoError(err)
+	vmID := ids.GenerateTestID()
+
+	yamlManifest := []byte(`
+owner: team-a
+avalancheGoPath: bin/avalanchego
+nodes:
+  - flags:
+      log-level: debug
+  - {}
+defaultFlags:
+  log-display-level: info
+chainConfigs:
+  C:
+    log-level: trace
+preFundedKeys:
+  - ` + key.String() + `
+subnets:
+  - name: xsvm
+    chains:
+      - vmID: ` + vmID.String() + `
+        genesis: '{"timestamp":0}'
+        config:
+          pruning-enabled: false
+`)
+	jsonManifest := []byte(`{
+  "owner": "team-a",
+  "avalancheGoPath": "bin/avalanchego",
+  "nodes": [{"flags": {"log-level": "debug"}}, {}],
+  "defaultFlags": {"log-display-level": "info"},
+  "chainConfigs": {"C": {"log-level": "trace"}},
+  "preFundedKeys": ["` + key.String() + `"],
+  "subnets": [{
+    "name": "xsvm",
+    "chains": [{
+      "vmID": "` + vmID.String() + `",
+      "genesis": "{\"timestamp\":0}",
+      "config": {"pruning-enabled": false}
+    }]
+  }]
+}`)
+
+	for _, manifestBytes := range [][]byte{yamlManifest, jsonManifest} {
+		manifest, err := ParseNetworkManifest(manifestBytes, "/manifests")
+		require.NoError(err)
+		require.Equal(2, manifest.GetNodeCount())
+
+		network, err := manifest.NewNetwork()
+		require.NoError(err)
+		require.Equal("team-a", network.Owner)
+		require.Equal("/manifests/bin/avalanchego", network.DefaultRuntimeConfig.AvalancheGoPath)
+		require.Equal(FlagsMap{"log-display-level": "info"}, network.DefaultFlags)
+		require.Equal(map[string]FlagsMap{"C": {"log-level": "trace"}}, network.ChainConfigs)
+		require.Equal([]*secp256k1.PrivateKey{key}, network.PreFundedKeys)
+
+		require.Len(network.Nodes, 2)
+		logLevel, err := network.Nodes[0].Flags.GetStringVal("log-level")
+		require.NoError(err)
+		require.Equal("debug", logLevel)
+
+		require.Len(network.Subnets, 1)
+		subnet := network.Subnets[0]
+		require.Equal("xsvm", subnet.Name)
+		require.Equal([]*Chain{{
+			VMID:    vmID,
+			Genesis: []byte(`{"timestamp":0}`),
+			Config:  `{"pruning-enabled":false}`,
+		}}, subnet.Chains)
+	}
+}
+
+func TestParseNetworkManifestUnknownField(t *testing.T) {
+	_, err := ParseNetworkManifest([]byte("nodeCont: 3"), "")
+	require.Error(t, err) //nolint:forbidigo // json does not export an error for unknown fields
+}
+
+func TestNetworkManifestVerify(t *testing.T) {
+	key, err := secp256k1.NewPrivateKey()
+	require.NoError(t, err)
+
+	tests := []struct {
+		name        string
+		manifest    NetworkManifest
+		expectedErr error
+	}{
+		{
+			name:     "empty",
+			manifest: NetworkManifest{},
+		},
+		{
+			name: "negative node count",
+			manifest: NetworkManifest{
+				NodeCount: -1,
+			},
+			expectedErr: errInvalidNodeCount,
+		},
+		{
+			name: "node count and nodes",
+			manifest: NetworkManifest{
+				NodeCount: 1,
+				Nodes:     []NodeManifest{{}},
+			},
+			expectedErr: errNodeCountConflict,
+		},
+		{
+			name: "negative key count",
+			manifest: NetworkManifest{
+				PreFundedKeyCount: -1,
+			},
+			expectedErr: errInvalidKeyCount,
+		},
+		{
+			name: "key count and keys",
+			manifest: NetworkManifest{
+				PreFundedKeyCount: 1,
+				PreFundedKeys:     []*secp256k1.PrivateKey{key},
+			},
+			expectedErr: errKeyCountConflict,
+		},
+		{
+			name: "unnamed subnet",
+			manifest: NetworkManifest{
+				Subnets: []SubnetManifest{{}},
+			},
+			expectedErr: errSubnetNameRequired,
+		},
+		{
+			name: "duplicate subnet name",
+			manifest: NetworkManifest{
+				Subnets: []SubnetManifest{{Name: "a"}, {Name: "a"}},
+			},
+			expectedErr: errDuplicateSubnetName,
+		},
+		{
+			name: "chain without vm",
+			manifest: NetworkManifest{
+				Subnets: []SubnetManifest{{
+					Name:   "a",
+					Chains: []ChainManifest{{}},
+				}},
+			},
+			expectedErr: errChainVMRequired,
+		},
+		{
+			name: "chain with vm id and name",
+			manifest: NetworkManifest{
+				Subnets: []SubnetManifest{{
+					Name: "a",
+					Chains: []ChainManifest{{
+						VMID:   ids.GenerateTestID(),
+						VMName: "xsvm",
+					}},
+				}},
+			},
+			expectedErr: errChainVMRequired,
+		},
+		{
+			name: "vm name too long",
+			manifest: NetworkManifest{
+				Subnets: []SubnetManifest{{
+					Name: "a",
+					Chains: []ChainManifest{{
+						VMName: "a-vm-name-that-is-longer-than-32-bytes",
+					}},
+				}},
+			},
+			expectedErr: errVMNameTooLong,
+		},
+		{
+			name: "genesis and genesis file",
+			manifest: NetworkManifest{
+				Subnets: []SubnetManifest{{
+					Name: "a",
+					Chains: []ChainManifest{{
+						VMName:      "xsvm",
+						Genesis:     "{}",
+						GenesisFile: "genesis.json",
+					}},
+				}},
+			},
+			expectedErr: errChainGenesisConflict,
+		},
+		{
+			name: "config and config file",
+			manifest: NetworkManifest{
+				Subnets: []SubnetManifest{{
+					Name: "a",
+					Chains: []ChainManifest{{
+						VMName:     "xsvm",
+						Config:     FlagsMap{"a": "b"},
+						ConfigFile: "config.json",
+					}},
+				}},
+			},
+			expectedErr: errChainConfigConflict,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require.ErrorIs(t, test.manifest.Verify(), test.expectedErr)
+		})
+	}
+}
+
+func TestNetworkManifestChainFiles(t *testing.T) {
+	require := require.New(t)
+
+	dir := t.TempDir()
+	genesis := []byte(`{"alloc":{}}`)
+	require.NoError(os.WriteFile(filepath.Join(dir, "genesis.json"), genesis, perms.ReadWrite))
+	config := `{"log-level":"debug"}`
+	require.NoError(os.WriteFile(filepath.Join(dir, "config.json"), []byte(config), perms.ReadWrite))
+
+	manifestPath := filepath.Join(dir, "network.yaml")
+	require.NoError(os.WriteFile(manifestPath, []byte(`
+subnets:
+  - name: subnet-evm
+    chains:
+      - vmName: subnetevm
+        genesisFile: genesis.json
+        configFile: config.json
+`), perms.ReadWrite))
+
+	manifest, err := ReadNetworkManifest(manifestPath)
+	require.NoError(err)
+	require.Equal(DefaultNodeCount, manifest.GetNodeCount())
+
+	network, err := manifest.NewNetwork()
+	require.NoError(err)
+	require.Len(network.Subnets, 1)
+	require.Equal([]*Chain{{
+		VMID:    VMIDFromName("subnetevm"),
+		Genesis: genesis,
+		Config:  config,
+	}}, network.Subnets[0].Chains)
+}
+
+func TestVMIDFromName(t *testing.T) {
+	// The ID of subnet-evm's VM is derived from its name
+	expectedID, err := ids.FromString("srEXiWaHuhNyGwPUi444Tu47ZEDwxTWrbQiuD7FmgSAQ6X7Dy")
+	require.NoError(t, err)
+	require.Equal(t, expectedID, VMIDFromName("subnetevm"))
+}
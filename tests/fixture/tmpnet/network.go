@@ -335,21 +335,68 @@ func (n *Network) Start(ctx context.Context, w io.Writer) error {
 }
 
 func (n *Network) AddEphemeralNode(ctx context.Context, w io.Writer, flags FlagsMap) (*Node, error) {
+	return n.AddNode(ctx, w, flags, true /* isEphemeral */)
+}
+
+// Starts a new node with the provided flags. A non-ephemeral node becomes a
+// persistent member of the network but, unlike the nodes the network was
+// created with, is not a validator of the primary network.
+func (n *Network) AddNode(ctx context.Context, w io.Writer, flags FlagsMap, isEphemeral bool) (*Node, error) {
 	node := NewNode("")
 	node.Flags = flags
-	node.IsEphemeral = true
+	node.IsEphemeral = isEphemeral
 	if err := n.StartNode(ctx, w, node); err != nil {
 		return nil, err
 	}
+	if !isEphemeral {
+		n.Nodes = append(n.Nodes, node)
+	}
 	return node, nil
 }
 
+// Stops the node with the provided ID and removes its configuration and data
+// from the network. Removing a node the network was created with will leave
+// the primary network with a validator that is permanently offline.
+func (n *Network) RemoveNode(ctx context.Context, nodeID ids.NodeID) error {
+	nodes, err := ReadNodes(n.Dir, true /* includeEphemeral */)
+	if err != nil {
+		return err
+	}
+	for _, node := range nodes {
+		if node.NodeID != nodeID {
+			continue
+		}
+		if err := node.Stop(ctx); err != nil {
+			return fmt.Errorf("failed to stop node %s: %w", nodeID, err)
+		}
+		if err := os.RemoveAll(node.getDataDir()); err != nil {
+			return fmt.Errorf("failed to remove dir of node %s: %w", nodeID, err)
+		}
+
+		remainingNodes := make([]*Node, 0, len(n.Nodes))
+		for _, node := range n.Nodes {
+			if node.NodeID != nodeID {
+				remainingNodes = append(remainingNodes, node)
+			}
+		}
+		n.Nodes = remainingNodes
+		return nil
+	}
+	return fmt.Errorf("%s is not known to the network", nodeID)
+}
+
 // Starts the provided node after configuring it for the network.
 func (n *Network) StartNode(ctx context.Context, w io.Writer, node *Node) error {
 	if err := n.EnsureNodeConfig(node); err != nil {
 		return err
 	}
 
+	// The node advertises the port of its fault proxy, so the proxy has to be
+	// started first.
+	if err := node.startFaultProxy(ctx, n.Dir); err != nil {
+		return err
+	}
+
 	bootstrapIPs, bootstrapIDs, err := n.getBootstrapIPsAndIDs(node)
 	if err != nil {
 		return err
@@ -519,6 +566,7 @@ func (n *Network) EnsureNodeConfig(node *Node) error {
 	if node.RuntimeConfig == nil {
 		node.RuntimeConfig = &NodeRuntimeConfig{
 			AvalancheGoPath: n.DefaultRuntimeConfig.AvalancheGoPath,
+			TmpnetctlPath:   n.DefaultRuntimeConfig.TmpnetctlPath,
 		}
 	}
 
@@ -591,9 +639,6 @@ func (n *Network) CreateSubnets(ctx context.Context, w io.Writer) error {
 		return nil
 	}
 
-	// Ensure the in-memory subnet state
-	n.Subnets = append(n.Subnets, createdSubnets...)
-
 	// Ensure the pre-funded key changes are persisted to disk
 	if err := n.Write(); err != nil {
 		return err
@@ -662,6 +707,57 @@ func (n *Network) CreateSubnets(ctx context.Context, w io.Writer) error {
 	return n.Restart(ctx, w)
 }
 
+// Creates a chain on an existing subnet of the network. Validators of the
+// subnet are restarted if the chain has explicit configuration.
+func (n *Network) CreateChain(ctx context.Context, w io.Writer, subnetName string, chain *Chain) error {
+	subnet := n.GetSubnet(subnetName)
+	if subnet == nil || subnet.SubnetID == ids.Empty {
+		return fmt.Errorf("subnet %q has not been created", subnetName)
+	}
+
+	subnet.Chains = append(subnet.Chains, chain)
+	if err := subnet.CreateChains(ctx, w, n.Nodes[0].URI); err != nil {
+		return err
+	}
+	if err := subnet.Write(n.getSubnetDir(), n.getChainConfigDir()); err != nil {
+		return err
+	}
+	if _, err := fmt.Fprintf(w, " wrote chain configuration for subnet %q\n", subnet.Name); err != nil {
+		return err
+	}
+
+	if len(chain.Config) == 0 {
+		return nil
+	}
+	// Restart nodes to allow configuration for the new chain to take effect
+	return n.Restart(ctx, w)
+}
+
+// Copies the logs and metrics snapshots of all nodes, including ephemeral
+// ones, to [outputDir]/[node ID]. A metrics snapshot is saved for each
+// running node before copying.
+func (n *Network) CollectArtifacts(ctx context.Context, w io.Writer, outputDir string) error {
+	nodes, err := ReadNodes(n.Dir, true /* includeEphemeral */)
+	if err != nil {
+		return err
+	}
+	for _, node := range nodes {
+		if err := node.SaveMetricsSnapshot(ctx); err != nil {
+			return fmt.Errorf("failed to save metrics snapshot of node %s: %w", node.NodeID, err)
+		}
+		nodeOutputDir := filepath.Join(outputDir, node.NodeID.String())
+		for _, dirName := range []string{"logs", "metrics"} {
+			if err := copyDir(filepath.Join(node.getDataDir(), dirName), filepath.Join(nodeOutputDir, dirName)); err != nil {
+				return fmt.Errorf("failed to collect %s of node %s: %w", dirName, node.NodeID, err)
+			}
+		}
+		if _, err := fmt.Fprintf(w, "Collected logs and metrics of node %s in %s\n", node.NodeID, nodeOutputDir); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func (n *Network) GetURIForNodeID(nodeID ids.NodeID) (string, error) {
 	for _, node := range n.Nodes {
 		if node.NodeID == nodeID {
@@ -671,6 +767,15 @@ func (n *Network) GetURIForNodeID(nodeID ids.NodeID) (string, error) {
 	return "", fmt.Errorf("%s is not known to the network", nodeID)
 }
 
+func (n *Network) GetNode(nodeID ids.NodeID) (*Node, error) {
+	for _, node := range n.Nodes {
+		if node.NodeID == nodeID {
+			return node, nil
+		}
+	}
+	return nil, fmt.Errorf("%s is not known to the network", nodeID)
+}
+
 func (n *Network) GetNodeURIs() []NodeURI {
 	return GetNodeURIs(n.Nodes)
 }
@@ -697,7 +802,13 @@ func (n *Network) getBootstrapIPsAndIDs(skippedNode *Node) ([]string, []string,
 			continue
 		}
 
-		bootstrapIPs = append(bootstrapIPs, node.StakingAddress)
+		// Ensure peers connect through the node's fault proxy, if any
+		peerAddress, err := node.getPeerAddress()
+		if err != nil {
+			return nil, nil, err
+		}
+
+		bootstrapIPs = append(bootstrapIPs, peerAddress)
 		bootstrapIDs = append(bootstrapIDs, node.NodeID.String())
 	}
 
@@ -5,6 +5,7 @@ package tmpnet
 
 import (
 	"context"
+	"crypto/tls"
 	"encoding/base64"
 	"errors"
 	"fmt"
@@ -45,11 +46,17 @@ type NodeRuntime interface {
 	InitiateStop() error
 	WaitForStopped(ctx context.Context) error
 	IsHealthy(ctx context.Context) (bool, error)
+	Pause() error
+	Resume() error
 }
 
 // Configuration required to configure a node runtime.
 type NodeRuntimeConfig struct {
 	AvalancheGoPath string
+	// Path to a tmpnetctl binary used to run a fault proxy in front of the
+	// node's staking port. If empty, peers connect to the node directly and
+	// network faults can't be injected.
+	TmpnetctlPath string `json:",omitempty"`
 }
 
 // Node supports configuring and running a node participating in a temporary network.
@@ -74,6 +81,10 @@ type Node struct {
 	// should therefore not be used as for bootstrapping purposes.
 	IsEphemeral bool
 
+	// Offset of the node's clock from the system time. Only applied by an
+	// avalanchego binary built with the clockskew tag.
+	ClockSkew time.Duration
+
 	// The configuration used to initialize the node runtime.
 	RuntimeConfig *NodeRuntimeConfig
 
@@ -163,6 +174,10 @@ func (n *Node) Start(w io.Writer) error {
 }
 
 func (n *Node) InitiateStop(ctx context.Context) error {
+	// A paused node can neither report its metrics nor handle a stop signal
+	if err := n.Resume(); err != nil && !errors.Is(err, ErrNotRunning) {
+		return err
+	}
 	if err := n.SaveMetricsSnapshot(ctx); err != nil {
 		return err
 	}
@@ -170,7 +185,21 @@ func (n *Node) InitiateStop(ctx context.Context) error {
 }
 
 func (n *Node) WaitForStopped(ctx context.Context) error {
-	return n.getRuntime().WaitForStopped(ctx)
+	if err := n.getRuntime().WaitForStopped(ctx); err != nil {
+		return err
+	}
+	return n.stopFaultProxy(ctx)
+}
+
+// Pause suspends the node without stopping it. Peers see the node as
+// unresponsive until it is resumed.
+func (n *Node) Pause() error {
+	return n.getRuntime().Pause()
+}
+
+// Resume continues a paused node.
+func (n *Node) Resume() error {
+	return n.getRuntime().Resume()
 }
 
 func (n *Node) readState() error {
@@ -311,44 +340,54 @@ func (n *Node) GetProofOfPossession() (*signer.ProofOfPossession, error) {
 
 // Derives the node ID. Requires that a tls keypair is present.
 func (n *Node) EnsureNodeID() error {
+	tlsCert, err := n.getTLSCert()
+	if err != nil {
+		return err
+	}
+	stakingCert, err := staking.ParseCertificate(tlsCert.Leaf.Raw)
+	if err != nil {
+		return fmt.Errorf("failed to ensure node ID: failed to parse staking cert: %w", err)
+	}
+	n.NodeID = ids.NodeIDFromCert(stakingCert)
+
+	return nil
+}
+
+// Loads the staking tls keypair of the node. Requires that a tls keypair is
+// present.
+func (n *Node) getTLSCert() (*tls.Certificate, error) {
 	keyKey := config.StakingTLSKeyContentKey
 	certKey := config.StakingCertContentKey
 
 	key, err := n.Flags.GetStringVal(keyKey)
 	if err != nil {
-		return err
+		return nil, err
 	}
 	if len(key) == 0 {
-		return errMissingTLSKeyForNodeID
+		return nil, errMissingTLSKeyForNodeID
 	}
 	keyBytes, err := base64.StdEncoding.DecodeString(key)
 	if err != nil {
-		return fmt.Errorf("failed to ensure node ID: failed to base64 decode value for %q: %w", keyKey, err)
+		return nil, fmt.Errorf("failed to ensure node ID: failed to base64 decode value for %q: %w", keyKey, err)
 	}
 
 	cert, err := n.Flags.GetStringVal(certKey)
 	if err != nil {
-		return err
+		return nil, err
 	}
 	if len(cert) == 0 {
-		return errMissingCertForNodeID
+		return nil, errMissingCertForNodeID
 	}
 	certBytes, err := base64.StdEncoding.DecodeString(cert)
 	if err != nil {
-		return fmt.Errorf("failed to ensure node ID: failed to base64 decode value for %q: %w", certKey, err)
+		return nil, fmt.Errorf("failed to ensure node ID: failed to base64 decode value for %q: %w", certKey, err)
 	}
 
 	tlsCert, err := staking.LoadTLSCertFromBytes(keyBytes, certBytes)
 	if err != nil {
-		return fmt.Errorf("failed to ensure node ID: failed to load tls cert: %w", err)
-	}
-	stakingCert, err := staking.ParseCertificate(tlsCert.Leaf.Raw)
-	if err != nil {
-		return fmt.Errorf("failed to ensure node ID: failed to parse staking cert: %w", err)
+		return nil, fmt.Errorf("failed to ensure node ID: failed to load tls cert: %w", err)
 	}
-	n.NodeID = ids.NodeIDFromCert(stakingCert)
-
-	return nil
+	return tlsCert, nil
 }
 
 // Saves the currently allocated API port to the node's configuration
@@ -64,6 +64,7 @@ type serializedNodeConfig struct {
 	NetworkUUID   string
 	NetworkOwner  string
 	IsEphemeral   bool
+	ClockSkew     time.Duration `json:",omitempty"`
 	RuntimeConfig *NodeRuntimeConfig
 }
 
@@ -72,6 +73,7 @@ func (n *Node) writeConfig() error {
 		NetworkUUID:   n.NetworkUUID,
 		NetworkOwner:  n.NetworkOwner,
 		IsEphemeral:   n.IsEphemeral,
+		ClockSkew:     n.ClockSkew,
 		RuntimeConfig: n.RuntimeConfig,
 	}
 	bytes, err := DefaultJSONMarshal(config)
@@ -23,6 +23,7 @@ import (
 	"github.com/ava-labs/avalanchego/config"
 	"github.com/ava-labs/avalanchego/node"
 	"github.com/ava-labs/avalanchego/utils/perms"
+	"github.com/ava-labs/avalanchego/utils/timer/mockable"
 )
 
 const (
@@ -114,6 +115,9 @@ func (p *NodeProcess) Start(w io.Writer) error {
 
 	// All arguments are provided in the flags file
 	cmd := exec.Command(p.node.RuntimeConfig.AvalancheGoPath, "--config-file", p.node.getFlagsPath()) // #nosec G204
+	if p.node.ClockSkew != 0 {
+		cmd.Env = append(os.Environ(), fmt.Sprintf("%s=%s", mockable.ClockSkewEnvName, p.node.ClockSkew))
+	}
 
 	// Ensure process is detached from the parent process so that an error in the parent will not affect the child
 	cmd.SysProcAttr = &syscall.SysProcAttr{
@@ -188,6 +192,30 @@ func (p *NodeProcess) WaitForStopped(ctx context.Context) error {
 	}
 }
 
+// Suspends the node process with SIGSTOP.
+func (p *NodeProcess) Pause() error {
+	return p.signal(syscall.SIGSTOP)
+}
+
+// Continues a suspended node process with SIGCONT.
+func (p *NodeProcess) Resume() error {
+	return p.signal(syscall.SIGCONT)
+}
+
+func (p *NodeProcess) signal(sig syscall.Signal) error {
+	proc, err := p.getProcess()
+	if err != nil {
+		return fmt.Errorf("failed to retrieve process to signal: %w", err)
+	}
+	if proc == nil {
+		return ErrNotRunning
+	}
+	if err := proc.Signal(sig); err != nil {
+		return fmt.Errorf("failed to send %s to pid %d: %w", sig, p.pid, err)
+	}
+	return nil
+}
+
 func (p *NodeProcess) IsHealthy(ctx context.Context) (bool, error) {
 	// Check that the node process is running as a precondition for
 	// checking health. getProcess will also ensure that the node's
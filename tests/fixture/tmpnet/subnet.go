@@ -131,6 +131,11 @@ func (s *Subnet) CreateChains(ctx context.Context, w io.Writer, uri string) erro
 	}
 
 	for _, chain := range s.Chains {
+		if chain.ChainID != ids.Empty {
+			// The chain already exists
+			continue
+		}
+
 		createChainTx, err := pWallet.IssueCreateChainTx(
 			s.SubnetID,
 			chain.Genesis,
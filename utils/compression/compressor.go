@@ -10,3 +10,20 @@ type Compressor interface {
 	Compress([]byte) ([]byte, error)
 	Decompress([]byte) ([]byte, error)
 }
+
+// NewCompressor returns a compressor of [compressionType] that refuses to
+// compress or decompress messages larger than [maxSize].
+func NewCompressor(compressionType Type, maxSize int64) (Compressor, error) {
+	switch compressionType {
+	case TypeNone:
+		return NewNoCompressor(), nil
+	case TypeZstd:
+		return NewZstdCompressor(maxSize)
+	case TypeLZ4:
+		return NewLZ4Compressor(maxSize)
+	case TypeSnappy:
+		return NewSnappyCompressor(maxSize)
+	default:
+		return nil, errUnknownCompressionType
+	}
+}
@@ -24,17 +24,39 @@ var (
 		TypeNone: func(int64) (Compressor, error) { //nolint:unparam // an error is needed to be returned to compile
 			return NewNoCompressor(), nil
 		},
-		TypeZstd: NewZstdCompressor,
+		TypeZstd:   NewZstdCompressor,
+		TypeLZ4:    NewLZ4Compressor,
+		TypeSnappy: NewSnappyCompressor,
 	}
 
 	//go:embed zstd_zip_bomb.bin
 	zstdZipBomb []byte
 
+	//go:embed lz4_zip_bomb.bin
+	lz4ZipBomb []byte
+
+	//go:embed snappy_zip_bomb.bin
+	snappyZipBomb []byte
+
 	zipBombs = map[Type][]byte{
-		TypeZstd: zstdZipBomb,
+		TypeZstd:   zstdZipBomb,
+		TypeLZ4:    lz4ZipBomb,
+		TypeSnappy: snappyZipBomb,
 	}
 )
 
+func TestNewCompressor(t *testing.T) {
+	for compressionType := range newCompressorFuncs {
+		t.Run(compressionType.String(), func(t *testing.T) {
+			_, err := NewCompressor(compressionType, maxMessageSize)
+			require.NoError(t, err)
+		})
+	}
+
+	_, err := NewCompressor(Type(0), maxMessageSize)
+	require.ErrorIs(t, err, errUnknownCompressionType)
+}
+
 func TestDecompressZipBombs(t *testing.T) {
 	for compressionType, zipBomb := range zipBombs {
 		// Make sure that the hardcoded zip bomb would be a valid message.
@@ -153,6 +175,14 @@ func FuzzZstdCompressor(f *testing.F) {
 	fuzzHelper(f, TypeZstd)
 }
 
+func FuzzLZ4Compressor(f *testing.F) {
+	fuzzHelper(f, TypeLZ4)
+}
+
+func FuzzSnappyCompressor(f *testing.F) {
+	fuzzHelper(f, TypeSnappy)
+}
+
 func fuzzHelper(f *testing.F, compressionType Type) {
 	var (
 		compressor Compressor
@@ -162,6 +192,12 @@ func fuzzHelper(f *testing.F, compressionType Type) {
 	case TypeZstd:
 		compressor, err = NewZstdCompressor(maxMessageSize)
 		require.NoError(f, err)
+	case TypeLZ4:
+		compressor, err = NewLZ4Compressor(maxMessageSize)
+		require.NoError(f, err)
+	case TypeSnappy:
+		compressor, err = NewSnappyCompressor(maxMessageSize)
+		require.NoError(f, err)
 	default:
 		require.FailNow(f, "Unknown compression type")
 	}
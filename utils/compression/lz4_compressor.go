@@ -0,0 +1,68 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package compression
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"math"
+
+	"github.com/pierrec/lz4/v4"
+)
+
+var _ Compressor = (*lz4Compressor)(nil)
+
+// NewLZ4Compressor returns a compressor that uses the lz4 frame format. lz4
+// compresses worse than zstd, but uses significantly less CPU.
+func NewLZ4Compressor(maxSize int64) (Compressor, error) {
+	if maxSize == math.MaxInt64 {
+		// "Decompress" creates "io.LimitReader" with max size + 1:
+		// if the max size + 1 overflows, "io.LimitReader" reads nothing
+		// returning 0 byte for the decompress call
+		// require max size < math.MaxInt64 to prevent int64 overflows
+		return nil, ErrInvalidMaxSizeCompressor
+	}
+
+	return &lz4Compressor{
+		maxSize: maxSize,
+	}, nil
+}
+
+type lz4Compressor struct {
+	maxSize int64
+}
+
+func (l *lz4Compressor) Compress(msg []byte) ([]byte, error) {
+	if int64(len(msg)) > l.maxSize {
+		return nil, fmt.Errorf("%w: (%d) > (%d)", ErrMsgTooLarge, len(msg), l.maxSize)
+	}
+
+	var buf bytes.Buffer
+	writer := lz4.NewWriter(&buf)
+	if _, err := writer.Write(msg); err != nil {
+		return nil, err
+	}
+	if err := writer.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func (l *lz4Compressor) Decompress(msg []byte) ([]byte, error) {
+	reader := lz4.NewReader(bytes.NewReader(msg))
+
+	// We allow [io.LimitReader] to read up to [l.maxSize + 1] bytes, so that if
+	// the decompressed payload is greater than the maximum size, this function
+	// will return the appropriate error instead of an incomplete byte slice.
+	limitReader := io.LimitReader(reader, l.maxSize+1)
+	decompressed, err := io.ReadAll(limitReader)
+	if err != nil {
+		return nil, err
+	}
+	if int64(len(decompressed)) > l.maxSize {
+		return nil, fmt.Errorf("%w: (%d) > (%d)", ErrDecompressedMsgTooLarge, len(decompressed), l.maxSize)
+	}
+	return decompressed, nil
+}
@@ -0,0 +1,52 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package compression
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/golang/snappy"
+)
+
+var _ Compressor = (*snappyCompressor)(nil)
+
+// NewSnappyCompressor returns a compressor that uses the snappy block format.
+// snappy compresses worse than zstd, but uses significantly less CPU.
+func NewSnappyCompressor(maxSize int64) (Compressor, error) {
+	if maxSize == math.MaxInt64 {
+		// Keep the same limits as the other compressors so that the max size
+		// can be used interchangeably.
+		return nil, ErrInvalidMaxSizeCompressor
+	}
+
+	return &snappyCompressor{
+		maxSize: maxSize,
+	}, nil
+}
+
+type snappyCompressor struct {
+	maxSize int64
+}
+
+func (s *snappyCompressor) Compress(msg []byte) ([]byte, error) {
+	if int64(len(msg)) > s.maxSize {
+		return nil, fmt.Errorf("%w: (%d) > (%d)", ErrMsgTooLarge, len(msg), s.maxSize)
+	}
+	return snappy.Encode(nil, msg), nil
+}
+
+func (s *snappyCompressor) Decompress(msg []byte) ([]byte, error) {
+	// The snappy block format is prefixed with the length of the decompressed
+	// payload. It's checked before decompressing so that a payload greater
+	// than the maximum size is never allocated.
+	decompressedLen, err := snappy.DecodedLen(msg)
+	if err != nil {
+		return nil, err
+	}
+	if int64(decompressedLen) > s.maxSize {
+		return nil, fmt.Errorf("%w: (%d) > (%d)", ErrDecompressedMsgTooLarge, decompressedLen, s.maxSize)
+	}
+	return snappy.Decode(nil, msg)
+}
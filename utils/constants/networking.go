@@ -61,6 +61,12 @@ const (
 	// a timeout of 0 should generally not be provided.
 	DefaultNetworkTCPProxyReadTimeout = 3 * time.Second
 
+	// Reputation
+	DefaultNetworkReputationHalflife     = 5 * time.Minute
+	DefaultNetworkReputationMaxScore     = 100
+	DefaultNetworkReputationBanThreshold = -100
+	DefaultNetworkReputationBanDuration  = 10 * time.Minute
+
 	// Benchlist
 	DefaultBenchlistFailThreshold      = 10
 	DefaultBenchlistDuration           = 15 * time.Minute
@@ -87,6 +93,12 @@ const (
 	DefaultOutboundThrottlerVdrAllocSize        = 32 * units.MiB
 	DefaultOutboundThrottlerNodeMaxAtLargeBytes = DefaultMaxMessageSize
 
+	// Subnet Bandwidth Quotas. Subnets aren't limited unless a refill rate is
+	// given.
+	DefaultSubnetBandwidthQuotaRefillRate   = 0
+	DefaultSubnetBandwidthQuotaMaxBurstSize = 8 * DefaultMaxMessageSize
+	DefaultSubnetBandwidthQuotaDefaultShare = 0.5
+
 	// Network Health
 	DefaultHealthCheckAveragerHalflife = 10 * time.Second
 
@@ -0,0 +1,46 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package bls
+
+var _ Signer = (*localSigner)(nil)
+
+// Signer signs messages with a secret key that may not be held in memory by
+// the caller.
+type Signer interface {
+	// PublicKey returns the public key that corresponds to the secret key used
+	// for signing.
+	PublicKey() *PublicKey
+
+	// Sign [msg] to authorize this message.
+	Sign(msg []byte) (*Signature, error)
+
+	// SignProofOfPossession signs [msg] to prove the ownership of the secret
+	// key.
+	SignProofOfPossession(msg []byte) (*Signature, error)
+}
+
+// NewLocalSigner returns a Signer that signs with [sk].
+func NewLocalSigner(sk *SecretKey) Signer {
+	return &localSigner{
+		sk: sk,
+		pk: PublicFromSecretKey(sk),
+	}
+}
+
+type localSigner struct {
+	sk *SecretKey
+	pk *PublicKey
+}
+
+func (s *localSigner) PublicKey() *PublicKey {
+	return s.pk
+}
+
+func (s *localSigner) Sign(msg []byte) (*Signature, error) {
+	return Sign(s.sk, msg), nil
+}
+
+func (s *localSigner) SignProofOfPossession(msg []byte) (*Signature, error) {
+	return SignProofOfPossession(s.sk, msg), nil
+}
@@ -5,6 +5,11 @@ package mockable
 
 import "time"
 
+// ClockSkewEnvName is the environment variable that offsets the time of every
+// clock that isn't faked, in binaries built with the clockskew tag. This
+// allows testing a node whose clock is skewed relative to its peers.
+const ClockSkewEnvName = "AVALANCHEGO_CLOCK_SKEW"
+
 // MaxTime was taken from https://stackoverflow.com/questions/25065055/what-is-the-maximum-time-time-in-go/32620397#32620397
 var MaxTime = time.Unix(1<<63-62135596801, 0) // 0 is used because we drop the nano-seconds
 
@@ -25,7 +30,7 @@ func (c *Clock) Time() time.Time {
 	if c.faked {
 		return c.time
 	}
-	return time.Now()
+	return now()
 }
 
 // Time returns the unix time on this clock
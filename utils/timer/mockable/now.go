@@ -0,0 +1,13 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+//go:build !clockskew
+// +build !clockskew
+
+package mockable
+
+import "time"
+
+func now() time.Time {
+	return time.Now()
+}
@@ -0,0 +1,34 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+//go:build clockskew
+// +build clockskew
+
+package mockable
+
+import (
+	"fmt"
+	"os"
+	"time"
+)
+
+// skew is added to global time by every clock that isn't faked. It only
+// applies to time read through a Clock, so the skew is partial.
+var skew time.Duration
+
+func init() {
+	skewStr, ok := os.LookupEnv(ClockSkewEnvName)
+	if !ok {
+		return
+	}
+
+	var err error
+	skew, err = time.ParseDuration(skewStr)
+	if err != nil {
+		panic(fmt.Sprintf("invalid %s: %v", ClockSkewEnvName, err))
+	}
+}
+
+func now() time.Time {
+	return time.Now().Add(skew)
+}
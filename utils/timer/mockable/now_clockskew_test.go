@@ -0,0 +1,31 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+//go:build clockskew
+// +build clockskew
+
+package mockable
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestClockSkew(t *testing.T) {
+	require := require.New(t)
+
+	skew = time.Hour
+	defer func() {
+		skew = 0
+	}()
+
+	clock := Clock{}
+	require.WithinDuration(time.Now().Add(time.Hour), clock.Time(), time.Minute)
+
+	// Faked clocks ignore the skew.
+	faked := time.Unix(1000000, 0)
+	clock.Set(faked)
+	require.Equal(faked, clock.Time())
+}
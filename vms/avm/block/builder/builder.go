@@ -16,9 +16,11 @@ import (
 	"github.com/ava-labs/avalanchego/vms/avm/state"
 	"github.com/ava-labs/avalanchego/vms/avm/txs"
 	"github.com/ava-labs/avalanchego/vms/avm/txs/mempool"
+	"github.com/ava-labs/avalanchego/vms/components/fees"
 
 	blockexecutor "github.com/ava-labs/avalanchego/vms/avm/block/executor"
 	txexecutor "github.com/ava-labs/avalanchego/vms/avm/txs/executor"
+	txfees "github.com/ava-labs/avalanchego/vms/avm/txs/fees"
 )
 
 // targetBlockSize is the max block size we aim to produce
@@ -91,7 +93,16 @@ func (b *builder) BuildBlock(context.Context) (snowman.Block, error) {
 		blockTxs      []*txs.Tx
 		inputs        set.Set[ids.ID]
 		remainingSize = targetBlockSize
+		isEActive     = b.backend.Config.IsEActivated(nextTimestamp)
+		feeManager    *fees.Manager
 	)
+	if isEActive {
+		feeRates, err := stateDiff.GetFeeRates()
+		if err != nil {
+			return nil, err
+		}
+		feeManager = fees.NewManager(feeRates)
+	}
 	for {
 		tx, exists := b.mempool.Peek()
 		// Invariant: [mempool.MaxTxSize] < [targetBlockSize]. This guarantees
@@ -101,6 +112,29 @@ func (b *builder) BuildBlock(context.Context) (snowman.Block, error) {
 		if !exists || len(tx.Bytes()) > remainingSize {
 			break
 		}
+
+		var complexity fees.Dimensions
+		if isEActive {
+			complexity, err = txfees.TxComplexity(tx.Unsigned)
+			if err != nil {
+				b.mempool.Remove(tx)
+				b.mempool.MarkDropped(tx.ID(), err)
+				continue
+			}
+
+			err = feeManager.CumulateComplexity(complexity, b.backend.Config.DynamicFeeConfig.BlockMaxComplexity)
+			if err != nil {
+				if len(blockTxs) != 0 {
+					// The tx may fit into the next block.
+					break
+				}
+
+				// The tx doesn't even fit into an empty block.
+				b.mempool.Remove(tx)
+				b.mempool.MarkDropped(tx.ID(), err)
+				continue
+			}
+		}
 		b.mempool.Remove(tx)
 
 		// Invariant: [tx] has already been syntactically verified.
@@ -110,38 +144,34 @@ func (b *builder) BuildBlock(context.Context) (snowman.Block, error) {
 			return nil, err
 		}
 
-		err = tx.Unsigned.Visit(&txexecutor.SemanticVerifier{
-			Backend: b.backend,
-			State:   txDiff,
-			Tx:      tx,
-		})
-		if err != nil {
-			txID := tx.ID()
-			b.mempool.MarkDropped(txID, err)
-			continue
-		}
-
 		executor := &txexecutor.Executor{
 			Codec: b.backend.Codec,
 			State: txDiff,
 			Tx:    tx,
 		}
-		err = tx.Unsigned.Visit(executor)
-		if err != nil {
-			txID := tx.ID()
-			b.mempool.MarkDropped(txID, err)
-			continue
+		err = tx.Unsigned.Visit(&txexecutor.SemanticVerifier{
+			Backend:   b.backend,
+			State:     txDiff,
+			Tx:        tx,
+			IsEActive: isEActive,
+		})
+		if err == nil {
+			err = tx.Unsigned.Visit(executor)
 		}
-
-		if inputs.Overlaps(executor.Inputs) {
-			txID := tx.ID()
-			b.mempool.MarkDropped(txID, blockexecutor.ErrConflictingBlockTxs)
-			continue
+		if err == nil && inputs.Overlaps(executor.Inputs) {
+			err = blockexecutor.ErrConflictingBlockTxs
+		}
+		if err == nil {
+			err = b.manager.VerifyUniqueInputs(preferredID, inputs)
 		}
-		err = b.manager.VerifyUniqueInputs(preferredID, inputs)
 		if err != nil {
 			txID := tx.ID()
 			b.mempool.MarkDropped(txID, err)
+			if isEActive {
+				if err := feeManager.RemoveComplexity(complexity); err != nil {
+					return nil, err
+				}
+			}
 			continue
 		}
 		inputs.Union(executor.Inputs)
@@ -25,6 +25,7 @@ import (
 	"github.com/ava-labs/avalanchego/utils/logging"
 	"github.com/ava-labs/avalanchego/utils/timer/mockable"
 	"github.com/ava-labs/avalanchego/vms/avm/block"
+	"github.com/ava-labs/avalanchego/vms/avm/config"
 	"github.com/ava-labs/avalanchego/vms/avm/fxs"
 	"github.com/ava-labs/avalanchego/vms/avm/metrics"
 	"github.com/ava-labs/avalanchego/vms/avm/state"
@@ -69,6 +70,9 @@ func TestBuilderBuildBlock(t *testing.T) {
 						Ctx: &snow.Context{
 							Log: logging.NoLog{},
 						},
+						Config: &config.Config{
+							EUpgradeTime: mockable.MaxTime,
+						},
 					},
 					manager,
 					&mockable.Clock{},
@@ -100,6 +104,9 @@ func TestBuilderBuildBlock(t *testing.T) {
 						Ctx: &snow.Context{
 							Log: logging.NoLog{},
 						},
+						Config: &config.Config{
+							EUpgradeTime: mockable.MaxTime,
+						},
 					},
 					manager,
 					&mockable.Clock{},
@@ -144,6 +151,9 @@ func TestBuilderBuildBlock(t *testing.T) {
 						Ctx: &snow.Context{
 							Log: logging.NoLog{},
 						},
+						Config: &config.Config{
+							EUpgradeTime: mockable.MaxTime,
+						},
 					},
 					manager,
 					&mockable.Clock{},
@@ -189,6 +199,9 @@ func TestBuilderBuildBlock(t *testing.T) {
 						Ctx: &snow.Context{
 							Log: logging.NoLog{},
 						},
+						Config: &config.Config{
+							EUpgradeTime: mockable.MaxTime,
+						},
 					},
 					manager,
 					&mockable.Clock{},
@@ -235,6 +248,9 @@ func TestBuilderBuildBlock(t *testing.T) {
 						Ctx: &snow.Context{
 							Log: logging.NoLog{},
 						},
+						Config: &config.Config{
+							EUpgradeTime: mockable.MaxTime,
+						},
 					},
 					manager,
 					&mockable.Clock{},
@@ -325,6 +341,9 @@ func TestBuilderBuildBlock(t *testing.T) {
 				return New(
 					&txexecutor.Backend{
 						Codec: codec,
+						Config: &config.Config{
+							EUpgradeTime: mockable.MaxTime,
+						},
 						Ctx: &snow.Context{
 							Log: logging.NoLog{},
 						},
@@ -397,6 +416,9 @@ func TestBuilderBuildBlock(t *testing.T) {
 				return New(
 					&txexecutor.Backend{
 						Codec: codec,
+						Config: &config.Config{
+							EUpgradeTime: mockable.MaxTime,
+						},
 						Ctx: &snow.Context{
 							Log: logging.NoLog{},
 						},
@@ -471,6 +493,9 @@ func TestBuilderBuildBlock(t *testing.T) {
 				return New(
 					&txexecutor.Backend{
 						Codec: codec,
+						Config: &config.Config{
+							EUpgradeTime: mockable.MaxTime,
+						},
 						Ctx: &snow.Context{
 							Log: logging.NoLog{},
 						},
@@ -523,6 +548,9 @@ func TestBlockBuilderAddLocalTx(t *testing.T) {
 		Ctx: &snow.Context{
 			Log: logging.NoLog{},
 		},
+		Config: &config.Config{
+			EUpgradeTime: mockable.MaxTime,
+		},
 		Codec: parser.Codec(),
 	}
 
@@ -19,6 +19,9 @@ import (
 	"github.com/ava-labs/avalanchego/vms/avm/block"
 	"github.com/ava-labs/avalanchego/vms/avm/state"
 	"github.com/ava-labs/avalanchego/vms/avm/txs/executor"
+	"github.com/ava-labs/avalanchego/vms/components/fees"
+
+	txfees "github.com/ava-labs/avalanchego/vms/avm/txs/fees"
 )
 
 const SyncBound = 10 * time.Second
@@ -33,6 +36,7 @@ var (
 	ErrConflictingBlockTxs         = errors.New("block contains conflicting transactions")
 	ErrIncorrectHeight             = errors.New("block has incorrect height")
 	ErrBlockNotFound               = errors.New("block not found")
+	ErrBlockComplexityTooHigh      = errors.New("block complexity too high")
 )
 
 // Exported for testing in avm package.
@@ -75,10 +79,12 @@ func (b *Block) Verify(context.Context) error {
 
 	// Syntactic verification is generally pretty fast, so we verify this first
 	// before performing any possible DB reads.
+	isEActive := b.manager.backend.Config.IsEActivated(newChainTime)
 	for _, tx := range txs {
 		err := tx.Unsigned.Visit(&executor.SyntacticVerifier{
-			Backend: b.manager.backend,
-			Tx:      tx,
+			Backend:   b.manager.backend,
+			Tx:        tx,
+			IsEActive: isEActive,
 		})
 		if err != nil {
 			txID := tx.ID()
@@ -124,6 +130,17 @@ func (b *Block) Verify(context.Context) error {
 
 	stateDiff.SetTimestamp(newChainTime)
 
+	// After the E upgrade, the complexity of the block is bounded and
+	// determines the fee rates of the next block.
+	var feeManager *fees.Manager
+	if isEActive {
+		feeRates, err := stateDiff.GetFeeRates()
+		if err != nil {
+			return err
+		}
+		feeManager = fees.NewManager(feeRates)
+	}
+
 	blockState := &blockState{
 		statelessBlock: b.Block,
 		onAcceptState:  stateDiff,
@@ -131,12 +148,24 @@ func (b *Block) Verify(context.Context) error {
 	}
 
 	for _, tx := range txs {
+		if feeManager != nil {
+			complexity, err := txfees.TxComplexity(tx.Unsigned)
+			if err != nil {
+				return err
+			}
+			err = feeManager.CumulateComplexity(complexity, b.manager.backend.Config.DynamicFeeConfig.BlockMaxComplexity)
+			if err != nil {
+				return fmt.Errorf("%w: %w", ErrBlockComplexityTooHigh, err)
+			}
+		}
+
 		// Verify that the tx is valid according to the current state of the
 		// chain.
 		err := tx.Unsigned.Visit(&executor.SemanticVerifier{
-			Backend: b.manager.backend,
-			State:   stateDiff,
-			Tx:      tx,
+			Backend:   b.manager.backend,
+			State:     stateDiff,
+			Tx:        tx,
+			IsEActive: isEActive,
 		})
 		if err != nil {
 			txID := tx.ID()
@@ -187,6 +216,18 @@ func (b *Block) Verify(context.Context) error {
 		}
 	}
 
+	// The fee rates are only updated after all the txs in the block have been
+	// verified so that every tx in the block pays the same fee rates.
+	if feeManager != nil {
+		// Invariant: [newChainTime] was verified to not be before
+		// [parentChainTime].
+		elapsedSeconds := uint64(newChainTime.Unix() - parentChainTime.Unix())
+		stateDiff.SetFeeRates(feeManager.UpdateFeeRates(
+			b.manager.backend.Config.DynamicFeeConfig,
+			elapsedSeconds,
+		))
+	}
+
 	// Verify that none of the transactions consumed any inputs that were
 	// already imported in a currently processing block.
 	err = b.manager.VerifyUniqueInputs(parentID, blockState.importedInputs)
@@ -858,6 +858,7 @@ func TestBlockReject(t *testing.T) {
 						mempool:      mempool,
 						metrics:      metrics.NewMockMetrics(ctrl),
 						backend:      defaultTestBackend(true, nil),
+						clk:          &mockable.Clock{},
 						state:        mockState,
 						blkIDToState: map[ids.ID]*blockState{
 							blockID: {},
@@ -911,6 +912,7 @@ func TestBlockReject(t *testing.T) {
 						mempool:      mempool,
 						metrics:      metrics.NewMockMetrics(ctrl),
 						backend:      defaultTestBackend(true, nil),
+						clk:          &mockable.Clock{},
 						state:        mockState,
 						blkIDToState: map[ids.ID]*blockState{
 							blockID: {},
@@ -147,9 +147,13 @@ func (m *manager) VerifyTx(tx *txs.Tx) error {
 		return ErrChainNotSynced
 	}
 
+	// The tx is verified as if it were included in the next block, which can't
+	// be built before the local time.
+	isEActive := m.backend.Config.IsEActivated(m.clk.Time())
 	err := tx.Unsigned.Visit(&executor.SyntacticVerifier{
-		Backend: m.backend,
-		Tx:      tx,
+		Backend:   m.backend,
+		Tx:        tx,
+		IsEActive: isEActive,
 	})
 	if err != nil {
 		return err
@@ -161,9 +165,10 @@ func (m *manager) VerifyTx(tx *txs.Tx) error {
 	}
 
 	err = tx.Unsigned.Visit(&executor.SemanticVerifier{
-		Backend: m.backend,
-		State:   stateDiff,
-		Tx:      tx,
+		Backend:   m.backend,
+		State:     stateDiff,
+		Tx:        tx,
+		IsEActive: isEActive,
 	})
 	if err != nil {
 		return err
@@ -13,6 +13,7 @@ import (
 
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/utils/set"
+	"github.com/ava-labs/avalanchego/utils/timer/mockable"
 	"github.com/ava-labs/avalanchego/vms/avm/block"
 	"github.com/ava-labs/avalanchego/vms/avm/state"
 	"github.com/ava-labs/avalanchego/vms/avm/txs"
@@ -139,6 +140,7 @@ func TestManagerVerifyTx(t *testing.T) {
 			managerF: func(*gomock.Controller) *manager {
 				return &manager{
 					backend: defaultTestBackend(true, nil),
+					clk:     &mockable.Clock{},
 				}
 			},
 			expectedErr: errTestSyntacticVerifyFail,
@@ -165,6 +167,7 @@ func TestManagerVerifyTx(t *testing.T) {
 
 				return &manager{
 					backend:      defaultTestBackend(true, nil),
+					clk:          &mockable.Clock{},
 					state:        state,
 					lastAccepted: lastAcceptedID,
 				}
@@ -195,6 +198,7 @@ func TestManagerVerifyTx(t *testing.T) {
 
 				return &manager{
 					backend:      defaultTestBackend(true, nil),
+					clk:          &mockable.Clock{},
 					state:        state,
 					lastAccepted: lastAcceptedID,
 				}
@@ -225,6 +229,7 @@ func TestManagerVerifyTx(t *testing.T) {
 
 				return &manager{
 					backend:      defaultTestBackend(true, nil),
+					clk:          &mockable.Clock{},
 					state:        state,
 					lastAccepted: lastAcceptedID,
 				}
@@ -17,6 +17,7 @@ import (
 	"github.com/ava-labs/avalanchego/utils/formatting/address"
 	"github.com/ava-labs/avalanchego/utils/json"
 	"github.com/ava-labs/avalanchego/utils/rpc"
+	"github.com/ava-labs/avalanchego/vms/components/fees"
 )
 
 var _ Client = (*client)(nil)
@@ -30,6 +31,9 @@ type Client interface {
 	GetBlockByHeight(ctx context.Context, height uint64, options ...rpc.Option) ([]byte, error)
 	// GetHeight returns the height of the last accepted block.
 	GetHeight(ctx context.Context, options ...rpc.Option) (uint64, error)
+	// GetFeeRates returns the dynamic fee rates charged to the transactions of
+	// the next block and whether the dynamic fees are currently active
+	GetFeeRates(ctx context.Context, options ...rpc.Option) (fees.Dimensions, bool, error)
 	// GetTxStatus returns the status of [txID]
 	//
 	// Deprecated: GetTxStatus only returns Accepted or Unknown, GetTx should be
@@ -264,6 +268,16 @@ func (c *client) GetHeight(ctx context.Context, options ...rpc.Option) (uint64,
 	return uint64(res.Height), err
 }
 
+func (c *client) GetFeeRates(ctx context.Context, options ...rpc.Option) (fees.Dimensions, bool, error) {
+	res := &GetFeeRatesReply{}
+	err := c.requester.SendRequest(ctx, "avm.getFeeRates", struct{}{}, res, options...)
+	var feeRates fees.Dimensions
+	for i, feeRate := range res.FeeRates {
+		feeRates[i] = uint64(feeRate)
+	}
+	return feeRates, res.Active, err
+}
+
 func (c *client) IssueTx(ctx context.Context, txBytes []byte, options ...rpc.Option) (ids.ID, error) {
 	txStr, err := formatting.Encode(formatting.Hex, txBytes)
 	if err != nil {
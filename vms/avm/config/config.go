@@ -3,7 +3,11 @@
 
 package config
 
-import "time"
+import (
+	"time"
+
+	"github.com/ava-labs/avalanchego/vms/components/fees"
+)
 
 // Struct collecting all the foundational parameters of the AVM
 type Config struct {
@@ -13,6 +17,9 @@ type Config struct {
 	// Fee that must be burned by every asset creating transaction
 	CreateAssetTxFee uint64
 
+	// Parameters of the dynamic fees charged after the E upgrade
+	DynamicFeeConfig fees.DynamicFeesConfig
+
 	// Time of the E network upgrade
 	EUpgradeTime time.Time
 }
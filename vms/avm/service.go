@@ -23,6 +23,7 @@ import (
 	"github.com/ava-labs/avalanchego/utils/set"
 	"github.com/ava-labs/avalanchego/vms/avm/txs"
 	"github.com/ava-labs/avalanchego/vms/components/avax"
+	"github.com/ava-labs/avalanchego/vms/components/fees"
 	"github.com/ava-labs/avalanchego/vms/components/keystore"
 	"github.com/ava-labs/avalanchego/vms/components/verify"
 	"github.com/ava-labs/avalanchego/vms/nftfx"
@@ -192,6 +193,41 @@ func (s *Service) GetHeight(_ *http.Request, _ *struct{}, reply *api.GetHeightRe
 	return nil
 }
 
+// GetFeeRatesReply is the response from GetFeeRates
+type GetFeeRatesReply struct {
+	// True if the dynamic fees are charged at the current chain time
+	Active bool `json:"active"`
+	// Fee rates charged per unit of complexity, indexed by fee dimension
+	FeeRates [fees.FeeDimensions]avajson.Uint64 `json:"feeRates"`
+}
+
+// GetFeeRates returns the dynamic fee rates charged to the transactions of the
+// next block.
+func (s *Service) GetFeeRates(_ *http.Request, _ *struct{}, reply *GetFeeRatesReply) error {
+	s.vm.ctx.Log.Debug("API called",
+		zap.String("service", "avm"),
+		zap.String("method", "getFeeRates"),
+	)
+
+	s.vm.ctx.Lock.Lock()
+	defer s.vm.ctx.Lock.Unlock()
+
+	if s.vm.chainManager == nil {
+		return errNotLinearized
+	}
+
+	feeRates, err := s.vm.state.GetFeeRates()
+	if err != nil {
+		return fmt.Errorf("couldn't get fee rates: %w", err)
+	}
+
+	reply.Active = s.vm.IsEActivated(s.vm.state.GetTimestamp())
+	for i, feeRate := range feeRates {
+		reply.FeeRates[i] = avajson.Uint64(feeRate)
+	}
+	return nil
+}
+
 // IssueTx attempts to issue a transaction into consensus
 func (s *Service) IssueTx(_ *http.Request, args *api.FormattedTx, reply *api.JSONTxID) error {
 	s.vm.ctx.Log.Debug("API called",
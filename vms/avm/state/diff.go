@@ -13,6 +13,7 @@ import (
 	"github.com/ava-labs/avalanchego/vms/avm/block"
 	"github.com/ava-labs/avalanchego/vms/avm/txs"
 	"github.com/ava-labs/avalanchego/vms/components/avax"
+	"github.com/ava-labs/avalanchego/vms/components/fees"
 )
 
 var (
@@ -40,6 +41,7 @@ type diff struct {
 
 	lastAccepted ids.ID
 	timestamp    time.Time
+	feeRates     *fees.Dimensions // nil if the fee rates weren't modified in this diff
 }
 
 func NewDiff(
@@ -161,6 +163,22 @@ func (d *diff) SetTimestamp(t time.Time) {
 	d.timestamp = t
 }
 
+func (d *diff) GetFeeRates() (fees.Dimensions, error) {
+	if d.feeRates != nil {
+		return *d.feeRates, nil
+	}
+
+	parentState, ok := d.stateVersions.GetState(d.parentID)
+	if !ok {
+		return fees.Empty, fmt.Errorf("%w: %s", ErrMissingParentState, d.parentID)
+	}
+	return parentState.GetFeeRates()
+}
+
+func (d *diff) SetFeeRates(feeRates fees.Dimensions) {
+	d.feeRates = &feeRates
+}
+
 func (d *diff) Apply(state Chain) {
 	for utxoID, utxo := range d.modifiedUTXOs {
 		if utxo != nil {
@@ -180,4 +198,7 @@ func (d *diff) Apply(state Chain) {
 
 	state.SetLastAccepted(d.lastAccepted)
 	state.SetTimestamp(d.timestamp)
+	if d.feeRates != nil {
+		state.SetFeeRates(*d.feeRates)
+	}
 }
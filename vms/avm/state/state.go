@@ -19,6 +19,7 @@ import (
 	"github.com/ava-labs/avalanchego/vms/avm/block"
 	"github.com/ava-labs/avalanchego/vms/avm/txs"
 	"github.com/ava-labs/avalanchego/vms/components/avax"
+	"github.com/ava-labs/avalanchego/vms/components/fees"
 )
 
 const (
@@ -37,6 +38,7 @@ var (
 	isInitializedKey = []byte{0x00}
 	timestampKey     = []byte{0x01}
 	lastAcceptedKey  = []byte{0x02}
+	feeRatesKey      = []byte{0x03}
 
 	_ State = (*state)(nil)
 )
@@ -49,6 +51,10 @@ type ReadOnlyChain interface {
 	GetBlock(blkID ids.ID) (block.Block, error)
 	GetLastAccepted() ids.ID
 	GetTimestamp() time.Time
+
+	// GetFeeRates returns the per-dimension fee rates charged by the dynamic
+	// fee mechanism.
+	GetFeeRates() (fees.Dimensions, error)
 }
 
 type Chain interface {
@@ -60,6 +66,7 @@ type Chain interface {
 	AddBlock(block block.Block)
 	SetLastAccepted(blkID ids.ID)
 	SetTimestamp(t time.Time)
+	SetFeeRates(feeRates fees.Dimensions)
 }
 
 // State persistently maintains a set of UTXOs, transaction, statuses, and
@@ -72,12 +79,13 @@ type State interface {
 	SetInitialized() error
 
 	// InitializeChainState is called after the VM has been linearized. Calling
-	// [GetLastAccepted] or [GetTimestamp] before calling this function will
-	// return uninitialized data.
+	// [GetLastAccepted], [GetTimestamp] or [GetFeeRates] before calling this
+	// function will return uninitialized data. If the fee rates were never
+	// updated, [initialFeeRates] are used.
 	//
 	// Invariant: After the chain is linearized, this function is expected to be
 	// called during startup.
-	InitializeChainState(stopVertexID ids.ID, genesisTimestamp time.Time) error
+	InitializeChainState(stopVertexID ids.ID, genesisTimestamp time.Time, initialFeeRates fees.Dimensions) error
 
 	// Discard uncommitted changes to the database.
 	Abort()
@@ -108,7 +116,8 @@ type State interface {
  * '-. singletons
  *   |-- initializedKey -> nil
  *   |-- timestampKey -> timestamp
- *   '-- lastAcceptedKey -> lastAccepted
+ *   |-- lastAcceptedKey -> lastAccepted
+ *   '-- feeRatesKey -> feeRates
  */
 type state struct {
 	parser block.Parser
@@ -133,6 +142,7 @@ type state struct {
 	// [lastAccepted] is the most recently accepted block.
 	lastAccepted, persistedLastAccepted ids.ID
 	timestamp, persistedTimestamp       time.Time
+	feeRates, persistedFeeRates         fees.Dimensions
 	singletonDB                         database.Database
 
 	trackChecksum bool
@@ -331,7 +341,24 @@ func (s *state) AddBlock(block block.Block) {
 	s.addedBlocks[blkID] = block
 }
 
-func (s *state) InitializeChainState(stopVertexID ids.ID, genesisTimestamp time.Time) error {
+func (s *state) InitializeChainState(stopVertexID ids.ID, genesisTimestamp time.Time, initialFeeRates fees.Dimensions) error {
+	switch feeRatesBytes, err := s.singletonDB.Get(feeRatesKey); err {
+	case nil:
+		feeRates, err := fees.ParseDimensions(feeRatesBytes)
+		if err != nil {
+			return err
+		}
+		s.persistedFeeRates = feeRates
+		s.SetFeeRates(feeRates)
+	case database.ErrNotFound:
+		// The fee rates are only written once they are first updated, so that
+		// the initial fee rates can be modified until the fee rates activate.
+		s.persistedFeeRates = initialFeeRates
+		s.SetFeeRates(initialFeeRates)
+	default:
+		return err
+	}
+
 	lastAccepted, err := database.GetID(s.singletonDB, lastAcceptedKey)
 	if err == database.ErrNotFound {
 		return s.initializeChainState(stopVertexID, genesisTimestamp)
@@ -387,6 +414,14 @@ func (s *state) SetTimestamp(t time.Time) {
 	s.timestamp = t
 }
 
+func (s *state) GetFeeRates() (fees.Dimensions, error) {
+	return s.feeRates, nil
+}
+
+func (s *state) SetFeeRates(feeRates fees.Dimensions) {
+	s.feeRates = feeRates
+}
+
 func (s *state) Commit() error {
 	defer s.Abort()
 	batch, err := s.CommitBatch()
@@ -499,6 +534,12 @@ func (s *state) writeMetadata() error {
 		}
 		s.persistedLastAccepted = s.lastAccepted
 	}
+	if s.persistedFeeRates != s.feeRates {
+		if err := s.singletonDB.Put(feeRatesKey, s.feeRates.Bytes()); err != nil {
+			return fmt.Errorf("failed to write fee rates: %w", err)
+		}
+		s.persistedFeeRates = s.feeRates
+	}
 	return nil
 }
 
@@ -19,6 +19,7 @@ import (
 	"github.com/ava-labs/avalanchego/vms/avm/fxs"
 	"github.com/ava-labs/avalanchego/vms/avm/txs"
 	"github.com/ava-labs/avalanchego/vms/components/avax"
+	"github.com/ava-labs/avalanchego/vms/components/fees"
 	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
 )
 
@@ -288,7 +289,7 @@ func TestInitializeChainState(t *testing.T) {
 
 	stopVertexID := ids.GenerateTestID()
 	genesisTimestamp := version.DefaultUpgradeTime
-	require.NoError(s.InitializeChainState(stopVertexID, genesisTimestamp))
+	require.NoError(s.InitializeChainState(stopVertexID, genesisTimestamp, fees.Empty))
 
 	lastAcceptedID := s.GetLastAccepted()
 	genesis, err := s.GetBlock(lastAcceptedID)
@@ -309,10 +310,56 @@ func TestInitializeChainState(t *testing.T) {
 	s.SetLastAccepted(childBlock.ID())
 	require.NoError(s.Commit())
 
-	require.NoError(s.InitializeChainState(stopVertexID, genesisTimestamp))
+	require.NoError(s.InitializeChainState(stopVertexID, genesisTimestamp, fees.Empty))
 
 	lastAcceptedID = s.GetLastAccepted()
 	lastAccepted, err := s.GetBlock(lastAcceptedID)
 	require.NoError(err)
 	require.Equal(genesis.ID(), lastAccepted.Parent())
 }
+
+func TestFeeRates(t *testing.T) {
+	require := require.New(t)
+
+	db := memdb.New()
+	vdb := versiondb.New(db)
+	s, err := New(vdb, parser, prometheus.NewRegistry(), trackChecksums)
+	require.NoError(err)
+
+	// The initial fee rates are used until the fee rates are updated.
+	initialFeeRates := fees.Dimensions{5, 6, 7, 8}
+	require.NoError(s.InitializeChainState(ids.GenerateTestID(), time.Now(), initialFeeRates))
+	stateFeeRates, err := s.GetFeeRates()
+	require.NoError(err)
+	require.Equal(initialFeeRates, stateFeeRates)
+
+	parentID := ids.GenerateTestID()
+	d, err := NewDiff(parentID, &versions{
+		chains: map[ids.ID]Chain{
+			parentID: s,
+		},
+	})
+	require.NoError(err)
+
+	diffFeeRates, err := d.GetFeeRates()
+	require.NoError(err)
+	require.Equal(initialFeeRates, diffFeeRates)
+
+	feeRates := fees.Dimensions{1, 2, 3, 4}
+	d.SetFeeRates(feeRates)
+
+	diffFeeRates, err = d.GetFeeRates()
+	require.NoError(err)
+	require.Equal(feeRates, diffFeeRates)
+
+	d.Apply(s)
+	require.NoError(s.Commit())
+
+	s, err = New(vdb, parser, prometheus.NewRegistry(), trackChecksums)
+	require.NoError(err)
+	require.NoError(s.InitializeChainState(ids.GenerateTestID(), time.Now(), initialFeeRates))
+
+	persistedFeeRates, err := s.GetFeeRates()
+	require.NoError(err)
+	require.Equal(feeRates, persistedFeeRates)
+}
@@ -28,9 +28,21 @@ type SemanticVerifier struct {
 	*Backend
 	State state.ReadOnlyChain
 	Tx    *txs.Tx
+
+	// IsEActive must be set if the tx is being verified for inclusion after
+	// the E upgrade. After the E upgrade, the tx must burn the dynamic fee
+	// implied by [State].
+	IsEActive bool
 }
 
 func (v *SemanticVerifier) BaseTx(tx *txs.BaseTx) error {
+	if err := v.verifyFee(tx.Ins, nil, tx.Outs, nil); err != nil {
+		return err
+	}
+	return v.verifyBaseTx(tx)
+}
+
+func (v *SemanticVerifier) verifyBaseTx(tx *txs.BaseTx) error {
 	for i, in := range tx.Ins {
 		// Note: Verification of the length of [t.tx.Creds] happens during
 		// syntactic verification, which happens before semantic verification.
@@ -56,11 +68,17 @@ func (v *SemanticVerifier) BaseTx(tx *txs.BaseTx) error {
 }
 
 func (v *SemanticVerifier) CreateAssetTx(tx *txs.CreateAssetTx) error {
-	return v.BaseTx(&tx.BaseTx)
+	if err := v.verifyFee(tx.Ins, nil, tx.Outs, nil); err != nil {
+		return err
+	}
+	return v.verifyBaseTx(&tx.BaseTx)
 }
 
 func (v *SemanticVerifier) OperationTx(tx *txs.OperationTx) error {
-	if err := v.BaseTx(&tx.BaseTx); err != nil {
+	if err := v.verifyFee(tx.Ins, nil, tx.Outs, nil); err != nil {
+		return err
+	}
+	if err := v.verifyBaseTx(&tx.BaseTx); err != nil {
 		return err
 	}
 
@@ -81,7 +99,10 @@ func (v *SemanticVerifier) OperationTx(tx *txs.OperationTx) error {
 }
 
 func (v *SemanticVerifier) ImportTx(tx *txs.ImportTx) error {
-	if err := v.BaseTx(&tx.BaseTx); err != nil {
+	if err := v.verifyFee(tx.Ins, tx.ImportedIns, tx.Outs, nil); err != nil {
+		return err
+	}
+	if err := v.verifyBaseTx(&tx.BaseTx); err != nil {
 		return err
 	}
 
@@ -122,7 +143,10 @@ func (v *SemanticVerifier) ImportTx(tx *txs.ImportTx) error {
 }
 
 func (v *SemanticVerifier) ExportTx(tx *txs.ExportTx) error {
-	if err := v.BaseTx(&tx.BaseTx); err != nil {
+	if err := v.verifyFee(tx.Ins, nil, tx.Outs, tx.ExportedOuts); err != nil {
+		return err
+	}
+	if err := v.verifyBaseTx(&tx.BaseTx); err != nil {
 		return err
 	}
 
@@ -146,6 +170,32 @@ func (v *SemanticVerifier) ExportTx(tx *txs.ExportTx) error {
 	return nil
 }
 
+// verifyFee verifies that the tx burns the dynamic fee after the E upgrade.
+// Prior to the E upgrade, the static fee is verified during syntactic
+// verification.
+func (v *SemanticVerifier) verifyFee(
+	ins []*avax.TransferableInput,
+	additionalIns []*avax.TransferableInput,
+	outs []*avax.TransferableOutput,
+	additionalOuts []*avax.TransferableOutput,
+) error {
+	if !v.IsEActive {
+		return nil
+	}
+
+	fee, err := getTxFee(v.State, v.Tx.Unsigned)
+	if err != nil {
+		return err
+	}
+	return avax.VerifyTx(
+		fee,
+		v.FeeAssetID,
+		[][]*avax.TransferableInput{ins, additionalIns},
+		[][]*avax.TransferableOutput{outs, additionalOuts},
+		v.Codec,
+	)
+}
+
 func (v *SemanticVerifier) verifyTransfer(
 	tx txs.UnsignedTx,
 	in *avax.TransferableInput,
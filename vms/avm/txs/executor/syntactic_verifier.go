@@ -48,6 +48,11 @@ var (
 type SyntacticVerifier struct {
 	*Backend
 	Tx *txs.Tx
+
+	// IsEActive must be set if the tx is being verified for inclusion after
+	// the E upgrade. After the E upgrade, the fee depends on the chain state
+	// and is verified during semantic verification.
+	IsEActive bool
 }
 
 func (v *SyntacticVerifier) BaseTx(tx *txs.BaseTx) error {
@@ -56,7 +61,7 @@ func (v *SyntacticVerifier) BaseTx(tx *txs.BaseTx) error {
 	}
 
 	err := avax.VerifyTx(
-		v.Config.TxFee,
+		v.staticFee(v.Config.TxFee),
 		v.FeeAssetID,
 		[][]*avax.TransferableInput{tx.Ins},
 		[][]*avax.TransferableOutput{tx.Outs},
@@ -119,7 +124,7 @@ func (v *SyntacticVerifier) CreateAssetTx(tx *txs.CreateAssetTx) error {
 	}
 
 	err := avax.VerifyTx(
-		v.Config.CreateAssetTxFee,
+		v.staticFee(v.Config.CreateAssetTxFee),
 		v.FeeAssetID,
 		[][]*avax.TransferableInput{tx.Ins},
 		[][]*avax.TransferableOutput{tx.Outs},
@@ -167,7 +172,7 @@ func (v *SyntacticVerifier) OperationTx(tx *txs.OperationTx) error {
 	}
 
 	err := avax.VerifyTx(
-		v.Config.TxFee,
+		v.staticFee(v.Config.TxFee),
 		v.FeeAssetID,
 		[][]*avax.TransferableInput{tx.Ins},
 		[][]*avax.TransferableOutput{tx.Outs},
@@ -227,7 +232,7 @@ func (v *SyntacticVerifier) ImportTx(tx *txs.ImportTx) error {
 	}
 
 	err := avax.VerifyTx(
-		v.Config.TxFee,
+		v.staticFee(v.Config.TxFee),
 		v.FeeAssetID,
 		[][]*avax.TransferableInput{
 			tx.Ins,
@@ -269,7 +274,7 @@ func (v *SyntacticVerifier) ExportTx(tx *txs.ExportTx) error {
 	}
 
 	err := avax.VerifyTx(
-		v.Config.TxFee,
+		v.staticFee(v.Config.TxFee),
 		v.FeeAssetID,
 		[][]*avax.TransferableInput{tx.Ins},
 		[][]*avax.TransferableOutput{
@@ -300,3 +305,12 @@ func (v *SyntacticVerifier) ExportTx(tx *txs.ExportTx) error {
 
 	return nil
 }
+
+// staticFee returns the fee that must be burned by the tx prior to the E
+// upgrade. After the E upgrade, only the flow check is performed.
+func (v *SyntacticVerifier) staticFee(fee uint64) uint64 {
+	if v.IsEActive {
+		return 0
+	}
+	return fee
+}
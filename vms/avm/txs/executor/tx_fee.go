@@ -0,0 +1,27 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package executor
+
+import (
+	"github.com/ava-labs/avalanchego/vms/avm/state"
+	"github.com/ava-labs/avalanchego/vms/avm/txs"
+	"github.com/ava-labs/avalanchego/vms/components/fees"
+
+	txfees "github.com/ava-labs/avalanchego/vms/avm/txs/fees"
+)
+
+// getTxFee returns the fee [tx] must burn to be executed on top of
+// [chainState] after the E upgrade. The complexity of [tx] is charged at the
+// current fee rates.
+func getTxFee(chainState state.ReadOnlyChain, tx txs.UnsignedTx) (uint64, error) {
+	complexity, err := txfees.TxComplexity(tx)
+	if err != nil {
+		return 0, err
+	}
+	feeRates, err := chainState.GetFeeRates()
+	if err != nil {
+		return 0, err
+	}
+	return fees.NewManager(feeRates).CalculateFee(complexity)
+}
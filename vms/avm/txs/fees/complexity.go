@@ -0,0 +1,136 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package fees
+
+import (
+	"errors"
+
+	"github.com/ava-labs/avalanchego/utils/crypto/secp256k1"
+	"github.com/ava-labs/avalanchego/vms/avm/txs"
+	"github.com/ava-labs/avalanchego/vms/components/avax"
+	"github.com/ava-labs/avalanchego/vms/components/fees"
+	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
+
+	safemath "github.com/ava-labs/avalanchego/utils/math"
+)
+
+var (
+	_ txs.Visitor = (*complexityVisitor)(nil)
+
+	ErrUninitializedTx = errors.New("tx bytes are not initialized")
+)
+
+// TxComplexity returns the complexity consumed by executing [utx]. The unsigned
+// bytes of [utx] must have been populated.
+//
+// The complexity is measured as:
+//   - Bandwidth: the size of the unsigned tx plus the size of its signatures
+//   - UTXORead: the number of consumed UTXOs
+//   - UTXOWrite: the number of produced UTXOs
+//   - Compute: the cost of verifying the inputs and operations of the tx
+//
+// The complexity only depends on the unsigned tx so that wallets are able to
+// calculate the fee of a tx before signing it.
+func TxComplexity(utx txs.UnsignedTx) (fees.Dimensions, error) {
+	unsignedSize := len(utx.Bytes())
+	if unsignedSize == 0 {
+		return fees.Empty, ErrUninitializedTx
+	}
+
+	v := &complexityVisitor{
+		unsignedSize: uint64(unsignedSize),
+	}
+	err := utx.Visit(v)
+	return v.complexity, err
+}
+
+type complexityVisitor struct {
+	// inputs
+	unsignedSize uint64
+
+	// outputs
+	complexity fees.Dimensions
+}
+
+func (v *complexityVisitor) BaseTx(tx *txs.BaseTx) error {
+	return v.meter(&tx.BaseTx, nil, 0, 0, 0)
+}
+
+func (v *complexityVisitor) CreateAssetTx(tx *txs.CreateAssetTx) error {
+	var numOuts int
+	for _, state := range tx.States {
+		numOuts += len(state.Outs)
+	}
+	return v.meter(&tx.BaseTx.BaseTx, nil, 0, numOuts, 0)
+}
+
+func (v *complexityVisitor) OperationTx(tx *txs.OperationTx) error {
+	var (
+		numReads  int
+		numWrites int
+		compute   uint64
+	)
+	for _, op := range tx.Ops {
+		numReads += len(op.UTXOIDs)
+		numWrites += len(op.Op.Outs())
+
+		cost, err := op.Op.Cost()
+		if err != nil {
+			return err
+		}
+		compute, err = safemath.Add64(compute, cost)
+		if err != nil {
+			return err
+		}
+	}
+	return v.meter(&tx.BaseTx.BaseTx, nil, numReads, numWrites, compute)
+}
+
+func (v *complexityVisitor) ImportTx(tx *txs.ImportTx) error {
+	return v.meter(&tx.BaseTx.BaseTx, tx.ImportedIns, 0, 0, 0)
+}
+
+func (v *complexityVisitor) ExportTx(tx *txs.ExportTx) error {
+	return v.meter(&tx.BaseTx.BaseTx, nil, 0, len(tx.ExportedOuts), 0)
+}
+
+func (v *complexityVisitor) meter(
+	tx *avax.BaseTx,
+	additionalIns []*avax.TransferableInput,
+	additionalReads int,
+	additionalWrites int,
+	additionalCompute uint64,
+) error {
+	compute := additionalCompute
+	for _, ins := range [][]*avax.TransferableInput{tx.Ins, additionalIns} {
+		for _, in := range ins {
+			cost, err := in.In.Cost()
+			if err != nil {
+				return err
+			}
+			compute, err = safemath.Add64(compute, cost)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	numSigs := compute / secp256k1fx.CostPerSignature
+	sigsSize, err := safemath.Mul64(numSigs, secp256k1.SignatureLen)
+	if err != nil {
+		return err
+	}
+	bandwidth, err := safemath.Add64(v.unsignedSize, sigsSize)
+	if err != nil {
+		return err
+	}
+
+	v.complexity = fees.Dimensions{
+		fees.Bandwidth: bandwidth,
+		fees.UTXORead:  uint64(len(tx.Ins) + len(additionalIns) + additionalReads),
+		fees.UTXOWrite: uint64(len(tx.Outs) + additionalWrites),
+		fees.Compute:   compute,
+	}
+	return nil
+}
@@ -0,0 +1,185 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package fees
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/ava-labs/avalanchego/utils/crypto/secp256k1"
+	"github.com/ava-labs/avalanchego/vms/avm/txs"
+	"github.com/ava-labs/avalanchego/vms/components/avax"
+	"github.com/ava-labs/avalanchego/vms/components/fees"
+	"github.com/ava-labs/avalanchego/vms/components/verify"
+	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
+)
+
+func newTestIn(numSigs int) *avax.TransferableInput {
+	return &avax.TransferableInput{
+		UTXOID: avax.UTXOID{TxID: ids.GenerateTestID()},
+		Asset:  avax.Asset{ID: ids.GenerateTestID()},
+		In: &secp256k1fx.TransferInput{
+			Amt: 1,
+			Input: secp256k1fx.Input{
+				SigIndices: make([]uint32, numSigs),
+			},
+		},
+	}
+}
+
+func newTestOut() *avax.TransferableOutput {
+	return &avax.TransferableOutput{
+		Asset: avax.Asset{ID: ids.GenerateTestID()},
+		Out: &secp256k1fx.TransferOutput{
+			Amt: 1,
+			OutputOwners: secp256k1fx.OutputOwners{
+				Threshold: 1,
+				Addrs:     []ids.ShortID{ids.GenerateTestShortID()},
+			},
+		},
+	}
+}
+
+func newTestBaseTx(ins []*avax.TransferableInput, outs []*avax.TransferableOutput) txs.BaseTx {
+	return txs.BaseTx{
+		BaseTx: avax.BaseTx{
+			NetworkID:    constants.UnitTestID,
+			BlockchainID: ids.GenerateTestID(),
+			Ins:          ins,
+			Outs:         outs,
+		},
+	}
+}
+
+func TestTxComplexity(t *testing.T) {
+	tests := []struct {
+		name         string
+		unsignedTx   txs.UnsignedTx
+		expectedSigs uint64
+		expected     fees.Dimensions
+	}{
+		{
+			name: "base tx",
+			unsignedTx: func() txs.UnsignedTx {
+				tx := newTestBaseTx(
+					[]*avax.TransferableInput{newTestIn(1), newTestIn(2)},
+					[]*avax.TransferableOutput{newTestOut()},
+				)
+				return &tx
+			}(),
+			expectedSigs: 3,
+			expected: fees.Dimensions{
+				fees.UTXORead:  2,
+				fees.UTXOWrite: 1,
+				fees.Compute:   3 * secp256k1fx.CostPerSignature,
+			},
+		},
+		{
+			name: "create asset tx",
+			unsignedTx: &txs.CreateAssetTx{
+				BaseTx: newTestBaseTx(
+					[]*avax.TransferableInput{newTestIn(1)},
+					nil,
+				),
+				Name:   "Asset",
+				Symbol: "A",
+				States: []*txs.InitialState{
+					{
+						Outs: []verify.State{
+							&secp256k1fx.TransferOutput{Amt: 1},
+							&secp256k1fx.MintOutput{},
+						},
+					},
+				},
+			},
+			expectedSigs: 1,
+			expected: fees.Dimensions{
+				fees.UTXORead:  1,
+				fees.UTXOWrite: 2,
+				fees.Compute:   secp256k1fx.CostPerSignature,
+			},
+		},
+		{
+			name: "operation tx",
+			unsignedTx: &txs.OperationTx{
+				BaseTx: newTestBaseTx(
+					[]*avax.TransferableInput{newTestIn(1)},
+					nil,
+				),
+				Ops: []*txs.Operation{
+					{
+						Asset:   avax.Asset{ID: ids.GenerateTestID()},
+						UTXOIDs: []*avax.UTXOID{{TxID: ids.GenerateTestID()}},
+						Op: &secp256k1fx.MintOperation{
+							MintInput: secp256k1fx.Input{
+								SigIndices: []uint32{0, 1},
+							},
+						},
+					},
+				},
+			},
+			expectedSigs: 3,
+			expected: fees.Dimensions{
+				fees.UTXORead:  2,
+				fees.UTXOWrite: 2,
+				fees.Compute:   3 * secp256k1fx.CostPerSignature,
+			},
+		},
+		{
+			name: "import tx",
+			unsignedTx: &txs.ImportTx{
+				BaseTx: newTestBaseTx(
+					nil,
+					[]*avax.TransferableOutput{newTestOut()},
+				),
+				SourceChain: ids.GenerateTestID(),
+				ImportedIns: []*avax.TransferableInput{newTestIn(1)},
+			},
+			expectedSigs: 1,
+			expected: fees.Dimensions{
+				fees.UTXORead:  1,
+				fees.UTXOWrite: 1,
+				fees.Compute:   secp256k1fx.CostPerSignature,
+			},
+		},
+		{
+			name: "export tx",
+			unsignedTx: &txs.ExportTx{
+				BaseTx: newTestBaseTx(
+					[]*avax.TransferableInput{newTestIn(1)},
+					[]*avax.TransferableOutput{newTestOut()},
+				),
+				DestinationChain: ids.GenerateTestID(),
+				ExportedOuts:     []*avax.TransferableOutput{newTestOut(), newTestOut()},
+			},
+			expectedSigs: 1,
+			expected: fees.Dimensions{
+				fees.UTXORead:  1,
+				fees.UTXOWrite: 3,
+				fees.Compute:   secp256k1fx.CostPerSignature,
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require := require.New(t)
+
+			_, err := TxComplexity(test.unsignedTx)
+			require.ErrorIs(err, ErrUninitializedTx)
+
+			unsignedBytes := []byte{0x00, 0x01, 0x02, 0x03}
+			test.unsignedTx.SetBytes(unsignedBytes)
+
+			complexity, err := TxComplexity(test.unsignedTx)
+			require.NoError(err)
+
+			expected := test.expected
+			expected[fees.Bandwidth] = uint64(len(unsignedBytes)) + test.expectedSigs*secp256k1.SignatureLen
+			require.Equal(expected, complexity)
+		})
+	}
+}
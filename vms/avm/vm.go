@@ -398,7 +398,7 @@ func (vm *VM) GetBlockIDAtHeight(_ context.Context, height uint64) (ids.ID, erro
 
 func (vm *VM) Linearize(ctx context.Context, stopVertexID ids.ID, toEngine chan<- common.Message) error {
 	time := version.GetCortinaTime(vm.ctx.NetworkID)
-	err := vm.state.InitializeChainState(stopVertexID, time)
+	err := vm.state.InitializeChainState(stopVertexID, time, vm.Config.DynamicFeeConfig.InitialFeeRate)
 	if err != nil {
 		return err
 	}
@@ -0,0 +1,51 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package fees
+
+import (
+	"errors"
+	"fmt"
+)
+
+var (
+	errZeroUpdateDenominator = errors.New("update denominator must be positive")
+	errInitialBelowMinRate   = errors.New("initial fee rate below the minimum fee rate")
+	errTargetAboveMax        = errors.New("target complexity rate above the block max complexity")
+)
+
+// DynamicFeesConfig describes how the fee rates of a chain evolve over time
+type DynamicFeesConfig struct {
+	// InitialFeeRate is the fee rate used when the dynamic fees are activated
+	InitialFeeRate Dimensions `json:"initialFeeRate"`
+
+	// MinFeeRate is the lowest value each fee rate can be lowered to
+	MinFeeRate Dimensions `json:"minFeeRate"`
+
+	// UpdateDenominator controls how quickly the fee rates react to a block
+	// deviating from the target complexity. A fee rate changes by at most
+	// 1/UpdateDenominator of its value per block.
+	UpdateDenominator Dimensions `json:"updateDenominator"`
+
+	// BlockMaxComplexity is the maximum complexity a single block can consume
+	BlockMaxComplexity Dimensions `json:"blockMaxComplexity"`
+
+	// BlockTargetComplexityRate is the complexity per second the fee rates are
+	// adjusted towards
+	BlockTargetComplexityRate Dimensions `json:"blockTargetComplexityRate"`
+}
+
+// Verify returns an error if the config would result in invalid fee rates
+func (c *DynamicFeesConfig) Verify() error {
+	for i := Dimension(0); i < FeeDimensions; i++ {
+		switch {
+		case c.UpdateDenominator[i] == 0:
+			return fmt.Errorf("%w: %s", errZeroUpdateDenominator, i)
+		case c.InitialFeeRate[i] < c.MinFeeRate[i]:
+			return fmt.Errorf("%w: %s", errInitialBelowMinRate, i)
+		case c.BlockTargetComplexityRate[i] > c.BlockMaxComplexity[i]:
+			return fmt.Errorf("%w: %s", errTargetAboveMax, i)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,89 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package fees
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+
+	"github.com/ava-labs/avalanchego/utils/wrappers"
+
+	safemath "github.com/ava-labs/avalanchego/utils/math"
+)
+
+const (
+	Bandwidth Dimension = iota
+	UTXORead
+	UTXOWrite
+	Compute
+
+	FeeDimensions = iota
+
+	// DimensionsLen is the number of bytes [Dimensions] are serialized into
+	DimensionsLen = FeeDimensions * wrappers.LongLen
+)
+
+var (
+	Empty = Dimensions{}
+
+	errWrongDimensionsLen = errors.New("wrong dimensions length")
+
+	dimensionNames = [FeeDimensions]string{
+		Bandwidth: "bandwidth",
+		UTXORead:  "utxoRead",
+		UTXOWrite: "utxoWrite",
+		Compute:   "compute",
+	}
+)
+
+// Dimension is a resource consumed by transactions that is priced separately
+type Dimension int
+
+func (d Dimension) String() string {
+	if d < 0 || d >= FeeDimensions {
+		return "unknown"
+	}
+	return dimensionNames[d]
+}
+
+// Dimensions holds a value for each fee dimension. It is used both to express
+// the complexity of transactions and blocks and the fee rates charged per unit
+// of complexity.
+type Dimensions [FeeDimensions]uint64
+
+// Add returns the sum of [lhs] and [rhs] in every dimension. An error is
+// returned if any dimension overflows.
+func Add(lhs, rhs Dimensions) (Dimensions, error) {
+	var res Dimensions
+	for i := Dimension(0); i < FeeDimensions; i++ {
+		v, err := safemath.Add64(lhs[i], rhs[i])
+		if err != nil {
+			return res, fmt.Errorf("%w: %s", err, i)
+		}
+		res[i] = v
+	}
+	return res, nil
+}
+
+// Bytes returns the fixed-length binary representation of [d]
+func (d Dimensions) Bytes() []byte {
+	b := make([]byte, DimensionsLen)
+	for i, v := range d {
+		binary.BigEndian.PutUint64(b[i*wrappers.LongLen:], v)
+	}
+	return b
+}
+
+// ParseDimensions parses [Dimensions] from bytes produced by [Dimensions.Bytes]
+func ParseDimensions(b []byte) (Dimensions, error) {
+	var d Dimensions
+	if len(b) != DimensionsLen {
+		return d, fmt.Errorf("%w: expected %d but got %d", errWrongDimensionsLen, DimensionsLen, len(b))
+	}
+	for i := range d {
+		d[i] = binary.BigEndian.Uint64(b[i*wrappers.LongLen:])
+	}
+	return d, nil
+}
@@ -0,0 +1,151 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package fees
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+
+	safemath "github.com/ava-labs/avalanchego/utils/math"
+)
+
+var ErrBlockFull = errors.New("block full")
+
+// Manager tracks the fee rates of a block and the complexity consumed by the
+// transactions included in it.
+//
+// Manager is not thread safe.
+type Manager struct {
+	// fee rates charged for each unit of complexity
+	feeRates Dimensions
+
+	// complexity consumed by the transactions processed so far
+	cumulatedComplexity Dimensions
+}
+
+func NewManager(feeRates Dimensions) *Manager {
+	return &Manager{
+		feeRates: feeRates,
+	}
+}
+
+func (m *Manager) GetFeeRates() Dimensions {
+	return m.feeRates
+}
+
+func (m *Manager) GetCumulatedComplexity() Dimensions {
+	return m.cumulatedComplexity
+}
+
+// CalculateFee returns the fee charged for consuming [complexity] at the
+// current fee rates.
+func (m *Manager) CalculateFee(complexity Dimensions) (uint64, error) {
+	var fee uint64
+	for i := Dimension(0); i < FeeDimensions; i++ {
+		contribution, err := safemath.Mul64(m.feeRates[i], complexity[i])
+		if err != nil {
+			return 0, fmt.Errorf("%w: %s", err, i)
+		}
+		fee, err = safemath.Add64(fee, contribution)
+		if err != nil {
+			return 0, fmt.Errorf("%w: %s", err, i)
+		}
+	}
+	return fee, nil
+}
+
+// CumulateComplexity adds [complexity] to the complexity consumed so far. If
+// the result would exceed [bounds] in any dimension, the cumulated complexity
+// is left unchanged and [ErrBlockFull] is returned.
+func (m *Manager) CumulateComplexity(complexity, bounds Dimensions) error {
+	cumulated, err := Add(m.cumulatedComplexity, complexity)
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrBlockFull, err)
+	}
+	for i := Dimension(0); i < FeeDimensions; i++ {
+		if cumulated[i] > bounds[i] {
+			return fmt.Errorf("%w: %s complexity %d exceeds bound %d",
+				ErrBlockFull,
+				i,
+				cumulated[i],
+				bounds[i],
+			)
+		}
+	}
+	m.cumulatedComplexity = cumulated
+	return nil
+}
+
+// RemoveComplexity removes [complexity] from the complexity consumed so far.
+func (m *Manager) RemoveComplexity(complexity Dimensions) error {
+	var cumulated Dimensions
+	for i := Dimension(0); i < FeeDimensions; i++ {
+		v, err := safemath.Sub(m.cumulatedComplexity[i], complexity[i])
+		if err != nil {
+			return fmt.Errorf("%w: %s", err, i)
+		}
+		cumulated[i] = v
+	}
+	m.cumulatedComplexity = cumulated
+	return nil
+}
+
+// UpdateFeeRates returns the fee rates of the block following a block that
+// consumed the cumulated complexity of [m]. [elapsedSeconds] is the time
+// between the parent block and the block whose complexity was cumulated.
+//
+// Each fee rate is adjusted proportionally to how much the consumed complexity
+// deviated from the target complexity, as in EIP-1559.
+func (m *Manager) UpdateFeeRates(config DynamicFeesConfig, elapsedSeconds uint64) Dimensions {
+	// Blocks with the same timestamp as their parent are targeted as if one
+	// second had elapsed.
+	elapsedSeconds = max(elapsedSeconds, 1)
+
+	var next Dimensions
+	for i := Dimension(0); i < FeeDimensions; i++ {
+		target, err := safemath.Mul64(config.BlockTargetComplexityRate[i], elapsedSeconds)
+		if err != nil {
+			target = math.MaxUint64
+		}
+		target = min(target, config.BlockMaxComplexity[i])
+		next[i] = nextFeeRate(
+			m.feeRates[i],
+			m.cumulatedComplexity[i],
+			target,
+			config.UpdateDenominator[i],
+		)
+		next[i] = max(next[i], config.MinFeeRate[i])
+	}
+	return next
+}
+
+func nextFeeRate(rate, consumed, target, denominator uint64) uint64 {
+	if target == 0 || denominator == 0 || consumed == target {
+		return rate
+	}
+
+	// delta = rate * |consumed - target| / target / denominator
+	delta := new(big.Int).SetUint64(rate)
+	delta.Mul(delta, new(big.Int).SetUint64(safemath.AbsDiff(consumed, target)))
+	delta.Div(delta, new(big.Int).SetUint64(target))
+	delta.Div(delta, new(big.Int).SetUint64(denominator))
+
+	if consumed < target {
+		// delta <= rate because consumed < target and denominator >= 1.
+		return rate - delta.Uint64()
+	}
+
+	// Always increase the fee rate when the target is exceeded so that a zero
+	// or small fee rate can't get stuck.
+	if delta.Sign() == 0 {
+		delta.SetUint64(1)
+	}
+	next := delta.Add(delta, new(big.Int).SetUint64(rate))
+	if !next.IsUint64() {
+		return math.MaxUint64
+	}
+	return next.Uint64()
+}
@@ -0,0 +1,198 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package fees
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	safemath "github.com/ava-labs/avalanchego/utils/math"
+)
+
+var testDynamicFeesConfig = DynamicFeesConfig{
+	InitialFeeRate:            Dimensions{10, 20, 30, 40},
+	MinFeeRate:                Dimensions{1, 1, 1, 1},
+	UpdateDenominator:         Dimensions{8, 8, 8, 8},
+	BlockMaxComplexity:        Dimensions{1000, 100, 100, 1000},
+	BlockTargetComplexityRate: Dimensions{250, 25, 25, 250},
+}
+
+func TestDimensionsSerialization(t *testing.T) {
+	require := require.New(t)
+
+	d := Dimensions{1, math.MaxUint64, 0, 1 << 32}
+	parsed, err := ParseDimensions(d.Bytes())
+	require.NoError(err)
+	require.Equal(d, parsed)
+
+	_, err = ParseDimensions(d.Bytes()[1:])
+	require.ErrorIs(err, errWrongDimensionsLen)
+}
+
+func TestAdd(t *testing.T) {
+	require := require.New(t)
+
+	sum, err := Add(Dimensions{1, 2, 3, 4}, Dimensions{4, 3, 2, 1})
+	require.NoError(err)
+	require.Equal(Dimensions{5, 5, 5, 5}, sum)
+
+	_, err = Add(Dimensions{0, math.MaxUint64}, Dimensions{0, 1})
+	require.ErrorIs(err, safemath.ErrOverflow)
+}
+
+func TestCalculateFee(t *testing.T) {
+	require := require.New(t)
+
+	m := NewManager(Dimensions{1, 2, 3, 4})
+	fee, err := m.CalculateFee(Dimensions{10, 10, 10, 10})
+	require.NoError(err)
+	require.Equal(uint64(100), fee)
+
+	m = NewManager(Dimensions{math.MaxUint64, 0, 0, 0})
+	_, err = m.CalculateFee(Dimensions{2, 0, 0, 0})
+	require.ErrorIs(err, safemath.ErrOverflow)
+}
+
+func TestCumulateComplexity(t *testing.T) {
+	require := require.New(t)
+
+	var (
+		m      = NewManager(testDynamicFeesConfig.InitialFeeRate)
+		bounds = testDynamicFeesConfig.BlockMaxComplexity
+	)
+	require.NoError(m.CumulateComplexity(Dimensions{500, 50, 50, 500}, bounds))
+	require.NoError(m.CumulateComplexity(Dimensions{500, 50, 50, 500}, bounds))
+	require.Equal(bounds, m.GetCumulatedComplexity())
+
+	// A full block doesn't accept any more complexity.
+	err := m.CumulateComplexity(Dimensions{0, 0, 1, 0}, bounds)
+	require.ErrorIs(err, ErrBlockFull)
+	require.Equal(bounds, m.GetCumulatedComplexity())
+
+	require.NoError(m.RemoveComplexity(Dimensions{500, 50, 50, 500}))
+	require.Equal(Dimensions{500, 50, 50, 500}, m.GetCumulatedComplexity())
+
+	err = m.RemoveComplexity(Dimensions{501, 0, 0, 0})
+	require.ErrorIs(err, safemath.ErrUnderflow)
+}
+
+func TestUpdateFeeRates(t *testing.T) {
+	tests := []struct {
+		name           string
+		feeRates       Dimensions
+		consumed       Dimensions
+		elapsedSeconds uint64
+		expected       Dimensions
+	}{
+		{
+			name:           "on target",
+			feeRates:       Dimensions{80, 80, 80, 80},
+			consumed:       Dimensions{500, 50, 50, 500},
+			elapsedSeconds: 2,
+			expected:       Dimensions{80, 80, 80, 80},
+		},
+		{
+			name:           "above target",
+			feeRates:       Dimensions{80, 80, 80, 80},
+			consumed:       Dimensions{500, 50, 50, 500},
+			elapsedSeconds: 1,
+			expected:       Dimensions{90, 90, 90, 90},
+		},
+		{
+			name:           "below target",
+			feeRates:       Dimensions{80, 80, 80, 80},
+			consumed:       Dimensions{0, 0, 0, 0},
+			elapsedSeconds: 1,
+			expected:       Dimensions{70, 70, 70, 70},
+		},
+		{
+			name:           "same timestamp treated as one second",
+			feeRates:       Dimensions{80, 80, 80, 80},
+			consumed:       Dimensions{250, 25, 25, 250},
+			elapsedSeconds: 0,
+			expected:       Dimensions{80, 80, 80, 80},
+		},
+		{
+			name:           "target capped by max complexity",
+			feeRates:       Dimensions{80, 80, 80, 80},
+			consumed:       Dimensions{1000, 100, 100, 1000},
+			elapsedSeconds: 100,
+			expected:       Dimensions{80, 80, 80, 80},
+		},
+		{
+			name:           "small rates always increase",
+			feeRates:       Dimensions{1, 1, 1, 1},
+			consumed:       Dimensions{251, 26, 26, 251},
+			elapsedSeconds: 1,
+			expected:       Dimensions{2, 2, 2, 2},
+		},
+		{
+			name:           "bounded by min rate",
+			feeRates:       Dimensions{1, 1, 1, 1},
+			consumed:       Dimensions{0, 0, 0, 0},
+			elapsedSeconds: 1,
+			expected:       Dimensions{1, 1, 1, 1},
+		},
+		{
+			name:           "saturates on overflow",
+			feeRates:       Dimensions{math.MaxUint64, 80, 80, 80},
+			consumed:       Dimensions{1000, 50, 50, 500},
+			elapsedSeconds: 2,
+			expected:       Dimensions{math.MaxUint64, 80, 80, 80},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require := require.New(t)
+
+			m := NewManager(test.feeRates)
+			require.NoError(m.CumulateComplexity(test.consumed, testDynamicFeesConfig.BlockMaxComplexity))
+			require.Equal(test.expected, m.UpdateFeeRates(testDynamicFeesConfig, test.elapsedSeconds))
+		})
+	}
+}
+
+func TestDynamicFeesConfigVerify(t *testing.T) {
+	tests := []struct {
+		name        string
+		modify      func(*DynamicFeesConfig)
+		expectedErr error
+	}{
+		{
+			name:        "valid",
+			modify:      func(*DynamicFeesConfig) {},
+			expectedErr: nil,
+		},
+		{
+			name: "zero update denominator",
+			modify: func(c *DynamicFeesConfig) {
+				c.UpdateDenominator[Compute] = 0
+			},
+			expectedErr: errZeroUpdateDenominator,
+		},
+		{
+			name: "initial below min",
+			modify: func(c *DynamicFeesConfig) {
+				c.InitialFeeRate[Bandwidth] = 0
+			},
+			expectedErr: errInitialBelowMinRate,
+		},
+		{
+			name: "target above max",
+			modify: func(c *DynamicFeesConfig) {
+				c.BlockTargetComplexityRate[UTXORead] = c.BlockMaxComplexity[UTXORead] + 1
+			},
+			expectedErr: errTargetAboveMax,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			config := testDynamicFeesConfig
+			test.modify(&config)
+			require.ErrorIs(t, config.Verify(), test.expectedErr)
+		})
+	}
+}
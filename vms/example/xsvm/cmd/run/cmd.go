@@ -10,16 +10,42 @@ import (
 
 	"github.com/ava-labs/avalanchego/vms/example/xsvm"
 	"github.com/ava-labs/avalanchego/vms/rpcchainvm"
+	"github.com/ava-labs/avalanchego/vms/rpcchainvm/grpcutils"
 )
 
 func Command() *cobra.Command {
-	return &cobra.Command{
+	c := &cobra.Command{
 		Use:   "xsvm",
 		Short: "Runs an XSVM plugin",
 		RunE:  runFunc,
 	}
+	flags := c.Flags()
+	AddFlags(flags)
+	return c
 }
 
-func runFunc(*cobra.Command, []string) error {
-	return rpcchainvm.Serve(context.Background(), &xsvm.VM{})
+func runFunc(c *cobra.Command, args []string) error {
+	config, err := ParseFlags(c.Flags(), args)
+	if err != nil {
+		return err
+	}
+
+	if config.RemoteAddress == "" {
+		return rpcchainvm.Serve(context.Background(), &xsvm.VM{})
+	}
+
+	tlsConfig, err := grpcutils.LoadMutualTLSConfig(config.TLSCertFile, config.TLSKeyFile, config.TLSCAFile)
+	if err != nil {
+		return err
+	}
+	return rpcchainvm.ServeRemote(
+		context.Background(),
+		&xsvm.VM{},
+		config.RemoteAddress,
+		grpcutils.Network{
+			ListenHost:    config.ListenHost,
+			AdvertiseHost: config.AdvertiseHost,
+			TLSConfig:     tlsConfig,
+		},
+	)
 }
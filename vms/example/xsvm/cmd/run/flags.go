@@ -0,0 +1,80 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package run
+
+import (
+	"github.com/spf13/pflag"
+)
+
+const (
+	RemoteAddressKey = "remote-address"
+	TLSCertFileKey   = "tls-cert-file"
+	TLSKeyFileKey    = "tls-key-file"
+	TLSCAFileKey     = "tls-ca-file"
+	ListenHostKey    = "listen-host"
+	AdvertiseHostKey = "advertise-host"
+)
+
+func AddFlags(flags *pflag.FlagSet) {
+	flags.String(RemoteAddressKey, "", "Address to serve the VM on for a remote runtime. If empty, the VM is served as a subprocess plugin")
+	flags.String(TLSCertFileKey, "", "PEM encoded certificate presented to AvalancheGo when serving remotely")
+	flags.String(TLSKeyFileKey, "", "PEM encoded key of the certificate presented to AvalancheGo when serving remotely")
+	flags.String(TLSCAFileKey, "", "PEM encoded CAs that AvalancheGo's certificate must be signed by when serving remotely")
+	flags.String(ListenHostKey, "", "Host to bind the VM's HTTP handlers to when serving remotely")
+	flags.String(AdvertiseHostKey, "", "Host that AvalancheGo dials to reach the VM's HTTP handlers when serving remotely")
+}
+
+type Config struct {
+	RemoteAddress string
+	TLSCertFile   string
+	TLSKeyFile    string
+	TLSCAFile     string
+	ListenHost    string
+	AdvertiseHost string
+}
+
+func ParseFlags(flags *pflag.FlagSet, args []string) (*Config, error) {
+	if err := flags.Parse(args); err != nil {
+		return nil, err
+	}
+
+	remoteAddress, err := flags.GetString(RemoteAddressKey)
+	if err != nil {
+		return nil, err
+	}
+
+	tlsCertFile, err := flags.GetString(TLSCertFileKey)
+	if err != nil {
+		return nil, err
+	}
+
+	tlsKeyFile, err := flags.GetString(TLSKeyFileKey)
+	if err != nil {
+		return nil, err
+	}
+
+	tlsCAFile, err := flags.GetString(TLSCAFileKey)
+	if err != nil {
+		return nil, err
+	}
+
+	listenHost, err := flags.GetString(ListenHostKey)
+	if err != nil {
+		return nil, err
+	}
+
+	advertiseHost, err := flags.GetString(AdvertiseHostKey)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Config{
+		RemoteAddress: remoteAddress,
+		TLSCertFile:   tlsCertFile,
+		TLSKeyFile:    tlsKeyFile,
+		TLSCAFile:     tlsCAFile,
+		ListenHost:    listenHost,
+		AdvertiseHost: advertiseHost,
+	}, nil
+}
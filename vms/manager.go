@@ -38,6 +38,8 @@ type Factory interface {
 //     the factory that the ID is associated with.
 //  3. Manage the aliases of VMs
 //  4. Manage the versions of VMs
+//  5. Replace a VM factory. To replace a VM is to associate the ID of a
+//     registered VM with a new VMFactory, for example to upgrade the VM.
 type Manager interface {
 	ids.Aliaser
 
@@ -49,6 +51,11 @@ type Manager interface {
 	// ID is [vmID]
 	RegisterFactory(ctx context.Context, vmID ids.ID, factory Factory) error
 
+	// Map the registered [vmID] to [factory] instead of its current factory.
+	// The current factory is kept if [factory] fails to create an instance of
+	// the vm.
+	ReplaceFactory(ctx context.Context, vmID ids.ID, factory Factory) error
+
 	// ListFactories returns all the IDs that have had factories registered.
 	ListFactories() ([]ids.ID, error)
 
@@ -107,7 +114,29 @@ func (m *manager) RegisterFactory(ctx context.Context, vmID ids.ID, factory Fact
 	}
 
 	m.factories[vmID] = factory
+	return m.updateVersion(ctx, vmID, factory)
+}
 
+func (m *manager) ReplaceFactory(ctx context.Context, vmID ids.ID, factory Factory) error {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	if _, exists := m.factories[vmID]; !exists {
+		return fmt.Errorf("%q was %w", vmID, ErrNotFound)
+	}
+	if err := m.updateVersion(ctx, vmID, factory); err != nil {
+		return err
+	}
+
+	m.factories[vmID] = factory
+	return nil
+}
+
+// updateVersion records the version reported by a new instance of the vm
+// created by [factory].
+//
+// Invariant: [m.lock] must be held.
+func (m *manager) updateVersion(ctx context.Context, vmID ids.ID, factory Factory) error {
 	vm, err := factory.New(m.log)
 	if err != nil {
 		return err
@@ -115,6 +144,7 @@ func (m *manager) RegisterFactory(ctx context.Context, vmID ids.ID, factory Fact
 
 	commonVM, ok := vm.(common.VM)
 	if !ok {
+		delete(m.versions, vmID)
 		return nil
 	}
 
@@ -208,6 +208,20 @@ func (mr *MockManagerMockRecorder) RemoveAliases(arg0 any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveAliases", reflect.TypeOf((*MockManager)(nil).RemoveAliases), arg0)
 }
 
+// ReplaceFactory mocks base method.
+func (m *MockManager) ReplaceFactory(arg0 context.Context, arg1 ids.ID, arg2 Factory) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReplaceFactory", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ReplaceFactory indicates an expected call of ReplaceFactory.
+func (mr *MockManagerMockRecorder) ReplaceFactory(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReplaceFactory", reflect.TypeOf((*MockManager)(nil).ReplaceFactory), arg0, arg1, arg2)
+}
+
 // Versions mocks base method.
 func (m *MockManager) Versions() (map[string]string, error) {
 	m.ctrl.T.Helper()
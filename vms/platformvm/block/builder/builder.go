@@ -18,6 +18,7 @@ import (
 	"github.com/ava-labs/avalanchego/utils/set"
 	"github.com/ava-labs/avalanchego/utils/timer/mockable"
 	"github.com/ava-labs/avalanchego/utils/units"
+	"github.com/ava-labs/avalanchego/vms/components/fees"
 	"github.com/ava-labs/avalanchego/vms/platformvm/block"
 	"github.com/ava-labs/avalanchego/vms/platformvm/state"
 	"github.com/ava-labs/avalanchego/vms/platformvm/status"
@@ -26,6 +27,7 @@ import (
 
 	blockexecutor "github.com/ava-labs/avalanchego/vms/platformvm/block/executor"
 	txexecutor "github.com/ava-labs/avalanchego/vms/platformvm/txs/executor"
+	txfees "github.com/ava-labs/avalanchego/vms/platformvm/txs/fees"
 )
 
 // targetBlockSize is maximum number of transaction bytes to place into a
@@ -332,9 +334,18 @@ func packBlockTxs(
 	}
 
 	var (
-		blockTxs []*txs.Tx
-		inputs   set.Set[ids.ID]
+		blockTxs   []*txs.Tx
+		inputs     set.Set[ids.ID]
+		isEActive  = backend.Config.IsEActivated(timestamp)
+		feeManager *fees.Manager
 	)
+	if isEActive {
+		feeRates, err := stateDiff.GetFeeRates()
+		if err != nil {
+			return nil, err
+		}
+		feeManager = fees.NewManager(feeRates)
+	}
 
 	for {
 		tx, exists := mempool.Peek()
@@ -345,6 +356,29 @@ func packBlockTxs(
 		if txSize > remainingSize {
 			break
 		}
+
+		var complexity fees.Dimensions
+		if isEActive {
+			complexity, err = txfees.TxComplexity(tx.Unsigned)
+			if err != nil {
+				mempool.Remove(tx)
+				mempool.MarkDropped(tx.ID(), err)
+				continue
+			}
+
+			err = feeManager.CumulateComplexity(complexity, backend.Config.DynamicFeeConfig.BlockMaxComplexity)
+			if err != nil {
+				if len(blockTxs) != 0 {
+					// The tx may fit into the next block.
+					break
+				}
+
+				// The tx doesn't even fit into an empty block.
+				mempool.Remove(tx)
+				mempool.MarkDropped(tx.ID(), err)
+				continue
+			}
+		}
 		mempool.Remove(tx)
 
 		// Invariant: [tx] has already been syntactically verified.
@@ -361,21 +395,20 @@ func packBlockTxs(
 		}
 
 		err = tx.Unsigned.Visit(executor)
-		if err != nil {
-			txID := tx.ID()
-			mempool.MarkDropped(txID, err)
-			continue
+		if err == nil && inputs.Overlaps(executor.Inputs) {
+			err = blockexecutor.ErrConflictingBlockTxs
 		}
-
-		if inputs.Overlaps(executor.Inputs) {
-			txID := tx.ID()
-			mempool.MarkDropped(txID, blockexecutor.ErrConflictingBlockTxs)
-			continue
+		if err == nil {
+			err = manager.VerifyUniqueInputs(parentID, executor.Inputs)
 		}
-		err = manager.VerifyUniqueInputs(parentID, executor.Inputs)
 		if err != nil {
 			txID := tx.ID()
 			mempool.MarkDropped(txID, err)
+			if isEActive {
+				if err := feeManager.RemoveComplexity(complexity); err != nil {
+					return nil, err
+				}
+			}
 			continue
 		}
 		inputs.Union(executor.Inputs)
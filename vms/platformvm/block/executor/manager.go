@@ -29,6 +29,10 @@ type Manager interface {
 
 	// Returns the ID of the most recently accepted block.
 	LastAccepted() ids.ID
+	// SetLastAccepted sets the most recently accepted block to [blkID], which
+	// must have been accepted without being processed by this manager. This
+	// happens when the state is replaced by state sync.
+	SetLastAccepted(blkID ids.ID)
 
 	SetPreference(blkID ids.ID) (updated bool)
 	Preferred() ids.ID
@@ -112,6 +116,11 @@ func (m *manager) NewBlock(blk block.Block) snowman.Block {
 	}
 }
 
+func (m *manager) SetLastAccepted(blkID ids.ID) {
+	m.backend.lastAccepted = blkID
+	clear(m.backend.blkIDToState)
+}
+
 func (m *manager) SetPreference(blkID ids.ID) bool {
 	updated := m.preferred != blkID
 	m.preferred = blkID
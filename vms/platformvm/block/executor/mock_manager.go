@@ -131,6 +131,18 @@ func (mr *MockManagerMockRecorder) Preferred() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Preferred", reflect.TypeOf((*MockManager)(nil).Preferred))
 }
 
+// SetLastAccepted mocks base method.
+func (m *MockManager) SetLastAccepted(blkID ids.ID) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetLastAccepted", blkID)
+}
+
+// SetLastAccepted indicates an expected call of SetLastAccepted.
+func (mr *MockManagerMockRecorder) SetLastAccepted(blkID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetLastAccepted", reflect.TypeOf((*MockManager)(nil).SetLastAccepted), blkID)
+}
+
 // SetPreference mocks base method.
 func (m *MockManager) SetPreference(blkID ids.ID) bool {
 	m.ctrl.T.Helper()
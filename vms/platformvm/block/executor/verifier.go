@@ -6,15 +6,19 @@ package executor
 import (
 	"errors"
 	"fmt"
+	"time"
 
 	"github.com/ava-labs/avalanchego/chains/atomic"
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/utils/set"
+	"github.com/ava-labs/avalanchego/vms/components/fees"
 	"github.com/ava-labs/avalanchego/vms/platformvm/block"
 	"github.com/ava-labs/avalanchego/vms/platformvm/state"
 	"github.com/ava-labs/avalanchego/vms/platformvm/status"
 	"github.com/ava-labs/avalanchego/vms/platformvm/txs"
 	"github.com/ava-labs/avalanchego/vms/platformvm/txs/executor"
+
+	txfees "github.com/ava-labs/avalanchego/vms/platformvm/txs/fees"
 )
 
 var (
@@ -27,6 +31,7 @@ var (
 	errIncorrectBlockHeight                  = errors.New("incorrect block height")
 	errChildBlockEarlierThanParent           = errors.New("proposed timestamp before current chain time")
 	errOptionBlockTimestampNotMatchingParent = errors.New("option block proposed timestamp not matching parent block one")
+	errBlockComplexityTooHigh                = errors.New("block complexity too high")
 )
 
 // verifier handles the logic for verifying a block.
@@ -61,15 +66,26 @@ func (v *verifier) BanffProposalBlock(b *block.BanffProposalBlock) error {
 	}
 
 	// Advance the time to [nextChainTime].
-	nextChainTime := b.Timestamp()
+	var (
+		parentChainTime = onDecisionState.GetTimestamp()
+		nextChainTime   = b.Timestamp()
+	)
 	if _, err := executor.AdvanceTimeTo(v.txExecutorBackend, onDecisionState, nextChainTime); err != nil {
 		return err
 	}
 
+	nextFeeRates, isEActive, err := v.nextFeeRates(onDecisionState, parentChainTime, nextChainTime, b.Transactions)
+	if err != nil {
+		return err
+	}
+
 	inputs, atomicRequests, onAcceptFunc, err := v.processStandardTxs(b.Transactions, onDecisionState, b.Parent())
 	if err != nil {
 		return err
 	}
+	if isEActive {
+		onDecisionState.SetFeeRates(nextFeeRates)
+	}
 
 	onCommitState, err := state.NewDiffOn(onDecisionState)
 	if err != nil {
@@ -104,10 +120,14 @@ func (v *verifier) BanffStandardBlock(b *block.BanffStandardBlock) error {
 	}
 
 	// Advance the time to [b.Timestamp()].
+	var (
+		parentChainTime = onAcceptState.GetTimestamp()
+		nextChainTime   = b.Timestamp()
+	)
 	changed, err := executor.AdvanceTimeTo(
 		v.txExecutorBackend,
 		onAcceptState,
-		b.Timestamp(),
+		nextChainTime,
 	)
 	if err != nil {
 		return err
@@ -119,7 +139,20 @@ func (v *verifier) BanffStandardBlock(b *block.BanffStandardBlock) error {
 		return errBanffStandardBlockWithoutChanges
 	}
 
-	return v.standardBlock(&b.ApricotStandardBlock, onAcceptState)
+	nextFeeRates, isEActive, err := v.nextFeeRates(onAcceptState, parentChainTime, nextChainTime, b.Transactions)
+	if err != nil {
+		return err
+	}
+
+	if err := v.standardBlock(&b.ApricotStandardBlock, onAcceptState); err != nil {
+		return err
+	}
+	if isEActive {
+		// The fee rates are updated after the transactions are executed so that
+		// the transactions are charged the fee rates of the parent block.
+		onAcceptState.SetFeeRates(nextFeeRates)
+	}
+	return nil
 }
 
 func (v *verifier) ApricotAbortBlock(b *block.ApricotAbortBlock) error {
@@ -400,6 +433,42 @@ func (v *verifier) proposalBlock(
 	return nil
 }
 
+// nextFeeRates verifies that [txs] don't exceed the maximum block complexity
+// and returns the fee rates to be charged by the child of a block issued at
+// [chainTime] containing [txs]. Returns false if the dynamic fees are not
+// active, in which case the fee rates must not be modified.
+func (v *verifier) nextFeeRates(
+	parentState state.Chain,
+	parentChainTime time.Time,
+	chainTime time.Time,
+	txs []*txs.Tx,
+) (fees.Dimensions, bool, error) {
+	cfg := v.txExecutorBackend.Config
+	if !cfg.IsEActivated(chainTime) {
+		return fees.Empty, false, nil
+	}
+
+	feeRates, err := parentState.GetFeeRates()
+	if err != nil {
+		return fees.Empty, false, err
+	}
+
+	feeManager := fees.NewManager(feeRates)
+	for _, tx := range txs {
+		complexity, err := txfees.TxComplexity(tx.Unsigned)
+		if err != nil {
+			return fees.Empty, false, err
+		}
+		if err := feeManager.CumulateComplexity(complexity, cfg.DynamicFeeConfig.BlockMaxComplexity); err != nil {
+			return fees.Empty, false, fmt.Errorf("%w: %w", errBlockComplexityTooHigh, err)
+		}
+	}
+
+	// Invariant: [chainTime] was verified to not be before [parentChainTime].
+	elapsedSeconds := uint64(chainTime.Unix() - parentChainTime.Unix())
+	return feeManager.UpdateFeeRates(cfg.DynamicFeeConfig, elapsedSeconds), true, nil
+}
+
 // standardBlock populates the state of this block if [nil] is returned
 func (v *verifier) standardBlock(
 	b *block.ApricotStandardBlock,
@@ -16,6 +16,7 @@ import (
 	"github.com/ava-labs/avalanchego/utils/formatting/address"
 	"github.com/ava-labs/avalanchego/utils/json"
 	"github.com/ava-labs/avalanchego/utils/rpc"
+	"github.com/ava-labs/avalanchego/vms/components/fees"
 	"github.com/ava-labs/avalanchego/vms/platformvm/status"
 )
 
@@ -33,6 +34,9 @@ type Client interface {
 	//
 	// Deprecated: GetUTXOs should be used instead.
 	GetBalance(ctx context.Context, addrs []ids.ShortID, options ...rpc.Option) (*GetBalanceResponse, error)
+	// GetBalanceAtHeight returns the balance of [addrs] on the P Chain as of
+	// the accepted block at [height]
+	GetBalanceAtHeight(ctx context.Context, addrs []ids.ShortID, height uint64, options ...rpc.Option) (*GetBalanceResponse, error)
 	// ListAddresses returns an array of platform addresses controlled by [user]
 	//
 	// Deprecated: Keys should no longer be stored on the node.
@@ -46,6 +50,17 @@ type Client interface {
 		startUTXOID ids.ID,
 		options ...rpc.Option,
 	) ([][]byte, ids.ShortID, ids.ID, error)
+	// GetUTXOsAtHeight returns the byte representation of the UTXOs controlled
+	// by [addrs] as of the accepted block at [height]
+	GetUTXOsAtHeight(
+		ctx context.Context,
+		addrs []ids.ShortID,
+		height uint64,
+		limit uint32,
+		startAddress ids.ShortID,
+		startUTXOID ids.ID,
+		options ...rpc.Option,
+	) ([][]byte, ids.ShortID, ids.ID, error)
 	// GetAtomicUTXOs returns the byte representation of the atomic UTXOs controlled by [addrs]
 	// from [sourceChain]
 	GetAtomicUTXOs(
@@ -59,6 +74,9 @@ type Client interface {
 	) ([][]byte, ids.ShortID, ids.ID, error)
 	// GetSubnet returns information about the specified subnet
 	GetSubnet(ctx context.Context, subnetID ids.ID, options ...rpc.Option) (GetSubnetClientResponse, error)
+	// GetSubnetAtHeight returns information about the specified subnet as of
+	// the accepted block at [height]
+	GetSubnetAtHeight(ctx context.Context, subnetID ids.ID, height uint64, options ...rpc.Option) (GetSubnetClientResponse, error)
 	// GetSubnets returns information about the specified subnets
 	//
 	// Deprecated: Subnets should be fetched from a dedicated indexer.
@@ -68,6 +86,15 @@ type Client interface {
 	GetStakingAssetID(ctx context.Context, subnetID ids.ID, options ...rpc.Option) (ids.ID, error)
 	// GetCurrentValidators returns the list of current validators for subnet with ID [subnetID]
 	GetCurrentValidators(ctx context.Context, subnetID ids.ID, nodeIDs []ids.NodeID, options ...rpc.Option) ([]ClientPermissionlessValidator, error)
+	// GetCurrentValidatorsAtHeight returns the list of validators for subnet
+	// with ID [subnetID] as of the accepted block at [height]
+	GetCurrentValidatorsAtHeight(
+		ctx context.Context,
+		subnetID ids.ID,
+		nodeIDs []ids.NodeID,
+		height uint64,
+		options ...rpc.Option,
+	) ([]ClientPermissionlessValidator, error)
 	// GetCurrentSupply returns an upper bound on the supply of AVAX in the system along with the P-chain height
 	GetCurrentSupply(ctx context.Context, subnetID ids.ID, options ...rpc.Option) (uint64, uint64, error)
 	// SampleValidators returns the nodeIDs of a sample of [sampleSize] validators from the current validator set for subnet with ID [subnetID]
@@ -119,6 +146,9 @@ type Client interface {
 	GetRewardUTXOs(context.Context, *api.GetTxArgs, ...rpc.Option) ([][]byte, error)
 	// GetTimestamp returns the current chain timestamp
 	GetTimestamp(ctx context.Context, options ...rpc.Option) (time.Time, error)
+	// GetFeeRates returns the dynamic fee rates charged to the transactions of
+	// the next block and whether the dynamic fees are currently active
+	GetFeeRates(ctx context.Context, options ...rpc.Option) (fees.Dimensions, bool, error)
 	// GetValidatorsAt returns the weights of the validator set of a provided
 	// subnet at the specified height.
 	GetValidatorsAt(
@@ -131,6 +161,16 @@ type Client interface {
 	GetBlock(ctx context.Context, blockID ids.ID, options ...rpc.Option) ([]byte, error)
 	// GetBlockByHeight returns the block at the given [height].
 	GetBlockByHeight(ctx context.Context, height uint64, options ...rpc.Option) ([]byte, error)
+	// GetAddressTxs returns the IDs of at most [pageSize] accepted transactions
+	// that touched [addr], skipping the first [cursor] of them. The returned
+	// cursor should be provided to fetch the next page.
+	GetAddressTxs(
+		ctx context.Context,
+		addr ids.ShortID,
+		cursor uint64,
+		pageSize uint64,
+		options ...rpc.Option,
+	) ([]ids.ID, uint64, error)
 }
 
 // Client implementation for interacting with the P Chain endpoint
@@ -168,6 +208,17 @@ func (c *client) GetBalance(ctx context.Context, addrs []ids.ShortID, options ..
 	return res, err
 }
 
+func (c *client) GetBalanceAtHeight(ctx context.Context, addrs []ids.ShortID, height uint64, options ...rpc.Option) (*GetBalanceResponse, error) {
+	res := &GetBalanceResponse{}
+	err := c.requester.SendRequest(ctx, "platform.getBalanceAtHeight", &GetBalanceAtHeightRequest{
+		GetBalanceRequest: GetBalanceRequest{
+			Addresses: ids.ShortIDsToStrings(addrs),
+		},
+		Height: json.Uint64(height),
+	}, res, options...)
+	return res, err
+}
+
 func (c *client) ListAddresses(ctx context.Context, user api.UserPass, options ...rpc.Option) ([]ids.ShortID, error) {
 	res := &api.JSONAddresses{}
 	err := c.requester.SendRequest(ctx, "platform.listAddresses", &user, res, options...)
@@ -211,7 +262,38 @@ func (c *client) GetAtomicUTXOs(
 	if err != nil {
 		return nil, ids.ShortID{}, ids.Empty, err
 	}
+	return parseGetUTXOsReply(res)
+}
+
+func (c *client) GetUTXOsAtHeight(
+	ctx context.Context,
+	addrs []ids.ShortID,
+	height uint64,
+	limit uint32,
+	startAddress ids.ShortID,
+	startUTXOID ids.ID,
+	options ...rpc.Option,
+) ([][]byte, ids.ShortID, ids.ID, error) {
+	res := &api.GetUTXOsReply{}
+	err := c.requester.SendRequest(ctx, "platform.getUTXOsAtHeight", &GetUTXOsAtHeightArgs{
+		GetUTXOsArgs: api.GetUTXOsArgs{
+			Addresses: ids.ShortIDsToStrings(addrs),
+			Limit:     json.Uint32(limit),
+			StartIndex: api.Index{
+				Address: startAddress.String(),
+				UTXO:    startUTXOID.String(),
+			},
+			Encoding: formatting.Hex,
+		},
+		Height: json.Uint64(height),
+	}, res, options...)
+	if err != nil {
+		return nil, ids.ShortID{}, ids.Empty, err
+	}
+	return parseGetUTXOsReply(res)
+}
 
+func parseGetUTXOsReply(res *api.GetUTXOsReply) ([][]byte, ids.ShortID, ids.ID, error) {
 	utxos := make([][]byte, len(res.UTXOs))
 	for i, utxo := range res.UTXOs {
 		utxoBytes, err := formatting.Decode(res.Encoding, utxo)
@@ -248,6 +330,24 @@ func (c *client) GetSubnet(ctx context.Context, subnetID ids.ID, options ...rpc.
 	if err != nil {
 		return GetSubnetClientResponse{}, err
 	}
+	return newGetSubnetClientResponse(res)
+}
+
+func (c *client) GetSubnetAtHeight(ctx context.Context, subnetID ids.ID, height uint64, options ...rpc.Option) (GetSubnetClientResponse, error) {
+	res := &GetSubnetResponse{}
+	err := c.requester.SendRequest(ctx, "platform.getSubnetAtHeight", &GetSubnetAtHeightArgs{
+		GetSubnetArgs: GetSubnetArgs{
+			SubnetID: subnetID,
+		},
+		Height: json.Uint64(height),
+	}, res, options...)
+	if err != nil {
+		return GetSubnetClientResponse{}, err
+	}
+	return newGetSubnetClientResponse(res)
+}
+
+func newGetSubnetClientResponse(res *GetSubnetResponse) (GetSubnetClientResponse, error) {
 	controlKeys, err := address.ParseToIDs(res.ControlKeys)
 	if err != nil {
 		return GetSubnetClientResponse{}, err
@@ -322,6 +422,27 @@ func (c *client) GetCurrentValidators(
 	return getClientPermissionlessValidators(res.Validators)
 }
 
+func (c *client) GetCurrentValidatorsAtHeight(
+	ctx context.Context,
+	subnetID ids.ID,
+	nodeIDs []ids.NodeID,
+	height uint64,
+	options ...rpc.Option,
+) ([]ClientPermissionlessValidator, error) {
+	res := &GetCurrentValidatorsReply{}
+	err := c.requester.SendRequest(ctx, "platform.getCurrentValidatorsAtHeight", &GetCurrentValidatorsAtHeightArgs{
+		GetCurrentValidatorsArgs: GetCurrentValidatorsArgs{
+			SubnetID: subnetID,
+			NodeIDs:  nodeIDs,
+		},
+		Height: json.Uint64(height),
+	}, res, options...)
+	if err != nil {
+		return nil, err
+	}
+	return getClientPermissionlessValidators(res.Validators)
+}
+
 func (c *client) GetCurrentSupply(ctx context.Context, subnetID ids.ID, options ...rpc.Option) (uint64, uint64, error) {
 	res := &GetCurrentSupplyReply{}
 	err := c.requester.SendRequest(ctx, "platform.getCurrentSupply", &GetCurrentSupplyArgs{
@@ -509,6 +630,16 @@ func (c *client) GetTimestamp(ctx context.Context, options ...rpc.Option) (time.
 	return res.Timestamp, err
 }
 
+func (c *client) GetFeeRates(ctx context.Context, options ...rpc.Option) (fees.Dimensions, bool, error) {
+	res := &GetFeeRatesReply{}
+	err := c.requester.SendRequest(ctx, "platform.getFeeRates", struct{}{}, res, options...)
+	var feeRates fees.Dimensions
+	for i, feeRate := range res.FeeRates {
+		feeRates[i] = uint64(feeRate)
+	}
+	return feeRates, res.Active, err
+}
+
 func (c *client) GetValidatorsAt(
 	ctx context.Context,
 	subnetID ids.ID,
@@ -545,3 +676,19 @@ func (c *client) GetBlockByHeight(ctx context.Context, height uint64, options ..
 	}
 	return formatting.Decode(res.Encoding, res.Block)
 }
+
+func (c *client) GetAddressTxs(
+	ctx context.Context,
+	addr ids.ShortID,
+	cursor uint64,
+	pageSize uint64,
+	options ...rpc.Option,
+) ([]ids.ID, uint64, error) {
+	res := &GetAddressTxsReply{}
+	err := c.requester.SendRequest(ctx, "platform.getAddressTxs", &GetAddressTxsArgs{
+		JSONAddress: api.JSONAddress{Address: addr.String()},
+		Cursor:      json.Uint64(cursor),
+		PageSize:    json.Uint64(pageSize),
+	}, res, options...)
+	return res.TxIDs, uint64(res.Cursor), err
+}
@@ -12,6 +12,7 @@ import (
 	"github.com/ava-labs/avalanchego/snow/validators"
 	"github.com/ava-labs/avalanchego/utils/constants"
 	"github.com/ava-labs/avalanchego/utils/set"
+	"github.com/ava-labs/avalanchego/vms/components/fees"
 	"github.com/ava-labs/avalanchego/vms/platformvm/reward"
 	"github.com/ava-labs/avalanchego/vms/platformvm/txs"
 )
@@ -68,6 +69,9 @@ type Config struct {
 	// Transaction fee for adding a subnet delegator
 	AddSubnetDelegatorFee uint64
 
+	// Parameters of the dynamic fees charged after the E upgrade
+	DynamicFeeConfig fees.DynamicFeesConfig
+
 	// The minimum amount of tokens one must bond to be a validator
 	MinValidatorStake uint64
 
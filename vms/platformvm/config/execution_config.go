@@ -5,6 +5,7 @@ package config
 
 import (
 	"encoding/json"
+	"errors"
 	"time"
 
 	"github.com/ava-labs/avalanchego/utils/units"
@@ -23,8 +24,17 @@ var DefaultExecutionConfig = ExecutionConfig{
 	FxOwnerCacheSize:             4 * units.MiB,
 	ChecksumsEnabled:             false,
 	MempoolPruneFrequency:        30 * time.Minute,
+	ArchivalModeEnabled:          false,
+	AddressIndexEnabled:          false,
+	StateSyncServingEnabled:      false,
+	StateSyncEnabled:             false,
 }
 
+var (
+	errStateSyncRequiresServing = errors.New("state sync requires state sync serving to be enabled")
+	errStateSyncWithHistory     = errors.New("state sync is incompatible with archival mode and the address index")
+)
+
 // ExecutionConfig provides execution parameters of PlatformVM
 type ExecutionConfig struct {
 	Network                      network.Config `json:"network"`
@@ -38,6 +48,39 @@ type ExecutionConfig struct {
 	FxOwnerCacheSize             int            `json:"fx-owner-cache-size"`
 	ChecksumsEnabled             bool           `json:"checksums-enabled"`
 	MempoolPruneFrequency        time.Duration  `json:"mempool-prune-frequency"`
+	// ArchivalModeEnabled indexes the state at every accepted height so that
+	// it can be queried historically. Pending stakers aren't archived. Must be
+	// enabled before the chain is first initialized.
+	ArchivalModeEnabled bool `json:"archival-mode-enabled"`
+	// AddressIndexEnabled indexes accepted transactions by the addresses they
+	// touched. Must be enabled before the chain is first initialized.
+	AddressIndexEnabled bool `json:"address-index-enabled"`
+	// StateSyncServingEnabled maintains a merkleized copy of the chain state,
+	// and periodic summaries of it, so that peers can state sync the chain.
+	// The copy roughly doubles the disk usage of the state. Enabling it on an
+	// existing node rebuilds the copy from the current state on startup.
+	StateSyncServingEnabled bool `json:"state-sync-serving-enabled"`
+	// StateSyncEnabled allows the node to fetch the state of the chain from
+	// its peers, rather than executing every historical block, when it is
+	// first initialized. Only the validator diffs of the 1024 blocks before
+	// the synced height are fetched, so validator sets prior to them, as used
+	// by chains that verify blocks against old P-chain heights, are
+	// unavailable.
+	StateSyncEnabled bool `json:"state-sync-enabled"`
+}
+
+// Verify returns an error if the config contains incompatible options.
+func (c *ExecutionConfig) Verify() error {
+	if !c.StateSyncEnabled {
+		return nil
+	}
+	if !c.StateSyncServingEnabled {
+		return errStateSyncRequiresServing
+	}
+	if c.ArchivalModeEnabled || c.AddressIndexEnabled {
+		return errStateSyncWithHistory
+	}
+	return nil
 }
 
 // GetExecutionConfig returns an ExecutionConfig
@@ -51,5 +94,8 @@ func GetExecutionConfig(b []byte) (*ExecutionConfig, error) {
 		return &ec, nil
 	}
 
-	return &ec, json.Unmarshal(b, &ec)
+	if err := json.Unmarshal(b, &ec); err != nil {
+		return nil, err
+	}
+	return &ec, ec.Verify()
 }
@@ -70,7 +70,10 @@ func TestExecutionConfigUnmarshal(t *testing.T) {
 			"block-id-cache-size": 8,
 			"fx-owner-cache-size": 9,
 			"checksums-enabled": true,
-			"mempool-prune-frequency": 60000000000
+			"mempool-prune-frequency": 60000000000,
+			"archival-mode-enabled": true,
+			"address-index-enabled": true,
+			"state-sync-serving-enabled": false
 		}`)
 		ec, err := GetExecutionConfig(b)
 		require.NoError(err)
@@ -104,6 +107,9 @@ func TestExecutionConfigUnmarshal(t *testing.T) {
 			FxOwnerCacheSize:             9,
 			ChecksumsEnabled:             true,
 			MempoolPruneFrequency:        time.Minute,
+			ArchivalModeEnabled:          true,
+			AddressIndexEnabled:          true,
+			StateSyncServingEnabled:      false,
 		}
 		require.Equal(expected, ec)
 	})
@@ -165,4 +171,15 @@ func TestExecutionConfigUnmarshal(t *testing.T) {
 		}
 		require.Equal(expected, ec)
 	})
+	t.Run("state sync without serving", func(t *testing.T) {
+		b := []byte(`{"state-sync-enabled":true,"state-sync-serving-enabled":false}`)
+		_, err := GetExecutionConfig(b)
+		require.ErrorIs(t, err, errStateSyncRequiresServing)
+	})
+
+	t.Run("state sync with archival mode", func(t *testing.T) {
+		b := []byte(`{"state-sync-enabled":true,"state-sync-serving-enabled":true,"archival-mode-enabled":true}`)
+		_, err := GetExecutionConfig(b)
+		require.ErrorIs(t, err, errStateSyncWithHistory)
+	})
 }
@@ -21,7 +21,10 @@ import (
 	"github.com/ava-labs/avalanchego/vms/platformvm/txs/mempool"
 )
 
-const TxGossipHandlerID = 0
+const (
+	TxGossipHandlerID = iota
+	StateSyncHandlerID
+)
 
 var errMempoolDisabledWithPartialSync = errors.New("mempool is disabled partial syncing")
 
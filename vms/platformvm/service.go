@@ -28,6 +28,7 @@ import (
 	"github.com/ava-labs/avalanchego/utils/logging"
 	"github.com/ava-labs/avalanchego/utils/set"
 	"github.com/ava-labs/avalanchego/vms/components/avax"
+	"github.com/ava-labs/avalanchego/vms/components/fees"
 	"github.com/ava-labs/avalanchego/vms/components/keystore"
 	"github.com/ava-labs/avalanchego/vms/platformvm/fx"
 	"github.com/ava-labs/avalanchego/vms/platformvm/reward"
@@ -51,6 +52,9 @@ const (
 	// Max number of addresses that can be passed in as argument to GetStake
 	maxGetStakeAddrs = 256
 
+	// Max number of tx IDs that can be returned by GetAddressTxs
+	maxGetAddressTxsPageSize = 1024
+
 	// Note: Staker attributes cache should be large enough so that no evictions
 	// happen when the API loops through all stakers.
 	stakerAttributesCacheSize = 100_000
@@ -60,7 +64,9 @@ var (
 	errMissingDecisionBlock       = errors.New("should have a decision block within the past two blocks")
 	errPrimaryNetworkIsNotASubnet = errors.New("the primary network isn't a subnet")
 	errNoAddresses                = errors.New("no addresses provided")
+	errAtomicUTXOsNotArchived     = errors.New("atomic UTXOs are not archived")
 	errMissingBlockchainID        = errors.New("argument 'blockchainID' not given")
+	errPageSizeTooLarge           = errors.New("pageSize > maximum allowed")
 )
 
 // Service defines the API calls that can be made to the platform chain
@@ -178,8 +184,13 @@ func (s *Service) GetBalance(_ *http.Request, args *GetBalanceRequest, response
 		return fmt.Errorf("couldn't get UTXO set of %v: %w", args.Addresses, err)
 	}
 
-	currentTime := s.vm.clock.Unix()
+	s.populateBalance(utxos, s.vm.clock.Unix(), response)
+	return nil
+}
 
+// populateBalance sets the balances in [response] to the amounts held in
+// [utxos] as of [currentTime].
+func (s *Service) populateBalance(utxos []*avax.UTXO, currentTime uint64, response *GetBalanceResponse) {
 	unlockeds := map[ids.ID]uint64{}
 	lockedStakeables := map[ids.ID]uint64{}
 	lockedNotStakeables := map[ids.ID]uint64{}
@@ -267,6 +278,49 @@ utxoFor:
 	response.Unlocked = response.Unlockeds[s.vm.ctx.AVAXAssetID]
 	response.LockedStakeable = response.LockedStakeables[s.vm.ctx.AVAXAssetID]
 	response.LockedNotStakeable = response.LockedNotStakeables[s.vm.ctx.AVAXAssetID]
+}
+
+// GetBalanceAtHeightRequest is the request format for GetBalanceAtHeight
+type GetBalanceAtHeightRequest struct {
+	GetBalanceRequest
+	Height avajson.Uint64 `json:"height"`
+}
+
+// GetBalanceAtHeight gets the balance of an address as of the accepted block
+// at the provided height. Requires archival mode to be enabled.
+func (s *Service) GetBalanceAtHeight(_ *http.Request, args *GetBalanceAtHeightRequest, response *GetBalanceResponse) error {
+	s.vm.ctx.Log.Debug("API called",
+		zap.String("service", "platform"),
+		zap.String("method", "getBalanceAtHeight"),
+		logging.UserStrings("addresses", args.Addresses),
+		zap.Uint64("height", uint64(args.Height)),
+	)
+
+	addrs, err := avax.ParseServiceAddresses(s.addrManager, args.Addresses)
+	if err != nil {
+		return err
+	}
+
+	s.vm.ctx.Lock.Lock()
+	defer s.vm.ctx.Lock.Unlock()
+
+	historicalState, err := s.vm.state.GetHistoricalState(uint64(args.Height))
+	if err != nil {
+		return err
+	}
+	timestamp, err := historicalState.GetTimestamp()
+	if err != nil {
+		return fmt.Errorf("couldn't get timestamp at height %d: %w", args.Height, err)
+	}
+
+	utxos, err := avax.GetAllUTXOs(historicalState, addrs)
+	if err != nil {
+		return fmt.Errorf("couldn't get UTXO set of %v: %w", args.Addresses, err)
+	}
+
+	// Locktimes are compared against the chain time of the requested height
+	// rather than the current wall clock time.
+	s.populateBalance(utxos, uint64(timestamp.Unix()), response)
 	return nil
 }
 
@@ -323,11 +377,9 @@ func (s *Service) GetUTXOs(_ *http.Request, args *api.GetUTXOsArgs, response *ap
 		zap.String("method", "getUTXOs"),
 	)
 
-	if len(args.Addresses) == 0 {
-		return errNoAddresses
-	}
-	if len(args.Addresses) > maxGetUTXOsAddrs {
-		return fmt.Errorf("number of addresses given, %d, exceeds maximum, %d", len(args.Addresses), maxGetUTXOsAddrs)
+	addrSet, startAddr, startUTXO, limit, err := s.parseGetUTXOsArgs(args)
+	if err != nil {
+		return err
 	}
 
 	var sourceChain ids.ID
@@ -341,33 +393,11 @@ func (s *Service) GetUTXOs(_ *http.Request, args *api.GetUTXOsArgs, response *ap
 		sourceChain = chainID
 	}
 
-	addrSet, err := avax.ParseServiceAddresses(s.addrManager, args.Addresses)
-	if err != nil {
-		return err
-	}
-
-	startAddr := ids.ShortEmpty
-	startUTXO := ids.Empty
-	if args.StartIndex.Address != "" || args.StartIndex.UTXO != "" {
-		startAddr, err = avax.ParseServiceAddress(s.addrManager, args.StartIndex.Address)
-		if err != nil {
-			return fmt.Errorf("couldn't parse start index address %q: %w", args.StartIndex.Address, err)
-		}
-		startUTXO, err = ids.FromString(args.StartIndex.UTXO)
-		if err != nil {
-			return fmt.Errorf("couldn't parse start index utxo: %w", err)
-		}
-	}
-
 	var (
 		utxos     []*avax.UTXO
 		endAddr   ids.ShortID
 		endUTXOID ids.ID
 	)
-	limit := int(args.Limit)
-	if limit <= 0 || builder.MaxPageSize < limit {
-		limit = builder.MaxPageSize
-	}
 
 	s.vm.ctx.Lock.Lock()
 	defer s.vm.ctx.Lock.Unlock()
@@ -392,16 +422,107 @@ func (s *Service) GetUTXOs(_ *http.Request, args *api.GetUTXOsArgs, response *ap
 	if err != nil {
 		return fmt.Errorf("problem retrieving UTXOs: %w", err)
 	}
+	return s.populateUTXOs(utxos, endAddr, endUTXOID, args.Encoding, response)
+}
+
+// GetUTXOsAtHeightArgs are the arguments for calling GetUTXOsAtHeight
+type GetUTXOsAtHeightArgs struct {
+	api.GetUTXOsArgs
+	Height avajson.Uint64 `json:"height"`
+}
+
+// GetUTXOsAtHeight returns the UTXOs controlled by the given addresses as of
+// the accepted block at the provided height. Requires archival mode to be
+// enabled. Atomic UTXOs are not archived.
+func (s *Service) GetUTXOsAtHeight(_ *http.Request, args *GetUTXOsAtHeightArgs, response *api.GetUTXOsReply) error {
+	s.vm.ctx.Log.Debug("API called",
+		zap.String("service", "platform"),
+		zap.String("method", "getUTXOsAtHeight"),
+		zap.Uint64("height", uint64(args.Height)),
+	)
+
+	if args.SourceChain != "" {
+		return errAtomicUTXOsNotArchived
+	}
+
+	addrSet, startAddr, startUTXO, limit, err := s.parseGetUTXOsArgs(&args.GetUTXOsArgs)
+	if err != nil {
+		return err
+	}
+
+	s.vm.ctx.Lock.Lock()
+	defer s.vm.ctx.Lock.Unlock()
+
+	historicalState, err := s.vm.state.GetHistoricalState(uint64(args.Height))
+	if err != nil {
+		return err
+	}
+
+	utxos, endAddr, endUTXOID, err := avax.GetPaginatedUTXOs(
+		historicalState,
+		addrSet,
+		startAddr,
+		startUTXO,
+		limit,
+	)
+	if err != nil {
+		return fmt.Errorf("problem retrieving UTXOs: %w", err)
+	}
+	return s.populateUTXOs(utxos, endAddr, endUTXOID, args.Encoding, response)
+}
+
+// parseGetUTXOsArgs returns the addresses, the pagination start index, and the
+// page size requested by [args].
+func (s *Service) parseGetUTXOsArgs(args *api.GetUTXOsArgs) (set.Set[ids.ShortID], ids.ShortID, ids.ID, int, error) {
+	if len(args.Addresses) == 0 {
+		return nil, ids.ShortEmpty, ids.Empty, 0, errNoAddresses
+	}
+	if len(args.Addresses) > maxGetUTXOsAddrs {
+		return nil, ids.ShortEmpty, ids.Empty, 0, fmt.Errorf("number of addresses given, %d, exceeds maximum, %d", len(args.Addresses), maxGetUTXOsAddrs)
+	}
+
+	addrSet, err := avax.ParseServiceAddresses(s.addrManager, args.Addresses)
+	if err != nil {
+		return nil, ids.ShortEmpty, ids.Empty, 0, err
+	}
+
+	startAddr := ids.ShortEmpty
+	startUTXO := ids.Empty
+	if args.StartIndex.Address != "" || args.StartIndex.UTXO != "" {
+		startAddr, err = avax.ParseServiceAddress(s.addrManager, args.StartIndex.Address)
+		if err != nil {
+			return nil, ids.ShortEmpty, ids.Empty, 0, fmt.Errorf("couldn't parse start index address %q: %w", args.StartIndex.Address, err)
+		}
+		startUTXO, err = ids.FromString(args.StartIndex.UTXO)
+		if err != nil {
+			return nil, ids.ShortEmpty, ids.Empty, 0, fmt.Errorf("couldn't parse start index utxo: %w", err)
+		}
+	}
+
+	limit := int(args.Limit)
+	if limit <= 0 || builder.MaxPageSize < limit {
+		limit = builder.MaxPageSize
+	}
+	return addrSet, startAddr, startUTXO, limit, nil
+}
 
+// populateUTXOs encodes [utxos] and the pagination end index into [response].
+func (s *Service) populateUTXOs(
+	utxos []*avax.UTXO,
+	endAddr ids.ShortID,
+	endUTXOID ids.ID,
+	encoding formatting.Encoding,
+	response *api.GetUTXOsReply,
+) error {
 	response.UTXOs = make([]string, len(utxos))
 	for i, utxo := range utxos {
 		bytes, err := txs.Codec.Marshal(txs.CodecVersion, utxo)
 		if err != nil {
 			return fmt.Errorf("couldn't serialize UTXO %q: %w", utxo.InputID(), err)
 		}
-		response.UTXOs[i], err = formatting.Encode(args.Encoding, bytes)
+		response.UTXOs[i], err = formatting.Encode(encoding, bytes)
 		if err != nil {
-			return fmt.Errorf("couldn't encode UTXO %s as %s: %w", utxo.InputID(), args.Encoding, err)
+			return fmt.Errorf("couldn't encode UTXO %s as %s: %w", utxo.InputID(), encoding, err)
 		}
 	}
 
@@ -413,7 +534,7 @@ func (s *Service) GetUTXOs(_ *http.Request, args *api.GetUTXOsArgs, response *ap
 	response.EndIndex.Address = endAddress
 	response.EndIndex.UTXO = endUTXOID.String()
 	response.NumFetched = avajson.Uint64(len(utxos))
-	response.Encoding = args.Encoding
+	response.Encoding = encoding
 	return nil
 }
 
@@ -453,6 +574,77 @@ func (s *Service) GetSubnet(_ *http.Request, args *GetSubnetArgs, response *GetS
 	if err != nil {
 		return err
 	}
+	if err := s.populateSubnetOwner(subnetOwner, response); err != nil {
+		return err
+	}
+
+	switch subnetTransformationTx, err := s.vm.state.GetSubnetTransformation(args.SubnetID); err {
+	case nil:
+		response.IsPermissioned = false
+		response.SubnetTransformationTxID = subnetTransformationTx.ID()
+	case database.ErrNotFound:
+		response.IsPermissioned = true
+		response.SubnetTransformationTxID = ids.Empty
+	default:
+		return err
+	}
+
+	return nil
+}
+
+// GetSubnetAtHeightArgs are the arguments to GetSubnetAtHeight
+type GetSubnetAtHeightArgs struct {
+	GetSubnetArgs
+	Height avajson.Uint64 `json:"height"`
+}
+
+// GetSubnetAtHeight returns the subnet as of the accepted block at the
+// provided height. Requires archival mode to be enabled.
+func (s *Service) GetSubnetAtHeight(_ *http.Request, args *GetSubnetAtHeightArgs, response *GetSubnetResponse) error {
+	s.vm.ctx.Log.Debug("API called",
+		zap.String("service", "platform"),
+		zap.String("method", "getSubnetAtHeight"),
+		zap.Stringer("subnetID", args.SubnetID),
+		zap.Uint64("height", uint64(args.Height)),
+	)
+
+	if args.SubnetID == constants.PrimaryNetworkID {
+		return errPrimaryNetworkIsNotASubnet
+	}
+
+	s.vm.ctx.Lock.Lock()
+	defer s.vm.ctx.Lock.Unlock()
+
+	historicalState, err := s.vm.state.GetHistoricalState(uint64(args.Height))
+	if err != nil {
+		return err
+	}
+
+	subnetOwner, err := historicalState.GetSubnetOwner(args.SubnetID)
+	if err != nil {
+		return err
+	}
+	if err := s.populateSubnetOwner(subnetOwner, response); err != nil {
+		return err
+	}
+
+	switch subnetTransformationTxID, err := historicalState.GetSubnetTransformationTxID(args.SubnetID); err {
+	case nil:
+		response.IsPermissioned = false
+		response.SubnetTransformationTxID = subnetTransformationTxID
+	case database.ErrNotFound:
+		response.IsPermissioned = true
+		response.SubnetTransformationTxID = ids.Empty
+	default:
+		return err
+	}
+
+	return nil
+}
+
+// populateSubnetOwner sets the control keys, threshold, and locktime of
+// [response] to those of [subnetOwner].
+func (s *Service) populateSubnetOwner(subnetOwner fx.Owner, response *GetSubnetResponse) error {
 	owner, ok := subnetOwner.(*secp256k1fx.OutputOwners)
 	if !ok {
 		return fmt.Errorf("expected *secp256k1fx.OutputOwners but got %T", subnetOwner)
@@ -469,18 +661,6 @@ func (s *Service) GetSubnet(_ *http.Request, args *GetSubnetArgs, response *GetS
 	response.ControlKeys = controlAddrs
 	response.Threshold = avajson.Uint32(owner.Threshold)
 	response.Locktime = avajson.Uint64(owner.Locktime)
-
-	switch subnetTransformationTx, err := s.vm.state.GetSubnetTransformation(args.SubnetID); err {
-	case nil:
-		response.IsPermissioned = false
-		response.SubnetTransformationTxID = subnetTransformationTx.ID()
-	case database.ErrNotFound:
-		response.IsPermissioned = true
-		response.SubnetTransformationTxID = ids.Empty
-	default:
-		return err
-	}
-
 	return nil
 }
 
@@ -793,15 +973,7 @@ func (s *Service) GetCurrentValidators(_ *http.Request, args *GetCurrentValidato
 
 	for _, currentStaker := range targetStakers {
 		nodeID := currentStaker.NodeID
-		weight := avajson.Uint64(currentStaker.Weight)
-		apiStaker := platformapi.Staker{
-			TxID:        currentStaker.TxID,
-			StartTime:   avajson.Uint64(currentStaker.StartTime.Unix()),
-			EndTime:     avajson.Uint64(currentStaker.EndTime.Unix()),
-			Weight:      weight,
-			StakeAmount: &weight,
-			NodeID:      nodeID,
-		}
+		apiStaker := newAPIStaker(currentStaker)
 		potentialReward := avajson.Uint64(currentStaker.PotentialReward)
 
 		delegateeReward, err := s.vm.state.GetDelegateeReward(currentStaker.SubnetID, currentStaker.NodeID)
@@ -932,6 +1104,164 @@ func (s *Service) GetCurrentValidators(_ *http.Request, args *GetCurrentValidato
 	return nil
 }
 
+// GetCurrentValidatorsAtHeightArgs are the arguments for calling
+// GetCurrentValidatorsAtHeight
+type GetCurrentValidatorsAtHeightArgs struct {
+	GetCurrentValidatorsArgs
+	Height avajson.Uint64 `json:"height"`
+}
+
+// GetCurrentValidatorsAtHeight returns the validators that were current as of
+// the accepted block at the provided height. Requires archival mode to be
+// enabled.
+//
+// Uptimes, connectivity, and accrued delegatee rewards are not archived, so
+// they are not reported.
+func (s *Service) GetCurrentValidatorsAtHeight(_ *http.Request, args *GetCurrentValidatorsAtHeightArgs, reply *GetCurrentValidatorsReply) error {
+	s.vm.ctx.Log.Debug("API called",
+		zap.String("service", "platform"),
+		zap.String("method", "getCurrentValidatorsAtHeight"),
+		zap.Uint64("height", uint64(args.Height)),
+	)
+
+	reply.Validators = []interface{}{}
+
+	// Create set of nodeIDs
+	nodeIDs := set.Of(args.NodeIDs...)
+
+	s.vm.ctx.Lock.Lock()
+	defer s.vm.ctx.Lock.Unlock()
+
+	historicalState, err := s.vm.state.GetHistoricalState(uint64(args.Height))
+	if err != nil {
+		return err
+	}
+
+	numNodeIDs := nodeIDs.Len()
+	var targetValidators []*state.HistoricalValidator
+	if numNodeIDs == 0 { // Include all nodes
+		targetValidators, err = historicalState.GetCurrentValidators(args.SubnetID)
+		if err != nil {
+			return err
+		}
+	} else {
+		for nodeID := range nodeIDs {
+			validator, err := historicalState.GetCurrentValidator(args.SubnetID, nodeID)
+			switch err {
+			case nil:
+			case database.ErrNotFound:
+				// nothing to do, continue
+				continue
+			default:
+				return err
+			}
+			targetValidators = append(targetValidators, validator)
+		}
+	}
+
+	for _, validator := range targetValidators {
+		currentStaker := validator.Validator
+		apiStaker := newAPIStaker(currentStaker)
+
+		switch currentStaker.Priority {
+		case txs.PrimaryNetworkValidatorCurrentPriority, txs.SubnetPermissionlessValidatorCurrentPriority:
+			attr, err := s.loadStakerTxAttributes(currentStaker.TxID)
+			if err != nil {
+				return err
+			}
+
+			var (
+				validationRewardOwner *platformapi.Owner
+				delegationRewardOwner *platformapi.Owner
+			)
+			validationOwner, ok := attr.validationRewardsOwner.(*secp256k1fx.OutputOwners)
+			if ok {
+				validationRewardOwner, err = s.getAPIOwner(validationOwner)
+				if err != nil {
+					return err
+				}
+			}
+			delegationOwner, ok := attr.delegationRewardsOwner.(*secp256k1fx.OutputOwners)
+			if ok {
+				delegationRewardOwner, err = s.getAPIOwner(delegationOwner)
+				if err != nil {
+					return err
+				}
+			}
+
+			delegators := make([]platformapi.PrimaryDelegator, len(validator.Delegators))
+			delegatorWeight := avajson.Uint64(0)
+			for i, delegator := range validator.Delegators {
+				var rewardOwner *platformapi.Owner
+				// If we are handling multiple nodeIDs, we don't return the
+				// delegator information.
+				if numNodeIDs == 1 {
+					attr, err := s.loadStakerTxAttributes(delegator.TxID)
+					if err != nil {
+						return err
+					}
+					owner, ok := attr.rewardsOwner.(*secp256k1fx.OutputOwners)
+					if ok {
+						rewardOwner, err = s.getAPIOwner(owner)
+						if err != nil {
+							return err
+						}
+					}
+				}
+
+				potentialReward := avajson.Uint64(delegator.PotentialReward)
+				delegators[i] = platformapi.PrimaryDelegator{
+					Staker:          newAPIStaker(delegator),
+					RewardOwner:     rewardOwner,
+					PotentialReward: &potentialReward,
+				}
+				delegatorWeight += avajson.Uint64(delegator.Weight)
+			}
+			delegatorCount := avajson.Uint64(len(delegators))
+
+			potentialReward := avajson.Uint64(currentStaker.PotentialReward)
+			vdr := platformapi.PermissionlessValidator{
+				Staker:                apiStaker,
+				PotentialReward:       &potentialReward,
+				RewardOwner:           validationRewardOwner,
+				ValidationRewardOwner: validationRewardOwner,
+				DelegationRewardOwner: delegationRewardOwner,
+				DelegationFee:         avajson.Float32(100 * float32(attr.shares) / float32(reward.PercentDenominator)),
+				Signer:                attr.proofOfPossession,
+				DelegatorCount:        &delegatorCount,
+				DelegatorWeight:       &delegatorWeight,
+			}
+			if numNodeIDs == 1 {
+				// queried a specific validator, load all of its delegators
+				vdr.Delegators = &delegators
+			}
+			reply.Validators = append(reply.Validators, vdr)
+
+		case txs.SubnetPermissionedValidatorCurrentPriority:
+			reply.Validators = append(reply.Validators, platformapi.PermissionedValidator{
+				Staker: apiStaker,
+			})
+
+		default:
+			return fmt.Errorf("unexpected staker priority %d", currentStaker.Priority)
+		}
+	}
+
+	return nil
+}
+
+func newAPIStaker(staker *state.Staker) platformapi.Staker {
+	weight := avajson.Uint64(staker.Weight)
+	return platformapi.Staker{
+		TxID:        staker.TxID,
+		StartTime:   avajson.Uint64(staker.StartTime.Unix()),
+		EndTime:     avajson.Uint64(staker.EndTime.Unix()),
+		Weight:      weight,
+		StakeAmount: &weight,
+		NodeID:      staker.NodeID,
+	}
+}
+
 // GetCurrentSupplyArgs are the arguments for calling GetCurrentSupply
 type GetCurrentSupplyArgs struct {
 	SubnetID ids.ID `json:"subnetID"`
@@ -1651,6 +1981,37 @@ func (s *Service) GetTimestamp(_ *http.Request, _ *struct{}, reply *GetTimestamp
 	return nil
 }
 
+// GetFeeRatesReply is the response from GetFeeRates
+type GetFeeRatesReply struct {
+	// True if the dynamic fees are charged at the current chain time
+	Active bool `json:"active"`
+	// Fee rates charged per unit of complexity, indexed by fee dimension
+	FeeRates [fees.FeeDimensions]avajson.Uint64 `json:"feeRates"`
+}
+
+// GetFeeRates returns the dynamic fee rates charged to the transactions of the
+// next block.
+func (s *Service) GetFeeRates(_ *http.Request, _ *struct{}, reply *GetFeeRatesReply) error {
+	s.vm.ctx.Log.Debug("API called",
+		zap.String("service", "platform"),
+		zap.String("method", "getFeeRates"),
+	)
+
+	s.vm.ctx.Lock.Lock()
+	defer s.vm.ctx.Lock.Unlock()
+
+	feeRates, err := s.vm.state.GetFeeRates()
+	if err != nil {
+		return fmt.Errorf("couldn't get fee rates: %w", err)
+	}
+
+	reply.Active = s.vm.IsEActivated(s.vm.state.GetTimestamp())
+	for i, feeRate := range feeRates {
+		reply.FeeRates[i] = avajson.Uint64(feeRate)
+	}
+	return nil
+}
+
 // GetValidatorsAtArgs is the response from GetValidatorsAt
 type GetValidatorsAtArgs struct {
 	Height   avajson.Uint64 `json:"height"`
@@ -1818,6 +2179,57 @@ func (s *Service) GetBlockByHeight(_ *http.Request, args *api.GetBlockByHeightAr
 	return err
 }
 
+type GetAddressTxsArgs struct {
+	api.JSONAddress
+	// Cursor is the number of txs to skip
+	Cursor avajson.Uint64 `json:"cursor"`
+	// PageSize is the maximum number of txs to return
+	PageSize avajson.Uint64 `json:"pageSize"`
+}
+
+type GetAddressTxsReply struct {
+	TxIDs []ids.ID `json:"txIDs"`
+	// Cursor to provide to fetch the next page
+	Cursor avajson.Uint64 `json:"cursor"`
+}
+
+// GetAddressTxs returns the IDs of the accepted transactions that touched the
+// provided address, in order of acceptance.
+func (s *Service) GetAddressTxs(_ *http.Request, args *GetAddressTxsArgs, reply *GetAddressTxsReply) error {
+	cursor := uint64(args.Cursor)
+	pageSize := uint64(args.PageSize)
+	s.vm.ctx.Log.Debug("API called",
+		zap.String("service", "platform"),
+		zap.String("method", "getAddressTxs"),
+		logging.UserString("address", args.Address),
+		zap.Uint64("cursor", cursor),
+		zap.Uint64("pageSize", pageSize),
+	)
+
+	if pageSize > maxGetAddressTxsPageSize {
+		return fmt.Errorf("%w (%d)", errPageSizeTooLarge, maxGetAddressTxsPageSize)
+	} else if pageSize == 0 {
+		pageSize = maxGetAddressTxsPageSize
+	}
+
+	address, err := avax.ParseServiceAddress(s.addrManager, args.Address)
+	if err != nil {
+		return fmt.Errorf("couldn't parse argument 'address' to address: %w", err)
+	}
+
+	s.vm.ctx.Lock.Lock()
+	defer s.vm.ctx.Lock.Unlock()
+
+	reply.TxIDs, err = s.vm.state.GetAddressTxs(address, cursor, int(pageSize))
+	if err != nil {
+		return fmt.Errorf("couldn't get txs of %s: %w", args.Address, err)
+	}
+
+	// To get the next page of tx IDs, the user should provide this cursor.
+	reply.Cursor = avajson.Uint64(cursor + uint64(len(reply.TxIDs)))
+	return nil
+}
+
 func (s *Service) getAPIUptime(staker *state.Staker) (*avajson.Float32, error) {
 	// Only report uptimes that we have been actively tracking.
 	if constants.PrimaryNetworkID != staker.SubnetID && !s.vm.TrackedSubnets.Contains(staker.SubnetID) {
@@ -657,6 +657,109 @@ func TestGetCurrentValidators(t *testing.T) {
 	}
 }
 
+func TestGetSubnetAtHeight(t *testing.T) {
+	var (
+		subnetID    = ids.GenerateTestID()
+		height      = uint64(1337)
+		owner       = &secp256k1fx.OutputOwners{Locktime: 1, Threshold: 2, Addrs: []ids.ShortID{}}
+		transformID = ids.GenerateTestID()
+	)
+	tests := []struct {
+		name             string
+		stateFunc        func(*gomock.Controller) state.State
+		expectedResponse *GetSubnetResponse
+		expectedErr      error
+	}{
+		{
+			name: "archival mode disabled",
+			stateFunc: func(ctrl *gomock.Controller) state.State {
+				s := state.NewMockState(ctrl)
+				s.EXPECT().GetHistoricalState(height).Return(nil, state.ErrArchivalModeDisabled)
+				return s
+			},
+			expectedErr: state.ErrArchivalModeDisabled,
+		},
+		{
+			name: "subnet not created yet",
+			stateFunc: func(ctrl *gomock.Controller) state.State {
+				historicalState := state.NewMockHistoricalState(ctrl)
+				historicalState.EXPECT().GetSubnetOwner(subnetID).Return(nil, database.ErrNotFound)
+
+				s := state.NewMockState(ctrl)
+				s.EXPECT().GetHistoricalState(height).Return(historicalState, nil)
+				return s
+			},
+			expectedErr: database.ErrNotFound,
+		},
+		{
+			name: "permissioned subnet",
+			stateFunc: func(ctrl *gomock.Controller) state.State {
+				historicalState := state.NewMockHistoricalState(ctrl)
+				historicalState.EXPECT().GetSubnetOwner(subnetID).Return(owner, nil)
+				historicalState.EXPECT().GetSubnetTransformationTxID(subnetID).Return(ids.Empty, database.ErrNotFound)
+
+				s := state.NewMockState(ctrl)
+				s.EXPECT().GetHistoricalState(height).Return(historicalState, nil)
+				return s
+			},
+			expectedResponse: &GetSubnetResponse{
+				IsPermissioned: true,
+				ControlKeys:    []string{},
+				Threshold:      2,
+				Locktime:       1,
+			},
+		},
+		{
+			name: "permissionless subnet",
+			stateFunc: func(ctrl *gomock.Controller) state.State {
+				historicalState := state.NewMockHistoricalState(ctrl)
+				historicalState.EXPECT().GetSubnetOwner(subnetID).Return(owner, nil)
+				historicalState.EXPECT().GetSubnetTransformationTxID(subnetID).Return(transformID, nil)
+
+				s := state.NewMockState(ctrl)
+				s.EXPECT().GetHistoricalState(height).Return(historicalState, nil)
+				return s
+			},
+			expectedResponse: &GetSubnetResponse{
+				IsPermissioned:           false,
+				ControlKeys:              []string{},
+				Threshold:                2,
+				Locktime:                 1,
+				SubnetTransformationTxID: transformID,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require := require.New(t)
+			ctrl := gomock.NewController(t)
+
+			service := &Service{
+				vm: &VM{
+					state: tt.stateFunc(ctrl),
+					ctx: &snow.Context{
+						Log: logging.NoLog{},
+					},
+				},
+			}
+
+			args := &GetSubnetAtHeightArgs{
+				GetSubnetArgs: GetSubnetArgs{
+					SubnetID: subnetID,
+				},
+				Height: avajson.Uint64(height),
+			}
+			reply := &GetSubnetResponse{}
+			err := service.GetSubnetAtHeight(nil, args, reply)
+			require.ErrorIs(err, tt.expectedErr)
+			if tt.expectedErr != nil {
+				return
+			}
+			require.Equal(tt.expectedResponse, reply)
+		})
+	}
+}
+
 func TestGetTimestamp(t *testing.T) {
 	require := require.New(t)
 	service, _ := defaultService(t)
@@ -981,3 +1084,80 @@ func TestServiceGetBlockByHeight(t *testing.T) {
 		})
 	}
 }
+
+func TestServiceGetAddressTxs(t *testing.T) {
+	var (
+		addr  = ids.GenerateTestShortID()
+		txIDs = []ids.ID{ids.GenerateTestID(), ids.GenerateTestID()}
+	)
+	tests := []struct {
+		name           string
+		args           *GetAddressTxsArgs
+		setup          func(*state.MockState)
+		expectedErr    error
+		expectedTxIDs  []ids.ID
+		expectedCursor uint64
+	}{
+		{
+			name: "page size too large",
+			args: &GetAddressTxsArgs{
+				JSONAddress: api.JSONAddress{Address: addr.String()},
+				PageSize:    maxGetAddressTxsPageSize + 1,
+			},
+			setup:       func(*state.MockState) {},
+			expectedErr: errPageSizeTooLarge,
+		},
+		{
+			name: "index disabled",
+			args: &GetAddressTxsArgs{
+				JSONAddress: api.JSONAddress{Address: addr.String()},
+			},
+			setup: func(s *state.MockState) {
+				s.EXPECT().GetAddressTxs(addr, uint64(0), maxGetAddressTxsPageSize).Return(nil, state.ErrAddressIndexDisabled)
+			},
+			expectedErr: state.ErrAddressIndexDisabled,
+		},
+		{
+			name: "paginated",
+			args: &GetAddressTxsArgs{
+				JSONAddress: api.JSONAddress{Address: addr.String()},
+				Cursor:      3,
+				PageSize:    2,
+			},
+			setup: func(s *state.MockState) {
+				s.EXPECT().GetAddressTxs(addr, uint64(3), 2).Return(txIDs, nil)
+			},
+			expectedTxIDs:  txIDs,
+			expectedCursor: 5,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require := require.New(t)
+			ctrl := gomock.NewController(t)
+
+			state := state.NewMockState(ctrl)
+			test.setup(state)
+
+			ctx := &snow.Context{
+				Log: logging.NoLog{},
+			}
+			service := &Service{
+				vm: &VM{
+					state: state,
+					ctx:   ctx,
+				},
+				addrManager: avax.NewAddressManager(ctx),
+			}
+
+			reply := &GetAddressTxsReply{}
+			err := service.GetAddressTxs(nil, test.args, reply)
+			require.ErrorIs(err, test.expectedErr)
+			if test.expectedErr != nil {
+				return
+			}
+			require.Equal(test.expectedTxIDs, reply.TxIDs)
+			require.Equal(test.expectedCursor, uint64(reply.Cursor))
+		})
+	}
+}
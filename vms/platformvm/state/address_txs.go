@@ -0,0 +1,305 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package state
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"slices"
+
+	"go.uber.org/zap"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/set"
+	"github.com/ava-labs/avalanchego/utils/wrappers"
+	"github.com/ava-labs/avalanchego/vms/components/avax"
+	"github.com/ava-labs/avalanchego/vms/platformvm/block"
+	"github.com/ava-labs/avalanchego/vms/platformvm/status"
+	"github.com/ava-labs/avalanchego/vms/platformvm/txs"
+)
+
+var (
+	ErrAddressIndexDisabled = errors.New("address index is disabled")
+
+	errAddressIndexNotInitialized = errors.New("address index must be enabled when the chain is first initialized")
+	errAddressIndexOutOfSync      = errors.New("address index is not at the last accepted height")
+)
+
+// The address index is stored as:
+//
+//	[address]          => number of txs indexed for [address]
+//	[address] + [index] => the [index]th tx that touched [address]
+//
+// A transaction touches an address if the address owns a UTXO the transaction
+// consumes, an output the transaction produces, or is specified as an owner by
+// the transaction. Reward transactions touch the addresses that the stake and
+// rewards of the staker are returned to. The transaction of a proposal block
+// is only indexed if the Commit option of the block was accepted.
+
+// GetAddressTxs returns the IDs of the transactions that touched [addr], in
+// order of acceptance, starting with the [cursor]th transaction. At most
+// [pageSize] IDs are returned.
+func (s *state) GetAddressTxs(addr ids.ShortID, cursor uint64, pageSize int) ([]ids.ID, error) {
+	if s.addressTxsDB == nil {
+		return nil, ErrAddressIndexDisabled
+	}
+
+	start := make([]byte, ids.ShortIDLen+wrappers.LongLen)
+	copy(start, addr[:])
+	copy(start[ids.ShortIDLen:], database.PackUInt64(cursor))
+
+	it := s.addressTxsDB.NewIteratorWithStartAndPrefix(start, addr[:])
+	defer it.Release()
+
+	var txIDs []ids.ID
+	for len(txIDs) < pageSize && it.Next() {
+		txID, err := ids.ToID(it.Value())
+		if err != nil {
+			return nil, err
+		}
+		txIDs = append(txIDs, txID)
+	}
+	return txIDs, it.Error()
+}
+
+// loadAddressTxs verifies that the address index contains every accepted
+// height.
+func (s *state) loadAddressTxs() error {
+	if s.addressTxsDB == nil {
+		return nil
+	}
+
+	indexedHeight, err := database.GetUInt64(s.singletonDB, AddressTxsHeightKey)
+	if err == database.ErrNotFound {
+		return errAddressIndexNotInitialized
+	}
+	if err != nil {
+		return err
+	}
+
+	lastAccepted, err := s.GetStatelessBlock(s.lastAccepted)
+	if err != nil {
+		return err
+	}
+	if lastAcceptedHeight := lastAccepted.Height(); indexedHeight != lastAcceptedHeight {
+		return fmt.Errorf("%w: indexed height %d but last accepted height %d",
+			errAddressIndexOutOfSync,
+			indexedHeight,
+			lastAcceptedHeight,
+		)
+	}
+	return nil
+}
+
+// writeAddressTxs indexes the transactions in the blocks that are about to be
+// written by the addresses they touched.
+//
+// Invariant: writeAddressTxs must be called before the added blocks and UTXOs
+// are written.
+func (s *state) writeAddressTxs(height uint64) error {
+	if s.addressTxsDB == nil {
+		return nil
+	}
+
+	blks := make([]block.Block, 0, len(s.addedBlocks))
+	for _, blk := range s.addedBlocks {
+		blks = append(blks, blk)
+	}
+	slices.SortFunc(blks, func(a, b block.Block) int {
+		return cmp.Compare(a.Height(), b.Height())
+	})
+
+	// UTXOs produced in these blocks may have been consumed by later
+	// transactions in these blocks, in which case they were never written.
+	producedUTXOs := make(map[ids.ID]*avax.UTXO)
+	for _, blk := range blks {
+		for _, tx := range blk.Txs() {
+			// The transaction of a proposal block is only indexed if the
+			// Commit option was accepted.
+			if added, ok := s.addedTxs[tx.ID()]; ok && added.status == status.Aborted {
+				continue
+			}
+
+			addrs, err := s.getTouchedAddresses(tx, producedUTXOs)
+			if err != nil {
+				return fmt.Errorf("failed to get addresses touched by %s: %w", tx.ID(), err)
+			}
+			if err := s.writeAddressTx(tx.ID(), addrs); err != nil {
+				return fmt.Errorf("failed to index %s: %w", tx.ID(), err)
+			}
+
+			for _, utxo := range tx.UTXOs() {
+				producedUTXOs[utxo.InputID()] = utxo
+			}
+		}
+	}
+	return database.PutUInt64(s.singletonDB, AddressTxsHeightKey, height)
+}
+
+func (s *state) writeAddressTx(txID ids.ID, addrs set.Set[ids.ShortID]) error {
+	for addr := range addrs {
+		numTxs, err := database.GetUInt64(s.addressTxsDB, addr[:])
+		if err != nil && err != database.ErrNotFound {
+			return err
+		}
+
+		key := make([]byte, ids.ShortIDLen+wrappers.LongLen)
+		copy(key, addr[:])
+		copy(key[ids.ShortIDLen:], database.PackUInt64(numTxs))
+		if err := s.addressTxsDB.Put(key, txID[:]); err != nil {
+			return err
+		}
+		if err := database.PutUInt64(s.addressTxsDB, addr[:], numTxs+1); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// getTouchedAddresses returns the addresses touched by [tx]. [producedUTXOs]
+// contains the UTXOs that were produced by previously indexed transactions
+// that have not yet been written.
+func (s *state) getTouchedAddresses(tx *txs.Tx, producedUTXOs map[ids.ID]*avax.UTXO) (set.Set[ids.ShortID], error) {
+	var (
+		addrs   = set.Set[ids.ShortID]{}
+		utxoIDs = tx.Unsigned.InputIDs()
+	)
+	if importTx, ok := tx.Unsigned.(*txs.ImportTx); ok {
+		importedUTXOs, err := s.getImportedUTXOs(importTx)
+		if err != nil {
+			return nil, err
+		}
+		for _, utxo := range importedUTXOs {
+			utxoIDs.Remove(utxo.InputID())
+			if err := addAddresses(addrs, utxo.Out); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	for utxoID := range utxoIDs {
+		utxo, ok := producedUTXOs[utxoID]
+		if !ok {
+			var err error
+			utxo, err = s.utxoState.GetUTXO(utxoID)
+			if err == database.ErrNotFound {
+				s.ctx.Log.Debug("dropping utxo from address index",
+					zap.Stringer("txID", tx.ID()),
+					zap.Stringer("utxoID", utxoID),
+				)
+				continue
+			}
+			if err != nil {
+				return nil, err
+			}
+		}
+		if err := addAddresses(addrs, utxo.Out); err != nil {
+			return nil, err
+		}
+	}
+
+	for _, out := range tx.Unsigned.Outputs() {
+		if err := addAddresses(addrs, out.Out); err != nil {
+			return nil, err
+		}
+	}
+
+	switch utx := tx.Unsigned.(type) {
+	case *txs.ExportTx:
+		for _, out := range utx.ExportedOutputs {
+			if err := addAddresses(addrs, out.Out); err != nil {
+				return nil, err
+			}
+		}
+	case *txs.CreateSubnetTx:
+		if err := addAddresses(addrs, utx.Owner); err != nil {
+			return nil, err
+		}
+	case *txs.TransferSubnetOwnershipTx:
+		if err := addAddresses(addrs, utx.Owner); err != nil {
+			return nil, err
+		}
+	case *txs.RewardValidatorTx:
+		stakerTx, _, err := s.GetTx(utx.TxID)
+		if err != nil {
+			return nil, err
+		}
+		if err := addStakerAddresses(addrs, stakerTx.Unsigned); err != nil {
+			return nil, err
+		}
+	default:
+		if err := addStakerAddresses(addrs, utx); err != nil {
+			return nil, err
+		}
+	}
+	return addrs, nil
+}
+
+func (s *state) getImportedUTXOs(tx *txs.ImportTx) ([]*avax.UTXO, error) {
+	utxoIDs := make([][]byte, len(tx.ImportedInputs))
+	for i, in := range tx.ImportedInputs {
+		utxoID := in.UTXOID.InputID()
+		utxoIDs[i] = utxoID[:]
+	}
+
+	utxosBytes, err := s.ctx.SharedMemory.Get(tx.SourceChain, utxoIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get shared memory: %w", err)
+	}
+
+	utxos := make([]*avax.UTXO, len(utxosBytes))
+	for i, utxoBytes := range utxosBytes {
+		utxo := &avax.UTXO{}
+		if _, err := txs.Codec.Unmarshal(utxoBytes, utxo); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal UTXO: %w", err)
+		}
+		utxos[i] = utxo
+	}
+	return utxos, nil
+}
+
+// addStakerAddresses adds the addresses that the stake and rewards of [utx]
+// are returned to, if [utx] is a staker.
+func addStakerAddresses(addrs set.Set[ids.ShortID], utx txs.UnsignedTx) error {
+	if staker, ok := utx.(txs.PermissionlessStaker); ok {
+		for _, out := range staker.Stake() {
+			if err := addAddresses(addrs, out.Out); err != nil {
+				return err
+			}
+		}
+	}
+
+	switch staker := utx.(type) {
+	case txs.ValidatorTx:
+		return addAddresses(
+			addrs,
+			staker.ValidationRewardsOwner(),
+			staker.DelegationRewardsOwner(),
+		)
+	case txs.DelegatorTx:
+		return addAddresses(addrs, staker.RewardsOwner())
+	default:
+		return nil
+	}
+}
+
+// addAddresses adds the addresses of each [owners] that is addressable.
+func addAddresses(addrs set.Set[ids.ShortID], owners ...interface{}) error {
+	for _, owner := range owners {
+		addressable, ok := owner.(avax.Addressable)
+		if !ok {
+			continue
+		}
+		for _, addrBytes := range addressable.Addresses() {
+			addr, err := ids.ToShortID(addrBytes)
+			if err != nil {
+				return err
+			}
+			addrs.Add(addr)
+		}
+	}
+	return nil
+}
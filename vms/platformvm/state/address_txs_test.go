@@ -0,0 +1,262 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package state
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/database/memdb"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/vms/components/avax"
+	"github.com/ava-labs/avalanchego/vms/platformvm/block"
+	"github.com/ava-labs/avalanchego/vms/platformvm/config"
+	"github.com/ava-labs/avalanchego/vms/platformvm/status"
+	"github.com/ava-labs/avalanchego/vms/platformvm/txs"
+	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
+)
+
+func newAddressIndexedStateFromDB(require *require.Assertions, db database.Database) *state {
+	execCfg, _ := config.GetExecutionConfig(nil)
+	execCfg.AddressIndexEnabled = true
+	return newStateFromDBWithConfig(require, db, execCfg)
+}
+
+func newTestOwner(addr ids.ShortID) *secp256k1fx.OutputOwners {
+	return &secp256k1fx.OutputOwners{
+		Threshold: 1,
+		Addrs:     []ids.ShortID{addr},
+	}
+}
+
+func newTestOutput(addr ids.ShortID, amount uint64) *avax.TransferableOutput {
+	return &avax.TransferableOutput{
+		Asset: avax.Asset{ID: initialTxID},
+		Out: &secp256k1fx.TransferOutput{
+			Amt:          amount,
+			OutputOwners: *newTestOwner(addr),
+		},
+	}
+}
+
+func newTestInput(utxo *avax.UTXO) *avax.TransferableInput {
+	return &avax.TransferableInput{
+		UTXOID: utxo.UTXOID,
+		Asset:  utxo.Asset,
+		In: &secp256k1fx.TransferInput{
+			Amt: utxo.Out.(*secp256k1fx.TransferOutput).Amt,
+			Input: secp256k1fx.Input{
+				SigIndices: []uint32{0},
+			},
+		},
+	}
+}
+
+// acceptTestBlockWithTxs commits [txs] as the block at [height].
+func acceptTestBlockWithTxs(require *require.Assertions, s *state, height uint64, txs ...*txs.Tx) {
+	blk, err := block.NewBanffStandardBlock(initialTime, s.GetLastAccepted(), height, txs)
+	require.NoError(err)
+
+	for _, tx := range txs {
+		s.AddTx(tx, status.Committed)
+	}
+	s.SetHeight(height)
+	s.SetLastAccepted(blk.ID())
+	s.AddStatelessBlock(blk)
+	require.NoError(s.Commit())
+}
+
+func TestGetAddressTxsIndexDisabled(t *testing.T) {
+	require := require.New(t)
+
+	s := newInitializedState(require)
+	_, err := s.GetAddressTxs(ids.GenerateTestShortID(), 0, 10)
+	require.ErrorIs(err, ErrAddressIndexDisabled)
+}
+
+func TestAddressTxs(t *testing.T) {
+	require := require.New(t)
+
+	s := newAddressIndexedStateFromDB(require, memdb.New())
+	initializeState(require, s)
+	require.NoError(s.Commit())
+
+	var (
+		senderAddr    = ids.GenerateTestShortID()
+		recipientAddr = ids.GenerateTestShortID()
+		subnetAddr    = ids.GenerateTestShortID()
+		stakerAddr    = ids.GenerateTestShortID()
+		rewardsAddr   = ids.GenerateTestShortID()
+		abortedAddr   = ids.GenerateTestShortID()
+		unrelatedAddr = ids.GenerateTestShortID()
+		utxo          = newTestUTXO(senderAddr, 10)
+	)
+
+	// Height 1: Fund the sender.
+	s.AddUTXO(utxo)
+	acceptTestBlock(require, s, 1)
+
+	// Height 2: Send funds to the recipient, which are then spent by the
+	// recipient in the same block to create a subnet.
+	transferTx := &txs.Tx{Unsigned: &txs.BaseTx{BaseTx: avax.BaseTx{
+		Ins:  []*avax.TransferableInput{newTestInput(utxo)},
+		Outs: []*avax.TransferableOutput{newTestOutput(recipientAddr, 10)},
+	}}}
+	require.NoError(transferTx.Initialize(txs.Codec))
+
+	recipientUTXO := transferTx.UTXOs()[0]
+	createSubnetTx := &txs.Tx{Unsigned: &txs.CreateSubnetTx{
+		BaseTx: txs.BaseTx{BaseTx: avax.BaseTx{
+			Ins: []*avax.TransferableInput{newTestInput(recipientUTXO)},
+		}},
+		Owner: newTestOwner(subnetAddr),
+	}}
+	require.NoError(createSubnetTx.Initialize(txs.Codec))
+
+	delegatorTx := &txs.Tx{Unsigned: &txs.AddDelegatorTx{
+		StakeOuts:              []*avax.TransferableOutput{newTestOutput(stakerAddr, 1)},
+		DelegationRewardsOwner: newTestOwner(rewardsAddr),
+	}}
+	require.NoError(delegatorTx.Initialize(txs.Codec))
+
+	s.DeleteUTXO(utxo.InputID())
+	acceptTestBlockWithTxs(require, s, 2, transferTx, createSubnetTx, delegatorTx)
+
+	// Height 3: Reward the delegator.
+	rewardTx := &txs.Tx{Unsigned: &txs.RewardValidatorTx{
+		TxID: delegatorTx.ID(),
+	}}
+	require.NoError(rewardTx.Initialize(txs.Codec))
+	acceptTestBlockWithTxs(require, s, 3, rewardTx)
+
+	// Height 4 and 5: Abort a proposal to add a delegator.
+	abortedTx := &txs.Tx{Unsigned: &txs.AddDelegatorTx{
+		StakeOuts:              []*avax.TransferableOutput{newTestOutput(abortedAddr, 1)},
+		DelegationRewardsOwner: newTestOwner(abortedAddr),
+	}}
+	require.NoError(abortedTx.Initialize(txs.Codec))
+	proposalBlk, err := block.NewBanffProposalBlock(initialTime, s.GetLastAccepted(), 4, abortedTx, nil)
+	require.NoError(err)
+	abortBlk, err := block.NewBanffAbortBlock(initialTime, proposalBlk.ID(), 5)
+	require.NoError(err)
+	s.AddTx(abortedTx, status.Aborted)
+	s.AddStatelessBlock(proposalBlk)
+	s.AddStatelessBlock(abortBlk)
+	s.SetHeight(5)
+	s.SetLastAccepted(abortBlk.ID())
+	require.NoError(s.Commit())
+
+	tests := []struct {
+		addr        ids.ShortID
+		expectedTxs []ids.ID
+	}{
+		{
+			addr:        senderAddr,
+			expectedTxs: []ids.ID{transferTx.ID()},
+		},
+		{
+			addr:        recipientAddr,
+			expectedTxs: []ids.ID{transferTx.ID(), createSubnetTx.ID()},
+		},
+		{
+			addr:        subnetAddr,
+			expectedTxs: []ids.ID{createSubnetTx.ID()},
+		},
+		{
+			addr:        stakerAddr,
+			expectedTxs: []ids.ID{delegatorTx.ID(), rewardTx.ID()},
+		},
+		{
+			addr:        rewardsAddr,
+			expectedTxs: []ids.ID{delegatorTx.ID(), rewardTx.ID()},
+		},
+		{
+			addr:        abortedAddr,
+			expectedTxs: nil,
+		},
+		{
+			addr:        unrelatedAddr,
+			expectedTxs: nil,
+		},
+	}
+	for _, test := range tests {
+		txIDs, err := s.GetAddressTxs(test.addr, 0, 10)
+		require.NoError(err)
+		require.Equal(test.expectedTxs, txIDs)
+
+		// Paginating should return the same txs.
+		var paginatedTxIDs []ids.ID
+		for cursor := uint64(0); ; cursor++ {
+			txIDs, err := s.GetAddressTxs(test.addr, cursor, 1)
+			require.NoError(err)
+			if len(txIDs) == 0 {
+				break
+			}
+			paginatedTxIDs = append(paginatedTxIDs, txIDs...)
+		}
+		require.Equal(test.expectedTxs, paginatedTxIDs)
+	}
+
+	// The index should be persisted.
+	s = newAddressIndexedStateFromDB(require, s.baseDB)
+	require.NoError(s.load())
+	txIDs, err := s.GetAddressTxs(recipientAddr, 1, 10)
+	require.NoError(err)
+	require.Equal([]ids.ID{createSubnetTx.ID()}, txIDs)
+}
+
+func TestLoadAddressTxs(t *testing.T) {
+	tests := []struct {
+		name        string
+		setup       func(*require.Assertions, database.Database)
+		expectedErr error
+	}{
+		{
+			name: "indexed from genesis",
+			setup: func(require *require.Assertions, db database.Database) {
+				s := newAddressIndexedStateFromDB(require, db)
+				initializeState(require, s)
+				require.NoError(s.Commit())
+				acceptTestBlock(require, s, 1)
+			},
+			expectedErr: nil,
+		},
+		{
+			name: "not indexed from genesis",
+			setup: func(require *require.Assertions, db database.Database) {
+				s := newStateFromDB(require, db)
+				initializeState(require, s)
+				require.NoError(s.Commit())
+			},
+			expectedErr: errAddressIndexNotInitialized,
+		},
+		{
+			name: "missing heights",
+			setup: func(require *require.Assertions, db database.Database) {
+				s := newAddressIndexedStateFromDB(require, db)
+				initializeState(require, s)
+				require.NoError(s.Commit())
+
+				s = newStateFromDB(require, db)
+				require.NoError(s.load())
+				acceptTestBlock(require, s, 1)
+			},
+			expectedErr: errAddressIndexOutOfSync,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require := require.New(t)
+
+			db := memdb.New()
+			test.setup(require, db)
+
+			s := newAddressIndexedStateFromDB(require, db)
+			err := s.load()
+			require.ErrorIs(err, test.expectedErr)
+		})
+	}
+}
@@ -0,0 +1,489 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package state
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/crypto/bls"
+	"github.com/ava-labs/avalanchego/utils/hashing"
+	"github.com/ava-labs/avalanchego/vms/components/avax"
+	"github.com/ava-labs/avalanchego/vms/platformvm/block"
+	"github.com/ava-labs/avalanchego/vms/platformvm/fx"
+	"github.com/ava-labs/avalanchego/vms/platformvm/txs"
+	"github.com/ava-labs/avalanchego/x/archivedb"
+)
+
+// Every archived key starts with one of the following prefixes.
+const (
+	archiveTimestampPrefix byte = iota
+	archiveUTXOPrefix
+	archiveAddressPrefix
+	archiveSubnetOwnerPrefix
+	archiveTransformedSubnetPrefix
+	archiveValidatorPrefix
+	archiveDelegatorPrefix
+)
+
+var (
+	_ HistoricalState = (*historicalState)(nil)
+
+	ErrArchivalModeDisabled = errors.New("archival mode is disabled")
+	ErrHeightNotArchived    = errors.New("height has not been archived")
+
+	errArchiveNotInitialized = errors.New("archival mode must be enabled when the chain is first initialized")
+	errArchiveOutOfSync      = errors.New("archive is not at the last accepted height")
+
+	archiveTimestampKey = []byte{archiveTimestampPrefix}
+)
+
+// HistoricalState is a read-only view of the chain state as of a previously
+// accepted height.
+//
+// Pending stakers aren't archived, so only the current stakers of a height can
+// be queried.
+type HistoricalState interface {
+	avax.UTXOReader
+
+	GetTimestamp() (time.Time, error)
+	GetSubnetOwner(subnetID ids.ID) (fx.Owner, error)
+	GetSubnetTransformationTxID(subnetID ids.ID) (ids.ID, error)
+
+	// GetCurrentValidator returns the validator of [subnetID] with [nodeID]
+	// along with its delegators.
+	GetCurrentValidator(subnetID ids.ID, nodeID ids.NodeID) (*HistoricalValidator, error)
+
+	// GetCurrentValidators returns all the validators of [subnetID] along with
+	// their delegators. The validators are sorted by nodeID.
+	GetCurrentValidators(subnetID ids.ID) ([]*HistoricalValidator, error)
+}
+
+// HistoricalValidator is a current validator and its current delegators as of
+// a previously accepted height.
+type HistoricalValidator struct {
+	Validator  *Staker
+	Delegators []*Staker
+}
+
+type archivedStaker struct {
+	TxID            ids.ID       `serialize:"true"`
+	NodeID          ids.NodeID   `serialize:"true"`
+	PublicKey       []byte       `serialize:"true"`
+	Weight          uint64       `serialize:"true"`
+	StartTime       uint64       `serialize:"true"`
+	EndTime         uint64       `serialize:"true"`
+	PotentialReward uint64       `serialize:"true"`
+	Priority        txs.Priority `serialize:"true"`
+}
+
+func newArchivedStaker(staker *Staker) archivedStaker {
+	var publicKey []byte
+	if staker.PublicKey != nil {
+		publicKey = bls.PublicKeyToCompressedBytes(staker.PublicKey)
+	}
+	return archivedStaker{
+		TxID:            staker.TxID,
+		NodeID:          staker.NodeID,
+		PublicKey:       publicKey,
+		Weight:          staker.Weight,
+		StartTime:       uint64(staker.StartTime.Unix()),
+		EndTime:         uint64(staker.EndTime.Unix()),
+		PotentialReward: staker.PotentialReward,
+		Priority:        staker.Priority,
+	}
+}
+
+// toStaker returns the current staker described by [s].
+func (s *archivedStaker) toStaker(subnetID ids.ID) (*Staker, error) {
+	var publicKey *bls.PublicKey
+	if len(s.PublicKey) > 0 {
+		var err error
+		publicKey, err = bls.PublicKeyFromCompressedBytes(s.PublicKey)
+		if err != nil {
+			return nil, err
+		}
+	}
+	endTime := time.Unix(int64(s.EndTime), 0)
+	return &Staker{
+		TxID:            s.TxID,
+		NodeID:          s.NodeID,
+		PublicKey:       publicKey,
+		SubnetID:        subnetID,
+		Weight:          s.Weight,
+		StartTime:       time.Unix(int64(s.StartTime), 0),
+		EndTime:         endTime,
+		PotentialReward: s.PotentialReward,
+		NextTime:        endTime,
+		Priority:        s.Priority,
+	}, nil
+}
+
+func prefixedKey(prefix byte, parts ...[]byte) []byte {
+	size := 1
+	for _, part := range parts {
+		size += len(part)
+	}
+	key := make([]byte, 1, size)
+	key[0] = prefix
+	for _, part := range parts {
+		key = append(key, part...)
+	}
+	return key
+}
+
+func (s *state) GetHistoricalState(height uint64) (HistoricalState, error) {
+	if s.archiveDB == nil {
+		return nil, ErrArchivalModeDisabled
+	}
+
+	archivedHeight, err := s.archiveDB.Height()
+	if err != nil {
+		return nil, err
+	}
+	if height > archivedHeight {
+		return nil, fmt.Errorf("%w: requested height %d is after the last accepted height %d",
+			ErrHeightNotArchived,
+			height,
+			archivedHeight,
+		)
+	}
+	return &historicalState{
+		reader: s.archiveDB.Open(height),
+	}, nil
+}
+
+// loadArchive verifies that the archive contains every accepted height.
+func (s *state) loadArchive() error {
+	if s.archiveDB == nil {
+		return nil
+	}
+
+	archivedHeight, err := s.archiveDB.Height()
+	if err == database.ErrNotFound {
+		return errArchiveNotInitialized
+	}
+	if err != nil {
+		return err
+	}
+
+	lastAccepted, err := s.GetStatelessBlock(s.lastAccepted)
+	if err != nil {
+		return err
+	}
+	if lastAcceptedHeight := lastAccepted.Height(); archivedHeight != lastAcceptedHeight {
+		return fmt.Errorf("%w: archived height %d but last accepted height %d",
+			errArchiveOutOfSync,
+			archivedHeight,
+			lastAcceptedHeight,
+		)
+	}
+	return nil
+}
+
+// writeArchive records the modifications that are about to be written as the
+// state at [height].
+//
+// Invariant: writeArchive must be called before the modifications are written
+// to disk.
+func (s *state) writeArchive(height uint64) error {
+	if s.archiveDB == nil {
+		return nil
+	}
+
+	archivedHeight, err := s.archiveDB.Height()
+	switch {
+	case err == database.ErrNotFound:
+		// Nothing has been archived yet, so this is the genesis state.
+	case err != nil:
+		return err
+	case height <= archivedHeight:
+		// No block has been accepted since the last write. This happens when
+		// only uptimes are being persisted.
+		return nil
+	}
+
+	batch := s.archiveDB.NewBatch(height)
+	if !s.timestamp.Equal(s.persistedTimestamp) {
+		if err := database.PutTimestamp(batch, archiveTimestampKey, s.timestamp); err != nil {
+			return fmt.Errorf("failed to archive timestamp: %w", err)
+		}
+	}
+
+	if err := s.writeArchivedUTXOs(batch); err != nil {
+		return err
+	}
+	if err := s.writeArchivedValidators(batch); err != nil {
+		return err
+	}
+	if err := s.writeArchivedSubnets(batch); err != nil {
+		return err
+	}
+	return batch.Write()
+}
+
+func (s *state) writeArchivedUTXOs(batch database.KeyValueWriterDeleter) error {
+	for utxoID, utxo := range s.modifiedUTXOs {
+		key := prefixedKey(archiveUTXOPrefix, utxoID[:])
+		if utxo == nil {
+			deletedUTXO, err := s.utxoState.GetUTXO(utxoID)
+			if err == database.ErrNotFound {
+				// The UTXO was never written, so it was never archived.
+				continue
+			}
+			if err != nil {
+				return fmt.Errorf("failed to get deleted UTXO: %w", err)
+			}
+			if err := batch.Delete(key); err != nil {
+				return fmt.Errorf("failed to archive deleted UTXO: %w", err)
+			}
+			for _, addr := range utxoAddresses(deletedUTXO) {
+				if err := batch.Delete(archivedAddressUTXOKey(addr, utxoID)); err != nil {
+					return fmt.Errorf("failed to archive address UTXO: %w", err)
+				}
+			}
+			continue
+		}
+
+		utxoBytes, err := txs.GenesisCodec.Marshal(txs.CodecVersion, utxo)
+		if err != nil {
+			return fmt.Errorf("failed to marshal UTXO: %w", err)
+		}
+		if err := batch.Put(key, utxoBytes); err != nil {
+			return fmt.Errorf("failed to archive UTXO: %w", err)
+		}
+		for _, addr := range utxoAddresses(utxo) {
+			if err := batch.Put(archivedAddressUTXOKey(addr, utxoID), nil); err != nil {
+				return fmt.Errorf("failed to archive address UTXO: %w", err)
+			}
+		}
+	}
+	return nil
+}
+
+func utxoAddresses(utxo *avax.UTXO) [][]byte {
+	addressable, ok := utxo.Out.(avax.Addressable)
+	if !ok {
+		return nil
+	}
+	return addressable.Addresses()
+}
+
+// archivedAddressPrefix returns the prefix of the keys that index the UTXOs of
+// [addr]. Addresses are hashed so that the address of one key can't be a
+// prefix of the address of another.
+func archivedAddressPrefix(addr []byte) []byte {
+	return prefixedKey(archiveAddressPrefix, hashing.ComputeHash256(addr))
+}
+
+// archivedAddressUTXOKey returns the key that indexes [utxoID] under [addr].
+// Each UTXO is indexed by its own key so that a height only records the UTXOs
+// that were added or removed at that height.
+func archivedAddressUTXOKey(addr []byte, utxoID ids.ID) []byte {
+	return prefixedKey(archiveAddressPrefix, hashing.ComputeHash256(addr), utxoID[:])
+}
+
+// archivedValidatorKey returns the key of the validator of [subnetID] with
+// [nodeID].
+func archivedValidatorKey(subnetID ids.ID, nodeID ids.NodeID) []byte {
+	return prefixedKey(archiveValidatorPrefix, subnetID[:], nodeID.Bytes())
+}
+
+// archivedDelegatorKey returns the key of the delegator added by [txID] to the
+// validator of [subnetID] with [nodeID].
+func archivedDelegatorKey(subnetID ids.ID, nodeID ids.NodeID, txID ids.ID) []byte {
+	return prefixedKey(archiveDelegatorPrefix, subnetID[:], nodeID.Bytes(), txID[:])
+}
+
+// writeArchivedValidators records the current stakers that were added or
+// removed. Each staker is archived under its own key so that a height only
+// records the stakers that changed at that height.
+func (s *state) writeArchivedValidators(batch database.KeyValueWriterDeleter) error {
+	for subnetID, validatorDiffs := range s.currentStakers.validatorDiffs {
+		for nodeID, validatorDiff := range validatorDiffs {
+			key := archivedValidatorKey(subnetID, nodeID)
+			switch validatorDiff.validatorStatus {
+			case added:
+				if err := putArchivedStaker(batch, key, validatorDiff.validator); err != nil {
+					return fmt.Errorf("failed to archive validator: %w", err)
+				}
+			case deleted:
+				if err := batch.Delete(key); err != nil {
+					return fmt.Errorf("failed to archive removed validator: %w", err)
+				}
+			}
+
+			var err error
+			if validatorDiff.addedDelegators != nil {
+				validatorDiff.addedDelegators.Ascend(func(delegator *Staker) bool {
+					key := archivedDelegatorKey(subnetID, nodeID, delegator.TxID)
+					err = putArchivedStaker(batch, key, delegator)
+					return err == nil
+				})
+			}
+			if err != nil {
+				return fmt.Errorf("failed to archive delegator: %w", err)
+			}
+			for txID := range validatorDiff.deletedDelegators {
+				if err := batch.Delete(archivedDelegatorKey(subnetID, nodeID, txID)); err != nil {
+					return fmt.Errorf("failed to archive removed delegator: %w", err)
+				}
+			}
+		}
+	}
+	return nil
+}
+
+func putArchivedStaker(batch database.KeyValueWriter, key []byte, staker *Staker) error {
+	archived := newArchivedStaker(staker)
+	stakerBytes, err := block.GenesisCodec.Marshal(block.CodecVersion, &archived)
+	if err != nil {
+		return err
+	}
+	return batch.Put(key, stakerBytes)
+}
+
+func (s *state) writeArchivedSubnets(batch database.KeyValueWriterDeleter) error {
+	for subnetID, owner := range s.subnetOwners {
+		owner := owner
+		ownerBytes, err := block.GenesisCodec.Marshal(block.CodecVersion, &owner)
+		if err != nil {
+			return fmt.Errorf("failed to marshal subnet owner: %w", err)
+		}
+		key := prefixedKey(archiveSubnetOwnerPrefix, subnetID[:])
+		if err := batch.Put(key, ownerBytes); err != nil {
+			return fmt.Errorf("failed to archive subnet owner: %w", err)
+		}
+	}
+	for subnetID, tx := range s.transformedSubnets {
+		key := prefixedKey(archiveTransformedSubnetPrefix, subnetID[:])
+		if err := database.PutID(batch, key, tx.ID()); err != nil {
+			return fmt.Errorf("failed to archive transformed subnet: %w", err)
+		}
+	}
+	return nil
+}
+
+type historicalState struct {
+	reader *archivedb.Reader
+}
+
+func (h *historicalState) GetTimestamp() (time.Time, error) {
+	return database.GetTimestamp(h.reader, archiveTimestampKey)
+}
+
+func (h *historicalState) GetUTXO(utxoID ids.ID) (*avax.UTXO, error) {
+	utxoBytes, err := h.reader.Get(prefixedKey(archiveUTXOPrefix, utxoID[:]))
+	if err != nil {
+		return nil, err
+	}
+
+	utxo := &avax.UTXO{}
+	if _, err := txs.GenesisCodec.Unmarshal(utxoBytes, utxo); err != nil {
+		return nil, err
+	}
+	return utxo, nil
+}
+
+func (h *historicalState) UTXOIDs(addr []byte, start ids.ID, limit int) ([]ids.ID, error) {
+	prefix := archivedAddressPrefix(addr)
+	iter := h.reader.NewIteratorWithStartAndPrefix(archivedAddressUTXOKey(addr, start), prefix)
+	defer iter.Release()
+
+	utxoIDs := []ids.ID(nil)
+	for len(utxoIDs) < limit && iter.Next() {
+		utxoID, err := ids.ToID(iter.Key()[len(prefix):])
+		if err != nil {
+			return nil, err
+		}
+		if utxoID == start {
+			continue
+		}
+
+		start = ids.Empty
+		utxoIDs = append(utxoIDs, utxoID)
+	}
+	return utxoIDs, iter.Error()
+}
+
+func (h *historicalState) GetSubnetOwner(subnetID ids.ID) (fx.Owner, error) {
+	ownerBytes, err := h.reader.Get(prefixedKey(archiveSubnetOwnerPrefix, subnetID[:]))
+	if err != nil {
+		return nil, err
+	}
+
+	var owner fx.Owner
+	if _, err := block.GenesisCodec.Unmarshal(ownerBytes, &owner); err != nil {
+		return nil, err
+	}
+	return owner, nil
+}
+
+func (h *historicalState) GetSubnetTransformationTxID(subnetID ids.ID) (ids.ID, error) {
+	return database.GetID(h.reader, prefixedKey(archiveTransformedSubnetPrefix, subnetID[:]))
+}
+
+func (h *historicalState) GetCurrentValidator(subnetID ids.ID, nodeID ids.NodeID) (*HistoricalValidator, error) {
+	validatorBytes, err := h.reader.Get(archivedValidatorKey(subnetID, nodeID))
+	if err != nil {
+		return nil, err
+	}
+	return h.getValidator(subnetID, nodeID, validatorBytes)
+}
+
+func (h *historicalState) GetCurrentValidators(subnetID ids.ID) ([]*HistoricalValidator, error) {
+	prefix := prefixedKey(archiveValidatorPrefix, subnetID[:])
+	iter := h.reader.NewIteratorWithPrefix(prefix)
+	defer iter.Release()
+
+	var validators []*HistoricalValidator
+	for iter.Next() {
+		nodeID, err := ids.ToNodeID(iter.Key()[len(prefix):])
+		if err != nil {
+			return nil, err
+		}
+		validator, err := h.getValidator(subnetID, nodeID, iter.Value())
+		if err != nil {
+			return nil, fmt.Errorf("failed to get validator %s: %w", nodeID, err)
+		}
+		validators = append(validators, validator)
+	}
+	return validators, iter.Error()
+}
+
+// getValidator returns the validator of [subnetID] with [nodeID], archived as
+// [validatorBytes], along with its delegators.
+func (h *historicalState) getValidator(subnetID ids.ID, nodeID ids.NodeID, validatorBytes []byte) (*HistoricalValidator, error) {
+	validator, err := parseArchivedStaker(subnetID, validatorBytes)
+	if err != nil {
+		return nil, err
+	}
+
+	iter := h.reader.NewIteratorWithPrefix(prefixedKey(archiveDelegatorPrefix, subnetID[:], nodeID.Bytes()))
+	defer iter.Release()
+
+	delegators := []*Staker{}
+	for iter.Next() {
+		delegator, err := parseArchivedStaker(subnetID, iter.Value())
+		if err != nil {
+			return nil, err
+		}
+		delegators = append(delegators, delegator)
+	}
+	return &HistoricalValidator{
+		Validator:  validator,
+		Delegators: delegators,
+	}, iter.Error()
+}
+
+func parseArchivedStaker(subnetID ids.ID, stakerBytes []byte) (*Staker, error) {
+	archived := archivedStaker{}
+	if _, err := block.GenesisCodec.Unmarshal(stakerBytes, &archived); err != nil {
+		return nil, err
+	}
+	return archived.toStaker(subnetID)
+}
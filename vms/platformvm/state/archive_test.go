@@ -0,0 +1,393 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package state
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/database/memdb"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils"
+	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/ava-labs/avalanchego/utils/crypto/bls"
+	"github.com/ava-labs/avalanchego/vms/components/avax"
+	"github.com/ava-labs/avalanchego/vms/platformvm/block"
+	"github.com/ava-labs/avalanchego/vms/platformvm/config"
+	"github.com/ava-labs/avalanchego/vms/platformvm/txs"
+	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
+)
+
+func newArchivalStateFromDB(require *require.Assertions, db database.Database) *state {
+	execCfg, _ := config.GetExecutionConfig(nil)
+	execCfg.ArchivalModeEnabled = true
+	return newStateFromDBWithConfig(require, db, execCfg)
+}
+
+func newTestUTXO(addr ids.ShortID, amount uint64) *avax.UTXO {
+	return &avax.UTXO{
+		UTXOID: avax.UTXOID{
+			TxID: ids.GenerateTestID(),
+		},
+		Asset: avax.Asset{ID: initialTxID},
+		Out: &secp256k1fx.TransferOutput{
+			Amt: amount,
+			OutputOwners: secp256k1fx.OutputOwners{
+				Threshold: 1,
+				Addrs:     []ids.ShortID{addr},
+			},
+		},
+	}
+}
+
+// acceptTestBlock commits the pending modifications of [s] as the block at
+// [height].
+func acceptTestBlock(require *require.Assertions, s *state, height uint64) {
+	blk, err := block.NewApricotCommitBlock(s.GetLastAccepted(), height)
+	require.NoError(err)
+
+	s.SetHeight(height)
+	s.SetLastAccepted(blk.ID())
+	s.AddStatelessBlock(blk)
+	require.NoError(s.Commit())
+}
+
+func TestGetHistoricalStateArchivalModeDisabled(t *testing.T) {
+	require := require.New(t)
+
+	s := newInitializedState(require)
+	_, err := s.GetHistoricalState(0)
+	require.ErrorIs(err, ErrArchivalModeDisabled)
+}
+
+func TestHistoricalState(t *testing.T) {
+	require := require.New(t)
+
+	s := newArchivalStateFromDB(require, memdb.New())
+	initializeState(require, s)
+	require.NoError(s.Commit())
+
+	var (
+		addr          = ids.GenerateTestShortID()
+		firstUTXO     = newTestUTXO(addr, 1)
+		secondUTXO    = newTestUTXO(addr, 2)
+		subnetID      = ids.GenerateTestID()
+		firstOwner    = &secp256k1fx.OutputOwners{Threshold: 1, Addrs: []ids.ShortID{addr}}
+		secondOwner   = &secp256k1fx.OutputOwners{Addrs: []ids.ShortID{}}
+		transformTx   = &txs.Tx{Unsigned: &txs.TransformSubnetTx{Subnet: subnetID}}
+		nodeID        = ids.GenerateTestNodeID()
+		height1Time   = initialTime.Add(time.Second)
+		height2Time   = height1Time.Add(time.Second)
+		validatorEnd  = initialValidatorEndTime
+		validatorTxID = ids.GenerateTestID()
+	)
+	transformTx.SetBytes(nil, utils.RandomBytes(32))
+
+	sk, err := bls.NewSecretKey()
+	require.NoError(err)
+	validator := &Staker{
+		TxID:            validatorTxID,
+		NodeID:          nodeID,
+		PublicKey:       bls.PublicFromSecretKey(sk),
+		SubnetID:        constants.PrimaryNetworkID,
+		Weight:          10,
+		StartTime:       height1Time,
+		EndTime:         validatorEnd,
+		PotentialReward: 5,
+		NextTime:        validatorEnd,
+		Priority:        txs.PrimaryNetworkValidatorCurrentPriority,
+	}
+	delegator := &Staker{
+		TxID:            ids.GenerateTestID(),
+		NodeID:          nodeID,
+		SubnetID:        constants.PrimaryNetworkID,
+		Weight:          3,
+		StartTime:       height2Time,
+		EndTime:         validatorEnd,
+		PotentialReward: 1,
+		NextTime:        validatorEnd,
+		Priority:        txs.PrimaryNetworkDelegatorCurrentPriority,
+	}
+
+	// Height 1: Add a UTXO, a subnet, and a validator.
+	s.SetTimestamp(height1Time)
+	s.AddUTXO(firstUTXO)
+	s.SetSubnetOwner(subnetID, firstOwner)
+	s.PutCurrentValidator(validator)
+	acceptTestBlock(require, s, 1)
+
+	// Uptime only commits shouldn't modify the archive.
+	require.NoError(s.Commit())
+
+	// Height 2: Replace the UTXO, transfer and transform the subnet, and add a
+	// delegator.
+	s.SetTimestamp(height2Time)
+	s.DeleteUTXO(firstUTXO.InputID())
+	s.AddUTXO(secondUTXO)
+	s.SetSubnetOwner(subnetID, secondOwner)
+	s.AddSubnetTransformation(transformTx)
+	s.PutCurrentDelegator(delegator)
+	acceptTestBlock(require, s, 2)
+
+	// Height 3: Remove the validator and the delegator.
+	s.DeleteCurrentDelegator(delegator)
+	s.DeleteCurrentValidator(validator)
+	s.DeleteUTXO(secondUTXO.InputID())
+	acceptTestBlock(require, s, 3)
+
+	type expectedState struct {
+		timestamp         time.Time
+		utxoIDs           []ids.ID
+		owner             *secp256k1fx.OutputOwners
+		transformationID  ids.ID
+		validator         *Staker
+		expectedDelegator []*Staker
+	}
+	tests := []struct {
+		height   uint64
+		expected expectedState
+	}{
+		{
+			height: 0,
+			expected: expectedState{
+				timestamp: initialTime,
+			},
+		},
+		{
+			height: 1,
+			expected: expectedState{
+				timestamp: height1Time,
+				utxoIDs:   []ids.ID{firstUTXO.InputID()},
+				owner:     firstOwner,
+				validator: validator,
+			},
+		},
+		{
+			height: 2,
+			expected: expectedState{
+				timestamp:         height2Time,
+				utxoIDs:           []ids.ID{secondUTXO.InputID()},
+				owner:             secondOwner,
+				transformationID:  transformTx.ID(),
+				validator:         validator,
+				expectedDelegator: []*Staker{delegator},
+			},
+		},
+		{
+			height: 3,
+			expected: expectedState{
+				timestamp:        height2Time,
+				owner:            secondOwner,
+				transformationID: transformTx.ID(),
+			},
+		},
+	}
+	for _, test := range tests {
+		historicalState, err := s.GetHistoricalState(test.height)
+		require.NoError(err)
+
+		timestamp, err := historicalState.GetTimestamp()
+		require.NoError(err)
+		require.Equal(test.expected.timestamp.Unix(), timestamp.Unix())
+
+		utxoIDs, err := historicalState.UTXOIDs(addr.Bytes(), ids.Empty, 10)
+		require.NoError(err)
+		require.Equal(test.expected.utxoIDs, utxoIDs)
+		for _, utxoID := range utxoIDs {
+			_, err := historicalState.GetUTXO(utxoID)
+			require.NoError(err)
+		}
+
+		owner, err := historicalState.GetSubnetOwner(subnetID)
+		if test.expected.owner == nil {
+			require.ErrorIs(err, database.ErrNotFound)
+		} else {
+			require.NoError(err)
+			require.Equal(test.expected.owner, owner)
+		}
+
+		transformationID, err := historicalState.GetSubnetTransformationTxID(subnetID)
+		if test.expected.transformationID == ids.Empty {
+			require.ErrorIs(err, database.ErrNotFound)
+		} else {
+			require.NoError(err)
+			require.Equal(test.expected.transformationID, transformationID)
+		}
+
+		validators, err := historicalState.GetCurrentValidators(constants.PrimaryNetworkID)
+		require.NoError(err)
+
+		historicalValidator, err := historicalState.GetCurrentValidator(constants.PrimaryNetworkID, nodeID)
+		if test.expected.validator == nil {
+			require.ErrorIs(err, database.ErrNotFound)
+			// Only the genesis validator remains.
+			require.Len(validators, 1)
+			continue
+		}
+		require.NoError(err)
+		require.Len(validators, 2)
+		require.Contains(validators, historicalValidator)
+
+		require.Equal(test.expected.validator.TxID, historicalValidator.Validator.TxID)
+		require.Equal(test.expected.validator.PublicKey, historicalValidator.Validator.PublicKey)
+		require.Equal(test.expected.validator.Weight, historicalValidator.Validator.Weight)
+		require.Equal(test.expected.validator.EndTime.Unix(), historicalValidator.Validator.EndTime.Unix())
+		require.Equal(test.expected.validator.PotentialReward, historicalValidator.Validator.PotentialReward)
+		require.Equal(test.expected.validator.Priority, historicalValidator.Validator.Priority)
+		require.Len(historicalValidator.Delegators, len(test.expected.expectedDelegator))
+		for i, expectedDelegator := range test.expected.expectedDelegator {
+			require.Equal(expectedDelegator.TxID, historicalValidator.Delegators[i].TxID)
+			require.Equal(expectedDelegator.Weight, historicalValidator.Delegators[i].Weight)
+		}
+	}
+
+	// Adding the delegator at height 2 didn't rewrite the validator.
+	_, modifiedHeight, exists, err := s.archiveDB.Open(2).GetEntry(archivedValidatorKey(constants.PrimaryNetworkID, nodeID))
+	require.NoError(err)
+	require.True(exists)
+	require.Equal(uint64(1), modifiedHeight)
+
+	_, err = s.GetHistoricalState(4)
+	require.ErrorIs(err, ErrHeightNotArchived)
+}
+
+func TestHistoricalStateUTXOIDsPagination(t *testing.T) {
+	require := require.New(t)
+
+	s := newArchivalStateFromDB(require, memdb.New())
+	initializeState(require, s)
+	require.NoError(s.Commit())
+
+	addr := ids.GenerateTestShortID()
+	for i := 0; i < 5; i++ {
+		s.AddUTXO(newTestUTXO(addr, 1))
+	}
+	acceptTestBlock(require, s, 1)
+
+	historicalState, err := s.GetHistoricalState(1)
+	require.NoError(err)
+
+	allUTXOIDs, err := historicalState.UTXOIDs(addr.Bytes(), ids.Empty, 10)
+	require.NoError(err)
+	require.Len(allUTXOIDs, 5)
+
+	var (
+		paginatedUTXOIDs []ids.ID
+		previous         = ids.Empty
+	)
+	for {
+		utxoIDs, err := historicalState.UTXOIDs(addr.Bytes(), previous, 2)
+		require.NoError(err)
+		if len(utxoIDs) == 0 {
+			break
+		}
+		paginatedUTXOIDs = append(paginatedUTXOIDs, utxoIDs...)
+		previous = utxoIDs[len(utxoIDs)-1]
+	}
+	require.Equal(allUTXOIDs, paginatedUTXOIDs)
+}
+
+func TestHistoricalStateArchivesOnlyModifiedAddressUTXOs(t *testing.T) {
+	require := require.New(t)
+
+	s := newArchivalStateFromDB(require, memdb.New())
+	initializeState(require, s)
+	require.NoError(s.Commit())
+
+	addr := ids.GenerateTestShortID()
+	for i := 0; i < 5; i++ {
+		s.AddUTXO(newTestUTXO(addr, 1))
+	}
+	acceptTestBlock(require, s, 1)
+
+	removedUTXO := newTestUTXO(addr, 2)
+	s.AddUTXO(removedUTXO)
+	acceptTestBlock(require, s, 2)
+
+	s.DeleteUTXO(removedUTXO.InputID())
+	acceptTestBlock(require, s, 3)
+
+	// Each height only records the address entries of the UTXOs that were
+	// added or removed at that height.
+	for height := uint64(2); height <= 3; height++ {
+		iter := s.archiveDB.NewDiffIterator(height-1, height, nil, archivedAddressPrefix(addr.Bytes()))
+		var numModified int
+		for iter.Next() {
+			numModified++
+		}
+		require.NoError(iter.Error())
+		iter.Release()
+		require.Equal(1, numModified)
+	}
+
+	historicalState, err := s.GetHistoricalState(2)
+	require.NoError(err)
+	utxoIDs, err := historicalState.UTXOIDs(addr.Bytes(), ids.Empty, 10)
+	require.NoError(err)
+	require.Len(utxoIDs, 6)
+	require.Contains(utxoIDs, removedUTXO.InputID())
+
+	historicalState, err = s.GetHistoricalState(3)
+	require.NoError(err)
+	utxoIDs, err = historicalState.UTXOIDs(addr.Bytes(), ids.Empty, 10)
+	require.NoError(err)
+	require.Len(utxoIDs, 5)
+	require.NotContains(utxoIDs, removedUTXO.InputID())
+}
+
+func TestLoadArchive(t *testing.T) {
+	tests := []struct {
+		name        string
+		setup       func(*require.Assertions, database.Database)
+		expectedErr error
+	}{
+		{
+			name: "archived from genesis",
+			setup: func(require *require.Assertions, db database.Database) {
+				s := newArchivalStateFromDB(require, db)
+				initializeState(require, s)
+				require.NoError(s.Commit())
+				acceptTestBlock(require, s, 1)
+			},
+			expectedErr: nil,
+		},
+		{
+			name: "not archived from genesis",
+			setup: func(require *require.Assertions, db database.Database) {
+				s := newStateFromDB(require, db)
+				initializeState(require, s)
+				require.NoError(s.Commit())
+			},
+			expectedErr: errArchiveNotInitialized,
+		},
+		{
+			name: "missing heights",
+			setup: func(require *require.Assertions, db database.Database) {
+				s := newArchivalStateFromDB(require, db)
+				initializeState(require, s)
+				require.NoError(s.Commit())
+
+				s = newStateFromDB(require, db)
+				require.NoError(s.load())
+				acceptTestBlock(require, s, 1)
+			},
+			expectedErr: errArchiveOutOfSync,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require := require.New(t)
+
+			db := memdb.New()
+			test.setup(require, db)
+
+			s := newArchivalStateFromDB(require, db)
+			err := s.load()
+			require.ErrorIs(err, test.expectedErr)
+		})
+	}
+}
@@ -1,9 +1,9 @@
 // Code generated by MockGen. DO NOT EDIT.
-// Source: github.com/ava-labs/avalanchego/vms/platformvm/state (interfaces: Chain,Diff,State,Versions)
+// Source: github.com/ava-labs/avalanchego/vms/platformvm/state (interfaces: Chain,Diff,HistoricalState,State,Versions)
 //
 // Generated by this command:
 //
-//	mockgen -package=state -destination=vms/platformvm/state/mock_state.go github.com/ava-labs/avalanchego/vms/platformvm/state Chain,Diff,State,Versions
+//	mockgen -package=state -destination=vms/platformvm/state/mock_state.go github.com/ava-labs/avalanchego/vms/platformvm/state Chain,Diff,HistoricalState,State,Versions
 //
 
 // Package state is a generated GoMock package.
@@ -18,10 +18,12 @@ import (
 	ids "github.com/ava-labs/avalanchego/ids"
 	validators "github.com/ava-labs/avalanchego/snow/validators"
 	avax "github.com/ava-labs/avalanchego/vms/components/avax"
+	fees "github.com/ava-labs/avalanchego/vms/components/fees"
 	block "github.com/ava-labs/avalanchego/vms/platformvm/block"
 	fx "github.com/ava-labs/avalanchego/vms/platformvm/fx"
 	status "github.com/ava-labs/avalanchego/vms/platformvm/status"
 	txs "github.com/ava-labs/avalanchego/vms/platformvm/txs"
+	merkledb "github.com/ava-labs/avalanchego/x/merkledb"
 	gomock "go.uber.org/mock/gomock"
 )
 
@@ -255,6 +257,21 @@ func (mr *MockChainMockRecorder) GetDelegateeReward(arg0, arg1 any) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDelegateeReward", reflect.TypeOf((*MockChain)(nil).GetDelegateeReward), arg0, arg1)
 }
 
+// GetFeeRates mocks base method.
+func (m *MockChain) GetFeeRates() (fees.Dimensions, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetFeeRates")
+	ret0, _ := ret[0].(fees.Dimensions)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetFeeRates indicates an expected call of GetFeeRates.
+func (mr *MockChainMockRecorder) GetFeeRates() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFeeRates", reflect.TypeOf((*MockChain)(nil).GetFeeRates))
+}
+
 // GetPendingDelegatorIterator mocks base method.
 func (m *MockChain) GetPendingDelegatorIterator(arg0 ids.ID, arg1 ids.NodeID) (StakerIterator, error) {
 	m.ctrl.T.Helper()
@@ -449,6 +466,18 @@ func (mr *MockChainMockRecorder) SetDelegateeReward(arg0, arg1, arg2 any) *gomoc
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetDelegateeReward", reflect.TypeOf((*MockChain)(nil).SetDelegateeReward), arg0, arg1, arg2)
 }
 
+// SetFeeRates mocks base method.
+func (m *MockChain) SetFeeRates(arg0 fees.Dimensions) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetFeeRates", arg0)
+}
+
+// SetFeeRates indicates an expected call of SetFeeRates.
+func (mr *MockChainMockRecorder) SetFeeRates(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetFeeRates", reflect.TypeOf((*MockChain)(nil).SetFeeRates), arg0)
+}
+
 // SetSubnetOwner mocks base method.
 func (m *MockChain) SetSubnetOwner(arg0 ids.ID, arg1 fx.Owner) {
 	m.ctrl.T.Helper()
@@ -717,6 +746,21 @@ func (mr *MockDiffMockRecorder) GetDelegateeReward(arg0, arg1 any) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDelegateeReward", reflect.TypeOf((*MockDiff)(nil).GetDelegateeReward), arg0, arg1)
 }
 
+// GetFeeRates mocks base method.
+func (m *MockDiff) GetFeeRates() (fees.Dimensions, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetFeeRates")
+	ret0, _ := ret[0].(fees.Dimensions)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetFeeRates indicates an expected call of GetFeeRates.
+func (mr *MockDiffMockRecorder) GetFeeRates() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFeeRates", reflect.TypeOf((*MockDiff)(nil).GetFeeRates))
+}
+
 // GetPendingDelegatorIterator mocks base method.
 func (m *MockDiff) GetPendingDelegatorIterator(arg0 ids.ID, arg1 ids.NodeID) (StakerIterator, error) {
 	m.ctrl.T.Helper()
@@ -911,6 +955,18 @@ func (mr *MockDiffMockRecorder) SetDelegateeReward(arg0, arg1, arg2 any) *gomock
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetDelegateeReward", reflect.TypeOf((*MockDiff)(nil).SetDelegateeReward), arg0, arg1, arg2)
 }
 
+// SetFeeRates mocks base method.
+func (m *MockDiff) SetFeeRates(arg0 fees.Dimensions) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetFeeRates", arg0)
+}
+
+// SetFeeRates indicates an expected call of SetFeeRates.
+func (mr *MockDiffMockRecorder) SetFeeRates(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetFeeRates", reflect.TypeOf((*MockDiff)(nil).SetFeeRates), arg0)
+}
+
 // SetSubnetOwner mocks base method.
 func (m *MockDiff) SetSubnetOwner(arg0 ids.ID, arg1 fx.Owner) {
 	m.ctrl.T.Helper()
@@ -935,6 +991,134 @@ func (mr *MockDiffMockRecorder) SetTimestamp(arg0 any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetTimestamp", reflect.TypeOf((*MockDiff)(nil).SetTimestamp), arg0)
 }
 
+// MockHistoricalState is a mock of HistoricalState interface.
+type MockHistoricalState struct {
+	ctrl     *gomock.Controller
+	recorder *MockHistoricalStateMockRecorder
+}
+
+// MockHistoricalStateMockRecorder is the mock recorder for MockHistoricalState.
+type MockHistoricalStateMockRecorder struct {
+	mock *MockHistoricalState
+}
+
+// NewMockHistoricalState creates a new mock instance.
+func NewMockHistoricalState(ctrl *gomock.Controller) *MockHistoricalState {
+	mock := &MockHistoricalState{ctrl: ctrl}
+	mock.recorder = &MockHistoricalStateMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockHistoricalState) EXPECT() *MockHistoricalStateMockRecorder {
+	return m.recorder
+}
+
+// GetCurrentValidator mocks base method.
+func (m *MockHistoricalState) GetCurrentValidator(arg0 ids.ID, arg1 ids.NodeID) (*HistoricalValidator, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCurrentValidator", arg0, arg1)
+	ret0, _ := ret[0].(*HistoricalValidator)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCurrentValidator indicates an expected call of GetCurrentValidator.
+func (mr *MockHistoricalStateMockRecorder) GetCurrentValidator(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCurrentValidator", reflect.TypeOf((*MockHistoricalState)(nil).GetCurrentValidator), arg0, arg1)
+}
+
+// GetCurrentValidators mocks base method.
+func (m *MockHistoricalState) GetCurrentValidators(arg0 ids.ID) ([]*HistoricalValidator, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCurrentValidators", arg0)
+	ret0, _ := ret[0].([]*HistoricalValidator)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCurrentValidators indicates an expected call of GetCurrentValidators.
+func (mr *MockHistoricalStateMockRecorder) GetCurrentValidators(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCurrentValidators", reflect.TypeOf((*MockHistoricalState)(nil).GetCurrentValidators), arg0)
+}
+
+// GetSubnetOwner mocks base method.
+func (m *MockHistoricalState) GetSubnetOwner(arg0 ids.ID) (fx.Owner, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSubnetOwner", arg0)
+	ret0, _ := ret[0].(fx.Owner)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSubnetOwner indicates an expected call of GetSubnetOwner.
+func (mr *MockHistoricalStateMockRecorder) GetSubnetOwner(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSubnetOwner", reflect.TypeOf((*MockHistoricalState)(nil).GetSubnetOwner), arg0)
+}
+
+// GetSubnetTransformationTxID mocks base method.
+func (m *MockHistoricalState) GetSubnetTransformationTxID(arg0 ids.ID) (ids.ID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSubnetTransformationTxID", arg0)
+	ret0, _ := ret[0].(ids.ID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSubnetTransformationTxID indicates an expected call of GetSubnetTransformationTxID.
+func (mr *MockHistoricalStateMockRecorder) GetSubnetTransformationTxID(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSubnetTransformationTxID", reflect.TypeOf((*MockHistoricalState)(nil).GetSubnetTransformationTxID), arg0)
+}
+
+// GetTimestamp mocks base method.
+func (m *MockHistoricalState) GetTimestamp() (time.Time, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTimestamp")
+	ret0, _ := ret[0].(time.Time)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTimestamp indicates an expected call of GetTimestamp.
+func (mr *MockHistoricalStateMockRecorder) GetTimestamp() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTimestamp", reflect.TypeOf((*MockHistoricalState)(nil).GetTimestamp))
+}
+
+// GetUTXO mocks base method.
+func (m *MockHistoricalState) GetUTXO(arg0 ids.ID) (*avax.UTXO, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUTXO", arg0)
+	ret0, _ := ret[0].(*avax.UTXO)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUTXO indicates an expected call of GetUTXO.
+func (mr *MockHistoricalStateMockRecorder) GetUTXO(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUTXO", reflect.TypeOf((*MockHistoricalState)(nil).GetUTXO), arg0)
+}
+
+// UTXOIDs mocks base method.
+func (m *MockHistoricalState) UTXOIDs(arg0 []byte, arg1 ids.ID, arg2 int) ([]ids.ID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UTXOIDs", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]ids.ID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UTXOIDs indicates an expected call of UTXOIDs.
+func (mr *MockHistoricalStateMockRecorder) UTXOIDs(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UTXOIDs", reflect.TypeOf((*MockHistoricalState)(nil).UTXOIDs), arg0, arg1, arg2)
+}
+
 // MockState is a mock of State interface.
 type MockState struct {
 	ctrl     *gomock.Controller
@@ -970,6 +1154,20 @@ func (mr *MockStateMockRecorder) Abort() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Abort", reflect.TypeOf((*MockState)(nil).Abort))
 }
 
+// AbortStateSync mocks base method.
+func (m *MockState) AbortStateSync() error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AbortStateSync")
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AbortStateSync indicates an expected call of AbortStateSync.
+func (mr *MockStateMockRecorder) AbortStateSync() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AbortStateSync", reflect.TypeOf((*MockState)(nil).AbortStateSync))
+}
+
 // AddChain mocks base method.
 func (m *MockState) AddChain(arg0 *txs.Tx) {
 	m.ctrl.T.Helper()
@@ -1199,6 +1397,35 @@ func (mr *MockStateMockRecorder) DeleteUTXO(arg0 any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteUTXO", reflect.TypeOf((*MockState)(nil).DeleteUTXO), arg0)
 }
 
+// FinishStateSync mocks base method.
+func (m *MockState) FinishStateSync(arg0 context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FinishStateSync", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// FinishStateSync indicates an expected call of FinishStateSync.
+func (mr *MockStateMockRecorder) FinishStateSync(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FinishStateSync", reflect.TypeOf((*MockState)(nil).FinishStateSync), arg0)
+}
+
+// GetAddressTxs mocks base method.
+func (m *MockState) GetAddressTxs(arg0 ids.ShortID, arg1 uint64, arg2 int) ([]ids.ID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAddressTxs", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]ids.ID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAddressTxs indicates an expected call of GetAddressTxs.
+func (mr *MockStateMockRecorder) GetAddressTxs(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAddressTxs", reflect.TypeOf((*MockState)(nil).GetAddressTxs), arg0, arg1, arg2)
+}
+
 // GetBlockIDAtHeight mocks base method.
 func (m *MockState) GetBlockIDAtHeight(arg0 uint64) (ids.ID, error) {
 	m.ctrl.T.Helper()
@@ -1304,6 +1531,36 @@ func (mr *MockStateMockRecorder) GetDelegateeReward(arg0, arg1 any) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDelegateeReward", reflect.TypeOf((*MockState)(nil).GetDelegateeReward), arg0, arg1)
 }
 
+// GetFeeRates mocks base method.
+func (m *MockState) GetFeeRates() (fees.Dimensions, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetFeeRates")
+	ret0, _ := ret[0].(fees.Dimensions)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetFeeRates indicates an expected call of GetFeeRates.
+func (mr *MockStateMockRecorder) GetFeeRates() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFeeRates", reflect.TypeOf((*MockState)(nil).GetFeeRates))
+}
+
+// GetHistoricalState mocks base method.
+func (m *MockState) GetHistoricalState(arg0 uint64) (HistoricalState, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetHistoricalState", arg0)
+	ret0, _ := ret[0].(HistoricalState)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetHistoricalState indicates an expected call of GetHistoricalState.
+func (mr *MockStateMockRecorder) GetHistoricalState(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetHistoricalState", reflect.TypeOf((*MockState)(nil).GetHistoricalState), arg0)
+}
+
 // GetLastAccepted mocks base method.
 func (m *MockState) GetLastAccepted() ids.ID {
 	m.ctrl.T.Helper()
@@ -1318,6 +1575,36 @@ func (mr *MockStateMockRecorder) GetLastAccepted() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLastAccepted", reflect.TypeOf((*MockState)(nil).GetLastAccepted))
 }
 
+// GetLastStateSummary mocks base method.
+func (m *MockState) GetLastStateSummary(arg0 context.Context) (*StateSummary, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLastStateSummary", arg0)
+	ret0, _ := ret[0].(*StateSummary)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLastStateSummary indicates an expected call of GetLastStateSummary.
+func (mr *MockStateMockRecorder) GetLastStateSummary(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLastStateSummary", reflect.TypeOf((*MockState)(nil).GetLastStateSummary), arg0)
+}
+
+// GetOngoingStateSummary mocks base method.
+func (m *MockState) GetOngoingStateSummary() (*StateSummary, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOngoingStateSummary")
+	ret0, _ := ret[0].(*StateSummary)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOngoingStateSummary indicates an expected call of GetOngoingStateSummary.
+func (mr *MockStateMockRecorder) GetOngoingStateSummary() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOngoingStateSummary", reflect.TypeOf((*MockState)(nil).GetOngoingStateSummary))
+}
+
 // GetPendingDelegatorIterator mocks base method.
 func (m *MockState) GetPendingDelegatorIterator(arg0 ids.ID, arg1 ids.NodeID) (StakerIterator, error) {
 	m.ctrl.T.Helper()
@@ -1393,6 +1680,21 @@ func (mr *MockStateMockRecorder) GetStartTime(arg0, arg1 any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetStartTime", reflect.TypeOf((*MockState)(nil).GetStartTime), arg0, arg1)
 }
 
+// GetStateSummary mocks base method.
+func (m *MockState) GetStateSummary(arg0 context.Context, arg1 uint64) (*StateSummary, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetStateSummary", arg0, arg1)
+	ret0, _ := ret[0].(*StateSummary)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetStateSummary indicates an expected call of GetStateSummary.
+func (mr *MockStateMockRecorder) GetStateSummary(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetStateSummary", reflect.TypeOf((*MockState)(nil).GetStateSummary), arg0, arg1)
+}
+
 // GetStatelessBlock mocks base method.
 func (m *MockState) GetStatelessBlock(arg0 ids.ID) (block.Block, error) {
 	m.ctrl.T.Helper()
@@ -1588,6 +1890,18 @@ func (mr *MockStateMockRecorder) SetDelegateeReward(arg0, arg1, arg2 any) *gomoc
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetDelegateeReward", reflect.TypeOf((*MockState)(nil).SetDelegateeReward), arg0, arg1, arg2)
 }
 
+// SetFeeRates mocks base method.
+func (m *MockState) SetFeeRates(arg0 fees.Dimensions) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetFeeRates", arg0)
+}
+
+// SetFeeRates indicates an expected call of SetFeeRates.
+func (mr *MockStateMockRecorder) SetFeeRates(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetFeeRates", reflect.TypeOf((*MockState)(nil).SetFeeRates), arg0)
+}
+
 // SetHeight mocks base method.
 func (m *MockState) SetHeight(arg0 uint64) {
 	m.ctrl.T.Helper()
@@ -1650,6 +1964,34 @@ func (mr *MockStateMockRecorder) SetUptime(arg0, arg1, arg2, arg3 any) *gomock.C
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetUptime", reflect.TypeOf((*MockState)(nil).SetUptime), arg0, arg1, arg2, arg3)
 }
 
+// StartStateSync mocks base method.
+func (m *MockState) StartStateSync(arg0 *StateSummary) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StartStateSync", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// StartStateSync indicates an expected call of StartStateSync.
+func (mr *MockStateMockRecorder) StartStateSync(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StartStateSync", reflect.TypeOf((*MockState)(nil).StartStateSync), arg0)
+}
+
+// SyncDB mocks base method.
+func (m *MockState) SyncDB() merkledb.MerkleDB {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SyncDB")
+	ret0, _ := ret[0].(merkledb.MerkleDB)
+	return ret0
+}
+
+// SyncDB indicates an expected call of SyncDB.
+func (mr *MockStateMockRecorder) SyncDB() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SyncDB", reflect.TypeOf((*MockState)(nil).SyncDB))
+}
+
 // UTXOIDs mocks base method.
 func (m *MockState) UTXOIDs(arg0 []byte, arg1 ids.ID, arg2 int) ([]ids.ID, error) {
 	m.ctrl.T.Helper()
@@ -28,8 +28,10 @@ import (
 	"github.com/ava-labs/avalanchego/utils/constants"
 	"github.com/ava-labs/avalanchego/utils/crypto/bls"
 	"github.com/ava-labs/avalanchego/utils/hashing"
+	"github.com/ava-labs/avalanchego/utils/set"
 	"github.com/ava-labs/avalanchego/utils/wrappers"
 	"github.com/ava-labs/avalanchego/vms/components/avax"
+	"github.com/ava-labs/avalanchego/vms/components/fees"
 	"github.com/ava-labs/avalanchego/vms/platformvm/block"
 	"github.com/ava-labs/avalanchego/vms/platformvm/config"
 	"github.com/ava-labs/avalanchego/vms/platformvm/fx"
@@ -38,6 +40,8 @@ import (
 	"github.com/ava-labs/avalanchego/vms/platformvm/reward"
 	"github.com/ava-labs/avalanchego/vms/platformvm/status"
 	"github.com/ava-labs/avalanchego/vms/platformvm/txs"
+	"github.com/ava-labs/avalanchego/x/archivedb"
+	"github.com/ava-labs/avalanchego/x/merkledb"
 
 	safemath "github.com/ava-labs/avalanchego/utils/math"
 )
@@ -68,12 +72,19 @@ var (
 	SupplyPrefix                  = []byte("supply")
 	ChainPrefix                   = []byte("chain")
 	SingletonPrefix               = []byte("singleton")
+	ArchivePrefix                 = []byte("archive")
+	AddressTxsPrefix              = []byte("addressTxs")
+	StateSyncPrefix               = []byte("stateSync")
 
 	TimestampKey      = []byte("timestamp")
 	CurrentSupplyKey  = []byte("current supply")
+	FeeRatesKey       = []byte("fee rates")
 	LastAcceptedKey   = []byte("last accepted")
 	HeightsIndexedKey = []byte("heights indexed")
 	InitializedKey    = []byte("initialized")
+
+	AddressTxsHeightKey = []byte("address txs height")
+	StateSyncHeightKey  = []byte("state sync height")
 )
 
 // Chain collects all methods to manage the state of the chain for block
@@ -87,6 +98,11 @@ type Chain interface {
 	GetTimestamp() time.Time
 	SetTimestamp(tm time.Time)
 
+	// GetFeeRates returns the dynamic fee rates charged to the transactions
+	// of the next block.
+	GetFeeRates() (fees.Dimensions, error)
+	SetFeeRates(feeRates fees.Dimensions)
+
 	GetCurrentSupply(subnetID ids.ID) (uint64, error)
 	SetCurrentSupply(subnetID ids.ID, cs uint64)
 
@@ -121,6 +137,44 @@ type State interface {
 
 	GetBlockIDAtHeight(height uint64) (ids.ID, error)
 
+	// GetHistoricalState returns the state as of the accepted block at
+	// [height]. Returns [ErrArchivalModeDisabled] if archival mode is
+	// disabled.
+	GetHistoricalState(height uint64) (HistoricalState, error)
+
+	// GetAddressTxs returns the IDs of the accepted transactions that touched
+	// [addr], in order of acceptance, skipping the first [cursor] of them.
+	// Returns [ErrAddressIndexDisabled] if the address index is disabled.
+	GetAddressTxs(addr ids.ShortID, cursor uint64, pageSize int) ([]ids.ID, error)
+
+	// GetStateSummary returns the state summary generated at [height].
+	// Returns database.ErrNotFound if the summary can't be served.
+	GetStateSummary(ctx context.Context, height uint64) (*StateSummary, error)
+
+	// GetLastStateSummary returns the most recent state summary that can be
+	// served. Returns database.ErrNotFound if there is none.
+	GetLastStateSummary(ctx context.Context) (*StateSummary, error)
+
+	// GetOngoingStateSummary returns the summary that is being state synced.
+	// Returns database.ErrNotFound if there is no ongoing state sync.
+	GetOngoingStateSummary() (*StateSummary, error)
+
+	// StartStateSync stops updating the trie returned by [SyncDB] so that it
+	// can be synced to [summary].
+	StartStateSync(summary *StateSummary) error
+
+	// FinishStateSync replaces the state with the state synced into the trie
+	// returned by [SyncDB].
+	FinishStateSync(ctx context.Context) error
+
+	// AbortStateSync stops the ongoing state sync. The trie returned by
+	// [SyncDB] is rebuilt from the state once the node restarts.
+	AbortStateSync() error
+
+	// SyncDB returns the trie that state sync peers are served from. Returns
+	// nil if state sync serving is disabled.
+	SyncDB() merkledb.MerkleDB
+
 	GetRewardUTXOs(txID ids.ID) ([]*avax.UTXO, error)
 	GetSubnets() ([]*txs.Tx, error)
 	GetChains(subnetID ids.ID) ([]*txs.Tx, error)
@@ -247,8 +301,23 @@ type stateBlk struct {
  *   |-- initializedKey -> nil
  *   |-- timestampKey -> timestamp
  *   |-- currentSupplyKey -> currentSupply
+ *   |-- feeRatesKey -> feeRates
  *   |-- lastAcceptedKey -> lastAccepted
- *   '-- heightsIndexKey -> startIndexHeight + endIndexHeight
+ *   |-- heightsIndexKey -> startIndexHeight + endIndexHeight
+ *   '-- stateSyncHeightKey -> stateSyncHeight
+ *
+ * The state sync trie is not part of the VMDB. It is written outside of the
+ * versioned database so that it can be synced directly.
+ *
+ * DB
+ * '-. stateSync
+ *   |-. trie
+ *   | '-- merkledb
+ *   |-. summary
+ *   | '-- height -> state summary
+ *   '-. metadata
+ *     |-- lastAcceptedKey -> the last accepted block reflected by the trie
+ *     '-- ongoingSummaryKey -> the summary being state synced
  */
 type state struct {
 	validatorState
@@ -335,11 +404,36 @@ type state struct {
 	// The persisted fields represent the current database value
 	timestamp, persistedTimestamp         time.Time
 	currentSupply, persistedCurrentSupply uint64
+	feeRates, persistedFeeRates           fees.Dimensions
 	// [lastAccepted] is the most recently accepted block.
 	lastAccepted, persistedLastAccepted ids.ID
 	// TODO: Remove indexedHeights once v1.11.3 has been released.
 	indexedHeights *heightRange
 	singletonDB    database.Database
+
+	archiveDB *archivedb.Database // nil if archival mode is disabled
+
+	addressTxsDB database.Database // nil if the address index is disabled
+
+	syncDB           merkledb.MerkleDB // nil if state sync serving is disabled
+	syncSummaryDB    database.Database
+	syncMetadataDB   database.Database
+	stateSyncEnabled bool
+	// [syncing] is true while the trie is being state synced, during which
+	// the trie isn't updated with the local state.
+	syncing bool
+	// [syncStale] is true if a state sync was aborted, which left the trie
+	// out of date with the local state. The trie isn't updated or served until
+	// it is rebuilt once the node restarts.
+	syncStale bool
+	// [syncLastAccepted] is the last accepted block reflected by the trie.
+	syncLastAccepted ids.ID
+	// map of subnetID -> nodeIDs of the validators whose delegatee rewards
+	// have been modified
+	modifiedDelegateeRewards map[ids.ID]set.Set[ids.NodeID]
+	// [stateSyncHeight] is the height the chain was state synced to, or 0 if
+	// the chain was never state synced.
+	stateSyncHeight uint64
 }
 
 // heightRange is used to track which heights are safe to use the native DB
@@ -563,6 +657,31 @@ func newState(
 		return nil, err
 	}
 
+	var archiveDB *archivedb.Database
+	if execCfg.ArchivalModeEnabled {
+		archiveDB = archivedb.New(prefixdb.New(ArchivePrefix, baseDB))
+	}
+
+	var addressTxsDB database.Database
+	if execCfg.AddressIndexEnabled {
+		addressTxsDB = prefixdb.New(AddressTxsPrefix, baseDB)
+	}
+
+	var (
+		syncDB         merkledb.MerkleDB
+		syncSummaryDB  database.Database
+		syncMetadataDB database.Database
+	)
+	if execCfg.StateSyncServingEnabled {
+		stateSyncDB := prefixdb.New(StateSyncPrefix, db)
+		syncSummaryDB = prefixdb.New(stateSyncSummaryPrefix, stateSyncDB)
+		syncMetadataDB = prefixdb.New(stateSyncMetadataPrefix, stateSyncDB)
+		syncDB, err = openSyncDB(prefixdb.New(stateSyncTriePrefix, stateSyncDB), syncMetadataDB)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	return &state{
 		validatorState: newValidatorState(),
 
@@ -639,6 +758,16 @@ func newState(
 		chainDBCache: chainDBCache,
 
 		singletonDB: prefixdb.New(SingletonPrefix, baseDB),
+
+		archiveDB: archiveDB,
+
+		addressTxsDB: addressTxsDB,
+
+		syncDB:                   syncDB,
+		syncSummaryDB:            syncSummaryDB,
+		syncMetadataDB:           syncMetadataDB,
+		stateSyncEnabled:         execCfg.StateSyncEnabled,
+		modifiedDelegateeRewards: make(map[ids.ID]set.Set[ids.NodeID]),
 	}, nil
 }
 
@@ -990,6 +1119,14 @@ func (s *state) SetTimestamp(tm time.Time) {
 	s.timestamp = tm
 }
 
+func (s *state) GetFeeRates() (fees.Dimensions, error) {
+	return s.feeRates, nil
+}
+
+func (s *state) SetFeeRates(feeRates fees.Dimensions) {
+	s.feeRates = feeRates
+}
+
 func (s *state) GetLastAccepted() ids.ID {
 	return s.lastAccepted
 }
@@ -1037,6 +1174,19 @@ func (s *state) SetCurrentSupply(subnetID ids.ID, cs uint64) {
 	}
 }
 
+// checkValidatorDiffs returns an error if the diffs up to and including
+// [endHeight] weren't synced.
+func (s *state) checkValidatorDiffs(endHeight uint64) error {
+	if endHeight+stateSyncValidatorDiffsLength > s.stateSyncHeight {
+		return nil
+	}
+	return fmt.Errorf("%w: %d <= %d",
+		ErrMissingValidatorDiffs,
+		endHeight,
+		s.stateSyncHeight-stateSyncValidatorDiffsLength,
+	)
+}
+
 func (s *state) ApplyValidatorWeightDiffs(
 	ctx context.Context,
 	validators map[ids.NodeID]*validators.GetValidatorOutput,
@@ -1044,6 +1194,10 @@ func (s *state) ApplyValidatorWeightDiffs(
 	endHeight uint64,
 	subnetID ids.ID,
 ) error {
+	if err := s.checkValidatorDiffs(endHeight); err != nil {
+		return err
+	}
+
 	diffIter := s.validatorWeightDiffsDB.NewIteratorWithStartAndPrefix(
 		marshalStartDiffKey(subnetID, startHeight),
 		subnetID[:],
@@ -1135,6 +1289,10 @@ func (s *state) ApplyValidatorPublicKeyDiffs(
 	startHeight uint64,
 	endHeight uint64,
 ) error {
+	if err := s.checkValidatorDiffs(endHeight); err != nil {
+		return err
+	}
+
 	diffIter := s.validatorPublicKeyDiffsDB.NewIteratorWithStartAndPrefix(
 		marshalStartDiffKey(constants.PrimaryNetworkID, startHeight),
 		constants.PrimaryNetworkID[:],
@@ -1258,9 +1416,12 @@ func (s *state) syncGenesis(genesisBlk block.Block, genesis *genesis.Genesis) er
 func (s *state) load() error {
 	return utils.Err(
 		s.loadMetadata(),
+		s.loadArchive(),
+		s.loadAddressTxs(),
 		s.loadCurrentValidators(),
 		s.loadPendingValidators(),
 		s.initValidatorSets(),
+		s.loadSyncDB(), // Must be called after the stakers are loaded
 	)
 }
 
@@ -1279,6 +1440,23 @@ func (s *state) loadMetadata() error {
 	s.persistedCurrentSupply = currentSupply
 	s.SetCurrentSupply(constants.PrimaryNetworkID, currentSupply)
 
+	switch feeRatesBytes, err := s.singletonDB.Get(FeeRatesKey); err {
+	case nil:
+		feeRates, err := fees.ParseDimensions(feeRatesBytes)
+		if err != nil {
+			return err
+		}
+		s.persistedFeeRates = feeRates
+		s.SetFeeRates(feeRates)
+	case database.ErrNotFound:
+		// The fee rates are only written once they are first updated, so that
+		// the initial fee rates can be modified until the fee rates activate.
+		s.persistedFeeRates = s.cfg.DynamicFeeConfig.InitialFeeRate
+		s.SetFeeRates(s.cfg.DynamicFeeConfig.InitialFeeRate)
+	default:
+		return err
+	}
+
 	lastAccepted, err := database.GetID(s.singletonDB, LastAcceptedKey)
 	if err != nil {
 		return err
@@ -1612,6 +1790,9 @@ func (s *state) write(updateValidators bool, height uint64) error {
 	}
 
 	return utils.Err(
+		s.writeArchive(height),    // Must be called before any modifications are written
+		s.writeAddressTxs(height), // Must be called before any modifications are written
+		s.writeSyncDB(height),     // Must be called before any modifications are written
 		s.writeBlocks(),
 		s.writeCurrentStakers(updateValidators, height, codecVersion),
 		s.writePendingStakers(),
@@ -1629,6 +1810,16 @@ func (s *state) write(updateValidators bool, height uint64) error {
 }
 
 func (s *state) Close() error {
+	var archiveErr, addressTxsErr, syncErr error
+	if s.archiveDB != nil {
+		archiveErr = s.archiveDB.Close()
+	}
+	if s.addressTxsDB != nil {
+		addressTxsErr = s.addressTxsDB.Close()
+	}
+	if s.syncDB != nil {
+		syncErr = s.syncDB.Close()
+	}
 	return utils.Err(
 		s.pendingSubnetValidatorBaseDB.Close(),
 		s.pendingSubnetDelegatorBaseDB.Close(),
@@ -1651,6 +1842,9 @@ func (s *state) Close() error {
 		s.singletonDB.Close(),
 		s.blockDB.Close(),
 		s.blockIDDB.Close(),
+		archiveErr,
+		addressTxsErr,
+		syncErr,
 	)
 }
 
@@ -2254,6 +2448,12 @@ func (s *state) writeMetadata() error {
 		}
 		s.persistedCurrentSupply = s.currentSupply
 	}
+	if s.persistedFeeRates != s.feeRates {
+		if err := s.singletonDB.Put(FeeRatesKey, s.feeRates.Bytes()); err != nil {
+			return fmt.Errorf("failed to write fee rates: %w", err)
+		}
+		s.persistedFeeRates = s.feeRates
+	}
 	if s.persistedLastAccepted != s.lastAccepted {
 		if err := database.PutID(s.singletonDB, LastAcceptedKey, s.lastAccepted); err != nil {
 			return fmt.Errorf("failed to write last accepted: %w", err)
@@ -0,0 +1,1415 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package state
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/trace"
+	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/ava-labs/avalanchego/utils/crypto/bls"
+	"github.com/ava-labs/avalanchego/utils/hashing"
+	"github.com/ava-labs/avalanchego/utils/maybe"
+	"github.com/ava-labs/avalanchego/utils/set"
+	"github.com/ava-labs/avalanchego/utils/units"
+	"github.com/ava-labs/avalanchego/vms/components/avax"
+	"github.com/ava-labs/avalanchego/vms/components/fees"
+	"github.com/ava-labs/avalanchego/vms/platformvm/block"
+	"github.com/ava-labs/avalanchego/vms/platformvm/fx"
+	"github.com/ava-labs/avalanchego/vms/platformvm/status"
+	"github.com/ava-labs/avalanchego/vms/platformvm/txs"
+	"github.com/ava-labs/avalanchego/x/merkledb"
+)
+
+const (
+	// StateSummaryFrequency is the number of blocks between state summaries.
+	StateSummaryFrequency = 1024
+
+	// StateSyncBranchFactor is the branch factor of the state sync trie.
+	StateSyncBranchFactor = merkledb.BranchFactor16
+
+	// Summaries older than [numServedSummaries] intervals are pruned. The
+	// trie keeps enough history to serve proofs for all of the others.
+	numServedSummaries     = 2
+	stateSyncHistoryLength = (numServedSummaries + 1) * StateSummaryFrequency
+
+	// The trie holds the validator diffs of the last
+	// [stateSyncValidatorDiffsLength] blocks so that a synced node can
+	// compute the validator sets that recent blocks were built against.
+	stateSyncValidatorDiffsLength = StateSummaryFrequency
+
+	// Number of changes to commit at once when rebuilding the trie.
+	stateSyncRebuildBatchSize = 64 * units.KiB
+	stateSyncClearWriteSize   = units.MiB
+)
+
+// Every key in the state sync trie starts with one of the following prefixes.
+const (
+	syncCurrentValidatorPrefix byte = iota
+	syncCurrentDelegatorPrefix
+	syncPendingValidatorPrefix
+	syncPendingDelegatorPrefix
+	syncUTXOPrefix
+	syncSubnetPrefix
+	syncSubnetOwnerPrefix
+	syncTransformedSubnetPrefix
+	syncSupplyPrefix
+	syncChainPrefix
+	syncTimestampPrefix
+	syncFeeRatesPrefix
+	syncValidatorWeightDiffPrefix
+	syncValidatorPublicKeyDiffPrefix
+)
+
+var (
+	ErrStateSyncServingDisabled = errors.New("state sync serving is disabled")
+	ErrMissingValidatorDiffs    = errors.New("validator diffs before the state sync height are unavailable")
+
+	errNoOngoingStateSync = errors.New("no ongoing state sync")
+	errStateSyncRoot      = errors.New("state sync trie has an unexpected root")
+	errNotStakerTx        = errors.New("not a staker tx")
+
+	stateSyncTriePrefix     = []byte("trie")
+	stateSyncSummaryPrefix  = []byte("summary")
+	stateSyncMetadataPrefix = []byte("metadata")
+
+	stateSyncLastAcceptedKey   = []byte("last accepted")
+	stateSyncOngoingSummaryKey = []byte("ongoing summary")
+
+	syncTimestampKey = []byte{syncTimestampPrefix}
+	syncFeeRatesKey  = []byte{syncFeeRatesPrefix}
+)
+
+// StateSummary commits to the state of the chain after its block was
+// accepted.
+type StateSummary struct {
+	BlockBytes []byte `serialize:"true"`
+	// Root of the state sync trie after the block was accepted
+	Root ids.ID `serialize:"true"`
+
+	id    ids.ID
+	block block.Block
+	bytes []byte
+}
+
+func NewStateSummary(blk block.Block, root ids.ID) (*StateSummary, error) {
+	summary := &StateSummary{
+		BlockBytes: blk.Bytes(),
+		Root:       root,
+		block:      blk,
+	}
+	bytes, err := block.GenesisCodec.Marshal(block.CodecVersion, summary)
+	if err != nil {
+		return nil, err
+	}
+	summary.id = hashing.ComputeHash256Array(bytes)
+	summary.bytes = bytes
+	return summary, nil
+}
+
+// ParseStateSummary parses a state summary sent by a peer.
+func ParseStateSummary(bytes []byte) (*StateSummary, error) {
+	summary := &StateSummary{}
+	if _, err := block.GenesisCodec.Unmarshal(bytes, summary); err != nil {
+		return nil, err
+	}
+	blk, err := block.Parse(block.Codec, summary.BlockBytes)
+	if err != nil {
+		return nil, err
+	}
+	summary.id = hashing.ComputeHash256Array(bytes)
+	summary.block = blk
+	summary.bytes = bytes
+	return summary, nil
+}
+
+func (s *StateSummary) ID() ids.ID {
+	return s.id
+}
+
+func (s *StateSummary) Height() uint64 {
+	return s.block.Height()
+}
+
+func (s *StateSummary) Bytes() []byte {
+	return s.bytes
+}
+
+// Block returns the last block accepted into the summarized state.
+func (s *StateSummary) Block() block.Block {
+	return s.block
+}
+
+// syncStaker is the value of current stakers in the state sync trie.
+type syncStaker struct {
+	Tx              []byte `serialize:"true"`
+	StartTime       uint64 `serialize:"true"`
+	PotentialReward uint64 `serialize:"true"`
+	DelegateeReward uint64 `serialize:"true"`
+}
+
+// openSyncDB opens the trie stored in [trieDB]. If the trie can't be opened,
+// which can happen if the node didn't shut down cleanly, it is cleared so that
+// it is rebuilt from the local state when the state is loaded.
+func openSyncDB(trieDB database.Database, metadataDB database.Database) (merkledb.MerkleDB, error) {
+	config := merkledb.Config{
+		BranchFactor:                StateSyncBranchFactor,
+		HistoryLength:               stateSyncHistoryLength,
+		ValueNodeCacheSize:          16 * units.MiB,
+		IntermediateNodeCacheSize:   16 * units.MiB,
+		IntermediateWriteBufferSize: 4 * units.MiB,
+		IntermediateWriteBatchSize:  256 * units.KiB,
+		Tracer:                      trace.Noop,
+	}
+	syncDB, err := merkledb.New(context.TODO(), trieDB, config)
+	if err == nil {
+		return syncDB, nil
+	}
+
+	if err := metadataDB.Delete(stateSyncLastAcceptedKey); err != nil {
+		return nil, err
+	}
+	if err := database.Clear(trieDB, stateSyncClearWriteSize); err != nil {
+		return nil, err
+	}
+	return merkledb.New(context.TODO(), trieDB, config)
+}
+
+func (s *state) SyncDB() merkledb.MerkleDB {
+	return s.syncDB
+}
+
+func (s *state) SetDelegateeReward(subnetID ids.ID, nodeID ids.NodeID, amount uint64) error {
+	if err := s.validatorState.SetDelegateeReward(subnetID, nodeID, amount); err != nil {
+		return err
+	}
+	if s.syncDB != nil {
+		nodeIDs := s.modifiedDelegateeRewards[subnetID]
+		nodeIDs.Add(nodeID)
+		s.modifiedDelegateeRewards[subnetID] = nodeIDs
+	}
+	return nil
+}
+
+func (s *state) GetStateSummary(ctx context.Context, height uint64) (*StateSummary, error) {
+	if s.syncDB == nil || s.syncStale {
+		return nil, database.ErrNotFound
+	}
+	bytes, err := s.syncSummaryDB.Get(database.PackUInt64(height))
+	if err != nil {
+		return nil, err
+	}
+	return s.servableStateSummary(ctx, bytes)
+}
+
+func (s *state) GetLastStateSummary(ctx context.Context) (*StateSummary, error) {
+	if s.syncDB == nil || s.syncStale {
+		return nil, database.ErrNotFound
+	}
+
+	it := s.syncSummaryDB.NewIterator()
+	defer it.Release()
+
+	var lastBytes []byte
+	for it.Next() {
+		lastBytes = it.Value()
+	}
+	if err := it.Error(); err != nil {
+		return nil, err
+	}
+	if lastBytes == nil {
+		return nil, database.ErrNotFound
+	}
+	return s.servableStateSummary(ctx, lastBytes)
+}
+
+// servableStateSummary returns the summary if the trie can still serve proofs
+// of its root. The trie history is only kept in memory, so summaries written
+// before the node restarted may no longer be servable.
+func (s *state) servableStateSummary(ctx context.Context, bytes []byte) (*StateSummary, error) {
+	summary, err := ParseStateSummary(bytes)
+	if err != nil {
+		return nil, err
+	}
+	_, err = s.syncDB.GetRangeProofAtRoot(
+		ctx,
+		summary.Root,
+		maybe.Nothing[[]byte](),
+		maybe.Nothing[[]byte](),
+		1,
+	)
+	if errors.Is(err, merkledb.ErrInsufficientHistory) {
+		return nil, database.ErrNotFound
+	}
+	return summary, err
+}
+
+func (s *state) GetOngoingStateSummary() (*StateSummary, error) {
+	if s.syncDB == nil {
+		return nil, database.ErrNotFound
+	}
+	bytes, err := s.syncMetadataDB.Get(stateSyncOngoingSummaryKey)
+	if err != nil {
+		return nil, err
+	}
+	return ParseStateSummary(bytes)
+}
+
+// StartStateSync marks [summary] as the target of an ongoing state sync. The
+// trie is no longer updated with the local state until the sync finishes or is
+// aborted.
+func (s *state) StartStateSync(summary *StateSummary) error {
+	if s.syncDB == nil {
+		return ErrStateSyncServingDisabled
+	}
+	if err := s.syncMetadataDB.Delete(stateSyncLastAcceptedKey); err != nil {
+		return err
+	}
+	if err := s.syncMetadataDB.Put(stateSyncOngoingSummaryKey, summary.Bytes()); err != nil {
+		return err
+	}
+	s.syncLastAccepted = ids.Empty
+	s.syncing = true
+	return nil
+}
+
+// AbortStateSync stops the ongoing state sync.
+//
+// Rebuilding the trie from the local state may take a while, so it isn't done
+// here. Instead, the trie is marked as stale and rebuilt once the node
+// restarts.
+func (s *state) AbortStateSync() error {
+	if s.syncDB == nil {
+		return ErrStateSyncServingDisabled
+	}
+	if err := s.syncMetadataDB.Delete(stateSyncOngoingSummaryKey); err != nil {
+		return err
+	}
+	s.syncing = false
+	s.syncStale = true
+	return nil
+}
+
+// FinishStateSync replaces the local state with the state that was synced
+// into the trie.
+//
+// Only the state required to continue executing blocks is synced. The history
+// of the chain prior to the summary, such as reward UTXOs, is unavailable.
+// Validator diffs are only synced for the last [stateSyncValidatorDiffsLength]
+// blocks.
+func (s *state) FinishStateSync(ctx context.Context) error {
+	summary, err := s.GetOngoingStateSummary()
+	if err == database.ErrNotFound {
+		return errNoOngoingStateSync
+	}
+	if err != nil {
+		return err
+	}
+
+	root, err := s.syncDB.GetMerkleRoot(ctx)
+	if err != nil {
+		return err
+	}
+	if root != summary.Root {
+		return fmt.Errorf("%w: expected %s but got %s", errStateSyncRoot, summary.Root, root)
+	}
+
+	height := summary.Height()
+	s.ctx.Log.Info("applying synced state",
+		zap.Stringer("summaryID", summary.ID()),
+		zap.Uint64("height", height),
+		zap.Stringer("root", root),
+	)
+
+	// Stakers must be removed and added in separate writes because stakers
+	// only track a single diff for each validator.
+	if err := s.removeUnsyncedState(ctx); err != nil {
+		return err
+	}
+	if err := s.write(true /*=updateValidators*/, height); err != nil {
+		return err
+	}
+
+	if err := s.addSyncedState(ctx); err != nil {
+		return err
+	}
+	blk := summary.Block()
+	s.AddStatelessBlock(blk)
+	s.SetLastAccepted(blk.ID())
+	s.indexedHeights = nil
+	s.SetHeight(height)
+	if err := s.write(true /*=updateValidators*/, height); err != nil {
+		return err
+	}
+
+	// Delegatee rewards can only be set once the validators are written.
+	if err := s.setSyncedDelegateeRewards(ctx); err != nil {
+		return err
+	}
+	if err := s.addSyncedValidatorDiffs(ctx, height); err != nil {
+		return err
+	}
+
+	if err := database.PutUInt64(s.singletonDB, StateSyncHeightKey, height); err != nil {
+		return err
+	}
+	if err := s.Commit(); err != nil {
+		return err
+	}
+	s.stateSyncHeight = height
+
+	s.syncing = false
+	clear(s.modifiedDelegateeRewards)
+	if err := s.syncSummaryDB.Put(database.PackUInt64(height), summary.Bytes()); err != nil {
+		return err
+	}
+	if err := s.putSyncLastAccepted(); err != nil {
+		return err
+	}
+	return s.syncMetadataDB.Delete(stateSyncOngoingSummaryKey)
+}
+
+// removeUnsyncedState removes the stakers and UTXOs that are not in the trie.
+func (s *state) removeUnsyncedState(ctx context.Context) error {
+	for _, stakers := range []*baseStakers{s.currentStakers, s.pendingStakers} {
+		var toRemove []*Staker
+		it := stakers.GetStakerIterator()
+		for it.Next() {
+			staker := it.Value()
+			synced, err := s.hasSyncedStaker(ctx, staker)
+			if err != nil {
+				it.Release()
+				return err
+			}
+			if !synced {
+				toRemove = append(toRemove, staker)
+			}
+		}
+		it.Release()
+
+		for _, staker := range toRemove {
+			if staker.Priority.IsValidator() {
+				stakers.DeleteValidator(staker)
+			} else {
+				stakers.DeleteDelegator(staker)
+			}
+		}
+	}
+
+	it := s.utxoState.NewUTXOIterator()
+	defer it.Release()
+
+	var toRemove []ids.ID
+	for it.Next() {
+		utxoID, err := ids.ToID(it.Key())
+		if err != nil {
+			return err
+		}
+		_, err = s.syncDB.GetValue(ctx, prefixedKey(syncUTXOPrefix, utxoID[:]))
+		if err == database.ErrNotFound {
+			toRemove = append(toRemove, utxoID)
+			continue
+		}
+		if err != nil {
+			return err
+		}
+	}
+	if err := it.Error(); err != nil {
+		return err
+	}
+	for _, utxoID := range toRemove {
+		s.DeleteUTXO(utxoID)
+	}
+	return nil
+}
+
+func (s *state) hasSyncedStaker(ctx context.Context, staker *Staker) (bool, error) {
+	key := syncStakerKey(staker)
+	value, err := s.syncDB.GetValue(ctx, key)
+	if err == database.ErrNotFound {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	if !staker.Priority.IsValidator() {
+		// Delegators are keyed by their txID.
+		return true, nil
+	}
+
+	// Validators are keyed by their subnetID and nodeID, so the tx must be
+	// compared.
+	txBytes := value
+	if staker.Priority.IsCurrent() {
+		syncStaker := syncStaker{}
+		if _, err := block.GenesisCodec.Unmarshal(value, &syncStaker); err != nil {
+			return false, err
+		}
+		txBytes = syncStaker.Tx
+	}
+	return hashing.ComputeHash256Array(txBytes) == staker.TxID, nil
+}
+
+// addSyncedState adds all the state in the trie that isn't in the local state.
+func (s *state) addSyncedState(ctx context.Context) error {
+	localTxIDs := set.NewSet[ids.ID](0)
+	for _, stakers := range []*baseStakers{s.currentStakers, s.pendingStakers} {
+		it := stakers.GetStakerIterator()
+		for it.Next() {
+			localTxIDs.Add(it.Value().TxID)
+		}
+		it.Release()
+	}
+
+	err := s.iterateSyncDB(ctx, syncCurrentValidatorPrefix, func(_, value []byte) error {
+		return s.addSyncedCurrentStaker(value, localTxIDs)
+	})
+	if err != nil {
+		return err
+	}
+	err = s.iterateSyncDB(ctx, syncCurrentDelegatorPrefix, func(_, value []byte) error {
+		return s.addSyncedCurrentStaker(value, localTxIDs)
+	})
+	if err != nil {
+		return err
+	}
+	err = s.iterateSyncDB(ctx, syncPendingValidatorPrefix, func(_, value []byte) error {
+		return s.addSyncedPendingStaker(value, localTxIDs)
+	})
+	if err != nil {
+		return err
+	}
+	err = s.iterateSyncDB(ctx, syncPendingDelegatorPrefix, func(_, value []byte) error {
+		return s.addSyncedPendingStaker(value, localTxIDs)
+	})
+	if err != nil {
+		return err
+	}
+
+	err = s.iterateSyncDB(ctx, syncUTXOPrefix, func(key, value []byte) error {
+		utxoID, err := ids.ToID(key)
+		if err != nil {
+			return err
+		}
+		_, err = s.GetUTXO(utxoID)
+		if err == nil {
+			return nil
+		}
+		if err != database.ErrNotFound {
+			return err
+		}
+
+		utxo := &avax.UTXO{}
+		if _, err := txs.GenesisCodec.Unmarshal(value, utxo); err != nil {
+			return err
+		}
+		s.AddUTXO(utxo)
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	subnets, err := s.GetSubnets()
+	if err != nil {
+		return err
+	}
+	localSubnetIDs := set.NewSet[ids.ID](len(subnets))
+	for _, subnet := range subnets {
+		localSubnetIDs.Add(subnet.ID())
+	}
+	err = s.iterateSyncDB(ctx, syncSubnetPrefix, func(_, value []byte) error {
+		tx, err := txs.Parse(txs.GenesisCodec, value)
+		if err != nil {
+			return err
+		}
+		if localSubnetIDs.Contains(tx.ID()) {
+			return nil
+		}
+		s.AddSubnet(tx)
+		s.AddTx(tx, status.Committed)
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	err = s.iterateSyncDB(ctx, syncSubnetOwnerPrefix, func(key, value []byte) error {
+		subnetID, err := ids.ToID(key)
+		if err != nil {
+			return err
+		}
+		var owner fx.Owner
+		if _, err := block.GenesisCodec.Unmarshal(value, &owner); err != nil {
+			return err
+		}
+		s.SetSubnetOwner(subnetID, owner)
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	err = s.iterateSyncDB(ctx, syncTransformedSubnetPrefix, func(_, value []byte) error {
+		tx, err := txs.Parse(txs.GenesisCodec, value)
+		if err != nil {
+			return err
+		}
+		s.AddSubnetTransformation(tx)
+		s.AddTx(tx, status.Committed)
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	err = s.iterateSyncDB(ctx, syncSupplyPrefix, func(key, value []byte) error {
+		subnetID, err := ids.ToID(key)
+		if err != nil {
+			return err
+		}
+		supply, err := database.ParseUInt64(value)
+		if err != nil {
+			return err
+		}
+		s.SetCurrentSupply(subnetID, supply)
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	err = s.iterateSyncDB(ctx, syncChainPrefix, func(_, value []byte) error {
+		tx, err := txs.Parse(txs.GenesisCodec, value)
+		if err != nil {
+			return err
+		}
+		createChainTx, ok := tx.Unsigned.(*txs.CreateChainTx)
+		if !ok {
+			return fmt.Errorf("expected tx type *txs.CreateChainTx but got %T", tx.Unsigned)
+		}
+		chains, err := s.GetChains(createChainTx.SubnetID)
+		if err != nil {
+			return err
+		}
+		chainID := tx.ID()
+		for _, chain := range chains {
+			if chain.ID() == chainID {
+				return nil
+			}
+		}
+		s.AddChain(tx)
+		s.AddTx(tx, status.Committed)
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	timestampBytes, err := s.syncDB.GetValue(ctx, syncTimestampKey)
+	if err != nil {
+		return err
+	}
+	timestamp, err := database.ParseUInt64(timestampBytes)
+	if err != nil {
+		return err
+	}
+	s.SetTimestamp(time.Unix(int64(timestamp), 0))
+
+	feeRatesBytes, err := s.syncDB.GetValue(ctx, syncFeeRatesKey)
+	if err != nil {
+		return err
+	}
+	feeRates, err := fees.ParseDimensions(feeRatesBytes)
+	if err != nil {
+		return err
+	}
+	s.SetFeeRates(feeRates)
+	return nil
+}
+
+func (s *state) addSyncedCurrentStaker(value []byte, localTxIDs set.Set[ids.ID]) error {
+	syncStaker := syncStaker{}
+	if _, err := block.GenesisCodec.Unmarshal(value, &syncStaker); err != nil {
+		return err
+	}
+	tx, err := txs.Parse(txs.GenesisCodec, syncStaker.Tx)
+	if err != nil {
+		return err
+	}
+	txID := tx.ID()
+	if localTxIDs.Contains(txID) {
+		return nil
+	}
+
+	stakerTx, ok := tx.Unsigned.(txs.Staker)
+	if !ok {
+		return fmt.Errorf("%w: %T", errNotStakerTx, tx.Unsigned)
+	}
+	staker, err := NewCurrentStaker(
+		txID,
+		stakerTx,
+		time.Unix(int64(syncStaker.StartTime), 0),
+		syncStaker.PotentialReward,
+	)
+	if err != nil {
+		return err
+	}
+
+	if staker.Priority.IsValidator() {
+		s.PutCurrentValidator(staker)
+	} else {
+		s.PutCurrentDelegator(staker)
+	}
+	s.AddTx(tx, status.Committed)
+	return nil
+}
+
+func (s *state) addSyncedPendingStaker(value []byte, localTxIDs set.Set[ids.ID]) error {
+	tx, err := txs.Parse(txs.GenesisCodec, value)
+	if err != nil {
+		return err
+	}
+	txID := tx.ID()
+	if localTxIDs.Contains(txID) {
+		return nil
+	}
+
+	stakerTx, ok := tx.Unsigned.(txs.ScheduledStaker)
+	if !ok {
+		return fmt.Errorf("%w: %T", errNotStakerTx, tx.Unsigned)
+	}
+	staker, err := NewPendingStaker(txID, stakerTx)
+	if err != nil {
+		return err
+	}
+
+	if staker.Priority.IsValidator() {
+		s.PutPendingValidator(staker)
+	} else {
+		s.PutPendingDelegator(staker)
+	}
+	s.AddTx(tx, status.Committed)
+	return nil
+}
+
+func (s *state) setSyncedDelegateeRewards(ctx context.Context) error {
+	err := s.iterateSyncDB(ctx, syncCurrentValidatorPrefix, func(key, value []byte) error {
+		syncStaker := syncStaker{}
+		if _, err := block.GenesisCodec.Unmarshal(value, &syncStaker); err != nil {
+			return err
+		}
+
+		subnetID, err := ids.ToID(key[:ids.IDLen])
+		if err != nil {
+			return err
+		}
+		nodeID, err := ids.ToNodeID(key[ids.IDLen:])
+		if err != nil {
+			return err
+		}
+
+		reward, err := s.GetDelegateeReward(subnetID, nodeID)
+		if err != nil {
+			return err
+		}
+		if reward == syncStaker.DelegateeReward {
+			return nil
+		}
+		return s.SetDelegateeReward(subnetID, nodeID, syncStaker.DelegateeReward)
+	})
+	if err != nil {
+		return err
+	}
+	return s.write(true /*=updateValidators*/, s.currentHeight)
+}
+
+// addSyncedValidatorDiffs replaces the validator diffs written while applying
+// the synced state with the validator diffs in the trie.
+func (s *state) addSyncedValidatorDiffs(ctx context.Context, height uint64) error {
+	// The writes that applied the synced state recorded the change from the
+	// local state, rather than the changes made by the block at [height].
+	subnets, err := s.GetSubnets()
+	if err != nil {
+		return err
+	}
+	subnetIDs := []ids.ID{constants.PrimaryNetworkID}
+	for _, subnet := range subnets {
+		subnetIDs = append(subnetIDs, subnet.ID())
+	}
+	for _, subnetID := range subnetIDs {
+		if err := deleteDiffs(s.validatorWeightDiffsDB, subnetID, height); err != nil {
+			return err
+		}
+	}
+	if err := deleteDiffs(s.validatorPublicKeyDiffsDB, constants.PrimaryNetworkID, height); err != nil {
+		return err
+	}
+
+	err = s.iterateSyncDB(ctx, syncValidatorWeightDiffPrefix, func(key, value []byte) error {
+		height, err := database.ParseUInt64(key[:database.Uint64Size])
+		if err != nil {
+			return err
+		}
+		subnetID, err := ids.ToID(key[database.Uint64Size : database.Uint64Size+ids.IDLen])
+		if err != nil {
+			return err
+		}
+		nodeID, err := ids.ToNodeID(key[database.Uint64Size+ids.IDLen:])
+		if err != nil {
+			return err
+		}
+		return s.validatorWeightDiffsDB.Put(marshalDiffKey(subnetID, height, nodeID), value)
+	})
+	if err != nil {
+		return err
+	}
+	return s.iterateSyncDB(ctx, syncValidatorPublicKeyDiffPrefix, func(key, value []byte) error {
+		height, err := database.ParseUInt64(key[:database.Uint64Size])
+		if err != nil {
+			return err
+		}
+		nodeID, err := ids.ToNodeID(key[database.Uint64Size:])
+		if err != nil {
+			return err
+		}
+		return s.validatorPublicKeyDiffsDB.Put(marshalDiffKey(constants.PrimaryNetworkID, height, nodeID), value)
+	})
+}
+
+// deleteDiffs removes the diffs of [subnetID] at [height] from [db].
+func deleteDiffs(db database.Database, subnetID ids.ID, height uint64) error {
+	it := db.NewIteratorWithPrefix(marshalStartDiffKey(subnetID, height))
+	defer it.Release()
+
+	for it.Next() {
+		if err := db.Delete(it.Key()); err != nil {
+			return err
+		}
+	}
+	return it.Error()
+}
+
+// iterateSyncDB calls [f] with every key in the trie that starts with
+// [prefix]. The prefix is removed from the provided keys.
+func (s *state) iterateSyncDB(ctx context.Context, prefix byte, f func(key, value []byte) error) error {
+	it := s.syncDB.NewIteratorWithPrefix([]byte{prefix})
+	defer it.Release()
+
+	for it.Next() {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if err := f(it.Key()[1:], it.Value()); err != nil {
+			return err
+		}
+	}
+	return it.Error()
+}
+
+// writeSyncDB applies the pending modifications of the state to the trie.
+//
+// Must be called before any modifications are written.
+func (s *state) writeSyncDB(height uint64) error {
+	if s.syncDB == nil {
+		return nil
+	}
+	if s.syncing || s.syncStale {
+		clear(s.modifiedDelegateeRewards)
+		return nil
+	}
+
+	ctx := context.TODO()
+	ops, err := s.syncDBOps(height)
+	if err != nil {
+		return err
+	}
+	view, err := s.syncDB.NewView(ctx, merkledb.ViewChanges{
+		BatchOps:     ops,
+		ConsumeBytes: true,
+	})
+	if err != nil {
+		return err
+	}
+	newRoot, err := view.GetMerkleRoot(ctx)
+	if err != nil {
+		return err
+	}
+	oldRoot, err := s.syncDB.GetMerkleRoot(ctx)
+	if err != nil {
+		return err
+	}
+	if newRoot != oldRoot {
+		// The trie is marked as stale until both the changes and the block
+		// they were made in are written.
+		if err := s.syncMetadataDB.Delete(stateSyncLastAcceptedKey); err != nil {
+			return err
+		}
+		s.syncLastAccepted = ids.Empty
+		if err := view.CommitToDB(ctx); err != nil {
+			return err
+		}
+	}
+	if err := s.putSyncLastAccepted(); err != nil {
+		return err
+	}
+
+	blk, ok := s.addedBlocks[s.lastAccepted]
+	if !ok {
+		return nil
+	}
+	if height == 0 || height%StateSummaryFrequency != 0 {
+		return nil
+	}
+
+	summary, err := NewStateSummary(blk, newRoot)
+	if err != nil {
+		return err
+	}
+	if err := s.syncSummaryDB.Put(database.PackUInt64(height), summary.Bytes()); err != nil {
+		return err
+	}
+	if height < numServedSummaries*StateSummaryFrequency {
+		return nil
+	}
+	return s.syncSummaryDB.Delete(database.PackUInt64(height - numServedSummaries*StateSummaryFrequency))
+}
+
+func (s *state) putSyncLastAccepted() error {
+	if s.syncLastAccepted == s.lastAccepted {
+		return nil
+	}
+	if err := database.PutID(s.syncMetadataDB, stateSyncLastAcceptedKey, s.lastAccepted); err != nil {
+		return err
+	}
+	s.syncLastAccepted = s.lastAccepted
+	return nil
+}
+
+func (s *state) syncDBOps(height uint64) ([]database.BatchOp, error) {
+	var ops []database.BatchOp
+	put := func(key, value []byte) {
+		ops = append(ops, database.BatchOp{
+			Key:   key,
+			Value: value,
+		})
+	}
+	del := func(key []byte) {
+		ops = append(ops, database.BatchOp{
+			Key:    key,
+			Delete: true,
+		})
+	}
+
+	// Delegatee rewards are updated before the validator diffs are applied so
+	// that removed validators are not re-added.
+	for subnetID, nodeIDs := range s.modifiedDelegateeRewards {
+		delete(s.modifiedDelegateeRewards, subnetID)
+		for nodeID := range nodeIDs {
+			staker, err := s.currentStakers.GetValidator(subnetID, nodeID)
+			if err == database.ErrNotFound {
+				continue
+			}
+			if err != nil {
+				return nil, err
+			}
+			value, err := s.syncCurrentStakerValue(staker)
+			if err != nil {
+				return nil, err
+			}
+			put(syncStakerKey(staker), value)
+		}
+	}
+
+	for subnetID, validatorDiffs := range s.currentStakers.validatorDiffs {
+		for nodeID, validatorDiff := range validatorDiffs {
+			weightDiff, err := syncWeightDiff(validatorDiff)
+			if err != nil {
+				return nil, err
+			}
+			if weightDiff.Amount != 0 {
+				put(syncWeightDiffKey(height, subnetID, nodeID), marshalWeightDiff(weightDiff))
+			}
+
+			switch validatorDiff.validatorStatus {
+			case added:
+				if validatorDiff.validator.PublicKey != nil {
+					put(syncPublicKeyDiffKey(height, nodeID), nil)
+				}
+
+				value, err := s.syncCurrentStakerValue(validatorDiff.validator)
+				if err != nil {
+					return nil, err
+				}
+				put(syncStakerKey(validatorDiff.validator), value)
+			case deleted:
+				if pk := validatorDiff.validator.PublicKey; pk != nil {
+					put(syncPublicKeyDiffKey(height, nodeID), bls.PublicKeyToUncompressedBytes(pk))
+				}
+
+				del(syncStakerKey(validatorDiff.validator))
+			}
+
+			addedDelegatorIterator := NewTreeIterator(validatorDiff.addedDelegators)
+			for addedDelegatorIterator.Next() {
+				staker := addedDelegatorIterator.Value()
+				value, err := s.syncCurrentStakerValue(staker)
+				if err != nil {
+					addedDelegatorIterator.Release()
+					return nil, err
+				}
+				put(syncStakerKey(staker), value)
+			}
+			addedDelegatorIterator.Release()
+
+			for _, staker := range validatorDiff.deletedDelegators {
+				del(syncStakerKey(staker))
+			}
+		}
+	}
+
+	// Diffs are ordered by height, so the diffs that left the window are the
+	// first keys of their prefix.
+	for _, prefix := range []byte{syncValidatorWeightDiffPrefix, syncValidatorPublicKeyDiffPrefix} {
+		it := s.syncDB.NewIteratorWithPrefix([]byte{prefix})
+		for it.Next() {
+			diffHeight, err := database.ParseUInt64(it.Key()[1 : 1+database.Uint64Size])
+			if err != nil {
+				it.Release()
+				return nil, err
+			}
+			if diffHeight+stateSyncValidatorDiffsLength > height {
+				break
+			}
+			del(it.Key())
+		}
+		err := it.Error()
+		it.Release()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	for _, validatorDiffs := range s.pendingStakers.validatorDiffs {
+		for _, validatorDiff := range validatorDiffs {
+			switch validatorDiff.validatorStatus {
+			case added:
+				tx, _, err := s.GetTx(validatorDiff.validator.TxID)
+				if err != nil {
+					return nil, err
+				}
+				put(syncStakerKey(validatorDiff.validator), tx.Bytes())
+			case deleted:
+				del(syncStakerKey(validatorDiff.validator))
+			}
+
+			addedDelegatorIterator := NewTreeIterator(validatorDiff.addedDelegators)
+			for addedDelegatorIterator.Next() {
+				staker := addedDelegatorIterator.Value()
+				tx, _, err := s.GetTx(staker.TxID)
+				if err != nil {
+					addedDelegatorIterator.Release()
+					return nil, err
+				}
+				put(syncStakerKey(staker), tx.Bytes())
+			}
+			addedDelegatorIterator.Release()
+
+			for _, staker := range validatorDiff.deletedDelegators {
+				del(syncStakerKey(staker))
+			}
+		}
+	}
+
+	for utxoID, utxo := range s.modifiedUTXOs {
+		key := prefixedKey(syncUTXOPrefix, utxoID[:])
+		if utxo == nil {
+			del(key)
+			continue
+		}
+		utxoBytes, err := txs.GenesisCodec.Marshal(txs.CodecVersion, utxo)
+		if err != nil {
+			return nil, err
+		}
+		put(key, utxoBytes)
+	}
+
+	for _, subnet := range s.addedSubnets {
+		subnetID := subnet.ID()
+		put(prefixedKey(syncSubnetPrefix, subnetID[:]), subnet.Bytes())
+
+		// The owner defined in the subnet tx is the owner until it is
+		// explicitly changed.
+		createSubnetTx, ok := subnet.Unsigned.(*txs.CreateSubnetTx)
+		if !ok {
+			return nil, fmt.Errorf("%q %w", subnetID, errIsNotSubnet)
+		}
+		if _, ok := s.subnetOwners[subnetID]; ok {
+			continue
+		}
+		ownerBytes, err := block.GenesisCodec.Marshal(block.CodecVersion, &createSubnetTx.Owner)
+		if err != nil {
+			return nil, err
+		}
+		put(prefixedKey(syncSubnetOwnerPrefix, subnetID[:]), ownerBytes)
+	}
+
+	for subnetID, owner := range s.subnetOwners {
+		subnetID := subnetID
+		owner := owner
+		ownerBytes, err := block.GenesisCodec.Marshal(block.CodecVersion, &owner)
+		if err != nil {
+			return nil, err
+		}
+		put(prefixedKey(syncSubnetOwnerPrefix, subnetID[:]), ownerBytes)
+	}
+
+	for subnetID, tx := range s.transformedSubnets {
+		subnetID := subnetID
+		put(prefixedKey(syncTransformedSubnetPrefix, subnetID[:]), tx.Bytes())
+	}
+
+	for subnetID, supply := range s.modifiedSupplies {
+		subnetID := subnetID
+		put(prefixedKey(syncSupplyPrefix, subnetID[:]), database.PackUInt64(supply))
+	}
+	put(
+		prefixedKey(syncSupplyPrefix, constants.PrimaryNetworkID[:]),
+		database.PackUInt64(s.currentSupply),
+	)
+
+	for _, chains := range s.addedChains {
+		for _, chain := range chains {
+			chainID := chain.ID()
+			put(prefixedKey(syncChainPrefix, chainID[:]), chain.Bytes())
+		}
+	}
+
+	put(syncTimestampKey, database.PackUInt64(uint64(s.timestamp.Unix())))
+	put(syncFeeRatesKey, s.feeRates.Bytes())
+	return ops, nil
+}
+
+// loadSyncDB rebuilds the trie if it doesn't reflect the last accepted state.
+func (s *state) loadSyncDB() error {
+	if s.syncDB == nil {
+		return nil
+	}
+
+	stateSyncHeight, err := database.GetUInt64(s.singletonDB, StateSyncHeightKey)
+	switch err {
+	case nil:
+		s.stateSyncHeight = stateSyncHeight
+	case database.ErrNotFound:
+	default:
+		return err
+	}
+
+	_, err = s.syncMetadataDB.Get(stateSyncOngoingSummaryKey)
+	switch {
+	case err == nil && s.stateSyncEnabled:
+		s.syncing = true
+		return nil
+	case err == nil:
+		// State sync was disabled while a state sync was ongoing.
+		if err := s.AbortStateSync(); err != nil {
+			return err
+		}
+		return s.rebuildSyncDB(context.TODO())
+	case err != database.ErrNotFound:
+		return err
+	}
+
+	syncLastAccepted, err := database.GetID(s.syncMetadataDB, stateSyncLastAcceptedKey)
+	if err != nil && err != database.ErrNotFound {
+		return err
+	}
+	if err == nil && syncLastAccepted == s.lastAccepted {
+		s.syncLastAccepted = syncLastAccepted
+		return nil
+	}
+	return s.rebuildSyncDB(context.TODO())
+}
+
+// rebuildSyncDB replaces the contents of the trie with the local state.
+func (s *state) rebuildSyncDB(ctx context.Context) error {
+	s.ctx.Log.Info("rebuilding state sync trie")
+	startTime := time.Now()
+
+	if err := s.syncMetadataDB.Delete(stateSyncLastAcceptedKey); err != nil {
+		return err
+	}
+	s.syncLastAccepted = ids.Empty
+	if err := s.syncDB.Clear(); err != nil {
+		return err
+	}
+
+	var ops []database.BatchOp
+	flush := func() error {
+		view, err := s.syncDB.NewView(ctx, merkledb.ViewChanges{
+			BatchOps:     ops,
+			ConsumeBytes: true,
+		})
+		if err != nil {
+			return err
+		}
+		ops = nil
+		return view.CommitToDB(ctx)
+	}
+	put := func(key, value []byte) error {
+		ops = append(ops, database.BatchOp{
+			Key:   key,
+			Value: value,
+		})
+		if len(ops) < stateSyncRebuildBatchSize {
+			return nil
+		}
+		return flush()
+	}
+
+	for _, stakers := range []*baseStakers{s.currentStakers, s.pendingStakers} {
+		it := stakers.GetStakerIterator()
+		for it.Next() {
+			staker := it.Value()
+
+			var value []byte
+			if staker.Priority.IsCurrent() {
+				var err error
+				value, err = s.syncCurrentStakerValue(staker)
+				if err != nil {
+					it.Release()
+					return err
+				}
+			} else {
+				tx, _, err := s.GetTx(staker.TxID)
+				if err != nil {
+					it.Release()
+					return err
+				}
+				value = tx.Bytes()
+			}
+			if err := put(syncStakerKey(staker), value); err != nil {
+				it.Release()
+				return err
+			}
+		}
+		it.Release()
+	}
+
+	utxoIt := s.utxoState.NewUTXOIterator()
+	defer utxoIt.Release()
+	for utxoIt.Next() {
+		if err := put(prefixedKey(syncUTXOPrefix, utxoIt.Key()), utxoIt.Value()); err != nil {
+			return err
+		}
+	}
+	if err := utxoIt.Error(); err != nil {
+		return err
+	}
+
+	subnets, err := s.GetSubnets()
+	if err != nil {
+		return err
+	}
+	subnetIDs := []ids.ID{constants.PrimaryNetworkID}
+	for _, subnet := range subnets {
+		subnetID := subnet.ID()
+		subnetIDs = append(subnetIDs, subnetID)
+		if err := put(prefixedKey(syncSubnetPrefix, subnetID[:]), subnet.Bytes()); err != nil {
+			return err
+		}
+
+		owner, err := s.GetSubnetOwner(subnetID)
+		if err != nil {
+			return err
+		}
+		ownerBytes, err := block.GenesisCodec.Marshal(block.CodecVersion, &owner)
+		if err != nil {
+			return err
+		}
+		if err := put(prefixedKey(syncSubnetOwnerPrefix, subnetID[:]), ownerBytes); err != nil {
+			return err
+		}
+
+		transformSubnetTx, err := s.GetSubnetTransformation(subnetID)
+		switch err {
+		case nil:
+			if err := put(prefixedKey(syncTransformedSubnetPrefix, subnetID[:]), transformSubnetTx.Bytes()); err != nil {
+				return err
+			}
+		case database.ErrNotFound:
+		default:
+			return err
+		}
+	}
+
+	for _, subnetID := range subnetIDs {
+		supply, err := s.GetCurrentSupply(subnetID)
+		switch err {
+		case nil:
+			if err := put(prefixedKey(syncSupplyPrefix, subnetID[:]), database.PackUInt64(supply)); err != nil {
+				return err
+			}
+		case database.ErrNotFound:
+		default:
+			return err
+		}
+
+		chains, err := s.GetChains(subnetID)
+		if err != nil {
+			return err
+		}
+		for _, chain := range chains {
+			chainID := chain.ID()
+			if err := put(prefixedKey(syncChainPrefix, chainID[:]), chain.Bytes()); err != nil {
+				return err
+			}
+		}
+	}
+
+	if err := put(syncTimestampKey, database.PackUInt64(uint64(s.timestamp.Unix()))); err != nil {
+		return err
+	}
+	if err := put(syncFeeRatesKey, s.feeRates.Bytes()); err != nil {
+		return err
+	}
+
+	for _, subnetID := range subnetIDs {
+		err := s.iterateValidatorDiffs(s.validatorWeightDiffsDB, subnetID, func(height uint64, nodeID ids.NodeID, value []byte) error {
+			return put(syncWeightDiffKey(height, subnetID, nodeID), value)
+		})
+		if err != nil {
+			return err
+		}
+	}
+	err = s.iterateValidatorDiffs(s.validatorPublicKeyDiffsDB, constants.PrimaryNetworkID, func(height uint64, nodeID ids.NodeID, value []byte) error {
+		return put(syncPublicKeyDiffKey(height, nodeID), value)
+	})
+	if err != nil {
+		return err
+	}
+	if err := flush(); err != nil {
+		return err
+	}
+
+	root, err := s.syncDB.GetMerkleRoot(ctx)
+	if err != nil {
+		return err
+	}
+	s.ctx.Log.Info("rebuilt state sync trie",
+		zap.Stringer("root", root),
+		zap.Duration("duration", time.Since(startTime)),
+	)
+	s.syncStale = false
+	return s.putSyncLastAccepted()
+}
+
+// iterateValidatorDiffs calls [f] with the diffs of [subnetID] in [db] that
+// are held in the trie.
+func (s *state) iterateValidatorDiffs(
+	db database.Database,
+	subnetID ids.ID,
+	f func(height uint64, nodeID ids.NodeID, value []byte) error,
+) error {
+	it := db.NewIteratorWithStartAndPrefix(
+		marshalStartDiffKey(subnetID, s.currentHeight),
+		subnetID[:],
+	)
+	defer it.Release()
+
+	for it.Next() {
+		_, height, nodeID, err := unmarshalDiffKey(it.Key())
+		if err != nil {
+			return err
+		}
+		if height+stateSyncValidatorDiffsLength <= s.currentHeight {
+			break
+		}
+		if err := f(height, nodeID, it.Value()); err != nil {
+			return err
+		}
+	}
+	return it.Error()
+}
+
+// syncWeightDiff returns the change in weight of the validator, matching the
+// diff recorded in [writeCurrentStakers].
+func syncWeightDiff(validatorDiff *diffValidator) (*ValidatorWeightDiff, error) {
+	weightDiff := &ValidatorWeightDiff{
+		Decrease: validatorDiff.validatorStatus == deleted,
+	}
+	if validatorDiff.validatorStatus != unmodified {
+		weightDiff.Amount = validatorDiff.validator.Weight
+	}
+
+	addedDelegatorIterator := NewTreeIterator(validatorDiff.addedDelegators)
+	defer addedDelegatorIterator.Release()
+	for addedDelegatorIterator.Next() {
+		staker := addedDelegatorIterator.Value()
+		if err := weightDiff.Add(false, staker.Weight); err != nil {
+			return nil, err
+		}
+	}
+	for _, staker := range validatorDiff.deletedDelegators {
+		if err := weightDiff.Add(true, staker.Weight); err != nil {
+			return nil, err
+		}
+	}
+	return weightDiff, nil
+}
+
+func syncWeightDiffKey(height uint64, subnetID ids.ID, nodeID ids.NodeID) []byte {
+	return prefixedKey(syncValidatorWeightDiffPrefix, database.PackUInt64(height), subnetID[:], nodeID.Bytes())
+}
+
+func syncPublicKeyDiffKey(height uint64, nodeID ids.NodeID) []byte {
+	return prefixedKey(syncValidatorPublicKeyDiffPrefix, database.PackUInt64(height), nodeID.Bytes())
+}
+
+func (s *state) syncCurrentStakerValue(staker *Staker) ([]byte, error) {
+	tx, _, err := s.GetTx(staker.TxID)
+	if err != nil {
+		return nil, err
+	}
+
+	value := syncStaker{
+		Tx:              tx.Bytes(),
+		StartTime:       uint64(staker.StartTime.Unix()),
+		PotentialReward: staker.PotentialReward,
+	}
+	if staker.Priority.IsValidator() {
+		// Validators that were just added don't have any delegatee reward.
+		reward, err := s.validatorState.GetDelegateeReward(staker.SubnetID, staker.NodeID)
+		switch err {
+		case nil:
+			value.DelegateeReward = reward
+		case database.ErrNotFound:
+		default:
+			return nil, err
+		}
+	}
+	return block.GenesisCodec.Marshal(block.CodecVersion, &value)
+}
+
+// syncStakerKey returns the key of [staker] in the trie. Validators are keyed
+// by their subnetID and nodeID, and delegators by their txID.
+func syncStakerKey(staker *Staker) []byte {
+	switch {
+	case staker.Priority.IsCurrentValidator():
+		return prefixedKey(syncCurrentValidatorPrefix, staker.SubnetID[:], staker.NodeID.Bytes())
+	case staker.Priority.IsCurrentDelegator():
+		return prefixedKey(syncCurrentDelegatorPrefix, staker.TxID[:])
+	case staker.Priority.IsPendingValidator():
+		return prefixedKey(syncPendingValidatorPrefix, staker.SubnetID[:], staker.NodeID.Bytes())
+	default:
+		return prefixedKey(syncPendingDelegatorPrefix, staker.TxID[:])
+	}
+}
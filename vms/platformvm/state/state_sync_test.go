@@ -0,0 +1,381 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package state
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/database/memdb"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow/validators"
+	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/ava-labs/avalanchego/vms/components/avax"
+	"github.com/ava-labs/avalanchego/vms/platformvm/config"
+	"github.com/ava-labs/avalanchego/vms/platformvm/status"
+	"github.com/ava-labs/avalanchego/vms/platformvm/txs"
+	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
+	"github.com/ava-labs/avalanchego/x/merkledb"
+)
+
+// newStateSyncTestState returns an initialized state with its validator sets
+// loaded.
+func newStateSyncTestState(require *require.Assertions) *state {
+	return newStateSyncTestStateFromDB(require, memdb.New())
+}
+
+func newStateSyncTestStateFromDB(require *require.Assertions, db database.Database) *state {
+	s := newStateFromDBWithConfig(require, db, newStateSyncTestConfig(require))
+	initializeState(require, s)
+	require.NoError(s.Commit())
+	require.NoError(s.load())
+	return s
+}
+
+func newStateSyncTestConfig(require *require.Assertions) *config.ExecutionConfig {
+	execCfg, err := config.GetExecutionConfig(nil)
+	require.NoError(err)
+	execCfg.StateSyncServingEnabled = true
+	return execCfg
+}
+
+// modifyStateSyncTestState modifies every kind of state included in the trie
+// and returns the nodeID of the added validator.
+func modifyStateSyncTestState(require *require.Assertions, s *state) ids.NodeID {
+	subnetTx := &txs.Tx{Unsigned: &txs.CreateSubnetTx{
+		Owner: &secp256k1fx.OutputOwners{},
+	}}
+	require.NoError(subnetTx.Initialize(txs.Codec))
+	s.AddSubnet(subnetTx)
+	s.AddTx(subnetTx, status.Committed)
+	s.SetCurrentSupply(subnetTx.ID(), 10)
+
+	chainTx := &txs.Tx{Unsigned: &txs.CreateChainTx{
+		SubnetID:   subnetTx.ID(),
+		ChainName:  "y",
+		VMID:       constants.AVMID,
+		SubnetAuth: &secp256k1fx.Input{},
+	}}
+	require.NoError(chainTx.Initialize(txs.Codec))
+	s.AddChain(chainTx)
+	s.AddTx(chainTx, status.Committed)
+
+	addr := ids.GenerateTestShortID()
+	s.SetSubnetOwner(subnetTx.ID(), &secp256k1fx.OutputOwners{
+		Threshold: 1,
+		Addrs:     []ids.ShortID{addr},
+	})
+	s.AddUTXO(newTestUTXO(addr, 5))
+	genesisUTXOID := avax.UTXOID{TxID: initialTxID}
+	s.DeleteUTXO(genesisUTXOID.InputID())
+
+	genesisValidator, err := s.GetCurrentValidator(constants.PrimaryNetworkID, initialNodeID)
+	require.NoError(err)
+	s.DeleteCurrentValidator(genesisValidator)
+
+	utx := createPermissionlessValidatorTx(require, constants.PrimaryNetworkID, txs.Validator{
+		NodeID: ids.GenerateTestNodeID(),
+		Start:  uint64(initialTime.Unix()),
+		End:    uint64(initialValidatorEndTime.Unix()),
+		Wght:   1234,
+	})
+	validatorTx := &txs.Tx{Unsigned: utx}
+	require.NoError(validatorTx.Initialize(txs.Codec))
+	staker, err := NewCurrentStaker(validatorTx.ID(), utx, initialTime, 7)
+	require.NoError(err)
+	s.PutCurrentValidator(staker)
+	s.AddTx(validatorTx, status.Committed)
+
+	s.SetTimestamp(initialTime.Add(time.Hour))
+	return utx.NodeID()
+}
+
+func TestStateSyncRebuild(t *testing.T) {
+	require := require.New(t)
+	ctx := context.Background()
+
+	s := newStateSyncTestState(require)
+	nodeID := modifyStateSyncTestState(require, s)
+	acceptTestBlock(require, s, 1)
+	require.NoError(s.SetDelegateeReward(constants.PrimaryNetworkID, nodeID, 3))
+	acceptTestBlock(require, s, 2)
+
+	expectedRoot, err := s.syncDB.GetMerkleRoot(ctx)
+	require.NoError(err)
+
+	// The trie is updated incrementally as blocks are accepted, so it should
+	// match a trie built from scratch.
+	require.NoError(s.rebuildSyncDB(ctx))
+	root, err := s.syncDB.GetMerkleRoot(ctx)
+	require.NoError(err)
+	require.Equal(expectedRoot, root)
+}
+
+func TestStateSummaries(t *testing.T) {
+	require := require.New(t)
+	ctx := context.Background()
+
+	s := newStateSyncTestState(require)
+	_, err := s.GetLastStateSummary(ctx)
+	require.ErrorIs(err, database.ErrNotFound)
+
+	acceptTestBlock(require, s, StateSummaryFrequency-1)
+	_, err = s.GetLastStateSummary(ctx)
+	require.ErrorIs(err, database.ErrNotFound)
+
+	acceptTestBlock(require, s, StateSummaryFrequency)
+	root, err := s.syncDB.GetMerkleRoot(ctx)
+	require.NoError(err)
+
+	summary, err := s.GetLastStateSummary(ctx)
+	require.NoError(err)
+	require.Equal(uint64(StateSummaryFrequency), summary.Height())
+	require.Equal(s.GetLastAccepted(), summary.Block().ID())
+	require.Equal(root, summary.Root)
+
+	parsedSummary, err := ParseStateSummary(summary.Bytes())
+	require.NoError(err)
+	require.Equal(summary.ID(), parsedSummary.ID())
+
+	summary, err = s.GetStateSummary(ctx, StateSummaryFrequency)
+	require.NoError(err)
+	require.Equal(parsedSummary.ID(), summary.ID())
+
+	_, err = s.GetStateSummary(ctx, StateSummaryFrequency-1)
+	require.ErrorIs(err, database.ErrNotFound)
+}
+
+func TestStateSummariesServingDisabled(t *testing.T) {
+	require := require.New(t)
+
+	execCfg, err := config.GetExecutionConfig(nil)
+	require.NoError(err)
+	execCfg.StateSyncServingEnabled = false
+	s := newStateFromDBWithConfig(require, memdb.New(), execCfg)
+	initializeState(require, s)
+
+	require.Nil(s.SyncDB())
+	_, err = s.GetLastStateSummary(context.Background())
+	require.ErrorIs(err, database.ErrNotFound)
+	require.ErrorIs(s.StartStateSync(&StateSummary{}), ErrStateSyncServingDisabled)
+}
+
+func TestFinishStateSync(t *testing.T) {
+	require := require.New(t)
+	ctx := context.Background()
+
+	server := newStateSyncTestState(require)
+	nodeID := modifyStateSyncTestState(require, server)
+	acceptTestBlock(require, server, 1)
+	require.NoError(server.SetDelegateeReward(constants.PrimaryNetworkID, nodeID, 3))
+	acceptTestBlock(require, server, StateSummaryFrequency)
+
+	summary, err := server.GetLastStateSummary(ctx)
+	require.NoError(err)
+
+	client := newStateSyncTestState(require)
+	_, err = client.GetOngoingStateSummary()
+	require.ErrorIs(err, database.ErrNotFound)
+	require.ErrorIs(client.FinishStateSync(ctx), errNoOngoingStateSync)
+
+	require.NoError(client.StartStateSync(summary))
+	ongoingSummary, err := client.GetOngoingStateSummary()
+	require.NoError(err)
+	require.Equal(summary.ID(), ongoingSummary.ID())
+
+	// The trie can't be finished before it is synced.
+	require.ErrorIs(client.FinishStateSync(ctx), errStateSyncRoot)
+
+	copySyncDB(require, server.syncDB, client.syncDB)
+	require.NoError(client.FinishStateSync(ctx))
+
+	_, err = client.GetOngoingStateSummary()
+	require.ErrorIs(err, database.ErrNotFound)
+	require.Equal(summary.Block().ID(), client.GetLastAccepted())
+	require.Equal(server.GetTimestamp(), client.GetTimestamp())
+
+	_, err = client.GetCurrentValidator(constants.PrimaryNetworkID, initialNodeID)
+	require.ErrorIs(err, database.ErrNotFound)
+	_, err = client.GetCurrentValidator(constants.PrimaryNetworkID, nodeID)
+	require.NoError(err)
+	reward, err := client.GetDelegateeReward(constants.PrimaryNetworkID, nodeID)
+	require.NoError(err)
+	require.Equal(uint64(3), reward)
+	require.Equal(
+		server.cfg.Validators.GetMap(constants.PrimaryNetworkID),
+		client.cfg.Validators.GetMap(constants.PrimaryNetworkID),
+	)
+
+	genesisUTXOID := avax.UTXOID{TxID: initialTxID}
+	_, err = client.GetUTXO(genesisUTXOID.InputID())
+	require.ErrorIs(err, database.ErrNotFound)
+
+	subnets, err := client.GetSubnets()
+	require.NoError(err)
+	require.Len(subnets, 1)
+	chains, err := client.GetChains(subnets[0].ID())
+	require.NoError(err)
+	require.Len(chains, 1)
+
+	// The validator diffs of recent blocks are synced.
+	genesisValidators := validatorSetAtGenesis(require, client)
+	require.Contains(genesisValidators, initialNodeID)
+	require.NotContains(genesisValidators, nodeID)
+	require.Equal(validatorSetAtGenesis(require, server), genesisValidators)
+
+	// The local state must produce the synced trie.
+	require.NoError(client.rebuildSyncDB(ctx))
+	root, err := client.syncDB.GetMerkleRoot(ctx)
+	require.NoError(err)
+	require.Equal(summary.Root, root)
+
+	summary, err = client.GetLastStateSummary(ctx)
+	require.NoError(err)
+	require.Equal(uint64(StateSummaryFrequency), summary.Height())
+}
+
+func TestStateSyncValidatorDiffsPruned(t *testing.T) {
+	require := require.New(t)
+	ctx := context.Background()
+
+	server := newStateSyncTestState(require)
+	modifyStateSyncTestState(require, server)
+	acceptTestBlock(require, server, 1)
+	acceptTestBlock(require, server, stateSyncValidatorDiffsLength+1)
+	acceptTestBlock(require, server, 2*StateSummaryFrequency)
+
+	// Diffs that left the window are removed from the trie.
+	expectedRoot, err := server.syncDB.GetMerkleRoot(ctx)
+	require.NoError(err)
+	require.NoError(server.rebuildSyncDB(ctx))
+	root, err := server.syncDB.GetMerkleRoot(ctx)
+	require.NoError(err)
+	require.Equal(expectedRoot, root)
+
+	summary, err := server.GetLastStateSummary(ctx)
+	require.NoError(err)
+
+	client := newStateSyncTestState(require)
+	require.NoError(client.StartStateSync(summary))
+	copySyncDB(require, server.syncDB, client.syncDB)
+	require.NoError(client.FinishStateSync(ctx))
+
+	err = client.ApplyValidatorWeightDiffs(
+		ctx,
+		map[ids.NodeID]*validators.GetValidatorOutput{},
+		2*StateSummaryFrequency,
+		2*StateSummaryFrequency-stateSyncValidatorDiffsLength,
+		constants.PrimaryNetworkID,
+	)
+	require.ErrorIs(err, ErrMissingValidatorDiffs)
+	err = client.ApplyValidatorPublicKeyDiffs(
+		ctx,
+		map[ids.NodeID]*validators.GetValidatorOutput{},
+		2*StateSummaryFrequency,
+		2*StateSummaryFrequency-stateSyncValidatorDiffsLength,
+	)
+	require.ErrorIs(err, ErrMissingValidatorDiffs)
+
+	require.NoError(client.ApplyValidatorWeightDiffs(
+		ctx,
+		map[ids.NodeID]*validators.GetValidatorOutput{},
+		2*StateSummaryFrequency,
+		2*StateSummaryFrequency-stateSyncValidatorDiffsLength+1,
+		constants.PrimaryNetworkID,
+	))
+}
+
+// validatorSetAtGenesis returns the primary network validator set of [s]
+// prior to the first block.
+func validatorSetAtGenesis(require *require.Assertions, s *state) map[ids.NodeID]*validators.GetValidatorOutput {
+	vdrs := s.cfg.Validators.GetMap(constants.PrimaryNetworkID)
+	require.NoError(s.ApplyValidatorWeightDiffs(
+		context.Background(),
+		vdrs,
+		s.currentHeight,
+		1,
+		constants.PrimaryNetworkID,
+	))
+	require.NoError(s.ApplyValidatorPublicKeyDiffs(
+		context.Background(),
+		vdrs,
+		s.currentHeight,
+		1,
+	))
+	return vdrs
+}
+
+func TestAbortStateSync(t *testing.T) {
+	require := require.New(t)
+	ctx := context.Background()
+
+	db := memdb.New()
+	s := newStateSyncTestStateFromDB(require, db)
+	expectedRoot, err := s.syncDB.GetMerkleRoot(ctx)
+	require.NoError(err)
+
+	blk, err := s.GetStatelessBlock(s.GetLastAccepted())
+	require.NoError(err)
+	summary, err := NewStateSummary(blk, ids.GenerateTestID())
+	require.NoError(err)
+	require.NoError(s.StartStateSync(summary))
+
+	// Partially sync the trie.
+	server := newStateSyncTestState(require)
+	modifyStateSyncTestState(require, server)
+	acceptTestBlock(require, server, 1)
+	copySyncDB(require, server.syncDB, s.syncDB)
+
+	require.NoError(s.AbortStateSync())
+	_, err = s.GetOngoingStateSummary()
+	require.ErrorIs(err, database.ErrNotFound)
+
+	// The trie isn't served until it is rebuilt.
+	_, err = s.GetLastStateSummary(ctx)
+	require.ErrorIs(err, database.ErrNotFound)
+
+	// The trie is rebuilt once the node restarts.
+	require.NoError(s.Commit())
+	s = newStateFromDBWithConfig(require, db, newStateSyncTestConfig(require))
+	require.NoError(s.load())
+	root, err := s.syncDB.GetMerkleRoot(ctx)
+	require.NoError(err)
+	require.Equal(expectedRoot, root)
+}
+
+// copySyncDB replaces the key/values of [to] with those of [from], as a
+// completed sync would.
+func copySyncDB(require *require.Assertions, from merkledb.MerkleDB, to merkledb.MerkleDB) {
+	var ops []database.BatchOp
+	it := to.NewIterator()
+	for it.Next() {
+		ops = append(ops, database.BatchOp{
+			Key:    it.Key(),
+			Delete: true,
+		})
+	}
+	require.NoError(it.Error())
+	it.Release()
+
+	it = from.NewIterator()
+	for it.Next() {
+		ops = append(ops, database.BatchOp{
+			Key:   it.Key(),
+			Value: it.Value(),
+		})
+	}
+	require.NoError(it.Error())
+	it.Release()
+
+	view, err := to.NewView(context.Background(), merkledb.ViewChanges{
+		BatchOps:     ops,
+		ConsumeBytes: true,
+	})
+	require.NoError(err)
+	require.NoError(view.CommitToDB(context.Background()))
+}
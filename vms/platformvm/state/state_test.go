@@ -22,9 +22,11 @@ import (
 	"github.com/ava-labs/avalanchego/snow/validators"
 	"github.com/ava-labs/avalanchego/utils/constants"
 	"github.com/ava-labs/avalanchego/utils/crypto/bls"
+	"github.com/ava-labs/avalanchego/utils/logging"
 	"github.com/ava-labs/avalanchego/utils/units"
 	"github.com/ava-labs/avalanchego/utils/wrappers"
 	"github.com/ava-labs/avalanchego/vms/components/avax"
+	"github.com/ava-labs/avalanchego/vms/components/fees"
 	"github.com/ava-labs/avalanchego/vms/platformvm/block"
 	"github.com/ava-labs/avalanchego/vms/platformvm/config"
 	"github.com/ava-labs/avalanchego/vms/platformvm/fx"
@@ -701,7 +703,11 @@ func TestPersistStakers(t *testing.T) {
 
 func newInitializedState(require *require.Assertions) State {
 	s, _ := newUninitializedState(require)
+	initializeState(require, s)
+	return s
+}
 
+func initializeState(require *require.Assertions, s *state) {
 	initialValidator := &txs.AddValidatorTx{
 		Validator: txs.Validator{
 			NodeID: initialNodeID,
@@ -762,8 +768,6 @@ func newInitializedState(require *require.Assertions) State {
 	genesisBlk, err := block.NewApricotCommitBlock(genesisBlkID, 0)
 	require.NoError(err)
 	require.NoError(s.syncGenesis(genesisBlk, genesisState))
-
-	return s
 }
 
 func newUninitializedState(require *require.Assertions) (*state, database.Database) {
@@ -773,6 +777,10 @@ func newUninitializedState(require *require.Assertions) (*state, database.Databa
 
 func newStateFromDB(require *require.Assertions, db database.Database) *state {
 	execCfg, _ := config.GetExecutionConfig(nil)
+	return newStateFromDBWithConfig(require, db, execCfg)
+}
+
+func newStateFromDBWithConfig(require *require.Assertions, db database.Database, execCfg *config.ExecutionConfig) *state {
 	state, err := newState(
 		db,
 		metrics.Noop,
@@ -780,7 +788,9 @@ func newStateFromDB(require *require.Assertions, db database.Database) *state {
 			Validators: validators.NewManager(),
 		},
 		execCfg,
-		&snow.Context{},
+		&snow.Context{
+			Log: logging.NoLog{},
+		},
 		prometheus.NewRegistry(),
 		reward.NewCalculator(reward.Config{
 			MaxConsumptionRate: .12 * reward.PercentDenominator,
@@ -1440,3 +1450,32 @@ func TestStateSubnetOwner(t *testing.T) {
 	require.NoError(err)
 	require.Equal(owner2, owner)
 }
+
+func TestStateFeeRates(t *testing.T) {
+	require := require.New(t)
+
+	db := memdb.New()
+	s := newStateFromDB(require, db)
+	initializeState(require, s)
+	require.NoError(s.Commit())
+
+	// The fee rates default to the initial fee rates of the config.
+	feeRates, err := s.GetFeeRates()
+	require.NoError(err)
+	require.Equal(s.cfg.DynamicFeeConfig.InitialFeeRate, feeRates)
+
+	d, err := NewDiffOn(s)
+	require.NoError(err)
+
+	expectedFeeRates := fees.Dimensions{1, 2, 3, 4}
+	d.SetFeeRates(expectedFeeRates)
+	require.NoError(d.Apply(s))
+	require.NoError(s.Commit())
+
+	s = newStateFromDB(require, db)
+	require.NoError(s.load())
+
+	feeRates, err = s.GetFeeRates()
+	require.NoError(err)
+	require.Equal(expectedFeeRates, feeRates)
+}
@@ -0,0 +1,191 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package platformvm
+
+import (
+	"context"
+
+	"go.uber.org/zap"
+
+	"github.com/ava-labs/avalanchego/snow/engine/common"
+	"github.com/ava-labs/avalanchego/vms/platformvm/network"
+	"github.com/ava-labs/avalanchego/vms/platformvm/state"
+	"github.com/ava-labs/avalanchego/x/sync"
+
+	snowmanblock "github.com/ava-labs/avalanchego/snow/engine/snowman/block"
+)
+
+const (
+	// Maximum number of outstanding proof requests while state syncing.
+	maxOutstandingStateSyncRequests = 16
+	// Maximum number of key ranges that are synced concurrently.
+	stateSyncWorkLimit = 16
+)
+
+var (
+	_ snowmanblock.StateSyncableVM = (*VM)(nil)
+	_ snowmanblock.StateSummary    = (*stateSummary)(nil)
+)
+
+type stateSummary struct {
+	*state.StateSummary
+
+	vm *VM
+}
+
+// Accept starts syncing to the summary unless the local state is already at
+// least as recent.
+func (s *stateSummary) Accept(ctx context.Context) (snowmanblock.StateSyncMode, error) {
+	return s.vm.acceptStateSummary(ctx, s.StateSummary)
+}
+
+func (vm *VM) StateSyncEnabled(context.Context) (bool, error) {
+	return vm.stateSyncEnabled, nil
+}
+
+func (vm *VM) GetOngoingSyncStateSummary(context.Context) (snowmanblock.StateSummary, error) {
+	summary, err := vm.state.GetOngoingStateSummary()
+	if err != nil {
+		return nil, err
+	}
+	return vm.newStateSummary(summary), nil
+}
+
+func (vm *VM) GetLastStateSummary(ctx context.Context) (snowmanblock.StateSummary, error) {
+	summary, err := vm.state.GetLastStateSummary(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return vm.newStateSummary(summary), nil
+}
+
+func (vm *VM) ParseStateSummary(_ context.Context, summaryBytes []byte) (snowmanblock.StateSummary, error) {
+	summary, err := state.ParseStateSummary(summaryBytes)
+	if err != nil {
+		return nil, err
+	}
+	return vm.newStateSummary(summary), nil
+}
+
+func (vm *VM) GetStateSummary(ctx context.Context, summaryHeight uint64) (snowmanblock.StateSummary, error) {
+	summary, err := vm.state.GetStateSummary(ctx, summaryHeight)
+	if err != nil {
+		return nil, err
+	}
+	return vm.newStateSummary(summary), nil
+}
+
+func (vm *VM) newStateSummary(summary *state.StateSummary) *stateSummary {
+	return &stateSummary{
+		StateSummary: summary,
+		vm:           vm,
+	}
+}
+
+func (vm *VM) acceptStateSummary(ctx context.Context, summary *state.StateSummary) (snowmanblock.StateSyncMode, error) {
+	lastAccepted, err := vm.state.GetStatelessBlock(vm.state.GetLastAccepted())
+	if err != nil {
+		return 0, err
+	}
+	if summary.Height() <= lastAccepted.Height() {
+		vm.ctx.Log.Info("skipping state sync",
+			zap.Uint64("summaryHeight", summary.Height()),
+			zap.Uint64("lastAcceptedHeight", lastAccepted.Height()),
+		)
+		return snowmanblock.StateSyncSkipped, nil
+	}
+
+	if err := vm.state.StartStateSync(summary); err != nil {
+		return 0, err
+	}
+
+	client, err := sync.NewClient(&sync.ClientConfig{
+		NetworkClient: sync.NewP2PNetworkClient(
+			vm.Network.NewClient(network.StateSyncHandlerID),
+			maxOutstandingStateSyncRequests,
+		),
+		Log:          vm.ctx.Log,
+		Metrics:      vm.stateSyncMetrics,
+		BranchFactor: state.StateSyncBranchFactor,
+	})
+	if err != nil {
+		return 0, err
+	}
+	manager, err := sync.NewManager(sync.ManagerConfig{
+		DB:                    vm.state.SyncDB(),
+		Client:                client,
+		SimultaneousWorkLimit: stateSyncWorkLimit,
+		Log:                   vm.ctx.Log,
+		TargetRoot:            summary.Root,
+		BranchFactor:          state.StateSyncBranchFactor,
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	vm.ctx.Log.Info("starting state sync",
+		zap.Stringer("summaryID", summary.ID()),
+		zap.Uint64("height", summary.Height()),
+		zap.Stringer("root", summary.Root),
+	)
+	if err := manager.Start(vm.onShutdownCtx); err != nil {
+		return 0, err
+	}
+
+	go vm.awaitStateSync(manager)
+	return snowmanblock.StateSyncStatic, nil
+}
+
+// awaitStateSync applies the synced state once [manager] finishes and then
+// notifies the engine.
+//
+// The engine is notified even if the synced state couldn't be applied, so that
+// it doesn't wait forever. The error is then reported when the engine starts
+// bootstrapping.
+func (vm *VM) awaitStateSync(manager *sync.Manager) {
+	err := manager.Wait(vm.onShutdownCtx)
+	if vm.onShutdownCtx.Err() != nil {
+		// The sync is resumed when the node restarts.
+		return
+	}
+
+	vm.ctx.Lock.Lock()
+	err = vm.finishStateSync(err)
+	vm.stateSyncErr = err
+	vm.ctx.Lock.Unlock()
+	if err != nil {
+		vm.ctx.Log.Error("failed to finish state sync",
+			zap.Error(err),
+		)
+	}
+
+	select {
+	case vm.toEngine <- common.StateSyncDone:
+	case <-vm.onShutdownCtx.Done():
+	}
+}
+
+// finishStateSync replaces the local state with the synced state. If the sync
+// failed, the local state is kept and the chain is bootstrapped from it
+// instead.
+func (vm *VM) finishStateSync(syncErr error) error {
+	if syncErr != nil {
+		vm.ctx.Log.Error("state sync failed",
+			zap.Error(syncErr),
+		)
+		return vm.state.AbortStateSync()
+	}
+
+	if err := vm.state.FinishStateSync(vm.onShutdownCtx); err != nil {
+		return err
+	}
+	lastAcceptedID := vm.state.GetLastAccepted()
+	vm.manager.SetLastAccepted(lastAcceptedID)
+	if err := vm.SetPreference(vm.onShutdownCtx, lastAcceptedID); err != nil {
+		return err
+	}
+	// The synced state may include chains that weren't created during
+	// initialization.
+	return vm.initBlockchains()
+}
@@ -0,0 +1,71 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package platformvm
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow"
+	"github.com/ava-labs/avalanchego/vms/platformvm/state"
+
+	snowmanblock "github.com/ava-labs/avalanchego/snow/engine/snowman/block"
+)
+
+func TestStateSyncableVM(t *testing.T) {
+	require := require.New(t)
+	ctx := context.Background()
+
+	vm, _, _ := defaultVM(t, latestFork)
+	vm.ctx.Lock.Lock()
+	defer vm.ctx.Lock.Unlock()
+
+	enabled, err := vm.StateSyncEnabled(ctx)
+	require.NoError(err)
+	require.False(enabled)
+
+	_, err = vm.GetOngoingSyncStateSummary(ctx)
+	require.ErrorIs(err, database.ErrNotFound)
+	_, err = vm.GetLastStateSummary(ctx)
+	require.ErrorIs(err, database.ErrNotFound)
+
+	// A summary of the last accepted block is already synced.
+	lastAccepted, err := vm.state.GetStatelessBlock(vm.state.GetLastAccepted())
+	require.NoError(err)
+	summary, err := state.NewStateSummary(lastAccepted, ids.GenerateTestID())
+	require.NoError(err)
+
+	parsedSummary, err := vm.ParseStateSummary(ctx, summary.Bytes())
+	require.NoError(err)
+	require.Equal(summary.ID(), parsedSummary.ID())
+	require.Equal(summary.Height(), parsedSummary.Height())
+
+	mode, err := parsedSummary.Accept(ctx)
+	require.NoError(err)
+	require.Equal(snowmanblock.StateSyncSkipped, mode)
+
+	_, err = vm.GetOngoingSyncStateSummary(ctx)
+	require.ErrorIs(err, database.ErrNotFound)
+}
+
+func TestStateSyncFailureReportedOnBootstrap(t *testing.T) {
+	require := require.New(t)
+	ctx := context.Background()
+
+	vm, _, _ := defaultVM(t, latestFork)
+	vm.ctx.Lock.Lock()
+	defer vm.ctx.Lock.Unlock()
+
+	// The engine starts bootstrapping once it is notified that state sync is
+	// done, even if the synced state couldn't be applied.
+	errFinish := errors.New("failed to apply synced state")
+	vm.stateSyncErr = errFinish
+	err := vm.SetState(ctx, snow.Bootstrapping)
+	require.ErrorIs(err, errFinish)
+}
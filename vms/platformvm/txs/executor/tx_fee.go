@@ -0,0 +1,40 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package executor
+
+import (
+	"time"
+
+	"github.com/ava-labs/avalanchego/vms/components/fees"
+	"github.com/ava-labs/avalanchego/vms/platformvm/state"
+	"github.com/ava-labs/avalanchego/vms/platformvm/txs"
+
+	txfees "github.com/ava-labs/avalanchego/vms/platformvm/txs/fees"
+)
+
+// getTxFee returns the fee [tx] must burn to be executed on top of
+// [chainState] at [timestamp]. Prior to the E upgrade, [staticFee] is charged.
+// After the E upgrade, the complexity of [tx] is charged at the current fee
+// rates.
+func getTxFee(
+	backend *Backend,
+	chainState state.Chain,
+	timestamp time.Time,
+	tx txs.UnsignedTx,
+	staticFee uint64,
+) (uint64, error) {
+	if !backend.Config.IsEActivated(timestamp) {
+		return staticFee, nil
+	}
+
+	complexity, err := txfees.TxComplexity(tx)
+	if err != nil {
+		return 0, err
+	}
+	feeRates, err := chainState.GetFeeRates()
+	if err != nil {
+		return 0, err
+	}
+	return fees.NewManager(feeRates).CalculateFee(complexity)
+}
@@ -0,0 +1,171 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package fees
+
+import (
+	"errors"
+
+	"github.com/ava-labs/avalanchego/utils/crypto/secp256k1"
+	"github.com/ava-labs/avalanchego/vms/components/avax"
+	"github.com/ava-labs/avalanchego/vms/components/fees"
+	"github.com/ava-labs/avalanchego/vms/components/verify"
+	"github.com/ava-labs/avalanchego/vms/platformvm/txs"
+	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
+
+	safemath "github.com/ava-labs/avalanchego/utils/math"
+)
+
+var (
+	_ txs.Visitor = (*complexityVisitor)(nil)
+
+	ErrUninitializedTx       = errors.New("tx bytes are not initialized")
+	ErrUnknownSubnetAuthType = errors.New("unknown subnet auth type")
+)
+
+// TxComplexity returns the complexity consumed by executing [utx]. The unsigned
+// bytes of [utx] must have been populated.
+//
+// The complexity is measured as:
+//   - Bandwidth: the size of the unsigned tx plus the size of its signatures
+//   - UTXORead: the number of consumed UTXOs
+//   - UTXOWrite: the number of produced UTXOs
+//   - Compute: the cost of verifying the signatures of the tx
+//
+// The complexity only depends on the unsigned tx so that wallets are able to
+// calculate the fee of a tx before signing it.
+func TxComplexity(utx txs.UnsignedTx) (fees.Dimensions, error) {
+	unsignedSize := len(utx.Bytes())
+	if unsignedSize == 0 {
+		return fees.Empty, ErrUninitializedTx
+	}
+
+	v := &complexityVisitor{
+		unsignedSize: uint64(unsignedSize),
+	}
+	err := utx.Visit(v)
+	return v.complexity, err
+}
+
+type complexityVisitor struct {
+	// inputs
+	unsignedSize uint64
+
+	// outputs
+	complexity fees.Dimensions
+}
+
+// AdvanceTimeTx is issued by the block producer and doesn't pay a fee
+func (*complexityVisitor) AdvanceTimeTx(*txs.AdvanceTimeTx) error {
+	return nil
+}
+
+// RewardValidatorTx is issued by the block producer and doesn't pay a fee
+func (*complexityVisitor) RewardValidatorTx(*txs.RewardValidatorTx) error {
+	return nil
+}
+
+func (v *complexityVisitor) BaseTx(tx *txs.BaseTx) error {
+	return v.meter(&tx.BaseTx, nil, nil, nil)
+}
+
+func (v *complexityVisitor) AddValidatorTx(tx *txs.AddValidatorTx) error {
+	return v.meter(&tx.BaseTx.BaseTx, nil, tx.StakeOuts, nil)
+}
+
+func (v *complexityVisitor) AddSubnetValidatorTx(tx *txs.AddSubnetValidatorTx) error {
+	return v.meter(&tx.BaseTx.BaseTx, nil, nil, tx.SubnetAuth)
+}
+
+func (v *complexityVisitor) AddDelegatorTx(tx *txs.AddDelegatorTx) error {
+	return v.meter(&tx.BaseTx.BaseTx, nil, tx.StakeOuts, nil)
+}
+
+func (v *complexityVisitor) CreateChainTx(tx *txs.CreateChainTx) error {
+	return v.meter(&tx.BaseTx.BaseTx, nil, nil, tx.SubnetAuth)
+}
+
+func (v *complexityVisitor) CreateSubnetTx(tx *txs.CreateSubnetTx) error {
+	return v.meter(&tx.BaseTx.BaseTx, nil, nil, nil)
+}
+
+func (v *complexityVisitor) ImportTx(tx *txs.ImportTx) error {
+	return v.meter(&tx.BaseTx.BaseTx, tx.ImportedInputs, nil, nil)
+}
+
+func (v *complexityVisitor) ExportTx(tx *txs.ExportTx) error {
+	return v.meter(&tx.BaseTx.BaseTx, nil, tx.ExportedOutputs, nil)
+}
+
+func (v *complexityVisitor) RemoveSubnetValidatorTx(tx *txs.RemoveSubnetValidatorTx) error {
+	return v.meter(&tx.BaseTx.BaseTx, nil, nil, tx.SubnetAuth)
+}
+
+func (v *complexityVisitor) TransformSubnetTx(tx *txs.TransformSubnetTx) error {
+	return v.meter(&tx.BaseTx.BaseTx, nil, nil, tx.SubnetAuth)
+}
+
+func (v *complexityVisitor) AddPermissionlessValidatorTx(tx *txs.AddPermissionlessValidatorTx) error {
+	return v.meter(&tx.BaseTx.BaseTx, nil, tx.StakeOuts, nil)
+}
+
+func (v *complexityVisitor) AddPermissionlessDelegatorTx(tx *txs.AddPermissionlessDelegatorTx) error {
+	return v.meter(&tx.BaseTx.BaseTx, nil, tx.StakeOuts, nil)
+}
+
+func (v *complexityVisitor) TransferSubnetOwnershipTx(tx *txs.TransferSubnetOwnershipTx) error {
+	return v.meter(&tx.BaseTx.BaseTx, nil, nil, tx.SubnetAuth)
+}
+
+func (v *complexityVisitor) meter(
+	tx *avax.BaseTx,
+	additionalIns []*avax.TransferableInput,
+	additionalOuts []*avax.TransferableOutput,
+	subnetAuth verify.Verifiable,
+) error {
+	var compute uint64
+	for _, ins := range [][]*avax.TransferableInput{tx.Ins, additionalIns} {
+		for _, in := range ins {
+			cost, err := in.In.Cost()
+			if err != nil {
+				return err
+			}
+			compute, err = safemath.Add64(compute, cost)
+			if err != nil {
+				return err
+			}
+		}
+	}
+	if subnetAuth != nil {
+		coster, ok := subnetAuth.(avax.Coster)
+		if !ok {
+			return ErrUnknownSubnetAuthType
+		}
+		cost, err := coster.Cost()
+		if err != nil {
+			return err
+		}
+		compute, err = safemath.Add64(compute, cost)
+		if err != nil {
+			return err
+		}
+	}
+
+	numSigs := compute / secp256k1fx.CostPerSignature
+	sigsSize, err := safemath.Mul64(numSigs, secp256k1.SignatureLen)
+	if err != nil {
+		return err
+	}
+	bandwidth, err := safemath.Add64(v.unsignedSize, sigsSize)
+	if err != nil {
+		return err
+	}
+
+	v.complexity = fees.Dimensions{
+		fees.Bandwidth: bandwidth,
+		fees.UTXORead:  uint64(len(tx.Ins) + len(additionalIns)),
+		fees.UTXOWrite: uint64(len(tx.Outs) + len(additionalOuts)),
+		fees.Compute:   compute,
+	}
+	return nil
+}
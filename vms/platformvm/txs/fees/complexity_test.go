@@ -0,0 +1,202 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package fees
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/ava-labs/avalanchego/utils/crypto/secp256k1"
+	"github.com/ava-labs/avalanchego/vms/components/avax"
+	"github.com/ava-labs/avalanchego/vms/components/fees"
+	"github.com/ava-labs/avalanchego/vms/components/verify"
+	"github.com/ava-labs/avalanchego/vms/platformvm/stakeable"
+	"github.com/ava-labs/avalanchego/vms/platformvm/txs"
+	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
+)
+
+// unknownSubnetAuth is a subnet authorization that doesn't report a cost.
+type unknownSubnetAuth struct {
+	verify.Verifiable
+}
+
+func newTestIn(numSigs int) *avax.TransferableInput {
+	return &avax.TransferableInput{
+		UTXOID: avax.UTXOID{TxID: ids.GenerateTestID()},
+		Asset:  avax.Asset{ID: ids.GenerateTestID()},
+		In: &secp256k1fx.TransferInput{
+			Amt: 1,
+			Input: secp256k1fx.Input{
+				SigIndices: make([]uint32, numSigs),
+			},
+		},
+	}
+}
+
+func newTestOut() *avax.TransferableOutput {
+	return &avax.TransferableOutput{
+		Asset: avax.Asset{ID: ids.GenerateTestID()},
+		Out: &secp256k1fx.TransferOutput{
+			Amt: 1,
+			OutputOwners: secp256k1fx.OutputOwners{
+				Threshold: 1,
+				Addrs:     []ids.ShortID{ids.GenerateTestShortID()},
+			},
+		},
+	}
+}
+
+func newTestBaseTx(ins []*avax.TransferableInput, outs []*avax.TransferableOutput) txs.BaseTx {
+	return txs.BaseTx{
+		BaseTx: avax.BaseTx{
+			NetworkID:    constants.UnitTestID,
+			BlockchainID: constants.PlatformChainID,
+			Ins:          ins,
+			Outs:         outs,
+		},
+	}
+}
+
+func TestTxComplexity(t *testing.T) {
+	tests := []struct {
+		name         string
+		unsignedTx   txs.UnsignedTx
+		expectedSigs uint64
+		expected     fees.Dimensions
+		expectedErr  error
+	}{
+		{
+			name: "base tx",
+			unsignedTx: &txs.BaseTx{
+				BaseTx: newTestBaseTx(
+					[]*avax.TransferableInput{newTestIn(1), newTestIn(2)},
+					[]*avax.TransferableOutput{newTestOut()},
+				).BaseTx,
+			},
+			expectedSigs: 3,
+			expected: fees.Dimensions{
+				fees.UTXORead:  2,
+				fees.UTXOWrite: 1,
+				fees.Compute:   3 * secp256k1fx.CostPerSignature,
+			},
+		},
+		{
+			name: "import tx",
+			unsignedTx: &txs.ImportTx{
+				BaseTx: newTestBaseTx(
+					nil,
+					[]*avax.TransferableOutput{newTestOut()},
+				),
+				SourceChain:    ids.GenerateTestID(),
+				ImportedInputs: []*avax.TransferableInput{newTestIn(1)},
+			},
+			expectedSigs: 1,
+			expected: fees.Dimensions{
+				fees.UTXORead:  1,
+				fees.UTXOWrite: 1,
+				fees.Compute:   secp256k1fx.CostPerSignature,
+			},
+		},
+		{
+			name: "export tx",
+			unsignedTx: &txs.ExportTx{
+				BaseTx: newTestBaseTx(
+					[]*avax.TransferableInput{newTestIn(1)},
+					[]*avax.TransferableOutput{newTestOut()},
+				),
+				DestinationChain: ids.GenerateTestID(),
+				ExportedOutputs:  []*avax.TransferableOutput{newTestOut(), newTestOut()},
+			},
+			expectedSigs: 1,
+			expected: fees.Dimensions{
+				fees.UTXORead:  1,
+				fees.UTXOWrite: 3,
+				fees.Compute:   secp256k1fx.CostPerSignature,
+			},
+		},
+		{
+			name: "stakeable locked input",
+			unsignedTx: &txs.BaseTx{
+				BaseTx: newTestBaseTx(
+					[]*avax.TransferableInput{
+						{
+							UTXOID: avax.UTXOID{TxID: ids.GenerateTestID()},
+							Asset:  avax.Asset{ID: ids.GenerateTestID()},
+							In: &stakeable.LockIn{
+								Locktime:       1,
+								TransferableIn: newTestIn(2).In,
+							},
+						},
+					},
+					nil,
+				).BaseTx,
+			},
+			expectedSigs: 2,
+			expected: fees.Dimensions{
+				fees.UTXORead: 1,
+				fees.Compute:  2 * secp256k1fx.CostPerSignature,
+			},
+		},
+		{
+			name: "subnet authorization",
+			unsignedTx: &txs.TransferSubnetOwnershipTx{
+				BaseTx: newTestBaseTx(
+					[]*avax.TransferableInput{newTestIn(1)},
+					nil,
+				),
+				Subnet: ids.GenerateTestID(),
+				SubnetAuth: &secp256k1fx.Input{
+					SigIndices: []uint32{0, 1},
+				},
+				Owner: &secp256k1fx.OutputOwners{},
+			},
+			expectedSigs: 3,
+			expected: fees.Dimensions{
+				fees.UTXORead: 1,
+				fees.Compute:  3 * secp256k1fx.CostPerSignature,
+			},
+		},
+		{
+			name: "unknown subnet authorization",
+			unsignedTx: &txs.TransferSubnetOwnershipTx{
+				BaseTx:     newTestBaseTx(nil, nil),
+				Subnet:     ids.GenerateTestID(),
+				SubnetAuth: &unknownSubnetAuth{},
+				Owner:      &secp256k1fx.OutputOwners{},
+			},
+			expectedErr: ErrUnknownSubnetAuthType,
+		},
+		{
+			name:       "reward validator tx",
+			unsignedTx: &txs.RewardValidatorTx{TxID: ids.GenerateTestID()},
+			expected:   fees.Empty,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require := require.New(t)
+
+			_, err := TxComplexity(test.unsignedTx)
+			require.ErrorIs(err, ErrUninitializedTx)
+
+			unsignedBytes := []byte{0x00, 0x01, 0x02, 0x03}
+			test.unsignedTx.SetBytes(unsignedBytes)
+
+			complexity, err := TxComplexity(test.unsignedTx)
+			require.ErrorIs(err, test.expectedErr)
+			if test.expectedErr != nil {
+				return
+			}
+
+			expected := test.expected
+			if test.expected != fees.Empty {
+				expected[fees.Bandwidth] = uint64(len(unsignedBytes)) + test.expectedSigs*secp256k1.SignatureLen
+			}
+			require.Equal(expected, complexity)
+		})
+	}
+}
@@ -43,6 +43,7 @@ import (
 	"github.com/ava-labs/avalanchego/vms/platformvm/txs/mempool"
 	"github.com/ava-labs/avalanchego/vms/platformvm/utxo"
 	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
+	"github.com/ava-labs/avalanchego/x/sync"
 
 	snowmanblock "github.com/ava-labs/avalanchego/snow/engine/snowman/block"
 	blockbuilder "github.com/ava-labs/avalanchego/vms/platformvm/block/builder"
@@ -88,6 +89,14 @@ type VM struct {
 	txBuilder txbuilder.Builder
 	manager   blockexecutor.Manager
 
+	// Used to notify the engine when state sync finishes
+	toEngine         chan<- common.Message
+	stateSyncEnabled bool
+	stateSyncMetrics sync.SyncMetrics
+	// stateSyncErr is the error that occurred while applying the synced
+	// state, if any. Must only be accessed with the context lock held.
+	stateSyncErr error
+
 	// Cancelled on shutdown
 	onShutdownCtx context.Context
 	// Call [onShutdownCtxCancel] to cancel [onShutdownCtx] during Shutdown()
@@ -128,6 +137,14 @@ func (vm *VM) Initialize(
 
 	vm.ctx = chainCtx
 	vm.db = db
+	vm.toEngine = toEngine
+	vm.stateSyncEnabled = execConfig.StateSyncEnabled
+	if vm.stateSyncEnabled {
+		vm.stateSyncMetrics, err = sync.NewMetrics("state_sync", registerer)
+		if err != nil {
+			return fmt.Errorf("failed to initialize state sync metrics: %w", err)
+		}
+	}
 
 	// Note: this codec is never used to serialize anything
 	vm.codecRegistry = linearcodec.NewDefault()
@@ -212,6 +229,11 @@ func (vm *VM) Initialize(
 	if err != nil {
 		return fmt.Errorf("failed to initialize network: %w", err)
 	}
+	if syncDB := vm.state.SyncDB(); syncDB != nil {
+		if err := vm.Network.AddHandler(network.StateSyncHandlerID, sync.NewHandler(syncDB)); err != nil {
+			return fmt.Errorf("failed to register state sync handler: %w", err)
+		}
+	}
 
 	vm.onShutdownCtx, vm.onShutdownCtxCancel = context.WithCancel(context.Background())
 	// TODO: Wait for this goroutine to exit during Shutdown once the platformvm
@@ -337,7 +359,21 @@ func (vm *VM) createSubnet(subnetID ids.ID) error {
 
 // onBootstrapStarted marks this VM as bootstrapping
 func (vm *VM) onBootstrapStarted() error {
+	if vm.stateSyncErr != nil {
+		return fmt.Errorf("failed to finish state sync: %w", vm.stateSyncErr)
+	}
+
 	vm.bootstrapped.Set(false)
+
+	// Bootstrapping from the local state, so any state sync that wasn't
+	// finished must be abandoned.
+	if _, err := vm.state.GetOngoingStateSummary(); err == nil {
+		if err := vm.state.AbortStateSync(); err != nil {
+			return err
+		}
+	} else if err != database.ErrNotFound {
+		return err
+	}
 	return vm.fx.Bootstrapping()
 }
 
@@ -476,18 +512,24 @@ func (vm *VM) CreateHandlers(context.Context) (map[string]http.Handler, error) {
 	}, err
 }
 
-func (vm *VM) Connected(_ context.Context, nodeID ids.NodeID, _ *version.Application) error {
-	return vm.uptimeManager.Connect(nodeID, constants.PrimaryNetworkID)
+func (vm *VM) Connected(ctx context.Context, nodeID ids.NodeID, nodeVersion *version.Application) error {
+	if err := vm.uptimeManager.Connect(nodeID, constants.PrimaryNetworkID); err != nil {
+		return err
+	}
+	return vm.Network.Connected(ctx, nodeID, nodeVersion)
 }
 
 func (vm *VM) ConnectedSubnet(_ context.Context, nodeID ids.NodeID, subnetID ids.ID) error {
 	return vm.uptimeManager.Connect(nodeID, subnetID)
 }
 
-func (vm *VM) Disconnected(_ context.Context, nodeID ids.NodeID) error {
+func (vm *VM) Disconnected(ctx context.Context, nodeID ids.NodeID) error {
 	if err := vm.uptimeManager.Disconnect(nodeID); err != nil {
 		return err
 	}
+	if err := vm.Network.Disconnected(ctx, nodeID); err != nil {
+		return err
+	}
 	return vm.state.Commit()
 }
 
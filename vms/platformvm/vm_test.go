@@ -1432,6 +1432,7 @@ func TestBootstrapPartiallyAccepted(t *testing.T) {
 		BootstrapTracker:               bootstrapTracker,
 		AncestorsMaxContainersReceived: 2000,
 		Blocked:                        blocked,
+		DB:                             prefixdb.New(chains.ChainFetchingDBPrefix, baseDB),
 		VM:                             vm,
 	}
 
@@ -0,0 +1,184 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package aggregator
+
+import (
+	"context"
+	"fmt"
+
+	"go.uber.org/zap"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/network/p2p"
+	"github.com/ava-labs/avalanchego/proto/pb/sdk"
+	"github.com/ava-labs/avalanchego/utils/crypto/bls"
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/ava-labs/avalanchego/utils/set"
+	"github.com/ava-labs/avalanchego/vms/platformvm/warp"
+)
+
+type signatureResult struct {
+	nodeID        ids.NodeID
+	responseBytes []byte
+	err           error
+}
+
+// New returns an Aggregator that requests signatures using [client]. The
+// client must be registered with [HandlerID].
+func New(log logging.Logger, client *p2p.Client) *Aggregator {
+	return &Aggregator{
+		log:    log,
+		client: client,
+	}
+}
+
+// Aggregator collects signatures over warp messages from validators and
+// aggregates them into a single BitSetSignature.
+type Aggregator struct {
+	log    logging.Logger
+	client *p2p.Client
+}
+
+// AggregateSignatures requests a signature over [msg] from every validator in
+// [vdrs] and returns a signed warp message once at least
+// [quorumNum]/[quorumDen] of [totalWeight] has signed it.
+//
+// Every signature share is verified against the public key of the validator
+// that provided it, so invalid shares never invalidate the aggregate.
+// Outstanding requests are abandoned once the quorum has been reached.
+//
+// [vdrs] must be the canonical validator set of the source subnet of [msg], as
+// returned by [warp.GetCanonicalValidatorSet].
+func (a *Aggregator) AggregateSignatures(
+	ctx context.Context,
+	msg *warp.UnsignedMessage,
+	justification []byte,
+	vdrs []*warp.Validator,
+	totalWeight uint64,
+	quorumNum uint64,
+	quorumDen uint64,
+) (*warp.Message, error) {
+	// Fail fast if the quorum can't be reached even if every validator signs.
+	availableWeight, err := warp.SumWeight(vdrs)
+	if err != nil {
+		return nil, err
+	}
+	if err := warp.VerifyWeight(availableWeight, totalWeight, quorumNum, quorumDen); err != nil {
+		return nil, err
+	}
+
+	requestBytes, err := proto.Marshal(&sdk.SignatureRequest{
+		Message:       msg.Bytes(),
+		Justification: justification,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// A validator may be registered with multiple nodeIDs. Every nodeID is
+	// queried, but each validator is only counted once.
+	nodeIDs := set.NewSet[ids.NodeID](len(vdrs))
+	validatorIndices := make(map[ids.NodeID]int, len(vdrs))
+	for i, vdr := range vdrs {
+		for _, nodeID := range vdr.NodeIDs {
+			nodeIDs.Add(nodeID)
+			validatorIndices[nodeID] = i
+		}
+	}
+
+	// The results channel is buffered so that late responses never block the
+	// p2p network after this function returns.
+	results := make(chan signatureResult, nodeIDs.Len())
+	onResponse := func(_ context.Context, nodeID ids.NodeID, responseBytes []byte, err error) {
+		results <- signatureResult{
+			nodeID:        nodeID,
+			responseBytes: responseBytes,
+			err:           err,
+		}
+	}
+	if err := a.client.AppRequest(ctx, nodeIDs, requestBytes, onResponse); err != nil {
+		return nil, fmt.Errorf("failed to request signatures: %w", err)
+	}
+
+	var (
+		unsignedBytes = msg.Bytes()
+		signers       = set.NewBits()
+		signatures    = make([]*bls.Signature, 0, len(vdrs))
+		signedWeight  uint64
+	)
+	for pending := nodeIDs.Len(); pending > 0; pending-- {
+		var result signatureResult
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case result = <-results:
+		}
+
+		if result.err != nil {
+			a.log.Debug("failed to get signature",
+				zap.Stringer("nodeID", result.nodeID),
+				zap.Error(result.err),
+			)
+			continue
+		}
+
+		index := validatorIndices[result.nodeID]
+		if signers.Contains(index) {
+			continue
+		}
+
+		vdr := vdrs[index]
+		signature, err := parseSignature(result.responseBytes)
+		if err != nil {
+			a.log.Debug("failed to parse signature",
+				zap.Stringer("nodeID", result.nodeID),
+				zap.Error(err),
+			)
+			continue
+		}
+		if !bls.Verify(vdr.PublicKey, signature, unsignedBytes) {
+			a.log.Debug("dropping invalid signature",
+				zap.Stringer("nodeID", result.nodeID),
+			)
+			continue
+		}
+
+		signers.Add(index)
+		signatures = append(signatures, signature)
+		// This can't overflow because [availableWeight] didn't overflow.
+		signedWeight += vdr.Weight
+
+		if warp.VerifyWeight(signedWeight, totalWeight, quorumNum, quorumDen) == nil {
+			return newMessage(msg, signers, signatures)
+		}
+	}
+
+	return nil, warp.VerifyWeight(signedWeight, totalWeight, quorumNum, quorumDen)
+}
+
+func parseSignature(responseBytes []byte) (*bls.Signature, error) {
+	response := &sdk.SignatureResponse{}
+	if err := proto.Unmarshal(responseBytes, response); err != nil {
+		return nil, err
+	}
+	return bls.SignatureFromBytes(response.Signature)
+}
+
+func newMessage(
+	msg *warp.UnsignedMessage,
+	signers set.Bits,
+	signatures []*bls.Signature,
+) (*warp.Message, error) {
+	aggregateSignature, err := bls.AggregateSignatures(signatures)
+	if err != nil {
+		return nil, err
+	}
+
+	signature := &warp.BitSetSignature{
+		Signers: signers.Bytes(),
+	}
+	copy(signature.Signature[:], bls.SignatureToBytes(aggregateSignature))
+	return warp.NewMessage(msg, signature)
+}
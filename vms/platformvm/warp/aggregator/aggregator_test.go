@@ -0,0 +1,329 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package aggregator
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/network/p2p"
+	"github.com/ava-labs/avalanchego/snow/engine/common"
+	"github.com/ava-labs/avalanchego/snow/validators"
+	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/ava-labs/avalanchego/utils/crypto/bls"
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/ava-labs/avalanchego/utils/set"
+	"github.com/ava-labs/avalanchego/vms/platformvm/warp"
+)
+
+var _ common.AppSender = (*testSender)(nil)
+
+// testSender delivers the requests of an aggregator directly to the handlers
+// of the validators. Requests to validators without a handler fail.
+type testSender struct {
+	common.FakeSender
+
+	nodeID   ids.NodeID
+	network  *p2p.Network
+	handlers map[ids.NodeID]p2p.Handler
+}
+
+func (s *testSender) SendAppRequest(_ context.Context, nodeIDs set.Set[ids.NodeID], requestID uint32, requestBytes []byte) error {
+	_, requestBytes, ok := p2p.ParseMessage(requestBytes)
+	if !ok {
+		return errTest
+	}
+
+	for nodeID := range nodeIDs {
+		// The p2p network is locked while sending requests, so responses must
+		// be delivered asynchronously.
+		go func(nodeID ids.NodeID) {
+			ctx := context.Background()
+			handler, ok := s.handlers[nodeID]
+			if !ok {
+				_ = s.network.AppRequestFailed(ctx, nodeID, requestID, common.ErrTimeout)
+				return
+			}
+
+			responseBytes, err := handler.AppRequest(ctx, s.nodeID, time.Time{}, requestBytes)
+			if err != nil {
+				_ = s.network.AppRequestFailed(ctx, nodeID, requestID, &common.AppError{
+					Code:    1,
+					Message: err.Error(),
+				})
+				return
+			}
+			_ = s.network.AppResponse(ctx, nodeID, requestID, responseBytes)
+		}(nodeID)
+	}
+	return nil
+}
+
+type testValidator struct {
+	nodeID ids.NodeID
+	sk     *bls.SecretKey
+	weight uint64
+	// handler returns the handler of the validator, or nil if the validator
+	// is offline.
+	handler func(signer warp.Signer) p2p.Handler
+}
+
+func online(signer warp.Signer) p2p.Handler {
+	return NewHandler(verifierFunc(acceptAll), signer)
+}
+
+func offline(warp.Signer) p2p.Handler {
+	return nil
+}
+
+// invalid validators sign with the wrong key.
+func invalid(warp.Signer) p2p.Handler {
+	sk, err := bls.NewSecretKey()
+	if err != nil {
+		panic(err)
+	}
+	return NewHandler(verifierFunc(acceptAll), warp.NewSigner(bls.NewLocalSigner(sk), constants.UnitTestID, sourceChainID))
+}
+
+// rejecting validators refuse to sign the message.
+func rejecting(signer warp.Signer) p2p.Handler {
+	return NewHandler(
+		verifierFunc(func(context.Context, *warp.UnsignedMessage, []byte) error {
+			return errTest
+		}),
+		signer,
+	)
+}
+
+var sourceChainID = ids.GenerateTestID()
+
+func TestAggregateSignatures(t *testing.T) {
+	tests := []struct {
+		name          string
+		handlers      []func(warp.Signer) p2p.Handler
+		quorumNum     uint64
+		quorumDen     uint64
+		expectedErr   error
+		expectSigners int
+	}{
+		{
+			name:          "all validators sign",
+			handlers:      []func(warp.Signer) p2p.Handler{online, online, online},
+			quorumNum:     1,
+			quorumDen:     1,
+			expectSigners: 3,
+		},
+		{
+			name:          "quorum reached with unavailable validators",
+			handlers:      []func(warp.Signer) p2p.Handler{online, online, offline, rejecting},
+			quorumNum:     1,
+			quorumDen:     2,
+			expectSigners: 2,
+		},
+		{
+			name:          "invalid signatures are dropped",
+			handlers:      []func(warp.Signer) p2p.Handler{online, online, invalid},
+			quorumNum:     2,
+			quorumDen:     3,
+			expectSigners: 2,
+		},
+		{
+			name:        "insufficient weight",
+			handlers:    []func(warp.Signer) p2p.Handler{online, offline, invalid, rejecting},
+			quorumNum:   1,
+			quorumDen:   2,
+			expectedErr: warp.ErrInsufficientWeight,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require := require.New(t)
+			ctx := context.Background()
+
+			msg, err := warp.NewUnsignedMessage(constants.UnitTestID, sourceChainID, []byte("payload"))
+			require.NoError(err)
+
+			sender := &testSender{
+				nodeID:   ids.GenerateTestNodeID(),
+				handlers: make(map[ids.NodeID]p2p.Handler),
+			}
+			vdrs := make(map[ids.NodeID]*validators.GetValidatorOutput)
+			for _, newHandler := range test.handlers {
+				sk, err := bls.NewSecretKey()
+				require.NoError(err)
+
+				nodeID := ids.GenerateTestNodeID()
+				signer := warp.NewSigner(bls.NewLocalSigner(sk), constants.UnitTestID, sourceChainID)
+				if handler := newHandler(signer); handler != nil {
+					sender.handlers[nodeID] = handler
+				}
+				vdrs[nodeID] = &validators.GetValidatorOutput{
+					NodeID:    nodeID,
+					PublicKey: bls.PublicFromSecretKey(sk),
+					Weight:    1,
+				}
+			}
+
+			network, err := p2p.NewNetwork(logging.NoLog{}, sender, prometheus.NewRegistry(), "")
+			require.NoError(err)
+			sender.network = network
+
+			state := &validators.TestState{
+				GetSubnetIDF: func(context.Context, ids.ID) (ids.ID, error) {
+					return constants.PrimaryNetworkID, nil
+				},
+				GetValidatorSetF: func(context.Context, uint64, ids.ID) (map[ids.NodeID]*validators.GetValidatorOutput, error) {
+					return vdrs, nil
+				},
+			}
+			canonicalVdrs, totalWeight, err := warp.GetCanonicalValidatorSet(ctx, state, 0, constants.PrimaryNetworkID)
+			require.NoError(err)
+
+			aggregator := New(logging.NoLog{}, network.NewClient(HandlerID))
+			signedMsg, err := aggregator.AggregateSignatures(
+				ctx,
+				msg,
+				nil,
+				canonicalVdrs,
+				totalWeight,
+				test.quorumNum,
+				test.quorumDen,
+			)
+			require.ErrorIs(err, test.expectedErr)
+			if test.expectedErr != nil {
+				return
+			}
+
+			require.Equal(msg, &signedMsg.UnsignedMessage)
+			numSigners, err := signedMsg.Signature.NumSigners()
+			require.NoError(err)
+			require.Equal(test.expectSigners, numSigners)
+			require.NoError(signedMsg.Signature.Verify(
+				ctx,
+				msg,
+				constants.UnitTestID,
+				state,
+				0,
+				test.quorumNum,
+				test.quorumDen,
+			))
+		})
+	}
+}
+
+func TestAggregateSignaturesUnreachableQuorum(t *testing.T) {
+	require := require.New(t)
+
+	msg, err := warp.NewUnsignedMessage(constants.UnitTestID, sourceChainID, []byte("payload"))
+	require.NoError(err)
+
+	sk, err := bls.NewSecretKey()
+	require.NoError(err)
+	pk := bls.PublicFromSecretKey(sk)
+	vdrs := []*warp.Validator{
+		{
+			PublicKey:      pk,
+			PublicKeyBytes: bls.PublicKeyToUncompressedBytes(pk),
+			Weight:         1,
+			NodeIDs:        []ids.NodeID{ids.GenerateTestNodeID()},
+		},
+	}
+
+	// No requests should be sent if the quorum can't be reached.
+	sender := &testSender{}
+	network, err := p2p.NewNetwork(logging.NoLog{}, sender, prometheus.NewRegistry(), "")
+	require.NoError(err)
+
+	aggregator := New(logging.NoLog{}, network.NewClient(HandlerID))
+	_, err = aggregator.AggregateSignatures(context.Background(), msg, nil, vdrs, 3, 1, 2)
+	require.ErrorIs(err, warp.ErrInsufficientWeight)
+}
+
+func TestAggregateSignaturesStopsAtQuorum(t *testing.T) {
+	require := require.New(t)
+	ctx := context.Background()
+
+	msg, err := warp.NewUnsignedMessage(constants.UnitTestID, sourceChainID, []byte("payload"))
+	require.NoError(err)
+
+	// The second validator never responds, so aggregation can only finish if
+	// it stops once the first validator's signature reaches the quorum.
+	blocked := make(chan struct{})
+	defer close(blocked)
+
+	sender := &testSender{
+		nodeID:   ids.GenerateTestNodeID(),
+		handlers: make(map[ids.NodeID]p2p.Handler),
+	}
+	network, err := p2p.NewNetwork(logging.NoLog{}, sender, prometheus.NewRegistry(), "")
+	require.NoError(err)
+	sender.network = network
+
+	vdrs := make([]*warp.Validator, 2)
+	for i := range vdrs {
+		sk, err := bls.NewSecretKey()
+		require.NoError(err)
+		pk := bls.PublicFromSecretKey(sk)
+		nodeID := ids.GenerateTestNodeID()
+		vdrs[i] = &warp.Validator{
+			PublicKey:      pk,
+			PublicKeyBytes: bls.PublicKeyToUncompressedBytes(pk),
+			Weight:         1,
+			NodeIDs:        []ids.NodeID{nodeID},
+		}
+
+		verifier := verifierFunc(acceptAll)
+		if i == 1 {
+			verifier = func(context.Context, *warp.UnsignedMessage, []byte) error {
+				<-blocked
+				return errTest
+			}
+		}
+		signer := warp.NewSigner(bls.NewLocalSigner(sk), constants.UnitTestID, sourceChainID)
+		sender.handlers[nodeID] = NewHandler(verifier, signer)
+	}
+
+	aggregator := New(logging.NoLog{}, network.NewClient(HandlerID))
+	signedMsg, err := aggregator.AggregateSignatures(ctx, msg, nil, vdrs, 2, 1, 2)
+	require.NoError(err)
+
+	numSigners, err := signedMsg.Signature.NumSigners()
+	require.NoError(err)
+	require.Equal(1, numSigners)
+}
+
+func TestAggregateSignaturesContextCanceled(t *testing.T) {
+	require := require.New(t)
+
+	msg, err := warp.NewUnsignedMessage(constants.UnitTestID, sourceChainID, []byte("payload"))
+	require.NoError(err)
+
+	sk, err := bls.NewSecretKey()
+	require.NoError(err)
+	pk := bls.PublicFromSecretKey(sk)
+	vdrs := []*warp.Validator{
+		{
+			PublicKey:      pk,
+			PublicKeyBytes: bls.PublicKeyToUncompressedBytes(pk),
+			Weight:         1,
+			NodeIDs:        []ids.NodeID{ids.GenerateTestNodeID()},
+		},
+	}
+
+	// Requests are never answered.
+	network, err := p2p.NewNetwork(logging.NoLog{}, common.FakeSender{}, prometheus.NewRegistry(), "")
+	require.NoError(err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	aggregator := New(logging.NoLog{}, network.NewClient(HandlerID))
+	_, err = aggregator.AggregateSignatures(ctx, msg, nil, vdrs, 1, 1, 1)
+	require.ErrorIs(err, context.Canceled)
+}
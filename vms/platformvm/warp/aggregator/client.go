@@ -0,0 +1,86 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package aggregator
+
+import (
+	"context"
+
+	"github.com/ava-labs/avalanchego/utils/formatting"
+	"github.com/ava-labs/avalanchego/utils/rpc"
+	"github.com/ava-labs/avalanchego/vms/platformvm/warp"
+
+	avajson "github.com/ava-labs/avalanchego/utils/json"
+)
+
+var _ Client = (*client)(nil)
+
+// Client for interacting with a standalone signature aggregator
+type Client interface {
+	// AggregateSignatures returns [msg] signed by at least
+	// [quorumNum]/[quorumDen] of the stake of its source subnet at [height],
+	// along with the P-chain height the signature can be verified at. If
+	// [height] is 0, the current P-chain height is used.
+	AggregateSignatures(
+		ctx context.Context,
+		msg *warp.UnsignedMessage,
+		justification []byte,
+		height uint64,
+		quorumNum uint64,
+		quorumDen uint64,
+		options ...rpc.Option,
+	) (*warp.Message, uint64, error)
+}
+
+type client struct {
+	requester rpc.EndpointRequester
+}
+
+// NewClient returns a client to interact with the aggregator API at [uri]
+func NewClient(uri string) Client {
+	return &client{requester: rpc.NewEndpointRequester(
+		uri + "/ext/aggregator",
+	)}
+}
+
+func (c *client) AggregateSignatures(
+	ctx context.Context,
+	msg *warp.UnsignedMessage,
+	justification []byte,
+	height uint64,
+	quorumNum uint64,
+	quorumDen uint64,
+	options ...rpc.Option,
+) (*warp.Message, uint64, error) {
+	msgStr, err := formatting.Encode(formatting.Hex, msg.Bytes())
+	if err != nil {
+		return nil, 0, err
+	}
+	var justificationStr string
+	if len(justification) > 0 {
+		justificationStr, err = formatting.Encode(formatting.Hex, justification)
+		if err != nil {
+			return nil, 0, err
+		}
+	}
+
+	res := &AggregateSignaturesReply{}
+	err = c.requester.SendRequest(ctx, "aggregator.aggregateSignatures", &AggregateSignaturesArgs{
+		Message:       msgStr,
+		Justification: justificationStr,
+		Height:        avajson.Uint64(height),
+		QuorumNum:     avajson.Uint64(quorumNum),
+		QuorumDen:     avajson.Uint64(quorumDen),
+		Encoding:      formatting.Hex,
+	}, res, options...)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	signedBytes, err := formatting.Decode(res.Encoding, res.Message)
+	if err != nil {
+		return nil, 0, err
+	}
+	signedMsg, err := warp.ParseMessage(signedBytes)
+	return signedMsg, uint64(res.Height), err
+}
@@ -0,0 +1,248 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/gorilla/rpc/v2"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/spf13/cobra"
+	"go.uber.org/zap"
+
+	"github.com/ava-labs/avalanchego/api/info"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/message"
+	"github.com/ava-labs/avalanchego/network"
+	"github.com/ava-labs/avalanchego/snow/validators"
+	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/ava-labs/avalanchego/utils/ips"
+	"github.com/ava-labs/avalanchego/utils/json"
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/ava-labs/avalanchego/utils/set"
+	"github.com/ava-labs/avalanchego/vms/platformvm"
+	"github.com/ava-labs/avalanchego/vms/platformvm/warp/aggregator"
+)
+
+const readHeaderTimeout = 10 * time.Second
+
+var errURIRequired = errors.New("--uri is required")
+
+func main() {
+	var (
+		uri                       string
+		httpAddress               string
+		requestTimeout            time.Duration
+		validatorRefreshFrequency time.Duration
+	)
+	rootCmd := &cobra.Command{
+		Use:   "aggregator",
+		Short: "Serve an API that aggregates warp signatures from subnet validators",
+		RunE: func(*cobra.Command, []string) error {
+			if len(uri) == 0 {
+				return errURIRequired
+			}
+
+			ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+			defer cancel()
+
+			log := logging.NewLogger(
+				"aggregator",
+				logging.NewWrappedCore(logging.Info, os.Stdout, logging.Plain.ConsoleEncoder()),
+			)
+			return run(ctx, log, uri, httpAddress, requestTimeout, validatorRefreshFrequency)
+		},
+	}
+	rootCmd.PersistentFlags().StringVar(&uri, "uri", "http://localhost:9650", "API URI of the node that validator sets and peers are fetched from")
+	rootCmd.PersistentFlags().StringVar(&httpAddress, "http-address", "127.0.0.1:9660", "Address to serve the aggregator API on")
+	rootCmd.PersistentFlags().DurationVar(&requestTimeout, "request-timeout", 5*time.Second, "How long to wait for a validator to provide a signature")
+	rootCmd.PersistentFlags().DurationVar(&validatorRefreshFrequency, "validator-refresh-frequency", time.Minute, "How frequently to refresh the primary network validator set")
+
+	if err := rootCmd.Execute(); err != nil {
+		fmt.Fprintf(os.Stderr, "aggregator failed: %v\n", err)
+		os.Exit(1)
+	}
+	os.Exit(0)
+}
+
+func run(
+	ctx context.Context,
+	log logging.Logger,
+	uri string,
+	httpAddress string,
+	requestTimeout time.Duration,
+	validatorRefreshFrequency time.Duration,
+) error {
+	infoClient := info.NewClient(uri)
+	networkID, err := infoClient.GetNetworkID(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get networkID: %w", err)
+	}
+
+	state := &apiState{
+		client: platformvm.NewClient(uri),
+	}
+
+	// The network only connects to, and learns the IPs of, primary network
+	// validators.
+	vdrs := validators.NewManager()
+	if err := syncValidators(ctx, state, vdrs); err != nil {
+		return fmt.Errorf("failed to fetch validators: %w", err)
+	}
+
+	msgCreator, err := message.NewCreator(
+		logging.NoLog{},
+		prometheus.NewRegistry(),
+		"",
+		constants.DefaultNetworkCompressionType,
+		constants.DefaultNetworkMaximumInboundTimeout,
+	)
+	if err != nil {
+		return err
+	}
+
+	appNetwork := newAppNetwork(log, msgCreator, requestTimeout)
+	peerNetwork, err := network.NewTestNetwork(
+		log,
+		networkID,
+		vdrs,
+		set.Set[ids.ID]{},
+		appNetwork,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create network: %w", err)
+	}
+	appNetwork.network = peerNetwork
+
+	if err := trackPeers(ctx, infoClient, peerNetwork); err != nil {
+		return fmt.Errorf("failed to fetch peers: %w", err)
+	}
+
+	dispatchErr := make(chan error, 1)
+	go func() {
+		dispatchErr <- peerNetwork.Dispatch()
+	}()
+	defer peerNetwork.StartClose()
+
+	go func() {
+		ticker := time.NewTicker(validatorRefreshFrequency)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+
+			if err := syncValidators(ctx, state, vdrs); err != nil {
+				log.Warn("failed to refresh validators", zap.Error(err))
+			}
+		}
+	}()
+
+	server := rpc.NewServer()
+	server.RegisterCodec(json.NewCodec(), "application/json")
+	server.RegisterCodec(json.NewCodec(), "application/json;charset=UTF-8")
+	service := aggregator.NewService(log, networkID, state, appNetwork)
+	if err := server.RegisterService(service, "aggregator"); err != nil {
+		return err
+	}
+
+	mux := http.NewServeMux()
+	mux.Handle("/ext/aggregator", server)
+	httpServer := &http.Server{
+		Addr:              httpAddress,
+		Handler:           mux,
+		ReadHeaderTimeout: readHeaderTimeout,
+	}
+
+	serverErr := make(chan error, 1)
+	go func() {
+		log.Info("serving aggregator API",
+			zap.Uint32("networkID", networkID),
+			zap.String("address", httpAddress),
+		)
+		serverErr <- httpServer.ListenAndServe()
+	}()
+
+	select {
+	case <-ctx.Done():
+		return httpServer.Shutdown(context.Background())
+	case err := <-serverErr:
+		return err
+	case err := <-dispatchErr:
+		return errors.Join(err, httpServer.Shutdown(context.Background()))
+	}
+}
+
+// trackPeers connects to the node at the API and to each of its peers. The
+// rest of the network is discovered through peer list gossip.
+func trackPeers(ctx context.Context, client info.Client, peerNetwork network.Network) error {
+	nodeID, _, err := client.GetNodeID(ctx)
+	if err != nil {
+		return err
+	}
+	nodeIPStr, err := client.GetNodeIP(ctx)
+	if err != nil {
+		return err
+	}
+	nodeIP, err := ips.ToIPPort(nodeIPStr)
+	if err != nil {
+		return err
+	}
+	peerNetwork.ManuallyTrack(nodeID, nodeIP)
+
+	peers, err := client.Peers(ctx)
+	if err != nil {
+		return err
+	}
+	for _, peer := range peers {
+		peerIP, err := ips.ToIPPort(peer.PublicIP)
+		if err != nil {
+			continue
+		}
+		peerNetwork.ManuallyTrack(peer.ID, peerIP)
+	}
+	return nil
+}
+
+// syncValidators updates [vdrs] to contain the current primary network
+// validators.
+func syncValidators(ctx context.Context, state validators.State, vdrs validators.Manager) error {
+	height, err := state.GetCurrentHeight(ctx)
+	if err != nil {
+		return err
+	}
+	current, err := state.GetValidatorSet(ctx, height, constants.PrimaryNetworkID)
+	if err != nil {
+		return err
+	}
+
+	for _, nodeID := range vdrs.GetValidatorIDs(constants.PrimaryNetworkID) {
+		if _, ok := current[nodeID]; ok {
+			continue
+		}
+		weight := vdrs.GetWeight(constants.PrimaryNetworkID, nodeID)
+		if err := vdrs.RemoveWeight(constants.PrimaryNetworkID, nodeID, weight); err != nil {
+			return err
+		}
+	}
+	for nodeID, vdr := range current {
+		if _, ok := vdrs.GetValidator(constants.PrimaryNetworkID, nodeID); ok {
+			continue
+		}
+		if err := vdrs.AddStaker(constants.PrimaryNetworkID, nodeID, vdr.PublicKey, ids.Empty, vdr.Weight); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,239 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package main
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"go.uber.org/zap"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/message"
+	"github.com/ava-labs/avalanchego/network"
+	"github.com/ava-labs/avalanchego/network/p2p"
+	"github.com/ava-labs/avalanchego/snow/engine/common"
+	"github.com/ava-labs/avalanchego/snow/networking/router"
+	"github.com/ava-labs/avalanchego/subnets"
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/ava-labs/avalanchego/utils/set"
+	"github.com/ava-labs/avalanchego/version"
+	"github.com/ava-labs/avalanchego/vms/platformvm/warp/aggregator"
+
+	p2ppb "github.com/ava-labs/avalanchego/proto/pb/p2p"
+)
+
+var (
+	_ router.ExternalHandler = (*appNetwork)(nil)
+	_ aggregator.Clients     = (*appNetwork)(nil)
+	_ common.AppSender       = (*appSender)(nil)
+)
+
+// appNetwork routes the AppRequests issued by the aggregator over the
+// peer-to-peer network and routes the responses back to the per-chain p2p
+// networks. Unlike a node, there are no chain handlers to time out requests,
+// so appNetwork is responsible for failing requests that aren't answered
+// within [timeout].
+type appNetwork struct {
+	log        logging.Logger
+	msgCreator message.OutboundMsgBuilder
+	timeout    time.Duration
+
+	// network must be set before any clients are created.
+	network network.Network
+
+	lock   sync.Mutex
+	chains map[ids.ID]*appSender
+}
+
+func newAppNetwork(
+	log logging.Logger,
+	msgCreator message.OutboundMsgBuilder,
+	timeout time.Duration,
+) *appNetwork {
+	return &appNetwork{
+		log:        log,
+		msgCreator: msgCreator,
+		timeout:    timeout,
+		chains:     make(map[ids.ID]*appSender),
+	}
+}
+
+func (n *appNetwork) Client(chainID ids.ID, subnetID ids.ID) (*p2p.Client, error) {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+
+	if chain, ok := n.chains[chainID]; ok {
+		return chain.client, nil
+	}
+
+	chain := &appSender{
+		network:  n,
+		chainID:  chainID,
+		subnetID: subnetID,
+		pending:  make(map[uint32]*pendingRequest),
+	}
+	p2pNetwork, err := p2p.NewNetwork(n.log, chain, prometheus.NewRegistry(), "")
+	if err != nil {
+		return nil, err
+	}
+	chain.p2pNetwork = p2pNetwork
+	chain.client = p2pNetwork.NewClient(aggregator.HandlerID)
+	n.chains[chainID] = chain
+	return chain.client, nil
+}
+
+func (n *appNetwork) HandleInbound(ctx context.Context, msg message.InboundMessage) {
+	defer msg.OnFinishedHandling()
+
+	var (
+		nodeID = msg.NodeID()
+		err    error
+	)
+	switch m := msg.Message().(type) {
+	case *p2ppb.AppResponse:
+		chain, ok := n.getChain(m.ChainId)
+		if !ok || !chain.clearRequest(nodeID, m.RequestId) {
+			return
+		}
+		err = chain.p2pNetwork.AppResponse(ctx, nodeID, m.RequestId, m.AppBytes)
+	case *p2ppb.AppError:
+		chain, ok := n.getChain(m.ChainId)
+		if !ok || !chain.clearRequest(nodeID, m.RequestId) {
+			return
+		}
+		err = chain.p2pNetwork.AppRequestFailed(ctx, nodeID, m.RequestId, &common.AppError{
+			Code:    m.ErrorCode,
+			Message: m.ErrorMessage,
+		})
+	default:
+		return
+	}
+	if err != nil {
+		n.log.Debug("failed to handle message",
+			zap.Stringer("messageOp", msg.Op()),
+			zap.Stringer("nodeID", nodeID),
+			zap.Error(err),
+		)
+	}
+}
+
+func (*appNetwork) Connected(ids.NodeID, *version.Application, ids.ID) {}
+
+func (*appNetwork) Disconnected(ids.NodeID) {}
+
+func (n *appNetwork) getChain(chainIDBytes []byte) (*appSender, bool) {
+	chainID, err := ids.ToID(chainIDBytes)
+	if err != nil {
+		return nil, false
+	}
+
+	n.lock.Lock()
+	defer n.lock.Unlock()
+
+	chain, ok := n.chains[chainID]
+	return chain, ok
+}
+
+type pendingRequest struct {
+	nodeID ids.NodeID
+	timer  *time.Timer
+}
+
+// appSender sends the AppRequests of a single chain. Only AppRequests are
+// supported because the aggregator never responds to or gossips messages.
+type appSender struct {
+	common.AppSender
+
+	network    *appNetwork
+	chainID    ids.ID
+	subnetID   ids.ID
+	p2pNetwork *p2p.Network
+	client     *p2p.Client
+
+	lock    sync.Mutex
+	pending map[uint32]*pendingRequest
+}
+
+func (s *appSender) SendAppRequest(
+	_ context.Context,
+	nodeIDs set.Set[ids.NodeID],
+	requestID uint32,
+	appRequestBytes []byte,
+) error {
+	msg, err := s.network.msgCreator.AppRequest(
+		s.chainID,
+		requestID,
+		s.network.timeout,
+		appRequestBytes,
+	)
+	if err != nil {
+		return err
+	}
+
+	sentTo := s.network.network.Send(
+		msg,
+		common.SendConfig{
+			NodeIDs: nodeIDs,
+		},
+		s.subnetID,
+		subnets.NoOpAllower,
+	)
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	for nodeID := range nodeIDs {
+		// The p2p network holds its lock while sending requests, so failures
+		// must be reported asynchronously.
+		timeout := s.network.timeout
+		if !sentTo.Contains(nodeID) {
+			timeout = 0
+		}
+		s.pending[requestID] = &pendingRequest{
+			nodeID: nodeID,
+			timer: time.AfterFunc(timeout, func() {
+				s.timeout(nodeID, requestID)
+			}),
+		}
+	}
+	return nil
+}
+
+func (s *appSender) timeout(nodeID ids.NodeID, requestID uint32) {
+	if !s.clearRequest(nodeID, requestID) {
+		return
+	}
+
+	err := s.p2pNetwork.AppRequestFailed(
+		context.Background(),
+		nodeID,
+		requestID,
+		common.ErrTimeout,
+	)
+	if err != nil {
+		s.network.log.Debug("failed to time out request",
+			zap.Stringer("nodeID", nodeID),
+			zap.Uint32("requestID", requestID),
+			zap.Error(err),
+		)
+	}
+}
+
+// clearRequest returns true if the request was pending and marks it as no
+// longer pending.
+func (s *appSender) clearRequest(nodeID ids.NodeID, requestID uint32) bool {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	request, ok := s.pending[requestID]
+	if !ok || request.nodeID != nodeID {
+		return false
+	}
+	request.timer.Stop()
+	delete(s.pending, requestID)
+	return true
+}
@@ -0,0 +1,41 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package main
+
+import (
+	"context"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow/validators"
+	"github.com/ava-labs/avalanchego/vms/platformvm"
+)
+
+var _ validators.State = (*apiState)(nil)
+
+// apiState fetches validator sets from the P-chain API of a node.
+type apiState struct {
+	client platformvm.Client
+}
+
+// GetMinimumHeight returns the current height because the API doesn't expose
+// the proposal window.
+func (s *apiState) GetMinimumHeight(ctx context.Context) (uint64, error) {
+	return s.client.GetHeight(ctx)
+}
+
+func (s *apiState) GetCurrentHeight(ctx context.Context) (uint64, error) {
+	return s.client.GetHeight(ctx)
+}
+
+func (s *apiState) GetSubnetID(ctx context.Context, chainID ids.ID) (ids.ID, error) {
+	return s.client.ValidatedBy(ctx, chainID)
+}
+
+func (s *apiState) GetValidatorSet(
+	ctx context.Context,
+	height uint64,
+	subnetID ids.ID,
+) (map[ids.NodeID]*validators.GetValidatorOutput, error) {
+	return s.client.GetValidatorsAt(ctx, subnetID, height)
+}
@@ -0,0 +1,71 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package aggregator
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"google.golang.org/protobuf/proto"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/network/p2p"
+	"github.com/ava-labs/avalanchego/proto/pb/sdk"
+	"github.com/ava-labs/avalanchego/vms/platformvm/warp"
+)
+
+// HandlerID is the p2p handler ID that signature requests are sent to.
+const HandlerID = 2
+
+var _ p2p.Handler = (*Handler)(nil)
+
+// Verifier verifies that an unsigned warp message should be signed.
+type Verifier interface {
+	// Verify returns nil if [msg] should be signed given the application
+	// specific [justification].
+	Verify(ctx context.Context, msg *warp.UnsignedMessage, justification []byte) error
+}
+
+// NewHandler returns a p2p handler that signs the warp messages that are
+// approved by [verifier] with [signer].
+func NewHandler(verifier Verifier, signer warp.Signer) *Handler {
+	return &Handler{
+		Handler:  p2p.NoOpHandler{},
+		verifier: verifier,
+		signer:   signer,
+	}
+}
+
+// Handler responds to signature requests issued by an Aggregator.
+type Handler struct {
+	p2p.Handler
+	verifier Verifier
+	signer   warp.Signer
+}
+
+func (h *Handler) AppRequest(ctx context.Context, _ ids.NodeID, _ time.Time, requestBytes []byte) ([]byte, error) {
+	request := &sdk.SignatureRequest{}
+	if err := proto.Unmarshal(requestBytes, request); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal request: %w", err)
+	}
+
+	msg, err := warp.ParseUnsignedMessage(request.Message)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse warp message: %w", err)
+	}
+
+	if err := h.verifier.Verify(ctx, msg, request.Justification); err != nil {
+		return nil, fmt.Errorf("failed to verify warp message: %w", err)
+	}
+
+	signature, err := h.signer.Sign(msg)
+	if err != nil {
+		return nil, fmt.Errorf("failed to sign warp message: %w", err)
+	}
+
+	return proto.Marshal(&sdk.SignatureResponse{
+		Signature: signature,
+	})
+}
@@ -0,0 +1,114 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package aggregator
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/proto/pb/sdk"
+	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/ava-labs/avalanchego/utils/crypto/bls"
+	"github.com/ava-labs/avalanchego/vms/platformvm/warp"
+)
+
+var (
+	_ Verifier = verifierFunc(nil)
+
+	errTest = errors.New("non-nil error")
+)
+
+type verifierFunc func(ctx context.Context, msg *warp.UnsignedMessage, justification []byte) error
+
+func (f verifierFunc) Verify(ctx context.Context, msg *warp.UnsignedMessage, justification []byte) error {
+	return f(ctx, msg, justification)
+}
+
+func acceptAll(context.Context, *warp.UnsignedMessage, []byte) error {
+	return nil
+}
+
+func TestHandler(t *testing.T) {
+	chainID := ids.GenerateTestID()
+	msg, err := warp.NewUnsignedMessage(constants.UnitTestID, chainID, []byte("payload"))
+	require.NoError(t, err)
+	otherChainMsg, err := warp.NewUnsignedMessage(constants.UnitTestID, ids.GenerateTestID(), []byte("payload"))
+	require.NoError(t, err)
+
+	justification := []byte("justification")
+
+	tests := []struct {
+		name        string
+		verifier    Verifier
+		request     []byte
+		expectedErr error
+	}{
+		{
+			name:     "valid request",
+			verifier: verifierFunc(acceptAll),
+			request: func() []byte {
+				bytes, err := proto.Marshal(&sdk.SignatureRequest{
+					Message:       msg.Bytes(),
+					Justification: justification,
+				})
+				require.NoError(t, err)
+				return bytes
+			}(),
+		},
+		{
+			name: "verification failed",
+			verifier: verifierFunc(func(_ context.Context, _ *warp.UnsignedMessage, gotJustification []byte) error {
+				require.Equal(t, justification, gotJustification)
+				return errTest
+			}),
+			request: func() []byte {
+				bytes, err := proto.Marshal(&sdk.SignatureRequest{
+					Message:       msg.Bytes(),
+					Justification: justification,
+				})
+				require.NoError(t, err)
+				return bytes
+			}(),
+			expectedErr: errTest,
+		},
+		{
+			name:     "signing failed",
+			verifier: verifierFunc(acceptAll),
+			request: func() []byte {
+				bytes, err := proto.Marshal(&sdk.SignatureRequest{
+					Message: otherChainMsg.Bytes(),
+				})
+				require.NoError(t, err)
+				return bytes
+			}(),
+			expectedErr: warp.ErrWrongSourceChainID,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require := require.New(t)
+
+			sk, err := bls.NewSecretKey()
+			require.NoError(err)
+			signer := warp.NewSigner(bls.NewLocalSigner(sk), constants.UnitTestID, chainID)
+
+			handler := NewHandler(test.verifier, signer)
+			responseBytes, err := handler.AppRequest(context.Background(), ids.GenerateTestNodeID(), time.Time{}, test.request)
+			require.ErrorIs(err, test.expectedErr)
+			if test.expectedErr != nil {
+				return
+			}
+
+			signature, err := parseSignature(responseBytes)
+			require.NoError(err)
+			require.True(bls.Verify(bls.PublicFromSecretKey(sk), signature, msg.Bytes()))
+		})
+	}
+}
@@ -0,0 +1,156 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package aggregator
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"go.uber.org/zap"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/network/p2p"
+	"github.com/ava-labs/avalanchego/snow/validators"
+	"github.com/ava-labs/avalanchego/utils/formatting"
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/ava-labs/avalanchego/vms/platformvm/warp"
+
+	avajson "github.com/ava-labs/avalanchego/utils/json"
+)
+
+var errInvalidQuorum = errors.New("quorum must satisfy 0 < quorumNum <= quorumDen")
+
+// Clients provides the p2p clients that signature requests are sent with.
+type Clients interface {
+	// Client returns a client, registered with [HandlerID], that sends
+	// requests to [chainID] on the validators of [subnetID].
+	Client(chainID ids.ID, subnetID ids.ID) (*p2p.Client, error)
+}
+
+// NewService returns the API service of a standalone signature aggregator.
+func NewService(
+	log logging.Logger,
+	networkID uint32,
+	state validators.State,
+	clients Clients,
+) *Service {
+	return &Service{
+		log:       log,
+		networkID: networkID,
+		state:     state,
+		clients:   clients,
+	}
+}
+
+type Service struct {
+	log       logging.Logger
+	networkID uint32
+	state     validators.State
+	clients   Clients
+}
+
+// AggregateSignaturesArgs are the arguments for AggregateSignatures.
+type AggregateSignaturesArgs struct {
+	// Message is the encoded unsigned warp message.
+	Message string `json:"message"`
+	// Justification is the encoded justification passed to the signers.
+	Justification string `json:"justification"`
+	// Height is the P-chain height the validator set is taken from. If 0, the
+	// current P-chain height is used.
+	Height    avajson.Uint64      `json:"height"`
+	QuorumNum avajson.Uint64      `json:"quorumNum"`
+	QuorumDen avajson.Uint64      `json:"quorumDen"`
+	Encoding  formatting.Encoding `json:"encoding"`
+}
+
+// AggregateSignaturesReply is the response from AggregateSignatures.
+type AggregateSignaturesReply struct {
+	// Message is the encoded signed warp message.
+	Message string `json:"message"`
+	// Height is the P-chain height the signature can be verified at.
+	Height   avajson.Uint64      `json:"height"`
+	Encoding formatting.Encoding `json:"encoding"`
+}
+
+// AggregateSignatures collects signatures over an unsigned warp message from
+// the validators of its source subnet until the requested quorum is reached.
+func (s *Service) AggregateSignatures(r *http.Request, args *AggregateSignaturesArgs, reply *AggregateSignaturesReply) error {
+	s.log.Debug("API called",
+		zap.String("service", "aggregator"),
+		zap.String("method", "aggregateSignatures"),
+		zap.Uint64("height", uint64(args.Height)),
+	)
+
+	quorumNum := uint64(args.QuorumNum)
+	quorumDen := uint64(args.QuorumDen)
+	if quorumNum == 0 || quorumNum > quorumDen {
+		return errInvalidQuorum
+	}
+
+	msgBytes, err := formatting.Decode(args.Encoding, args.Message)
+	if err != nil {
+		return fmt.Errorf("failed to decode message: %w", err)
+	}
+	var justification []byte
+	if len(args.Justification) > 0 {
+		justification, err = formatting.Decode(args.Encoding, args.Justification)
+		if err != nil {
+			return fmt.Errorf("failed to decode justification: %w", err)
+		}
+	}
+
+	msg, err := warp.ParseUnsignedMessage(msgBytes)
+	if err != nil {
+		return fmt.Errorf("failed to parse message: %w", err)
+	}
+	if msg.NetworkID != s.networkID {
+		return warp.ErrWrongNetworkID
+	}
+
+	ctx := r.Context()
+	height := uint64(args.Height)
+	if height == 0 {
+		height, err = s.state.GetCurrentHeight(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to get current height: %w", err)
+		}
+	}
+
+	subnetID, err := s.state.GetSubnetID(ctx, msg.SourceChainID)
+	if err != nil {
+		return fmt.Errorf("failed to get subnetID of %s: %w", msg.SourceChainID, err)
+	}
+
+	vdrs, totalWeight, err := warp.GetCanonicalValidatorSet(ctx, s.state, height, subnetID)
+	if err != nil {
+		return fmt.Errorf("failed to get validator set: %w", err)
+	}
+
+	client, err := s.clients.Client(msg.SourceChainID, subnetID)
+	if err != nil {
+		return err
+	}
+
+	signedMsg, err := New(s.log, client).AggregateSignatures(
+		ctx,
+		msg,
+		justification,
+		vdrs,
+		totalWeight,
+		quorumNum,
+		quorumDen,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to aggregate signatures: %w", err)
+	}
+
+	reply.Message, err = formatting.Encode(args.Encoding, signedMsg.Bytes())
+	if err != nil {
+		return fmt.Errorf("failed to encode message: %w", err)
+	}
+	reply.Height = avajson.Uint64(height)
+	reply.Encoding = args.Encoding
+	return nil
+}
@@ -20,7 +20,7 @@ func TestSigner(t *testing.T) {
 			require.NoError(t, err)
 
 			chainID := ids.GenerateTestID()
-			s := NewSigner(sk, constants.UnitTestID, chainID)
+			s := NewSigner(bls.NewLocalSigner(sk), constants.UnitTestID, chainID)
 
 			test(t, s, sk, constants.UnitTestID, chainID)
 		})
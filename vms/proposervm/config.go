@@ -8,6 +8,7 @@ import (
 	"time"
 
 	"github.com/ava-labs/avalanchego/staking"
+	"github.com/ava-labs/avalanchego/vms/proposervm/proposer"
 )
 
 type Config struct {
@@ -32,6 +33,10 @@ type Config struct {
 
 	// Block certificate
 	StakingCertLeaf *staking.Certificate
+
+	// Policy that proposers are selected with. Defaults to
+	// [proposer.StakeWeighted] if nil.
+	ProposerPolicy proposer.Policy
 }
 
 func (c *Config) IsDurangoActivated(timestamp time.Time) bool {
@@ -1301,3 +1301,109 @@ func TestBlockVerify_PostForkBlock_PChainTooLow(t *testing.T) {
 	err = invalidChild.Verify(context.Background())
 	require.ErrorIs(err, errPChainHeightTooLow)
 }
+
+func TestBlockVerify_PostForkBlock_RoundRobinProposer(t *testing.T) {
+	require := require.New(t)
+
+	var (
+		activationTime = time.Unix(0, 0)
+		durangoTime    = activationTime // post Durango
+	)
+	coreVM, valState, proVM, coreGenBlk, _ := initTestProposerVM(t, activationTime, durangoTime, 0)
+	defer func() {
+		require.NoError(proVM.Shutdown(context.Background()))
+	}()
+	proVM.Windower = proposer.NewWithPolicy(valState, proVM.ctx.SubnetID, proVM.ctx.ChainID, proposer.RoundRobin)
+
+	pChainHeight := uint64(100)
+	valState.GetCurrentHeightF = func(context.Context) (uint64, error) {
+		return pChainHeight, nil
+	}
+
+	parentCoreBlk := &snowman.TestBlock{
+		TestDecidable: choices.TestDecidable{
+			IDV:     ids.Empty.Prefix(1111),
+			StatusV: choices.Processing,
+		},
+		BytesV:  []byte{1},
+		ParentV: coreGenBlk.ID(),
+		HeightV: coreGenBlk.Height() + 1,
+	}
+	coreVM.BuildBlockF = func(context.Context) (snowman.Block, error) {
+		return parentCoreBlk, nil
+	}
+	coreVM.GetBlockF = func(_ context.Context, blkID ids.ID) (snowman.Block, error) {
+		switch blkID {
+		case coreGenBlk.ID():
+			return coreGenBlk, nil
+		case parentCoreBlk.ID():
+			return parentCoreBlk, nil
+		default:
+			return nil, database.ErrNotFound
+		}
+	}
+
+	require.NoError(waitForProposerWindow(proVM, coreGenBlk, pChainHeight))
+	parentBlk, err := proVM.BuildBlock(context.Background())
+	require.NoError(err)
+	require.NoError(parentBlk.Verify(context.Background()))
+
+	childCoreBlk := &snowman.TestBlock{
+		ParentV: parentCoreBlk.ID(),
+		BytesV:  []byte{2},
+		HeightV: parentCoreBlk.Height() + 1,
+	}
+	parentPChainHeight := parentBlk.(*postForkBlock).PChainHeight()
+
+	// The validators take turns proposing, so this node is expected to propose
+	// exactly one of any 4 consecutive slots.
+	var (
+		expectedSlots   []uint64
+		unexpectedSlots []uint64
+	)
+	for slot := uint64(0); slot < 4; slot++ {
+		expectedProposer, err := proVM.ExpectedProposer(
+			context.Background(),
+			childCoreBlk.Height(),
+			parentPChainHeight,
+			slot,
+		)
+		require.NoError(err)
+		if expectedProposer == proVM.ctx.NodeID {
+			expectedSlots = append(expectedSlots, slot)
+		} else {
+			unexpectedSlots = append(unexpectedSlots, slot)
+		}
+	}
+	require.Len(expectedSlots, 1)
+
+	buildChild := func(slot uint64) *postForkBlock {
+		timestamp := parentBlk.Timestamp().Add(time.Duration(slot) * proposer.WindowDuration)
+		proVM.Set(timestamp)
+
+		childSlb, err := block.Build(
+			parentBlk.ID(),
+			timestamp,
+			parentPChainHeight,
+			proVM.StakingCertLeaf,
+			childCoreBlk.Bytes(),
+			proVM.ctx.ChainID,
+			proVM.StakingLeafSigner,
+		)
+		require.NoError(err)
+		return &postForkBlock{
+			SignedBlock: childSlb,
+			postForkCommonComponents: postForkCommonComponents{
+				vm:       proVM,
+				innerBlk: childCoreBlk,
+				status:   choices.Processing,
+			},
+		}
+	}
+
+	for _, slot := range unexpectedSlots {
+		err := buildChild(slot).Verify(context.Background())
+		require.ErrorIs(err, errUnexpectedProposer)
+	}
+	require.NoError(buildChild(expectedSlots[0]).Verify(context.Background()))
+}
@@ -0,0 +1,301 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package proposer
+
+import (
+	"errors"
+	"fmt"
+	"math/bits"
+
+	"gonum.org/v1/gonum/mathext/prng"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/math"
+	"github.com/ava-labs/avalanchego/utils/sampler"
+)
+
+const (
+	StakeWeightedPolicy       PolicyName = "stake-weighted"
+	UniformPolicy             PolicyName = "uniform"
+	RoundRobinPolicy          PolicyName = "round-robin"
+	CappedStakeWeightedPolicy PolicyName = "capped-stake-weighted"
+)
+
+var (
+	_ Policy   = (*weightedPolicy)(nil)
+	_ Policy   = roundRobinPolicy{}
+	_ Schedule = (*weightedSchedule)(nil)
+	_ Schedule = (*roundRobinSchedule)(nil)
+
+	// StakeWeighted samples proposers weighted by their stake.
+	StakeWeighted Policy = &weightedPolicy{
+		weights: stakeWeights,
+	}
+	// Uniform samples proposers with equal probability, regardless of their
+	// stake.
+	Uniform Policy = &weightedPolicy{
+		weights: uniformWeights,
+	}
+	// RoundRobin rotates through the validators, sorted by nodeID, by block
+	// height.
+	RoundRobin Policy = roundRobinPolicy{}
+
+	ErrUnknownPolicy                 = errors.New("unknown proposer policy")
+	ErrInvalidMaxWeightPercentage    = errors.New("max weight percentage must be in [1, 100]")
+	errUnexpectedMaxWeightPercentage = errors.New("max weight percentage is only supported by the " + string(CappedStakeWeightedPolicy) + " policy")
+)
+
+// PolicyName identifies a Policy in a PolicyConfig.
+type PolicyName string
+
+// Policy selects the proposers of blocks from a validator set.
+//
+// The validators passed to a Policy are sorted by nodeID and every node must
+// select the same proposers, so a Policy must be deterministic.
+type Policy interface {
+	// Proposers returns the Pre-Durango proposer list for building a block at
+	// [blockHeight] of the chain identified by [chainSource]. At most
+	// [maxWindows] proposers are returned, in order.
+	Proposers(
+		validators []Validator,
+		chainSource,
+		blockHeight uint64,
+		maxWindows int,
+	) ([]ids.NodeID, error)
+
+	// Schedule returns the Post-Durango schedule of the chain identified by
+	// [chainSource].
+	//
+	// Invariant: [validators] is not empty.
+	Schedule(validators []Validator, chainSource uint64) (Schedule, error)
+}
+
+// Schedule assigns the Post-Durango slots of a block to proposers.
+//
+// A Schedule isn't safe for concurrent use.
+type Schedule interface {
+	// ExpectedProposer returns the nodeID that is scheduled to propose a block
+	// of height [blockHeight] at [slot].
+	ExpectedProposer(blockHeight, slot uint64) (ids.NodeID, error)
+}
+
+// PolicyConfig specifies the Policy that a subnet selects proposers with.
+type PolicyConfig struct {
+	// Policy defaults to [StakeWeightedPolicy] if empty.
+	Policy PolicyName `json:"policy" yaml:"policy"`
+	// MaxWeightPercentage is the maximum percentage of the total stake that
+	// a single validator's weight is capped to by the
+	// [CappedStakeWeightedPolicy].
+	MaxWeightPercentage uint64 `json:"maxWeightPercentage" yaml:"maxWeightPercentage"`
+}
+
+func (c PolicyConfig) Verify() error {
+	_, err := c.NewPolicy()
+	return err
+}
+
+// NewPolicy returns the Policy specified by the config.
+func (c PolicyConfig) NewPolicy() (Policy, error) {
+	if c.Policy == CappedStakeWeightedPolicy {
+		return NewCappedStakeWeighted(c.MaxWeightPercentage)
+	}
+	if c.MaxWeightPercentage != 0 {
+		return nil, fmt.Errorf("%w: got %q", errUnexpectedMaxWeightPercentage, c.Policy)
+	}
+
+	switch c.Policy {
+	case "", StakeWeightedPolicy:
+		return StakeWeighted, nil
+	case UniformPolicy:
+		return Uniform, nil
+	case RoundRobinPolicy:
+		return RoundRobin, nil
+	default:
+		return nil, fmt.Errorf("%w: %q", ErrUnknownPolicy, c.Policy)
+	}
+}
+
+// NewCappedStakeWeighted returns a policy that samples proposers weighted by
+// their stake, where no validator's weight exceeds [maxWeightPercentage] of the
+// total stake.
+func NewCappedStakeWeighted(maxWeightPercentage uint64) (Policy, error) {
+	if maxWeightPercentage == 0 || maxWeightPercentage > 100 {
+		return nil, fmt.Errorf("%w: got %d", ErrInvalidMaxWeightPercentage, maxWeightPercentage)
+	}
+	return &weightedPolicy{
+		weights: func(validators []Validator) ([]uint64, error) {
+			return cappedStakeWeights(validators, maxWeightPercentage)
+		},
+	}, nil
+}
+
+func stakeWeights(validators []Validator) ([]uint64, error) {
+	weights := make([]uint64, len(validators))
+	for i, validator := range validators {
+		weights[i] = validator.Weight
+	}
+	return weights, nil
+}
+
+func uniformWeights(validators []Validator) ([]uint64, error) {
+	weights := make([]uint64, len(validators))
+	for i := range weights {
+		weights[i] = 1
+	}
+	return weights, nil
+}
+
+func cappedStakeWeights(validators []Validator, maxWeightPercentage uint64) ([]uint64, error) {
+	var totalWeight uint64
+	for _, validator := range validators {
+		var err error
+		totalWeight, err = math.Add64(totalWeight, validator.Weight)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// Because [maxWeightPercentage] is at most 100, hi is less than 100 and
+	// the quotient can't overflow.
+	hi, lo := bits.Mul64(totalWeight, maxWeightPercentage)
+	maxWeight, _ := bits.Div64(hi, lo, 100)
+	maxWeight = max(maxWeight, 1)
+
+	weights := make([]uint64, len(validators))
+	for i, validator := range validators {
+		weights[i] = min(validator.Weight, maxWeight)
+	}
+	return weights, nil
+}
+
+// weightedPolicy samples proposers, without replacement of their weight, with
+// weights assigned to the validators by [weights].
+type weightedPolicy struct {
+	weights func([]Validator) ([]uint64, error)
+}
+
+func (p *weightedPolicy) Proposers(
+	validators []Validator,
+	chainSource,
+	blockHeight uint64,
+	maxWindows int,
+) ([]ids.NodeID, error) {
+	// Note: The 32-bit prng is used here for legacy reasons. All other usages
+	// of a prng in this file should use the 64-bit version.
+	source := prng.NewMT19937()
+	sampler, weights, err := p.makeSampler(validators, source)
+	if err != nil {
+		return nil, err
+	}
+
+	var totalWeight uint64
+	for _, weight := range weights {
+		totalWeight, err = math.Add64(totalWeight, weight)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	source.Seed(chainSource ^ blockHeight)
+
+	numToSample := int(min(uint64(maxWindows), totalWeight))
+	indices, err := sampler.Sample(numToSample)
+	if err != nil {
+		return nil, err
+	}
+
+	nodeIDs := make([]ids.NodeID, numToSample)
+	for i, index := range indices {
+		nodeIDs[i] = validators[index].NodeID
+	}
+	return nodeIDs, nil
+}
+
+func (p *weightedPolicy) Schedule(validators []Validator, chainSource uint64) (Schedule, error) {
+	source := prng.NewMT19937_64()
+	sampler, _, err := p.makeSampler(validators, source)
+	if err != nil {
+		return nil, err
+	}
+	return &weightedSchedule{
+		validators:  validators,
+		chainSource: chainSource,
+		source:      source,
+		sampler:     sampler,
+	}, nil
+}
+
+func (p *weightedPolicy) makeSampler(
+	validators []Validator,
+	source sampler.Source,
+) (sampler.WeightedWithoutReplacement, []uint64, error) {
+	weights, err := p.weights(validators)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	sampler := sampler.NewDeterministicWeightedWithoutReplacement(source)
+	return sampler, weights, sampler.Initialize(weights)
+}
+
+type weightedSchedule struct {
+	validators  []Validator
+	chainSource uint64
+	source      *prng.MT19937_64
+	sampler     sampler.WeightedWithoutReplacement
+}
+
+func (s *weightedSchedule) ExpectedProposer(blockHeight, slot uint64) (ids.NodeID, error) {
+	// Slot is reversed to utilize a different state space in the seed than the
+	// height. If the slot was not reversed the state space would collide;
+	// biasing the seed generation. For example, without reversing the slot
+	// height=0 and slot=1 would equal height=1 and slot=0.
+	s.source.Seed(s.chainSource ^ blockHeight ^ bits.Reverse64(slot))
+	indices, err := s.sampler.Sample(1)
+	if err != nil {
+		return ids.EmptyNodeID, fmt.Errorf("failed sampling proposers: %w", err)
+	}
+	return s.validators[indices[0]].NodeID, nil
+}
+
+// roundRobinPolicy makes the validator at index [blockHeight] modulo the number
+// of validators the first proposer of a block, followed by the next validators
+// in order.
+type roundRobinPolicy struct{}
+
+func (roundRobinPolicy) Proposers(
+	validators []Validator,
+	_,
+	blockHeight uint64,
+	maxWindows int,
+) ([]ids.NodeID, error) {
+	if len(validators) == 0 {
+		return nil, nil
+	}
+
+	numValidators := uint64(len(validators))
+	numToSample := min(uint64(maxWindows), numValidators)
+	nodeIDs := make([]ids.NodeID, numToSample)
+	for i := range nodeIDs {
+		index := (blockHeight%numValidators + uint64(i)) % numValidators
+		nodeIDs[i] = validators[index].NodeID
+	}
+	return nodeIDs, nil
+}
+
+func (roundRobinPolicy) Schedule(validators []Validator, _ uint64) (Schedule, error) {
+	return &roundRobinSchedule{
+		validators: validators,
+	}, nil
+}
+
+type roundRobinSchedule struct {
+	validators []Validator
+}
+
+func (s *roundRobinSchedule) ExpectedProposer(blockHeight, slot uint64) (ids.NodeID, error) {
+	numValidators := uint64(len(s.validators))
+	index := (blockHeight%numValidators + slot%numValidators) % numValidators
+	return s.validators[index].NodeID, nil
+}
@@ -0,0 +1,266 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package proposer
+
+import (
+	"context"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow/validators"
+)
+
+func TestPolicyConfigNewPolicy(t *testing.T) {
+	tests := []struct {
+		name           string
+		config         PolicyConfig
+		expectedPolicy Policy
+		expectedErr    error
+	}{
+		{
+			name:           "default",
+			config:         PolicyConfig{},
+			expectedPolicy: StakeWeighted,
+		},
+		{
+			name: "stake weighted",
+			config: PolicyConfig{
+				Policy: StakeWeightedPolicy,
+			},
+			expectedPolicy: StakeWeighted,
+		},
+		{
+			name: "uniform",
+			config: PolicyConfig{
+				Policy: UniformPolicy,
+			},
+			expectedPolicy: Uniform,
+		},
+		{
+			name: "round robin",
+			config: PolicyConfig{
+				Policy: RoundRobinPolicy,
+			},
+			expectedPolicy: RoundRobin,
+		},
+		{
+			name: "capped stake weighted",
+			config: PolicyConfig{
+				Policy:              CappedStakeWeightedPolicy,
+				MaxWeightPercentage: 20,
+			},
+		},
+		{
+			name: "capped stake weighted without cap",
+			config: PolicyConfig{
+				Policy: CappedStakeWeightedPolicy,
+			},
+			expectedErr: ErrInvalidMaxWeightPercentage,
+		},
+		{
+			name: "capped stake weighted cap too high",
+			config: PolicyConfig{
+				Policy:              CappedStakeWeightedPolicy,
+				MaxWeightPercentage: 101,
+			},
+			expectedErr: ErrInvalidMaxWeightPercentage,
+		},
+		{
+			name: "unexpected cap",
+			config: PolicyConfig{
+				Policy:              UniformPolicy,
+				MaxWeightPercentage: 20,
+			},
+			expectedErr: errUnexpectedMaxWeightPercentage,
+		},
+		{
+			name: "unknown policy",
+			config: PolicyConfig{
+				Policy: "reputation",
+			},
+			expectedErr: ErrUnknownPolicy,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require := require.New(t)
+
+			policy, err := test.config.NewPolicy()
+			require.ErrorIs(err, test.expectedErr)
+			require.ErrorIs(test.config.Verify(), test.expectedErr)
+			if err != nil || test.expectedPolicy == nil {
+				return
+			}
+			require.Equal(test.expectedPolicy, policy)
+		})
+	}
+}
+
+func TestCappedStakeWeights(t *testing.T) {
+	tests := []struct {
+		name                string
+		weights             []uint64
+		maxWeightPercentage uint64
+		expectedWeights     []uint64
+	}{
+		{
+			name:                "no cap reached",
+			weights:             []uint64{10, 20, 30, 40},
+			maxWeightPercentage: 50,
+			expectedWeights:     []uint64{10, 20, 30, 40},
+		},
+		{
+			name:                "cap reached",
+			weights:             []uint64{10, 10, 80},
+			maxWeightPercentage: 25,
+			expectedWeights:     []uint64{10, 10, 25},
+		},
+		{
+			name:                "cap rounded down",
+			weights:             []uint64{1, 2, 996},
+			maxWeightPercentage: 10,
+			expectedWeights:     []uint64{1, 2, 99},
+		},
+		{
+			name:                "cap at least 1",
+			weights:             []uint64{1, 1},
+			maxWeightPercentage: 1,
+			expectedWeights:     []uint64{1, 1},
+		},
+		{
+			name:                "total weight at max",
+			weights:             []uint64{math.MaxUint64 - 1, 1},
+			maxWeightPercentage: 100,
+			expectedWeights:     []uint64{math.MaxUint64 - 1, 1},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require := require.New(t)
+
+			validators := make([]Validator, len(test.weights))
+			for i, weight := range test.weights {
+				validators[i] = Validator{
+					NodeID: ids.BuildTestNodeID([]byte{byte(i) + 1}),
+					Weight: weight,
+				}
+			}
+
+			weights, err := cappedStakeWeights(validators, test.maxWeightPercentage)
+			require.NoError(err)
+			require.Equal(test.expectedWeights, weights)
+		})
+	}
+}
+
+func TestUniformIgnoresStake(t *testing.T) {
+	require := require.New(t)
+
+	var (
+		dominantID = ids.BuildTestNodeID([]byte{1})
+		minorID    = ids.BuildTestNodeID([]byte{2})
+	)
+	vdrState := &validators.TestState{
+		T: t,
+		GetValidatorSetF: func(context.Context, uint64, ids.ID) (map[ids.NodeID]*validators.GetValidatorOutput, error) {
+			return map[ids.NodeID]*validators.GetValidatorOutput{
+				dominantID: {
+					NodeID: dominantID,
+					Weight: 1_000_000,
+				},
+				minorID: {
+					NodeID: minorID,
+					Weight: 1,
+				},
+			}, nil
+		},
+	}
+
+	// Stake weighted sampling lets the dominant validator take every window.
+	w := New(vdrState, subnetID, fixedChainID)
+	proposers, err := w.Proposers(context.Background(), 1, 0, MaxVerifyWindows)
+	require.NoError(err)
+	require.Len(proposers, MaxVerifyWindows)
+	require.NotContains(proposers, minorID)
+
+	// Uniform sampling gives every validator exactly one window.
+	w = NewWithPolicy(vdrState, subnetID, fixedChainID, Uniform)
+	proposers, err = w.Proposers(context.Background(), 1, 0, MaxVerifyWindows)
+	require.NoError(err)
+	require.ElementsMatch([]ids.NodeID{dominantID, minorID}, proposers)
+}
+
+func TestRoundRobin(t *testing.T) {
+	require := require.New(t)
+
+	validatorIDs, vdrState := makeValidators(t, 3)
+	w := NewWithPolicy(vdrState, subnetID, randomChainID, RoundRobin)
+
+	var (
+		ctx                 = context.Background()
+		pChainHeight uint64 = 0
+	)
+	for blockHeight := uint64(0); blockHeight < 6; blockHeight++ {
+		first := int(blockHeight % 3)
+		expectedProposers := []ids.NodeID{
+			validatorIDs[first],
+			validatorIDs[(first+1)%3],
+			validatorIDs[(first+2)%3],
+		}
+
+		proposers, err := w.Proposers(ctx, blockHeight, pChainHeight, MaxVerifyWindows)
+		require.NoError(err)
+		require.Equal(expectedProposers, proposers)
+
+		proposers, err = w.Proposers(ctx, blockHeight, pChainHeight, 1)
+		require.NoError(err)
+		require.Equal(expectedProposers[:1], proposers)
+
+		for slot := uint64(0); slot < 6; slot++ {
+			expectedProposer := expectedProposers[slot%3]
+
+			proposer, err := w.ExpectedProposer(ctx, blockHeight, pChainHeight, slot)
+			require.NoError(err)
+			require.Equal(expectedProposer, proposer)
+
+			delay, err := w.MinDelayForProposer(ctx, blockHeight, pChainHeight, expectedProposer, slot)
+			require.NoError(err)
+			require.Equal(WindowDuration*time.Duration(slot), delay)
+		}
+	}
+}
+
+func TestPoliciesWithoutValidators(t *testing.T) {
+	_, vdrState := makeValidators(t, 0)
+	capped, err := NewCappedStakeWeighted(10)
+	require.NoError(t, err)
+
+	policies := map[string]Policy{
+		"stake weighted":        StakeWeighted,
+		"uniform":               Uniform,
+		"round robin":           RoundRobin,
+		"capped stake weighted": capped,
+	}
+	for name, policy := range policies {
+		t.Run(name, func(t *testing.T) {
+			require := require.New(t)
+
+			proposers, err := policy.Proposers(nil, 0, 1, MaxVerifyWindows)
+			require.NoError(err)
+			require.Empty(proposers)
+
+			w := NewWithPolicy(vdrState, subnetID, randomChainID, policy)
+			proposers, err = w.Proposers(context.Background(), 1, 0, MaxVerifyWindows)
+			require.NoError(err)
+			require.Empty(proposers)
+
+			_, err = w.ExpectedProposer(context.Background(), 1, 0, 0)
+			require.ErrorIs(err, ErrAnyoneCanPropose)
+		})
+	}
+}
@@ -8,13 +8,14 @@ import (
 	"github.com/ava-labs/avalanchego/utils"
 )
 
-var _ utils.Sortable[validatorData] = validatorData{}
+var _ utils.Sortable[Validator] = Validator{}
 
-type validatorData struct {
-	id     ids.NodeID
-	weight uint64
+// Validator is a validator that a Policy selects proposers from.
+type Validator struct {
+	NodeID ids.NodeID
+	Weight uint64
 }
 
-func (d validatorData) Compare(other validatorData) int {
-	return d.id.Compare(other.id)
+func (v Validator) Compare(other Validator) int {
+	return v.NodeID.Compare(other.NodeID)
 }
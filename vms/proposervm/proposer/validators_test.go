@@ -12,27 +12,27 @@ import (
 	"github.com/ava-labs/avalanchego/ids"
 )
 
-func TestValidatorDataCompare(t *testing.T) {
+func TestValidatorCompare(t *testing.T) {
 	tests := []struct {
-		a        validatorData
-		b        validatorData
+		a        Validator
+		b        Validator
 		expected int
 	}{
 		{
-			a:        validatorData{},
-			b:        validatorData{},
+			a:        Validator{},
+			b:        Validator{},
 			expected: 0,
 		},
 		{
-			a: validatorData{
-				id: ids.BuildTestNodeID([]byte{1}),
+			a: Validator{
+				NodeID: ids.BuildTestNodeID([]byte{1}),
 			},
-			b:        validatorData{},
+			b:        Validator{},
 			expected: 1,
 		},
 	}
 	for _, test := range tests {
-		t.Run(fmt.Sprintf("%s_%s_%d", test.a.id, test.b.id, test.expected), func(t *testing.T) {
+		t.Run(fmt.Sprintf("%s_%s_%d", test.a.NodeID, test.b.NodeID, test.expected), func(t *testing.T) {
 			require := require.New(t)
 
 			require.Equal(test.expected, test.a.Compare(test.b))
@@ -6,17 +6,11 @@ package proposer
 import (
 	"context"
 	"errors"
-	"fmt"
-	"math/bits"
 	"time"
 
-	"gonum.org/v1/gonum/mathext/prng"
-
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/snow/validators"
 	"github.com/ava-labs/avalanchego/utils"
-	"github.com/ava-labs/avalanchego/utils/math"
-	"github.com/ava-labs/avalanchego/utils/sampler"
 	"github.com/ava-labs/avalanchego/utils/wrappers"
 )
 
@@ -101,47 +95,35 @@ type windower struct {
 	state       validators.State
 	subnetID    ids.ID
 	chainSource uint64
+	policy      Policy
 }
 
+// New returns a windower that selects proposers with the [StakeWeighted]
+// policy.
 func New(state validators.State, subnetID, chainID ids.ID) Windower {
+	return NewWithPolicy(state, subnetID, chainID, StakeWeighted)
+}
+
+// NewWithPolicy returns a windower that selects proposers with [policy].
+//
+// Invariant: Every node of the subnet must use the same policy, otherwise
+// blocks are built by proposers that other nodes don't expect.
+func NewWithPolicy(state validators.State, subnetID, chainID ids.ID, policy Policy) Windower {
 	w := wrappers.Packer{Bytes: chainID[:]}
 	return &windower{
 		state:       state,
 		subnetID:    subnetID,
 		chainSource: w.UnpackLong(),
+		policy:      policy,
 	}
 }
 
 func (w *windower) Proposers(ctx context.Context, blockHeight, pChainHeight uint64, maxWindows int) ([]ids.NodeID, error) {
-	// Note: The 32-bit prng is used here for legacy reasons. All other usages
-	// of a prng in this file should use the 64-bit version.
-	source := prng.NewMT19937()
-	sampler, validators, err := w.makeSampler(ctx, pChainHeight, source)
-	if err != nil {
-		return nil, err
-	}
-
-	var totalWeight uint64
-	for _, validator := range validators {
-		totalWeight, err = math.Add64(totalWeight, validator.weight)
-		if err != nil {
-			return nil, err
-		}
-	}
-
-	source.Seed(w.chainSource ^ blockHeight)
-
-	numToSample := int(min(uint64(maxWindows), totalWeight))
-	indices, err := sampler.Sample(numToSample)
+	validators, err := w.validators(ctx, pChainHeight)
 	if err != nil {
 		return nil, err
 	}
-
-	nodeIDs := make([]ids.NodeID, numToSample)
-	for i, index := range indices {
-		nodeIDs[i] = validators[index].id
-	}
-	return nodeIDs, nil
+	return w.policy.Proposers(validators, w.chainSource, blockHeight, maxWindows)
 }
 
 func (w *windower) Delay(ctx context.Context, blockHeight, pChainHeight uint64, validatorID ids.NodeID, maxWindows int) (time.Duration, error) {
@@ -170,22 +152,11 @@ func (w *windower) ExpectedProposer(
 	pChainHeight,
 	slot uint64,
 ) (ids.NodeID, error) {
-	source := prng.NewMT19937_64()
-	sampler, validators, err := w.makeSampler(ctx, pChainHeight, source)
+	schedule, err := w.schedule(ctx, pChainHeight)
 	if err != nil {
 		return ids.EmptyNodeID, err
 	}
-	if len(validators) == 0 {
-		return ids.EmptyNodeID, ErrAnyoneCanPropose
-	}
-
-	return w.expectedProposer(
-		validators,
-		source,
-		sampler,
-		blockHeight,
-		slot,
-	)
+	return schedule.ExpectedProposer(blockHeight, slot)
 }
 
 func (w *windower) MinDelayForProposer(
@@ -195,24 +166,14 @@ func (w *windower) MinDelayForProposer(
 	nodeID ids.NodeID,
 	startSlot uint64,
 ) (time.Duration, error) {
-	source := prng.NewMT19937_64()
-	sampler, validators, err := w.makeSampler(ctx, pChainHeight, source)
+	schedule, err := w.schedule(ctx, pChainHeight)
 	if err != nil {
 		return 0, err
 	}
-	if len(validators) == 0 {
-		return 0, ErrAnyoneCanPropose
-	}
 
 	maxSlot := startSlot + MaxLookAheadSlots
 	for slot := startSlot; slot < maxSlot; slot++ {
-		expectedNodeID, err := w.expectedProposer(
-			validators,
-			source,
-			sampler,
-			blockHeight,
-			slot,
-		)
+		expectedNodeID, err := schedule.ExpectedProposer(blockHeight, slot)
 		if err != nil {
 			return 0, err
 		}
@@ -226,56 +187,39 @@ func (w *windower) MinDelayForProposer(
 	return time.Duration(maxSlot) * WindowDuration, nil
 }
 
-func (w *windower) makeSampler(
-	ctx context.Context,
-	pChainHeight uint64,
-	source sampler.Source,
-) (sampler.WeightedWithoutReplacement, []validatorData, error) {
-	// Get the canconical representation of the validator set at the provided
-	// p-chain height.
+// schedule returns the Post-Durango schedule of the validator set at
+// [pChainHeight].
+func (w *windower) schedule(ctx context.Context, pChainHeight uint64) (Schedule, error) {
+	validators, err := w.validators(ctx, pChainHeight)
+	if err != nil {
+		return nil, err
+	}
+	if len(validators) == 0 {
+		return nil, ErrAnyoneCanPropose
+	}
+	return w.policy.Schedule(validators, w.chainSource)
+}
+
+// validators returns the canonical representation of the validator set at the
+// provided p-chain height.
+func (w *windower) validators(ctx context.Context, pChainHeight uint64) ([]Validator, error) {
 	validatorsMap, err := w.state.GetValidatorSet(ctx, pChainHeight, w.subnetID)
 	if err != nil {
-		return nil, nil, err
+		return nil, err
 	}
 
-	validators := make([]validatorData, 0, len(validatorsMap))
+	validators := make([]Validator, 0, len(validatorsMap))
 	for k, v := range validatorsMap {
-		validators = append(validators, validatorData{
-			id:     k,
-			weight: v.Weight,
+		validators = append(validators, Validator{
+			NodeID: k,
+			Weight: v.Weight,
 		})
 	}
 
 	// Note: validators are sorted by ID. Sorting by weight would not create a
 	// canonically sorted list.
 	utils.Sort(validators)
-
-	weights := make([]uint64, len(validators))
-	for i, validator := range validators {
-		weights[i] = validator.weight
-	}
-
-	sampler := sampler.NewDeterministicWeightedWithoutReplacement(source)
-	return sampler, validators, sampler.Initialize(weights)
-}
-
-func (w *windower) expectedProposer(
-	validators []validatorData,
-	source *prng.MT19937_64,
-	sampler sampler.WeightedWithoutReplacement,
-	blockHeight,
-	slot uint64,
-) (ids.NodeID, error) {
-	// Slot is reversed to utilize a different state space in the seed than the
-	// height. If the slot was not reversed the state space would collide;
-	// biasing the seed generation. For example, without reversing the slot
-	// height=0 and slot=1 would equal height=1 and slot=0.
-	source.Seed(w.chainSource ^ blockHeight ^ bits.Reverse64(slot))
-	indices, err := sampler.Sample(1)
-	if err != nil {
-		return ids.EmptyNodeID, fmt.Errorf("failed sampling proposers: %w", err)
-	}
-	return validators[indices[0]].id, nil
+	return validators, nil
 }
 
 func TimeToSlot(start, now time.Time) uint64 {
@@ -158,7 +158,11 @@ func (vm *VM) Initialize(
 		return err
 	}
 	vm.State = baseState
-	vm.Windower = proposer.New(chainCtx.ValidatorState, chainCtx.SubnetID, chainCtx.ChainID)
+	proposerPolicy := vm.ProposerPolicy
+	if proposerPolicy == nil {
+		proposerPolicy = proposer.StakeWeighted
+	}
+	vm.Windower = proposer.NewWithPolicy(chainCtx.ValidatorState, chainCtx.SubnetID, chainCtx.ChainID, proposerPolicy)
 	vm.Tree = tree.New()
 	innerBlkCache, err := metercacher.New(
 		"inner_block_cache",
@@ -55,3 +55,32 @@ func (mr *MockVMGetterMockRecorder) Get() *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockVMGetter)(nil).Get))
 }
+
+// GetPlugin mocks base method.
+func (m *MockVMGetter) GetPlugin(arg0 string) (vms.Factory, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPlugin", arg0)
+	ret0, _ := ret[0].(vms.Factory)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPlugin indicates an expected call of GetPlugin.
+func (mr *MockVMGetterMockRecorder) GetPlugin(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPlugin", reflect.TypeOf((*MockVMGetter)(nil).GetPlugin), arg0)
+}
+
+// Install mocks base method.
+func (m *MockVMGetter) Install(arg0 ids.ID, arg1 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Install", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Install indicates an expected call of Install.
+func (mr *MockVMGetterMockRecorder) Install(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Install", reflect.TypeOf((*MockVMGetter)(nil).Install), arg0, arg1)
+}
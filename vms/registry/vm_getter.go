@@ -6,11 +6,17 @@ package registry
 import (
 	"errors"
 	"fmt"
+	"io"
+	"io/fs"
+	"os"
 	"path/filepath"
+	"strings"
 
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/utils/filesystem"
+	"github.com/ava-labs/avalanchego/utils/perms"
 	"github.com/ava-labs/avalanchego/utils/resource"
+	"github.com/ava-labs/avalanchego/utils/set"
 	"github.com/ava-labs/avalanchego/vms"
 	"github.com/ava-labs/avalanchego/vms/rpcchainvm"
 	"github.com/ava-labs/avalanchego/vms/rpcchainvm/runtime"
@@ -19,7 +25,9 @@ import (
 var (
 	_ VMGetter = (*vmGetter)(nil)
 
-	errInvalidVMID = errors.New("invalid vmID")
+	errInvalidVMID      = errors.New("invalid vmID")
+	errInvalidVMBin     = errors.New("invalid vm binary")
+	errPluginOutsideDir = errors.New("plugin isn't in the plugin directory")
 )
 
 // VMGetter defines functionality to get the plugins on the node.
@@ -31,6 +39,15 @@ type VMGetter interface {
 		unregisteredVMs map[ids.ID]vms.Factory,
 		err error,
 	)
+
+	// GetPlugin returns a factory of the VM whose plugin binary is at [path].
+	// [path] must resolve to a file in the plugin directory.
+	GetPlugin(path string) (vms.Factory, error)
+
+	// Install replaces the plugin of [vmID] with the plugin binary at [path],
+	// so that the VM is loaded from it after a restart. [path] must resolve to
+	// a file in the plugin directory.
+	Install(vmID ids.ID, path string) error
 }
 
 // VMGetterConfig defines settings for VMGetter
@@ -38,8 +55,12 @@ type VMGetterConfig struct {
 	FileReader      filesystem.Reader
 	Manager         vms.Manager
 	PluginDirectory string
-	CPUTracker      resource.ProcessTracker
-	RuntimeTracker  runtime.Tracker
+	// RemoteVMs maps vmIDs or VM aliases to VM servers that were started
+	// independently of the node. They take precedence over plugins for the
+	// same VM.
+	RemoteVMs      map[string]rpcchainvm.RemoteConfig
+	CPUTracker     resource.ProcessTracker
+	RuntimeTracker runtime.Tracker
 }
 
 type vmGetter struct {
@@ -61,28 +82,47 @@ func (getter *vmGetter) Get() (map[ids.ID]vms.Factory, map[ids.ID]vms.Factory, e
 
 	registeredVMs := make(map[ids.ID]vms.Factory)
 	unregisteredVMs := make(map[ids.ID]vms.Factory)
+	remoteVMs := set.NewSet[ids.ID](len(getter.config.RemoteVMs))
+	for name, config := range getter.config.RemoteVMs {
+		vmID, err := getter.lookup(name)
+		if err != nil {
+			return nil, nil, err
+		}
+		remoteVMs.Add(vmID)
+
+		registeredFactory, err := getter.config.Manager.GetFactory(vmID)
+		if err == nil {
+			registeredVMs[vmID] = registeredFactory
+			continue
+		}
+		if !errors.Is(err, vms.ErrNotFound) {
+			return nil, nil, err
+		}
+
+		unregisteredVMs[vmID] = rpcchainvm.NewRemoteFactory(
+			config,
+			getter.config.RuntimeTracker,
+		)
+	}
+
 	for _, file := range files {
 		if file.IsDir() {
 			continue
 		}
 
-		nameWithExtension := file.Name()
-		// Strip any extension from the file. This is to support windows .exe
-		// files.
-		name := nameWithExtension[:len(nameWithExtension)-len(filepath.Ext(nameWithExtension))]
-
+		name := pluginName(file.Name())
 		// Skip hidden files.
 		if len(name) == 0 {
 			continue
 		}
 
-		vmID, err := getter.config.Manager.Lookup(name)
+		vmID, err := getter.lookup(name)
 		if err != nil {
-			// there is no alias with plugin name, try to use full vmID.
-			vmID, err = ids.FromString(name)
-			if err != nil {
-				return nil, nil, fmt.Errorf("%w: %q", errInvalidVMID, name)
-			}
+			return nil, nil, err
+		}
+
+		if remoteVMs.Contains(vmID) {
+			continue
 		}
 
 		registeredFactory, err := getter.config.Manager.GetFactory(vmID)
@@ -107,3 +147,173 @@ func (getter *vmGetter) Get() (map[ids.ID]vms.Factory, map[ids.ID]vms.Factory, e
 	}
 	return registeredVMs, unregisteredVMs, nil
 }
+
+func (getter *vmGetter) GetPlugin(path string) (vms.Factory, error) {
+	path, err := getter.resolvePlugin(path)
+	if err != nil {
+		return nil, err
+	}
+
+	return rpcchainvm.NewFactory(
+		path,
+		getter.config.CPUTracker,
+		getter.config.RuntimeTracker,
+	), nil
+}
+
+func (getter *vmGetter) Install(vmID ids.ID, path string) error {
+	path, err := getter.resolvePlugin(path)
+	if err != nil {
+		return err
+	}
+	installPath, err := getter.pluginPath(vmID)
+	if err != nil {
+		return err
+	}
+
+	// The plugin may already be installed, in which case it must not be
+	// truncated by copying it over itself.
+	installedInfo, err := os.Stat(installPath)
+	switch {
+	case err == nil:
+		info, err := os.Stat(path)
+		if err != nil {
+			return err
+		}
+		if os.SameFile(info, installedInfo) {
+			return nil
+		}
+	case !errors.Is(err, fs.ErrNotExist):
+		return err
+	}
+	return installPlugin(path, installPath)
+}
+
+// resolvePlugin returns the absolute path, with any symlinks evaluated, of the
+// plugin binary at [path]. Only binaries in the plugin directory are accepted,
+// so that the API can't be used to run arbitrary files on the host.
+func (getter *vmGetter) resolvePlugin(path string) (string, error) {
+	dir, err := resolvePath(getter.config.PluginDirectory)
+	if err != nil {
+		return "", err
+	}
+	resolvedPath, err := resolvePath(path)
+	if err != nil {
+		return "", err
+	}
+
+	rel, err := filepath.Rel(dir, resolvedPath)
+	if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return "", fmt.Errorf("%w: %q", errPluginOutsideDir, path)
+	}
+
+	info, err := os.Stat(resolvedPath)
+	if err != nil {
+		return "", err
+	}
+	if !info.Mode().IsRegular() {
+		return "", fmt.Errorf("%w: %q isn't a file", errInvalidVMBin, path)
+	}
+	return resolvedPath, nil
+}
+
+// pluginPath returns the path in the plugin directory that the plugin of
+// [vmID] is loaded from on startup.
+func (getter *vmGetter) pluginPath(vmID ids.ID) (string, error) {
+	files, err := getter.config.FileReader.ReadDir(getter.config.PluginDirectory)
+	if err != nil {
+		return "", err
+	}
+
+	for _, file := range files {
+		if file.IsDir() {
+			continue
+		}
+
+		name := pluginName(file.Name())
+		if len(name) == 0 {
+			continue
+		}
+
+		// Files that aren't plugins of [vmID] are left alone.
+		pluginVMID, err := getter.lookup(name)
+		if err == nil && pluginVMID == vmID {
+			return filepath.Join(getter.config.PluginDirectory, file.Name()), nil
+		}
+	}
+	return filepath.Join(getter.config.PluginDirectory, vmID.String()), nil
+}
+
+// pluginName strips any extension from the plugin file [fileName]. This is to
+// support windows .exe files.
+func pluginName(fileName string) string {
+	return fileName[:len(fileName)-len(filepath.Ext(fileName))]
+}
+
+// resolvePath returns the absolute path of [path] with any symlinks evaluated.
+func resolvePath(path string) (string, error) {
+	path, err := filepath.Abs(path)
+	if err != nil {
+		return "", err
+	}
+	return filepath.EvalSymlinks(path)
+}
+
+// installPlugin replaces the file at [installPath] with a copy of [path]. The
+// copy is written to a hidden file, which isn't loaded as a plugin, and
+// renamed into place so that a partially written plugin is never loaded.
+func installPlugin(path string, installPath string) error {
+	src, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer src.Close()
+
+	dst, err := os.CreateTemp(filepath.Dir(installPath), ".plugin-*")
+	if err != nil {
+		return err
+	}
+	tmpPath := dst.Name()
+	if err := writePlugin(dst, src); err != nil {
+		_ = os.Remove(tmpPath)
+		return err
+	}
+	if err := os.Rename(tmpPath, installPath); err != nil {
+		_ = os.Remove(tmpPath)
+		return err
+	}
+	return nil
+}
+
+// writePlugin copies [src] into the executable file [dst] and closes [dst].
+func writePlugin(dst *os.File, src io.Reader) error {
+	if err := dst.Chmod(perms.ReadWriteExecute); err != nil {
+		_ = dst.Close()
+		return err
+	}
+	if _, err := io.Copy(dst, src); err != nil {
+		_ = dst.Close()
+		return err
+	}
+	if err := dst.Sync(); err != nil {
+		_ = dst.Close()
+		return err
+	}
+	return dst.Close()
+}
+
+// lookup returns the vmID that [name] is an alias of, or the vmID that [name]
+// encodes.
+func (getter *vmGetter) lookup(name string) (ids.ID, error) {
+	vmID, err := getter.config.Manager.Lookup(name)
+	if err == nil {
+		return vmID, nil
+	}
+
+	// there is no alias with this name, try to use full vmID.
+	vmID, err = ids.FromString(name)
+	if err != nil {
+		return ids.Empty, fmt.Errorf("%w: %q", errInvalidVMID, name)
+	}
+	return vmID, nil
+}
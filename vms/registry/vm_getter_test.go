@@ -6,6 +6,8 @@ package registry
 import (
 	"errors"
 	"io/fs"
+	"os"
+	"path/filepath"
 	"testing"
 	"time"
 
@@ -16,8 +18,10 @@ import (
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/utils/filesystem"
 	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/ava-labs/avalanchego/utils/perms"
 	"github.com/ava-labs/avalanchego/utils/resource"
 	"github.com/ava-labs/avalanchego/vms"
+	"github.com/ava-labs/avalanchego/vms/rpcchainvm"
 )
 
 var (
@@ -63,7 +67,7 @@ var (
 
 // Get should fail if we hit an io issue when reading files on the disk
 func TestGet_ReadDirFails(t *testing.T) {
-	resources := initVMGetterTest(t)
+	resources := initVMGetterTest(t, nil)
 
 	// disk read fails
 	resources.mockReader.EXPECT().ReadDir(pluginDir).Times(1).Return(nil, errTest)
@@ -74,7 +78,7 @@ func TestGet_ReadDirFails(t *testing.T) {
 
 // Get should fail if we see an invalid VM id
 func TestGet_InvalidVMName(t *testing.T) {
-	resources := initVMGetterTest(t)
+	resources := initVMGetterTest(t, nil)
 
 	resources.mockReader.EXPECT().ReadDir(pluginDir).Times(1).Return(invalidVMs, nil)
 	// didn't find an alias, so we'll try using this invalid vm name
@@ -86,7 +90,7 @@ func TestGet_InvalidVMName(t *testing.T) {
 
 // Get should fail if we can't get the VM factory
 func TestGet_GetFactoryFails(t *testing.T) {
-	resources := initVMGetterTest(t)
+	resources := initVMGetterTest(t, nil)
 
 	vm, _ := ids.FromString("vmId")
 
@@ -103,7 +107,7 @@ func TestGet_GetFactoryFails(t *testing.T) {
 func TestGet_Success(t *testing.T) {
 	require := require.New(t)
 
-	resources := initVMGetterTest(t)
+	resources := initVMGetterTest(t, nil)
 
 	registeredVMId := ids.GenerateTestID()
 	unregisteredVMId := ids.GenerateTestID()
@@ -128,6 +132,198 @@ func TestGet_Success(t *testing.T) {
 	require.NoError(err)
 }
 
+// Remote VMs should be returned even if there is no plugin for them and should
+// take precedence over plugins with the same vmID.
+func TestGet_RemoteVMs(t *testing.T) {
+	require := require.New(t)
+
+	registeredVMId := ids.GenerateTestID()
+	unregisteredVMId := ids.GenerateTestID()
+	remoteVMId := ids.GenerateTestID()
+
+	resources := initVMGetterTest(t, map[string]rpcchainvm.RemoteConfig{
+		unregisteredVMName:  {Address: "127.0.0.1:9700"},
+		remoteVMId.String(): {Address: "127.0.0.1:9701"},
+	})
+
+	registeredVMFactory := vms.NewMockFactory(resources.ctrl)
+
+	resources.mockReader.EXPECT().ReadDir(pluginDir).Times(1).Return(twoValidVMs, nil)
+	resources.mockManager.EXPECT().Lookup(registeredVMName).Times(1).Return(registeredVMId, nil)
+	resources.mockManager.EXPECT().GetFactory(registeredVMId).Times(1).Return(registeredVMFactory, nil)
+	resources.mockManager.EXPECT().Lookup(unregisteredVMName).Times(2).Return(unregisteredVMId, nil)
+	resources.mockManager.EXPECT().Lookup(remoteVMId.String()).Times(1).Return(ids.Empty, errTest)
+	// The plugin is shadowed by the remote VM, so its factory is only
+	// looked up once.
+	resources.mockManager.EXPECT().GetFactory(unregisteredVMId).Times(1).Return(nil, vms.ErrNotFound)
+	resources.mockManager.EXPECT().GetFactory(remoteVMId).Times(1).Return(nil, vms.ErrNotFound)
+
+	registeredVMs, unregisteredVMs, err := resources.getter.Get()
+	require.NoError(err)
+
+	require.Len(registeredVMs, 1)
+	require.NotNil(registeredVMs[registeredVMId])
+
+	require.Len(unregisteredVMs, 2)
+	require.NotNil(unregisteredVMs[unregisteredVMId])
+	require.NotNil(unregisteredVMs[remoteVMId])
+}
+
+func TestGetPlugin(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, unregisteredVMName)
+	require.NoError(t, os.WriteFile(path, nil, perms.ReadWriteExecute))
+	stagedDir := filepath.Join(dir, "staged")
+	require.NoError(t, os.Mkdir(stagedDir, perms.ReadWriteExecute))
+	stagedPath := filepath.Join(stagedDir, unregisteredVMName)
+	require.NoError(t, os.WriteFile(stagedPath, nil, perms.ReadWriteExecute))
+
+	outsideDir := t.TempDir()
+	outsidePath := filepath.Join(outsideDir, unregisteredVMName)
+	require.NoError(t, os.WriteFile(outsidePath, nil, perms.ReadWriteExecute))
+	symlinkPath := filepath.Join(stagedDir, registeredVMName)
+	require.NoError(t, os.Symlink(outsidePath, symlinkPath))
+
+	tests := []struct {
+		name        string
+		path        string
+		expectedErr error
+	}{
+		{
+			name:        "binary",
+			path:        path,
+			expectedErr: nil,
+		},
+		{
+			name:        "staged binary",
+			path:        stagedPath,
+			expectedErr: nil,
+		},
+		{
+			name:        "relative path out of plugin directory",
+			path:        filepath.Join(stagedDir, "..", "..", filepath.Base(outsideDir), unregisteredVMName),
+			expectedErr: errPluginOutsideDir,
+		},
+		{
+			name:        "outside plugin directory",
+			path:        outsidePath,
+			expectedErr: errPluginOutsideDir,
+		},
+		{
+			name:        "symlink out of plugin directory",
+			path:        symlinkPath,
+			expectedErr: errPluginOutsideDir,
+		},
+		{
+			name:        "directory",
+			path:        dir,
+			expectedErr: errInvalidVMBin,
+		},
+		{
+			name:        "missing",
+			path:        filepath.Join(dir, registeredVMName),
+			expectedErr: fs.ErrNotExist,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			getter := NewVMGetter(VMGetterConfig{
+				FileReader:      filesystem.NewReader(),
+				PluginDirectory: dir,
+			})
+
+			factory, err := getter.GetPlugin(test.path)
+			require.ErrorIs(t, err, test.expectedErr)
+			if err == nil {
+				require.NotNil(t, factory)
+			}
+		})
+	}
+}
+
+func TestInstall(t *testing.T) {
+	registeredVMID, err := ids.FromString(registeredVMName)
+	require.NoError(t, err)
+	unregisteredVMID, err := ids.FromString(unregisteredVMName)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name          string
+		vmID          ids.ID
+		path          string
+		installedPath string
+		expectedErr   error
+		expectedBytes []byte
+	}{
+		{
+			name:          "replaces the installed plugin",
+			vmID:          registeredVMID,
+			path:          filepath.Join("staged", unregisteredVMName),
+			installedPath: registeredVM.MockName,
+			expectedBytes: []byte("new"),
+		},
+		{
+			name:          "installs a new plugin",
+			vmID:          unregisteredVMID,
+			path:          filepath.Join("staged", unregisteredVMName),
+			installedPath: unregisteredVMName,
+			expectedBytes: []byte("new"),
+		},
+		{
+			name:          "already installed",
+			vmID:          registeredVMID,
+			path:          registeredVM.MockName,
+			installedPath: registeredVM.MockName,
+			expectedBytes: []byte("old"),
+		},
+		{
+			name:          "outside plugin directory",
+			vmID:          registeredVMID,
+			path:          filepath.Join("..", "outside"),
+			installedPath: registeredVM.MockName,
+			expectedErr:   errPluginOutsideDir,
+			expectedBytes: []byte("old"),
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require := require.New(t)
+
+			root := t.TempDir()
+			require.NoError(os.WriteFile(filepath.Join(root, "outside"), []byte("outside"), perms.ReadWriteExecute))
+			dir := filepath.Join(root, "plugins")
+			require.NoError(os.MkdirAll(filepath.Join(dir, "staged"), perms.ReadWriteExecute))
+			require.NoError(os.WriteFile(filepath.Join(dir, registeredVM.MockName), []byte("old"), perms.ReadWriteExecute))
+			require.NoError(os.WriteFile(filepath.Join(dir, "staged", unregisteredVMName), []byte("new"), perms.ReadWriteExecute))
+
+			ctrl := gomock.NewController(t)
+			mockManager := vms.NewMockManager(ctrl)
+			mockManager.EXPECT().Lookup(gomock.Any()).Return(ids.Empty, errTest).AnyTimes()
+
+			getter := NewVMGetter(VMGetterConfig{
+				FileReader:      filesystem.NewReader(),
+				Manager:         mockManager,
+				PluginDirectory: dir,
+			})
+
+			err := getter.Install(test.vmID, filepath.Join(dir, test.path))
+			require.ErrorIs(err, test.expectedErr)
+
+			installedBytes, err := os.ReadFile(filepath.Join(dir, test.installedPath))
+			require.NoError(err)
+			require.Equal(test.expectedBytes, installedBytes)
+
+			// The temporary file that the plugin is written to must have been
+			// renamed.
+			files, err := os.ReadDir(dir)
+			require.NoError(err)
+			for _, file := range files {
+				require.NotEqual(byte('.'), file.Name()[0])
+			}
+		})
+	}
+}
+
 type vmGetterTestResources struct {
 	ctrl        *gomock.Controller
 	mockReader  *filesystem.MockReader
@@ -135,7 +331,7 @@ type vmGetterTestResources struct {
 	getter      VMGetter
 }
 
-func initVMGetterTest(t *testing.T) *vmGetterTestResources {
+func initVMGetterTest(t *testing.T, remoteVMs map[string]rpcchainvm.RemoteConfig) *vmGetterTestResources {
 	ctrl := gomock.NewController(t)
 
 	mockReader := filesystem.NewMockReader(ctrl)
@@ -156,6 +352,7 @@ func initVMGetterTest(t *testing.T) *vmGetterTestResources {
 			FileReader:      mockReader,
 			Manager:         mockManager,
 			PluginDirectory: pluginDir,
+			RemoteVMs:       remoteVMs,
 			CPUTracker:      mockCPUTracker,
 		},
 	)
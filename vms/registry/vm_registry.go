@@ -6,6 +6,7 @@ package registry
 import (
 	"context"
 
+	"github.com/ava-labs/avalanchego/chains"
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/vms"
 )
@@ -17,12 +18,20 @@ var _ VMRegistry = (*vmRegistry)(nil)
 type VMRegistry interface {
 	// Reload installs all non-installed vms on the node.
 	Reload(ctx context.Context) ([]ids.ID, map[ids.ID]error, error)
+
+	// Upgrade replaces the installed vm [vmID] with the plugin binary at
+	// [path] and reloads the chains that run [vmID] with it, without
+	// restarting the node. If the chains can't be reloaded, they keep running
+	// the previous vm. Once the chains are reloaded, the binary is installed
+	// as the plugin of [vmID] so that the upgrade persists across restarts.
+	Upgrade(ctx context.Context, vmID ids.ID, path string) error
 }
 
 // VMRegistryConfig defines configurations for VMRegistry
 type VMRegistryConfig struct {
-	VMGetter  VMGetter
-	VMManager vms.Manager
+	VMGetter     VMGetter
+	VMManager    vms.Manager
+	ChainManager chains.Manager
 }
 
 type vmRegistry struct {
@@ -55,3 +64,31 @@ func (r *vmRegistry) Reload(ctx context.Context) ([]ids.ID, map[ids.ID]error, er
 	}
 	return registeredVms, failedVMs, nil
 }
+
+func (r *vmRegistry) Upgrade(ctx context.Context, vmID ids.ID, path string) error {
+	previous, err := r.config.VMManager.GetFactory(vmID)
+	if err != nil {
+		return err
+	}
+
+	factory, err := r.config.VMGetter.GetPlugin(path)
+	if err != nil {
+		return err
+	}
+
+	// Replacing the factory performs the handshake with the new vm before any
+	// chain is drained.
+	if err := r.config.VMManager.ReplaceFactory(ctx, vmID, factory); err != nil {
+		return err
+	}
+
+	if err := r.config.ChainManager.ReloadVM(ctx, vmID, factory, previous); err != nil {
+		// Drop the error to surface the original error
+		_ = r.config.VMManager.ReplaceFactory(ctx, vmID, previous)
+		return err
+	}
+
+	// The plugin is installed only after the chains are reloaded, as the
+	// previous vm must keep running from its binary until then.
+	return r.config.VMGetter.Install(vmID, path)
+}
@@ -10,6 +10,7 @@ import (
 	"github.com/stretchr/testify/require"
 	"go.uber.org/mock/gomock"
 
+	"github.com/ava-labs/avalanchego/chains"
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/vms"
 )
@@ -117,10 +118,101 @@ func TestReload_PartialRegisterFailure(t *testing.T) {
 	require.Equal(id4, installedVMs[0])
 }
 
+// Tests the happy case where Upgrade succeeds.
+func TestUpgrade_Success(t *testing.T) {
+	require := require.New(t)
+
+	resources := initVMRegistryTest(t)
+
+	previous := vms.NewMockFactory(resources.ctrl)
+	factory := vms.NewMockFactory(resources.ctrl)
+
+	resources.mockVMManager.EXPECT().GetFactory(id1).Times(1).Return(previous, nil)
+	resources.mockVMGetter.EXPECT().GetPlugin("plugin").Times(1).Return(factory, nil)
+	resources.mockVMManager.EXPECT().
+		ReplaceFactory(gomock.Any(), id1, factory).
+		Times(1).
+		Return(nil)
+	resources.chainManager.reloadVM = func(_ context.Context, vmID ids.ID, newFactory vms.Factory, fallback vms.Factory) error {
+		require.Equal(id1, vmID)
+		require.Equal(factory, newFactory)
+		require.Equal(previous, fallback)
+		return nil
+	}
+	resources.mockVMGetter.EXPECT().Install(id1, "plugin").Times(1).Return(nil)
+
+	require.NoError(resources.vmRegistry.Upgrade(context.Background(), id1, "plugin"))
+}
+
+// Tests that the chains aren't reloaded if the new vm can't be registered.
+func TestUpgrade_ReplaceFactoryFails(t *testing.T) {
+	require := require.New(t)
+
+	resources := initVMRegistryTest(t)
+
+	previous := vms.NewMockFactory(resources.ctrl)
+	factory := vms.NewMockFactory(resources.ctrl)
+
+	resources.mockVMManager.EXPECT().GetFactory(id1).Times(1).Return(previous, nil)
+	resources.mockVMGetter.EXPECT().GetPlugin("plugin").Times(1).Return(factory, nil)
+	resources.mockVMManager.EXPECT().
+		ReplaceFactory(gomock.Any(), id1, factory).
+		Times(1).
+		Return(errTest)
+	resources.chainManager.reloadVM = func(context.Context, ids.ID, vms.Factory, vms.Factory) error {
+		require.FailNow("unexpectedly reloaded chains")
+		return nil
+	}
+
+	err := resources.vmRegistry.Upgrade(context.Background(), id1, "plugin")
+	require.ErrorIs(err, errTest)
+}
+
+// Tests that the previous vm is restored if the chains can't be reloaded.
+func TestUpgrade_ReloadVMFails(t *testing.T) {
+	require := require.New(t)
+
+	resources := initVMRegistryTest(t)
+
+	previous := vms.NewMockFactory(resources.ctrl)
+	factory := vms.NewMockFactory(resources.ctrl)
+
+	resources.mockVMManager.EXPECT().GetFactory(id1).Times(1).Return(previous, nil)
+	resources.mockVMGetter.EXPECT().GetPlugin("plugin").Times(1).Return(factory, nil)
+	gomock.InOrder(
+		resources.mockVMManager.EXPECT().
+			ReplaceFactory(gomock.Any(), id1, factory).
+			Times(1).
+			Return(nil),
+		resources.mockVMManager.EXPECT().
+			ReplaceFactory(gomock.Any(), id1, previous).
+			Times(1).
+			Return(nil),
+	)
+	resources.chainManager.reloadVM = func(context.Context, ids.ID, vms.Factory, vms.Factory) error {
+		return errTest
+	}
+
+	err := resources.vmRegistry.Upgrade(context.Background(), id1, "plugin")
+	require.ErrorIs(err, errTest)
+}
+
+// testChainManager reloads vms with [reloadVM].
+type testChainManager struct {
+	chains.Manager
+
+	reloadVM func(ctx context.Context, vmID ids.ID, factory vms.Factory, fallback vms.Factory) error
+}
+
+func (m *testChainManager) ReloadVM(ctx context.Context, vmID ids.ID, factory vms.Factory, fallback vms.Factory) error {
+	return m.reloadVM(ctx, vmID, factory, fallback)
+}
+
 type registryTestResources struct {
 	ctrl          *gomock.Controller
 	mockVMGetter  *MockVMGetter
 	mockVMManager *vms.MockManager
+	chainManager  *testChainManager
 	vmRegistry    VMRegistry
 }
 
@@ -129,11 +221,15 @@ func initVMRegistryTest(t *testing.T) *registryTestResources {
 
 	mockVMGetter := NewMockVMGetter(ctrl)
 	mockVMManager := vms.NewMockManager(ctrl)
+	chainManager := &testChainManager{
+		Manager: chains.TestManager,
+	}
 
 	vmRegistry := NewVMRegistry(
 		VMRegistryConfig{
-			VMGetter:  mockVMGetter,
-			VMManager: mockVMManager,
+			VMGetter:     mockVMGetter,
+			VMManager:    mockVMManager,
+			ChainManager: chainManager,
 		},
 	)
 
@@ -141,6 +237,7 @@ func initVMRegistryTest(t *testing.T) *registryTestResources {
 		ctrl:          ctrl,
 		mockVMGetter:  mockVMGetter,
 		mockVMManager: mockVMManager,
+		chainManager:  chainManager,
 		vmRegistry:    vmRegistry,
 	}
 }
@@ -5,17 +5,30 @@ package rpcchainvm
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"sync"
+	"time"
 
 	"github.com/ava-labs/avalanchego/utils/logging"
 	"github.com/ava-labs/avalanchego/utils/resource"
 	"github.com/ava-labs/avalanchego/vms"
 	"github.com/ava-labs/avalanchego/vms/rpcchainvm/grpcutils"
 	"github.com/ava-labs/avalanchego/vms/rpcchainvm/runtime"
+	"github.com/ava-labs/avalanchego/vms/rpcchainvm/runtime/remote"
 	"github.com/ava-labs/avalanchego/vms/rpcchainvm/runtime/subprocess"
 )
 
-var _ vms.Factory = (*factory)(nil)
+const defaultRemoteHealthCheckInterval = 10 * time.Second
+
+var (
+	_ vms.Factory           = (*factory)(nil)
+	_ vms.Factory           = (*remoteFactory)(nil)
+	_ runtime.Stopper       = (*remoteStopper)(nil)
+	_ runtime.HealthChecker = (*remoteStopper)(nil)
+
+	errRemoteVMInUse = errors.New("remote vm server is already used by another chain")
+)
 
 type factory struct {
 	path           string
@@ -66,3 +79,166 @@ func (f *factory) New(log logging.Logger) (interface{}, error) {
 
 	return vm, nil
 }
+
+// RemoteConfig describes a VM server that was started independently of
+// AvalancheGo, for example in its own container, with [ServeRemote].
+type RemoteConfig struct {
+	// Address of the VM server.
+	// Example: vm.internal:9700
+	Address string `json:"address"`
+	// PEM encoded key pair presented to the VM server and to the VM server
+	// when it dials back.
+	CertFile string `json:"certFile"`
+	KeyFile  string `json:"keyFile"`
+	// PEM encoded certificate authorities that the VM server's certificates
+	// must be signed by.
+	CAFile string `json:"caFile"`
+	// Host that the services dialed by the VM server are bound to. Defaults to
+	// localhost.
+	ListenHost string `json:"listenHost"`
+	// Host that the VM server dials to reach those services. Defaults to the
+	// listen host.
+	AdvertiseHost string `json:"advertiseHost"`
+	// Duration to wait for the VM server to become reachable. Defaults to
+	// [runtime.DefaultHandshakeTimeout].
+	HandshakeTimeout time.Duration `json:"handshakeTimeout"`
+	// Frequency at which the VM server is checked. Defaults to 10s.
+	HealthCheckInterval time.Duration `json:"healthCheckInterval"`
+}
+
+func (c *RemoteConfig) Verify() error {
+	switch {
+	case c.Address == "":
+		return fmt.Errorf("%w: address required", runtime.ErrInvalidConfig)
+	case c.CertFile == "", c.KeyFile == "", c.CAFile == "":
+		return fmt.Errorf("%w: certificate, key, and CA files required", runtime.ErrInvalidConfig)
+	case c.HandshakeTimeout < 0, c.HealthCheckInterval < 0:
+		return fmt.Errorf("%w: durations must not be negative", runtime.ErrInvalidConfig)
+	default:
+		return nil
+	}
+}
+
+type remoteFactory struct {
+	config         RemoteConfig
+	runtimeTracker runtime.Tracker
+
+	lock sync.Mutex
+	// inUse is true while a chain is running on the VM server. The VM server
+	// hosts a single VM instance, so it can't be shared between chains.
+	inUse bool
+}
+
+// NewRemoteFactory returns a factory that connects to the VM server described
+// by [config] rather than starting a subprocess.
+func NewRemoteFactory(config RemoteConfig, runtimeTracker runtime.Tracker) vms.Factory {
+	return &remoteFactory{
+		config:         config,
+		runtimeTracker: runtimeTracker,
+	}
+}
+
+func (f *remoteFactory) New(log logging.Logger) (interface{}, error) {
+	if !f.acquire() {
+		return nil, fmt.Errorf("%w: %s", errRemoteVMInUse, f.config.Address)
+	}
+	vm, err := f.newVM(log)
+	if err != nil {
+		f.release()
+		return nil, err
+	}
+	return vm, nil
+}
+
+func (f *remoteFactory) newVM(log logging.Logger) (*VMClient, error) {
+	// The TLS files are loaded for every new VM so that certificates can be
+	// rotated without restarting the node.
+	tlsConfig, err := grpcutils.LoadMutualTLSConfig(f.config.CertFile, f.config.KeyFile, f.config.CAFile)
+	if err != nil {
+		return nil, err
+	}
+
+	network := grpcutils.Network{
+		ListenHost:    f.config.ListenHost,
+		AdvertiseHost: f.config.AdvertiseHost,
+		TLSConfig:     tlsConfig,
+	}
+	config := &remote.Config{
+		Network:             network,
+		HandshakeTimeout:    f.config.HandshakeTimeout,
+		HealthCheckInterval: f.config.HealthCheckInterval,
+		Log:                 log,
+	}
+	if config.HandshakeTimeout == 0 {
+		config.HandshakeTimeout = runtime.DefaultHandshakeTimeout
+	}
+	if config.HealthCheckInterval == 0 {
+		config.HealthCheckInterval = defaultRemoteHealthCheckInterval
+	}
+
+	status, bootstrapStopper, err := remote.Bootstrap(
+		context.TODO(),
+		f.config.Address,
+		config,
+	)
+	if err != nil {
+		return nil, err
+	}
+	stopper := &remoteStopper{
+		Stopper: bootstrapStopper,
+		release: f.release,
+	}
+
+	clientConn, err := network.Dial(status.Addr)
+	if err != nil {
+		stopper.Stop(context.TODO())
+		return nil, err
+	}
+
+	vm := NewClient(clientConn)
+	vm.SetRemote(stopper, network)
+
+	f.runtimeTracker.TrackRuntime(stopper)
+
+	return vm, nil
+}
+
+func (f *remoteFactory) acquire() bool {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	if f.inUse {
+		return false
+	}
+	f.inUse = true
+	return true
+}
+
+func (f *remoteFactory) release() {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	f.inUse = false
+}
+
+// remoteStopper releases the VM server once the chain running on it is
+// stopped.
+type remoteStopper struct {
+	runtime.Stopper
+
+	once    sync.Once
+	release func()
+}
+
+func (s *remoteStopper) Stop(ctx context.Context) {
+	s.Stopper.Stop(ctx)
+	s.once.Do(s.release)
+}
+
+func (s *remoteStopper) HealthCheck(ctx context.Context) (interface{}, error) {
+	checker, ok := s.Stopper.(runtime.HealthChecker)
+	if !ok {
+		return nil, nil
+	}
+	return checker.HealthCheck(ctx)
+}
@@ -30,15 +30,18 @@ var (
 
 // Client is an http.ResponseWriter that talks over RPC.
 type Client struct {
-	client responsewriterpb.WriterClient
-	header http.Header
+	client  responsewriterpb.WriterClient
+	header  http.Header
+	network grpcutils.Network
 }
 
-// NewClient returns a response writer connected to a remote response writer
-func NewClient(header http.Header, client responsewriterpb.WriterClient) *Client {
+// NewClient returns a response writer connected to a remote response writer.
+// [network] is used to reach hijacked connections.
+func NewClient(header http.Header, client responsewriterpb.WriterClient, network grpcutils.Network) *Client {
 	return &Client{
-		client: client,
-		header: header,
+		client:  client,
+		header:  header,
+		network: network,
 	}
 }
 
@@ -103,7 +106,7 @@ func (c *Client) Hijack() (net.Conn, *bufio.ReadWriter, error) {
 		return nil, nil, err
 	}
 
-	clientConn, err := grpcutils.Dial(resp.ServerAddr)
+	clientConn, err := c.network.Dial(resp.ServerAddr)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -31,13 +31,16 @@ var (
 // Server is an http.ResponseWriter that is managed over RPC.
 type Server struct {
 	responsewriterpb.UnsafeWriterServer
-	writer http.ResponseWriter
+	writer  http.ResponseWriter
+	network grpcutils.Network
 }
 
-// NewServer returns an http.ResponseWriter instance managed remotely
-func NewServer(writer http.ResponseWriter) *Server {
+// NewServer returns an http.ResponseWriter instance managed remotely. [network]
+// is used to serve hijacked connections.
+func NewServer(writer http.ResponseWriter, network grpcutils.Network) *Server {
 	return &Server{
-		writer: writer,
+		writer:  writer,
+		network: network,
 	}
 }
 
@@ -92,12 +95,12 @@ func (s *Server) Hijack(context.Context, *emptypb.Empty) (*responsewriterpb.Hija
 		return nil, err
 	}
 
-	serverListener, err := grpcutils.NewListener()
+	serverListener, serverAddr, err := s.network.Listen()
 	if err != nil {
 		return nil, err
 	}
 
-	server := grpcutils.NewServer()
+	server := s.network.NewServer()
 	closer := grpcutils.ServerCloser{}
 	closer.Add(server)
 
@@ -115,6 +118,6 @@ func (s *Server) Hijack(context.Context, *emptypb.Empty) (*responsewriterpb.Hija
 		LocalString:   local.String(),
 		RemoteNetwork: remote.Network(),
 		RemoteString:  remote.String(),
-		ServerAddr:    serverListener.Addr().String(),
+		ServerAddr:    serverAddr,
 	}, nil
 }
@@ -18,14 +18,17 @@ var _ http.Handler = (*Client)(nil)
 
 // Client is an http.Handler that talks over RPC.
 type Client struct {
-	client httppb.HTTPClient
+	client  httppb.HTTPClient
+	network grpcutils.Network
 }
 
 // NewClient returns an HTTP handler database instance connected to a remote
-// HTTP handler instance
-func NewClient(client httppb.HTTPClient) *Client {
+// HTTP handler instance. [network] is used to serve the response writer to the
+// remote HTTP handler.
+func NewClient(client httppb.HTTPClient, network grpcutils.Network) *Client {
 	return &Client{
-		client: client,
+		client:  client,
+		network: network,
 	}
 }
 
@@ -45,15 +48,15 @@ func (c *Client) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	// Wrap [w] with a lock to ensure that it is accessed in a thread-safe manner.
 	w = gresponsewriter.NewLockedWriter(w)
 
-	serverListener, err := grpcutils.NewListener()
+	serverListener, serverAddr, err := c.network.Listen()
 	if err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
 	}
 
-	server := grpcutils.NewServer()
+	server := c.network.NewServer()
 	closer.Add(server)
-	responsewriterpb.RegisterWriterServer(server, gresponsewriter.NewServer(w))
+	responsewriterpb.RegisterWriterServer(server, gresponsewriter.NewServer(w, c.network))
 
 	// Start responsewriter gRPC service.
 	go grpcutils.Serve(serverListener, server)
@@ -66,7 +69,7 @@ func (c *Client) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 
 	req := &httppb.HTTPRequest{
 		ResponseWriter: &httppb.ResponseWriter{
-			ServerAddr: serverListener.Addr().String(),
+			ServerAddr: serverAddr,
 			Header:     make([]*httppb.Element, 0, len(r.Header)),
 		},
 		Request: &httppb.Request{
@@ -29,17 +29,20 @@ var (
 type Server struct {
 	httppb.UnsafeHTTPServer
 	handler http.Handler
+	network grpcutils.Network
 }
 
-// NewServer returns an http.Handler instance managed remotely
-func NewServer(handler http.Handler) *Server {
+// NewServer returns an http.Handler instance managed remotely. [network] is
+// used to reach the response writer of each request.
+func NewServer(handler http.Handler, network grpcutils.Network) *Server {
 	return &Server{
 		handler: handler,
+		network: network,
 	}
 }
 
 func (s *Server) Handle(ctx context.Context, req *httppb.HTTPRequest) (*emptypb.Empty, error) {
-	clientConn, err := grpcutils.Dial(req.ResponseWriter.ServerAddr)
+	clientConn, err := s.network.Dial(req.ResponseWriter.ServerAddr)
 	if err != nil {
 		return nil, err
 	}
@@ -49,7 +52,7 @@ func (s *Server) Handle(ctx context.Context, req *httppb.HTTPRequest) (*emptypb.
 		writerHeaders[elem.Key] = elem.Values
 	}
 
-	writer := gresponsewriter.NewClient(writerHeaders, responsewriterpb.NewWriterClient(clientConn))
+	writer := gresponsewriter.NewClient(writerHeaders, responsewriterpb.NewWriterClient(clientConn), s.network)
 
 	// create the request with the current context
 	request, err := http.NewRequestWithContext(
@@ -8,6 +8,7 @@ import (
 	"time"
 
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
 	"google.golang.org/grpc/credentials/insecure"
 	"google.golang.org/grpc/keepalive"
 )
@@ -102,3 +103,11 @@ func WithChainStreamInterceptor(interceptors ...grpc.StreamClientInterceptor) Di
 		d.opts = append(d.opts, grpc.WithChainStreamInterceptor(interceptors...))
 	}
 }
+
+// WithTransportCredentials replaces the insecure transport credentials set by
+// DefaultDialOptions with [creds].
+func WithTransportCredentials(creds credentials.TransportCredentials) DialOption {
+	return func(d *DialOptions) {
+		d.opts = append(d.opts, grpc.WithTransportCredentials(creds))
+	}
+}
@@ -0,0 +1,109 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package grpcutils
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+)
+
+var errNoCACertificates = errors.New("no CA certificates found")
+
+// Network describes how the gRPC servers that a VM and AvalancheGo expose to
+// each other are served and dialed.
+//
+// The zero value serves on localhost without transport security, which is
+// only appropriate when both processes run on the same host.
+type Network struct {
+	// ListenHost is the host that servers bind to. If empty, servers bind to
+	// localhost.
+	ListenHost string
+	// AdvertiseHost is the host that is reported to the peer for it to dial.
+	// If empty, the address of the listener is reported.
+	AdvertiseHost string
+	// TLSConfig, if non-nil, secures both the servers and the connections to
+	// the peer's servers.
+	TLSConfig *tls.Config
+}
+
+// Listen returns a TCP listener on the next available port of ListenHost along
+// with the address that the peer should dial to reach it.
+func (n Network) Listen() (net.Listener, string, error) {
+	var (
+		listener net.Listener
+		err      error
+	)
+	if n.ListenHost == "" {
+		listener, err = NewListener()
+	} else {
+		listener, err = net.Listen("tcp", net.JoinHostPort(n.ListenHost, "0"))
+	}
+	if err != nil {
+		return nil, "", err
+	}
+
+	addr := listener.Addr().String()
+	if n.AdvertiseHost == "" {
+		return listener, addr, nil
+	}
+
+	_, port, err := net.SplitHostPort(addr)
+	if err != nil {
+		_ = listener.Close()
+		return nil, "", err
+	}
+	return listener, net.JoinHostPort(n.AdvertiseHost, port), nil
+}
+
+// NewServer returns a gRPC server secured by TLSConfig, if provided.
+func (n Network) NewServer(opts ...ServerOption) *grpc.Server {
+	if n.TLSConfig != nil {
+		opts = append(opts[:len(opts):len(opts)], WithCreds(credentials.NewTLS(n.TLSConfig)))
+	}
+	return NewServer(opts...)
+}
+
+// Dial returns a gRPC ClientConn secured by TLSConfig, if provided.
+func (n Network) Dial(addr string, opts ...DialOption) (*grpc.ClientConn, error) {
+	if n.TLSConfig != nil {
+		opts = append(opts[:len(opts):len(opts)], WithTransportCredentials(credentials.NewTLS(n.TLSConfig)))
+	}
+	return Dial(addr, opts...)
+}
+
+// LoadMutualTLSConfig returns a TLS config that presents the key pair in
+// [certFile] and [keyFile] and requires the peer to present a certificate
+// signed by one of the PEM encoded certificates in [caFile].
+//
+// The returned config can be used to both serve and dial.
+func LoadMutualTLSConfig(certFile, keyFile, caFile string) (*tls.Config, error) {
+	cert, err := tls.LoadX509KeyPair(certFile, keyFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load key pair: %w", err)
+	}
+
+	caBytes, err := os.ReadFile(caFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read CA file: %w", err)
+	}
+
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(caBytes) {
+		return nil, fmt.Errorf("%w in %q", errNoCACertificates, caFile)
+	}
+	return &tls.Config{
+		Certificates: []tls.Certificate{cert},
+		RootCAs:      pool,
+		ClientCAs:    pool,
+		ClientAuth:   tls.RequireAndVerifyClientCert,
+		MinVersion:   tls.VersionTLS13,
+	}, nil
+}
@@ -9,6 +9,7 @@ import (
 	"time"
 
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
 	"google.golang.org/grpc/keepalive"
 )
 
@@ -86,6 +87,13 @@ func WithStreamInterceptor(streamInterceptor grpc.StreamServerInterceptor) Serve
 	}
 }
 
+// WithCreds sets the transport credentials of the gRPC server.
+func WithCreds(creds credentials.TransportCredentials) ServerOption {
+	return func(s *ServerOptions) {
+		s.opts = append(s.opts, grpc.Creds(creds))
+	}
+}
+
 // NewListener returns a TCP listener listening against the next available port
 // on the system bound to localhost.
 func NewListener() (net.Listener, error) {
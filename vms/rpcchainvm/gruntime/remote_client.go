@@ -0,0 +1,41 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package gruntime
+
+import (
+	"context"
+
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/types/known/emptypb"
+
+	"github.com/ava-labs/avalanchego/ids"
+
+	pb "github.com/ava-labs/avalanchego/proto/pb/vm/runtime"
+)
+
+// RemoteClient manages the lifecycle of a VM server that was started
+// independently of AvalancheGo.
+type RemoteClient struct {
+	client pb.RemoteClient
+}
+
+func NewRemoteClient(client pb.RemoteClient) *RemoteClient {
+	return &RemoteClient{client: client}
+}
+
+// Handshake returns the protocol version and instance ID of the VM server.
+func (c *RemoteClient) Handshake(ctx context.Context, opts ...grpc.CallOption) (uint, ids.ID, error) {
+	resp, err := c.client.Handshake(ctx, &emptypb.Empty{}, opts...)
+	if err != nil {
+		return 0, ids.Empty, err
+	}
+	instanceID, err := ids.ToID(resp.InstanceId)
+	return uint(resp.ProtocolVersion), instanceID, err
+}
+
+// Shutdown requests the VM server to stop.
+func (c *RemoteClient) Shutdown(ctx context.Context) error {
+	_, err := c.client.Shutdown(ctx, &emptypb.Empty{})
+	return err
+}
@@ -0,0 +1,48 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package gruntime
+
+import (
+	"context"
+
+	"google.golang.org/protobuf/types/known/emptypb"
+
+	"github.com/ava-labs/avalanchego/ids"
+
+	pb "github.com/ava-labs/avalanchego/proto/pb/vm/runtime"
+)
+
+var _ pb.RemoteServer = (*RemoteServer)(nil)
+
+// RemoteServer exposes the lifecycle of a VM server that was started
+// independently of AvalancheGo.
+type RemoteServer struct {
+	pb.UnsafeRemoteServer
+	protocolVersion uint
+	instanceID      ids.ID
+	onShutdown      func()
+}
+
+// NewRemoteServer returns a server that reports [protocolVersion] and
+// [instanceID] during the handshake and calls [onShutdown] when AvalancheGo
+// requests the VM server to stop. [onShutdown] must not block.
+func NewRemoteServer(protocolVersion uint, instanceID ids.ID, onShutdown func()) *RemoteServer {
+	return &RemoteServer{
+		protocolVersion: protocolVersion,
+		instanceID:      instanceID,
+		onShutdown:      onShutdown,
+	}
+}
+
+func (s *RemoteServer) Handshake(context.Context, *emptypb.Empty) (*pb.HandshakeResponse, error) {
+	return &pb.HandshakeResponse{
+		ProtocolVersion: uint32(s.protocolVersion),
+		InstanceId:      s.instanceID[:],
+	}, nil
+}
+
+func (s *RemoteServer) Shutdown(context.Context, *emptypb.Empty) (*emptypb.Empty, error) {
+	s.onShutdown()
+	return &emptypb.Empty{}, nil
+}
@@ -0,0 +1,262 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package rpcchainvm
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"sync"
+
+	"go.uber.org/zap"
+	"google.golang.org/protobuf/types/known/emptypb"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow"
+	"github.com/ava-labs/avalanchego/utils"
+	"github.com/ava-labs/avalanchego/vms"
+	"github.com/ava-labs/avalanchego/vms/rpcchainvm/ghttp"
+	"github.com/ava-labs/avalanchego/vms/rpcchainvm/grpcutils"
+
+	httppb "github.com/ava-labs/avalanchego/proto/pb/http"
+	vmpb "github.com/ava-labs/avalanchego/proto/pb/vm"
+)
+
+var (
+	errNotInitialized       = errors.New("vm not initialized")
+	errUnexpectedVMType     = errors.New("unexpected vm type")
+	errLastAcceptedMismatch = errors.New("last accepted block mismatch")
+	errVerifyProcessing     = errors.New("failed to verify processing block")
+)
+
+// Reload replaces the VM process with a new VM process created by [factory].
+//
+// The new VM process is started before the current one is stopped, so the
+// current VM process keeps running if the new one fails its handshake. Once
+// the current VM process is stopped, the new VM process is initialized with
+// the arguments of Initialize and resumes from the last accepted block. The
+// blocks that are still processing, the connected peers, the state of the
+// engine and the preferred block are replayed to the new VM process, and the
+// handlers of the VM are forwarded to it.
+//
+// The VM process is replaced once the in-flight calls into the current VM
+// process, including HTTP requests to its handlers, complete. Calls made while
+// the VM process is replaced wait for it to be replaced. Long-lived HTTP
+// requests, such as websocket connections, delay the reload until they are
+// closed.
+//
+// If an error is returned after the current VM process was stopped, the VM
+// has no process until Reload succeeds.
+//
+// Invariant: The context lock of the chain must be held, so that no messages
+// are handled by the VM while it is reloaded.
+func (vm *VMClient) Reload(ctx context.Context, factory vms.Factory) error {
+	if vm.chainCtx == nil {
+		return errNotInitialized
+	}
+
+	vmIntf, err := factory.New(vm.chainCtx.Log)
+	if err != nil {
+		return fmt.Errorf("failed to start vm process: %w", err)
+	}
+	next, ok := vmIntf.(*VMClient)
+	if !ok {
+		return fmt.Errorf("%w: %T", errUnexpectedVMType, vmIntf)
+	}
+
+	vm.processLock.Lock()
+	defer vm.processLock.Unlock()
+
+	if err := vm.detachProcess(ctx); err != nil {
+		vm.chainCtx.Log.Warn("failed to shutdown vm process",
+			zap.Error(err),
+		)
+	}
+
+	vm.client = next.client
+	vm.runtime = next.runtime
+	vm.pid = next.pid
+	vm.processTracker = next.processTracker
+	vm.network = next.network
+	vm.db = nil
+	vm.stopped = false
+	vm.serverCloser = grpcutils.ServerCloser{}
+	vm.conns = next.conns
+
+	if err := vm.resume(ctx); err != nil {
+		_ = vm.detachProcess(ctx)
+		return err
+	}
+	return nil
+}
+
+// detachProcess stops the VM process without closing the chain's database,
+// which outlives the VM process.
+func (vm *VMClient) detachProcess(ctx context.Context) error {
+	if vm.db != nil {
+		vm.db.keepOpen.Set(true)
+	}
+	return vm.stopProcess(ctx)
+}
+
+// resume initializes the VM process and replays the state that the engine
+// established in the previous VM process.
+func (vm *VMClient) resume(ctx context.Context) error {
+	resp, err := vm.initializeProcess(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to initialize vm process: %w", err)
+	}
+
+	lastAcceptedID, err := ids.ToID(resp.LastAcceptedId)
+	if err != nil {
+		return err
+	}
+	if expectedID := vm.State.LastAcceptedBlock().ID(); lastAcceptedID != expectedID {
+		return fmt.Errorf("%w: expected %s but got %s", errLastAcceptedMismatch, expectedID, lastAcceptedID)
+	}
+
+	if vm.engineState != snow.Initializing {
+		_, err := vm.client.SetState(ctx, &vmpb.SetStateRequest{
+			State: vmpb.State(vm.engineState),
+		})
+		if err != nil {
+			return fmt.Errorf("failed to set state %s: %w", vm.engineState, err)
+		}
+	}
+
+	for nodeID, nodeVersion := range vm.connected {
+		_, err := vm.client.Connected(ctx, &vmpb.ConnectedRequest{
+			NodeId: nodeID.Bytes(),
+			Name:   nodeVersion.Name,
+			Major:  uint32(nodeVersion.Major),
+			Minor:  uint32(nodeVersion.Minor),
+			Patch:  uint32(nodeVersion.Patch),
+		})
+		if err != nil {
+			return fmt.Errorf("failed to connect %s: %w", nodeID, err)
+		}
+	}
+
+	// Parents are verified before their children.
+	processing := make([]*blockClient, 0, len(vm.processing))
+	for _, blk := range vm.processing {
+		processing = append(processing, blk)
+	}
+	slices.SortFunc(processing, func(a, b *blockClient) int {
+		return cmp.Compare(a.height, b.height)
+	})
+	for _, blk := range processing {
+		req := &vmpb.BlockVerifyRequest{
+			Bytes: blk.bytes,
+		}
+		if blk.blockCtx != nil {
+			req.PChainHeight = &blk.blockCtx.PChainHeight
+		}
+		if _, err := vm.client.BlockVerify(ctx, req); err != nil {
+			return fmt.Errorf("%w %s: %w", errVerifyProcessing, blk.id, err)
+		}
+	}
+
+	if vm.preference != ids.Empty {
+		_, err := vm.client.SetPreference(ctx, &vmpb.SetPreferenceRequest{
+			Id: vm.preference[:],
+		})
+		if err != nil {
+			return fmt.Errorf("failed to set preference %s: %w", vm.preference, err)
+		}
+	}
+
+	if len(vm.handlers) == 0 {
+		return nil
+	}
+	return vm.resumeHandlers(ctx)
+}
+
+// resumeHandlers forwards the handlers of the VM to the VM process.
+func (vm *VMClient) resumeHandlers(ctx context.Context) error {
+	resp, err := vm.client.CreateHandlers(ctx, &emptypb.Empty{})
+	if err != nil {
+		return fmt.Errorf("failed to create handlers: %w", err)
+	}
+
+	handlers := make(map[string]http.Handler, len(resp.Handlers))
+	for _, handler := range resp.Handlers {
+		clientConn, err := vm.network.Dial(handler.ServerAddr)
+		if err != nil {
+			return err
+		}
+
+		vm.conns = append(vm.conns, clientConn)
+		handlers[handler.Prefix] = ghttp.NewClient(httppb.NewHTTPClient(clientConn), vm.network)
+	}
+
+	// Handlers can't be added after the chain was registered, so only the
+	// handlers that the previous VM process created are forwarded.
+	for prefix, client := range vm.handlers {
+		handler, ok := handlers[prefix]
+		if !ok {
+			vm.chainCtx.Log.Warn("handler removed by reloaded vm",
+				zap.String("prefix", prefix),
+			)
+			handler = http.NotFoundHandler()
+		}
+		client.set(handler)
+	}
+	for prefix := range handlers {
+		if _, ok := vm.handlers[prefix]; !ok {
+			vm.chainCtx.Log.Warn("handler added by reloaded vm isn't served",
+				zap.String("prefix", prefix),
+			)
+		}
+	}
+	return nil
+}
+
+// processDB is the chain's database as served to a VM process.
+type processDB struct {
+	database.Database
+
+	// keepOpen is set once the VM process is replaced, so that stopping the
+	// VM process doesn't close the database used by the next VM process.
+	keepOpen utils.Atomic[bool]
+}
+
+func (db *processDB) Close() error {
+	if db.keepOpen.Get() {
+		return nil
+	}
+	return db.Database.Close()
+}
+
+// handlerClient forwards requests to a handler of the current VM process.
+type handlerClient struct {
+	// processLock is held while a request is forwarded, so that the VM process
+	// isn't replaced while it handles the request.
+	processLock *sync.RWMutex
+
+	lock    sync.RWMutex
+	handler http.Handler
+}
+
+func (h *handlerClient) set(handler http.Handler) {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+
+	h.handler = handler
+}
+
+func (h *handlerClient) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	h.processLock.RLock()
+	defer h.processLock.RUnlock()
+
+	h.lock.RLock()
+	handler := h.handler
+	h.lock.RUnlock()
+
+	handler.ServeHTTP(w, r)
+}
@@ -0,0 +1,373 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package rpcchainvm
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/database/memdb"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow"
+	"github.com/ava-labs/avalanchego/snow/choices"
+	"github.com/ava-labs/avalanchego/snow/consensus/snowman"
+	"github.com/ava-labs/avalanchego/snow/engine/common"
+	"github.com/ava-labs/avalanchego/snow/engine/snowman/block"
+	"github.com/ava-labs/avalanchego/snow/snowtest"
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/ava-labs/avalanchego/utils/set"
+	"github.com/ava-labs/avalanchego/version"
+	"github.com/ava-labs/avalanchego/vms"
+	"github.com/ava-labs/avalanchego/vms/rpcchainvm/grpcutils"
+	"github.com/ava-labs/avalanchego/vms/rpcchainvm/runtime"
+)
+
+var (
+	errTestVerify = errors.New("non-nil test verify error")
+
+	genesisID = ids.GenerateTestID()
+	childID   = ids.GenerateTestID()
+)
+
+// reloadTestVM records the calls that the engine's state is replayed with.
+type reloadTestVM struct {
+	block.TestVM
+
+	lock       sync.Mutex
+	state      snow.State
+	connected  set.Set[ids.NodeID]
+	preference ids.ID
+	accepted   set.Set[ids.ID]
+	shutdown   bool
+}
+
+type reloadTestBlock struct {
+	*snowman.TestBlock
+	vm *reloadTestVM
+}
+
+func (b *reloadTestBlock) Accept(context.Context) error {
+	b.vm.lock.Lock()
+	defer b.vm.lock.Unlock()
+
+	b.vm.accepted.Add(b.ID())
+	return nil
+}
+
+// newReloadTestVM returns a VM that reports [vmVersion], whose last accepted
+// block is the genesis block, and whose processing child of the genesis block
+// fails verification with [verifyErr].
+func newReloadTestVM(vmVersion string, verifyErr error) *reloadTestVM {
+	vm := &reloadTestVM{}
+	genesis := &reloadTestBlock{
+		TestBlock: &snowman.TestBlock{
+			TestDecidable: choices.TestDecidable{
+				IDV:     genesisID,
+				StatusV: choices.Accepted,
+			},
+			BytesV: genesisID[:],
+		},
+		vm: vm,
+	}
+	child := &reloadTestBlock{
+		TestBlock: &snowman.TestBlock{
+			TestDecidable: choices.TestDecidable{
+				IDV:     childID,
+				StatusV: choices.Processing,
+			},
+			ParentV: genesisID,
+			HeightV: 1,
+			VerifyV: verifyErr,
+			BytesV:  childID[:],
+		},
+		vm: vm,
+	}
+	blks := map[ids.ID]snowman.Block{
+		genesisID: genesis,
+		childID:   child,
+	}
+
+	vm.InitializeF = func(context.Context, *snow.Context, database.Database, []byte, []byte, []byte, chan<- common.Message, []*common.Fx, common.AppSender) error {
+		return nil
+	}
+	vm.SetStateF = func(_ context.Context, state snow.State) error {
+		vm.lock.Lock()
+		defer vm.lock.Unlock()
+
+		vm.state = state
+		return nil
+	}
+	vm.ConnectedF = func(_ context.Context, nodeID ids.NodeID, _ *version.Application) error {
+		vm.lock.Lock()
+		defer vm.lock.Unlock()
+
+		vm.connected.Add(nodeID)
+		return nil
+	}
+	vm.SetPreferenceF = func(_ context.Context, blkID ids.ID) error {
+		vm.lock.Lock()
+		defer vm.lock.Unlock()
+
+		vm.preference = blkID
+		return nil
+	}
+	vm.ShutdownF = func(context.Context) error {
+		vm.lock.Lock()
+		defer vm.lock.Unlock()
+
+		vm.shutdown = true
+		return nil
+	}
+	vm.VersionF = func(context.Context) (string, error) {
+		return vmVersion, nil
+	}
+	vm.CreateHandlersF = func(context.Context) (map[string]http.Handler, error) {
+		return map[string]http.Handler{
+			"/version": http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				_, _ = w.Write([]byte(vmVersion))
+			}),
+		}, nil
+	}
+	vm.LastAcceptedF = func(context.Context) (ids.ID, error) {
+		return genesisID, nil
+	}
+	vm.GetBlockF = func(_ context.Context, blkID ids.ID) (snowman.Block, error) {
+		blk, ok := blks[blkID]
+		if !ok {
+			return nil, database.ErrNotFound
+		}
+		return blk, nil
+	}
+	vm.ParseBlockF = func(_ context.Context, blkBytes []byte) (snowman.Block, error) {
+		blkID, err := ids.ToID(blkBytes)
+		if err != nil {
+			return nil, err
+		}
+		return vm.GetBlockF(context.Background(), blkID)
+	}
+	return vm
+}
+
+func (vm *reloadTestVM) isShutdown() bool {
+	vm.lock.Lock()
+	defer vm.lock.Unlock()
+
+	return vm.shutdown
+}
+
+type reloadTest struct {
+	t        *testing.T
+	certFile string
+	keyFile  string
+	network  grpcutils.Network
+}
+
+func newReloadTest(t *testing.T) *reloadTest {
+	certFile, keyFile := writeTLSFiles(t, t.TempDir())
+	tlsConfig, err := grpcutils.LoadMutualTLSConfig(certFile, keyFile, certFile)
+	require.NoError(t, err)
+
+	return &reloadTest{
+		t:        t,
+		certFile: certFile,
+		keyFile:  keyFile,
+		network:  grpcutils.Network{TLSConfig: tlsConfig},
+	}
+}
+
+// serve serves [vm] and returns a factory that connects to it.
+func (r *reloadTest) serve(vm block.ChainVM) vms.Factory {
+	listener, err := grpcutils.NewListener()
+	require.NoError(r.t, err)
+
+	go func() {
+		_ = serveRemote(context.Background(), vm, listener, r.network)
+	}()
+	return r.factory(listener.Addr().String())
+}
+
+func (r *reloadTest) factory(addr string) vms.Factory {
+	return NewRemoteFactory(
+		RemoteConfig{
+			Address:          addr,
+			CertFile:         r.certFile,
+			KeyFile:          r.keyFile,
+			CAFile:           r.certFile,
+			HandshakeTimeout: 100 * time.Millisecond,
+		},
+		runtime.NewManager(),
+	)
+}
+
+// initialize returns a client of the VM created by [factory] whose child block
+// is processing.
+func (r *reloadTest) initialize(factory vms.Factory) (*VMClient, snowman.Block, http.Handler) {
+	require := require.New(r.t)
+	ctx := context.Background()
+
+	vmIntf, err := factory.New(logging.NoLog{})
+	require.NoError(err)
+	client := vmIntf.(*VMClient)
+	r.t.Cleanup(func() {
+		_ = client.Shutdown(context.Background())
+	})
+
+	chainCtx := snowtest.Context(r.t, snowtest.CChainID)
+	require.NoError(client.Initialize(ctx, chainCtx, memdb.New(), nil, nil, nil, nil, nil, nil))
+	require.NoError(client.SetState(ctx, snow.NormalOp))
+	require.NoError(client.Connected(ctx, chainCtx.NodeID, version.CurrentApp))
+
+	handlers, err := client.CreateHandlers(ctx)
+	require.NoError(err)
+
+	blk, err := client.ParseBlock(ctx, childID[:])
+	require.NoError(err)
+	require.NoError(blk.Verify(ctx))
+	require.NoError(client.SetPreference(ctx, childID))
+	return client, blk, handlers["/version"]
+}
+
+func serveVersion(require *require.Assertions, handler http.Handler, expected string) {
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/version", nil))
+	require.Equal(expected, recorder.Body.String())
+}
+
+func TestReload(t *testing.T) {
+	require := require.New(t)
+	ctx := context.Background()
+
+	r := newReloadTest(t)
+	oldVM := newReloadTestVM("v1", nil)
+	client, blk, handler := r.initialize(r.serve(oldVM))
+	serveVersion(require, handler, "v1")
+
+	newVM := newReloadTestVM("v2", nil)
+	require.NoError(client.Reload(ctx, r.serve(newVM)))
+	require.True(oldVM.isShutdown())
+
+	// The engine's state was replayed to the new VM process.
+	newVM.lock.Lock()
+	require.Equal(snow.NormalOp, newVM.state)
+	require.Len(newVM.connected, 1)
+	require.Equal(childID, newVM.preference)
+	newVM.lock.Unlock()
+
+	version, err := client.Version(ctx)
+	require.NoError(err)
+	require.Equal("v2", version)
+	serveVersion(require, handler, "v2")
+
+	// The block that was processing is decided by the new VM process.
+	require.NoError(blk.Accept(ctx))
+	newVM.lock.Lock()
+	require.True(newVM.accepted.Contains(childID))
+	newVM.lock.Unlock()
+}
+
+func TestReloadHandshakeFailure(t *testing.T) {
+	require := require.New(t)
+	ctx := context.Background()
+
+	r := newReloadTest(t)
+	oldVM := newReloadTestVM("v1", nil)
+	client, _, handler := r.initialize(r.serve(oldVM))
+
+	listener, err := grpcutils.NewListener()
+	require.NoError(err)
+	addr := listener.Addr().String()
+	require.NoError(listener.Close())
+
+	// The VM process keeps running if the new one can't be started.
+	err = client.Reload(ctx, r.factory(addr))
+	require.ErrorIs(err, runtime.ErrHandshakeFailed)
+	require.False(oldVM.isShutdown())
+
+	version, err := client.Version(ctx)
+	require.NoError(err)
+	require.Equal("v1", version)
+	serveVersion(require, handler, "v1")
+}
+
+func TestReloadRollback(t *testing.T) {
+	require := require.New(t)
+	ctx := context.Background()
+
+	r := newReloadTest(t)
+	oldVM := newReloadTestVM("v1", nil)
+	client, _, handler := r.initialize(r.serve(oldVM))
+
+	// The new VM process fails to verify the processing block, so it can't
+	// resume the chain.
+	newVM := newReloadTestVM("v2", errTestVerify)
+	err := client.Reload(ctx, r.serve(newVM))
+	require.ErrorIs(err, errVerifyProcessing)
+	require.True(oldVM.isShutdown())
+	require.True(newVM.isShutdown())
+
+	// Reloading the previous VM restores the chain.
+	require.NoError(client.Reload(ctx, r.serve(newReloadTestVM("v1", nil))))
+
+	version, err := client.Version(ctx)
+	require.NoError(err)
+	require.Equal("v1", version)
+	serveVersion(require, handler, "v1")
+}
+
+func TestReloadWaitsForInFlightRequests(t *testing.T) {
+	require := require.New(t)
+	ctx := context.Background()
+
+	r := newReloadTest(t)
+	oldVM := newReloadTestVM("v1", nil)
+	var (
+		started = make(chan struct{})
+		release = make(chan struct{})
+	)
+	oldVM.CreateHandlersF = func(context.Context) (map[string]http.Handler, error) {
+		return map[string]http.Handler{
+			"/version": http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				close(started)
+				<-release
+				_, _ = w.Write([]byte("v1"))
+			}),
+		}, nil
+	}
+	client, _, handler := r.initialize(r.serve(oldVM))
+
+	served := make(chan string, 1)
+	go func() {
+		recorder := httptest.NewRecorder()
+		handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/version", nil))
+		served <- recorder.Body.String()
+	}()
+	<-started
+
+	reloaded := make(chan error, 1)
+	go func() {
+		reloaded <- client.Reload(ctx, r.serve(newReloadTestVM("v2", nil)))
+	}()
+
+	// The VM process isn't replaced while it handles a request.
+	select {
+	case err := <-reloaded:
+		require.FailNow("reloaded during an in-flight request", "err: %v", err)
+	case <-time.After(100 * time.Millisecond):
+	}
+	require.False(oldVM.isShutdown())
+
+	close(release)
+	require.Equal("v1", <-served)
+	require.NoError(<-reloaded)
+	require.True(oldVM.isShutdown())
+	serveVersion(require, handler, "v2")
+}
@@ -0,0 +1,155 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package rpcchainvm
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/pem"
+	"math/big"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/ava-labs/avalanchego/snow/engine/snowman/block"
+	"github.com/ava-labs/avalanchego/utils"
+	"github.com/ava-labs/avalanchego/utils/hashing"
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/ava-labs/avalanchego/vms/rpcchainvm/grpcutils"
+	"github.com/ava-labs/avalanchego/vms/rpcchainvm/runtime"
+
+	vmpb "github.com/ava-labs/avalanchego/proto/pb/vm"
+)
+
+// writeTLSFiles writes a self-signed certificate, that is valid for localhost,
+// along with its key to [dir]. The certificate is also its own CA.
+func writeTLSFiles(t *testing.T, dir string) (string, string) {
+	require := require.New(t)
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(err)
+
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IPAddresses:           []net.IP{net.IPv4(127, 0, 0, 1)},
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	certBytes, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(err)
+	keyBytes, err := x509.MarshalPKCS8PrivateKey(key)
+	require.NoError(err)
+
+	certFile := filepath.Join(dir, "vm.crt")
+	keyFile := filepath.Join(dir, "vm.key")
+	require.NoError(os.WriteFile(certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: certBytes}), 0o600))
+	require.NoError(os.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: keyBytes}), 0o600))
+	return certFile, keyFile
+}
+
+func TestRemoteVM(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+
+	certFile, keyFile := writeTLSFiles(t, t.TempDir())
+	tlsConfig, err := grpcutils.LoadMutualTLSConfig(certFile, keyFile, certFile)
+	require.NoError(err)
+
+	vm := block.NewMockChainVM(ctrl)
+	vm.EXPECT().Version(gomock.Any()).Return("v1.2.3", nil)
+	vm.EXPECT().CreateHandlers(gomock.Any()).Return(map[string]http.Handler{
+		"/hello": http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			_, _ = w.Write([]byte("hello"))
+		}),
+	}, nil)
+
+	listener, err := grpcutils.NewListener()
+	require.NoError(err)
+	addr := listener.Addr().String()
+
+	served := make(chan error, 1)
+	go func() {
+		served <- serveRemote(
+			context.Background(),
+			vm,
+			listener,
+			grpcutils.Network{TLSConfig: tlsConfig},
+		)
+	}()
+
+	runtimeManager := runtime.NewManager()
+	factory := NewRemoteFactory(
+		RemoteConfig{
+			Address:  addr,
+			CertFile: certFile,
+			KeyFile:  keyFile,
+			CAFile:   certFile,
+		},
+		runtimeManager,
+	)
+	vmIntf, err := factory.New(logging.NoLog{})
+	require.NoError(err)
+	client := vmIntf.(*VMClient)
+
+	// The VM server hosts a single VM instance, so it can't be used by a
+	// second chain.
+	_, err = factory.New(logging.NoLog{})
+	require.ErrorIs(err, errRemoteVMInUse)
+
+	ctx := context.Background()
+	version, err := client.Version(ctx)
+	require.NoError(err)
+	require.Equal("v1.2.3", version)
+
+	// Requests to the VM's handlers are served by the VM server and the
+	// responses are written back over the network.
+	handlers, err := client.CreateHandlers(ctx)
+	require.NoError(err)
+	require.Contains(handlers, "/hello")
+
+	recorder := httptest.NewRecorder()
+	handlers["/hello"].ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/hello", nil))
+	require.Equal("hello", recorder.Body.String())
+
+	// Shutting down the client stops the VM server without signaling it.
+	require.NoError(client.Shutdown(ctx))
+	select {
+	case err := <-served:
+		require.NoError(err)
+	case <-time.After(runtime.DefaultGracefulTimeout):
+		require.FailNow("remote vm server didn't stop")
+	}
+}
+
+func TestVMServerInitializeOnce(t *testing.T) {
+	require := require.New(t)
+
+	server := NewServer(nil, &utils.Atomic[bool]{})
+
+	// The first request is rejected because it is malformed.
+	_, err := server.Initialize(context.Background(), &vmpb.InitializeRequest{})
+	require.ErrorIs(err, hashing.ErrInvalidHashLen)
+
+	_, err = server.Initialize(context.Background(), &vmpb.InitializeRequest{})
+	require.ErrorIs(err, errAlreadyInitialized)
+}
+
+func TestServeRemoteRequiresTLS(t *testing.T) {
+	err := ServeRemote(context.Background(), nil, "127.0.0.1:0", grpcutils.Network{})
+	require.ErrorIs(t, err, errMutualTLSRequired)
+}
@@ -0,0 +1,103 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package remote
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/ava-labs/avalanchego/version"
+	"github.com/ava-labs/avalanchego/vms/rpcchainvm/grpcutils"
+	"github.com/ava-labs/avalanchego/vms/rpcchainvm/gruntime"
+	"github.com/ava-labs/avalanchego/vms/rpcchainvm/runtime"
+
+	pb "github.com/ava-labs/avalanchego/proto/pb/vm/runtime"
+)
+
+type Config struct {
+	// Network used to reach the VM server and to serve the callbacks it dials.
+	// Mutual TLS is required.
+	Network grpcutils.Network
+	// Duration to wait for the VM server to become reachable and complete the
+	// handshake.
+	HandshakeTimeout time.Duration
+	// Frequency at which the VM server is checked once the handshake succeeds.
+	HealthCheckInterval time.Duration
+	Log                 logging.Logger
+}
+
+type Status struct {
+	// Address of the VM gRPC service.
+	Addr string
+	// Instance of the VM server that completed the handshake.
+	InstanceID ids.ID
+}
+
+// Bootstrap connects to a VM server that was started independently of
+// AvalancheGo and listens on [addr].
+//
+// Connectivity to the VM server is checked periodically until the returned
+// stopper is stopped. Stopping requests the VM server to shut down rather than
+// signaling a process.
+func Bootstrap(
+	ctx context.Context,
+	addr string,
+	config *Config,
+) (*Status, runtime.Stopper, error) {
+	switch {
+	case addr == "":
+		return nil, nil, fmt.Errorf("%w: address required", runtime.ErrInvalidConfig)
+	case config.Network.TLSConfig == nil:
+		return nil, nil, fmt.Errorf("%w: mutual TLS required", runtime.ErrInvalidConfig)
+	case config.HealthCheckInterval <= 0:
+		return nil, nil, fmt.Errorf("%w: health check interval must be positive", runtime.ErrInvalidConfig)
+	case config.Log == nil:
+		return nil, nil, fmt.Errorf("%w: logger required", runtime.ErrInvalidConfig)
+	}
+
+	clientConn, err := config.Network.Dial(addr)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create client conn: %w", err)
+	}
+	client := gruntime.NewRemoteClient(pb.NewRemoteClient(clientConn))
+
+	// The VM server may still be starting, so the handshake waits for the
+	// connection to become ready until the timeout expires.
+	handshakeCtx, cancel := context.WithTimeout(ctx, config.HandshakeTimeout)
+	protocolVersion, instanceID, err := client.Handshake(handshakeCtx)
+	cancel()
+	if err != nil {
+		_ = clientConn.Close()
+		return nil, nil, fmt.Errorf("%w: %w", runtime.ErrHandshakeFailed, err)
+	}
+	if protocolVersion != version.RPCChainVMProtocol {
+		_ = clientConn.Close()
+		return nil, nil, fmt.Errorf("%w: %w. AvalancheGo version %s implements RPCChainVM protocol version %d. The VM implements RPCChainVM protocol version %d",
+			runtime.ErrHandshakeFailed,
+			runtime.ErrProtocolVersionMismatch,
+			version.Current,
+			version.RPCChainVMProtocol,
+			protocolVersion,
+		)
+	}
+
+	config.Log.Info("remote vm handshake succeeded",
+		zap.String("addr", addr),
+		zap.Stringer("instanceID", instanceID),
+	)
+
+	stopper := newStopper(config.Log, addr, clientConn, client, instanceID)
+	stopper.start(config.HealthCheckInterval)
+
+	status := &Status{
+		Addr:       addr,
+		InstanceID: instanceID,
+	}
+	return status, stopper, nil
+}
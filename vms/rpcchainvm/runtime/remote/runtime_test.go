@@ -0,0 +1,254 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package remote
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"math/big"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/ava-labs/avalanchego/version"
+	"github.com/ava-labs/avalanchego/vms/rpcchainvm/grpcutils"
+	"github.com/ava-labs/avalanchego/vms/rpcchainvm/gruntime"
+	"github.com/ava-labs/avalanchego/vms/rpcchainvm/runtime"
+
+	pb "github.com/ava-labs/avalanchego/proto/pb/vm/runtime"
+)
+
+// newTLSConfig returns a mutual TLS config whose self-signed certificate is
+// valid for localhost and is its only trusted CA.
+func newTLSConfig(t *testing.T) *tls.Config {
+	require := require.New(t)
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(err)
+
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IPAddresses:           []net.IP{net.IPv4(127, 0, 0, 1)},
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	certBytes, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(err)
+	cert, err := x509.ParseCertificate(certBytes)
+	require.NoError(err)
+
+	pool := x509.NewCertPool()
+	pool.AddCert(cert)
+	return &tls.Config{
+		Certificates: []tls.Certificate{{
+			Certificate: [][]byte{certBytes},
+			PrivateKey:  key,
+		}},
+		RootCAs:    pool,
+		ClientCAs:  pool,
+		ClientAuth: tls.RequireAndVerifyClientCert,
+		MinVersion: tls.VersionTLS13,
+	}
+}
+
+type testServer struct {
+	addr     string
+	shutdown chan struct{}
+	stop     func()
+}
+
+// serve starts a VM server on [addr] that only exposes the remote runtime
+// service.
+func serve(
+	t *testing.T,
+	addr string,
+	tlsConfig *tls.Config,
+	protocolVersion uint,
+	instanceID ids.ID,
+) *testServer {
+	require := require.New(t)
+
+	listener, err := net.Listen("tcp", addr)
+	require.NoError(err)
+
+	s := &testServer{
+		addr:     listener.Addr().String(),
+		shutdown: make(chan struct{}, 1),
+	}
+	server := grpcutils.Network{TLSConfig: tlsConfig}.NewServer()
+	pb.RegisterRemoteServer(server, gruntime.NewRemoteServer(
+		protocolVersion,
+		instanceID,
+		func() {
+			s.shutdown <- struct{}{}
+		},
+	))
+	go grpcutils.Serve(listener, server)
+
+	s.stop = server.Stop
+	t.Cleanup(s.stop)
+	return s
+}
+
+func newConfig(tlsConfig *tls.Config) *Config {
+	return &Config{
+		Network:             grpcutils.Network{TLSConfig: tlsConfig},
+		HandshakeTimeout:    time.Second,
+		HealthCheckInterval: 10 * time.Millisecond,
+		Log:                 logging.NoLog{},
+	}
+}
+
+func TestBootstrap(t *testing.T) {
+	tlsConfig := newTLSConfig(t)
+
+	tests := []struct {
+		name            string
+		protocolVersion uint
+		config          func(*Config)
+		expectedErr     error
+	}{
+		{
+			name:            "success",
+			protocolVersion: version.RPCChainVMProtocol,
+			config:          func(*Config) {},
+			expectedErr:     nil,
+		},
+		{
+			name:            "protocol version mismatch",
+			protocolVersion: version.RPCChainVMProtocol + 1,
+			config:          func(*Config) {},
+			expectedErr:     runtime.ErrProtocolVersionMismatch,
+		},
+		{
+			name:            "untrusted certificate",
+			protocolVersion: version.RPCChainVMProtocol,
+			config: func(c *Config) {
+				c.Network.TLSConfig = newTLSConfig(t)
+				c.HandshakeTimeout = 100 * time.Millisecond
+			},
+			expectedErr: runtime.ErrHandshakeFailed,
+		},
+		{
+			name:            "missing tls",
+			protocolVersion: version.RPCChainVMProtocol,
+			config: func(c *Config) {
+				c.Network.TLSConfig = nil
+			},
+			expectedErr: runtime.ErrInvalidConfig,
+		},
+		{
+			name:            "missing health check interval",
+			protocolVersion: version.RPCChainVMProtocol,
+			config: func(c *Config) {
+				c.HealthCheckInterval = 0
+			},
+			expectedErr: runtime.ErrInvalidConfig,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require := require.New(t)
+
+			instanceID := ids.GenerateTestID()
+			server := serve(t, "127.0.0.1:0", tlsConfig, test.protocolVersion, instanceID)
+
+			config := newConfig(tlsConfig)
+			test.config(config)
+
+			status, stopper, err := Bootstrap(context.Background(), server.addr, config)
+			require.ErrorIs(err, test.expectedErr)
+			if err != nil {
+				return
+			}
+			defer stopper.Stop(context.Background())
+
+			require.Equal(server.addr, status.Addr)
+			require.Equal(instanceID, status.InstanceID)
+		})
+	}
+}
+
+func TestStop(t *testing.T) {
+	require := require.New(t)
+
+	tlsConfig := newTLSConfig(t)
+	server := serve(t, "127.0.0.1:0", tlsConfig, version.RPCChainVMProtocol, ids.GenerateTestID())
+
+	_, stopper, err := Bootstrap(context.Background(), server.addr, newConfig(tlsConfig))
+	require.NoError(err)
+
+	stopper.Stop(context.Background())
+	require.Len(server.shutdown, 1)
+
+	// Stopping again is a no-op.
+	stopper.Stop(context.Background())
+	require.Len(server.shutdown, 1)
+}
+
+func TestHealthCheckReconnect(t *testing.T) {
+	require := require.New(t)
+
+	tlsConfig := newTLSConfig(t)
+	instanceID := ids.GenerateTestID()
+	server := serve(t, "127.0.0.1:0", tlsConfig, version.RPCChainVMProtocol, instanceID)
+
+	_, stopper, err := Bootstrap(context.Background(), server.addr, newConfig(tlsConfig))
+	require.NoError(err)
+	defer stopper.Stop(context.Background())
+
+	checker := stopper.(runtime.HealthChecker)
+	isHealthy := func() bool {
+		_, err := checker.HealthCheck(context.Background())
+		return err == nil
+	}
+	require.True(isHealthy())
+
+	server.stop()
+	require.Eventually(func() bool {
+		return !isHealthy()
+	}, 5*time.Second, 10*time.Millisecond)
+
+	// The same instance becoming reachable again is healthy.
+	serve(t, server.addr, tlsConfig, version.RPCChainVMProtocol, instanceID)
+	require.Eventually(isHealthy, 5*time.Second, 10*time.Millisecond)
+}
+
+func TestHealthCheckRestart(t *testing.T) {
+	require := require.New(t)
+
+	tlsConfig := newTLSConfig(t)
+	server := serve(t, "127.0.0.1:0", tlsConfig, version.RPCChainVMProtocol, ids.GenerateTestID())
+
+	_, stopper, err := Bootstrap(context.Background(), server.addr, newConfig(tlsConfig))
+	require.NoError(err)
+	defer stopper.Stop(context.Background())
+
+	server.stop()
+	serve(t, server.addr, tlsConfig, version.RPCChainVMProtocol, ids.GenerateTestID())
+
+	checker := stopper.(runtime.HealthChecker)
+	require.Eventually(func() bool {
+		_, err := checker.HealthCheck(context.Background())
+		return errors.Is(err, errRestarted)
+	}, 5*time.Second, 10*time.Millisecond)
+
+	// A restarted instance is never reported as healthy again.
+	time.Sleep(50 * time.Millisecond)
+	_, err = checker.HealthCheck(context.Background())
+	require.ErrorIs(err, errRestarted)
+}
@@ -0,0 +1,147 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package remote
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/ava-labs/avalanchego/vms/rpcchainvm/gruntime"
+	"github.com/ava-labs/avalanchego/vms/rpcchainvm/runtime"
+)
+
+var (
+	_ runtime.Stopper       = (*stopper)(nil)
+	_ runtime.HealthChecker = (*stopper)(nil)
+
+	errRestarted = errors.New("remote vm server restarted")
+)
+
+// stopper periodically checks a remote VM server and requests it to shut down
+// once stopped.
+type stopper struct {
+	log        logging.Logger
+	addr       string
+	clientConn *grpc.ClientConn
+	client     *gruntime.RemoteClient
+	instanceID ids.ID
+
+	lock sync.RWMutex
+	// err is the result of the most recent check of the VM server.
+	err error
+
+	once    sync.Once
+	closing chan struct{}
+	wg      sync.WaitGroup
+}
+
+func newStopper(
+	log logging.Logger,
+	addr string,
+	clientConn *grpc.ClientConn,
+	client *gruntime.RemoteClient,
+	instanceID ids.ID,
+) *stopper {
+	return &stopper{
+		log:        log,
+		addr:       addr,
+		clientConn: clientConn,
+		client:     client,
+		instanceID: instanceID,
+		closing:    make(chan struct{}),
+	}
+}
+
+func (s *stopper) start(interval time.Duration) {
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ticker.C:
+				s.check(interval)
+			case <-s.closing:
+				return
+			}
+		}
+	}()
+}
+
+// check performs a new handshake with the VM server. gRPC transparently
+// reconnects to the VM server, so the VM server is reported healthy again once
+// it is reachable unless it was restarted in the meantime.
+func (s *stopper) check(timeout time.Duration) {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	// Fail fast rather than waiting for the connection to become ready.
+	_, instanceID, err := s.client.Handshake(ctx, grpc.WaitForReady(false))
+	if err == nil && instanceID != s.instanceID {
+		err = fmt.Errorf("%w: expected instance %s but found %s", errRestarted, s.instanceID, instanceID)
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	// A restarted VM server lost the state of the chain, so it is never
+	// considered healthy again.
+	if errors.Is(s.err, errRestarted) {
+		return
+	}
+
+	switch {
+	case errors.Is(err, errRestarted):
+		s.log.Error("remote vm server restarted",
+			zap.String("addr", s.addr),
+			zap.Error(err),
+		)
+	case err != nil && s.err == nil:
+		s.log.Warn("remote vm server unreachable",
+			zap.String("addr", s.addr),
+			zap.Error(err),
+		)
+	case err == nil && s.err != nil:
+		s.log.Info("remote vm server reachable",
+			zap.String("addr", s.addr),
+		)
+	}
+	s.err = err
+}
+
+func (s *stopper) HealthCheck(context.Context) (interface{}, error) {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	return nil, s.err
+}
+
+func (s *stopper) Stop(ctx context.Context) {
+	s.once.Do(func() {
+		close(s.closing)
+		s.wg.Wait()
+
+		ctx, cancel := context.WithTimeout(ctx, runtime.DefaultGracefulTimeout)
+		defer cancel()
+
+		if err := s.client.Shutdown(ctx); err != nil {
+			s.log.Warn("failed to shutdown remote vm server",
+				zap.String("addr", s.addr),
+				zap.Error(err),
+			)
+		}
+		_ = s.clientConn.Close()
+	})
+}
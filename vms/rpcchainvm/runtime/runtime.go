@@ -39,6 +39,12 @@ type Stopper interface {
 	Stop(ctx context.Context)
 }
 
+type HealthChecker interface {
+	// HealthCheck returns an error if the VM can no longer be reached through
+	// the runtime.
+	HealthCheck(ctx context.Context) (interface{}, error)
+}
+
 type Tracker interface {
 	// TrackRuntime adds a VM stopper to the manager.
 	TrackRuntime(runtime Stopper)
@@ -5,15 +5,20 @@ package rpcchainvm
 
 import (
 	"context"
+	"crypto/rand"
+	"errors"
 	"fmt"
+	"net"
 	"os"
 	"os/signal"
+	"sync"
 	"syscall"
 	"time"
 
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/health"
 
+	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/snow/engine/snowman/block"
 	"github.com/ava-labs/avalanchego/utils"
 	"github.com/ava-labs/avalanchego/version"
@@ -28,6 +33,8 @@ import (
 
 const defaultRuntimeDialTimeout = 5 * time.Second
 
+var errMutualTLSRequired = errors.New("mutual TLS required")
+
 // The address of the Runtime server is expected to be passed via ENV `runtime.EngineAddressKey`.
 // This address is used by the Runtime client to send Initialize RPC to server.
 //
@@ -38,7 +45,7 @@ func Serve(ctx context.Context, vm block.ChainVM, opts ...grpcutils.ServerOption
 	defer signal.Stop(signals)
 
 	var allowShutdown utils.Atomic[bool]
-	server := newVMServer(vm, &allowShutdown, opts...)
+	server := newVMServer(vm, &allowShutdown, grpcutils.Network{}, opts...)
 	go func(ctx context.Context) {
 		defer func() {
 			server.GracefulStop()
@@ -102,10 +109,88 @@ func Serve(ctx context.Context, vm block.ChainVM, opts ...grpcutils.ServerOption
 	return nil
 }
 
+// ServeRemote starts the RPC Chain VM server on [addr] so that AvalancheGo can
+// reach it over the network rather than starting it as a subprocess.
+//
+// [network] must be secured with mutual TLS. It secures the RPC Chain VM server
+// and is used to serve the VM's handlers and to dial the services that
+// AvalancheGo exposes to the VM.
+//
+// ServeRemote blocks until AvalancheGo requests the server to shut down, a
+// SIGINT or SIGTERM is received, or [ctx] is cancelled.
+func ServeRemote(ctx context.Context, vm block.ChainVM, addr string, network grpcutils.Network, opts ...grpcutils.ServerOption) error {
+	if network.TLSConfig == nil {
+		return errMutualTLSRequired
+	}
+
+	listener, err := net.Listen("tcp", addr)
+	if err != nil {
+		return fmt.Errorf("failed to create new listener: %w", err)
+	}
+	return serveRemote(ctx, vm, listener, network, opts...)
+}
+
+func serveRemote(ctx context.Context, vm block.ChainVM, listener net.Listener, network grpcutils.Network, opts ...grpcutils.ServerOption) error {
+	var instanceID ids.ID
+	if _, err := rand.Read(instanceID[:]); err != nil {
+		_ = listener.Close()
+		return fmt.Errorf("failed to generate instance ID: %w", err)
+	}
+
+	signals := make(chan os.Signal, 2)
+	signal.Notify(signals, syscall.SIGINT, syscall.SIGTERM)
+	defer signal.Stop(signals)
+
+	var (
+		// Signals are only ignored before shutdown by subprocesses, which
+		// share the signals sent to AvalancheGo's process group.
+		allowShutdown utils.Atomic[bool]
+		shutdownOnce  sync.Once
+		shutdown      = make(chan struct{})
+		done          = make(chan struct{})
+	)
+	defer close(done)
+
+	server := newVMServer(vm, &allowShutdown, network, opts...)
+	runtimepb.RegisterRemoteServer(server, gruntime.NewRemoteServer(
+		version.RPCChainVMProtocol,
+		instanceID,
+		func() {
+			shutdownOnce.Do(func() {
+				close(shutdown)
+			})
+		},
+	))
+
+	go func() {
+		select {
+		case s := <-signals:
+			fmt.Printf("runtime engine: received shutdown signal: %s\n", s)
+		case <-shutdown:
+			fmt.Println("runtime engine: received shutdown request")
+		case <-ctx.Done():
+			fmt.Println("runtime engine: context has been cancelled")
+		case <-done:
+			return
+		}
+		server.GracefulStop()
+		fmt.Println("vm server: graceful termination success")
+	}()
+
+	fmt.Printf("runtime engine: serving instance %s on %s\n", instanceID, listener.Addr())
+
+	// start RPC Chain VM server
+	grpcutils.Serve(listener, server)
+
+	return nil
+}
+
 // Returns an RPC Chain VM server serving health and VM services.
-func newVMServer(vm block.ChainVM, allowShutdown *utils.Atomic[bool], opts ...grpcutils.ServerOption) *grpc.Server {
-	server := grpcutils.NewServer(opts...)
-	vmpb.RegisterVMServer(server, NewServer(vm, allowShutdown))
+func newVMServer(vm block.ChainVM, allowShutdown *utils.Atomic[bool], network grpcutils.Network, opts ...grpcutils.ServerOption) *grpc.Server {
+	server := network.NewServer(opts...)
+	vmServer := NewServer(vm, allowShutdown)
+	vmServer.network = network
+	vmpb.RegisterVMServer(server, vmServer)
 
 	health := health.NewServer()
 	health.SetServingStatus("", healthpb.HealthCheckResponse_SERVING)
@@ -9,6 +9,7 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
+	"sync"
 	"time"
 
 	"github.com/prometheus/client_golang/prometheus"
@@ -85,10 +86,21 @@ var (
 // VMClient is an implementation of a VM that talks over RPC.
 type VMClient struct {
 	*chain.State
+
+	// processLock guards the fields that are replaced when the VM process is
+	// reloaded. Every call into the VM process holds the read lock, so that
+	// callers that don't hold the context lock of the chain, such as HTTP
+	// handlers, gossip, health checks and metrics, never call into a VM
+	// process that is being replaced.
+	processLock    sync.RWMutex
 	client         vmpb.VMClient
 	runtime        runtime.Stopper
 	pid            int
 	processTracker resource.ProcessTracker
+	network        grpcutils.Network
+	db             *processDB
+	// stopped is true if the VM process was stopped and not replaced.
+	stopped bool
 
 	messenger            *messenger.Server
 	keystore             *gkeystore.Server
@@ -102,13 +114,34 @@ type VMClient struct {
 	conns        []*grpc.ClientConn
 
 	grpcServerMetrics *grpc_prometheus.ServerMetrics
+
+	// The arguments of Initialize are kept to initialize a new VM process when
+	// the VM is reloaded.
+	chainCtx     *snow.Context
+	chainDB      database.Database
+	genesisBytes []byte
+	upgradeBytes []byte
+	configBytes  []byte
+	toEngine     chan<- common.Message
+	sender       common.AppSender
+
+	// The state that the engine established in the VM process, which is
+	// replayed to a new VM process when the VM is reloaded.
+	engineState snow.State
+	preference  ids.ID
+	connected   map[ids.NodeID]*version.Application
+	processing  map[ids.ID]*blockClient
+	handlers    map[string]*handlerClient
 }
 
 // NewClient returns a VM connected to a remote VM
 func NewClient(clientConn *grpc.ClientConn) *VMClient {
 	return &VMClient{
-		client: vmpb.NewVMClient(clientConn),
-		conns:  []*grpc.ClientConn{clientConn},
+		client:     vmpb.NewVMClient(clientConn),
+		conns:      []*grpc.ClientConn{clientConn},
+		connected:  make(map[ids.NodeID]*version.Application),
+		processing: make(map[ids.ID]*blockClient),
+		handlers:   make(map[string]*handlerClient),
 	}
 }
 
@@ -120,6 +153,14 @@ func (vm *VMClient) SetProcess(runtime runtime.Stopper, pid int, processTracker
 	processTracker.TrackProcess(vm.pid)
 }
 
+// SetRemote gives ownership of a VM server that isn't a local process to the
+// client. [network] is used to serve the callbacks that the VM server dials and
+// to dial the servers that the VM server exposes.
+func (vm *VMClient) SetRemote(runtime runtime.Stopper, network grpcutils.Network) {
+	vm.runtime = runtime
+	vm.network = network
+}
+
 func (vm *VMClient) Initialize(
 	ctx context.Context,
 	chainCtx *snow.Context,
@@ -135,6 +176,14 @@ func (vm *VMClient) Initialize(
 		return errUnsupportedFXs
 	}
 
+	vm.chainCtx = chainCtx
+	vm.chainDB = db
+	vm.genesisBytes = genesisBytes
+	vm.upgradeBytes = upgradeBytes
+	vm.configBytes = configBytes
+	vm.toEngine = toEngine
+	vm.sender = appSender
+
 	// Register metrics
 	registerer := prometheus.NewRegistry()
 	multiGatherer := metrics.NewMultiGatherer()
@@ -149,53 +198,7 @@ func (vm *VMClient) Initialize(
 		return err
 	}
 
-	// Initialize the database
-	dbServerListener, err := grpcutils.NewListener()
-	if err != nil {
-		return err
-	}
-	dbServerAddr := dbServerListener.Addr().String()
-
-	go grpcutils.Serve(dbServerListener, vm.newDBServer(db))
-	chainCtx.Log.Info("grpc: serving database",
-		zap.String("address", dbServerAddr),
-	)
-
-	vm.messenger = messenger.NewServer(toEngine)
-	vm.keystore = gkeystore.NewServer(chainCtx.Keystore)
-	vm.sharedMemory = gsharedmemory.NewServer(chainCtx.SharedMemory, db)
-	vm.bcLookup = galiasreader.NewServer(chainCtx.BCLookup)
-	vm.appSender = appsender.NewServer(appSender)
-	vm.validatorStateServer = gvalidators.NewServer(chainCtx.ValidatorState)
-	vm.warpSignerServer = gwarp.NewServer(chainCtx.WarpSigner)
-
-	serverListener, err := grpcutils.NewListener()
-	if err != nil {
-		return err
-	}
-	serverAddr := serverListener.Addr().String()
-
-	go grpcutils.Serve(serverListener, vm.newInitServer())
-	chainCtx.Log.Info("grpc: serving vm services",
-		zap.String("address", serverAddr),
-	)
-
-	resp, err := vm.client.Initialize(ctx, &vmpb.InitializeRequest{
-		NetworkId:    chainCtx.NetworkID,
-		SubnetId:     chainCtx.SubnetID[:],
-		ChainId:      chainCtx.ChainID[:],
-		NodeId:       chainCtx.NodeID.Bytes(),
-		PublicKey:    bls.PublicKeyToCompressedBytes(chainCtx.PublicKey),
-		XChainId:     chainCtx.XChainID[:],
-		CChainId:     chainCtx.CChainID[:],
-		AvaxAssetId:  chainCtx.AVAXAssetID[:],
-		ChainDataDir: chainCtx.ChainDataDir,
-		GenesisBytes: genesisBytes,
-		UpgradeBytes: upgradeBytes,
-		ConfigBytes:  configBytes,
-		DbServerAddr: dbServerAddr,
-		ServerAddr:   serverAddr,
-	})
+	resp, err := vm.initializeProcess(ctx)
 	if err != nil {
 		return err
 	}
@@ -249,8 +252,58 @@ func (vm *VMClient) Initialize(
 	return chainCtx.Metrics.Register(multiGatherer)
 }
 
+// initializeProcess serves the database and the services of the node to the VM
+// process and initializes the VM process with the arguments of Initialize.
+func (vm *VMClient) initializeProcess(ctx context.Context) (*vmpb.InitializeResponse, error) {
+	// Initialize the database
+	dbServerListener, dbServerAddr, err := vm.network.Listen()
+	if err != nil {
+		return nil, err
+	}
+
+	go grpcutils.Serve(dbServerListener, vm.newDBServer(vm.chainDB))
+	vm.chainCtx.Log.Info("grpc: serving database",
+		zap.String("address", dbServerAddr),
+	)
+
+	vm.messenger = messenger.NewServer(vm.toEngine)
+	vm.keystore = gkeystore.NewServer(vm.chainCtx.Keystore)
+	vm.sharedMemory = gsharedmemory.NewServer(vm.chainCtx.SharedMemory, vm.chainDB)
+	vm.bcLookup = galiasreader.NewServer(vm.chainCtx.BCLookup)
+	vm.appSender = appsender.NewServer(vm.sender)
+	vm.validatorStateServer = gvalidators.NewServer(vm.chainCtx.ValidatorState)
+	vm.warpSignerServer = gwarp.NewServer(vm.chainCtx.WarpSigner)
+
+	serverListener, serverAddr, err := vm.network.Listen()
+	if err != nil {
+		return nil, err
+	}
+
+	go grpcutils.Serve(serverListener, vm.newInitServer())
+	vm.chainCtx.Log.Info("grpc: serving vm services",
+		zap.String("address", serverAddr),
+	)
+
+	return vm.client.Initialize(ctx, &vmpb.InitializeRequest{
+		NetworkId:    vm.chainCtx.NetworkID,
+		SubnetId:     vm.chainCtx.SubnetID[:],
+		ChainId:      vm.chainCtx.ChainID[:],
+		NodeId:       vm.chainCtx.NodeID.Bytes(),
+		PublicKey:    bls.PublicKeyToCompressedBytes(vm.chainCtx.PublicKey),
+		XChainId:     vm.chainCtx.XChainID[:],
+		CChainId:     vm.chainCtx.CChainID[:],
+		AvaxAssetId:  vm.chainCtx.AVAXAssetID[:],
+		ChainDataDir: vm.chainCtx.ChainDataDir,
+		GenesisBytes: vm.genesisBytes,
+		UpgradeBytes: vm.upgradeBytes,
+		ConfigBytes:  vm.configBytes,
+		DbServerAddr: dbServerAddr,
+		ServerAddr:   serverAddr,
+	})
+}
+
 func (vm *VMClient) newDBServer(db database.Database) *grpc.Server {
-	server := grpcutils.NewServer(
+	server := vm.network.NewServer(
 		grpcutils.WithUnaryInterceptor(vm.grpcServerMetrics.UnaryServerInterceptor()),
 		grpcutils.WithStreamInterceptor(vm.grpcServerMetrics.StreamServerInterceptor()),
 	)
@@ -262,7 +315,8 @@ func (vm *VMClient) newDBServer(db database.Database) *grpc.Server {
 	vm.serverCloser.Add(server)
 
 	// Register services
-	rpcdbpb.RegisterDatabaseServer(server, rpcdb.NewServer(db))
+	vm.db = &processDB{Database: db}
+	rpcdbpb.RegisterDatabaseServer(server, rpcdb.NewServer(vm.db))
 	healthpb.RegisterHealthServer(server, grpcHealth)
 
 	// Ensure metric counters are zeroed on restart
@@ -272,7 +326,7 @@ func (vm *VMClient) newDBServer(db database.Database) *grpc.Server {
 }
 
 func (vm *VMClient) newInitServer() *grpc.Server {
-	server := grpcutils.NewServer(
+	server := vm.network.NewServer(
 		grpcutils.WithUnaryInterceptor(vm.grpcServerMetrics.UnaryServerInterceptor()),
 		grpcutils.WithStreamInterceptor(vm.grpcServerMetrics.StreamServerInterceptor()),
 	)
@@ -300,12 +354,16 @@ func (vm *VMClient) newInitServer() *grpc.Server {
 }
 
 func (vm *VMClient) SetState(ctx context.Context, state snow.State) error {
+	vm.processLock.RLock()
+	defer vm.processLock.RUnlock()
+
 	resp, err := vm.client.SetState(ctx, &vmpb.SetStateRequest{
 		State: vmpb.State(state),
 	})
 	if err != nil {
 		return err
 	}
+	vm.engineState = state
 
 	id, err := ids.ToID(resp.LastAcceptedId)
 	if err != nil {
@@ -336,6 +394,20 @@ func (vm *VMClient) SetState(ctx context.Context, state snow.State) error {
 }
 
 func (vm *VMClient) Shutdown(ctx context.Context) error {
+	vm.processLock.Lock()
+	defer vm.processLock.Unlock()
+
+	return vm.stopProcess(ctx)
+}
+
+// stopProcess shuts down the VM process and stops serving it. It is a no-op if
+// the VM process was already stopped.
+func (vm *VMClient) stopProcess(ctx context.Context) error {
+	if vm.stopped {
+		return nil
+	}
+	vm.stopped = true
+
 	errs := wrappers.Errs{}
 	_, err := vm.client.Shutdown(ctx, &emptypb.Empty{})
 	errs.Add(err)
@@ -347,11 +419,16 @@ func (vm *VMClient) Shutdown(ctx context.Context) error {
 
 	vm.runtime.Stop(ctx)
 
-	vm.processTracker.UntrackProcess(vm.pid)
+	if vm.processTracker != nil {
+		vm.processTracker.UntrackProcess(vm.pid)
+	}
 	return errs.Err
 }
 
 func (vm *VMClient) CreateHandlers(ctx context.Context) (map[string]http.Handler, error) {
+	vm.processLock.RLock()
+	defer vm.processLock.RUnlock()
+
 	resp, err := vm.client.CreateHandlers(ctx, &emptypb.Empty{})
 	if err != nil {
 		return nil, err
@@ -359,18 +436,29 @@ func (vm *VMClient) CreateHandlers(ctx context.Context) (map[string]http.Handler
 
 	handlers := make(map[string]http.Handler, len(resp.Handlers))
 	for _, handler := range resp.Handlers {
-		clientConn, err := grpcutils.Dial(handler.ServerAddr)
+		clientConn, err := vm.network.Dial(handler.ServerAddr)
 		if err != nil {
 			return nil, err
 		}
 
 		vm.conns = append(vm.conns, clientConn)
-		handlers[handler.Prefix] = ghttp.NewClient(httppb.NewHTTPClient(clientConn))
+
+		// The handler is forwarded to the VM process that replaces this one if
+		// the VM is reloaded.
+		client := &handlerClient{
+			processLock: &vm.processLock,
+		}
+		client.set(ghttp.NewClient(httppb.NewHTTPClient(clientConn), vm.network))
+		vm.handlers[handler.Prefix] = client
+		handlers[handler.Prefix] = client
 	}
 	return handlers, nil
 }
 
 func (vm *VMClient) Connected(ctx context.Context, nodeID ids.NodeID, nodeVersion *version.Application) error {
+	vm.processLock.RLock()
+	defer vm.processLock.RUnlock()
+
 	_, err := vm.client.Connected(ctx, &vmpb.ConnectedRequest{
 		NodeId: nodeID.Bytes(),
 		Name:   nodeVersion.Name,
@@ -378,19 +466,35 @@ func (vm *VMClient) Connected(ctx context.Context, nodeID ids.NodeID, nodeVersio
 		Minor:  uint32(nodeVersion.Minor),
 		Patch:  uint32(nodeVersion.Patch),
 	})
-	return err
+	if err != nil {
+		return err
+	}
+
+	vm.connected[nodeID] = nodeVersion
+	return nil
 }
 
 func (vm *VMClient) Disconnected(ctx context.Context, nodeID ids.NodeID) error {
+	vm.processLock.RLock()
+	defer vm.processLock.RUnlock()
+
 	_, err := vm.client.Disconnected(ctx, &vmpb.DisconnectedRequest{
 		NodeId: nodeID.Bytes(),
 	})
-	return err
+	if err != nil {
+		return err
+	}
+
+	delete(vm.connected, nodeID)
+	return nil
 }
 
 // If the underlying VM doesn't actually implement this method, its [BuildBlock]
 // method will be called instead.
 func (vm *VMClient) buildBlockWithContext(ctx context.Context, blockCtx *block.Context) (snowman.Block, error) {
+	vm.processLock.RLock()
+	defer vm.processLock.RUnlock()
+
 	resp, err := vm.client.BuildBlock(ctx, &vmpb.BuildBlockRequest{
 		PChainHeight: &blockCtx.PChainHeight,
 	})
@@ -401,6 +505,9 @@ func (vm *VMClient) buildBlockWithContext(ctx context.Context, blockCtx *block.C
 }
 
 func (vm *VMClient) buildBlock(ctx context.Context) (snowman.Block, error) {
+	vm.processLock.RLock()
+	defer vm.processLock.RUnlock()
+
 	resp, err := vm.client.BuildBlock(ctx, &vmpb.BuildBlockRequest{})
 	if err != nil {
 		return nil, err
@@ -409,6 +516,9 @@ func (vm *VMClient) buildBlock(ctx context.Context) (snowman.Block, error) {
 }
 
 func (vm *VMClient) parseBlock(ctx context.Context, bytes []byte) (snowman.Block, error) {
+	vm.processLock.RLock()
+	defer vm.processLock.RUnlock()
+
 	resp, err := vm.client.ParseBlock(ctx, &vmpb.ParseBlockRequest{
 		Bytes: bytes,
 	})
@@ -448,6 +558,9 @@ func (vm *VMClient) parseBlock(ctx context.Context, bytes []byte) (snowman.Block
 }
 
 func (vm *VMClient) getBlock(ctx context.Context, blkID ids.ID) (snowman.Block, error) {
+	vm.processLock.RLock()
+	defer vm.processLock.RUnlock()
+
 	resp, err := vm.client.GetBlock(ctx, &vmpb.GetBlockRequest{
 		Id: blkID[:],
 	})
@@ -482,13 +595,32 @@ func (vm *VMClient) getBlock(ctx context.Context, blkID ids.ID) (snowman.Block,
 }
 
 func (vm *VMClient) SetPreference(ctx context.Context, blkID ids.ID) error {
+	vm.processLock.RLock()
+	defer vm.processLock.RUnlock()
+
 	_, err := vm.client.SetPreference(ctx, &vmpb.SetPreferenceRequest{
 		Id: blkID[:],
 	})
-	return err
+	if err != nil {
+		return err
+	}
+
+	vm.preference = blkID
+	return nil
 }
 
 func (vm *VMClient) HealthCheck(ctx context.Context) (interface{}, error) {
+	vm.processLock.RLock()
+	defer vm.processLock.RUnlock()
+
+	// Runtimes that aren't local processes can fail independently of the VM
+	// server, so their health is reported first.
+	if checker, ok := vm.runtime.(runtime.HealthChecker); ok {
+		if _, err := checker.HealthCheck(ctx); err != nil {
+			return nil, fmt.Errorf("runtime health check failed: %w", err)
+		}
+	}
+
 	// HealthCheck is a special case, where we want to fail fast instead of block.
 	failFast := grpc.WaitForReady(false)
 	health, err := vm.client.Health(ctx, &emptypb.Empty{}, failFast)
@@ -500,6 +632,9 @@ func (vm *VMClient) HealthCheck(ctx context.Context) (interface{}, error) {
 }
 
 func (vm *VMClient) Version(ctx context.Context) (string, error) {
+	vm.processLock.RLock()
+	defer vm.processLock.RUnlock()
+
 	resp, err := vm.client.Version(ctx, &emptypb.Empty{})
 	if err != nil {
 		return "", err
@@ -508,6 +643,9 @@ func (vm *VMClient) Version(ctx context.Context) (string, error) {
 }
 
 func (vm *VMClient) CrossChainAppRequest(ctx context.Context, chainID ids.ID, requestID uint32, deadline time.Time, request []byte) error {
+	vm.processLock.RLock()
+	defer vm.processLock.RUnlock()
+
 	_, err := vm.client.CrossChainAppRequest(
 		ctx,
 		&vmpb.CrossChainAppRequestMsg{
@@ -521,6 +659,9 @@ func (vm *VMClient) CrossChainAppRequest(ctx context.Context, chainID ids.ID, re
 }
 
 func (vm *VMClient) CrossChainAppRequestFailed(ctx context.Context, chainID ids.ID, requestID uint32, appErr *common.AppError) error {
+	vm.processLock.RLock()
+	defer vm.processLock.RUnlock()
+
 	msg := &vmpb.CrossChainAppRequestFailedMsg{
 		ChainId:      chainID[:],
 		RequestId:    requestID,
@@ -533,6 +674,9 @@ func (vm *VMClient) CrossChainAppRequestFailed(ctx context.Context, chainID ids.
 }
 
 func (vm *VMClient) CrossChainAppResponse(ctx context.Context, chainID ids.ID, requestID uint32, response []byte) error {
+	vm.processLock.RLock()
+	defer vm.processLock.RUnlock()
+
 	_, err := vm.client.CrossChainAppResponse(
 		ctx,
 		&vmpb.CrossChainAppResponseMsg{
@@ -545,6 +689,9 @@ func (vm *VMClient) CrossChainAppResponse(ctx context.Context, chainID ids.ID, r
 }
 
 func (vm *VMClient) AppRequest(ctx context.Context, nodeID ids.NodeID, requestID uint32, deadline time.Time, request []byte) error {
+	vm.processLock.RLock()
+	defer vm.processLock.RUnlock()
+
 	_, err := vm.client.AppRequest(
 		ctx,
 		&vmpb.AppRequestMsg{
@@ -558,6 +705,9 @@ func (vm *VMClient) AppRequest(ctx context.Context, nodeID ids.NodeID, requestID
 }
 
 func (vm *VMClient) AppResponse(ctx context.Context, nodeID ids.NodeID, requestID uint32, response []byte) error {
+	vm.processLock.RLock()
+	defer vm.processLock.RUnlock()
+
 	_, err := vm.client.AppResponse(
 		ctx,
 		&vmpb.AppResponseMsg{
@@ -570,6 +720,9 @@ func (vm *VMClient) AppResponse(ctx context.Context, nodeID ids.NodeID, requestI
 }
 
 func (vm *VMClient) AppRequestFailed(ctx context.Context, nodeID ids.NodeID, requestID uint32, appErr *common.AppError) error {
+	vm.processLock.RLock()
+	defer vm.processLock.RUnlock()
+
 	msg := &vmpb.AppRequestFailedMsg{
 		NodeId:       nodeID.Bytes(),
 		RequestId:    requestID,
@@ -582,6 +735,9 @@ func (vm *VMClient) AppRequestFailed(ctx context.Context, nodeID ids.NodeID, req
 }
 
 func (vm *VMClient) AppGossip(ctx context.Context, nodeID ids.NodeID, msg []byte) error {
+	vm.processLock.RLock()
+	defer vm.processLock.RUnlock()
+
 	_, err := vm.client.AppGossip(
 		ctx,
 		&vmpb.AppGossipMsg{
@@ -593,6 +749,9 @@ func (vm *VMClient) AppGossip(ctx context.Context, nodeID ids.NodeID, msg []byte
 }
 
 func (vm *VMClient) Gather() ([]*dto.MetricFamily, error) {
+	vm.processLock.RLock()
+	defer vm.processLock.RUnlock()
+
 	resp, err := vm.client.Gather(context.Background(), &emptypb.Empty{})
 	if err != nil {
 		return nil, err
@@ -607,6 +766,9 @@ func (vm *VMClient) GetAncestors(
 	maxBlocksSize int,
 	maxBlocksRetrivalTime time.Duration,
 ) ([][]byte, error) {
+	vm.processLock.RLock()
+	defer vm.processLock.RUnlock()
+
 	resp, err := vm.client.GetAncestors(ctx, &vmpb.GetAncestorsRequest{
 		BlkId:                 blkID[:],
 		MaxBlocksNum:          int32(maxBlocksNum),
@@ -620,6 +782,9 @@ func (vm *VMClient) GetAncestors(
 }
 
 func (vm *VMClient) batchedParseBlock(ctx context.Context, blksBytes [][]byte) ([]snowman.Block, error) {
+	vm.processLock.RLock()
+	defer vm.processLock.RUnlock()
+
 	resp, err := vm.client.BatchedParseBlock(ctx, &vmpb.BatchedParseBlockRequest{
 		Request: blksBytes,
 	})
@@ -668,6 +833,9 @@ func (vm *VMClient) batchedParseBlock(ctx context.Context, blksBytes [][]byte) (
 }
 
 func (vm *VMClient) GetBlockIDAtHeight(ctx context.Context, height uint64) (ids.ID, error) {
+	vm.processLock.RLock()
+	defer vm.processLock.RUnlock()
+
 	resp, err := vm.client.GetBlockIDAtHeight(
 		ctx,
 		&vmpb.GetBlockIDAtHeightRequest{Height: height},
@@ -682,6 +850,9 @@ func (vm *VMClient) GetBlockIDAtHeight(ctx context.Context, height uint64) (ids.
 }
 
 func (vm *VMClient) StateSyncEnabled(ctx context.Context) (bool, error) {
+	vm.processLock.RLock()
+	defer vm.processLock.RUnlock()
+
 	resp, err := vm.client.StateSyncEnabled(ctx, &emptypb.Empty{})
 	if err != nil {
 		return false, err
@@ -694,6 +865,9 @@ func (vm *VMClient) StateSyncEnabled(ctx context.Context) (bool, error) {
 }
 
 func (vm *VMClient) GetOngoingSyncStateSummary(ctx context.Context) (block.StateSummary, error) {
+	vm.processLock.RLock()
+	defer vm.processLock.RUnlock()
+
 	resp, err := vm.client.GetOngoingSyncStateSummary(ctx, &emptypb.Empty{})
 	if err != nil {
 		return nil, err
@@ -712,6 +886,9 @@ func (vm *VMClient) GetOngoingSyncStateSummary(ctx context.Context) (block.State
 }
 
 func (vm *VMClient) GetLastStateSummary(ctx context.Context) (block.StateSummary, error) {
+	vm.processLock.RLock()
+	defer vm.processLock.RUnlock()
+
 	resp, err := vm.client.GetLastStateSummary(ctx, &emptypb.Empty{})
 	if err != nil {
 		return nil, err
@@ -730,6 +907,9 @@ func (vm *VMClient) GetLastStateSummary(ctx context.Context) (block.StateSummary
 }
 
 func (vm *VMClient) ParseStateSummary(ctx context.Context, summaryBytes []byte) (block.StateSummary, error) {
+	vm.processLock.RLock()
+	defer vm.processLock.RUnlock()
+
 	resp, err := vm.client.ParseStateSummary(
 		ctx,
 		&vmpb.ParseStateSummaryRequest{
@@ -753,6 +933,9 @@ func (vm *VMClient) ParseStateSummary(ctx context.Context, summaryBytes []byte)
 }
 
 func (vm *VMClient) GetStateSummary(ctx context.Context, summaryHeight uint64) (block.StateSummary, error) {
+	vm.processLock.RLock()
+	defer vm.processLock.RUnlock()
+
 	resp, err := vm.client.GetStateSummary(
 		ctx,
 		&vmpb.GetStateSummaryRequest{
@@ -809,6 +992,8 @@ type blockClient struct {
 	height              uint64
 	time                time.Time
 	shouldVerifyWithCtx bool
+	// blockCtx is the context that the block was verified with, if any.
+	blockCtx *block.Context
 }
 
 func (b *blockClient) ID() ids.ID {
@@ -816,7 +1001,11 @@ func (b *blockClient) ID() ids.ID {
 }
 
 func (b *blockClient) Accept(ctx context.Context) error {
+	b.vm.processLock.RLock()
+	defer b.vm.processLock.RUnlock()
+
 	b.status = choices.Accepted
+	delete(b.vm.processing, b.id)
 	_, err := b.vm.client.BlockAccept(ctx, &vmpb.BlockAcceptRequest{
 		Id: b.id[:],
 	})
@@ -824,7 +1013,11 @@ func (b *blockClient) Accept(ctx context.Context) error {
 }
 
 func (b *blockClient) Reject(ctx context.Context) error {
+	b.vm.processLock.RLock()
+	defer b.vm.processLock.RUnlock()
+
 	b.status = choices.Rejected
+	delete(b.vm.processing, b.id)
 	_, err := b.vm.client.BlockReject(ctx, &vmpb.BlockRejectRequest{
 		Id: b.id[:],
 	})
@@ -840,12 +1033,16 @@ func (b *blockClient) Parent() ids.ID {
 }
 
 func (b *blockClient) Verify(ctx context.Context) error {
+	b.vm.processLock.RLock()
+	defer b.vm.processLock.RUnlock()
+
 	resp, err := b.vm.client.BlockVerify(ctx, &vmpb.BlockVerifyRequest{
 		Bytes: b.bytes,
 	})
 	if err != nil {
 		return err
 	}
+	b.vm.processing[b.id] = b
 
 	b.time, err = grpcutils.TimestampAsTime(resp.Timestamp)
 	return err
@@ -868,6 +1065,9 @@ func (b *blockClient) ShouldVerifyWithContext(context.Context) (bool, error) {
 }
 
 func (b *blockClient) VerifyWithContext(ctx context.Context, blockCtx *block.Context) error {
+	b.vm.processLock.RLock()
+	defer b.vm.processLock.RUnlock()
+
 	resp, err := b.vm.client.BlockVerify(ctx, &vmpb.BlockVerifyRequest{
 		Bytes:        b.bytes,
 		PChainHeight: &blockCtx.PChainHeight,
@@ -875,6 +1075,8 @@ func (b *blockClient) VerifyWithContext(ctx context.Context, blockCtx *block.Con
 	if err != nil {
 		return err
 	}
+	b.blockCtx = blockCtx
+	b.vm.processing[b.id] = b
 
 	b.time, err = grpcutils.TimestampAsTime(resp.Timestamp)
 	return err
@@ -901,6 +1103,9 @@ func (s *summaryClient) Bytes() []byte {
 }
 
 func (s *summaryClient) Accept(ctx context.Context) (block.StateSyncMode, error) {
+	s.vm.processLock.RLock()
+	defer s.vm.processLock.RUnlock()
+
 	resp, err := s.vm.client.StateSummaryAccept(
 		ctx,
 		&vmpb.StateSummaryAcceptRequest{
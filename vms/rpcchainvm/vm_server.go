@@ -9,6 +9,7 @@ import (
 	"errors"
 	"fmt"
 	"os"
+	"sync/atomic"
 	"time"
 
 	"github.com/prometheus/client_golang/prometheus"
@@ -58,6 +59,7 @@ var (
 	originalStderr = os.Stderr
 
 	errExpectedBlockWithVerifyContext = errors.New("expected block.WithVerifyContext")
+	errAlreadyInitialized             = errors.New("vm already initialized")
 )
 
 // VMServer is a VM that is managed over RPC.
@@ -71,6 +73,11 @@ type VMServer struct {
 	ssVM block.StateSyncableVM
 
 	allowShutdown *utils.Atomic[bool]
+	network       grpcutils.Network
+
+	// initialized is set by the first call to Initialize. A VM server hosts a
+	// single VM instance, so it can't be initialized for a second chain.
+	initialized atomic.Bool
 
 	processMetrics prometheus.Gatherer
 	db             database.Database
@@ -96,6 +103,10 @@ func NewServer(vm block.ChainVM, allowShutdown *utils.Atomic[bool]) *VMServer {
 }
 
 func (vm *VMServer) Initialize(ctx context.Context, req *vmpb.InitializeRequest) (*vmpb.InitializeResponse, error) {
+	if !vm.initialized.CompareAndSwap(false, true) {
+		return nil, errAlreadyInitialized
+	}
+
 	subnetID, err := ids.ToID(req.SubnetId)
 	if err != nil {
 		return nil, err
@@ -149,7 +160,7 @@ func (vm *VMServer) Initialize(ctx context.Context, req *vmpb.InitializeRequest)
 	vm.processMetrics = registerer
 
 	// Dial the database
-	dbClientConn, err := grpcutils.Dial(
+	dbClientConn, err := vm.network.Dial(
 		req.DbServerAddr,
 		grpcutils.WithChainUnaryInterceptor(grpcClientMetrics.UnaryClientInterceptor()),
 		grpcutils.WithChainStreamInterceptor(grpcClientMetrics.StreamClientInterceptor()),
@@ -172,7 +183,7 @@ func (vm *VMServer) Initialize(ctx context.Context, req *vmpb.InitializeRequest)
 		),
 	)
 
-	clientConn, err := grpcutils.Dial(
+	clientConn, err := vm.network.Dial(
 		req.ServerAddr,
 		grpcutils.WithChainUnaryInterceptor(grpcClientMetrics.UnaryClientInterceptor()),
 		grpcutils.WithChainStreamInterceptor(grpcClientMetrics.StreamClientInterceptor()),
@@ -316,20 +327,20 @@ func (vm *VMServer) CreateHandlers(ctx context.Context, _ *emptypb.Empty) (*vmpb
 	}
 	resp := &vmpb.CreateHandlersResponse{}
 	for prefix, handler := range handlers {
-		serverListener, err := grpcutils.NewListener()
+		serverListener, serverAddr, err := vm.network.Listen()
 		if err != nil {
 			return nil, err
 		}
-		server := grpcutils.NewServer()
+		server := vm.network.NewServer()
 		vm.serverCloser.Add(server)
-		httppb.RegisterHTTPServer(server, ghttp.NewServer(handler))
+		httppb.RegisterHTTPServer(server, ghttp.NewServer(handler, vm.network))
 
 		// Start HTTP service
 		go grpcutils.Serve(serverListener, server)
 
 		resp.Handlers = append(resp.Handlers, &vmpb.Handler{
 			Prefix:     prefix,
-			ServerAddr: serverListener.Addr().String(),
+			ServerAddr: serverAddr,
 		})
 	}
 	return resp, nil
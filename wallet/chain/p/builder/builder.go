@@ -7,6 +7,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"slices"
 	"time"
 
 	"github.com/ava-labs/avalanchego/ids"
@@ -15,12 +16,15 @@ import (
 	"github.com/ava-labs/avalanchego/utils/math"
 	"github.com/ava-labs/avalanchego/utils/set"
 	"github.com/ava-labs/avalanchego/vms/components/avax"
+	"github.com/ava-labs/avalanchego/vms/components/fees"
 	"github.com/ava-labs/avalanchego/vms/platformvm/fx"
 	"github.com/ava-labs/avalanchego/vms/platformvm/signer"
 	"github.com/ava-labs/avalanchego/vms/platformvm/stakeable"
 	"github.com/ava-labs/avalanchego/vms/platformvm/txs"
 	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
 	"github.com/ava-labs/avalanchego/wallet/subnet/primary/common"
+
+	txfees "github.com/ava-labs/avalanchego/vms/platformvm/txs/fees"
 )
 
 var (
@@ -309,9 +313,19 @@ func (b *builder) GetImportableBalance(
 func (b *builder) NewBaseTx(
 	outputs []*avax.TransferableOutput,
 	options ...common.Option,
+) (*txs.BaseTx, error) {
+	return buildWithFee(b, b.context.BaseTxFee, func(fee uint64) (*txs.BaseTx, error) {
+		return b.newBaseTx(fee, outputs, options...)
+	})
+}
+
+func (b *builder) newBaseTx(
+	fee uint64,
+	outputs []*avax.TransferableOutput,
+	options ...common.Option,
 ) (*txs.BaseTx, error) {
 	toBurn := map[ids.ID]uint64{
-		b.context.AVAXAssetID: b.context.BaseTxFee,
+		b.context.AVAXAssetID: fee,
 	}
 	for _, out := range outputs {
 		assetID := out.AssetID()
@@ -328,7 +342,9 @@ func (b *builder) NewBaseTx(
 	if err != nil {
 		return nil, err
 	}
-	outputs = append(outputs, changeOutputs...)
+	// [outputs] is clipped so that rebuilding the tx never modifies the
+	// provided outputs.
+	outputs = append(slices.Clip(outputs), changeOutputs...)
 	avax.SortTransferableOutputs(outputs, txs.Codec) // sort the outputs
 
 	tx := &txs.BaseTx{BaseTx: avax.BaseTx{
@@ -346,10 +362,22 @@ func (b *builder) NewAddValidatorTx(
 	rewardsOwner *secp256k1fx.OutputOwners,
 	shares uint32,
 	options ...common.Option,
+) (*txs.AddValidatorTx, error) {
+	return buildWithFee(b, b.context.AddPrimaryNetworkValidatorFee, func(fee uint64) (*txs.AddValidatorTx, error) {
+		return b.newAddValidatorTx(fee, vdr, rewardsOwner, shares, options...)
+	})
+}
+
+func (b *builder) newAddValidatorTx(
+	fee uint64,
+	vdr *txs.Validator,
+	rewardsOwner *secp256k1fx.OutputOwners,
+	shares uint32,
+	options ...common.Option,
 ) (*txs.AddValidatorTx, error) {
 	avaxAssetID := b.context.AVAXAssetID
 	toBurn := map[ids.ID]uint64{
-		avaxAssetID: b.context.AddPrimaryNetworkValidatorFee,
+		avaxAssetID: fee,
 	}
 	toStake := map[ids.ID]uint64{
 		avaxAssetID: vdr.Wght,
@@ -380,9 +408,19 @@ func (b *builder) NewAddValidatorTx(
 func (b *builder) NewAddSubnetValidatorTx(
 	vdr *txs.SubnetValidator,
 	options ...common.Option,
+) (*txs.AddSubnetValidatorTx, error) {
+	return buildWithFee(b, b.context.AddSubnetValidatorFee, func(fee uint64) (*txs.AddSubnetValidatorTx, error) {
+		return b.newAddSubnetValidatorTx(fee, vdr, options...)
+	})
+}
+
+func (b *builder) newAddSubnetValidatorTx(
+	fee uint64,
+	vdr *txs.SubnetValidator,
+	options ...common.Option,
 ) (*txs.AddSubnetValidatorTx, error) {
 	toBurn := map[ids.ID]uint64{
-		b.context.AVAXAssetID: b.context.AddSubnetValidatorFee,
+		b.context.AVAXAssetID: fee,
 	}
 	toStake := map[ids.ID]uint64{}
 	ops := common.NewOptions(options)
@@ -414,9 +452,20 @@ func (b *builder) NewRemoveSubnetValidatorTx(
 	nodeID ids.NodeID,
 	subnetID ids.ID,
 	options ...common.Option,
+) (*txs.RemoveSubnetValidatorTx, error) {
+	return buildWithFee(b, b.context.BaseTxFee, func(fee uint64) (*txs.RemoveSubnetValidatorTx, error) {
+		return b.newRemoveSubnetValidatorTx(fee, nodeID, subnetID, options...)
+	})
+}
+
+func (b *builder) newRemoveSubnetValidatorTx(
+	fee uint64,
+	nodeID ids.NodeID,
+	subnetID ids.ID,
+	options ...common.Option,
 ) (*txs.RemoveSubnetValidatorTx, error) {
 	toBurn := map[ids.ID]uint64{
-		b.context.AVAXAssetID: b.context.BaseTxFee,
+		b.context.AVAXAssetID: fee,
 	}
 	toStake := map[ids.ID]uint64{}
 	ops := common.NewOptions(options)
@@ -449,10 +498,21 @@ func (b *builder) NewAddDelegatorTx(
 	vdr *txs.Validator,
 	rewardsOwner *secp256k1fx.OutputOwners,
 	options ...common.Option,
+) (*txs.AddDelegatorTx, error) {
+	return buildWithFee(b, b.context.AddPrimaryNetworkDelegatorFee, func(fee uint64) (*txs.AddDelegatorTx, error) {
+		return b.newAddDelegatorTx(fee, vdr, rewardsOwner, options...)
+	})
+}
+
+func (b *builder) newAddDelegatorTx(
+	fee uint64,
+	vdr *txs.Validator,
+	rewardsOwner *secp256k1fx.OutputOwners,
+	options ...common.Option,
 ) (*txs.AddDelegatorTx, error) {
 	avaxAssetID := b.context.AVAXAssetID
 	toBurn := map[ids.ID]uint64{
-		avaxAssetID: b.context.AddPrimaryNetworkDelegatorFee,
+		avaxAssetID: fee,
 	}
 	toStake := map[ids.ID]uint64{
 		avaxAssetID: vdr.Wght,
@@ -486,9 +546,23 @@ func (b *builder) NewCreateChainTx(
 	fxIDs []ids.ID,
 	chainName string,
 	options ...common.Option,
+) (*txs.CreateChainTx, error) {
+	return buildWithFee(b, b.context.CreateBlockchainTxFee, func(fee uint64) (*txs.CreateChainTx, error) {
+		return b.newCreateChainTx(fee, subnetID, genesis, vmID, fxIDs, chainName, options...)
+	})
+}
+
+func (b *builder) newCreateChainTx(
+	fee uint64,
+	subnetID ids.ID,
+	genesis []byte,
+	vmID ids.ID,
+	fxIDs []ids.ID,
+	chainName string,
+	options ...common.Option,
 ) (*txs.CreateChainTx, error) {
 	toBurn := map[ids.ID]uint64{
-		b.context.AVAXAssetID: b.context.CreateBlockchainTxFee,
+		b.context.AVAXAssetID: fee,
 	}
 	toStake := map[ids.ID]uint64{}
 	ops := common.NewOptions(options)
@@ -524,9 +598,19 @@ func (b *builder) NewCreateChainTx(
 func (b *builder) NewCreateSubnetTx(
 	owner *secp256k1fx.OutputOwners,
 	options ...common.Option,
+) (*txs.CreateSubnetTx, error) {
+	return buildWithFee(b, b.context.CreateSubnetTxFee, func(fee uint64) (*txs.CreateSubnetTx, error) {
+		return b.newCreateSubnetTx(fee, owner, options...)
+	})
+}
+
+func (b *builder) newCreateSubnetTx(
+	fee uint64,
+	owner *secp256k1fx.OutputOwners,
+	options ...common.Option,
 ) (*txs.CreateSubnetTx, error) {
 	toBurn := map[ids.ID]uint64{
-		b.context.AVAXAssetID: b.context.CreateSubnetTxFee,
+		b.context.AVAXAssetID: fee,
 	}
 	toStake := map[ids.ID]uint64{}
 	ops := common.NewOptions(options)
@@ -553,9 +637,20 @@ func (b *builder) NewTransferSubnetOwnershipTx(
 	subnetID ids.ID,
 	owner *secp256k1fx.OutputOwners,
 	options ...common.Option,
+) (*txs.TransferSubnetOwnershipTx, error) {
+	return buildWithFee(b, b.context.BaseTxFee, func(fee uint64) (*txs.TransferSubnetOwnershipTx, error) {
+		return b.newTransferSubnetOwnershipTx(fee, subnetID, owner, options...)
+	})
+}
+
+func (b *builder) newTransferSubnetOwnershipTx(
+	fee uint64,
+	subnetID ids.ID,
+	owner *secp256k1fx.OutputOwners,
+	options ...common.Option,
 ) (*txs.TransferSubnetOwnershipTx, error) {
 	toBurn := map[ids.ID]uint64{
-		b.context.AVAXAssetID: b.context.BaseTxFee,
+		b.context.AVAXAssetID: fee,
 	}
 	toStake := map[ids.ID]uint64{}
 	ops := common.NewOptions(options)
@@ -589,6 +684,17 @@ func (b *builder) NewImportTx(
 	sourceChainID ids.ID,
 	to *secp256k1fx.OutputOwners,
 	options ...common.Option,
+) (*txs.ImportTx, error) {
+	return buildWithFee(b, b.context.BaseTxFee, func(fee uint64) (*txs.ImportTx, error) {
+		return b.newImportTx(fee, sourceChainID, to, options...)
+	})
+}
+
+func (b *builder) newImportTx(
+	fee uint64,
+	sourceChainID ids.ID,
+	to *secp256k1fx.OutputOwners,
+	options ...common.Option,
 ) (*txs.ImportTx, error) {
 	ops := common.NewOptions(options)
 	utxos, err := b.backend.UTXOs(ops.Context(), sourceChainID)
@@ -600,7 +706,6 @@ func (b *builder) NewImportTx(
 		addrs           = ops.Addresses(b.addrs)
 		minIssuanceTime = ops.MinIssuanceTime()
 		avaxAssetID     = b.context.AVAXAssetID
-		txFee           = b.context.BaseTxFee
 
 		importedInputs  = make([]*avax.TransferableInput, 0, len(utxos))
 		importedAmounts = make(map[ids.ID]uint64)
@@ -650,12 +755,12 @@ func (b *builder) NewImportTx(
 		outputs      = make([]*avax.TransferableOutput, 0, len(importedAmounts))
 		importedAVAX = importedAmounts[avaxAssetID]
 	)
-	if importedAVAX > txFee {
-		importedAmounts[avaxAssetID] -= txFee
+	if importedAVAX > fee {
+		importedAmounts[avaxAssetID] -= fee
 	} else {
-		if importedAVAX < txFee { // imported amount goes toward paying tx fee
+		if importedAVAX < fee { // imported amount goes toward paying tx fee
 			toBurn := map[ids.ID]uint64{
-				avaxAssetID: txFee - importedAVAX,
+				avaxAssetID: fee - importedAVAX,
 			}
 			toStake := map[ids.ID]uint64{}
 			var err error
@@ -696,9 +801,20 @@ func (b *builder) NewExportTx(
 	chainID ids.ID,
 	outputs []*avax.TransferableOutput,
 	options ...common.Option,
+) (*txs.ExportTx, error) {
+	return buildWithFee(b, b.context.BaseTxFee, func(fee uint64) (*txs.ExportTx, error) {
+		return b.newExportTx(fee, chainID, outputs, options...)
+	})
+}
+
+func (b *builder) newExportTx(
+	fee uint64,
+	chainID ids.ID,
+	outputs []*avax.TransferableOutput,
+	options ...common.Option,
 ) (*txs.ExportTx, error) {
 	toBurn := map[ids.ID]uint64{
-		b.context.AVAXAssetID: b.context.BaseTxFee,
+		b.context.AVAXAssetID: fee,
 	}
 	for _, out := range outputs {
 		assetID := out.AssetID()
@@ -747,9 +863,32 @@ func (b *builder) NewTransformSubnetTx(
 	maxValidatorWeightFactor byte,
 	uptimeRequirement uint32,
 	options ...common.Option,
+) (*txs.TransformSubnetTx, error) {
+	return buildWithFee(b, b.context.TransformSubnetTxFee, func(fee uint64) (*txs.TransformSubnetTx, error) {
+		return b.newTransformSubnetTx(fee, subnetID, assetID, initialSupply, maxSupply, minConsumptionRate, maxConsumptionRate, minValidatorStake, maxValidatorStake, minStakeDuration, maxStakeDuration, minDelegationFee, minDelegatorStake, maxValidatorWeightFactor, uptimeRequirement, options...)
+	})
+}
+
+func (b *builder) newTransformSubnetTx(
+	fee uint64,
+	subnetID ids.ID,
+	assetID ids.ID,
+	initialSupply uint64,
+	maxSupply uint64,
+	minConsumptionRate uint64,
+	maxConsumptionRate uint64,
+	minValidatorStake uint64,
+	maxValidatorStake uint64,
+	minStakeDuration time.Duration,
+	maxStakeDuration time.Duration,
+	minDelegationFee uint32,
+	minDelegatorStake uint64,
+	maxValidatorWeightFactor byte,
+	uptimeRequirement uint32,
+	options ...common.Option,
 ) (*txs.TransformSubnetTx, error) {
 	toBurn := map[ids.ID]uint64{
-		b.context.AVAXAssetID: b.context.TransformSubnetTxFee,
+		b.context.AVAXAssetID: fee,
 		assetID:               maxSupply - initialSupply,
 	}
 	toStake := map[ids.ID]uint64{}
@@ -800,12 +939,28 @@ func (b *builder) NewAddPermissionlessValidatorTx(
 	shares uint32,
 	options ...common.Option,
 ) (*txs.AddPermissionlessValidatorTx, error) {
-	avaxAssetID := b.context.AVAXAssetID
-	toBurn := map[ids.ID]uint64{}
+	staticFee := b.context.AddSubnetValidatorFee
 	if vdr.Subnet == constants.PrimaryNetworkID {
-		toBurn[avaxAssetID] = b.context.AddPrimaryNetworkValidatorFee
-	} else {
-		toBurn[avaxAssetID] = b.context.AddSubnetValidatorFee
+		staticFee = b.context.AddPrimaryNetworkValidatorFee
+	}
+	return buildWithFee(b, staticFee, func(fee uint64) (*txs.AddPermissionlessValidatorTx, error) {
+		return b.newAddPermissionlessValidatorTx(fee, vdr, signer, assetID, validationRewardsOwner, delegationRewardsOwner, shares, options...)
+	})
+}
+
+func (b *builder) newAddPermissionlessValidatorTx(
+	fee uint64,
+	vdr *txs.SubnetValidator,
+	signer signer.Signer,
+	assetID ids.ID,
+	validationRewardsOwner *secp256k1fx.OutputOwners,
+	delegationRewardsOwner *secp256k1fx.OutputOwners,
+	shares uint32,
+	options ...common.Option,
+) (*txs.AddPermissionlessValidatorTx, error) {
+	avaxAssetID := b.context.AVAXAssetID
+	toBurn := map[ids.ID]uint64{
+		avaxAssetID: fee,
 	}
 	toStake := map[ids.ID]uint64{
 		assetID: vdr.Wght,
@@ -843,12 +998,25 @@ func (b *builder) NewAddPermissionlessDelegatorTx(
 	rewardsOwner *secp256k1fx.OutputOwners,
 	options ...common.Option,
 ) (*txs.AddPermissionlessDelegatorTx, error) {
-	avaxAssetID := b.context.AVAXAssetID
-	toBurn := map[ids.ID]uint64{}
+	staticFee := b.context.AddSubnetDelegatorFee
 	if vdr.Subnet == constants.PrimaryNetworkID {
-		toBurn[avaxAssetID] = b.context.AddPrimaryNetworkDelegatorFee
-	} else {
-		toBurn[avaxAssetID] = b.context.AddSubnetDelegatorFee
+		staticFee = b.context.AddPrimaryNetworkDelegatorFee
+	}
+	return buildWithFee(b, staticFee, func(fee uint64) (*txs.AddPermissionlessDelegatorTx, error) {
+		return b.newAddPermissionlessDelegatorTx(fee, vdr, assetID, rewardsOwner, options...)
+	})
+}
+
+func (b *builder) newAddPermissionlessDelegatorTx(
+	fee uint64,
+	vdr *txs.SubnetValidator,
+	assetID ids.ID,
+	rewardsOwner *secp256k1fx.OutputOwners,
+	options ...common.Option,
+) (*txs.AddPermissionlessDelegatorTx, error) {
+	avaxAssetID := b.context.AVAXAssetID
+	toBurn := map[ids.ID]uint64{
+		avaxAssetID: fee,
 	}
 	toStake := map[ids.ID]uint64{
 		assetID: vdr.Wght,
@@ -1187,3 +1355,52 @@ func (b *builder) initCtx(tx txs.UnsignedTx) error {
 	tx.InitCtx(ctx)
 	return nil
 }
+
+// buildWithFee builds a tx that burns the fee required by the context of [b].
+// Prior to the activation of the dynamic fees, [staticFee] is burned.
+// Afterwards, the tx is rebuilt until it burns enough to pay for its own
+// complexity at the current fee rates.
+func buildWithFee[T txs.UnsignedTx](
+	b *builder,
+	staticFee uint64,
+	build func(fee uint64) (T, error),
+) (T, error) {
+	if !b.context.DynamicFeesActive {
+		return build(staticFee)
+	}
+
+	var (
+		feeManager = fees.NewManager(b.context.FeeRates)
+		fee        uint64
+	)
+	for {
+		tx, err := build(fee)
+		if err != nil {
+			return tx, err
+		}
+
+		var utx txs.UnsignedTx = tx
+		unsignedBytes, err := txs.Codec.Marshal(txs.CodecVersion, &utx)
+		if err != nil {
+			return tx, err
+		}
+		tx.SetBytes(unsignedBytes)
+
+		complexity, err := txfees.TxComplexity(tx)
+		if err != nil {
+			return tx, err
+		}
+		requiredFee, err := feeManager.CalculateFee(complexity)
+		if err != nil {
+			return tx, err
+		}
+
+		// Burning a larger fee may require consuming additional UTXOs, which
+		// increases the complexity of the tx. The fee is only increased, so
+		// this converges once the tx pays for its own complexity.
+		if requiredFee <= fee {
+			return tx, nil
+		}
+		fee = requiredFee
+	}
+}
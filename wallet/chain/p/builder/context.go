@@ -12,6 +12,8 @@ import (
 	"github.com/ava-labs/avalanchego/utils/constants"
 	"github.com/ava-labs/avalanchego/utils/logging"
 	"github.com/ava-labs/avalanchego/vms/avm"
+	"github.com/ava-labs/avalanchego/vms/components/fees"
+	"github.com/ava-labs/avalanchego/vms/platformvm"
 )
 
 const Alias = "P"
@@ -27,18 +29,25 @@ type Context struct {
 	AddPrimaryNetworkDelegatorFee uint64
 	AddSubnetValidatorFee         uint64
 	AddSubnetDelegatorFee         uint64
+
+	// If [DynamicFeesActive] is set, txs burn their complexity at [FeeRates]
+	// rather than the static fees.
+	DynamicFeesActive bool
+	FeeRates          fees.Dimensions
 }
 
 func NewContextFromURI(ctx context.Context, uri string) (*Context, error) {
 	infoClient := info.NewClient(uri)
 	xChainClient := avm.NewClient(uri, "X")
-	return NewContextFromClients(ctx, infoClient, xChainClient)
+	pChainClient := platformvm.NewClient(uri)
+	return NewContextFromClients(ctx, infoClient, xChainClient, pChainClient)
 }
 
 func NewContextFromClients(
 	ctx context.Context,
 	infoClient info.Client,
 	xChainClient avm.Client,
+	pChainClient platformvm.Client,
 ) (*Context, error) {
 	networkID, err := infoClient.GetNetworkID(ctx)
 	if err != nil {
@@ -55,6 +64,11 @@ func NewContextFromClients(
 		return nil, err
 	}
 
+	feeRates, dynamicFeesActive, err := pChainClient.GetFeeRates(ctx)
+	if err != nil {
+		return nil, err
+	}
+
 	return &Context{
 		NetworkID:                     networkID,
 		AVAXAssetID:                   asset.AssetID,
@@ -66,6 +80,8 @@ func NewContextFromClients(
 		AddPrimaryNetworkDelegatorFee: uint64(txFees.AddPrimaryNetworkDelegatorFee),
 		AddSubnetValidatorFee:         uint64(txFees.AddSubnetValidatorFee),
 		AddSubnetDelegatorFee:         uint64(txFees.AddSubnetDelegatorFee),
+		DynamicFeesActive:             dynamicFeesActive,
+		FeeRates:                      feeRates,
 	}, nil
 }
 
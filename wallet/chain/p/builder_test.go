@@ -16,6 +16,7 @@ import (
 	"github.com/ava-labs/avalanchego/utils/set"
 	"github.com/ava-labs/avalanchego/utils/units"
 	"github.com/ava-labs/avalanchego/vms/components/avax"
+	"github.com/ava-labs/avalanchego/vms/components/fees"
 	"github.com/ava-labs/avalanchego/vms/platformvm/reward"
 	"github.com/ava-labs/avalanchego/vms/platformvm/signer"
 	"github.com/ava-labs/avalanchego/vms/platformvm/stakeable"
@@ -23,6 +24,8 @@ import (
 	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
 	"github.com/ava-labs/avalanchego/wallet/chain/p/builder"
 	"github.com/ava-labs/avalanchego/wallet/subnet/primary/common"
+
+	txfees "github.com/ava-labs/avalanchego/vms/platformvm/txs/fees"
 )
 
 var (
@@ -94,6 +97,68 @@ func TestBaseTx(t *testing.T) {
 	require.Equal(outputsToMove[0], outs[1])
 }
 
+func TestBaseTxDynamicFees(t *testing.T) {
+	var (
+		require = require.New(t)
+
+		// context
+		dynamicFeesContext = func() *builder.Context {
+			ctx := *testContext
+			ctx.DynamicFeesActive = true
+			ctx.FeeRates = fees.Dimensions{
+				fees.Bandwidth: 10 * units.NanoAvax,
+				fees.UTXORead:  units.MicroAvax,
+				fees.UTXOWrite: 2 * units.MicroAvax,
+				fees.Compute:   units.NanoAvax,
+			}
+			return &ctx
+		}()
+
+		// backend
+		utxosKey   = testKeys[1]
+		utxos      = makeTestUTXOs(utxosKey)
+		chainUTXOs = common.NewDeterministicChainUTXOs(require, map[ids.ID][]*avax.UTXO{
+			constants.PlatformChainID: utxos,
+		})
+		backend = NewBackend(dynamicFeesContext, chainUTXOs, nil)
+
+		// builder
+		utxoAddr = utxosKey.Address()
+		builder  = builder.New(set.Of(utxoAddr), dynamicFeesContext, backend)
+
+		// data to build the transaction
+		outputsToMove = []*avax.TransferableOutput{{
+			Asset: avax.Asset{ID: avaxAssetID},
+			Out: &secp256k1fx.TransferOutput{
+				Amt: 7 * units.Avax,
+				OutputOwners: secp256k1fx.OutputOwners{
+					Threshold: 1,
+					Addrs:     []ids.ShortID{utxoAddr},
+				},
+			},
+		}}
+	)
+
+	utx, err := builder.NewBaseTx(outputsToMove)
+	require.NoError(err)
+
+	complexity, err := txfees.TxComplexity(utx)
+	require.NoError(err)
+	expectedFee, err := fees.NewManager(dynamicFeesContext.FeeRates).CalculateFee(complexity)
+	require.NoError(err)
+
+	// check UTXOs selection and fee financing
+	ins := utx.Ins
+	outs := utx.Outs
+	require.Len(ins, 2)
+	require.Len(outs, 2)
+
+	expectedConsumed := expectedFee + outputsToMove[0].Out.Amount()
+	consumed := ins[0].In.Amount() + ins[1].In.Amount() - outs[0].Out.Amount()
+	require.Equal(expectedConsumed, consumed)
+	require.Equal(outputsToMove[0], outs[1])
+}
+
 func TestAddSubnetValidatorTx(t *testing.T) {
 	var (
 		require = require.New(t)
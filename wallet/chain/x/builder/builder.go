@@ -7,6 +7,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"slices"
 
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/utils"
@@ -14,11 +15,14 @@ import (
 	"github.com/ava-labs/avalanchego/utils/set"
 	"github.com/ava-labs/avalanchego/vms/avm/txs"
 	"github.com/ava-labs/avalanchego/vms/components/avax"
+	"github.com/ava-labs/avalanchego/vms/components/fees"
 	"github.com/ava-labs/avalanchego/vms/components/verify"
 	"github.com/ava-labs/avalanchego/vms/nftfx"
 	"github.com/ava-labs/avalanchego/vms/propertyfx"
 	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
 	"github.com/ava-labs/avalanchego/wallet/subnet/primary/common"
+
+	txfees "github.com/ava-labs/avalanchego/vms/avm/txs/fees"
 )
 
 var (
@@ -207,9 +211,19 @@ func (b *builder) GetImportableBalance(
 func (b *builder) NewBaseTx(
 	outputs []*avax.TransferableOutput,
 	options ...common.Option,
+) (*txs.BaseTx, error) {
+	return buildWithFee(b, b.context.BaseTxFee, func(fee uint64) (*txs.BaseTx, error) {
+		return b.newBaseTx(fee, outputs, options...)
+	})
+}
+
+func (b *builder) newBaseTx(
+	fee uint64,
+	outputs []*avax.TransferableOutput,
+	options ...common.Option,
 ) (*txs.BaseTx, error) {
 	toBurn := map[ids.ID]uint64{
-		b.context.AVAXAssetID: b.context.BaseTxFee,
+		b.context.AVAXAssetID: fee,
 	}
 	for _, out := range outputs {
 		assetID := out.AssetID()
@@ -225,7 +239,9 @@ func (b *builder) NewBaseTx(
 	if err != nil {
 		return nil, err
 	}
-	outputs = append(outputs, changeOutputs...)
+	// [outputs] is clipped so that rebuilding the tx never modifies the
+	// provided outputs.
+	outputs = append(slices.Clip(outputs), changeOutputs...)
 	avax.SortTransferableOutputs(outputs, Parser.Codec()) // sort the outputs
 
 	tx := &txs.BaseTx{BaseTx: avax.BaseTx{
@@ -244,9 +260,22 @@ func (b *builder) NewCreateAssetTx(
 	denomination byte,
 	initialState map[uint32][]verify.State,
 	options ...common.Option,
+) (*txs.CreateAssetTx, error) {
+	return buildWithFee(b, b.context.CreateAssetTxFee, func(fee uint64) (*txs.CreateAssetTx, error) {
+		return b.newCreateAssetTx(fee, name, symbol, denomination, initialState, options...)
+	})
+}
+
+func (b *builder) newCreateAssetTx(
+	fee uint64,
+	name string,
+	symbol string,
+	denomination byte,
+	initialState map[uint32][]verify.State,
+	options ...common.Option,
 ) (*txs.CreateAssetTx, error) {
 	toBurn := map[ids.ID]uint64{
-		b.context.AVAXAssetID: b.context.CreateAssetTxFee,
+		b.context.AVAXAssetID: fee,
 	}
 	ops := common.NewOptions(options)
 	inputs, outputs, err := b.spend(toBurn, ops)
@@ -286,9 +315,19 @@ func (b *builder) NewCreateAssetTx(
 func (b *builder) NewOperationTx(
 	operations []*txs.Operation,
 	options ...common.Option,
+) (*txs.OperationTx, error) {
+	return buildWithFee(b, b.context.BaseTxFee, func(fee uint64) (*txs.OperationTx, error) {
+		return b.newOperationTx(fee, operations, options...)
+	})
+}
+
+func (b *builder) newOperationTx(
+	fee uint64,
+	operations []*txs.Operation,
+	options ...common.Option,
 ) (*txs.OperationTx, error) {
 	toBurn := map[ids.ID]uint64{
-		b.context.AVAXAssetID: b.context.BaseTxFee,
+		b.context.AVAXAssetID: fee,
 	}
 	ops := common.NewOptions(options)
 	inputs, outputs, err := b.spend(toBurn, ops)
@@ -365,6 +404,17 @@ func (b *builder) NewImportTx(
 	chainID ids.ID,
 	to *secp256k1fx.OutputOwners,
 	options ...common.Option,
+) (*txs.ImportTx, error) {
+	return buildWithFee(b, b.context.BaseTxFee, func(fee uint64) (*txs.ImportTx, error) {
+		return b.newImportTx(fee, chainID, to, options...)
+	})
+}
+
+func (b *builder) newImportTx(
+	fee uint64,
+	chainID ids.ID,
+	to *secp256k1fx.OutputOwners,
+	options ...common.Option,
 ) (*txs.ImportTx, error) {
 	ops := common.NewOptions(options)
 	utxos, err := b.backend.UTXOs(ops.Context(), chainID)
@@ -376,7 +426,6 @@ func (b *builder) NewImportTx(
 		addrs           = ops.Addresses(b.addrs)
 		minIssuanceTime = ops.MinIssuanceTime()
 		avaxAssetID     = b.context.AVAXAssetID
-		txFee           = b.context.BaseTxFee
 
 		importedInputs  = make([]*avax.TransferableInput, 0, len(utxos))
 		importedAmounts = make(map[ids.ID]uint64)
@@ -428,12 +477,12 @@ func (b *builder) NewImportTx(
 		outputs      = make([]*avax.TransferableOutput, 0, len(importedAmounts))
 		importedAVAX = importedAmounts[avaxAssetID]
 	)
-	if importedAVAX > txFee {
-		importedAmounts[avaxAssetID] -= txFee
+	if importedAVAX > fee {
+		importedAmounts[avaxAssetID] -= fee
 	} else {
-		if importedAVAX < txFee { // imported amount goes toward paying tx fee
+		if importedAVAX < fee { // imported amount goes toward paying tx fee
 			toBurn := map[ids.ID]uint64{
-				avaxAssetID: txFee - importedAVAX,
+				avaxAssetID: fee - importedAVAX,
 			}
 			var err error
 			inputs, outputs, err = b.spend(toBurn, ops)
@@ -474,9 +523,20 @@ func (b *builder) NewExportTx(
 	chainID ids.ID,
 	outputs []*avax.TransferableOutput,
 	options ...common.Option,
+) (*txs.ExportTx, error) {
+	return buildWithFee(b, b.context.BaseTxFee, func(fee uint64) (*txs.ExportTx, error) {
+		return b.newExportTx(fee, chainID, outputs, options...)
+	})
+}
+
+func (b *builder) newExportTx(
+	fee uint64,
+	chainID ids.ID,
+	outputs []*avax.TransferableOutput,
+	options ...common.Option,
 ) (*txs.ExportTx, error) {
 	toBurn := map[ids.ID]uint64{
-		b.context.AVAXAssetID: b.context.BaseTxFee,
+		b.context.AVAXAssetID: fee,
 	}
 	for _, out := range outputs {
 		assetID := out.AssetID()
@@ -889,3 +949,53 @@ func (b *builder) initCtx(tx txs.UnsignedTx) error {
 	tx.InitCtx(ctx)
 	return nil
 }
+
+// buildWithFee builds a tx that burns the fee required by the context of [b].
+// Prior to the activation of the dynamic fees, [staticFee] is burned.
+// Afterwards, the tx is rebuilt until it burns enough to pay for its own
+// complexity at the current fee rates.
+func buildWithFee[T txs.UnsignedTx](
+	b *builder,
+	staticFee uint64,
+	build func(fee uint64) (T, error),
+) (T, error) {
+	if !b.context.DynamicFeesActive {
+		return build(staticFee)
+	}
+
+	var (
+		codec      = Parser.Codec()
+		feeManager = fees.NewManager(b.context.FeeRates)
+		fee        uint64
+	)
+	for {
+		tx, err := build(fee)
+		if err != nil {
+			return tx, err
+		}
+
+		var utx txs.UnsignedTx = tx
+		unsignedBytes, err := codec.Marshal(txs.CodecVersion, &utx)
+		if err != nil {
+			return tx, err
+		}
+		tx.SetBytes(unsignedBytes)
+
+		complexity, err := txfees.TxComplexity(tx)
+		if err != nil {
+			return tx, err
+		}
+		requiredFee, err := feeManager.CalculateFee(complexity)
+		if err != nil {
+			return tx, err
+		}
+
+		// Burning a larger fee may require consuming additional UTXOs, which
+		// increases the complexity of the tx. The fee is only increased, so
+		// this converges once the tx pays for its own complexity.
+		if requiredFee <= fee {
+			return tx, nil
+		}
+		fee = requiredFee
+	}
+}
@@ -8,6 +8,7 @@ import (
 	"github.com/ava-labs/avalanchego/snow"
 	"github.com/ava-labs/avalanchego/utils/constants"
 	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/ava-labs/avalanchego/vms/components/fees"
 )
 
 const Alias = "X"
@@ -18,6 +19,11 @@ type Context struct {
 	AVAXAssetID      ids.ID
 	BaseTxFee        uint64
 	CreateAssetTxFee uint64
+
+	// If [DynamicFeesActive] is set, txs burn their complexity at [FeeRates]
+	// rather than the static fees.
+	DynamicFeesActive bool
+	FeeRates          fees.Dimensions
 }
 
 func NewSnowContext(
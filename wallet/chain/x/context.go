@@ -42,11 +42,18 @@ func NewContextFromClients(
 		return nil, err
 	}
 
+	feeRates, dynamicFeesActive, err := xChainClient.GetFeeRates(ctx)
+	if err != nil {
+		return nil, err
+	}
+
 	return &builder.Context{
-		NetworkID:        networkID,
-		BlockchainID:     chainID,
-		AVAXAssetID:      asset.AssetID,
-		BaseTxFee:        uint64(txFees.TxFee),
-		CreateAssetTxFee: uint64(txFees.CreateAssetTxFee),
+		NetworkID:         networkID,
+		BlockchainID:      chainID,
+		AVAXAssetID:       asset.AssetID,
+		BaseTxFee:         uint64(txFees.TxFee),
+		CreateAssetTxFee:  uint64(txFees.CreateAssetTxFee),
+		DynamicFeesActive: dynamicFeesActive,
+		FeeRates:          feeRates,
 	}, nil
 }
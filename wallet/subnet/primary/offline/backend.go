@@ -0,0 +1,143 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package offline
+
+import (
+	"context"
+
+	"github.com/ava-labs/avalanchego/codec"
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/vms/components/avax"
+	"github.com/ava-labs/avalanchego/vms/platformvm/fx"
+
+	psigner "github.com/ava-labs/avalanchego/wallet/chain/p/signer"
+	xsigner "github.com/ava-labs/avalanchego/wallet/chain/x/signer"
+)
+
+var (
+	_ psigner.Backend = (*recordingBackend)(nil)
+	_ psigner.Backend = (*backend)(nil)
+)
+
+// recordingBackend records the UTXOs and subnet owners that are needed to sign
+// a transaction.
+type recordingBackend struct {
+	utxoBackend   xsigner.Backend
+	subnetBackend psigner.Backend // nil if the chain has no subnets
+
+	utxos        []*UTXO
+	knownUTXOs   map[ids.ID]ids.ID // utxoID -> sourceChainID
+	subnetOwners []*SubnetOwner
+	codec        codec.Manager
+	codecVersion uint16
+}
+
+func newRecordingBackend(
+	utxoBackend xsigner.Backend,
+	subnetBackend psigner.Backend,
+	codec codec.Manager,
+	codecVersion uint16,
+) *recordingBackend {
+	return &recordingBackend{
+		utxoBackend:   utxoBackend,
+		subnetBackend: subnetBackend,
+		knownUTXOs:    make(map[ids.ID]ids.ID),
+		codec:         codec,
+		codecVersion:  codecVersion,
+	}
+}
+
+func (b *recordingBackend) GetUTXO(ctx context.Context, chainID, utxoID ids.ID) (*avax.UTXO, error) {
+	utxo, err := b.utxoBackend.GetUTXO(ctx, chainID, utxoID)
+	if err != nil {
+		return nil, err
+	}
+	if knownChainID, ok := b.knownUTXOs[utxoID]; ok && knownChainID == chainID {
+		return utxo, nil
+	}
+
+	utxoBytes, err := b.codec.Marshal(b.codecVersion, utxo)
+	if err != nil {
+		return nil, err
+	}
+	b.knownUTXOs[utxoID] = chainID
+	b.utxos = append(b.utxos, &UTXO{
+		SourceChainID: chainID,
+		UTXO:          utxoBytes,
+	})
+	return utxo, nil
+}
+
+func (b *recordingBackend) GetSubnetOwner(ctx context.Context, subnetID ids.ID) (fx.Owner, error) {
+	if b.subnetBackend == nil {
+		return nil, database.ErrNotFound
+	}
+
+	owner, err := b.subnetBackend.GetSubnetOwner(ctx, subnetID)
+	if err != nil {
+		return nil, err
+	}
+
+	ownerBytes, err := b.codec.Marshal(b.codecVersion, &owner)
+	if err != nil {
+		return nil, err
+	}
+	b.subnetOwners = append(b.subnetOwners, &SubnetOwner{
+		SubnetID: subnetID,
+		Owner:    ownerBytes,
+	})
+	return owner, nil
+}
+
+// backend provides the UTXOs and subnet owners that were recorded when the
+// transaction was built.
+type backend struct {
+	utxos        map[ids.ID]map[ids.ID]*avax.UTXO // sourceChainID -> utxoID -> utxo
+	subnetOwners map[ids.ID]fx.Owner
+}
+
+func newBackend(tx *Tx, c codec.Manager) (*backend, error) {
+	b := &backend{
+		utxos:        make(map[ids.ID]map[ids.ID]*avax.UTXO),
+		subnetOwners: make(map[ids.ID]fx.Owner, len(tx.SubnetOwners)),
+	}
+	for _, utxoInfo := range tx.UTXOs {
+		utxo := &avax.UTXO{}
+		if _, err := c.Unmarshal(utxoInfo.UTXO, utxo); err != nil {
+			return nil, err
+		}
+
+		utxos, ok := b.utxos[utxoInfo.SourceChainID]
+		if !ok {
+			utxos = make(map[ids.ID]*avax.UTXO)
+			b.utxos[utxoInfo.SourceChainID] = utxos
+		}
+		utxos[utxo.InputID()] = utxo
+	}
+	for _, ownerInfo := range tx.SubnetOwners {
+		var owner fx.Owner
+		if _, err := c.Unmarshal(ownerInfo.Owner, &owner); err != nil {
+			return nil, err
+		}
+		b.subnetOwners[ownerInfo.SubnetID] = owner
+	}
+	return b, nil
+}
+
+func (b *backend) GetUTXO(_ context.Context, chainID, utxoID ids.ID) (*avax.UTXO, error) {
+	utxo, ok := b.utxos[chainID][utxoID]
+	if !ok {
+		return nil, database.ErrNotFound
+	}
+	return utxo, nil
+}
+
+func (b *backend) GetSubnetOwner(_ context.Context, subnetID ids.ID) (fx.Owner, error) {
+	owner, ok := b.subnetOwners[subnetID]
+	if !ok {
+		return nil, database.ErrNotFound
+	}
+	return owner, nil
+}
@@ -0,0 +1,354 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/ava-labs/avalanchego/utils/formatting/address"
+	"github.com/ava-labs/avalanchego/utils/set"
+	"github.com/ava-labs/avalanchego/vms/components/avax"
+	"github.com/ava-labs/avalanchego/vms/platformvm/reward"
+	"github.com/ava-labs/avalanchego/vms/platformvm/signer"
+	"github.com/ava-labs/avalanchego/vms/platformvm/txs"
+	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
+	"github.com/ava-labs/avalanchego/wallet/chain/p"
+	"github.com/ava-labs/avalanchego/wallet/chain/x"
+	"github.com/ava-labs/avalanchego/wallet/subnet/primary"
+	"github.com/ava-labs/avalanchego/wallet/subnet/primary/common"
+	"github.com/ava-labs/avalanchego/wallet/subnet/primary/offline"
+
+	pbuilder "github.com/ava-labs/avalanchego/wallet/chain/p/builder"
+	xbuilder "github.com/ava-labs/avalanchego/wallet/chain/x/builder"
+)
+
+var (
+	errAddressesRequired = errors.New("--addresses is required")
+	errToRequired        = errors.New("--to is required")
+	errAmountRequired    = errors.New("--amount is required")
+	errNodeIDRequired    = errors.New("--node-id is required")
+	errEndTimeRequired   = errors.New("--end-time is required")
+	errPOPRequired       = errors.New("--proof-of-possession is required")
+	errRewardRequired    = errors.New("--reward-address is required")
+)
+
+// buildFlags are the flags shared by every build command.
+type buildFlags struct {
+	uri       string
+	addresses []string
+	out       string
+}
+
+func (f *buildFlags) register(cmd *cobra.Command) {
+	cmd.Flags().StringVar(&f.uri, "uri", primary.LocalAPIURI, "API URI of the node to fetch UTXOs from")
+	cmd.Flags().StringSliceVar(&f.addresses, "addresses", nil, "Addresses whose UTXOs may be spent. No keys are required")
+	cmd.Flags().StringVar(&f.out, "out", "", "Path to write the unsigned transaction to")
+}
+
+// wallet is a watch-only view of the primary network.
+type wallet struct {
+	networkID uint32
+	addrs     set.Set[ids.ShortID]
+
+	pContext *pbuilder.Context
+	pBackend p.Backend
+	pBuilder pbuilder.Builder
+
+	xContext *xbuilder.Context
+	xBackend x.Backend
+	xBuilder xbuilder.Builder
+}
+
+func (f *buildFlags) wallet(ctx context.Context) (*wallet, error) {
+	if len(f.addresses) == 0 {
+		return nil, errAddressesRequired
+	}
+	if len(f.out) == 0 {
+		return nil, errOutRequired
+	}
+
+	addrList, err := address.ParseToIDs(f.addresses)
+	if err != nil {
+		return nil, err
+	}
+	addrs := set.Of(addrList...)
+
+	state, err := primary.FetchState(ctx, f.uri, addrs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch UTXOs: %w", err)
+	}
+
+	pUTXOs := common.NewChainUTXOs(constants.PlatformChainID, state.UTXOs)
+	pBackend := p.NewBackend(state.PCTX, pUTXOs, nil)
+	xUTXOs := common.NewChainUTXOs(state.XCTX.BlockchainID, state.UTXOs)
+	xBackend := x.NewBackend(state.XCTX, xUTXOs)
+	return &wallet{
+		networkID: state.PCTX.NetworkID,
+		addrs:     addrs,
+		pContext:  state.PCTX,
+		pBackend:  pBackend,
+		pBuilder:  pbuilder.New(addrs, state.PCTX, pBackend),
+		xContext:  state.XCTX,
+		xBackend:  xBackend,
+		xBuilder:  xbuilder.New(addrs, state.XCTX, xBackend),
+	}, nil
+}
+
+func buildCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "build",
+		Short: "Build an unsigned transaction using only the addresses of the signers",
+	}
+	cmd.AddCommand(
+		buildTransferCommand(offline.PChain),
+		buildTransferCommand(offline.XChain),
+		buildAddValidatorCommand(),
+		buildAddDelegatorCommand(),
+	)
+	return cmd
+}
+
+func buildTransferCommand(chain string) *cobra.Command {
+	var (
+		flags  buildFlags
+		to     string
+		amount uint64
+	)
+	cmd := &cobra.Command{
+		Use:   fmt.Sprintf("%s-transfer", chain),
+		Short: fmt.Sprintf("Build a transaction that sends AVAX on the %s-chain", chain),
+		RunE: func(*cobra.Command, []string) error {
+			if len(to) == 0 {
+				return errToRequired
+			}
+			if amount == 0 {
+				return errAmountRequired
+			}
+			toAddr, err := address.ParseToID(to)
+			if err != nil {
+				return err
+			}
+
+			ctx := context.Background()
+			w, err := flags.wallet(ctx)
+			if err != nil {
+				return err
+			}
+
+			outputs := []*avax.TransferableOutput{{
+				Asset: avax.Asset{ID: w.pContext.AVAXAssetID},
+				Out: &secp256k1fx.TransferOutput{
+					Amt: amount,
+					OutputOwners: secp256k1fx.OutputOwners{
+						Threshold: 1,
+						Addrs:     []ids.ShortID{toAddr},
+					},
+				},
+			}}
+
+			var tx *offline.Tx
+			if chain == offline.PChain {
+				utx, err := w.pBuilder.NewBaseTx(outputs)
+				if err != nil {
+					return fmt.Errorf("failed to build transaction: %w", err)
+				}
+				tx, err = offline.NewPChainTx(ctx, w.networkID, utx, w.pBackend)
+				if err != nil {
+					return err
+				}
+			} else {
+				utx, err := w.xBuilder.NewBaseTx(outputs)
+				if err != nil {
+					return fmt.Errorf("failed to build transaction: %w", err)
+				}
+				tx, err = offline.NewXChainTx(ctx, w.networkID, w.xContext.BlockchainID, utx, w.xBackend)
+				if err != nil {
+					return err
+				}
+			}
+			return write(tx, flags.out)
+		},
+	}
+	flags.register(cmd)
+	cmd.Flags().StringVar(&to, "to", "", "Address to send the AVAX to")
+	cmd.Flags().Uint64Var(&amount, "amount", 0, "Amount of nAVAX to send")
+	return cmd
+}
+
+// stakeFlags are the flags shared by the staking build commands.
+type stakeFlags struct {
+	buildFlags
+	nodeID        string
+	endTime       string
+	weight        uint64
+	rewardAddress string
+}
+
+func (f *stakeFlags) register(cmd *cobra.Command) {
+	f.buildFlags.register(cmd)
+	cmd.Flags().StringVar(&f.nodeID, "node-id", "", "NodeID of the validator")
+	cmd.Flags().StringVar(&f.endTime, "end-time", "", "RFC3339 time the staking period ends at")
+	cmd.Flags().Uint64Var(&f.weight, "weight", 0, "Amount of nAVAX to stake")
+	cmd.Flags().StringVar(&f.rewardAddress, "reward-address", "", "Address to send the staking rewards to")
+}
+
+func (f *stakeFlags) parse() (*txs.SubnetValidator, *secp256k1fx.OutputOwners, error) {
+	if len(f.nodeID) == 0 {
+		return nil, nil, errNodeIDRequired
+	}
+	if len(f.endTime) == 0 {
+		return nil, nil, errEndTimeRequired
+	}
+	if f.weight == 0 {
+		return nil, nil, errAmountRequired
+	}
+	if len(f.rewardAddress) == 0 {
+		return nil, nil, errRewardRequired
+	}
+
+	nodeID, err := ids.NodeIDFromString(f.nodeID)
+	if err != nil {
+		return nil, nil, err
+	}
+	endTime, err := time.Parse(time.RFC3339, f.endTime)
+	if err != nil {
+		return nil, nil, err
+	}
+	rewardAddr, err := address.ParseToID(f.rewardAddress)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	vdr := &txs.SubnetValidator{
+		Validator: txs.Validator{
+			NodeID: nodeID,
+			Start:  uint64(time.Now().Unix()),
+			End:    uint64(endTime.Unix()),
+			Wght:   f.weight,
+		},
+		Subnet: constants.PrimaryNetworkID,
+	}
+	rewardsOwner := &secp256k1fx.OutputOwners{
+		Threshold: 1,
+		Addrs:     []ids.ShortID{rewardAddr},
+	}
+	return vdr, rewardsOwner, nil
+}
+
+func buildAddValidatorCommand() *cobra.Command {
+	var (
+		flags         stakeFlags
+		popJSON       string
+		delegationFee uint32
+	)
+	cmd := &cobra.Command{
+		Use:   "add-validator",
+		Short: "Build a transaction that adds a primary network validator",
+		RunE: func(*cobra.Command, []string) error {
+			vdr, rewardsOwner, err := flags.parse()
+			if err != nil {
+				return err
+			}
+			if len(popJSON) == 0 {
+				return errPOPRequired
+			}
+			pop := &signer.ProofOfPossession{}
+			if err := json.Unmarshal([]byte(popJSON), pop); err != nil {
+				return fmt.Errorf("failed to parse proof of possession: %w", err)
+			}
+			if err := pop.Verify(); err != nil {
+				return fmt.Errorf("invalid proof of possession: %w", err)
+			}
+
+			ctx := context.Background()
+			w, err := flags.wallet(ctx)
+			if err != nil {
+				return err
+			}
+
+			utx, err := w.pBuilder.NewAddPermissionlessValidatorTx(
+				vdr,
+				pop,
+				w.pContext.AVAXAssetID,
+				rewardsOwner,
+				rewardsOwner,
+				delegationFee,
+			)
+			if err != nil {
+				return fmt.Errorf("failed to build transaction: %w", err)
+			}
+			tx, err := offline.NewPChainTx(ctx, w.networkID, utx, w.pBackend)
+			if err != nil {
+				return err
+			}
+			return write(tx, flags.out)
+		},
+	}
+	flags.register(cmd)
+	cmd.Flags().StringVar(&popJSON, "proof-of-possession", "", "JSON encoded BLS proof of possession of the validator, as returned by info.getNodeID")
+	cmd.Flags().Uint32Var(&delegationFee, "delegation-fee", 2*reward.PercentDenominator/100, "Fraction of delegation rewards, out of 1,000,000, taken by the validator")
+	return cmd
+}
+
+func buildAddDelegatorCommand() *cobra.Command {
+	var flags stakeFlags
+	cmd := &cobra.Command{
+		Use:   "add-delegator",
+		Short: "Build a transaction that delegates to a primary network validator",
+		RunE: func(*cobra.Command, []string) error {
+			vdr, rewardsOwner, err := flags.parse()
+			if err != nil {
+				return err
+			}
+
+			ctx := context.Background()
+			w, err := flags.wallet(ctx)
+			if err != nil {
+				return err
+			}
+
+			utx, err := w.pBuilder.NewAddPermissionlessDelegatorTx(
+				vdr,
+				w.pContext.AVAXAssetID,
+				rewardsOwner,
+			)
+			if err != nil {
+				return fmt.Errorf("failed to build transaction: %w", err)
+			}
+			tx, err := offline.NewPChainTx(ctx, w.networkID, utx, w.pBackend)
+			if err != nil {
+				return err
+			}
+			return write(tx, flags.out)
+		},
+	}
+	flags.register(cmd)
+	return cmd
+}
+
+func write(tx *offline.Tx, path string) error {
+	txID, err := tx.ID()
+	if err != nil {
+		return err
+	}
+	missing, err := tx.MissingSignatures()
+	if err != nil {
+		return err
+	}
+	if err := tx.Write(path); err != nil {
+		return fmt.Errorf("failed to write transaction: %w", err)
+	}
+
+	fmt.Fprintf(os.Stdout, "Wrote unsigned transaction %s requiring %d signatures to %s\n", txID, missing, path)
+	return nil
+}
@@ -0,0 +1,243 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package main
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/ava-labs/avalanchego/api/info"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/crypto/secp256k1"
+	"github.com/ava-labs/avalanchego/vms/avm"
+	"github.com/ava-labs/avalanchego/vms/platformvm"
+	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
+	"github.com/ava-labs/avalanchego/wallet/subnet/primary"
+	"github.com/ava-labs/avalanchego/wallet/subnet/primary/offline"
+)
+
+var (
+	errInRequired        = errors.New("--in is required")
+	errOutRequired       = errors.New("--out is required")
+	errKeyFileRequired   = errors.New("--key-file is required")
+	errNoKeys            = errors.New("key file doesn't contain any keys")
+	errMissingSignatures = errors.New("transaction is missing signatures")
+	errWrongNetworkID    = errors.New("wrong networkID")
+)
+
+func main() {
+	rootCmd := &cobra.Command{
+		Use:   "offline-wallet",
+		Short: "Build transactions on a watch-only machine, sign them on an air-gapped machine, and issue them from a third machine",
+	}
+	rootCmd.AddCommand(
+		buildCommand(),
+		signCommand(),
+		inspectCommand(),
+		issueCommand(),
+	)
+
+	if err := rootCmd.Execute(); err != nil {
+		fmt.Fprintf(os.Stderr, "offline-wallet failed: %v\n", err)
+		os.Exit(1)
+	}
+	os.Exit(0)
+}
+
+func signCommand() *cobra.Command {
+	var (
+		in      string
+		out     string
+		keyFile string
+	)
+	cmd := &cobra.Command{
+		Use:   "sign",
+		Short: "Add the signatures of the provided keys to a transaction. Doesn't require network access",
+		RunE: func(*cobra.Command, []string) error {
+			if len(in) == 0 {
+				return errInRequired
+			}
+			if len(keyFile) == 0 {
+				return errKeyFileRequired
+			}
+			if len(out) == 0 {
+				out = in
+			}
+
+			tx, err := offline.Read(in)
+			if err != nil {
+				return fmt.Errorf("failed to read transaction: %w", err)
+			}
+			kc, err := readKeychain(keyFile)
+			if err != nil {
+				return fmt.Errorf("failed to read keys: %w", err)
+			}
+
+			missingBefore, err := tx.MissingSignatures()
+			if err != nil {
+				return err
+			}
+			if err := tx.Sign(context.Background(), kc); err != nil {
+				return fmt.Errorf("failed to sign transaction: %w", err)
+			}
+			missingAfter, err := tx.MissingSignatures()
+			if err != nil {
+				return err
+			}
+			if err := tx.Write(out); err != nil {
+				return fmt.Errorf("failed to write transaction: %w", err)
+			}
+
+			fmt.Fprintf(os.Stdout, "Added %d signatures, %d signatures are still missing\n", missingBefore-missingAfter, missingAfter)
+			return nil
+		},
+	}
+	cmd.Flags().StringVar(&in, "in", "", "Path to the transaction to sign")
+	cmd.Flags().StringVar(&out, "out", "", "Path to write the signed transaction to. Defaults to --in")
+	cmd.Flags().StringVar(&keyFile, "key-file", "", "Path to a file containing one PrivateKey-... key per line")
+	return cmd
+}
+
+func inspectCommand() *cobra.Command {
+	var in string
+	cmd := &cobra.Command{
+		Use:   "inspect",
+		Short: "Print the contents of a transaction. Doesn't require network access",
+		RunE: func(*cobra.Command, []string) error {
+			if len(in) == 0 {
+				return errInRequired
+			}
+
+			tx, err := offline.Read(in)
+			if err != nil {
+				return fmt.Errorf("failed to read transaction: %w", err)
+			}
+			txID, err := tx.ID()
+			if err != nil {
+				return err
+			}
+			missing, err := tx.MissingSignatures()
+			if err != nil {
+				return err
+			}
+
+			var parsedTx any
+			switch tx.Chain {
+			case offline.PChain:
+				parsedTx, err = tx.ParsePChainTx()
+			case offline.XChain:
+				parsedTx, err = tx.ParseXChainTx()
+			}
+			if err != nil {
+				return err
+			}
+			txJSON, err := json.MarshalIndent(parsedTx, "", "\t")
+			if err != nil {
+				return err
+			}
+
+			fmt.Fprintf(os.Stdout, "NetworkID: %d\n", tx.NetworkID)
+			fmt.Fprintf(os.Stdout, "Chain: %s (%s)\n", tx.Chain, tx.BlockchainID)
+			fmt.Fprintf(os.Stdout, "TxID: %s\n", txID)
+			fmt.Fprintf(os.Stdout, "Missing signatures: %d\n", missing)
+			fmt.Fprintf(os.Stdout, "%s\n", txJSON)
+			return nil
+		},
+	}
+	cmd.Flags().StringVar(&in, "in", "", "Path to the transaction to inspect")
+	return cmd
+}
+
+func issueCommand() *cobra.Command {
+	var (
+		in  string
+		uri string
+	)
+	cmd := &cobra.Command{
+		Use:   "issue",
+		Short: "Issue a fully signed transaction to the network",
+		RunE: func(*cobra.Command, []string) error {
+			if len(in) == 0 {
+				return errInRequired
+			}
+
+			tx, err := offline.Read(in)
+			if err != nil {
+				return fmt.Errorf("failed to read transaction: %w", err)
+			}
+			missing, err := tx.MissingSignatures()
+			if err != nil {
+				return err
+			}
+			if missing != 0 {
+				return fmt.Errorf("%w: %d", errMissingSignatures, missing)
+			}
+
+			ctx := context.Background()
+			networkID, err := info.NewClient(uri).GetNetworkID(ctx)
+			if err != nil {
+				return fmt.Errorf("failed to get networkID: %w", err)
+			}
+			if networkID != tx.NetworkID {
+				return fmt.Errorf("%w: transaction is for %d but node is on %d", errWrongNetworkID, tx.NetworkID, networkID)
+			}
+
+			var txID ids.ID
+			switch tx.Chain {
+			case offline.PChain:
+				txID, err = platformvm.NewClient(uri).IssueTx(ctx, tx.Tx)
+			case offline.XChain:
+				txID, err = avm.NewClient(uri, tx.BlockchainID.String()).IssueTx(ctx, tx.Tx)
+			default:
+				return fmt.Errorf("%w: %q", offline.ErrUnknownChain, tx.Chain)
+			}
+			if err != nil {
+				return fmt.Errorf("failed to issue transaction: %w", err)
+			}
+
+			fmt.Fprintf(os.Stdout, "Issued %s\n", txID)
+			return nil
+		},
+	}
+	cmd.Flags().StringVar(&in, "in", "", "Path to the transaction to issue")
+	cmd.Flags().StringVar(&uri, "uri", primary.LocalAPIURI, "API URI of the node to issue the transaction to")
+	return cmd
+}
+
+func readKeychain(path string) (*secp256k1fx.Keychain, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	kc := secp256k1fx.NewKeychain()
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if len(line) == 0 {
+			continue
+		}
+
+		key := &secp256k1.PrivateKey{}
+		if err := key.UnmarshalText([]byte(line)); err != nil {
+			return nil, err
+		}
+		kc.Add(key)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	if kc.Addrs.Len() == 0 {
+		return nil, errNoKeys
+	}
+	return kc, nil
+}
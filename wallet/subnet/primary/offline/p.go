@@ -0,0 +1,67 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package offline
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/ava-labs/avalanchego/utils/crypto/keychain"
+	"github.com/ava-labs/avalanchego/vms/platformvm/txs"
+	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
+	"github.com/ava-labs/avalanchego/wallet/chain/p/signer"
+)
+
+// NewPChainTx returns an offline transaction that issues [utx] on the
+// P-chain. [backend] must provide all the UTXOs consumed by [utx] and the
+// owners of any subnets that must authorize it.
+func NewPChainTx(
+	ctx context.Context,
+	networkID uint32,
+	utx txs.UnsignedTx,
+	backend signer.Backend,
+) (*Tx, error) {
+	recorder := newRecordingBackend(backend, backend, txs.Codec, txs.CodecVersion)
+
+	// Signing with an empty keychain adds an empty signature for every
+	// required signature and records the state that the signers will need.
+	tx, err := signer.SignUnsigned(ctx, signer.New(secp256k1fx.NewKeychain(), recorder), utx)
+	if err != nil {
+		return nil, err
+	}
+	return &Tx{
+		NetworkID:    networkID,
+		Chain:        PChain,
+		BlockchainID: constants.PlatformChainID,
+		Tx:           tx.Bytes(),
+		UTXOs:        recorder.utxos,
+		SubnetOwners: recorder.subnetOwners,
+	}, nil
+}
+
+// ParsePChainTx returns the P-chain transaction, including any signatures
+// that have already been added.
+func (t *Tx) ParsePChainTx() (*txs.Tx, error) {
+	if t.Chain != PChain {
+		return nil, fmt.Errorf("%w: expected %q but got %q", ErrUnknownChain, PChain, t.Chain)
+	}
+	return txs.Parse(txs.Codec, t.Tx)
+}
+
+func (t *Tx) signPChainTx(ctx context.Context, kc keychain.Keychain) error {
+	tx, err := t.ParsePChainTx()
+	if err != nil {
+		return err
+	}
+	backend, err := newBackend(t, txs.Codec)
+	if err != nil {
+		return err
+	}
+	if err := signer.New(kc, backend).Sign(ctx, tx); err != nil {
+		return err
+	}
+	t.Tx = tx.Bytes()
+	return nil
+}
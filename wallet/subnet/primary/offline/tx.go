@@ -0,0 +1,166 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+// Package offline supports building transactions on a machine that only knows
+// the addresses of the signers, signing them on a machine without network
+// access, and issuing them from a third machine.
+package offline
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/crypto/keychain"
+	"github.com/ava-labs/avalanchego/utils/crypto/secp256k1"
+	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
+	"github.com/ava-labs/avalanchego/vms/types"
+)
+
+const (
+	PChain = "P"
+	XChain = "X"
+
+	perms = 0o600
+)
+
+var (
+	ErrUnknownChain          = errors.New("unknown chain")
+	ErrUnknownCredentialType = errors.New("unknown credential type")
+
+	emptySig [secp256k1.SignatureLen]byte
+)
+
+// Tx is an unsigned, or partially signed, transaction along with everything
+// that is needed to sign it without network access.
+type Tx struct {
+	NetworkID uint32 `json:"networkID"`
+	// Chain is the alias of the chain the transaction will be issued on. Must
+	// be either [PChain] or [XChain].
+	Chain        string `json:"chain"`
+	BlockchainID ids.ID `json:"blockchainID"`
+	// Tx is the transaction, including any signatures that have already been
+	// added.
+	Tx types.JSONByteSlice `json:"tx"`
+	// UTXOs are the UTXOs consumed by the transaction.
+	UTXOs []*UTXO `json:"utxos"`
+	// SubnetOwners are the owners of the subnets that the transaction must be
+	// authorized by.
+	SubnetOwners []*SubnetOwner `json:"subnetOwners,omitempty"`
+}
+
+type UTXO struct {
+	// SourceChainID is the chain the UTXO was exported from, or the chain
+	// of the transaction if the UTXO wasn't imported.
+	SourceChainID ids.ID              `json:"sourceChainID"`
+	UTXO          types.JSONByteSlice `json:"utxo"`
+}
+
+type SubnetOwner struct {
+	SubnetID ids.ID              `json:"subnetID"`
+	Owner    types.JSONByteSlice `json:"owner"`
+}
+
+// Read parses the transaction stored in the file at [path].
+func Read(path string) (*Tx, error) {
+	bytes, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	tx := &Tx{}
+	return tx, json.Unmarshal(bytes, tx)
+}
+
+// Write stores the transaction in the file at [path].
+func (t *Tx) Write(path string) error {
+	bytes, err := json.MarshalIndent(t, "", "\t")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, bytes, perms)
+}
+
+// ID returns the ID the transaction will have once issued.
+func (t *Tx) ID() (ids.ID, error) {
+	switch t.Chain {
+	case PChain:
+		tx, err := t.ParsePChainTx()
+		if err != nil {
+			return ids.Empty, err
+		}
+		return tx.ID(), nil
+	case XChain:
+		tx, err := t.ParseXChainTx()
+		if err != nil {
+			return ids.Empty, err
+		}
+		return tx.ID(), nil
+	default:
+		return ids.Empty, fmt.Errorf("%w: %q", ErrUnknownChain, t.Chain)
+	}
+}
+
+// Sign adds as many missing signatures as possible with the keys in [kc].
+// Signatures that were previously added are kept, so a transaction that
+// requires multiple keys may be signed on multiple machines.
+func (t *Tx) Sign(ctx context.Context, kc keychain.Keychain) error {
+	switch t.Chain {
+	case PChain:
+		return t.signPChainTx(ctx, kc)
+	case XChain:
+		return t.signXChainTx(ctx, kc)
+	default:
+		return fmt.Errorf("%w: %q", ErrUnknownChain, t.Chain)
+	}
+}
+
+// MissingSignatures returns the number of signatures that must still be added
+// before the transaction can be issued.
+func (t *Tx) MissingSignatures() (int, error) {
+	switch t.Chain {
+	case PChain:
+		tx, err := t.ParsePChainTx()
+		if err != nil {
+			return 0, err
+		}
+		missing := 0
+		for _, credIntf := range tx.Creds {
+			cred, ok := credIntf.(*secp256k1fx.Credential)
+			if !ok {
+				return 0, ErrUnknownCredentialType
+			}
+			missing += missingSignatures(cred)
+		}
+		return missing, nil
+	case XChain:
+		tx, err := t.ParseXChainTx()
+		if err != nil {
+			return 0, err
+		}
+		missing := 0
+		for _, fxCred := range tx.Creds {
+			cred, ok := fxCred.Credential.(*secp256k1fx.Credential)
+			if !ok {
+				return 0, ErrUnknownCredentialType
+			}
+			missing += missingSignatures(cred)
+		}
+		return missing, nil
+	default:
+		return 0, fmt.Errorf("%w: %q", ErrUnknownChain, t.Chain)
+	}
+}
+
+func missingSignatures(cred *secp256k1fx.Credential) int {
+	missing := 0
+	for _, sig := range cred.Sigs {
+		if sig == emptySig {
+			missing++
+		}
+	}
+	return missing
+}
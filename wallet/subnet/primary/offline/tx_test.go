@@ -0,0 +1,240 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package offline
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/ava-labs/avalanchego/utils/crypto/secp256k1"
+	"github.com/ava-labs/avalanchego/utils/set"
+	"github.com/ava-labs/avalanchego/utils/units"
+	"github.com/ava-labs/avalanchego/vms/components/avax"
+	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
+	"github.com/ava-labs/avalanchego/wallet/chain/p"
+	"github.com/ava-labs/avalanchego/wallet/chain/x"
+	"github.com/ava-labs/avalanchego/wallet/subnet/primary/common"
+
+	ptxs "github.com/ava-labs/avalanchego/vms/platformvm/txs"
+	pbuilder "github.com/ava-labs/avalanchego/wallet/chain/p/builder"
+	psigner "github.com/ava-labs/avalanchego/wallet/chain/p/signer"
+	xbuilder "github.com/ava-labs/avalanchego/wallet/chain/x/builder"
+	xsigner "github.com/ava-labs/avalanchego/wallet/chain/x/signer"
+)
+
+var (
+	testKeys = secp256k1.TestKeys()
+
+	avaxAssetID = ids.Empty.Prefix(1789)
+	xChainID    = ids.Empty.Prefix(2021)
+
+	pContext = &pbuilder.Context{
+		NetworkID:             constants.UnitTestID,
+		AVAXAssetID:           avaxAssetID,
+		BaseTxFee:             units.MicroAvax,
+		AddSubnetValidatorFee: units.MilliAvax,
+	}
+	xContext = &xbuilder.Context{
+		NetworkID:    constants.UnitTestID,
+		BlockchainID: xChainID,
+		AVAXAssetID:  avaxAssetID,
+		BaseTxFee:    units.MicroAvax,
+	}
+)
+
+func newUTXOs(t *testing.T, chainID ids.ID, owners ...*secp256k1fx.OutputOwners) common.ChainUTXOs {
+	utxos := common.NewChainUTXOs(chainID, common.NewUTXOs())
+	for i, owner := range owners {
+		utxo := &avax.UTXO{
+			UTXOID: avax.UTXOID{
+				TxID:        ids.Empty.Prefix(uint64(i)),
+				OutputIndex: uint32(i),
+			},
+			Asset: avax.Asset{ID: avaxAssetID},
+			Out: &secp256k1fx.TransferOutput{
+				Amt:          units.Avax,
+				OutputOwners: *owner,
+			},
+		}
+		require.NoError(t, utxos.AddUTXO(context.Background(), chainID, utxo))
+	}
+	return utxos
+}
+
+func newOutputs(addr ids.ShortID) []*avax.TransferableOutput {
+	return []*avax.TransferableOutput{{
+		Asset: avax.Asset{ID: avaxAssetID},
+		Out: &secp256k1fx.TransferOutput{
+			Amt: units.MilliAvax,
+			OutputOwners: secp256k1fx.OutputOwners{
+				Threshold: 1,
+				Addrs:     []ids.ShortID{addr},
+			},
+		},
+	}}
+}
+
+// roundTrip simulates moving the transaction to another machine.
+func roundTrip(t *testing.T, tx *Tx) *Tx {
+	require := require.New(t)
+
+	path := filepath.Join(t.TempDir(), "tx.json")
+	require.NoError(tx.Write(path))
+	parsedTx, err := Read(path)
+	require.NoError(err)
+	require.Equal(tx, parsedTx)
+	return parsedTx
+}
+
+func requireMissingSignatures(t *testing.T, tx *Tx, expected int) {
+	missing, err := tx.MissingSignatures()
+	require.NoError(t, err)
+	require.Equal(t, expected, missing)
+}
+
+func TestPChainMultisig(t *testing.T) {
+	require := require.New(t)
+	ctx := context.Background()
+
+	owner := &secp256k1fx.OutputOwners{
+		Threshold: 2,
+		Addrs: []ids.ShortID{
+			testKeys[0].Address(),
+			testKeys[1].Address(),
+		},
+	}
+	backend := p.NewBackend(pContext, newUTXOs(t, constants.PlatformChainID, owner), nil)
+	builder := pbuilder.New(set.Of(owner.Addrs...), pContext, backend)
+
+	utx, err := builder.NewBaseTx(newOutputs(testKeys[2].Address()))
+	require.NoError(err)
+
+	tx, err := NewPChainTx(ctx, constants.UnitTestID, utx, backend)
+	require.NoError(err)
+	require.Len(tx.UTXOs, 1)
+	requireMissingSignatures(t, tx, 2)
+
+	// Keys that don't own the UTXOs don't add any signatures.
+	tx = roundTrip(t, tx)
+	require.NoError(tx.Sign(ctx, secp256k1fx.NewKeychain(testKeys[2])))
+	requireMissingSignatures(t, tx, 2)
+
+	// Each owner signs the transaction on their own machine.
+	tx = roundTrip(t, tx)
+	require.NoError(tx.Sign(ctx, secp256k1fx.NewKeychain(testKeys[1])))
+	requireMissingSignatures(t, tx, 1)
+
+	tx = roundTrip(t, tx)
+	require.NoError(tx.Sign(ctx, secp256k1fx.NewKeychain(testKeys[0])))
+	requireMissingSignatures(t, tx, 0)
+
+	// The result must match signing the transaction with an online wallet.
+	expectedTx, err := psigner.SignUnsigned(
+		ctx,
+		psigner.New(secp256k1fx.NewKeychain(testKeys[0], testKeys[1]), backend),
+		utx,
+	)
+	require.NoError(err)
+
+	signedTx, err := tx.ParsePChainTx()
+	require.NoError(err)
+	require.Equal(expectedTx.Bytes(), signedTx.Bytes())
+
+	txID, err := tx.ID()
+	require.NoError(err)
+	require.Equal(expectedTx.ID(), txID)
+}
+
+func TestPChainSubnetAuth(t *testing.T) {
+	require := require.New(t)
+	ctx := context.Background()
+
+	utxoOwner := &secp256k1fx.OutputOwners{
+		Threshold: 1,
+		Addrs:     []ids.ShortID{testKeys[0].Address()},
+	}
+	createSubnetTx := &ptxs.Tx{
+		Unsigned: &ptxs.CreateSubnetTx{
+			Owner: &secp256k1fx.OutputOwners{
+				Threshold: 1,
+				Addrs:     []ids.ShortID{testKeys[1].Address()},
+			},
+		},
+	}
+	subnetID := ids.GenerateTestID()
+	backend := p.NewBackend(
+		pContext,
+		newUTXOs(t, constants.PlatformChainID, utxoOwner),
+		map[ids.ID]*ptxs.Tx{
+			subnetID: createSubnetTx,
+		},
+	)
+	builder := pbuilder.New(set.Of(testKeys[0].Address(), testKeys[1].Address()), pContext, backend)
+
+	utx, err := builder.NewAddSubnetValidatorTx(&ptxs.SubnetValidator{
+		Validator: ptxs.Validator{
+			NodeID: ids.GenerateTestNodeID(),
+			End:    1,
+			Wght:   1,
+		},
+		Subnet: subnetID,
+	})
+	require.NoError(err)
+
+	tx, err := NewPChainTx(ctx, constants.UnitTestID, utx, backend)
+	require.NoError(err)
+	require.Len(tx.SubnetOwners, 1)
+	requireMissingSignatures(t, tx, 2)
+
+	tx = roundTrip(t, tx)
+	require.NoError(tx.Sign(ctx, secp256k1fx.NewKeychain(testKeys[0], testKeys[1])))
+	requireMissingSignatures(t, tx, 0)
+
+	expectedTx, err := psigner.SignUnsigned(
+		ctx,
+		psigner.New(secp256k1fx.NewKeychain(testKeys[0], testKeys[1]), backend),
+		utx,
+	)
+	require.NoError(err)
+	require.Equal([]byte(tx.Tx), expectedTx.Bytes())
+}
+
+func TestXChainTransfer(t *testing.T) {
+	require := require.New(t)
+	ctx := context.Background()
+
+	owner := &secp256k1fx.OutputOwners{
+		Threshold: 1,
+		Addrs:     []ids.ShortID{testKeys[0].Address()},
+	}
+	backend := x.NewBackend(xContext, newUTXOs(t, xChainID, owner, owner))
+	builder := xbuilder.New(set.Of(owner.Addrs...), xContext, backend)
+
+	utx, err := builder.NewBaseTx(newOutputs(testKeys[1].Address()))
+	require.NoError(err)
+
+	tx, err := NewXChainTx(ctx, constants.UnitTestID, xChainID, utx, backend)
+	require.NoError(err)
+	requireMissingSignatures(t, tx, len(tx.UTXOs))
+
+	tx = roundTrip(t, tx)
+	require.NoError(tx.Sign(ctx, secp256k1fx.NewKeychain(testKeys[0])))
+	requireMissingSignatures(t, tx, 0)
+
+	expectedTx, err := xsigner.SignUnsigned(
+		ctx,
+		xsigner.New(secp256k1fx.NewKeychain(testKeys[0]), backend),
+		utx,
+	)
+	require.NoError(err)
+	require.Equal([]byte(tx.Tx), expectedTx.Bytes())
+
+	_, err = tx.ParsePChainTx()
+	require.ErrorIs(err, ErrUnknownChain)
+}
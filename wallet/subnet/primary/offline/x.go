@@ -0,0 +1,68 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package offline
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/crypto/keychain"
+	"github.com/ava-labs/avalanchego/vms/avm/txs"
+	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
+	"github.com/ava-labs/avalanchego/wallet/chain/x/builder"
+	"github.com/ava-labs/avalanchego/wallet/chain/x/signer"
+)
+
+// NewXChainTx returns an offline transaction that issues [utx] on the X-chain
+// with ID [blockchainID]. [backend] must provide all the UTXOs consumed by
+// [utx].
+func NewXChainTx(
+	ctx context.Context,
+	networkID uint32,
+	blockchainID ids.ID,
+	utx txs.UnsignedTx,
+	backend signer.Backend,
+) (*Tx, error) {
+	recorder := newRecordingBackend(backend, nil, builder.Parser.Codec(), txs.CodecVersion)
+
+	// Signing with an empty keychain adds an empty signature for every
+	// required signature and records the UTXOs that the signers will need.
+	tx, err := signer.SignUnsigned(ctx, signer.New(secp256k1fx.NewKeychain(), recorder), utx)
+	if err != nil {
+		return nil, err
+	}
+	return &Tx{
+		NetworkID:    networkID,
+		Chain:        XChain,
+		BlockchainID: blockchainID,
+		Tx:           tx.Bytes(),
+		UTXOs:        recorder.utxos,
+	}, nil
+}
+
+// ParseXChainTx returns the X-chain transaction, including any signatures
+// that have already been added.
+func (t *Tx) ParseXChainTx() (*txs.Tx, error) {
+	if t.Chain != XChain {
+		return nil, fmt.Errorf("%w: expected %q but got %q", ErrUnknownChain, XChain, t.Chain)
+	}
+	return builder.Parser.ParseTx(t.Tx)
+}
+
+func (t *Tx) signXChainTx(ctx context.Context, kc keychain.Keychain) error {
+	tx, err := t.ParseXChainTx()
+	if err != nil {
+		return err
+	}
+	backend, err := newBackend(t, builder.Parser.Codec())
+	if err != nil {
+		return err
+	}
+	if err := signer.New(kc, backend).Sign(ctx, tx); err != nil {
+		return err
+	}
+	t.Tx = tx.Bytes()
+	return nil
+}
@@ -80,6 +80,16 @@ func (db *Database) Open(height uint64) *Reader {
 	}
 }
 
+// NewDiffIterator returns an iterator over every key >= [start] with [prefix]
+// that was modified at a height in (fromHeight, toHeight]. Each key is
+// returned with its value as of [toHeight].
+func (db *Database) NewDiffIterator(fromHeight, toHeight uint64, start, prefix []byte) *DiffIterator {
+	return &DiffIterator{
+		it:         newEntryIterator(db, start, prefix, toHeight),
+		fromHeight: fromHeight,
+	}
+}
+
 // NewBatch creates a write batch to perform changes at a given height.
 //
 // Note: Committing multiple batches at the same height, or at a lower height
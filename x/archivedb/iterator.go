@@ -0,0 +1,305 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package archivedb
+
+import (
+	"bytes"
+	"encoding/binary"
+	"slices"
+
+	"github.com/ava-labs/avalanchego/database"
+)
+
+var (
+	_ database.Iterator = (*Iterator)(nil)
+	_ database.Iterator = (*DiffIterator)(nil)
+)
+
+// Iterator iterates over the keys that exist at a height, in lexicographic
+// order, returning the value of each key at that height.
+type Iterator struct {
+	it *entryIterator
+}
+
+func (it *Iterator) Next() bool {
+	for it.it.Next() {
+		if it.it.exists {
+			return true
+		}
+	}
+	return false
+}
+
+func (it *Iterator) Error() error {
+	return it.it.Error()
+}
+
+func (it *Iterator) Key() []byte {
+	return it.it.key
+}
+
+func (it *Iterator) Value() []byte {
+	return it.it.value
+}
+
+// Height returns the height that the current key was last modified at.
+func (it *Iterator) Height() uint64 {
+	return it.it.height
+}
+
+func (it *Iterator) Release() {
+	it.it.Release()
+}
+
+// DiffIterator iterates over the keys that were modified after a start height
+// and at or before an end height, in lexicographic order.
+type DiffIterator struct {
+	it         *entryIterator
+	fromHeight uint64
+}
+
+func (it *DiffIterator) Next() bool {
+	for it.it.Next() {
+		if it.it.height > it.fromHeight {
+			return true
+		}
+	}
+	return false
+}
+
+func (it *DiffIterator) Error() error {
+	return it.it.Error()
+}
+
+func (it *DiffIterator) Key() []byte {
+	return it.it.key
+}
+
+// Value returns the value of the current key at the end height, or nil if the
+// key was deleted.
+func (it *DiffIterator) Value() []byte {
+	return it.it.value
+}
+
+// Exists returns false if the current key was deleted as of the end height.
+func (it *DiffIterator) Exists() bool {
+	return it.it.exists
+}
+
+// Height returns the height that the current key was last modified at.
+func (it *DiffIterator) Height() uint64 {
+	return it.it.height
+}
+
+func (it *DiffIterator) Release() {
+	it.it.Release()
+}
+
+// entryIterator iterates over every user key with [prefix] that is >= [start],
+// in lexicographic order, along with the most recent entry of the key at or
+// below [maxHeight]. Keys without any entries at or below [maxHeight] are
+// skipped.
+//
+// Because database keys are prefixed by the length of the user key, the user
+// keys of each length are stored in a separate range of the database. These
+// ranges are iterated over concurrently and merged.
+type entryIterator struct {
+	db        *Database
+	start     []byte
+	prefix    []byte
+	maxHeight uint64
+
+	initialized bool
+	ranges      []*rangeIterator
+	// current is the range that the current entry was read from
+	current *rangeIterator
+	err     error
+
+	key    []byte
+	value  []byte
+	height uint64
+	exists bool
+}
+
+func newEntryIterator(db *Database, start, prefix []byte, maxHeight uint64) *entryIterator {
+	if bytes.Compare(start, prefix) < 0 {
+		start = prefix
+	}
+	return &entryIterator{
+		db:        db,
+		start:     slices.Clone(start),
+		prefix:    slices.Clone(prefix),
+		maxHeight: maxHeight,
+	}
+}
+
+func (it *entryIterator) Next() bool {
+	if it.err != nil {
+		return false
+	}
+	if !it.initialized {
+		it.initialized = true
+		if it.err = it.initRanges(); it.err != nil {
+			it.clear()
+			return false
+		}
+	} else if it.current != nil {
+		if it.err = it.current.next(); it.err != nil {
+			it.clear()
+			return false
+		}
+	}
+
+	it.current = nil
+	for _, r := range it.ranges {
+		if r.done {
+			continue
+		}
+		if it.current == nil || bytes.Compare(r.key, it.current.key) < 0 {
+			it.current = r
+		}
+	}
+	if it.current == nil {
+		it.clear()
+		return false
+	}
+
+	it.key = it.current.key
+	it.value = it.current.value
+	it.height = it.current.height
+	it.exists = it.current.exists
+	return true
+}
+
+func (it *entryIterator) Error() error {
+	return it.err
+}
+
+func (it *entryIterator) Release() {
+	for _, r := range it.ranges {
+		r.it.Release()
+	}
+	it.ranges = nil
+	it.current = nil
+	it.clear()
+}
+
+func (it *entryIterator) clear() {
+	it.key = nil
+	it.value = nil
+	it.height = 0
+	it.exists = false
+}
+
+// initRanges opens an iterator over every range of database keys that could
+// contain user keys with [it.prefix].
+func (it *entryIterator) initRanges() error {
+	var seek []byte
+	for {
+		lengthPrefix, keyLen, ok, err := it.nextLengthPrefix(seek)
+		if err != nil || !ok {
+			return err
+		}
+		seek = prefixSuccessor(lengthPrefix)
+
+		if keyLen < uint64(len(it.prefix)) {
+			continue
+		}
+
+		dbPrefix := append(slices.Clip(lengthPrefix), it.prefix...)
+		dbStart := append(slices.Clip(lengthPrefix), it.start...)
+		r := &rangeIterator{
+			it:        it.db.db.NewIteratorWithStartAndPrefix(dbStart, dbPrefix),
+			keyLen:    keyLen,
+			start:     it.start,
+			maxHeight: it.maxHeight,
+		}
+		it.ranges = append(it.ranges, r)
+		if err := r.next(); err != nil {
+			return err
+		}
+	}
+}
+
+// nextLengthPrefix returns the length prefix of the first database key that is
+// >= [seek], along with the key length it encodes.
+func (it *entryIterator) nextLengthPrefix(seek []byte) ([]byte, uint64, bool, error) {
+	dbIt := it.db.db.NewIteratorWithStart(seek)
+	defer dbIt.Release()
+
+	if !dbIt.Next() {
+		return nil, 0, false, dbIt.Error()
+	}
+	dbKey := dbIt.Key()
+	keyLen, offset := binary.Uvarint(dbKey)
+	if offset <= 0 {
+		return nil, 0, false, ErrParsingKeyLength
+	}
+	return slices.Clone(dbKey[:offset]), keyLen, true, nil
+}
+
+// prefixSuccessor returns the smallest key that is greater than every key
+// prefixed by [lengthPrefix].
+//
+// The final byte of a uvarint never has its high bit set, so it can always be
+// incremented without overflowing.
+func prefixSuccessor(lengthPrefix []byte) []byte {
+	successor := slices.Clone(lengthPrefix)
+	successor[len(successor)-1]++
+	return successor
+}
+
+// rangeIterator iterates over the user keys of a single length.
+type rangeIterator struct {
+	it        database.Iterator
+	keyLen    uint64
+	start     []byte
+	maxHeight uint64
+
+	done   bool
+	key    []byte
+	value  []byte
+	height uint64
+	exists bool
+}
+
+// next advances to the most recent entry at or below [maxHeight] of the next
+// user key.
+func (r *rangeIterator) next() error {
+	lastKey := r.key
+	for r.it.Next() {
+		dbKey := r.it.Key()
+		if isMetadataKey(dbKey, r.keyLen) {
+			continue
+		}
+
+		key, height, err := parseDBKeyFromUser(dbKey)
+		if err != nil {
+			return err
+		}
+		if height > r.maxHeight {
+			continue
+		}
+		if bytes.Equal(key, lastKey) {
+			// This is an older entry of a key that was already visited.
+			continue
+		}
+		lastKey = slices.Clone(key)
+		if bytes.Compare(lastKey, r.start) < 0 {
+			continue
+		}
+
+		value, exists := parseDBValue(r.it.Value())
+		r.key = lastKey
+		r.value = slices.Clone(value)
+		r.height = height
+		r.exists = exists
+		return nil
+	}
+
+	r.done = true
+	r.key = nil
+	r.value = nil
+	return r.it.Error()
+}
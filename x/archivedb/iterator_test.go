@@ -0,0 +1,312 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package archivedb
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/database/memdb"
+)
+
+type iteratorEntry struct {
+	key   []byte
+	value []byte
+}
+
+func collectEntries(t *testing.T, it database.Iterator) []iteratorEntry {
+	require := require.New(t)
+
+	var entries []iteratorEntry
+	for it.Next() {
+		entries = append(entries, iteratorEntry{
+			key:   it.Key(),
+			value: it.Value(),
+		})
+	}
+	require.NoError(it.Error())
+	it.Release()
+	return entries
+}
+
+func newIteratorTestDB(t *testing.T) *Database {
+	require := require.New(t)
+
+	db := New(memdb.New())
+
+	batch := db.NewBatch(1)
+	require.NoError(batch.Put([]byte("b"), []byte("b@1")))
+	require.NoError(batch.Put([]byte("ab"), []byte("ab@1")))
+	require.NoError(batch.Put([]byte("abc"), []byte("abc@1")))
+	require.NoError(batch.Put([]byte("c"), []byte("c@1")))
+	require.NoError(batch.Write())
+
+	batch = db.NewBatch(2)
+	require.NoError(batch.Put([]byte("a"), []byte("a@2")))
+	require.NoError(batch.Put([]byte("b"), []byte("b@2")))
+	require.NoError(batch.Delete([]byte("c")))
+	require.NoError(batch.Write())
+
+	batch = db.NewBatch(3)
+	require.NoError(batch.Put([]byte("abc"), []byte("abc@3")))
+	require.NoError(batch.Put([]byte("c"), []byte("c@3")))
+	require.NoError(batch.Write())
+	return db
+}
+
+func TestIterator(t *testing.T) {
+	db := newIteratorTestDB(t)
+
+	tests := []struct {
+		name     string
+		height   uint64
+		start    []byte
+		prefix   []byte
+		expected []iteratorEntry
+	}{
+		{
+			name:     "before first height",
+			height:   0,
+			expected: nil,
+		},
+		{
+			name:   "first height",
+			height: 1,
+			expected: []iteratorEntry{
+				{key: []byte("ab"), value: []byte("ab@1")},
+				{key: []byte("abc"), value: []byte("abc@1")},
+				{key: []byte("b"), value: []byte("b@1")},
+				{key: []byte("c"), value: []byte("c@1")},
+			},
+		},
+		{
+			name:   "deleted key skipped",
+			height: 2,
+			expected: []iteratorEntry{
+				{key: []byte("a"), value: []byte("a@2")},
+				{key: []byte("ab"), value: []byte("ab@1")},
+				{key: []byte("abc"), value: []byte("abc@1")},
+				{key: []byte("b"), value: []byte("b@2")},
+			},
+		},
+		{
+			name:   "deleted key rewritten",
+			height: 3,
+			expected: []iteratorEntry{
+				{key: []byte("a"), value: []byte("a@2")},
+				{key: []byte("ab"), value: []byte("ab@1")},
+				{key: []byte("abc"), value: []byte("abc@3")},
+				{key: []byte("b"), value: []byte("b@2")},
+				{key: []byte("c"), value: []byte("c@3")},
+			},
+		},
+		{
+			name:   "after last height",
+			height: 4,
+			expected: []iteratorEntry{
+				{key: []byte("a"), value: []byte("a@2")},
+				{key: []byte("ab"), value: []byte("ab@1")},
+				{key: []byte("abc"), value: []byte("abc@3")},
+				{key: []byte("b"), value: []byte("b@2")},
+				{key: []byte("c"), value: []byte("c@3")},
+			},
+		},
+		{
+			name:   "start",
+			height: 3,
+			start:  []byte("ab"),
+			expected: []iteratorEntry{
+				{key: []byte("ab"), value: []byte("ab@1")},
+				{key: []byte("abc"), value: []byte("abc@3")},
+				{key: []byte("b"), value: []byte("b@2")},
+				{key: []byte("c"), value: []byte("c@3")},
+			},
+		},
+		{
+			name:   "start between keys",
+			height: 3,
+			start:  []byte("abd"),
+			expected: []iteratorEntry{
+				{key: []byte("b"), value: []byte("b@2")},
+				{key: []byte("c"), value: []byte("c@3")},
+			},
+		},
+		{
+			name:   "prefix",
+			height: 3,
+			prefix: []byte("ab"),
+			expected: []iteratorEntry{
+				{key: []byte("ab"), value: []byte("ab@1")},
+				{key: []byte("abc"), value: []byte("abc@3")},
+			},
+		},
+		{
+			name:   "start and prefix",
+			height: 3,
+			start:  []byte("abc"),
+			prefix: []byte("a"),
+			expected: []iteratorEntry{
+				{key: []byte("abc"), value: []byte("abc@3")},
+			},
+		},
+		{
+			name:     "start after prefix",
+			height:   3,
+			start:    []byte("b"),
+			prefix:   []byte("a"),
+			expected: nil,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			it := db.Open(test.height).NewIteratorWithStartAndPrefix(test.start, test.prefix)
+			require.Equal(t, test.expected, collectEntries(t, it))
+		})
+	}
+}
+
+func TestIteratorLongKeys(t *testing.T) {
+	require := require.New(t)
+
+	db := New(memdb.New())
+
+	// The length prefixes of these keys are not ordered the same as the keys.
+	keys := [][]byte{
+		bytes.Repeat([]byte{1}, 1),
+		bytes.Repeat([]byte{1}, 127),
+		bytes.Repeat([]byte{1}, 128),
+		bytes.Repeat([]byte{1}, 129),
+		bytes.Repeat([]byte{1}, 255),
+		bytes.Repeat([]byte{1}, 256),
+		bytes.Repeat([]byte{1}, 1024),
+		bytes.Repeat([]byte{2}, 2),
+	}
+
+	batch := db.NewBatch(1)
+	for i := len(keys) - 1; i >= 0; i-- {
+		require.NoError(batch.Put(keys[i], keys[i]))
+	}
+	require.NoError(batch.Write())
+
+	expected := make([]iteratorEntry, len(keys))
+	for i, key := range keys {
+		expected[i] = iteratorEntry{
+			key:   key,
+			value: key,
+		}
+	}
+	require.Equal(expected, collectEntries(t, db.Open(1).NewIterator()))
+	require.Equal(expected[3:], collectEntries(t, db.Open(1).NewIteratorWithStart(keys[3])))
+	require.Equal(expected[:len(expected)-1], collectEntries(t, db.Open(1).NewIteratorWithPrefix([]byte{1})))
+}
+
+func TestIteratorHeight(t *testing.T) {
+	require := require.New(t)
+
+	db := newIteratorTestDB(t)
+
+	it := db.Open(3).NewIteratorWithPrefix([]byte("ab")).(*Iterator)
+	defer it.Release()
+
+	require.True(it.Next())
+	require.Equal([]byte("ab"), it.Key())
+	require.Equal(uint64(1), it.Height())
+
+	require.True(it.Next())
+	require.Equal([]byte("abc"), it.Key())
+	require.Equal(uint64(3), it.Height())
+
+	require.False(it.Next())
+	require.NoError(it.Error())
+}
+
+func TestDiffIterator(t *testing.T) {
+	db := newIteratorTestDB(t)
+
+	type diffEntry struct {
+		key    []byte
+		value  []byte
+		height uint64
+		exists bool
+	}
+	tests := []struct {
+		name       string
+		fromHeight uint64
+		toHeight   uint64
+		start      []byte
+		prefix     []byte
+		expected   []diffEntry
+	}{
+		{
+			name:       "no heights",
+			fromHeight: 2,
+			toHeight:   2,
+			expected:   nil,
+		},
+		{
+			name:       "single height with deletion",
+			fromHeight: 1,
+			toHeight:   2,
+			expected: []diffEntry{
+				{key: []byte("a"), value: []byte("a@2"), height: 2, exists: true},
+				{key: []byte("b"), value: []byte("b@2"), height: 2, exists: true},
+				{key: []byte("c"), height: 2, exists: false},
+			},
+		},
+		{
+			name:       "multiple heights",
+			fromHeight: 1,
+			toHeight:   3,
+			expected: []diffEntry{
+				{key: []byte("a"), value: []byte("a@2"), height: 2, exists: true},
+				{key: []byte("abc"), value: []byte("abc@3"), height: 3, exists: true},
+				{key: []byte("b"), value: []byte("b@2"), height: 2, exists: true},
+				{key: []byte("c"), value: []byte("c@3"), height: 3, exists: true},
+			},
+		},
+		{
+			name:       "prefix",
+			fromHeight: 0,
+			toHeight:   3,
+			prefix:     []byte("ab"),
+			expected: []diffEntry{
+				{key: []byte("ab"), value: []byte("ab@1"), height: 1, exists: true},
+				{key: []byte("abc"), value: []byte("abc@3"), height: 3, exists: true},
+			},
+		},
+		{
+			name:       "start",
+			fromHeight: 1,
+			toHeight:   2,
+			start:      []byte("b"),
+			expected: []diffEntry{
+				{key: []byte("b"), value: []byte("b@2"), height: 2, exists: true},
+				{key: []byte("c"), height: 2, exists: false},
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require := require.New(t)
+
+			it := db.NewDiffIterator(test.fromHeight, test.toHeight, test.start, test.prefix)
+			defer it.Release()
+
+			var entries []diffEntry
+			for it.Next() {
+				entries = append(entries, diffEntry{
+					key:    it.Key(),
+					value:  it.Value(),
+					height: it.Height(),
+					exists: it.Exists(),
+				})
+			}
+			require.NoError(it.Error())
+			require.Equal(test.expected, entries)
+		})
+	}
+}
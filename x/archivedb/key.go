@@ -93,3 +93,15 @@ func newDBKeyFromMetadata(key []byte) []byte {
 	offset += copy(dbKey[offset:], key)
 	return dbKey[:offset]
 }
+
+// isMetadataKey returns true if [dbKey], which is prefixed by the length
+// [keyLen], was created by newDBKeyFromMetadata rather than newDBKeyFromUser.
+//
+// Metadata keys are stored with their length incremented by one, so they share
+// the length prefix of user keys that are one byte longer. However, user keys
+// are always suffixed by a height, so the two are distinguished by the total
+// length of the database key.
+func isMetadataKey(dbKey []byte, keyLen uint64) bool {
+	_, offset := binary.Uvarint(dbKey)
+	return offset > 0 && keyLen > 0 && uint64(len(dbKey)) == uint64(offset)+keyLen-1
+}
@@ -5,7 +5,10 @@ package archivedb
 
 import "github.com/ava-labs/avalanchego/database"
 
-var _ database.KeyValueReader = (*Reader)(nil)
+var (
+	_ database.KeyValueReader = (*Reader)(nil)
+	_ database.Iteratee       = (*Reader)(nil)
+)
 
 type Reader struct {
 	db     *Database
@@ -59,3 +62,31 @@ func (r *Reader) GetEntry(key []byte) ([]byte, uint64, bool, error) {
 	}
 	return value, height, true, nil
 }
+
+// NewIterator returns an iterator over every key that exists at the height of
+// this reader.
+func (r *Reader) NewIterator() database.Iterator {
+	return r.NewIteratorWithStartAndPrefix(nil, nil)
+}
+
+// NewIteratorWithStart returns an iterator over every key >= [start] that
+// exists at the height of this reader.
+func (r *Reader) NewIteratorWithStart(start []byte) database.Iterator {
+	return r.NewIteratorWithStartAndPrefix(start, nil)
+}
+
+// NewIteratorWithPrefix returns an iterator over every key with [prefix] that
+// exists at the height of this reader.
+func (r *Reader) NewIteratorWithPrefix(prefix []byte) database.Iterator {
+	return r.NewIteratorWithStartAndPrefix(nil, prefix)
+}
+
+// NewIteratorWithStartAndPrefix returns an iterator over every key >= [start]
+// with [prefix] that exists at the height of this reader. Each key is returned
+// with the latest value written at or below the height of this reader. Keys
+// that were deleted at or below the height of this reader are skipped.
+func (r *Reader) NewIteratorWithStartAndPrefix(start, prefix []byte) database.Iterator {
+	return &Iterator{
+		it: newEntryIterator(r.db, start, prefix, r.height),
+	}
+}
@@ -0,0 +1,207 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package main
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/spf13/cobra"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/database/leveldb"
+	"github.com/ava-labs/avalanchego/database/pebble"
+	"github.com/ava-labs/avalanchego/database/prefixdb"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/trace"
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/ava-labs/avalanchego/utils/units"
+	"github.com/ava-labs/avalanchego/x/merkledb"
+)
+
+var (
+	errDBDirRequired        = errors.New("--db-dir is required")
+	errSnapshotFileRequired = errors.New("--snapshot-file is required")
+	errUnexpectedRoot       = errors.New("unexpected root")
+)
+
+// dbFlags describe how to open the merkledb operated on by a command.
+type dbFlags struct {
+	dir          string
+	dbType       string
+	prefix       string
+	branchFactor int
+}
+
+func (f *dbFlags) register(cmd *cobra.Command) {
+	cmd.PersistentFlags().StringVar(&f.dir, "db-dir", "", "The path to the database directory")
+	cmd.PersistentFlags().StringVar(&f.dbType, "db-type", leveldb.Name, fmt.Sprintf("The database type. Must be one of {%s, %s}", leveldb.Name, pebble.Name))
+	cmd.PersistentFlags().StringVar(&f.prefix, "db-prefix", "", "[optional] hex encoded prefix the merkledb is stored under")
+	cmd.PersistentFlags().IntVar(&f.branchFactor, "branch-factor", int(merkledb.BranchFactor16), "The branch factor of the merkledb")
+}
+
+// open returns the merkledb described by [f] along with a function that
+// closes it and the underlying database.
+func (f *dbFlags) open(ctx context.Context) (merkledb.MerkleDB, func() error, error) {
+	if len(f.dir) == 0 {
+		return nil, nil, errDBDirRequired
+	}
+	prefix, err := hex.DecodeString(f.prefix)
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid --db-prefix: %w", err)
+	}
+
+	var baseDB database.Database
+	switch f.dbType {
+	case leveldb.Name:
+		baseDB, err = leveldb.New(f.dir, nil, logging.NoLog{}, "", prometheus.NewRegistry())
+	case pebble.Name:
+		baseDB, err = pebble.New(f.dir, nil, logging.NoLog{}, "", prometheus.NewRegistry())
+	default:
+		err = fmt.Errorf("db-type was %q but should have been one of {%s, %s}",
+			f.dbType,
+			leveldb.Name,
+			pebble.Name,
+		)
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var db database.Database = baseDB
+	if len(prefix) > 0 {
+		db = prefixdb.New(prefix, baseDB)
+	}
+	trieDB, err := merkledb.New(ctx, db, merkledb.Config{
+		BranchFactor:                merkledb.BranchFactor(f.branchFactor),
+		HistoryLength:               1,
+		ValueNodeCacheSize:          64 * units.MiB,
+		IntermediateNodeCacheSize:   64 * units.MiB,
+		IntermediateWriteBufferSize: 16 * units.MiB,
+		IntermediateWriteBatchSize:  units.MiB,
+		Reg:                         prometheus.NewRegistry(),
+		Tracer:                      trace.Noop,
+	})
+	if err != nil {
+		_ = baseDB.Close()
+		return nil, nil, err
+	}
+	return trieDB, func() error {
+		return errors.Join(trieDB.Close(), baseDB.Close())
+	}, nil
+}
+
+func main() {
+	rootCmd := &cobra.Command{
+		Use:   "merkledbctl",
+		Short: "merkledbctl commands",
+	}
+
+	var (
+		exportDBFlags      dbFlags
+		exportSnapshotFile string
+		exportRoot         string
+	)
+	exportCmd := &cobra.Command{
+		Use:   "export-snapshot",
+		Short: "Write a verifiable snapshot of a merkledb to a file",
+		RunE: func(*cobra.Command, []string) (err error) {
+			if len(exportSnapshotFile) == 0 {
+				return errSnapshotFileRequired
+			}
+
+			ctx := context.Background()
+			db, closeDB, err := exportDBFlags.open(ctx)
+			if err != nil {
+				return err
+			}
+			defer func() {
+				err = errors.Join(err, closeDB())
+			}()
+
+			rootID, err := db.GetMerkleRoot(ctx)
+			if err != nil {
+				return err
+			}
+			if len(exportRoot) > 0 {
+				expectedRootID, err := ids.FromString(exportRoot)
+				if err != nil {
+					return fmt.Errorf("invalid --root: %w", err)
+				}
+				if rootID != expectedRootID {
+					return fmt.Errorf("%w: database is at %s but %s was requested", errUnexpectedRoot, rootID, expectedRootID)
+				}
+			}
+
+			f, err := os.Create(exportSnapshotFile)
+			if err != nil {
+				return err
+			}
+			if err := db.WriteSnapshot(ctx, rootID, f); err != nil {
+				_ = f.Close()
+				return err
+			}
+			if err := f.Close(); err != nil {
+				return err
+			}
+
+			fmt.Fprintf(os.Stdout, "Wrote snapshot of root %s to %s\n", rootID, exportSnapshotFile)
+			return nil
+		},
+	}
+	exportDBFlags.register(exportCmd)
+	exportCmd.PersistentFlags().StringVar(&exportSnapshotFile, "snapshot-file", "", "The path to write the snapshot to")
+	exportCmd.PersistentFlags().StringVar(&exportRoot, "root", "", "[optional] the root the database is expected to be at")
+	rootCmd.AddCommand(exportCmd)
+
+	var (
+		restoreDBFlags      dbFlags
+		restoreSnapshotFile string
+	)
+	restoreCmd := &cobra.Command{
+		Use:   "restore-snapshot",
+		Short: "Rebuild an empty merkledb from a snapshot file",
+		RunE: func(*cobra.Command, []string) (err error) {
+			if len(restoreSnapshotFile) == 0 {
+				return errSnapshotFileRequired
+			}
+
+			f, err := os.Open(restoreSnapshotFile)
+			if err != nil {
+				return err
+			}
+			defer f.Close()
+
+			ctx := context.Background()
+			db, closeDB, err := restoreDBFlags.open(ctx)
+			if err != nil {
+				return err
+			}
+			defer func() {
+				err = errors.Join(err, closeDB())
+			}()
+
+			rootID, err := db.RestoreSnapshot(ctx, f)
+			if err != nil {
+				return err
+			}
+
+			fmt.Fprintf(os.Stdout, "Restored and verified root %s from %s\n", rootID, restoreSnapshotFile)
+			return nil
+		},
+	}
+	restoreDBFlags.register(restoreCmd)
+	restoreCmd.PersistentFlags().StringVar(&restoreSnapshotFile, "snapshot-file", "", "The path to read the snapshot from")
+	rootCmd.AddCommand(restoreCmd)
+
+	if err := rootCmd.Execute(); err != nil {
+		fmt.Fprintf(os.Stderr, "merkledbctl failed: %v\n", err)
+		os.Exit(1)
+	}
+	os.Exit(0)
+}
@@ -147,6 +147,7 @@ type MerkleDB interface {
 	ChangeProofer
 	RangeProofer
 	Prefetcher
+	Snapshotter
 }
 
 type Config struct {
@@ -3,7 +3,7 @@
 //
 // Generated by this command:
 //
-//	mockgen -source=x/merkledb/db.go -destination=x/merkledb/mock_db.go -package=merkledb -exclude_interfaces=ChangeProofer,RangeProofer,Clearer,Prefetcher
+//	mockgen -source=x/merkledb/db.go -destination=x/merkledb/mock_db.go -package=merkledb -exclude_interfaces=ChangeProofer,RangeProofer,Clearer,Prefetcher,Snapshotter
 //
 
 // Package merkledb is a generated GoMock package.
@@ -11,6 +11,7 @@ package merkledb
 
 import (
 	context "context"
+	io "io"
 	reflect "reflect"
 
 	database "github.com/ava-labs/avalanchego/database"
@@ -403,6 +404,21 @@ func (mr *MockMerkleDBMockRecorder) Put(key, value any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Put", reflect.TypeOf((*MockMerkleDB)(nil).Put), key, value)
 }
 
+// RestoreSnapshot mocks base method.
+func (m *MockMerkleDB) RestoreSnapshot(ctx context.Context, r io.Reader) (ids.ID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RestoreSnapshot", ctx, r)
+	ret0, _ := ret[0].(ids.ID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RestoreSnapshot indicates an expected call of RestoreSnapshot.
+func (mr *MockMerkleDBMockRecorder) RestoreSnapshot(ctx, r any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestoreSnapshot", reflect.TypeOf((*MockMerkleDB)(nil).RestoreSnapshot), ctx, r)
+}
+
 // VerifyChangeProof mocks base method.
 func (m *MockMerkleDB) VerifyChangeProof(ctx context.Context, proof *ChangeProof, start, end maybe.Maybe[[]byte], expectedEndRootID ids.ID) error {
 	m.ctrl.T.Helper()
@@ -417,6 +433,20 @@ func (mr *MockMerkleDBMockRecorder) VerifyChangeProof(ctx, proof, start, end, ex
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VerifyChangeProof", reflect.TypeOf((*MockMerkleDB)(nil).VerifyChangeProof), ctx, proof, start, end, expectedEndRootID)
 }
 
+// WriteSnapshot mocks base method.
+func (m *MockMerkleDB) WriteSnapshot(ctx context.Context, rootID ids.ID, w io.Writer) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WriteSnapshot", ctx, rootID, w)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// WriteSnapshot indicates an expected call of WriteSnapshot.
+func (mr *MockMerkleDBMockRecorder) WriteSnapshot(ctx, rootID, w any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WriteSnapshot", reflect.TypeOf((*MockMerkleDB)(nil).WriteSnapshot), ctx, rootID, w)
+}
+
 // getEditableNode mocks base method.
 func (m *MockMerkleDB) getEditableNode(key Key, hasValue bool) (*node, error) {
 	m.ctrl.T.Helper()
@@ -0,0 +1,303 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package merkledb
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+
+	"google.golang.org/protobuf/proto"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/maybe"
+	"github.com/ava-labs/avalanchego/utils/units"
+
+	pb "github.com/ava-labs/avalanchego/proto/pb/sync"
+)
+
+const (
+	snapshotVersion = 0
+
+	// The maximum number of key/value pairs in each range proof of a snapshot.
+	snapshotChunkSize = 2048
+
+	// Each record in a snapshot is prefixed by its length. A record with a
+	// length of 0 marks the end of the snapshot.
+	snapshotLengthSize = 4
+
+	// An upper bound on the size of a single encoded range proof. Prevents a
+	// malformed snapshot from forcing a huge allocation.
+	maxSnapshotRecordSize = 256 * units.MiB
+)
+
+var (
+	snapshotMagic = []byte("MRKLSNAP")
+
+	ErrInvalidSnapshot               = errors.New("invalid snapshot")
+	ErrSnapshotBranchFactorMismatch  = errors.New("snapshot branch factor doesn't match database branch factor")
+	ErrSnapshotRootMismatch          = errors.New("restored root doesn't match snapshot root")
+	ErrRestoreIntoNonEmptyDB         = errors.New("snapshots can only be restored into an empty database")
+	errSnapshotVersionMismatch       = errors.New("unsupported snapshot version")
+	errSnapshotRecordTooLarge        = errors.New("snapshot record too large")
+	errSnapshotMissingTerminator     = errors.New("snapshot ended without a terminating record")
+	errSnapshotUnexpectedEmptyRecord = errors.New("snapshot of an empty trie contains a range proof")
+)
+
+type Snapshotter interface {
+	// WriteSnapshot writes every key/value pair of the trie when its root was
+	// [rootID] to [w].
+	//
+	// The snapshot is a sequence of range proofs that, together, cover the
+	// entire key space. Each range proof is verifiable against [rootID], so a
+	// snapshot can be verified as it is read.
+	//
+	// Writes to the database are blocked until the snapshot has been written
+	// so that the snapshot is consistent.
+	// Returns [ErrInsufficientHistory] if the history doesn't contain
+	// [rootID].
+	WriteSnapshot(ctx context.Context, rootID ids.ID, w io.Writer) error
+
+	// RestoreSnapshot reads a snapshot written by WriteSnapshot from [r],
+	// verifies every range proof in it and commits the key/value pairs to the
+	// database. Returns the root ID of the restored trie.
+	//
+	// The database must be empty and must not be written to concurrently.
+	// If an error is returned, the database may contain a subset of the
+	// snapshot and should be discarded.
+	RestoreSnapshot(ctx context.Context, r io.Reader) (ids.ID, error)
+}
+
+// snapshotHeader is written at the start of every snapshot.
+type snapshotHeader struct {
+	version      uint16
+	branchFactor BranchFactor
+	rootID       ids.ID
+}
+
+func writeSnapshotHeader(w io.Writer, header snapshotHeader) error {
+	buf := make([]byte, 0, len(snapshotMagic)+2+2+ids.IDLen)
+	buf = append(buf, snapshotMagic...)
+	buf = binary.BigEndian.AppendUint16(buf, header.version)
+	buf = binary.BigEndian.AppendUint16(buf, uint16(header.branchFactor))
+	buf = append(buf, header.rootID[:]...)
+	_, err := w.Write(buf)
+	return err
+}
+
+func readSnapshotHeader(r io.Reader) (snapshotHeader, error) {
+	buf := make([]byte, len(snapshotMagic)+2+2+ids.IDLen)
+	if _, err := io.ReadFull(r, buf); err != nil {
+		return snapshotHeader{}, fmt.Errorf("%w: failed to read header: %w", ErrInvalidSnapshot, err)
+	}
+	if !bytes.Equal(buf[:len(snapshotMagic)], snapshotMagic) {
+		return snapshotHeader{}, fmt.Errorf("%w: unexpected magic bytes %x", ErrInvalidSnapshot, buf[:len(snapshotMagic)])
+	}
+	buf = buf[len(snapshotMagic):]
+
+	header := snapshotHeader{
+		version:      binary.BigEndian.Uint16(buf),
+		branchFactor: BranchFactor(binary.BigEndian.Uint16(buf[2:])),
+	}
+	copy(header.rootID[:], buf[4:])
+	if header.version != snapshotVersion {
+		return snapshotHeader{}, fmt.Errorf("%w: %w: %d", ErrInvalidSnapshot, errSnapshotVersionMismatch, header.version)
+	}
+	if err := header.branchFactor.Valid(); err != nil {
+		return snapshotHeader{}, fmt.Errorf("%w: %w", ErrInvalidSnapshot, err)
+	}
+	return header, nil
+}
+
+// writeSnapshotRecord writes [proof] to [w]. If [proof] is nil, the
+// terminating record is written.
+func writeSnapshotRecord(w io.Writer, proof *RangeProof) error {
+	var (
+		record []byte
+		err    error
+	)
+	if proof != nil {
+		record, err = proto.Marshal(proof.ToProto())
+		if err != nil {
+			return err
+		}
+	}
+
+	var length [snapshotLengthSize]byte
+	binary.BigEndian.PutUint32(length[:], uint32(len(record)))
+	if _, err := w.Write(length[:]); err != nil {
+		return err
+	}
+	_, err = w.Write(record)
+	return err
+}
+
+// readSnapshotRecord reads the next range proof from [r]. Returns nil if the
+// terminating record was read.
+func readSnapshotRecord(r io.Reader) (*RangeProof, error) {
+	var length [snapshotLengthSize]byte
+	if _, err := io.ReadFull(r, length[:]); err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil, fmt.Errorf("%w: %w", ErrInvalidSnapshot, errSnapshotMissingTerminator)
+		}
+		return nil, fmt.Errorf("%w: failed to read record length: %w", ErrInvalidSnapshot, err)
+	}
+
+	recordLen := binary.BigEndian.Uint32(length[:])
+	if recordLen == 0 {
+		return nil, nil
+	}
+	if recordLen > maxSnapshotRecordSize {
+		return nil, fmt.Errorf("%w: %w: %d > %d", ErrInvalidSnapshot, errSnapshotRecordTooLarge, recordLen, maxSnapshotRecordSize)
+	}
+
+	record := make([]byte, recordLen)
+	if _, err := io.ReadFull(r, record); err != nil {
+		return nil, fmt.Errorf("%w: failed to read record: %w", ErrInvalidSnapshot, err)
+	}
+
+	var pbProof pb.RangeProof
+	if err := proto.Unmarshal(record, &pbProof); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidSnapshot, err)
+	}
+	var proof RangeProof
+	if err := proof.UnmarshalProto(&pbProof); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidSnapshot, err)
+	}
+	return &proof, nil
+}
+
+// Returns the smallest key that is strictly greater than [key].
+func nextKey(key []byte) []byte {
+	next := make([]byte, len(key)+1)
+	copy(next, key)
+	return next
+}
+
+func (db *merkleDB) WriteSnapshot(ctx context.Context, rootID ids.ID, w io.Writer) error {
+	db.commitLock.RLock()
+	defer db.commitLock.RUnlock()
+
+	ctx, span := db.infoTracer.Start(ctx, "MerkleDB.WriteSnapshot")
+	defer span.End()
+
+	if db.closed {
+		return database.ErrClosed
+	}
+
+	bufferedWriter := bufio.NewWriter(w)
+	if err := writeSnapshotHeader(bufferedWriter, snapshotHeader{
+		version:      snapshotVersion,
+		branchFactor: tokenSizeToBranchFactor[db.tokenSize],
+		rootID:       rootID,
+	}); err != nil {
+		return err
+	}
+
+	// An empty trie is represented by a snapshot without any range proofs.
+	if rootID != ids.Empty {
+		historicalTrie, err := db.getTrieAtRootForRange(rootID, maybe.Nothing[[]byte](), maybe.Nothing[[]byte]())
+		if err != nil {
+			return err
+		}
+
+		start := maybe.Nothing[[]byte]()
+		for {
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+
+			proof, err := getRangeProof(historicalTrie, start, maybe.Nothing[[]byte](), snapshotChunkSize)
+			if err != nil {
+				return err
+			}
+			if err := writeSnapshotRecord(bufferedWriter, proof); err != nil {
+				return err
+			}
+			if len(proof.KeyValues) < snapshotChunkSize {
+				break
+			}
+			start = maybe.Some(nextKey(proof.KeyValues[len(proof.KeyValues)-1].Key))
+		}
+	}
+
+	if err := writeSnapshotRecord(bufferedWriter, nil); err != nil {
+		return err
+	}
+	return bufferedWriter.Flush()
+}
+
+func (db *merkleDB) RestoreSnapshot(ctx context.Context, r io.Reader) (ids.ID, error) {
+	ctx, span := db.infoTracer.Start(ctx, "MerkleDB.RestoreSnapshot")
+	defer span.End()
+
+	currentRootID, err := db.GetMerkleRoot(ctx)
+	if err != nil {
+		return ids.Empty, err
+	}
+	if currentRootID != ids.Empty {
+		return ids.Empty, ErrRestoreIntoNonEmptyDB
+	}
+
+	bufferedReader := bufio.NewReader(r)
+	header, err := readSnapshotHeader(bufferedReader)
+	if err != nil {
+		return ids.Empty, err
+	}
+	if expectedBranchFactor := tokenSizeToBranchFactor[db.tokenSize]; header.branchFactor != expectedBranchFactor {
+		return ids.Empty, fmt.Errorf("%w: snapshot has %d but database has %d",
+			ErrSnapshotBranchFactorMismatch,
+			header.branchFactor,
+			expectedBranchFactor,
+		)
+	}
+
+	start := maybe.Nothing[[]byte]()
+	for {
+		if err := ctx.Err(); err != nil {
+			return ids.Empty, err
+		}
+
+		proof, err := readSnapshotRecord(bufferedReader)
+		if err != nil {
+			return ids.Empty, err
+		}
+		if proof == nil {
+			break
+		}
+		if header.rootID == ids.Empty {
+			return ids.Empty, fmt.Errorf("%w: %w", ErrInvalidSnapshot, errSnapshotUnexpectedEmptyRecord)
+		}
+
+		if err := proof.Verify(ctx, start, maybe.Nothing[[]byte](), header.rootID, db.tokenSize); err != nil {
+			return ids.Empty, fmt.Errorf("%w: %w", ErrInvalidSnapshot, err)
+		}
+		if err := db.CommitRangeProof(ctx, start, maybe.Nothing[[]byte](), proof); err != nil {
+			return ids.Empty, err
+		}
+		if len(proof.KeyValues) > 0 {
+			start = maybe.Some(nextKey(proof.KeyValues[len(proof.KeyValues)-1].Key))
+		}
+	}
+
+	// Each range proof only proves the key/value pairs it contains. The final
+	// root check ensures that no key/value pairs were omitted.
+	restoredRootID, err := db.GetMerkleRoot(ctx)
+	if err != nil {
+		return ids.Empty, err
+	}
+	if restoredRootID != header.rootID {
+		return ids.Empty, fmt.Errorf("%w: expected %s but got %s",
+			ErrSnapshotRootMismatch,
+			header.rootID,
+			restoredRootID,
+		)
+	}
+	return restoredRootID, nil
+}
@@ -0,0 +1,286 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package merkledb
+
+import (
+	"bytes"
+	"context"
+	"math/rand"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/maybe"
+)
+
+func TestSnapshotRoundTrip(t *testing.T) {
+	tests := []struct {
+		name         string
+		numKeys      uint
+		branchFactor BranchFactor
+	}{
+		{
+			name:         "empty",
+			numKeys:      0,
+			branchFactor: BranchFactor16,
+		},
+		{
+			name:         "single chunk",
+			numKeys:      snapshotChunkSize / 2,
+			branchFactor: BranchFactor16,
+		},
+		{
+			name:         "exactly one chunk",
+			numKeys:      snapshotChunkSize,
+			branchFactor: BranchFactor16,
+		},
+		{
+			name:         "multiple chunks",
+			numKeys:      3 * snapshotChunkSize,
+			branchFactor: BranchFactor16,
+		},
+		{
+			name:         "branch factor 2",
+			numKeys:      snapshotChunkSize + 1,
+			branchFactor: BranchFactor2,
+		},
+		{
+			name:         "branch factor 256",
+			numKeys:      snapshotChunkSize + 1,
+			branchFactor: BranchFactor256,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require := require.New(t)
+
+			now := time.Now().UnixNano()
+			t.Logf("seed: %d", now)
+			r := rand.New(rand.NewSource(now)) // #nosec G404
+
+			db, err := getBasicDBWithBranchFactor(tt.branchFactor)
+			require.NoError(err)
+
+			// Use distinct keys so that [tt.numKeys] are actually written.
+			for i := uint(0); i < tt.numKeys; i++ {
+				key := make([]byte, 1+r.Intn(32))
+				_, _ = r.Read(key)
+				key = append(key, byte(i), byte(i>>8), byte(i>>16))
+				value := make([]byte, r.Intn(64))
+				_, _ = r.Read(value)
+				require.NoError(db.Put(key, value))
+			}
+
+			rootID, err := db.GetMerkleRoot(context.Background())
+			require.NoError(err)
+
+			snapshot := &bytes.Buffer{}
+			require.NoError(db.WriteSnapshot(context.Background(), rootID, snapshot))
+
+			restoredDB, err := getBasicDBWithBranchFactor(tt.branchFactor)
+			require.NoError(err)
+
+			restoredRootID, err := restoredDB.RestoreSnapshot(context.Background(), snapshot)
+			require.NoError(err)
+			require.Equal(rootID, restoredRootID)
+
+			restoredRootID, err = restoredDB.GetMerkleRoot(context.Background())
+			require.NoError(err)
+			require.Equal(rootID, restoredRootID)
+
+			it := db.NewIterator()
+			defer it.Release()
+			for it.Next() {
+				value, err := restoredDB.Get(it.Key())
+				require.NoError(err)
+				require.Equal(it.Value(), value)
+			}
+			require.NoError(it.Error())
+		})
+	}
+}
+
+func TestSnapshotHistoricalRoot(t *testing.T) {
+	require := require.New(t)
+
+	db, err := getBasicDB()
+	require.NoError(err)
+
+	writeBasicBatch(t, db)
+	historicalRootID, err := db.GetMerkleRoot(context.Background())
+	require.NoError(err)
+
+	require.NoError(db.Put([]byte{5}, []byte{5}))
+	require.NoError(db.Delete([]byte{0}))
+
+	snapshot := &bytes.Buffer{}
+	require.NoError(db.WriteSnapshot(context.Background(), historicalRootID, snapshot))
+
+	restoredDB, err := getBasicDB()
+	require.NoError(err)
+
+	restoredRootID, err := restoredDB.RestoreSnapshot(context.Background(), snapshot)
+	require.NoError(err)
+	require.Equal(historicalRootID, restoredRootID)
+
+	value, err := restoredDB.Get([]byte{0})
+	require.NoError(err)
+	require.Equal([]byte{0}, value)
+
+	_, err = restoredDB.Get([]byte{5})
+	require.ErrorIs(err, database.ErrNotFound)
+}
+
+func TestSnapshotUnknownRoot(t *testing.T) {
+	require := require.New(t)
+
+	db, err := getBasicDB()
+	require.NoError(err)
+
+	writeBasicBatch(t, db)
+
+	err = db.WriteSnapshot(context.Background(), ids.GenerateTestID(), &bytes.Buffer{})
+	require.ErrorIs(err, ErrInsufficientHistory)
+}
+
+func TestRestoreSnapshotErrors(t *testing.T) {
+	db, err := getBasicDB()
+	require.NoError(t, err)
+	writeBasicBatch(t, db)
+
+	rootID, err := db.GetMerkleRoot(context.Background())
+	require.NoError(t, err)
+
+	validSnapshot := &bytes.Buffer{}
+	require.NoError(t, db.WriteSnapshot(context.Background(), rootID, validSnapshot))
+
+	tests := []struct {
+		name        string
+		snapshot    func() []byte
+		newDB       func() (*merkleDB, error)
+		expectedErr error
+	}{
+		{
+			name: "non-empty database",
+			snapshot: func() []byte {
+				return validSnapshot.Bytes()
+			},
+			newDB: func() (*merkleDB, error) {
+				db, err := getBasicDB()
+				if err != nil {
+					return nil, err
+				}
+				return db, db.Put([]byte{0}, []byte{0})
+			},
+			expectedErr: ErrRestoreIntoNonEmptyDB,
+		},
+		{
+			name: "branch factor mismatch",
+			snapshot: func() []byte {
+				return validSnapshot.Bytes()
+			},
+			newDB: func() (*merkleDB, error) {
+				return getBasicDBWithBranchFactor(BranchFactor4)
+			},
+			expectedErr: ErrSnapshotBranchFactorMismatch,
+		},
+		{
+			name: "invalid magic",
+			snapshot: func() []byte {
+				snapshot := bytes.Clone(validSnapshot.Bytes())
+				snapshot[0]++
+				return snapshot
+			},
+			newDB:       getBasicDB,
+			expectedErr: ErrInvalidSnapshot,
+		},
+		{
+			name: "truncated",
+			snapshot: func() []byte {
+				return validSnapshot.Bytes()[:validSnapshot.Len()-snapshotLengthSize]
+			},
+			newDB:       getBasicDB,
+			expectedErr: errSnapshotMissingTerminator,
+		},
+		{
+			name: "wrong root",
+			snapshot: func() []byte {
+				snapshot := bytes.Clone(validSnapshot.Bytes())
+				rootIDOffset := len(snapshotMagic) + 4
+				snapshot[rootIDOffset]++
+				return snapshot
+			},
+			newDB:       getBasicDB,
+			expectedErr: ErrInvalidProof,
+		},
+		{
+			name: "tampered value",
+			snapshot: func() []byte {
+				otherDB, err := getBasicDB()
+				require.NoError(t, err)
+				writeBasicBatch(t, otherDB)
+				require.NoError(t, otherDB.Put([]byte{4}, []byte{5}))
+
+				otherRootID, err := otherDB.GetMerkleRoot(context.Background())
+				require.NoError(t, err)
+
+				otherSnapshot := &bytes.Buffer{}
+				require.NoError(t, otherDB.WriteSnapshot(context.Background(), otherRootID, otherSnapshot))
+
+				// Replace the root with the root of [db].
+				snapshot := otherSnapshot.Bytes()
+				rootIDOffset := len(snapshotMagic) + 4
+				copy(snapshot[rootIDOffset:], rootID[:])
+				return snapshot
+			},
+			newDB:       getBasicDB,
+			expectedErr: ErrInvalidProof,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require := require.New(t)
+
+			restoredDB, err := tt.newDB()
+			require.NoError(err)
+
+			_, err = restoredDB.RestoreSnapshot(context.Background(), bytes.NewReader(tt.snapshot()))
+			require.ErrorIs(err, tt.expectedErr)
+		})
+	}
+}
+
+func TestRestoreSnapshotOmittedKeys(t *testing.T) {
+	require := require.New(t)
+
+	db, err := getBasicDB()
+	require.NoError(err)
+	writeBasicBatch(t, db)
+
+	rootID, err := db.GetMerkleRoot(context.Background())
+	require.NoError(err)
+
+	// Build a snapshot that claims [rootID] but only includes a valid range
+	// proof for a subset of the keys.
+	proof, err := db.GetRangeProof(context.Background(), maybe.Nothing[[]byte](), maybe.Nothing[[]byte](), 2)
+	require.NoError(err)
+
+	snapshot := &bytes.Buffer{}
+	require.NoError(writeSnapshotHeader(snapshot, snapshotHeader{
+		version:      snapshotVersion,
+		branchFactor: BranchFactor16,
+		rootID:       rootID,
+	}))
+	require.NoError(writeSnapshotRecord(snapshot, proof))
+	require.NoError(writeSnapshotRecord(snapshot, nil))
+
+	restoredDB, err := getBasicDB()
+	require.NoError(err)
+
+	_, err = restoredDB.RestoreSnapshot(context.Background(), snapshot)
+	require.ErrorIs(err, ErrSnapshotRootMismatch)
+}
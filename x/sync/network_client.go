@@ -103,6 +103,7 @@ func NewNetworkClient(
 		registerer,
 		set.Of(myNodeID),
 		minVersion,
+		nil,
 	)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create peer tracker: %w", err)
@@ -174,6 +174,74 @@ func (s *NetworkServer) HandleChangeProofRequest(
 		return nil // dropping request
 	}
 
+	proofBytes, err := getChangeProof(ctx, s.db, req)
+	if err != nil {
+		return err
+	}
+	if proofBytes == nil {
+		// [s.db] doesn't have [endRoot] in its history.
+		// We can't generate a change/range proof. Drop this request.
+		return nil
+	}
+	return s.sendAppResponse(ctx, nodeID, requestID, proofBytes)
+}
+
+// Generates a range proof and sends it to [nodeID].
+// If [errAppSendFailed] is returned, this should be considered fatal.
+func (s *NetworkServer) HandleRangeProofRequest(
+	ctx context.Context,
+	nodeID ids.NodeID,
+	requestID uint32,
+	req *pb.SyncGetRangeProofRequest,
+) error {
+	if err := validateRangeProofRequest(req); err != nil {
+		s.log.Debug(
+			"dropping invalid range proof request",
+			zap.Stringer("nodeID", nodeID),
+			zap.Uint32("requestID", requestID),
+			zap.Stringer("req", req),
+			zap.Error(err),
+		)
+		return nil // drop request
+	}
+
+	proofBytes, err := getRangeProofResponse(ctx, s.db, req)
+	if err != nil {
+		return err
+	}
+	return s.sendAppResponse(ctx, nodeID, requestID, proofBytes)
+}
+
+// If [errAppSendFailed] is returned, this should be considered fatal.
+func (s *NetworkServer) sendAppResponse(
+	ctx context.Context,
+	nodeID ids.NodeID,
+	requestID uint32,
+	response []byte,
+) error {
+	if err := s.appSender.SendAppResponse(ctx, nodeID, requestID, response); err != nil {
+		s.log.Fatal(
+			"failed to send app response",
+			zap.Stringer("nodeID", nodeID),
+			zap.Uint32("requestID", requestID),
+			zap.Int("responseLen", len(response)),
+			zap.Error(err),
+		)
+		return fmt.Errorf("%w: %w", errAppSendFailed, err)
+	}
+	return nil
+}
+
+// Returns the serialized change proof, or range proof if there isn't enough
+// history to generate a change proof, specified by [req].
+// Returns nil if [db] doesn't have the end root of [req] in its history.
+//
+// Assumes [req] is well-formed.
+func getChangeProof(
+	ctx context.Context,
+	db DB,
+	req *pb.SyncGetChangeProofRequest,
+) ([]byte, error) {
 	// override limits if they exceed caps
 	var (
 		keyLimit   = min(req.KeyLimit, maxKeyValuesLimit)
@@ -184,33 +252,31 @@ func (s *NetworkServer) HandleChangeProofRequest(
 
 	startRoot, err := ids.ToID(req.StartRootHash)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	endRoot, err := ids.ToID(req.EndRootHash)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	for keyLimit > 0 {
-		changeProof, err := s.db.GetChangeProof(ctx, startRoot, endRoot, start, end, int(keyLimit))
+		changeProof, err := db.GetChangeProof(ctx, startRoot, endRoot, start, end, int(keyLimit))
 		if err != nil {
 			if !errors.Is(err, merkledb.ErrInsufficientHistory) {
 				// We should only fail to get a change proof if we have insufficient history.
 				// Other errors are unexpected.
-				return err
+				return nil, err
 			}
 			if errors.Is(err, merkledb.ErrNoEndRoot) {
-				// [s.db] doesn't have [endRoot] in its history.
-				// We can't generate a change/range proof. Drop this request.
-				return nil
+				return nil, nil
 			}
 
-			// [s.db] doesn't have sufficient history to generate change proof.
+			// [db] doesn't have sufficient history to generate change proof.
 			// Generate a range proof for the end root ID instead.
-			proofBytes, err := getRangeProof(
+			return getRangeProof(
 				ctx,
-				s.db,
+				db,
 				&pb.SyncGetRangeProofRequest{
 					RootHash:   req.EndRootHash,
 					StartKey:   req.StartKey,
@@ -226,21 +292,6 @@ func (s *NetworkServer) HandleChangeProofRequest(
 					})
 				},
 			)
-			if err != nil {
-				return err
-			}
-
-			if err := s.appSender.SendAppResponse(ctx, nodeID, requestID, proofBytes); err != nil {
-				s.log.Fatal(
-					"failed to send app response",
-					zap.Stringer("nodeID", nodeID),
-					zap.Uint32("requestID", requestID),
-					zap.Int("responseLen", len(proofBytes)),
-					zap.Error(err),
-				)
-				return fmt.Errorf("%w: %w", errAppSendFailed, err)
-			}
-			return nil
 		}
 
 		// We generated a change proof. See if it's small enough.
@@ -250,74 +301,39 @@ func (s *NetworkServer) HandleChangeProofRequest(
 			},
 		})
 		if err != nil {
-			return err
+			return nil, err
 		}
 
 		if len(proofBytes) < bytesLimit {
-			if err := s.appSender.SendAppResponse(ctx, nodeID, requestID, proofBytes); err != nil {
-				s.log.Fatal(
-					"failed to send app response",
-					zap.Stringer("nodeID", nodeID),
-					zap.Uint32("requestID", requestID),
-					zap.Int("responseLen", len(proofBytes)),
-					zap.Error(err),
-				)
-				return fmt.Errorf("%w: %w", errAppSendFailed, err)
-			}
-			return nil
+			return proofBytes, nil
 		}
 
 		// The proof was too large. Try to shrink it.
 		keyLimit = uint32(len(changeProof.KeyChanges)) / 2
 	}
-	return ErrMinProofSizeIsTooLarge
+	return nil, ErrMinProofSizeIsTooLarge
 }
 
-// Generates a range proof and sends it to [nodeID].
-// If [errAppSendFailed] is returned, this should be considered fatal.
-func (s *NetworkServer) HandleRangeProofRequest(
+// Returns the serialized range proof specified by [req].
+//
+// Assumes [req] is well-formed.
+func getRangeProofResponse(
 	ctx context.Context,
-	nodeID ids.NodeID,
-	requestID uint32,
+	db DB,
 	req *pb.SyncGetRangeProofRequest,
-) error {
-	if err := validateRangeProofRequest(req); err != nil {
-		s.log.Debug(
-			"dropping invalid range proof request",
-			zap.Stringer("nodeID", nodeID),
-			zap.Uint32("requestID", requestID),
-			zap.Stringer("req", req),
-			zap.Error(err),
-		)
-		return nil // drop request
-	}
-
+) ([]byte, error) {
 	// override limits if they exceed caps
 	req.KeyLimit = min(req.KeyLimit, maxKeyValuesLimit)
 	req.BytesLimit = min(req.BytesLimit, maxByteSizeLimit)
 
-	proofBytes, err := getRangeProof(
+	return getRangeProof(
 		ctx,
-		s.db,
+		db,
 		req,
 		func(rangeProof *merkledb.RangeProof) ([]byte, error) {
 			return proto.Marshal(rangeProof.ToProto())
 		},
 	)
-	if err != nil {
-		return err
-	}
-	if err := s.appSender.SendAppResponse(ctx, nodeID, requestID, proofBytes); err != nil {
-		s.log.Fatal(
-			"failed to send app response",
-			zap.Stringer("nodeID", nodeID),
-			zap.Uint32("requestID", requestID),
-			zap.Int("responseLen", len(proofBytes)),
-			zap.Error(err),
-		)
-		return fmt.Errorf("%w: %w", errAppSendFailed, err)
-	}
-	return nil
 }
 
 // Get the range proof specified by [req].
@@ -0,0 +1,191 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package sync
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"golang.org/x/sync/semaphore"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/network/p2p"
+	"github.com/ava-labs/avalanchego/utils/set"
+	"github.com/ava-labs/avalanchego/version"
+
+	pb "github.com/ava-labs/avalanchego/proto/pb/sync"
+)
+
+var (
+	_ p2p.Handler   = (*Handler)(nil)
+	_ NetworkClient = (*p2pNetworkClient)(nil)
+
+	errUnknownRequestType = errors.New("unknown request type")
+	errMissingRoot        = errors.New("requested root is not in the history")
+)
+
+// Handler serves the range and change proofs of a database to peers over a
+// p2p.Network.
+type Handler struct {
+	p2p.NoOpHandler
+
+	db DB
+}
+
+func NewHandler(db DB) *Handler {
+	return &Handler{
+		db: db,
+	}
+}
+
+func (h *Handler) AppRequest(
+	ctx context.Context,
+	_ ids.NodeID,
+	deadline time.Time,
+	requestBytes []byte,
+) ([]byte, error) {
+	var req pb.Request
+	if err := proto.Unmarshal(requestBytes, &req); err != nil {
+		return nil, err
+	}
+
+	// bufferedDeadline is half the time till actual deadline so that the
+	// message has a reasonable chance of completing its processing and sending
+	// the response to the peer.
+	bufferedDeadline := time.Now().Add(time.Until(deadline) / 2)
+	ctx, cancel := context.WithDeadline(ctx, bufferedDeadline)
+	defer cancel()
+
+	var (
+		proofBytes []byte
+		err        error
+	)
+	switch req := req.GetMessage().(type) {
+	case *pb.Request_ChangeProofRequest:
+		if err := validateChangeProofRequest(req.ChangeProofRequest); err != nil {
+			return nil, err
+		}
+		proofBytes, err = getChangeProof(ctx, h.db, req.ChangeProofRequest)
+	case *pb.Request_RangeProofRequest:
+		if err := validateRangeProofRequest(req.RangeProofRequest); err != nil {
+			return nil, err
+		}
+		proofBytes, err = getRangeProofResponse(ctx, h.db, req.RangeProofRequest)
+	default:
+		return nil, fmt.Errorf("%w: %T", errUnknownRequestType, req)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if proofBytes == nil {
+		return nil, errMissingRoot
+	}
+	return proofBytes, nil
+}
+
+type p2pNetworkClient struct {
+	client *p2p.Client
+	// controls maximum number of active outbound requests
+	activeRequests *semaphore.Weighted
+}
+
+type p2pResponse struct {
+	nodeID   ids.NodeID
+	response []byte
+	err      error
+}
+
+// NewP2PNetworkClient returns a NetworkClient that sends requests with
+// [client]. Responses are routed to [client] by the p2p.Network that created
+// it, so the AppResponse, AppRequestFailed, Connected, and Disconnected methods
+// of the returned NetworkClient are no-ops.
+func NewP2PNetworkClient(client *p2p.Client, maxActiveRequests int64) NetworkClient {
+	return &p2pNetworkClient{
+		client:         client,
+		activeRequests: semaphore.NewWeighted(maxActiveRequests),
+	}
+}
+
+func (c *p2pNetworkClient) RequestAny(
+	ctx context.Context,
+	request []byte,
+) (ids.NodeID, []byte, error) {
+	if err := c.activeRequests.Acquire(ctx, 1); err != nil {
+		return ids.EmptyNodeID, nil, errAcquiringSemaphore
+	}
+	defer c.activeRequests.Release(1)
+
+	responses := make(chan p2pResponse, 1)
+	if err := c.client.AppRequestAny(ctx, request, onP2PResponse(responses)); err != nil {
+		return ids.EmptyNodeID, nil, err
+	}
+	return awaitP2PResponse(ctx, ids.EmptyNodeID, responses)
+}
+
+func (c *p2pNetworkClient) Request(
+	ctx context.Context,
+	nodeID ids.NodeID,
+	request []byte,
+) ([]byte, error) {
+	if err := c.activeRequests.Acquire(ctx, 1); err != nil {
+		return nil, errAcquiringSemaphore
+	}
+	defer c.activeRequests.Release(1)
+
+	responses := make(chan p2pResponse, 1)
+	if err := c.client.AppRequest(ctx, set.Of(nodeID), request, onP2PResponse(responses)); err != nil {
+		return nil, err
+	}
+	_, response, err := awaitP2PResponse(ctx, nodeID, responses)
+	return response, err
+}
+
+func (*p2pNetworkClient) AppResponse(context.Context, ids.NodeID, uint32, []byte) error {
+	return nil
+}
+
+func (*p2pNetworkClient) AppRequestFailed(context.Context, ids.NodeID, uint32) error {
+	return nil
+}
+
+func (*p2pNetworkClient) Connected(context.Context, ids.NodeID, *version.Application) error {
+	return nil
+}
+
+func (*p2pNetworkClient) Disconnected(context.Context, ids.NodeID) error {
+	return nil
+}
+
+func onP2PResponse(responses chan<- p2pResponse) p2p.AppResponseCallback {
+	return func(_ context.Context, nodeID ids.NodeID, response []byte, err error) {
+		// [responses] is buffered and the callback is called at most once, so
+		// this never blocks.
+		responses <- p2pResponse{
+			nodeID:   nodeID,
+			response: response,
+			err:      err,
+		}
+	}
+}
+
+// awaitP2PResponse returns the response sent to [responses]. [nodeID] is
+// returned if [ctx] is canceled before the response is received.
+func awaitP2PResponse(
+	ctx context.Context,
+	nodeID ids.NodeID,
+	responses <-chan p2pResponse,
+) (ids.NodeID, []byte, error) {
+	select {
+	case <-ctx.Done():
+		return nodeID, nil, ctx.Err()
+	case r := <-responses:
+		if r.err != nil {
+			return r.nodeID, nil, fmt.Errorf("%w: %w", errRequestFailed, r.err)
+		}
+		return r.nodeID, r.response, nil
+	}
+}
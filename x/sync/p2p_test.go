@@ -0,0 +1,116 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package sync
+
+import (
+	"context"
+	"math/rand"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/ava-labs/avalanchego/database/memdb"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/network/p2p"
+	"github.com/ava-labs/avalanchego/snow/engine/common"
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/ava-labs/avalanchego/utils/set"
+	"github.com/ava-labs/avalanchego/x/merkledb"
+
+	pb "github.com/ava-labs/avalanchego/proto/pb/sync"
+)
+
+const testHandlerID = 0
+
+func TestP2PSync(t *testing.T) {
+	require := require.New(t)
+
+	now := time.Now().UnixNano()
+	t.Logf("seed: %d", now)
+	r := rand.New(rand.NewSource(now)) // #nosec G404
+
+	serverDB, err := generateTrie(t, r, 1000)
+	require.NoError(err)
+	serverRoot, err := serverDB.GetMerkleRoot(context.Background())
+	require.NoError(err)
+
+	var (
+		ctx          = context.Background()
+		clientNodeID = ids.GenerateTestNodeID()
+		serverNodeID = ids.GenerateTestNodeID()
+
+		clientSender = &common.SenderTest{}
+		serverSender = &common.SenderTest{}
+	)
+	clientNetwork, err := p2p.NewNetwork(logging.NoLog{}, clientSender, prometheus.NewRegistry(), "")
+	require.NoError(err)
+	serverNetwork, err := p2p.NewNetwork(logging.NoLog{}, serverSender, prometheus.NewRegistry(), "")
+	require.NoError(err)
+	require.NoError(serverNetwork.AddHandler(testHandlerID, NewHandler(serverDB)))
+
+	clientSender.SendAppRequestF = func(ctx context.Context, nodeIDs set.Set[ids.NodeID], requestID uint32, request []byte) error {
+		require.Equal(set.Of(serverNodeID), nodeIDs)
+		go func() {
+			require.NoError(serverNetwork.AppRequest(ctx, clientNodeID, requestID, time.Now().Add(time.Minute), request))
+		}()
+		return nil
+	}
+	serverSender.SendAppResponseF = func(ctx context.Context, _ ids.NodeID, requestID uint32, response []byte) error {
+		return clientNetwork.AppResponse(ctx, serverNodeID, requestID, response)
+	}
+	require.NoError(clientNetwork.Connected(ctx, serverNodeID, nil))
+
+	client, err := NewClient(&ClientConfig{
+		NetworkClient: NewP2PNetworkClient(clientNetwork.NewClient(testHandlerID), 10),
+		Metrics:       &mockMetrics{},
+		Log:           logging.NoLog{},
+		BranchFactor:  merkledb.BranchFactor16,
+	})
+	require.NoError(err)
+
+	db, err := merkledb.New(ctx, memdb.New(), newDefaultDBConfig())
+	require.NoError(err)
+
+	syncer, err := NewManager(ManagerConfig{
+		DB:                    db,
+		Client:                client,
+		TargetRoot:            serverRoot,
+		SimultaneousWorkLimit: 5,
+		Log:                   logging.NoLog{},
+		BranchFactor:          merkledb.BranchFactor16,
+	})
+	require.NoError(err)
+	require.NoError(syncer.Start(ctx))
+	require.NoError(syncer.Wait(ctx))
+
+	root, err := db.GetMerkleRoot(ctx)
+	require.NoError(err)
+	require.Equal(serverRoot, root)
+}
+
+func TestHandlerMissingRoot(t *testing.T) {
+	require := require.New(t)
+
+	db, err := merkledb.New(context.Background(), memdb.New(), newDefaultDBConfig())
+	require.NoError(err)
+
+	missingRoot := ids.GenerateTestID()
+	request, err := proto.Marshal(&pb.Request{
+		Message: &pb.Request_RangeProofRequest{
+			RangeProofRequest: &pb.SyncGetRangeProofRequest{
+				RootHash:   missingRoot[:],
+				KeyLimit:   defaultRequestKeyLimit,
+				BytesLimit: defaultRequestByteSizeLimit,
+			},
+		},
+	})
+	require.NoError(err)
+
+	handler := NewHandler(db)
+	_, err = handler.AppRequest(context.Background(), ids.EmptyNodeID, time.Now().Add(time.Minute), request)
+	require.ErrorIs(err, errMissingRoot)
+}